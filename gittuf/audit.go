@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"github.com/gittuf/gittuf/internal/audit"
+)
+
+type (
+	AuditEvent     = audit.Event
+	AuditLevel     = audit.Level
+	AuditOperation = audit.Operation
+	AuditQuery     = audit.Query
+)
+
+const (
+	AuditLevelInfo    = audit.LevelInfo
+	AuditLevelNotice  = audit.LevelNotice
+	AuditLevelWarning = audit.LevelWarning
+)
+
+// EnableAuditLog records every subsequent gittuf write operation in the
+// process, such as recording RSL entries and changing the policy, in the
+// audit log at path. Any audit log enabled previously is replaced.
+func EnableAuditLog(path string) error {
+	_, err := audit.Enable(path)
+	return err
+}
+
+// DisableAuditLog stops recording gittuf write operations.
+func DisableAuditLog() {
+	audit.Disable()
+}
+
+// QueryAuditLog returns the events in the audit log at path that match the
+// query, in the order they were recorded.
+func QueryAuditLog(path string, query *AuditQuery) ([]*AuditEvent, error) {
+	return audit.NewLog(path).Query(query)
+}
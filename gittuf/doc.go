@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gittuf is the public Go API for gittuf. It allows tools to embed
+// gittuf to open or clone repositories, record entries in the reference state
+// log (RSL), manage policy, and verify refs and objects against policy, without
+// shelling out to the gittuf CLI.
+//
+// The package wraps gittuf's internal implementation, which may change between
+// releases. The exported identifiers of this package are versioned with the
+// gittuf module using semantic versioning: backwards incompatible changes to
+// them are only made in a new major version.
+package gittuf
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+
+	"github.com/gittuf/gittuf/internal/repository"
+)
+
+type (
+	DoctorFinding  = repository.DoctorFinding
+	DoctorSeverity = repository.DoctorSeverity
+)
+
+const (
+	DoctorSeverityInfo    = repository.DoctorSeverityInfo
+	DoctorSeverityWarning = repository.DoctorSeverityWarning
+	DoctorSeverityError   = repository.DoctorSeverityError
+)
+
+// Doctor inspects the repository for common problems with its gittuf metadata
+// and returns the findings along with their severities and suggested fixes.
+func (r *Repository) Doctor(ctx context.Context) ([]*DoctorFinding, error) {
+	return r.r.Doctor(ctx)
+}
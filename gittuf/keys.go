@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// Signer signs gittuf metadata. Signers for keys on disk, in a KMS service, or
+// in the ssh-agent can be loaded using LoadSigner.
+type Signer = sslibdsse.SignerVerifier
+
+// Key is a public key that can be authorized in gittuf policy.
+type Key struct {
+	key *tuf.Key
+}
+
+// ID returns the identifier of the key used in gittuf policy.
+func (k *Key) ID() string {
+	return k.key.KeyID
+}
+
+// LoadPublicKey loads a public key from a reference. The reference is either
+// the path to a key on disk, a KMS or ssh-agent key reference, a GPG
+// fingerprint prefixed with "gpg:", a Sigstore identity of the form
+// "fulcio:<identity>::<issuer>", or an X.509 certificate identity of the form
+// "x509:<email>::<path to CA bundle>".
+func LoadPublicKey(keyRef string) (*Key, error) {
+	key, err := common.LoadPublicKey(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{key: key}, nil
+}
+
+// LoadPublicKeyFromBytes loads a public key from its encoded bytes.
+func LoadPublicKeyFromBytes(keyBytes []byte) (*Key, error) {
+	key, err := tuf.LoadKeyFromBytes(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{key: key}, nil
+}
+
+// LoadSigner loads a signer for the private key at the specified path, or for
+// a key held in a KMS service or the ssh-agent.
+func LoadSigner(keyRef string) (Signer, error) {
+	keyBytes, err := common.ReadKeyBytes(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadSignerFromBytes(keyBytes)
+}
+
+// LoadSignerFromBytes loads a signer for the specified PEM encoded private key.
+func LoadSignerFromBytes(keyBytes []byte) (Signer, error) {
+	return common.LoadSigner(keyBytes)
+}
+
+func unwrapKeys(keys []*Key) []*tuf.Key {
+	tufKeys := make([]*tuf.Key, 0, len(keys))
+	for _, key := range keys {
+		tufKeys = append(tufKeys, key.key)
+	}
+
+	return tufKeys
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// TargetsRoleName is the name of the top level rule file in gittuf policy.
+const TargetsRoleName = policy.TargetsRoleName
+
+// Submodule verification modes. See SetSubmodulePolicy.
+const (
+	SubmoduleVerificationPropagation = tuf.SubmoduleVerificationPropagation
+	SubmoduleVerificationRemote      = tuf.SubmoduleVerificationRemote
+)
+
+// PolicyProposal is a proposed version of a policy file that collects
+// signatures until it's signed by a threshold of the file's trusted keys.
+type PolicyProposal = policy.Proposal
+
+// InitializeRoot creates the repository's root of trust, with the signer's
+// key as the root key. Like other policy changes, the new root of trust is
+// staged until ApplyPolicy is called.
+func (r *Repository) InitializeRoot(ctx context.Context, signer Signer, signCommit bool) error {
+	return r.r.InitializeRoot(ctx, signer, signCommit)
+}
+
+// AddTopLevelTargetsKey authorizes a key to sign the top level rule file. The
+// signer must hold a root key.
+func (r *Repository) AddTopLevelTargetsKey(ctx context.Context, signer Signer, key *Key, signCommit bool) error {
+	return r.r.AddTopLevelTargetsKey(ctx, signer, key.key, signCommit)
+}
+
+// InitializeTargets creates the specified rule file. The signer must be
+// authorized to sign the rule file.
+func (r *Repository) InitializeTargets(ctx context.Context, signer Signer, targetsRoleName string, signCommit bool) error {
+	return r.r.InitializeTargets(ctx, signer, targetsRoleName, signCommit)
+}
+
+// AddRule adds a rule to the specified rule file. The rule protects the
+// namespaces matched by rulePatterns, such as "git:refs/heads/main", and
+// requires threshold of authorizedKeys to sign changes to them.
+func (r *Repository) AddRule(ctx context.Context, signer Signer, targetsRoleName, ruleName string, authorizedKeys []*Key, rulePatterns []string, threshold int, signCommit bool) error {
+	return r.r.AddDelegation(ctx, signer, targetsRoleName, ruleName, unwrapKeys(authorizedKeys), rulePatterns, threshold, signCommit)
+}
+
+// UpdateRule replaces the authorized keys, patterns, and threshold of an
+// existing rule in the specified rule file.
+func (r *Repository) UpdateRule(ctx context.Context, signer Signer, targetsRoleName, ruleName string, authorizedKeys []*Key, rulePatterns []string, threshold int, signCommit bool) error {
+	return r.r.UpdateDelegation(ctx, signer, targetsRoleName, ruleName, unwrapKeys(authorizedKeys), rulePatterns, threshold, signCommit)
+}
+
+// RemoveRule removes a rule from the specified rule file.
+func (r *Repository) RemoveRule(ctx context.Context, signer Signer, targetsRoleName, ruleName string, signCommit bool) error {
+	return r.r.RemoveDelegation(ctx, signer, targetsRoleName, ruleName, signCommit)
+}
+
+// SetSubmodulePolicy configures how updates to the pointer of the submodule at
+// submodulePath are verified against the submodule's repository at location.
+// verification is one of SubmoduleVerificationPropagation and
+// SubmoduleVerificationRemote. If refName is set, remote checks only consider
+// RSL entries for that ref in the submodule's repository.
+func (r *Repository) SetSubmodulePolicy(ctx context.Context, signer Signer, submodulePath, location, refName, verification string, signCommit bool) error {
+	return r.r.SetSubmodulePolicy(ctx, signer, submodulePath, location, refName, verification, signCommit)
+}
+
+// RemoveSubmodulePolicy removes the policy for the submodule at submodulePath.
+func (r *Repository) RemoveSubmodulePolicy(ctx context.Context, signer Signer, submodulePath string, signCommit bool) error {
+	return r.r.RemoveSubmodulePolicy(ctx, signer, submodulePath, signCommit)
+}
+
+// ApplyPolicy verifies the staged policy changes and applies them to the
+// repository's policy, recording the change in the RSL.
+func (r *Repository) ApplyPolicy(ctx context.Context, signRSLEntry bool) error {
+	return r.r.ApplyPolicy(ctx, signRSLEntry)
+}
+
+// CreatePolicyProposal proposes the staged version of the specified policy
+// file, so that signatures can be collected for it over time. The proposal's
+// ID is returned.
+func (r *Repository) CreatePolicyProposal(ctx context.Context, roleName string, signCommit bool) (string, error) {
+	return r.r.CreatePolicyProposal(ctx, roleName, nil, signCommit)
+}
+
+// AddSignatureToProposal adds the signer's signature to the specified policy
+// proposal.
+func (r *Repository) AddSignatureToProposal(ctx context.Context, signer Signer, proposalID string, signCommit bool) error {
+	return r.r.AddSignatureToProposal(ctx, signer, proposalID, signCommit)
+}
+
+// FinalizePolicyProposal stages the proposed policy file once it's signed by a
+// threshold of its trusted keys. The staged policy must then be applied using
+// ApplyPolicy.
+func (r *Repository) FinalizePolicyProposal(ctx context.Context, proposalID string, signCommit bool) error {
+	return r.r.FinalizePolicyProposal(ctx, proposalID, signCommit)
+}
+
+// ListPolicyProposals returns the repository's open policy proposals.
+func (r *Repository) ListPolicyProposals() ([]*PolicyProposal, error) {
+	return r.r.ListPolicyProposals()
+}
+
+// ExportTUFRepository writes the repository's current policy to dir in the
+// layout of a TUF repository, along with a summary of the RSL.
+func (r *Repository) ExportTUFRepository(ctx context.Context, dir string) error {
+	return r.r.ExportTUFRepository(ctx, dir)
+}
+
+// PushPolicy pushes the repository's policy and RSL to the specified remote.
+func (r *Repository) PushPolicy(ctx context.Context, remoteName string) error {
+	return r.r.PushPolicy(ctx, remoteName)
+}
+
+// PullPolicy pulls the policy and RSL from the specified remote.
+func (r *Repository) PullPolicy(ctx context.Context, remoteName string) error {
+	return r.r.PullPolicy(ctx, remoteName)
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+
+	"github.com/gittuf/gittuf/internal/repository"
+)
+
+var (
+	ErrUnauthorizedKey    = repository.ErrUnauthorizedKey
+	ErrCannotReinitialize = repository.ErrCannotReinitialize
+)
+
+// Repository is a Git repository that gittuf operates on.
+type Repository struct {
+	r *repository.Repository
+}
+
+// Open loads the Git repository at the specified path. The path may be within
+// the repository's worktree or be its Git directory.
+func Open(path string) (*Repository, error) {
+	r, err := repository.LoadRepositoryAt(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{r: r}, nil
+}
+
+// NewInMemory returns an empty repository backed by in-memory storage. Nothing
+// is written to disk and the Git binary is not invoked when recording and
+// walking the RSL or verifying refs, making it suitable for tests and sandboxed
+// services. Operations that require the Git binary return an error.
+func NewInMemory() (*Repository, error) {
+	r, err := repository.NewInMemoryRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{r: r}, nil
+}
+
+// Clone clones the repository at remoteURL into dir along with its gittuf
+// refs, and verifies the initial branch against the repository's policy before
+// populating the worktree. If initialBranch is empty, the remote's HEAD is
+// used. If verification fails, the cloned repository is removed.
+func Clone(ctx context.Context, remoteURL, dir, initialBranch string) (*Repository, error) {
+	r, err := repository.Clone(ctx, remoteURL, dir, initialBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{r: r}, nil
+}
+
+// CloneOptions configures CloneWithOptions.
+type CloneOptions struct {
+	// RootTrustAnchors contains the root public keys, obtained out-of-band,
+	// that are expected to have signed the repository's initial root of
+	// trust.
+	RootTrustAnchors []*Key
+
+	// RootTrustAnchorsThreshold is the number of RootTrustAnchors that must
+	// have signed the initial root of trust. If 0, all of them must have.
+	RootTrustAnchorsThreshold int
+
+	// RootPinFile is the path of the file used to pin the keys of the
+	// repository's initial root of trust on first use.
+	RootPinFile string
+
+	// InMemory clones the repository into in-memory storage instead of dir,
+	// which is ignored, so that it can be verified without writing to disk
+	// or invoking the Git binary.
+	InMemory bool
+}
+
+// CloneWithOptions clones the repository like Clone, authenticating the
+// repository's initial root of trust using the specified options. The options
+// are retained for subsequent verifications using the returned Repository.
+func CloneWithOptions(ctx context.Context, remoteURL, dir, initialBranch string, opts *CloneOptions) (*Repository, error) {
+	cloneOpts := &repository.CloneOptions{}
+	if opts != nil {
+		cloneOpts.RootTrustAnchors = unwrapKeys(opts.RootTrustAnchors)
+		cloneOpts.RootTrustAnchorsThreshold = opts.RootTrustAnchorsThreshold
+		cloneOpts.RootPinFile = opts.RootPinFile
+		cloneOpts.InMemory = opts.InMemory
+	}
+
+	r, err := repository.CloneWithOptions(ctx, remoteURL, dir, initialBranch, cloneOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{r: r}, nil
+}
+
+// SetRootTrustAnchors sets the root public keys, obtained out-of-band, that
+// are expected to have signed the repository's initial root of trust.
+// Verification fails unless the initial root of trust is signed by at least
+// threshold of these keys. If threshold is 0, all of the keys must have signed
+// it.
+func (r *Repository) SetRootTrustAnchors(rootKeys []*Key, threshold int) {
+	r.r.SetRootTrustAnchors(unwrapKeys(rootKeys), threshold)
+}
+
+// SetRootPinFile sets the path of a file used to pin the keys of the
+// repository's initial root of trust. The keys are pinned on first use, and
+// subsequent verifications fail unless the initial root of trust is signed by
+// the pinned threshold of the pinned keys.
+func (r *Repository) SetRootPinFile(path string) {
+	r.r.SetRootPinFile(path)
+}
+
+// InitializeNamespaces creates the RSL, attestations, and policy refs in the
+// repository.
+func (r *Repository) InitializeNamespaces() error {
+	return r.r.InitializeNamespaces()
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	gitRepo, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Open(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootSigner, err := LoadSignerFromBytes(artifacts.SSLibKey1Private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := LoadSignerFromBytes(artifacts.SSLibKey2Private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := LoadPublicKeyFromBytes(artifacts.SSLibKey2Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKeyID, err := targetsSigner.KeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, targetsKeyID, targetsKey.ID())
+
+	if err := repo.InitializeRoot(ctx, rootSigner, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddTopLevelTargetsKey(ctx, rootSigner, targetsKey, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.InitializeTargets(ctx, targetsSigner, TargetsRoleName, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddRule(ctx, targetsSigner, TargetsRoleName, "protect-main", []*Key{targetsKey}, []string{"git:refs/heads/main"}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.ApplyPolicy(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+
+	common.AddNTestCommitsToSpecifiedRef(t, gitRepo, "refs/heads/feature", 1, artifacts.GPGKey1Private)
+	if err := repo.RecordRSLEntryForReference("refs/heads/feature", false); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := repo.ListRSLEntries(ctx, &ListRSLEntriesOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, log.Entries, 1)
+	assert.Equal(t, RSLLogReferenceEntry, log.Entries[0].Type)
+	assert.Equal(t, "refs/heads/feature", log.Entries[0].RefName)
+	assert.NotEmpty(t, log.NextEntryID)
+
+	// The feature branch isn't protected by any rule
+	err = repo.VerifyRef(ctx, "refs/heads/feature", false)
+	assert.Nil(t, err)
+
+	// The main branch is protected but the commit isn't signed by an
+	// authorized key
+	common.AddNTestCommitsToSpecifiedRef(t, gitRepo, "refs/heads/main", 1, artifacts.GPGKey1Private)
+	if err := repo.RecordRSLEntryForReference("refs/heads/main", false); err != nil {
+		t.Fatal(err)
+	}
+	err = repo.VerifyRef(ctx, "refs/heads/main", true)
+	assert.NotNil(t, err)
+}
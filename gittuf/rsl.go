@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+
+	"github.com/gittuf/gittuf/internal/repository"
+)
+
+type (
+	ListRSLEntriesOptions = repository.ListRSLEntriesOptions
+	RSLLog                = repository.RSLLog
+	RSLLogEntry           = repository.RSLLogEntry
+	RSLLogEntryType       = repository.RSLLogEntryType
+	RSLLogAnnotation      = repository.RSLLogAnnotation
+	SkipImpact            = repository.SkipImpact
+)
+
+const (
+	RSLLogReferenceEntry   = repository.RSLLogReferenceEntry
+	RSLLogAnnotationEntry  = repository.RSLLogAnnotationEntry
+	RSLLogPropagationEntry = repository.RSLLogPropagationEntry
+)
+
+// RecordRSLEntryForReference records the current state of the specified ref
+// in the RSL. Signed entries are refused if the signing key is not trusted by
+// the rules protecting the ref.
+func (r *Repository) RecordRSLEntryForReference(refName string, signCommit bool) error {
+	return r.r.RecordRSLEntryForReference(refName, signCommit)
+}
+
+// SetAllowUnauthorizedRSLSigner configures RSL entries to be recorded with a
+// warning when the signing key is not trusted by the rules protecting the ref.
+func (r *Repository) SetAllowUnauthorizedRSLSigner(allow bool) {
+	r.r.SetAllowUnauthorizedRSLSigner(allow)
+}
+
+// RecordRSLEntryForReferenceWithActor records the current state of the
+// specified ref in the RSL along with who or what pushed it, such as a forge
+// username, and an optional URL for the push's context, such as a CI job.
+func (r *Repository) RecordRSLEntryForReferenceWithActor(refName, actor, actorURL string, signCommit bool) error {
+	return r.r.RecordRSLEntryForReferenceWithActor(refName, actor, actorURL, signCommit)
+}
+
+// RecordRSLEntryForReferenceDeletion records the deletion of the specified
+// ref in the RSL.
+func (r *Repository) RecordRSLEntryForReferenceDeletion(refName string, signCommit bool) error {
+	return r.r.RecordRSLEntryForReferenceDeletion(refName, signCommit)
+}
+
+// RecordRSLAnnotation records an annotation for the specified RSL entries. If
+// skip is true, the entries are marked as to be skipped during verification.
+func (r *Repository) RecordRSLAnnotation(rslEntryIDs []string, skip bool, message string, signCommit bool) error {
+	return r.r.RecordRSLAnnotation(rslEntryIDs, skip, message, signCommit)
+}
+
+// AnalyzeSkipImpact computes what changes if the specified RSL entries are
+// skipped, without recording an annotation.
+func (r *Repository) AnalyzeSkipImpact(rslEntryIDs []string) (*SkipImpact, error) {
+	return r.r.AnalyzeSkipImpact(rslEntryIDs)
+}
+
+// AnalyzeSkipAnnotationImpact computes what changed when the specified skip
+// annotation was recorded.
+func (r *Repository) AnalyzeSkipAnnotationImpact(annotationID string) (*SkipImpact, error) {
+	return r.r.AnalyzeSkipAnnotationImpact(annotationID)
+}
+
+// ListRSLEntries returns a page of RSL entries along with their annotations,
+// skip status, and signers.
+func (r *Repository) ListRSLEntries(ctx context.Context, opts *ListRSLEntriesOptions) (*RSLLog, error) {
+	return r.r.ListRSLEntries(ctx, opts)
+}
+
+// PushRSL pushes the RSL to the specified remote.
+func (r *Repository) PushRSL(ctx context.Context, remoteName string) error {
+	return r.r.PushRSL(ctx, remoteName)
+}
+
+// PullRSL pulls the RSL from the specified remote.
+func (r *Repository) PullRSL(ctx context.Context, remoteName string) error {
+	return r.r.PullRSL(ctx, remoteName)
+}
+
+// Sync verifies the new entries in the remote's RSL and fast-forwards the
+// local RSL and gittuf refs to match the remote.
+func (r *Repository) Sync(ctx context.Context, remoteName string) error {
+	return r.r.Sync(ctx, remoteName)
+}
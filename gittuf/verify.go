@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+)
+
+type (
+	VerifyRefOptions     = repository.VerifyRefOptions
+	VerificationProgress = policy.VerificationProgress
+	ProgressFunc         = policy.ProgressFunc
+
+	VerifyNetworkOptions      = repository.VerifyNetworkOptions
+	NetworkVerificationReport = repository.NetworkVerificationReport
+	NetworkRepositoryResult   = repository.NetworkRepositoryResult
+	NetworkRefResult          = repository.NetworkRefResult
+)
+
+// VerifyRef verifies the specified ref against the repository's policy. If
+// latestOnly is true, only the latest RSL entry for the ref is verified.
+// Otherwise, every entry for the ref is verified.
+func (r *Repository) VerifyRef(ctx context.Context, refName string, latestOnly bool) error {
+	return r.r.VerifyRef(ctx, refName, latestOnly)
+}
+
+// VerifyRefWithOptions verifies the specified ref against the repository's
+// policy using the specified options. Verification stops if ctx is canceled.
+func (r *Repository) VerifyRefWithOptions(ctx context.Context, refName string, opts *VerifyRefOptions) error {
+	return r.r.VerifyRefWithOptions(ctx, refName, opts)
+}
+
+// ClearVerificationCache discards all results in the repository's local
+// verification cache, which is used when VerifyRefOptions.UseCache is set.
+func (r *Repository) ClearVerificationCache() error {
+	return r.r.ClearVerificationCache()
+}
+
+// VerifySubmodules verifies that updates to the pointers of submodules
+// configured in the policy correspond to verified RSL entries in the
+// submodules' own repositories.
+func (r *Repository) VerifySubmodules(ctx context.Context, refName string) error {
+	return r.r.VerifySubmodules(ctx, refName)
+}
+
+// VerifyNetwork fetches each of the repositories at the specified locations
+// and verifies their refs, either using each repository's own policy or using
+// the policy of a shared repository. The results are aggregated into a single
+// report, which is also returned if verification fails for any repository.
+func VerifyNetwork(ctx context.Context, locations []string, opts *VerifyNetworkOptions) (*NetworkVerificationReport, error) {
+	return repository.VerifyNetwork(ctx, locations, opts)
+}
+
+// EnableOnDemandFetch configures the repository to fetch objects that are
+// missing locally from the specified remote when verification needs them, such
+// as in partial clones.
+func (r *Repository) EnableOnDemandFetch(ctx context.Context, remoteName string) error {
+	return r.r.EnableOnDemandFetch(ctx, remoteName)
+}
+
+// SetAutoDeepen configures verification to fetch the full history from the
+// specified remote and retry when it reaches the boundary of a shallow clone.
+func (r *Repository) SetAutoDeepen(remoteName string) {
+	r.r.SetAutoDeepen(remoteName)
+}
+
+// VerifyCommit verifies the signatures of the specified commits using the
+// keys in the repository's policy. The result for each commit is returned,
+// keyed by the commit's ID.
+func (r *Repository) VerifyCommit(ctx context.Context, ids ...string) map[string]string {
+	return r.r.VerifyCommit(ctx, ids...)
+}
+
+// VerifyTag verifies the signatures of the specified tags using the keys in
+// the repository's policy. The result for each tag is returned, keyed by the
+// tag's ID.
+func (r *Repository) VerifyTag(ctx context.Context, ids []string) map[string]string {
+	return r.r.VerifyTag(ctx, ids)
+}
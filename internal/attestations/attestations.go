@@ -4,6 +4,7 @@ package attestations
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/rsl"
@@ -17,6 +18,11 @@ const (
 	Ref                                        = "refs/gittuf/attestations"
 	referenceAuthorizationsTreeEntryName       = "reference-authorizations"
 	githubPullRequestAttestationsTreeEntryName = "github-pull-requests"
+	githubPullRequestApprovalsTreeEntryName    = "github-pull-request-approvals"
+	rslEntryCoSignaturesTreeEntryName          = "rsl-entry-cosignatures"
+	slsaProvenanceTreeEntryName                = "slsa-provenance"
+	verificationWaiversTreeEntryName           = "verification-waivers"
+	inTotoLinksTreeEntryName                   = "in-toto-links"
 	initialCommitMessage                       = "Initial commit"
 	defaultCommitMessage                       = "Update attestations"
 )
@@ -61,6 +67,39 @@ type Attestations struct {
 	// `<ref-path>/<commit-id>`, where `ref-path` is the absolute ref path, and
 	// `commit-id` is the ID of the merged commit.
 	githubPullRequestAttestations map[string]plumbing.Hash
+
+	// githubPullRequestApprovals maps each change to a ref approved on GitHub
+	// to the blob ID of the attestation recording the approvals. The key is a
+	// path of the same form as the keys of referenceAuthorizations.
+	githubPullRequestApprovals map[string]plumbing.Hash
+
+	// rslEntryCoSignatures maps each co-signed RSL reference entry to the blob
+	// ID of the attestation holding its co-signatures. The key is a path of
+	// the form `<ref-path>/<entry-id>`, where `ref-path` is the absolute ref
+	// path the entry is for and `entry-id` is the ID of the entry.
+	rslEntryCoSignatures map[string]plumbing.Hash
+
+	// slsaProvenance maps each SLSA provenance statement attached to a Git
+	// object to the blob ID of its envelope. The key is a path of the form
+	// `<object-id>/<artifact-digest>`, where `object-id` is the ID of the
+	// commit or tag the artifact was built from and `artifact-digest` is the
+	// SHA-256 digest of the artifact.
+	slsaProvenance map[string]plumbing.Hash
+
+	// verificationWaivers maps each waiver for a failed rule to the blob ID of
+	// its envelope. The key is a path of the form
+	// `<ref-path>/<entry-id>/<rule-name>`, where `ref-path` is the absolute
+	// ref path the RSL entry is for, `entry-id` is the ID of the entry, and
+	// `rule-name` is the name of the rule whose failure is accepted.
+	verificationWaivers map[string]plumbing.Hash
+
+	// inTotoLinks maps each in-toto link metadata file recorded for a change
+	// to a ref to the blob ID of the link. The key is a path of the form
+	// `<ref-path>/<from-id>-<to-id>/<link-name>`, where the first part
+	// identifies the change in the same way as the keys of
+	// referenceAuthorizations and `link-name` is the file name in-toto expects
+	// for the link.
+	inTotoLinks map[string]plumbing.Hash
 }
 
 // LoadCurrentAttestations inspects the repository's attestations namespace and
@@ -84,6 +123,9 @@ func LoadAttestationsForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (
 	if entry.RefName != Ref {
 		return nil, rsl.ErrRSLEntryDoesNotMatchRef
 	}
+	if entry.Deleted {
+		return nil, fmt.Errorf("%w: entry '%s' records the deletion of '%s'", rsl.ErrCannotDeleteGittufRef, entry.ID.String(), entry.RefName)
+	}
 
 	attestationsCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
 	if err != nil {
@@ -103,15 +145,31 @@ func LoadAttestationsForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (
 	}
 
 	var (
-		authorizationsTreeID     plumbing.Hash
-		githubPullRequestsTreeID plumbing.Hash
+		authorizationsTreeID             plumbing.Hash
+		githubPullRequestsTreeID         plumbing.Hash
+		githubPullRequestApprovalsTreeID plumbing.Hash
+		rslEntryCoSignaturesTreeID       plumbing.Hash
+		slsaProvenanceTreeID             plumbing.Hash
+		verificationWaiversTreeID        plumbing.Hash
+		inTotoLinksTreeID                plumbing.Hash
 	)
 
 	for _, e := range attestationsRootTree.Entries {
-		if e.Name == referenceAuthorizationsTreeEntryName {
+		switch e.Name {
+		case referenceAuthorizationsTreeEntryName:
 			authorizationsTreeID = e.Hash
-		} else if e.Name == githubPullRequestAttestationsTreeEntryName {
+		case githubPullRequestAttestationsTreeEntryName:
 			githubPullRequestsTreeID = e.Hash
+		case githubPullRequestApprovalsTreeEntryName:
+			githubPullRequestApprovalsTreeID = e.Hash
+		case rslEntryCoSignaturesTreeEntryName:
+			rslEntryCoSignaturesTreeID = e.Hash
+		case slsaProvenanceTreeEntryName:
+			slsaProvenanceTreeID = e.Hash
+		case verificationWaiversTreeEntryName:
+			verificationWaiversTreeID = e.Hash
+		case inTotoLinksTreeEntryName:
+			inTotoLinksTreeID = e.Hash
 		}
 	}
 
@@ -128,6 +186,11 @@ func LoadAttestationsForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (
 	attestations := &Attestations{
 		referenceAuthorizations:       map[string]plumbing.Hash{},
 		githubPullRequestAttestations: map[string]plumbing.Hash{},
+		githubPullRequestApprovals:    map[string]plumbing.Hash{},
+		rslEntryCoSignatures:          map[string]plumbing.Hash{},
+		slsaProvenance:                map[string]plumbing.Hash{},
+		verificationWaivers:           map[string]plumbing.Hash{},
+		inTotoLinks:                   map[string]plumbing.Hash{},
 	}
 
 	attestations.referenceAuthorizations, err = gitinterface.GetAllFilesInTree(authorizationsTree)
@@ -140,6 +203,76 @@ func LoadAttestationsForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (
 		return nil, err
 	}
 
+	// Attestations states recorded before co-signatures were supported do not
+	// have the tree
+	if !rslEntryCoSignaturesTreeID.IsZero() {
+		rslEntryCoSignaturesTree, err := gitinterface.GetTree(repo, rslEntryCoSignaturesTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.rslEntryCoSignatures, err = gitinterface.GetAllFilesInTree(rslEntryCoSignaturesTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Attestations states recorded before GitHub pull request approvals were
+	// supported do not have the tree
+	if !githubPullRequestApprovalsTreeID.IsZero() {
+		githubPullRequestApprovalsTree, err := gitinterface.GetTree(repo, githubPullRequestApprovalsTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.githubPullRequestApprovals, err = gitinterface.GetAllFilesInTree(githubPullRequestApprovalsTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Attestations states recorded before SLSA provenance was supported do not
+	// have the tree
+	if !slsaProvenanceTreeID.IsZero() {
+		slsaProvenanceTree, err := gitinterface.GetTree(repo, slsaProvenanceTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.slsaProvenance, err = gitinterface.GetAllFilesInTree(slsaProvenanceTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Attestations states recorded before verification waivers were supported
+	// do not have the tree
+	if !verificationWaiversTreeID.IsZero() {
+		verificationWaiversTree, err := gitinterface.GetTree(repo, verificationWaiversTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.verificationWaivers, err = gitinterface.GetAllFilesInTree(verificationWaiversTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Attestations states recorded before in-toto links were supported do not
+	// have the tree
+	if !inTotoLinksTreeID.IsZero() {
+		inTotoLinksTree, err := gitinterface.GetTree(repo, inTotoLinksTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.inTotoLinks, err = gitinterface.GetAllFilesInTree(inTotoLinksTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return attestations, nil
 }
 
@@ -176,6 +309,61 @@ func (a *Attestations) Commit(repo *git.Repository, commitMessage string, signCo
 		Hash: githubPullRequestsTreeID,
 	})
 
+	// Add GitHub pull request approvals tree
+	githubPullRequestApprovalsTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.githubPullRequestApprovals)
+	if err != nil {
+		return err
+	}
+	attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+		Name: githubPullRequestApprovalsTreeEntryName,
+		Mode: filemode.Dir,
+		Hash: githubPullRequestApprovalsTreeID,
+	})
+
+	// Add RSL entry co-signatures tree
+	rslEntryCoSignaturesTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.rslEntryCoSignatures)
+	if err != nil {
+		return err
+	}
+	attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+		Name: rslEntryCoSignaturesTreeEntryName,
+		Mode: filemode.Dir,
+		Hash: rslEntryCoSignaturesTreeID,
+	})
+
+	// Add SLSA provenance tree
+	slsaProvenanceTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.slsaProvenance)
+	if err != nil {
+		return err
+	}
+	attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+		Name: slsaProvenanceTreeEntryName,
+		Mode: filemode.Dir,
+		Hash: slsaProvenanceTreeID,
+	})
+
+	// Add verification waivers tree
+	verificationWaiversTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.verificationWaivers)
+	if err != nil {
+		return err
+	}
+	attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+		Name: verificationWaiversTreeEntryName,
+		Mode: filemode.Dir,
+		Hash: verificationWaiversTreeID,
+	})
+
+	// Add in-toto links tree
+	inTotoLinksTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.inTotoLinks)
+	if err != nil {
+		return err
+	}
+	attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+		Name: inTotoLinksTreeEntryName,
+		Mode: filemode.Dir,
+		Hash: inTotoLinksTreeID,
+	})
+
 	attestationsTreeID, err := gitinterface.WriteTree(repo, attestationsTreeEntries)
 	if err != nil {
 		return err
@@ -205,6 +205,16 @@ func TestLoadAttestationsForEntry(t *testing.T) {
 		assert.Empty(t, attestations.referenceAuthorizations)
 	})
 
+	t.Run("with deletion entry", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = LoadAttestationsForEntry(repo, rsl.NewReferenceDeletionEntry(Ref))
+		assert.ErrorIs(t, err, rsl.ErrCannotDeleteGittufRef)
+	})
+
 	t.Run("with RSL entry and with an attestation", func(t *testing.T) {
 		repo, err := git.Init(memory.NewStorage(), memfs.New())
 		if err != nil {
@@ -312,9 +322,14 @@ func TestAttestationsCommit(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Equal(t, 2, len(rootTree.Entries))
-	assert.Equal(t, githubPullRequestAttestationsTreeEntryName, rootTree.Entries[0].Name)
-	assert.Equal(t, referenceAuthorizationsTreeEntryName, rootTree.Entries[1].Name)
+	assert.Equal(t, 7, len(rootTree.Entries))
+	assert.Equal(t, githubPullRequestApprovalsTreeEntryName, rootTree.Entries[0].Name)
+	assert.Equal(t, githubPullRequestAttestationsTreeEntryName, rootTree.Entries[1].Name)
+	assert.Equal(t, inTotoLinksTreeEntryName, rootTree.Entries[2].Name)
+	assert.Equal(t, referenceAuthorizationsTreeEntryName, rootTree.Entries[3].Name)
+	assert.Equal(t, rslEntryCoSignaturesTreeEntryName, rootTree.Entries[4].Name)
+	assert.Equal(t, slsaProvenanceTreeEntryName, rootTree.Entries[5].Name)
+	assert.Equal(t, verificationWaiversTreeEntryName, rootTree.Entries[6].Name)
 
 	// We don't need to check every level of the tree because we do it in the
 	// tree builder API
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"sort"
 
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/go-git/go-git/v5"
@@ -140,6 +141,68 @@ func (a *Attestations) GetReferenceAuthorizationFor(repo *git.Repository, refNam
 	return env, nil
 }
 
+// GetReferenceAuthorizationsForRef returns all the reference authorization
+// attestations (with their signatures) recorded for the specified ref. The
+// authorizations are sorted by their path in the attestations namespace.
+func (a *Attestations) GetReferenceAuthorizationsForRef(repo *git.Repository, refName string) ([]*sslibdsse.Envelope, error) {
+	authPaths := []string{}
+	for authPath := range a.referenceAuthorizations {
+		if path.Dir(authPath) == refName {
+			authPaths = append(authPaths, authPath)
+		}
+	}
+	sort.Strings(authPaths)
+
+	envelopes := []*sslibdsse.Envelope{}
+	for _, authPath := range authPaths {
+		envBytes, err := gitinterface.ReadBlob(repo, a.referenceAuthorizations[authPath])
+		if err != nil {
+			return nil, err
+		}
+
+		env := &sslibdsse.Envelope{}
+		if err := json.Unmarshal(envBytes, env); err != nil {
+			return nil, err
+		}
+
+		authorization, err := ParseReferenceAuthorization(env)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validateReferenceAuthorization(env, refName, authorization.FromRevisionID, authorization.TargetTreeID); err != nil {
+			return nil, err
+		}
+
+		envelopes = append(envelopes, env)
+	}
+
+	return envelopes, nil
+}
+
+// ParseReferenceAuthorization returns the details of the change authorized by
+// the reference authorization attestation in the envelope.
+func ParseReferenceAuthorization(env *sslibdsse.Envelope) (*ReferenceAuthorization, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &struct {
+		PredicateType string                  `json:"predicate_type"`
+		Predicate     *ReferenceAuthorization `json:"predicate"`
+	}{}
+	if err := json.Unmarshal(payload, statement); err != nil {
+		return nil, err
+	}
+
+	if statement.PredicateType != ReferenceAuthorizationPredicateType || statement.Predicate == nil {
+		return nil, ErrInvalidAuthorization
+	}
+
+	return statement.Predicate, nil
+}
+
 // ReferenceAuthorizationPath constructs the expected path on-disk for the
 // reference authorization attestation.
 func ReferenceAuthorizationPath(refName, fromID, toID string) string {
@@ -138,6 +138,69 @@ func TestGetReferenceAuthorizationFor(t *testing.T) {
 	assert.Equal(t, featureZeroZero, featureAuth)
 }
 
+func TestGetReferenceAuthorizationsForRef(t *testing.T) {
+	testRef := "refs/heads/main"
+	testAnotherRef := "refs/heads/feature"
+	testID := plumbing.ZeroHash.String()
+	testTreeID := "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+	mainZeroZero := createReferenceAuthorizationAttestationEnvelopes(t, testRef, testID, testID)
+	mainZeroTree := createReferenceAuthorizationAttestationEnvelopes(t, testRef, testID, testTreeID)
+	featureZeroZero := createReferenceAuthorizationAttestationEnvelopes(t, testAnotherRef, testID, testID)
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	envs, err := attestations.GetReferenceAuthorizationsForRef(repo, testRef)
+	assert.Nil(t, err)
+	assert.Empty(t, envs)
+
+	if err := attestations.SetReferenceAuthorization(repo, mainZeroTree, testRef, testID, testTreeID); err != nil {
+		t.Fatal(err)
+	}
+	if err := attestations.SetReferenceAuthorization(repo, mainZeroZero, testRef, testID, testID); err != nil {
+		t.Fatal(err)
+	}
+	if err := attestations.SetReferenceAuthorization(repo, featureZeroZero, testAnotherRef, testID, testID); err != nil {
+		t.Fatal(err)
+	}
+
+	envs, err = attestations.GetReferenceAuthorizationsForRef(repo, testRef)
+	assert.Nil(t, err)
+	assert.Equal(t, []*sslibdsse.Envelope{mainZeroZero, mainZeroTree}, envs)
+
+	envs, err = attestations.GetReferenceAuthorizationsForRef(repo, testAnotherRef)
+	assert.Nil(t, err)
+	assert.Equal(t, []*sslibdsse.Envelope{featureZeroZero}, envs)
+}
+
+func TestParseReferenceAuthorization(t *testing.T) {
+	testRef := "refs/heads/main"
+	testID := plumbing.ZeroHash.String()
+	testTreeID := "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+	env := createReferenceAuthorizationAttestationEnvelopes(t, testRef, testID, testTreeID)
+
+	authorization, err := ParseReferenceAuthorization(env)
+	assert.Nil(t, err)
+	assert.Equal(t, &ReferenceAuthorization{TargetRef: testRef, FromRevisionID: testID, TargetTreeID: testTreeID}, authorization)
+
+	coSignature, err := NewRSLEntryCoSignature(testRef, testID, testID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err = dsse.CreateEnvelope(coSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ParseReferenceAuthorization(env)
+	assert.ErrorIs(t, err, ErrInvalidAuthorization)
+}
+
 func TestValidateReferenceAuthorization(t *testing.T) {
 	testRef := "refs/heads/main"
 	testAnotherRef := "refs/heads/feature"
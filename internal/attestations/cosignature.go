@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	RSLEntryCoSignaturePredicateType = "https://gittuf.dev/rsl-entry-cosignature/v0.1"
+	refNameKey                       = "refName"
+	entryIDKey                       = "entryID"
+	targetIDKey                      = "targetID"
+)
+
+var (
+	ErrInvalidCoSignature  = errors.New("RSL entry co-signature attestation does not match expected details")
+	ErrCoSignatureNotFound = errors.New("requested RSL entry co-signature not found")
+)
+
+// RSLEntryCoSignature is a lightweight record of an RSL reference entry that
+// is co-signed by one or more keys in addition to the entry's own signature.
+// It is meant to be used as a "predicate" in an in-toto attestation, with the
+// co-signatures embedded in the DSSE envelope for the attestation.
+type RSLEntryCoSignature struct {
+	RefName  string `json:"refName"`
+	EntryID  string `json:"entryID"`
+	TargetID string `json:"targetID"`
+}
+
+// NewRSLEntryCoSignature creates a new co-signature attestation for the RSL
+// entry with the specified ID, which records refName pointing to targetID. The
+// co-signature is embedded in an in-toto "statement" and returned with the
+// appropriate "predicate type" set.
+func NewRSLEntryCoSignature(refName, entryID, targetID string) (*ita.Statement, error) {
+	predicate := &RSLEntryCoSignature{
+		RefName:  refName,
+		EntryID:  entryID,
+		TargetID: targetID,
+	}
+
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateInterface := &map[string]any{}
+	if err := json.Unmarshal(predicateBytes, predicateInterface); err != nil {
+		return nil, err
+	}
+
+	predicateStruct, err := structpb.NewStruct(*predicateInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Digest: map[string]string{digestGitCommitKey: entryID},
+			},
+		},
+		PredicateType: RSLEntryCoSignaturePredicateType,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// SetRSLEntryCoSignature writes the co-signature attestation to the object
+// store and tracks it in the current attestations state.
+func (a *Attestations) SetRSLEntryCoSignature(repo *git.Repository, env *sslibdsse.Envelope, refName, entryID, targetID string) error {
+	if err := validateRSLEntryCoSignature(env, refName, entryID, targetID); err != nil {
+		return err
+	}
+
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, envBytes)
+	if err != nil {
+		return err
+	}
+
+	if a.rslEntryCoSignatures == nil {
+		a.rslEntryCoSignatures = map[string]plumbing.Hash{}
+	}
+
+	a.rslEntryCoSignatures[RSLEntryCoSignaturePath(refName, entryID)] = blobID
+	return nil
+}
+
+// GetRSLEntryCoSignatureFor returns the co-signature attestation (with its
+// signatures) for the specified RSL entry.
+func (a *Attestations) GetRSLEntryCoSignatureFor(repo *git.Repository, refName, entryID, targetID string) (*sslibdsse.Envelope, error) {
+	blobID, has := a.rslEntryCoSignatures[RSLEntryCoSignaturePath(refName, entryID)]
+	if !has {
+		return nil, ErrCoSignatureNotFound
+	}
+
+	envBytes, err := gitinterface.ReadBlob(repo, blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envBytes, env); err != nil {
+		return nil, err
+	}
+
+	if err := validateRSLEntryCoSignature(env, refName, entryID, targetID); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// RSLEntryCoSignaturePath constructs the expected path on-disk for the RSL
+// entry co-signature attestation.
+func RSLEntryCoSignaturePath(refName, entryID string) string {
+	return path.Join(refName, entryID)
+}
+
+func validateRSLEntryCoSignature(env *sslibdsse.Envelope, refName, entryID, targetID string) error {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return err
+	}
+
+	attestation := &ita.Statement{}
+	if err := json.Unmarshal(payload, attestation); err != nil {
+		return err
+	}
+
+	if attestation.PredicateType != RSLEntryCoSignaturePredicateType {
+		return ErrInvalidCoSignature
+	}
+
+	if len(attestation.Subject) == 0 || attestation.Subject[0].Digest[digestGitCommitKey] != entryID {
+		return ErrInvalidCoSignature
+	}
+
+	predicate := attestation.Predicate.AsMap()
+
+	if predicate[refNameKey] != refName {
+		return ErrInvalidCoSignature
+	}
+
+	if predicate[entryIDKey] != entryID {
+		return ErrInvalidCoSignature
+	}
+
+	if predicate[targetIDKey] != targetID {
+		return ErrInvalidCoSignature
+	}
+
+	return nil
+}
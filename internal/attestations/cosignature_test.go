@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	ita "github.com/in-toto/attestation/go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRSLEntryCoSignature(t *testing.T) {
+	testRef := "refs/heads/main"
+	testEntryID := "1111111111111111111111111111111111111111"
+	testTargetID := "2222222222222222222222222222222222222222"
+
+	coSignature, err := NewRSLEntryCoSignature(testRef, testEntryID, testTargetID)
+	assert.Nil(t, err)
+
+	assert.Equal(t, ita.StatementTypeUri, coSignature.Type)
+
+	assert.Equal(t, 1, len(coSignature.Subject))
+	assert.Equal(t, testEntryID, coSignature.Subject[0].Digest[digestGitCommitKey])
+
+	assert.Equal(t, RSLEntryCoSignaturePredicateType, coSignature.PredicateType)
+
+	predicate := coSignature.Predicate.AsMap()
+	assert.Equal(t, testRef, predicate[refNameKey])
+	assert.Equal(t, testEntryID, predicate[entryIDKey])
+	assert.Equal(t, testTargetID, predicate[targetIDKey])
+}
+
+func TestSetAndGetRSLEntryCoSignature(t *testing.T) {
+	testRef := "refs/heads/main"
+	testEntryID := "1111111111111111111111111111111111111111"
+	testTargetID := "2222222222222222222222222222222222222222"
+
+	coSignature, err := NewRSLEntryCoSignature(testRef, testEntryID, testTargetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := dsse.CreateEnvelope(coSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	_, err = attestations.GetRSLEntryCoSignatureFor(repo, testRef, testEntryID, testTargetID)
+	assert.ErrorIs(t, err, ErrCoSignatureNotFound)
+
+	// The envelope must match the entry it's set for
+	err = attestations.SetRSLEntryCoSignature(repo, env, testRef, testEntryID, testEntryID)
+	assert.ErrorIs(t, err, ErrInvalidCoSignature)
+
+	err = attestations.SetRSLEntryCoSignature(repo, env, testRef, testEntryID, testTargetID)
+	assert.Nil(t, err)
+	assert.Contains(t, attestations.rslEntryCoSignatures, RSLEntryCoSignaturePath(testRef, testEntryID))
+
+	if err := attestations.Commit(repo, "Test commit", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedEnv, err := attestations.GetRSLEntryCoSignatureFor(repo, testRef, testEntryID, testTargetID)
+	assert.Nil(t, err)
+	assert.Equal(t, env, storedEnv)
+
+	_, err = attestations.GetRSLEntryCoSignatureFor(repo, testRef, testEntryID, testEntryID)
+	assert.ErrorIs(t, err, ErrInvalidCoSignature)
+}
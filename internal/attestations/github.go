@@ -4,6 +4,7 @@ package attestations
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path"
 
@@ -17,10 +18,29 @@ import (
 )
 
 const (
-	GitHubPullRequestPredicateType = "https://gittuf.dev/github-pull-request/v0.1"
-	digestGitCommitKey             = "gitCommit"
+	GitHubPullRequestPredicateType         = "https://gittuf.dev/github-pull-request/v0.1"
+	GitHubPullRequestApprovalPredicateType = "https://gittuf.dev/github-pull-request-approval/v0.1"
+	digestGitCommitKey                     = "gitCommit"
 )
 
+var (
+	ErrInvalidGitHubPullRequestApproval  = errors.New("GitHub pull request approval attestation does not match expected details")
+	ErrGitHubPullRequestApprovalNotFound = errors.New("requested GitHub pull request approval not found")
+)
+
+// GitHubPullRequestApproval records the approvals of a GitHub pull request
+// merged into a Git reference. Like ReferenceAuthorization, it identifies the
+// change using the revision the ref moved from and the tree the ref moved to.
+// Approvers lists the IDs of the keys of the reviewers who approved the pull
+// request. It is meant to be used as a "predicate" in an in-toto attestation
+// signed by a GitHub app trusted in the root of trust.
+type GitHubPullRequestApproval struct {
+	TargetRef      string   `json:"targetRef"`
+	FromRevisionID string   `json:"fromRevisionID"`
+	TargetTreeID   string   `json:"targetTreeID"`
+	Approvers      []string `json:"approvers"`
+}
+
 func NewGitHubPullRequestAttestation(owner, repository string, pullRequestNumber int, commitID string, pullRequest *github.PullRequest) (*ita.Statement, error) {
 	pullRequestBytes, err := json.Marshal(pullRequest)
 	if err != nil {
@@ -69,8 +89,134 @@ func (a *Attestations) SetGitHubPullRequestAuthorization(repo *git.Repository, e
 	return nil
 }
 
+// NewGitHubPullRequestApprovalAttestation creates a new GitHub pull request
+// approval attestation for the provided information. The approval is embedded
+// in an in-toto "statement" and returned with the appropriate "predicate type"
+// set.
+func NewGitHubPullRequestApprovalAttestation(targetRef, fromRevisionID, targetTreeID string, approvers []string) (*ita.Statement, error) {
+	predicate := &GitHubPullRequestApproval{
+		TargetRef:      targetRef,
+		FromRevisionID: fromRevisionID,
+		TargetTreeID:   targetTreeID,
+		Approvers:      approvers,
+	}
+
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateInterface := map[string]any{}
+	if err := json.Unmarshal(predicateBytes, &predicateInterface); err != nil {
+		return nil, err
+	}
+
+	predicateStruct, err := structpb.NewStruct(predicateInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Digest: map[string]string{digestGitTreeKey: targetTreeID},
+			},
+		},
+		PredicateType: GitHubPullRequestApprovalPredicateType,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// SetGitHubPullRequestApprovalAttestation writes the new GitHub pull request
+// approval attestation to the object store and tracks it in the current
+// attestations state. An existing approval attestation for the same change is
+// replaced.
+func (a *Attestations) SetGitHubPullRequestApprovalAttestation(repo *git.Repository, env *sslibdsse.Envelope, refName, fromRevisionID, targetTreeID string) error {
+	if _, err := parseGitHubPullRequestApproval(env, refName, fromRevisionID, targetTreeID); err != nil {
+		return err
+	}
+
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, envBytes)
+	if err != nil {
+		return err
+	}
+
+	if a.githubPullRequestApprovals == nil {
+		a.githubPullRequestApprovals = map[string]plumbing.Hash{}
+	}
+
+	a.githubPullRequestApprovals[ReferenceAuthorizationPath(refName, fromRevisionID, targetTreeID)] = blobID
+	return nil
+}
+
+// GetGitHubPullRequestApprovalAttestationFor returns the requested GitHub pull
+// request approval attestation (with its signatures) along with the approval
+// recorded in it.
+func (a *Attestations) GetGitHubPullRequestApprovalAttestationFor(repo *git.Repository, refName, fromRevisionID, targetTreeID string) (*sslibdsse.Envelope, *GitHubPullRequestApproval, error) {
+	blobID, has := a.githubPullRequestApprovals[ReferenceAuthorizationPath(refName, fromRevisionID, targetTreeID)]
+	if !has {
+		return nil, nil, ErrGitHubPullRequestApprovalNotFound
+	}
+
+	envBytes, err := gitinterface.ReadBlob(repo, blobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envBytes, env); err != nil {
+		return nil, nil, err
+	}
+
+	approval, err := parseGitHubPullRequestApproval(env, refName, fromRevisionID, targetTreeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return env, approval, nil
+}
+
 // GitHubPullRequestAttestationPath constructs the expected path on-disk for the
 // GitHub pull request attestation.
 func GitHubPullRequestAttestationPath(refName, commitID string) string {
 	return path.Join(refName, commitID)
 }
+
+func parseGitHubPullRequestApproval(env *sslibdsse.Envelope, targetRef, fromRevisionID, targetTreeID string) (*GitHubPullRequestApproval, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &struct {
+		Subject []struct {
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+		PredicateType string                     `json:"predicate_type"`
+		Predicate     *GitHubPullRequestApproval `json:"predicate"`
+	}{}
+	if err := json.Unmarshal(payload, statement); err != nil {
+		return nil, err
+	}
+
+	if statement.PredicateType != GitHubPullRequestApprovalPredicateType || statement.Predicate == nil || len(statement.Subject) == 0 {
+		return nil, ErrInvalidGitHubPullRequestApproval
+	}
+
+	if statement.Subject[0].Digest[digestGitTreeKey] != targetTreeID {
+		return nil, ErrInvalidGitHubPullRequestApproval
+	}
+
+	approval := statement.Predicate
+	if approval.TargetRef != targetRef || approval.FromRevisionID != fromRevisionID || approval.TargetTreeID != targetTreeID {
+		return nil, ErrInvalidGitHubPullRequestApproval
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	ita "github.com/in-toto/attestation/go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGitHubPullRequestApprovalAttestation(t *testing.T) {
+	testRef := "refs/heads/main"
+	testFromID := "1111111111111111111111111111111111111111"
+	testTreeID := "2222222222222222222222222222222222222222"
+	testApprovers := []string{"key-1", "key-2"}
+
+	approval, err := NewGitHubPullRequestApprovalAttestation(testRef, testFromID, testTreeID, testApprovers)
+	assert.Nil(t, err)
+
+	assert.Equal(t, ita.StatementTypeUri, approval.Type)
+
+	assert.Equal(t, 1, len(approval.Subject))
+	assert.Equal(t, testTreeID, approval.Subject[0].Digest[digestGitTreeKey])
+
+	assert.Equal(t, GitHubPullRequestApprovalPredicateType, approval.PredicateType)
+
+	predicate := approval.Predicate.AsMap()
+	assert.Equal(t, testRef, predicate[targetRefKey])
+	assert.Equal(t, testFromID, predicate[fromRevisionIDKey])
+	assert.Equal(t, testTreeID, predicate[targetTreeIDKey])
+	assert.Equal(t, []any{"key-1", "key-2"}, predicate["approvers"])
+}
+
+func TestSetAndGetGitHubPullRequestApprovalAttestation(t *testing.T) {
+	testRef := "refs/heads/main"
+	testFromID := "1111111111111111111111111111111111111111"
+	testTreeID := "2222222222222222222222222222222222222222"
+	testApprovers := []string{"key-1"}
+
+	approval, err := NewGitHubPullRequestApprovalAttestation(testRef, testFromID, testTreeID, testApprovers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := dsse.CreateEnvelope(approval)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	_, _, err = attestations.GetGitHubPullRequestApprovalAttestationFor(repo, testRef, testFromID, testTreeID)
+	assert.ErrorIs(t, err, ErrGitHubPullRequestApprovalNotFound)
+
+	// The envelope must match the change it's set for
+	err = attestations.SetGitHubPullRequestApprovalAttestation(repo, env, testRef, testTreeID, testTreeID)
+	assert.ErrorIs(t, err, ErrInvalidGitHubPullRequestApproval)
+
+	err = attestations.SetGitHubPullRequestApprovalAttestation(repo, env, testRef, testFromID, testTreeID)
+	assert.Nil(t, err)
+	assert.Contains(t, attestations.githubPullRequestApprovals, ReferenceAuthorizationPath(testRef, testFromID, testTreeID))
+
+	if err := attestations.Commit(repo, "Test commit", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedEnv, storedApproval, err := attestations.GetGitHubPullRequestApprovalAttestationFor(repo, testRef, testFromID, testTreeID)
+	assert.Nil(t, err)
+	assert.Equal(t, env, storedEnv)
+	assert.Equal(t, testApprovers, storedApproval.Approvers)
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+const (
+	inTotoLinkType            = "link"
+	inTotoLinkFileNameFormat  = "%s.%s.link"
+	inTotoShortKeyIDLength    = 8
+	inTotoEnvelopePayloadType = "application/vnd.in-toto+json"
+)
+
+var ErrInvalidInTotoLink = errors.New("in-toto link metadata does not match expected details")
+
+// SetInTotoLink writes the in-toto link metadata to the object store and
+// tracks it in the current attestations state as evidence for the change to
+// refName from fromRevisionID to a commit with targetTreeID. The link may be
+// signed using in-toto's metablock format or a DSSE envelope; its signatures
+// are not verified here. The link is recorded using the file name in-toto
+// expects, derived from the name of the step and its signer's key ID, which
+// is returned.
+func (a *Attestations) SetInTotoLink(repo *git.Repository, link []byte, refName, fromRevisionID, targetTreeID string) (string, error) {
+	linkName, err := getInTotoLinkFileName(link)
+	if err != nil {
+		return "", err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, link)
+	if err != nil {
+		return "", err
+	}
+
+	if a.inTotoLinks == nil {
+		a.inTotoLinks = map[string]plumbing.Hash{}
+	}
+
+	a.inTotoLinks[InTotoLinkPath(refName, fromRevisionID, targetTreeID, linkName)] = blobID
+	return linkName, nil
+}
+
+// GetInTotoLinksFor returns the in-toto link metadata recorded for the change
+// to refName from fromRevisionID to a commit with targetTreeID, keyed by the
+// file name of each link.
+func (a *Attestations) GetInTotoLinksFor(repo *git.Repository, refName, fromRevisionID, targetTreeID string) (map[string][]byte, error) {
+	changePath := ReferenceAuthorizationPath(refName, fromRevisionID, targetTreeID)
+
+	links := map[string][]byte{}
+	for linkPath, blobID := range a.inTotoLinks {
+		if path.Dir(linkPath) != changePath {
+			continue
+		}
+
+		link, err := gitinterface.ReadBlob(repo, blobID)
+		if err != nil {
+			return nil, err
+		}
+
+		links[path.Base(linkPath)] = link
+	}
+
+	return links, nil
+}
+
+// InTotoLinkPath constructs the expected path on-disk for the in-toto link
+// metadata.
+func InTotoLinkPath(refName, fromID, toID, linkName string) string {
+	return path.Join(ReferenceAuthorizationPath(refName, fromID, toID), linkName)
+}
+
+// getInTotoLinkFileName returns the name of the file in-toto expects the link
+// metadata to be stored in, of the form `<step-name>.<short-key-id>.link`,
+// using the first signature on the link.
+func getInTotoLinkFileName(link []byte) (string, error) {
+	metadata := &struct {
+		Signed      json.RawMessage `json:"signed"`
+		PayloadType string          `json:"payloadType"`
+		Payload     string          `json:"payload"`
+		Signatures  []struct {
+			KeyID string `json:"keyid"`
+		} `json:"signatures"`
+	}{}
+	if err := json.Unmarshal(link, metadata); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidInTotoLink, err)
+	}
+
+	signed := []byte(metadata.Signed)
+	if metadata.PayloadType != "" {
+		if metadata.PayloadType != inTotoEnvelopePayloadType {
+			return "", ErrInvalidInTotoLink
+		}
+
+		payload, err := base64.StdEncoding.DecodeString(metadata.Payload)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidInTotoLink, err)
+		}
+		signed = payload
+	}
+
+	linkMetadata := &struct {
+		Type string `json:"_type"`
+		Name string `json:"name"`
+	}{}
+	if err := json.Unmarshal(signed, linkMetadata); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidInTotoLink, err)
+	}
+
+	if linkMetadata.Type != inTotoLinkType || linkMetadata.Name == "" || strings.ContainsAny(linkMetadata.Name, "/*?[\\") {
+		return "", ErrInvalidInTotoLink
+	}
+
+	if len(metadata.Signatures) == 0 || len(metadata.Signatures[0].KeyID) < inTotoShortKeyIDLength {
+		return "", ErrInvalidInTotoLink
+	}
+	shortKeyID := metadata.Signatures[0].KeyID[:inTotoShortKeyIDLength]
+	if strings.ContainsAny(shortKeyID, "/*?[\\") {
+		return "", ErrInvalidInTotoLink
+	}
+
+	return fmt.Sprintf(inTotoLinkFileNameFormat, linkMetadata.Name, shortKeyID), nil
+}
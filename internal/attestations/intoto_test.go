@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAndGetInTotoLinks(t *testing.T) {
+	testRef := "refs/heads/main"
+	testFromID := "1111111111111111111111111111111111111111"
+	testToID := "2222222222222222222222222222222222222222"
+	testKeyID := "3333333333333333333333333333333333333333333333333333333333333333"
+
+	link := []byte(`{"signed":{"_type":"link","name":"build","materials":{},"products":{},"byproducts":{},"command":[],"environment":{}},"signatures":[{"keyid":"` + testKeyID + `","sig":"00"}]}`)
+	envelopeLink := []byte(`{"payloadType":"application/vnd.in-toto+json","payload":"` + base64.StdEncoding.EncodeToString([]byte(`{"_type":"link","name":"test"}`)) + `","signatures":[{"keyid":"` + testKeyID + `","sig":"00"}]}`)
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	links, err := attestations.GetInTotoLinksFor(repo, testRef, testFromID, testToID)
+	assert.Nil(t, err)
+	assert.Empty(t, links)
+
+	_, err = attestations.SetInTotoLink(repo, []byte(`{"signed":{"_type":"layout"},"signatures":[]}`), testRef, testFromID, testToID)
+	assert.ErrorIs(t, err, ErrInvalidInTotoLink)
+
+	linkName, err := attestations.SetInTotoLink(repo, link, testRef, testFromID, testToID)
+	assert.Nil(t, err)
+	assert.Equal(t, "build.33333333.link", linkName)
+
+	linkName, err = attestations.SetInTotoLink(repo, envelopeLink, testRef, testFromID, testToID)
+	assert.Nil(t, err)
+	assert.Equal(t, "test.33333333.link", linkName)
+
+	if err := attestations.Commit(repo, "Test commit", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	links, err = attestations.GetInTotoLinksFor(repo, testRef, testFromID, testToID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"build.33333333.link": link, "test.33333333.link": envelopeLink}, links)
+
+	links, err = attestations.GetInTotoLinksFor(repo, testRef, testToID, testFromID)
+	assert.Nil(t, err)
+	assert.Empty(t, links)
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const (
+	SLSAProvenanceV02PredicateType = "https://slsa.dev/provenance/v0.2"
+	SLSAProvenanceV1PredicateType  = "https://slsa.dev/provenance/v1"
+	digestSHA256Key                = "sha256"
+)
+
+var (
+	ErrInvalidSLSAProvenance  = errors.New("SLSA provenance attestation does not match expected details")
+	ErrSLSAProvenanceNotFound = errors.New("requested SLSA provenance not found")
+)
+
+// SetSLSAProvenance writes the SLSA provenance envelope to the object store and
+// tracks it in the current attestations state as the provenance of the
+// artifact with the specified SHA-256 digest, built from the Git object with
+// the specified ID. The envelope is expected to be signed by the build system
+// already; its signatures are not verified here.
+func (a *Attestations) SetSLSAProvenance(repo *git.Repository, env *sslibdsse.Envelope, gitObjectID, artifactDigest string) error {
+	if err := validateSLSAProvenance(env, artifactDigest); err != nil {
+		return err
+	}
+
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, envBytes)
+	if err != nil {
+		return err
+	}
+
+	if a.slsaProvenance == nil {
+		a.slsaProvenance = map[string]plumbing.Hash{}
+	}
+
+	a.slsaProvenance[SLSAProvenancePath(gitObjectID, artifactDigest)] = blobID
+	return nil
+}
+
+// GetSLSAProvenanceFor returns the SLSA provenance envelope recorded for the
+// artifact with the specified digest built from the Git object.
+func (a *Attestations) GetSLSAProvenanceFor(repo *git.Repository, gitObjectID, artifactDigest string) (*sslibdsse.Envelope, error) {
+	blobID, has := a.slsaProvenance[SLSAProvenancePath(gitObjectID, artifactDigest)]
+	if !has {
+		return nil, ErrSLSAProvenanceNotFound
+	}
+
+	return loadSLSAProvenance(repo, blobID, artifactDigest)
+}
+
+// GetSLSAProvenanceForObject returns all the SLSA provenance envelopes
+// recorded for artifacts built from the Git object, keyed by the digest of
+// each artifact.
+func (a *Attestations) GetSLSAProvenanceForObject(repo *git.Repository, gitObjectID string) (map[string]*sslibdsse.Envelope, error) {
+	provenancePaths := []string{}
+	for provenancePath := range a.slsaProvenance {
+		if path.Dir(provenancePath) == gitObjectID {
+			provenancePaths = append(provenancePaths, provenancePath)
+		}
+	}
+	sort.Strings(provenancePaths)
+
+	envelopes := map[string]*sslibdsse.Envelope{}
+	for _, provenancePath := range provenancePaths {
+		artifactDigest := path.Base(provenancePath)
+
+		env, err := loadSLSAProvenance(repo, a.slsaProvenance[provenancePath], artifactDigest)
+		if err != nil {
+			return nil, err
+		}
+
+		envelopes[artifactDigest] = env
+	}
+
+	return envelopes, nil
+}
+
+// SLSAProvenancePath constructs the expected path on-disk for the SLSA
+// provenance attestation.
+func SLSAProvenancePath(gitObjectID, artifactDigest string) string {
+	return path.Join(gitObjectID, artifactDigest)
+}
+
+func loadSLSAProvenance(repo *git.Repository, blobID plumbing.Hash, artifactDigest string) (*sslibdsse.Envelope, error) {
+	envBytes, err := gitinterface.ReadBlob(repo, blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envBytes, env); err != nil {
+		return nil, err
+	}
+
+	if err := validateSLSAProvenance(env, artifactDigest); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// validateSLSAProvenance checks that the envelope contains a SLSA provenance
+// statement with the artifact digest as one of its subjects.
+func validateSLSAProvenance(env *sslibdsse.Envelope, artifactDigest string) error {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return err
+	}
+
+	statement := &struct {
+		Subject []struct {
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+		PredicateType string `json:"predicateType"`
+		// in-toto statements serialized using protobuf field names
+		PredicateTypeAlt string `json:"predicate_type"`
+	}{}
+	if err := json.Unmarshal(payload, statement); err != nil {
+		return err
+	}
+
+	predicateType := statement.PredicateType
+	if predicateType == "" {
+		predicateType = statement.PredicateTypeAlt
+	}
+	if predicateType != SLSAProvenanceV02PredicateType && predicateType != SLSAProvenanceV1PredicateType {
+		return ErrInvalidSLSAProvenance
+	}
+
+	for _, subject := range statement.Subject {
+		if subject.Digest[digestSHA256Key] == artifactDigest {
+			return nil
+		}
+	}
+
+	return ErrInvalidSLSAProvenance
+}
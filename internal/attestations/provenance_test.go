@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAndGetSLSAProvenance(t *testing.T) {
+	testObjectID := "1111111111111111111111111111111111111111"
+	testDigest := "2222222222222222222222222222222222222222222222222222222222222222"
+	testOtherDigest := "3333333333333333333333333333333333333333333333333333333333333333"
+
+	provenance := `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"artifact","digest":{"sha256":"` + testDigest + `"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{"buildDefinition":{},"runDetails":{}}}`
+	env := &sslibdsse.Envelope{
+		PayloadType: "application/vnd.in-toto+json",
+		Payload:     base64.StdEncoding.EncodeToString([]byte(provenance)),
+		Signatures:  []sslibdsse.Signature{{KeyID: "builder", Sig: "c2lnbmF0dXJl"}},
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	_, err = attestations.GetSLSAProvenanceFor(repo, testObjectID, testDigest)
+	assert.ErrorIs(t, err, ErrSLSAProvenanceNotFound)
+
+	// The provenance must have the artifact as a subject
+	err = attestations.SetSLSAProvenance(repo, env, testObjectID, testOtherDigest)
+	assert.ErrorIs(t, err, ErrInvalidSLSAProvenance)
+
+	err = attestations.SetSLSAProvenance(repo, env, testObjectID, testDigest)
+	assert.Nil(t, err)
+	assert.Contains(t, attestations.slsaProvenance, SLSAProvenancePath(testObjectID, testDigest))
+
+	if err := attestations.Commit(repo, "Test commit", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedEnv, err := attestations.GetSLSAProvenanceFor(repo, testObjectID, testDigest)
+	assert.Nil(t, err)
+	assert.Equal(t, env, storedEnv)
+
+	envs, err := attestations.GetSLSAProvenanceForObject(repo, testObjectID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*sslibdsse.Envelope{testDigest: env}, envs)
+
+	envs, err = attestations.GetSLSAProvenanceForObject(repo, "4444444444444444444444444444444444444444")
+	assert.Nil(t, err)
+	assert.Empty(t, envs)
+}
+
+func TestValidateSLSAProvenance(t *testing.T) {
+	testDigest := "2222222222222222222222222222222222222222222222222222222222222222"
+
+	authorization, err := NewReferenceAuthorization("refs/heads/main", testDigest, testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorizationBytes, err := json.Marshal(authorization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Other attestation types are rejected
+	env := &sslibdsse.Envelope{Payload: base64.StdEncoding.EncodeToString(authorizationBytes)}
+	err = validateSLSAProvenance(env, testDigest)
+	assert.ErrorIs(t, err, ErrInvalidSLSAProvenance)
+}
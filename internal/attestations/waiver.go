@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const VerificationWaiverPredicateType = "https://gittuf.dev/verification-waiver/v0.1"
+
+var (
+	ErrInvalidVerificationWaiver  = errors.New("verification waiver attestation does not match expected details")
+	ErrVerificationWaiverNotFound = errors.New("requested verification waiver not found")
+)
+
+// VerificationWaiver records that the failure of a rule when verifying an RSL
+// reference entry is accepted until the waiver expires. It is meant to be used
+// as a "predicate" in an in-toto attestation.
+type VerificationWaiver struct {
+	RefName       string `json:"refName"`
+	EntryID       string `json:"entryID"`
+	RuleName      string `json:"ruleName"`
+	Justification string `json:"justification"`
+
+	// Expires is the time, in RFC 3339 format, after which the waiver is no
+	// longer accepted.
+	Expires string `json:"expires"`
+}
+
+// ExpiresAt returns the time after which the waiver is no longer accepted.
+func (w *VerificationWaiver) ExpiresAt() (time.Time, error) {
+	return time.Parse(time.RFC3339, w.Expires)
+}
+
+// NewVerificationWaiver creates a new waiver for the failure of ruleName when
+// verifying the RSL entry with the specified ID, which is for refName. The
+// waiver is embedded in an in-toto "statement" and returned with the
+// appropriate "predicate type" set.
+func NewVerificationWaiver(refName, entryID, ruleName, justification string, expires time.Time) (*ita.Statement, error) {
+	predicate := &VerificationWaiver{
+		RefName:       refName,
+		EntryID:       entryID,
+		RuleName:      ruleName,
+		Justification: justification,
+		Expires:       expires.UTC().Format(time.RFC3339),
+	}
+
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateInterface := &map[string]any{}
+	if err := json.Unmarshal(predicateBytes, predicateInterface); err != nil {
+		return nil, err
+	}
+
+	predicateStruct, err := structpb.NewStruct(*predicateInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Digest: map[string]string{digestGitCommitKey: entryID},
+			},
+		},
+		PredicateType: VerificationWaiverPredicateType,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// SetVerificationWaiver writes the waiver attestation to the object store and
+// tracks it in the current attestations state.
+func (a *Attestations) SetVerificationWaiver(repo *git.Repository, env *sslibdsse.Envelope, refName, entryID, ruleName string) error {
+	if _, err := parseVerificationWaiver(env, refName, entryID, ruleName); err != nil {
+		return err
+	}
+
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, envBytes)
+	if err != nil {
+		return err
+	}
+
+	if a.verificationWaivers == nil {
+		a.verificationWaivers = map[string]plumbing.Hash{}
+	}
+
+	a.verificationWaivers[VerificationWaiverPath(refName, entryID, ruleName)] = blobID
+	return nil
+}
+
+// RemoveVerificationWaiver removes the waiver for the specified parameters
+// from the current attestations state.
+func (a *Attestations) RemoveVerificationWaiver(refName, entryID, ruleName string) error {
+	waiverPath := VerificationWaiverPath(refName, entryID, ruleName)
+	if _, has := a.verificationWaivers[waiverPath]; !has {
+		return ErrVerificationWaiverNotFound
+	}
+
+	delete(a.verificationWaivers, waiverPath)
+	return nil
+}
+
+// GetVerificationWaiverFor returns the waiver attestation (with its
+// signatures) for the failure of ruleName when verifying the specified RSL
+// entry, along with the decoded waiver.
+func (a *Attestations) GetVerificationWaiverFor(repo *git.Repository, refName, entryID, ruleName string) (*sslibdsse.Envelope, *VerificationWaiver, error) {
+	blobID, has := a.verificationWaivers[VerificationWaiverPath(refName, entryID, ruleName)]
+	if !has {
+		return nil, nil, ErrVerificationWaiverNotFound
+	}
+
+	envBytes, err := gitinterface.ReadBlob(repo, blobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envBytes, env); err != nil {
+		return nil, nil, err
+	}
+
+	waiver, err := parseVerificationWaiver(env, refName, entryID, ruleName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return env, waiver, nil
+}
+
+// VerificationWaiverPath constructs the expected path on-disk for the
+// verification waiver attestation.
+func VerificationWaiverPath(refName, entryID, ruleName string) string {
+	return path.Join(refName, entryID, ruleName)
+}
+
+func parseVerificationWaiver(env *sslibdsse.Envelope, refName, entryID, ruleName string) (*VerificationWaiver, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	attestation := &ita.Statement{}
+	if err := json.Unmarshal(payload, attestation); err != nil {
+		return nil, err
+	}
+
+	if attestation.PredicateType != VerificationWaiverPredicateType {
+		return nil, ErrInvalidVerificationWaiver
+	}
+
+	if len(attestation.Subject) == 0 || attestation.Subject[0].Digest[digestGitCommitKey] != entryID {
+		return nil, ErrInvalidVerificationWaiver
+	}
+
+	predicateBytes, err := json.Marshal(attestation.Predicate.AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	waiver := &VerificationWaiver{}
+	if err := json.Unmarshal(predicateBytes, waiver); err != nil {
+		return nil, err
+	}
+
+	if waiver.RefName != refName || waiver.EntryID != entryID || waiver.RuleName != ruleName {
+		return nil, ErrInvalidVerificationWaiver
+	}
+
+	if _, err := waiver.ExpiresAt(); err != nil {
+		return nil, ErrInvalidVerificationWaiver
+	}
+
+	return waiver, nil
+}
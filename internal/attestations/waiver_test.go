@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	ita "github.com/in-toto/attestation/go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVerificationWaiver(t *testing.T) {
+	testRef := "refs/heads/main"
+	testEntryID := "1111111111111111111111111111111111111111"
+	testRuleName := "protect-main"
+	expires := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	waiver, err := NewVerificationWaiver(testRef, testEntryID, testRuleName, "incident response", expires)
+	assert.Nil(t, err)
+
+	assert.Equal(t, ita.StatementTypeUri, waiver.Type)
+
+	assert.Equal(t, 1, len(waiver.Subject))
+	assert.Equal(t, testEntryID, waiver.Subject[0].Digest[digestGitCommitKey])
+
+	assert.Equal(t, VerificationWaiverPredicateType, waiver.PredicateType)
+
+	predicate := waiver.Predicate.AsMap()
+	assert.Equal(t, testRef, predicate["refName"])
+	assert.Equal(t, testEntryID, predicate["entryID"])
+	assert.Equal(t, testRuleName, predicate["ruleName"])
+	assert.Equal(t, "incident response", predicate["justification"])
+	assert.Equal(t, "2030-01-01T00:00:00Z", predicate["expires"])
+}
+
+func TestSetGetAndRemoveVerificationWaiver(t *testing.T) {
+	testRef := "refs/heads/main"
+	testEntryID := "1111111111111111111111111111111111111111"
+	testRuleName := "protect-main"
+	expires := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	waiver, err := NewVerificationWaiver(testRef, testEntryID, testRuleName, "incident response", expires)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := dsse.CreateEnvelope(waiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	_, _, err = attestations.GetVerificationWaiverFor(repo, testRef, testEntryID, testRuleName)
+	assert.ErrorIs(t, err, ErrVerificationWaiverNotFound)
+
+	// The envelope must match the rule it's set for
+	err = attestations.SetVerificationWaiver(repo, env, testRef, testEntryID, "protect-feature")
+	assert.ErrorIs(t, err, ErrInvalidVerificationWaiver)
+
+	err = attestations.SetVerificationWaiver(repo, env, testRef, testEntryID, testRuleName)
+	assert.Nil(t, err)
+	assert.Contains(t, attestations.verificationWaivers, VerificationWaiverPath(testRef, testEntryID, testRuleName))
+
+	if err := attestations.Commit(repo, "Test commit", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedEnv, storedWaiver, err := attestations.GetVerificationWaiverFor(repo, testRef, testEntryID, testRuleName)
+	assert.Nil(t, err)
+	assert.Equal(t, env, storedEnv)
+	assert.Equal(t, "incident response", storedWaiver.Justification)
+	storedExpires, err := storedWaiver.ExpiresAt()
+	assert.Nil(t, err)
+	assert.True(t, expires.Equal(storedExpires))
+
+	err = attestations.RemoveVerificationWaiver(testRef, testEntryID, testRuleName)
+	assert.Nil(t, err)
+
+	_, _, err = attestations.GetVerificationWaiverFor(repo, testRef, testEntryID, testRuleName)
+	assert.ErrorIs(t, err, ErrVerificationWaiverNotFound)
+
+	err = attestations.RemoveVerificationWaiver(testRef, testEntryID, testRuleName)
+	assert.ErrorIs(t, err, ErrVerificationWaiverNotFound)
+}
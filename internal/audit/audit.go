@@ -0,0 +1,440 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit records the write operations performed by a gittuf client,
+// such as recording RSL entries and changing the policy, in a local
+// append-only log. As the log is not pushed, it allows operators to
+// reconstruct what a client did even before its changes are shared.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+)
+
+const (
+	// LogPathKey is the environment variable used to set the path of the
+	// audit log when it is not specified explicitly.
+	LogPathKey = "GITTUF_AUDIT_LOG"
+
+	// HookName is the name of the RSL entry hook registered by Enable.
+	HookName = "audit-log"
+)
+
+var (
+	ErrInvalidLevel          = errors.New("unknown audit level, must be one of 'info', 'notice', or 'warning'")
+	ErrInvalidAuditLog       = errors.New("invalid audit log")
+	ErrAuditLogNotConfigured = errors.New("audit log path not specified, set GITTUF_AUDIT_LOG or pass it explicitly")
+)
+
+// Level indicates the significance of an audited operation.
+type Level string
+
+const (
+	// LevelInfo is used for routine operations, such as recording the
+	// state of a ref.
+	LevelInfo Level = "info"
+
+	// LevelNotice is used for changes to gittuf metadata, such as the policy
+	// and attestations.
+	LevelNotice Level = "notice"
+
+	// LevelWarning is used for operations that remove or override recorded
+	// state, such as deleting refs, skipping RSL entries, and changing the
+	// root of trust.
+	LevelWarning Level = "warning"
+)
+
+// ParseLevel returns the Level with the specified name.
+func ParseLevel(name string) (Level, error) {
+	level := Level(name)
+	if level.rank() < 0 {
+		return "", fmt.Errorf("%w: '%s'", ErrInvalidLevel, name)
+	}
+
+	return level, nil
+}
+
+func (l Level) rank() int {
+	switch l {
+	case LevelInfo:
+		return 0
+	case LevelNotice:
+		return 1
+	case LevelWarning:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// Operation identifies the kind of write operation that was audited.
+type Operation string
+
+const (
+	OperationRecordRef          Operation = "record-ref"
+	OperationDeleteRef          Operation = "delete-ref"
+	OperationAnnotate           Operation = "annotate"
+	OperationSkip               Operation = "skip"
+	OperationUnskip             Operation = "unskip"
+	OperationPropagate          Operation = "propagate"
+	OperationStagePolicy        Operation = "stage-policy"
+	OperationApplyPolicy        Operation = "apply-policy"
+	OperationUpdateAttestations Operation = "update-attestations"
+)
+
+// Event is the record of a single write operation in the audit log.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Level     Level     `json:"level"`
+	Operation Operation `json:"operation"`
+
+	// EntryID and EntryNumber identify the RSL entry that recorded the
+	// operation.
+	EntryID     string `json:"entryID"`
+	EntryNumber uint64 `json:"entryNumber,omitempty"`
+
+	RefName  string `json:"refName,omitempty"`
+	TargetID string `json:"targetID,omitempty"`
+
+	// Committer is the Git identity that recorded the RSL entry, and Signed
+	// indicates whether the entry was signed.
+	Committer string `json:"committer"`
+	Signed    bool   `json:"signed"`
+
+	// Signers contains the IDs of the keys that signed the policy metadata
+	// changed by the operation.
+	Signers []string `json:"signers,omitempty"`
+
+	// Parameters contains operation specific details, such as the RSL
+	// entries annotated or the policy files changed.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// NewEvent creates the audit event for the specified RSL entry, which must
+// already be recorded in the repository.
+func NewEvent(repo *git.Repository, entry rsl.Entry) (*Event, error) {
+	entryCommit, err := gitinterface.GetCommit(repo, entry.GetID())
+	if err != nil {
+		return nil, err
+	}
+
+	event := &Event{
+		Time:       time.Now().UTC(),
+		Level:      LevelInfo,
+		EntryID:    entry.GetID().String(),
+		Committer:  fmt.Sprintf("%s <%s>", entryCommit.Committer.Name, entryCommit.Committer.Email),
+		Signed:     entryCommit.PGPSignature != "",
+		Parameters: map[string]string{},
+	}
+
+	switch entry := entry.(type) {
+	case *rsl.ReferenceEntry:
+		event.EntryNumber = entry.Number
+		event.RefName = entry.RefName
+		event.TargetID = entry.TargetID.String()
+
+		switch {
+		case entry.RefName == policy.PolicyStagingRef || entry.RefName == policy.PolicyRef:
+			event.Operation = OperationStagePolicy
+			if entry.RefName == policy.PolicyRef {
+				event.Operation = OperationApplyPolicy
+			}
+			event.Level = LevelNotice
+
+			if err := addPolicyChanges(repo, entry, event); err != nil {
+				return nil, err
+			}
+		case entry.RefName == attestations.Ref:
+			event.Operation = OperationUpdateAttestations
+			event.Level = LevelNotice
+
+			if err := addCommitMessage(repo, entry, event); err != nil {
+				return nil, err
+			}
+		case entry.Deleted:
+			event.Operation = OperationDeleteRef
+			event.Level = LevelWarning
+		default:
+			event.Operation = OperationRecordRef
+		}
+
+		addParameter(event, "actor", entry.Actor)
+		addParameter(event, "actorURL", entry.ActorURL)
+		addParameter(event, "artifactDigest", entry.ArtifactDigest)
+	case *rsl.AnnotationEntry:
+		event.EntryNumber = entry.Number
+
+		switch {
+		case entry.Skip:
+			event.Operation = OperationSkip
+			event.Level = LevelWarning
+		case entry.Unskip:
+			event.Operation = OperationUnskip
+			event.Level = LevelWarning
+		default:
+			event.Operation = OperationAnnotate
+		}
+
+		entryIDs := make([]string, 0, len(entry.RSLEntryIDs))
+		for _, entryID := range entry.RSLEntryIDs {
+			entryIDs = append(entryIDs, entryID.String())
+		}
+		addParameter(event, "entryIDs", strings.Join(entryIDs, ","))
+		addParameter(event, "message", entry.Message)
+	case *rsl.PropagationEntry:
+		event.Operation = OperationPropagate
+		event.EntryNumber = entry.Number
+		event.RefName = entry.RefName
+		event.TargetID = entry.TargetID.String()
+
+		addParameter(event, "upstreamRepository", entry.UpstreamRepository)
+		addParameter(event, "upstreamEntryID", entry.UpstreamEntryID.String())
+	default:
+		return nil, rsl.ErrInvalidRSLEntry
+	}
+
+	if len(event.Parameters) == 0 {
+		event.Parameters = nil
+	}
+
+	return event, nil
+}
+
+// addPolicyChanges records the policy files changed in the entry and the keys
+// that signed them. Changes to the root of trust are raised to LevelWarning.
+func addPolicyChanges(repo *git.Repository, entry *rsl.ReferenceEntry, event *Event) error {
+	if err := addCommitMessage(repo, entry, event); err != nil {
+		return err
+	}
+
+	changes, err := policy.GetMetadataChanges(repo, entry)
+	if err != nil {
+		return err
+	}
+
+	roleNames := make([]string, 0, len(changes))
+	for _, change := range changes {
+		roleNames = append(roleNames, change.RoleName)
+		if change.RoleName == policy.RootRoleName {
+			event.Level = LevelWarning
+		}
+
+		for _, keyID := range change.KeyIDs {
+			if !slices.Contains(event.Signers, keyID) {
+				event.Signers = append(event.Signers, keyID)
+			}
+		}
+	}
+	slices.Sort(event.Signers)
+	addParameter(event, "roles", strings.Join(roleNames, ","))
+
+	return nil
+}
+
+// addCommitMessage records the summary of the commit that the entry's ref
+// points to, which describes the change to gittuf metadata.
+func addCommitMessage(repo *git.Repository, entry *rsl.ReferenceEntry, event *Event) error {
+	commit, err := gitinterface.GetCommit(repo, entry.TargetID)
+	if err != nil {
+		return err
+	}
+
+	summary, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+	addParameter(event, "message", summary)
+	return nil
+}
+
+func addParameter(event *Event, key, value string) {
+	if value != "" {
+		event.Parameters[key] = value
+	}
+}
+
+// Log is an append-only audit log stored in a local file, with one JSON
+// encoded event per line.
+type Log struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewLog returns the audit log stored at path. The file is created when the
+// first event is appended.
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the path of the file storing the audit log.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append adds the event to the end of the log.
+func (l *Log) Append(event *Event) error {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(eventBytes, '\n')); err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+
+	return file.Close()
+}
+
+// Query selects events from the audit log. Unset fields match all events.
+type Query struct {
+	// Since and Until bound the time at which events were recorded.
+	Since time.Time
+	Until time.Time
+
+	// MinLevel matches events at or above the specified level.
+	MinLevel Level
+
+	Operations []Operation
+	RefName    string
+
+	// Signer matches events whose RSL entry was recorded by the specified
+	// committer, or whose policy changes were signed by the specified key.
+	Signer string
+}
+
+func (q *Query) matches(event *Event) bool {
+	if !q.Since.IsZero() && event.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && event.Time.After(q.Until) {
+		return false
+	}
+	if q.MinLevel != "" && event.Level.rank() < q.MinLevel.rank() {
+		return false
+	}
+	if len(q.Operations) != 0 && !slices.Contains(q.Operations, event.Operation) {
+		return false
+	}
+	if q.RefName != "" && event.RefName != q.RefName {
+		return false
+	}
+	if q.Signer != "" && event.Committer != q.Signer && !slices.Contains(event.Signers, q.Signer) {
+		return false
+	}
+
+	return true
+}
+
+// Query returns the events in the log that match the query, in the order they
+// were recorded. A log that does not exist yet has no events.
+func (l *Log) Query(query *Query) ([]*Event, error) {
+	if query == nil {
+		query = &Query{}
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	file, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Event{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	events := []*Event{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		event := &Event{}
+		if err := json.Unmarshal(line, event); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidAuditLog, lineNumber, err)
+		}
+
+		if query.matches(event) {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Hook is the RSL entry hook that appends an event to the audit log for each
+// RSL entry created. As every gittuf write operation, including changes to
+// the policy and attestations, is recorded in the RSL, this captures all of
+// them.
+type Hook struct {
+	log *Log
+}
+
+// NewHook returns a Hook that appends to the specified log.
+func NewHook(log *Log) *Hook {
+	return &Hook{log: log}
+}
+
+// Name returns HookName.
+func (h *Hook) Name() string {
+	return HookName
+}
+
+// BeforeCommit does not reject any entries.
+func (h *Hook) BeforeCommit(_ *git.Repository, _ rsl.Entry) error {
+	return nil
+}
+
+// AfterCommit appends an event for the entry to the audit log.
+func (h *Hook) AfterCommit(repo *git.Repository, entry rsl.Entry) error {
+	event, err := NewEvent(repo, entry)
+	if err != nil {
+		return err
+	}
+
+	return h.log.Append(event)
+}
+
+// Enable records all subsequent gittuf write operations in the process to the
+// audit log at path, replacing any audit log enabled previously.
+func Enable(path string) (*Log, error) {
+	log := NewLog(path)
+
+	rsl.UnregisterEntryHook(HookName)
+	if err := rsl.RegisterEntryHook(NewHook(log)); err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// Disable stops recording gittuf write operations to the audit log.
+func Disable() {
+	rsl.UnregisterEntryHook(HookName)
+}
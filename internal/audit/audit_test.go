@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	log, err := Enable(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Disable()
+
+	r, err := repository.NewInMemoryRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := r.GoGitRepository()
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey1Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootKeyID, err := rootSigner.KeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.InitializeRoot(context.Background(), rootSigner, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ApplyPolicy(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), "refs/heads/main", "Test commit", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RecordRSLEntryForReferenceWithActor("main", "alice", "", false); err != nil {
+		t.Fatal(err)
+	}
+	mainEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, "refs/heads/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RecordRSLAnnotation([]string{mainEntry.ID.String()}, true, "revoke", false); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := log.Query(nil)
+	assert.Nil(t, err)
+	if !assert.Len(t, events, 4) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, OperationStagePolicy, events[0].Operation)
+	assert.Equal(t, LevelWarning, events[0].Level)
+	assert.Equal(t, policy.PolicyStagingRef, events[0].RefName)
+	assert.Equal(t, []string{rootKeyID}, events[0].Signers)
+	assert.Equal(t, policy.RootRoleName, events[0].Parameters["roles"])
+
+	assert.Equal(t, OperationApplyPolicy, events[1].Operation)
+	assert.Equal(t, policy.PolicyRef, events[1].RefName)
+
+	assert.Equal(t, OperationRecordRef, events[2].Operation)
+	assert.Equal(t, LevelInfo, events[2].Level)
+	assert.Equal(t, mainEntry.ID.String(), events[2].EntryID)
+	assert.Equal(t, mainEntry.TargetID.String(), events[2].TargetID)
+	assert.Equal(t, "alice", events[2].Parameters["actor"])
+	assert.False(t, events[2].Signed)
+
+	assert.Equal(t, OperationSkip, events[3].Operation)
+	assert.Equal(t, LevelWarning, events[3].Level)
+	assert.Equal(t, mainEntry.ID.String(), events[3].Parameters["entryIDs"])
+	assert.Equal(t, "revoke", events[3].Parameters["message"])
+
+	t.Run("query", func(t *testing.T) {
+		// Both policy events change the root of trust
+		events, err := log.Query(&Query{MinLevel: LevelWarning})
+		assert.Nil(t, err)
+		assert.Len(t, events, 3)
+
+		events, err = log.Query(&Query{Operations: []Operation{OperationRecordRef, OperationSkip}, RefName: "refs/heads/main"})
+		assert.Nil(t, err)
+		assert.Len(t, events, 1)
+
+		events, err = log.Query(&Query{Signer: rootKeyID})
+		assert.Nil(t, err)
+		assert.Len(t, events, 2)
+
+		events, err = log.Query(&Query{Since: time.Now().Add(time.Hour)})
+		assert.Nil(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		Disable()
+		defer Enable(logPath) //nolint:errcheck
+
+		if err := r.RecordRSLEntryForReference("main", false); err != nil {
+			t.Fatal(err)
+		}
+
+		events, err := log.Query(nil)
+		assert.Nil(t, err)
+		assert.Len(t, events, 4)
+	})
+
+	t.Run("invalid log", func(t *testing.T) {
+		invalidLogPath := filepath.Join(t.TempDir(), "invalid.log")
+		if err := os.WriteFile(invalidLogPath, []byte("not json\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := NewLog(invalidLogPath).Query(nil)
+		assert.ErrorIs(t, err, ErrInvalidAuditLog)
+
+		events, err := NewLog(filepath.Join(t.TempDir(), "missing.log")).Query(nil)
+		assert.Nil(t, err)
+		assert.Empty(t, events)
+	})
+
+	_, err = ParseLevel("debug")
+	assert.ErrorIs(t, err, ErrInvalidLevel)
+}
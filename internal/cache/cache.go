@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	// RSLIndexRef defines the Git namespace used to persist the RSL index. Like
+	// the verification cache, it is deliberately outside refs/gittuf/ so that
+	// it is local to the repository and is never synced with remotes.
+	RSLIndexRef = "refs/gittuf-local/rsl-index"
+
+	rslIndexTreeEntryName = "index.json"
+	rslIndexCommitMessage = "Update RSL index"
+)
+
+var ErrInvalidRSLIndex = errors.New("invalid RSL index tree structure")
+
+// IndexEntry identifies an RSL entry in the RSL index.
+type IndexEntry struct {
+	// ID is the ID of the RSL entry.
+	ID string `json:"id"`
+
+	// Number is the number recorded in the RSL entry, or zero if the entry
+	// predates entry numbering.
+	Number uint64 `json:"number"`
+}
+
+// RSLIndex maps ref names to the RSL reference entries recorded for them, and
+// entry numbers to the corresponding entries. The index is built by walking
+// the RSL once, after which lookups do not require walking the RSL again. It
+// is only valid for the RSL ending at TipID.
+type RSLIndex struct {
+	// TipID is the ID of the latest RSL entry included in the index.
+	TipID string `json:"tipID"`
+
+	// FirstID is the ID of the first RSL entry.
+	FirstID string `json:"firstID"`
+
+	// Refs maps each ref name to its reference entries in order of
+	// occurrence.
+	Refs map[string][]*IndexEntry `json:"refs"`
+
+	// Numbers maps each entry number to the ID of the entry. Entries without
+	// a number are not included.
+	Numbers map[uint64]string `json:"numbers"`
+
+	modified bool
+}
+
+// LoadRSLIndex loads the RSL index persisted in the repository. If the index
+// does not exist yet, an empty index is returned.
+func LoadRSLIndex(repo *git.Repository) (*RSLIndex, error) {
+	index := newRSLIndex()
+
+	ref, err := repo.Reference(plumbing.ReferenceName(RSLIndexRef), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return index, nil
+		}
+		return nil, err
+	}
+
+	indexCommit, err := gitinterface.GetCommit(repo, ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	indexTree, err := gitinterface.GetTree(repo, indexCommit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(indexTree.Entries) != 1 || indexTree.Entries[0].Name != rslIndexTreeEntryName {
+		return nil, ErrInvalidRSLIndex
+	}
+
+	contents, err := gitinterface.ReadBlob(repo, indexTree.Entries[0].Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(contents, index); err != nil {
+		return nil, err
+	}
+	if index.Refs == nil {
+		index.Refs = map[string][]*IndexEntry{}
+	}
+	if index.Numbers == nil {
+		index.Numbers = map[uint64]string{}
+	}
+
+	return index, nil
+}
+
+// InvalidateRSLIndex removes the RSL index persisted in the repository. The
+// index is rebuilt from scratch the next time it is populated.
+func InvalidateRSLIndex(repo *git.Repository) error {
+	err := repo.Storer.RemoveReference(plumbing.ReferenceName(RSLIndexRef))
+	if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// GetTip returns the ID of the latest RSL entry included in the index. If the
+// index is empty, the zero hash is returned.
+func (i *RSLIndex) GetTip() plumbing.Hash {
+	if i.TipID == "" {
+		return plumbing.ZeroHash
+	}
+
+	return plumbing.NewHash(i.TipID)
+}
+
+// SetTip records that the index includes every RSL entry up to entryID.
+func (i *RSLIndex) SetTip(entryID plumbing.Hash) {
+	if i.TipID == entryID.String() {
+		return
+	}
+
+	i.TipID = entryID.String()
+	i.modified = true
+}
+
+// AddEntry adds an RSL entry to the index. refName is empty for annotation
+// entries, which are only indexed by number. Entries must be added in order of
+// occurrence.
+func (i *RSLIndex) AddEntry(refName string, entryID plumbing.Hash, number uint64) {
+	if i.FirstID == "" {
+		i.FirstID = entryID.String()
+	}
+	if refName != "" {
+		i.Refs[refName] = append(i.Refs[refName], &IndexEntry{ID: entryID.String(), Number: number})
+	}
+	if number != 0 {
+		i.Numbers[number] = entryID.String()
+	}
+	i.modified = true
+}
+
+// Reset clears the index so that it can be rebuilt.
+func (i *RSLIndex) Reset() {
+	*i = *newRSLIndex()
+	i.modified = true
+}
+
+// GetFirstEntry returns the ID of the first RSL entry in the index.
+func (i *RSLIndex) GetFirstEntry() (plumbing.Hash, bool) {
+	if i.FirstID == "" {
+		return plumbing.ZeroHash, false
+	}
+
+	return plumbing.NewHash(i.FirstID), true
+}
+
+// GetLatestEntryForRef returns the ID of the latest reference entry for the
+// ref in the index.
+func (i *RSLIndex) GetLatestEntryForRef(refName string) (plumbing.Hash, bool) {
+	entries := i.Refs[refName]
+	if len(entries) == 0 {
+		return plumbing.ZeroHash, false
+	}
+
+	return plumbing.NewHash(entries[len(entries)-1].ID), true
+}
+
+// GetFirstEntryForRef returns the ID of the first reference entry for the ref
+// in the index.
+func (i *RSLIndex) GetFirstEntryForRef(refName string) (plumbing.Hash, bool) {
+	entries := i.Refs[refName]
+	if len(entries) == 0 {
+		return plumbing.ZeroHash, false
+	}
+
+	return plumbing.NewHash(entries[0].ID), true
+}
+
+// GetEntryByNumber returns the ID of the entry with the specified number in
+// the index.
+func (i *RSLIndex) GetEntryByNumber(number uint64) (plumbing.Hash, bool) {
+	entryID, has := i.Numbers[number]
+	if !has {
+		return plumbing.ZeroHash, false
+	}
+
+	return plumbing.NewHash(entryID), true
+}
+
+// Commit persists the RSL index in the repository. The commit is never signed
+// as the index is local to the repository. If the index has not been modified
+// since it was loaded, no commit is created.
+func (i *RSLIndex) Commit(repo *git.Repository) error {
+	if !i.modified {
+		return nil
+	}
+
+	contents, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, contents)
+	if err != nil {
+		return err
+	}
+
+	treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{
+		{
+			Name: rslIndexTreeEntryName,
+			Mode: filemode.Regular,
+			Hash: blobID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := gitinterface.Commit(repo, treeID, RSLIndexRef, rslIndexCommitMessage, false); err != nil {
+		return err
+	}
+
+	i.modified = false
+	return nil
+}
+
+func newRSLIndex() *RSLIndex {
+	return &RSLIndex{
+		Refs:    map[string][]*IndexEntry{},
+		Numbers: map[uint64]string{},
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRSLIndex(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstEntryID := plumbing.NewHash("abcdef1234567890")
+	annotationID := plumbing.NewHash("1234567890abcdef")
+	secondEntryID := plumbing.NewHash("fedcba0987654321")
+
+	index, err := LoadRSLIndex(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.ZeroHash, index.GetTip())
+	_, has := index.GetLatestEntryForRef("refs/heads/main")
+	assert.False(t, has)
+
+	// Committing an unmodified index is a no-op
+	err = index.Commit(repo)
+	assert.Nil(t, err)
+	_, err = repo.Reference(plumbing.ReferenceName(RSLIndexRef), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	index.AddEntry("refs/heads/main", firstEntryID, 1)
+	index.AddEntry("", annotationID, 2)
+	index.AddEntry("refs/heads/main", secondEntryID, 0)
+	index.SetTip(secondEntryID)
+
+	err = index.Commit(repo)
+	assert.Nil(t, err)
+
+	index, err = LoadRSLIndex(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, secondEntryID, index.GetTip())
+
+	entryID, has := index.GetFirstEntry()
+	assert.True(t, has)
+	assert.Equal(t, firstEntryID, entryID)
+
+	entryID, has = index.GetFirstEntryForRef("refs/heads/main")
+	assert.True(t, has)
+	assert.Equal(t, firstEntryID, entryID)
+
+	entryID, has = index.GetLatestEntryForRef("refs/heads/main")
+	assert.True(t, has)
+	assert.Equal(t, secondEntryID, entryID)
+
+	entryID, has = index.GetEntryByNumber(2)
+	assert.True(t, has)
+	assert.Equal(t, annotationID, entryID)
+
+	// Entries without a number are not indexed by number
+	_, has = index.GetEntryByNumber(3)
+	assert.False(t, has)
+
+	index.Reset()
+	assert.Equal(t, plumbing.ZeroHash, index.GetTip())
+	assert.Empty(t, index.Refs)
+	_, has = index.GetFirstEntry()
+	assert.False(t, has)
+
+	err = InvalidateRSLIndex(repo)
+	assert.Nil(t, err)
+	_, err = repo.Reference(plumbing.ReferenceName(RSLIndexRef), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	index, err = LoadRSLIndex(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.ZeroHash, index.GetTip())
+
+	// Invalidating a missing index is a no-op
+	err = InvalidateRSLIndex(repo)
+	assert.Nil(t, err)
+}
@@ -11,7 +11,8 @@ import (
 )
 
 type options struct {
-	force bool
+	force  bool
+	record bool
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -22,6 +23,13 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		false,
 		"overwrite hooks, if they already exist",
 	)
+
+	cmd.Flags().BoolVar(
+		&o.record,
+		"record",
+		false,
+		"store the hooks in the repository and record them in the RSL, signing the RSL entry",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, _ []string) error {
@@ -30,17 +38,26 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	err = repo.UpdateHook(repository.HookPrePush, prePushScript, o.force)
+	err = repo.InstallHooks(o.force)
 	var hookErr *repository.ErrHookExists
 	if errors.As(err, &hookErr) {
+		script, _ := repository.GetHookScript(hookErr.HookType)
 		fmt.Fprintf(
 			cmd.ErrOrStderr(),
 			"'%s' already exists. Use --force flag or merge existing hook and the following script manually:\n\n%s\n",
 			string(hookErr.HookType),
-			prePushScript,
+			script,
 		)
 	}
-	return err
+	if err != nil {
+		return err
+	}
+
+	if !o.record {
+		return nil
+	}
+
+	return repo.RecordHooks(true)
 }
 
 func New() *cobra.Command {
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/audit"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	file       string
+	since      string
+	until      string
+	level      string
+	operations []string
+	refName    string
+	signer     string
+	jsonOutput bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.file,
+		"file",
+		"",
+		fmt.Sprintf("path of audit log (default: value of %s)", audit.LogPathKey),
+	)
+
+	cmd.Flags().StringVar(
+		&o.since,
+		"since",
+		"",
+		"only show operations recorded at or after the specified RFC 3339 timestamp",
+	)
+
+	cmd.Flags().StringVar(
+		&o.until,
+		"until",
+		"",
+		"only show operations recorded at or before the specified RFC 3339 timestamp",
+	)
+
+	cmd.Flags().StringVar(
+		&o.level,
+		"level",
+		"",
+		"only show operations at or above the specified level ('info', 'notice', or 'warning')",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.operations,
+		"operation",
+		[]string{},
+		"only show operations of the specified kind, such as 'record-ref' or 'stage-policy'",
+	)
+
+	cmd.Flags().StringVar(
+		&o.refName,
+		"ref",
+		"",
+		"only show operations for the specified ref",
+	)
+
+	cmd.Flags().StringVar(
+		&o.signer,
+		"signer",
+		"",
+		"only show operations recorded by the specified committer ('Name <email>') or signed by the specified key ID",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print operations as JSON",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	path := o.file
+	if path == "" {
+		path = os.Getenv(audit.LogPathKey)
+	}
+	if path == "" {
+		return audit.ErrAuditLogNotConfigured
+	}
+
+	query := &audit.Query{
+		RefName: o.refName,
+		Signer:  o.signer,
+	}
+
+	var err error
+	if o.since != "" {
+		query.Since, err = time.Parse(time.RFC3339, o.since)
+		if err != nil {
+			return err
+		}
+	}
+	if o.until != "" {
+		query.Until, err = time.Parse(time.RFC3339, o.until)
+		if err != nil {
+			return err
+		}
+	}
+	if o.level != "" {
+		query.MinLevel, err = audit.ParseLevel(o.level)
+		if err != nil {
+			return err
+		}
+	}
+	for _, operation := range o.operations {
+		query.Operations = append(query.Operations, audit.Operation(operation))
+	}
+
+	events, err := audit.NewLog(path).Query(query)
+	if err != nil {
+		return err
+	}
+
+	if o.jsonOutput {
+		eventsJSON, err := json.MarshalIndent(events, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(eventsJSON))
+		return nil
+	}
+
+	for _, event := range events {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s [%s] %s", event.Time.Format(time.RFC3339), event.Level, event.Operation)
+		if event.RefName != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), " %s", event.RefName)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), " (entry %s) by %s\n", event.EntryID, event.Committer)
+		if len(event.Signers) != 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "    Signed by: %s\n", strings.Join(event.Signers, ", "))
+		}
+		if message, has := event.Parameters["message"]; has {
+			fmt.Fprintf(cmd.OutOrStdout(), "    Message: %s\n", message)
+		}
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "audit",
+		Short:             "Show the write operations recorded in the local audit log",
+		Long:              fmt.Sprintf("The 'audit' command shows the gittuf write operations recorded in the local audit log, such as RSL entries, annotations, and policy changes, in the order they were performed. Operations are recorded when the audit log is enabled using the global '--audit-log' flag or the %s environment variable. As the audit log is not pushed, it can be used to reconstruct what a client did before its changes were shared.", audit.LogPathKey),
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
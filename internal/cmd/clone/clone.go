@@ -3,12 +3,17 @@
 package clone
 
 import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/spf13/cobra"
 )
 
 type options struct {
-	branch string
+	branch        string
+	rootKeys      []string
+	rootThreshold int
+	rootPinFile   string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -19,6 +24,29 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"",
 		"specify branch to check out",
 	)
+
+	cmd.Flags().StringArrayVar(
+		&o.rootKeys,
+		"root-key",
+		[]string{},
+		"root public key obtained out-of-band that must have signed the initial root of trust",
+	)
+
+	cmd.Flags().IntVar(
+		&o.rootThreshold,
+		"root-threshold",
+		0,
+		"number of root keys specified using --root-key that must have signed the initial root of trust (default: all of them)",
+	)
+
+	cmd.Flags().StringVar(
+		&o.rootPinFile,
+		"root-pin-file",
+		"",
+		"file used to pin the initial root keys on first use and verify them subsequently",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("root-key", "root-pin-file")
 }
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
@@ -26,7 +54,21 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 	if len(args) > 1 {
 		dir = args[1]
 	}
-	_, err := repository.Clone(cmd.Context(), args[0], dir, o.branch)
+
+	rootKeys := make([]*tuf.Key, 0, len(o.rootKeys))
+	for _, key := range o.rootKeys {
+		rootKey, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+		rootKeys = append(rootKeys, rootKey)
+	}
+
+	_, err := repository.CloneWithOptions(cmd.Context(), args[0], dir, o.branch, &repository.CloneOptions{
+		RootTrustAnchors:          rootKeys,
+		RootTrustAnchorsThreshold: o.rootThreshold,
+		RootPinFile:               o.rootPinFile,
+	})
 	return err
 }
 
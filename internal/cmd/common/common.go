@@ -3,6 +3,8 @@
 package common
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,6 +13,9 @@ import (
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/kms"
+	"github.com/gittuf/gittuf/internal/signerverifier/smime"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshagent"
 	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
@@ -20,10 +25,12 @@ import (
 const (
 	GPGKeyPrefix = "gpg:"
 	FulcioPrefix = "fulcio:"
+	X509Prefix   = "x509:"
 )
 
-// LoadPublicKey returns a tuf.Key object for a PGP / Sigstore Fulcio / SSH
-// (on-disk) key for use in gittuf metadata.
+// LoadPublicKey returns a tuf.Key object for a PGP / Sigstore Fulcio / X.509 /
+// KMS / ssh-agent / SSH (on-disk) key for use in gittuf metadata. X.509
+// identities are specified as "x509:<email>::<path to CA bundle>".
 func LoadPublicKey(key string) (*tuf.Key, error) {
 	var keyObj *tuf.Key
 
@@ -57,6 +64,33 @@ func LoadPublicKey(key string) (*tuf.Key, error) {
 				Issuer:   ks[1],
 			},
 		}
+	case strings.HasPrefix(key, X509Prefix):
+		identity, caBundlePath, found := strings.Cut(strings.TrimPrefix(key, X509Prefix), "::")
+		if !found {
+			return nil, fmt.Errorf("incorrect format for x509 identity")
+		}
+
+		caBundle, err := os.ReadFile(caBundlePath)
+		if err != nil {
+			return nil, err
+		}
+
+		keyObj, err = smime.LoadX509Identity(identity, caBundle)
+		if err != nil {
+			return nil, err
+		}
+	case kms.IsKeyReference(key):
+		var err error
+		keyObj, err = kms.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+	case sshagent.IsKeyReference(key):
+		var err error
+		keyObj, err = sshagent.LoadPublicKey(key)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		kb, err := os.ReadFile(key)
 		if err != nil {
@@ -72,10 +106,56 @@ func LoadPublicKey(key string) (*tuf.Key, error) {
 	return keyObj, nil
 }
 
+// ReadKeyBytes returns the bytes of the signing key at keyPath. If keyPath is a
+// reference to a key held in a KMS service or the ssh-agent, the reference
+// itself is returned, as the key material is never available locally.
+func ReadKeyBytes(keyPath string) ([]byte, error) {
+	if kms.IsKeyReference(keyPath) || sshagent.IsKeyReference(keyPath) {
+		return []byte(strings.TrimSpace(keyPath)), nil
+	}
+
+	return os.ReadFile(keyPath)
+}
+
+// LoadOwnerPrincipals returns a mapping of owners, such as GitHub users and
+// teams, to principal names. The mapping is loaded from the JSON file at
+// mapPath, if specified, and then amended using mappings of the form
+// "<owner>=<principal>".
+func LoadOwnerPrincipals(mapPath string, mappings []string) (map[string]string, error) {
+	ownerPrincipals := map[string]string{}
+	if mapPath != "" {
+		mapContents, err := os.ReadFile(mapPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(mapContents, &ownerPrincipals); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, mapping := range mappings {
+		owner, principalName, found := strings.Cut(mapping, "=")
+		if !found || owner == "" || principalName == "" {
+			return nil, fmt.Errorf("invalid owner mapping '%s', expected '<owner>=<principal>'", mapping)
+		}
+		ownerPrincipals[owner] = principalName
+	}
+
+	return ownerPrincipals, nil
+}
+
 // LoadSigner loads a signer for the specified key bytes. The key must be
-// encoded either in a standard PEM format. For now, the custom securesystemslib
-// format is also supported.
+// encoded either in a standard PEM format or be a reference to a key held in a
+// KMS service or the ssh-agent. For now, the custom securesystemslib format is
+// also supported.
 func LoadSigner(keyBytes []byte) (sslibdsse.SignerVerifier, error) {
+	if kms.IsKeyReference(string(keyBytes)) {
+		return kms.NewSignerVerifierFromKeyReference(context.Background(), string(keyBytes))
+	}
+	if sshagent.IsKeyReference(string(keyBytes)) {
+		return sshagent.NewSignerVerifierFromKeyReference(string(keyBytes))
+	}
+
 	signer, err := sslibsv.NewSignerVerifierFromPEM(keyBytes)
 	if err == nil {
 		return signer, nil
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/sigstore/sigstore/pkg/signature/kms/fake"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,6 +19,7 @@ func TestLoadSigner(t *testing.T) {
 		"SSH RSA key":   {keyBytes: artifacts.SSHRSAPrivate},
 		"SSH ECDSA key": {keyBytes: artifacts.SSHECDSAPrivate},
 		"Legacy key":    {keyBytes: artifacts.SSLibKey1Private},
+		"KMS key":       {keyBytes: []byte(fake.ReferenceScheme)},
 	}
 
 	for name, test := range tests {
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestgithubapprovals
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey        string
+	repository        string
+	pullRequestNumber int
+	approvers         []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"GitHub app's signing key to use for signing attestation",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.repository,
+		"repository",
+		"",
+		"path to base GitHub repository the pull request is opened against, of form {owner}/{repo}",
+	)
+	cmd.MarkFlagRequired("repository") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.pullRequestNumber,
+		"pull-request-number",
+		-1,
+		"number of merged pull request to record approvals for",
+	)
+	cmd.MarkFlagRequired("pull-request-number") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.approvers,
+		"approver",
+		[]string{},
+		"mapping of GitHub reviewer to ID of their key in the policy, of form @{login}={keyID}",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repositoryParts := strings.Split(o.repository, "/")
+	if len(repositoryParts) != 2 {
+		return fmt.Errorf("invalid format for repository, must be {owner}/{repo}")
+	}
+
+	approverKeyIDs := map[string]string{}
+	for _, approver := range o.approvers {
+		account, keyID, found := strings.Cut(approver, "=")
+		if !found || !strings.HasPrefix(account, "@") || keyID == "" {
+			return fmt.Errorf("invalid format for approver '%s', must be @{login}={keyID}", approver)
+		}
+		approverKeyIDs[account] = keyID
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.signingKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddGitHubPullRequestApprovalAttestation(cmd.Context(), signer, repositoryParts[0], repositoryParts[1], o.pullRequestNumber, approverKeyIDs, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "attest-github-approvals",
+		Short: fmt.Sprintf("Record approvals of a merged GitHub pull request as an attestation (developer mode only, set %s=1)", dev.DevModeKey),
+		RunE:  o.Run,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
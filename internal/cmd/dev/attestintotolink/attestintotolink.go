@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestintotolink
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	fromRef string
+	link    string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.fromRef,
+		"from-ref",
+		"f",
+		"",
+		"ref whose merge into the target ref the link is evidence for",
+	)
+	cmd.MarkFlagRequired("from-ref") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.link,
+		"link",
+		"",
+		"path to signed in-toto link metadata",
+	)
+	cmd.MarkFlagRequired("link") //nolint:errcheck
+}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	link, err := os.ReadFile(o.link)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddInTotoLink(args[0], o.fromRef, link, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "attest-in-toto-link <targetRef>",
+		Short:             fmt.Sprintf("Record in-toto link metadata for a change to a ref (developer mode only, set %s=1)", dev.DevModeKey),
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
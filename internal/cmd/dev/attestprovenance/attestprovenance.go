@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestprovenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/repository"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	target         string
+	artifactDigest string
+	provenance     string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.target,
+		"target",
+		"",
+		"Git reference, commit, or tag the artifact was built from",
+	)
+	cmd.MarkFlagRequired("target") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.artifactDigest,
+		"artifact-digest",
+		"",
+		"SHA-256 digest of the artifact described by the provenance",
+	)
+	cmd.MarkFlagRequired("artifact-digest") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.provenance,
+		"provenance",
+		"",
+		"path to signed DSSE envelope containing SLSA provenance",
+	)
+	cmd.MarkFlagRequired("provenance") //nolint:errcheck
+}
+
+func (o *options) Run(_ *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	envBytes, err := os.ReadFile(o.provenance)
+	if err != nil {
+		return err
+	}
+
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envBytes, env); err != nil {
+		return err
+	}
+
+	return repo.AddSLSAProvenance(o.target, strings.TrimPrefix(o.artifactDigest, "sha256:"), env, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "attest-provenance",
+		Short:             fmt.Sprintf("Attach SLSA provenance for a build artifact to a commit or tag (developer mode only, set %s=1)", dev.DevModeKey),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -4,7 +4,6 @@ package authorize
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/dev"
@@ -56,7 +55,7 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	keyBytes, err := os.ReadFile(o.signingKey)
+	keyBytes, err := common.ReadKeyBytes(o.signingKey)
 	if err != nil {
 		return err
 	}
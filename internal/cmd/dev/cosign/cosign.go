@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"signing key to use for co-signing the RSL entry",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.signingKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddRSLEntryCoSignature(cmd.Context(), signer, args[0], true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "cosign <entryID>",
+		Short:             fmt.Sprintf("Co-sign an RSL reference entry (developer mode only, set %s=1)", dev.DevModeKey),
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -6,8 +6,16 @@ import (
 	"fmt"
 
 	"github.com/gittuf/gittuf/internal/cmd/dev/attestgithub"
+	"github.com/gittuf/gittuf/internal/cmd/dev/attestgithubapprovals"
+	"github.com/gittuf/gittuf/internal/cmd/dev/attestintotolink"
+	"github.com/gittuf/gittuf/internal/cmd/dev/attestprovenance"
 	"github.com/gittuf/gittuf/internal/cmd/dev/authorize"
+	"github.com/gittuf/gittuf/internal/cmd/dev/cosign"
+	"github.com/gittuf/gittuf/internal/cmd/dev/generatefixture"
+	"github.com/gittuf/gittuf/internal/cmd/dev/listauthorizations"
+	"github.com/gittuf/gittuf/internal/cmd/dev/listprovenance"
 	"github.com/gittuf/gittuf/internal/cmd/dev/rslrecordat"
+	"github.com/gittuf/gittuf/internal/cmd/dev/waive"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/spf13/cobra"
 )
@@ -21,8 +29,16 @@ func New() *cobra.Command {
 	}
 
 	cmd.AddCommand(authorize.New())
+	cmd.AddCommand(cosign.New())
 	cmd.AddCommand(attestgithub.New())
+	cmd.AddCommand(attestgithubapprovals.New())
+	cmd.AddCommand(attestintotolink.New())
+	cmd.AddCommand(attestprovenance.New())
+	cmd.AddCommand(generatefixture.New())
+	cmd.AddCommand(listauthorizations.New())
+	cmd.AddCommand(listprovenance.New())
 	cmd.AddCommand(rslrecordat.New())
+	cmd.AddCommand(waive.New())
 
 	return cmd
 }
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package generatefixture
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/dev/fixtures"
+	"github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	refs        int
+	entries     int
+	annotations int
+	skips       int
+	divergence  int
+	seed        int64
+	bare        bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&o.refs,
+		"refs",
+		1,
+		"number of branches updated in the fixture",
+	)
+
+	cmd.Flags().IntVar(
+		&o.entries,
+		"entries",
+		10,
+		"number of reference entries in the RSL",
+	)
+
+	cmd.Flags().IntVar(
+		&o.annotations,
+		"annotations",
+		0,
+		"number of annotations in the RSL that do not skip entries",
+	)
+
+	cmd.Flags().IntVar(
+		&o.skips,
+		"skips",
+		0,
+		"number of annotations in the RSL that skip entries",
+	)
+
+	cmd.Flags().IntVar(
+		&o.divergence,
+		"divergence",
+		0,
+		fmt.Sprintf("number of entries in a diverging RSL recorded for remote '%s'", fixtures.DefaultRemoteName),
+	)
+
+	cmd.Flags().Int64Var(
+		&o.seed,
+		"seed",
+		0,
+		"seed for the random choices made while generating the fixture",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.bare,
+		"bare",
+		false,
+		"create a bare repository",
+	)
+}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	repo, err := git.PlainInit(args[0], o.bare)
+	if err != nil {
+		return err
+	}
+
+	return fixtures.Generate(repo, &fixtures.Options{
+		Refs:        o.refs,
+		Entries:     o.entries,
+		Annotations: o.annotations,
+		Skips:       o.skips,
+		Divergence:  o.divergence,
+		Seed:        o.seed,
+	})
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "generate-fixture",
+		Short: fmt.Sprintf("Generate a repository with a deterministic RSL of the specified shape (developer mode only, set %s=1)", dev.DevModeKey),
+		Long:  "The generate-fixture command initializes a new Git repository at the specified path and populates it with commits and RSL entries according to the specified options. The same options always produce the same repository, which makes the fixtures useful for benchmarking and for reproducing bug reports.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  o.Run,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package listauthorizations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	jsonOutput bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print authorizations as JSON",
+	)
+}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	approvals, err := repo.GetReferenceAuthorizations(args[0])
+	if err != nil {
+		return err
+	}
+
+	if o.jsonOutput {
+		approvalsJSON, err := json.MarshalIndent(approvals, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(approvalsJSON))
+		return nil
+	}
+
+	for _, approval := range approvals {
+		fmt.Printf("Authorization for %s\n", approval.TargetRef)
+		fmt.Printf("    From:      %s\n", approval.FromRevisionID)
+		fmt.Printf("    To tree:   %s\n", approval.TargetTreeID)
+		fmt.Printf("    Approvers: %s\n", strings.Join(approval.Approvers, ", "))
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "list-authorizations <targetRef>",
+		Short:             "List reference authorizations recorded for a Git reference",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package listprovenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	jsonOutput bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print provenance envelopes as JSON",
+	)
+}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	provenance, err := repo.GetSLSAProvenance(args[0])
+	if err != nil {
+		return err
+	}
+
+	if o.jsonOutput {
+		provenanceJSON, err := json.MarshalIndent(provenance, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(provenanceJSON))
+		return nil
+	}
+
+	artifactDigests := make([]string, 0, len(provenance))
+	for artifactDigest := range provenance {
+		artifactDigests = append(artifactDigests, artifactDigest)
+	}
+	sort.Strings(artifactDigests)
+
+	for _, artifactDigest := range artifactDigests {
+		keyIDs := []string{}
+		for _, signature := range provenance[artifactDigest].Signatures {
+			keyIDs = append(keyIDs, signature.KeyID)
+		}
+
+		fmt.Printf("Provenance for sha256:%s\n", artifactDigest)
+		fmt.Printf("    Signed by: %s\n", strings.Join(keyIDs, ", "))
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "list-provenance <target>",
+		Short:             "List SLSA provenance attached to a commit or tag",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
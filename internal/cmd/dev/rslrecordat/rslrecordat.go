@@ -4,8 +4,8 @@ package rslrecordat
 
 import (
 	"fmt"
-	"os"
 
+	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/repository"
 	"github.com/spf13/cobra"
@@ -42,7 +42,7 @@ func (o *options) Run(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	signingKeyBytes, err := os.ReadFile(o.signingKeyPath)
+	signingKeyBytes, err := common.ReadKeyBytes(o.signingKeyPath)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package waive
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey    string
+	ruleName      string
+	justification string
+	expiresIn     time.Duration
+	revoke        bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"signing key to use for signing or revoking the waiver",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of the rule whose failure is waived",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringVarP(
+		&o.justification,
+		"justification",
+		"m",
+		"",
+		"justification for waiving the rule's failure",
+	)
+
+	cmd.Flags().DurationVar(
+		&o.expiresIn,
+		"expires-in",
+		7*24*time.Hour,
+		"duration after which the waiver is no longer accepted",
+	)
+
+	cmd.Flags().BoolVarP(
+		&o.revoke,
+		"revoke",
+		"r",
+		false,
+		"revoke existing waiver",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("revoke", "justification")
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.signingKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	if o.revoke {
+		return repo.RemoveVerificationWaiver(cmd.Context(), signer, args[0], o.ruleName, true)
+	}
+
+	return repo.AddVerificationWaiver(cmd.Context(), signer, args[0], o.ruleName, o.justification, time.Now().Add(o.expiresIn), true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "waive <entryID>",
+		Short:             fmt.Sprintf("Waive the failure of a rule when verifying an RSL entry (developer mode only, set %s=1)", dev.DevModeKey),
+		Long:              "The waive command signs a waiver accepting the failure of the specified rule when verifying the RSL entry. The waiver expires after the specified duration. Verification treats the rule's failure as a warning only while the waiver is signed by a threshold of the keys trusted for the top level rule file.",
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package doctor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+var ErrProblemsFound = errors.New("problems found in repository")
+
+type options struct {
+	jsonOutput bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print findings as JSON",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	findings, err := repo.Doctor(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if o.jsonOutput {
+		findingsJSON, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(findingsJSON))
+	} else {
+		if len(findings) == 0 {
+			fmt.Println("No problems found")
+		}
+
+		for _, finding := range findings {
+			fmt.Printf("[%s] %s", finding.Severity, finding.Check)
+			if finding.Subject != "" {
+				fmt.Printf(" (%s)", finding.Subject)
+			}
+			fmt.Printf(": %s\n", finding.Message)
+			if finding.Fix != "" {
+				fmt.Printf("    Fix: %s\n", finding.Fix)
+			}
+		}
+	}
+
+	for _, finding := range findings {
+		if finding.Severity == repository.DoctorSeverityError {
+			return ErrProblemsFound
+		}
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "doctor",
+		Short:             "Check the repository for common problems with its gittuf metadata",
+		Long:              "The 'doctor' command inspects the repository for common problems with its gittuf metadata, such as a missing RSL, unsigned RSL entries for refs protected by the policy, annotations that refer to unknown entries, remote RSL trackers that are out of sync with the local RSL, and policy metadata that has expired or is about to expire. Each finding is reported with a severity and a suggested fix. The command fails if any finding has the 'error' severity.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addforgeprincipal
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/forge"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p             *persistent.Options
+	policyName    string
+	principalName string
+	forge         string
+	forgeURL      string
+	username      string
+	threshold     int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to add principal to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.principalName,
+		"principal-name",
+		"",
+		"name of principal, defaults to the forge username",
+	)
+
+	cmd.Flags().StringVar(
+		&o.forge,
+		"forge",
+		forge.GitHub,
+		"forge to fetch the user's published keys from (github, gitlab)",
+	)
+
+	cmd.Flags().StringVar(
+		&o.forgeURL,
+		"forge-url",
+		"",
+		"URL of a self-hosted forge instance",
+	)
+
+	cmd.Flags().StringVar(
+		&o.username,
+		"username",
+		"",
+		"username of the account on the forge",
+	)
+	cmd.MarkFlagRequired("username") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.threshold,
+		"threshold",
+		1,
+		"threshold of principal's keys required to sign",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	resolver, err := forge.NewKeyResolver(o.forge, o.forgeURL)
+	if err != nil {
+		return err
+	}
+
+	principalName := o.principalName
+	if principalName == "" {
+		principalName = o.username
+	}
+
+	return repo.AddForgePrincipal(cmd.Context(), signer, o.policyName, principalName, resolver, o.username, o.threshold, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-forge-principal",
+		Short:             "Add a principal using the keys a user has published on GitHub or GitLab",
+		Long:              `This command allows users to define a named principal in the specified policy file using the SSH signing keys and GPG keys a user has published on GitHub or GitLab. The keys are pinned in the policy file, and changes on the forge are only picked up using the "refresh-forge-principals" command. Currently, API tokens are read from the GITHUB_TOKEN and GITLAB_TOKEN environment variables.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addprincipal
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p              *persistent.Options
+	policyName     string
+	principalName  string
+	authorizedKeys []string
+	threshold      int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to add principal to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.principalName,
+		"principal-name",
+		"",
+		"name of principal",
+	)
+	cmd.MarkFlagRequired("principal-name") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.authorizedKeys,
+		"authorize-key",
+		[]string{},
+		"public key belonging to principal",
+	)
+	cmd.MarkFlagRequired("authorize-key") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.threshold,
+		"threshold",
+		1,
+		"threshold of principal's keys required to sign",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	authorizedKeys := []*tuf.Key{}
+	for _, key := range o.authorizedKeys {
+		key, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+
+		authorizedKeys = append(authorizedKeys, key)
+	}
+
+	return repo.AddPrincipal(cmd.Context(), signer, o.policyName, o.principalName, authorizedKeys, o.threshold, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-principal",
+		Short:             "Add a principal such as a person or team to a policy file",
+		Long:              `This command allows users to define a named principal in the specified policy file. A principal groups one or more keys and is considered to have signed when the threshold of its keys have signed. Rules in the same policy file can trust the principal using the "set-rule-principals" command. Defining a principal with an existing name replaces it.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
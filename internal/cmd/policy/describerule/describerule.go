@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package describerule
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	targetRef string
+	ruleName  string
+	json      bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.targetRef,
+		"target-ref",
+		"policy",
+		"specify which policy ref should be inspected",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().BoolVar(
+		&o.json,
+		"json",
+		false,
+		"print rule as JSON",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	rule, err := repo.DescribeRule(cmd.Context(), o.targetRef, o.ruleName)
+	if err != nil {
+		return err
+	}
+
+	if o.json {
+		ruleJSON, err := json.MarshalIndent(rule, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(ruleJSON))
+		return nil
+	}
+
+	fmt.Printf("Rule %s:\n", rule.Name)
+	fmt.Printf("    Policy file: %s\n", rule.PolicyName)
+	fmt.Println("    Patterns:")
+	for _, pattern := range rule.Patterns {
+		fmt.Printf("        %s\n", pattern)
+	}
+	fmt.Println("    Authorized keys:")
+	for _, key := range rule.Keys {
+		fmt.Printf("        %s (%s)\n", key.KeyID, key.KeyType)
+	}
+	fmt.Printf("    Required valid signatures: %d\n", rule.Threshold)
+	if rule.RequireSignedCommits {
+		fmt.Println("    Requires signed commits: true")
+	}
+	if rule.InTotoLayoutDigest != "" {
+		fmt.Printf("    In-toto layout: sha256:%s\n", rule.InTotoLayoutDigest)
+		fmt.Println("    In-toto layout keys:")
+		for _, keyID := range rule.InTotoLayoutKeyIDs {
+			fmt.Printf("        %s\n", keyID)
+		}
+	}
+	if rule.EnginePolicyDigest != "" {
+		fmt.Printf("    Engine policy: %s, sha256:%s\n", rule.EnginePolicyEngine, rule.EnginePolicyDigest)
+	}
+	if rule.RequireSignOff {
+		fmt.Println("    Requires sign-off: true")
+	}
+	if rule.CommitMessagePattern != "" {
+		fmt.Printf("    Commit message pattern: %s\n", rule.CommitMessagePattern)
+	}
+	if rule.NoMergeCommits {
+		fmt.Println("    Disallows merge commits: true")
+	}
+	fmt.Printf("    Expires: %s\n", rule.Expires)
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "describe-rule",
+		Short:             "Describe a rule in the current state",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	from string
+	to   string
+	json bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.from,
+		"from",
+		"",
+		"ID of policy RSL entry or policy commit to compare from",
+	)
+	cmd.MarkFlagRequired("from") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.to,
+		"to",
+		"",
+		"ID of policy RSL entry or policy commit to compare to (default: current policy)",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.json,
+		"json",
+		false,
+		"print differences as JSON",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	diff, err := repo.DiffPolicy(cmd.Context(), o.from, o.to)
+	if err != nil {
+		return err
+	}
+
+	if o.json {
+		diffJSON, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(diffJSON))
+		return nil
+	}
+
+	if diff.IsEmpty() {
+		fmt.Println("No policy changes")
+		return nil
+	}
+
+	for _, rule := range diff.AddedRules {
+		fmt.Printf("Added rule %s in %s: %s\n", rule.Name, rule.PolicyName, strings.Join(rule.Patterns, ", "))
+	}
+	for _, rule := range diff.RemovedRules {
+		fmt.Printf("Removed rule %s from %s\n", rule.Name, rule.PolicyName)
+	}
+	for _, change := range diff.ModifiedRules {
+		fmt.Printf("Modified rule %s\n", change.Name)
+		if !slices.Equal(change.Before.Patterns, change.After.Patterns) {
+			fmt.Printf("    Patterns: %s -> %s\n", strings.Join(change.Before.Patterns, ", "), strings.Join(change.After.Patterns, ", "))
+		}
+		if change.Before.PolicyName != change.After.PolicyName {
+			fmt.Printf("    Policy file: %s -> %s\n", change.Before.PolicyName, change.After.PolicyName)
+		}
+		if change.Before.RequireSignedCommits != change.After.RequireSignedCommits {
+			fmt.Printf("    Requires signed commits: %t -> %t\n", change.Before.RequireSignedCommits, change.After.RequireSignedCommits)
+		}
+		if change.Before.InTotoLayoutDigest != change.After.InTotoLayoutDigest {
+			fmt.Printf("    In-toto layout: %s -> %s\n", layoutDescription(change.Before.InTotoLayoutDigest), layoutDescription(change.After.InTotoLayoutDigest))
+		}
+		if change.Before.EnginePolicyEngine != change.After.EnginePolicyEngine || change.Before.EnginePolicyDigest != change.After.EnginePolicyDigest {
+			fmt.Printf("    Engine policy: %s -> %s\n", enginePolicyDescription(change.Before.EnginePolicyEngine, change.Before.EnginePolicyDigest), enginePolicyDescription(change.After.EnginePolicyEngine, change.After.EnginePolicyDigest))
+		}
+		if change.Before.RequireSignOff != change.After.RequireSignOff {
+			fmt.Printf("    Requires sign-off: %t -> %t\n", change.Before.RequireSignOff, change.After.RequireSignOff)
+		}
+		if change.Before.CommitMessagePattern != change.After.CommitMessagePattern {
+			fmt.Printf("    Commit message pattern: %s -> %s\n", patternDescription(change.Before.CommitMessagePattern), patternDescription(change.After.CommitMessagePattern))
+		}
+		if change.Before.NoMergeCommits != change.After.NoMergeCommits {
+			fmt.Printf("    Disallows merge commits: %t -> %t\n", change.Before.NoMergeCommits, change.After.NoMergeCommits)
+		}
+	}
+	for _, principal := range diff.AddedKeys {
+		fmt.Printf("Added key %s\n", principal.KeyID)
+	}
+	for _, principal := range diff.RemovedKeys {
+		fmt.Printf("Removed key %s\n", principal.KeyID)
+	}
+	for _, change := range diff.ModifiedKeys {
+		fmt.Printf("Modified key %s\n", change.KeyID)
+		if len(change.AddedRoles) > 0 {
+			fmt.Printf("    Authorized for: %s\n", strings.Join(change.AddedRoles, ", "))
+		}
+		if len(change.RemovedRoles) > 0 {
+			fmt.Printf("    No longer authorized for: %s\n", strings.Join(change.RemovedRoles, ", "))
+		}
+	}
+	for _, change := range diff.ThresholdChanges {
+		fmt.Printf("Changed threshold for %s: %d -> %d\n", change.RoleName, change.Before, change.After)
+	}
+
+	return nil
+}
+
+func layoutDescription(layoutDigest string) string {
+	if layoutDigest == "" {
+		return "none"
+	}
+	return "sha256:" + layoutDigest
+}
+
+func enginePolicyDescription(engine, policyDigest string) string {
+	if policyDigest == "" {
+		return "none"
+	}
+	return engine + ", sha256:" + policyDigest
+}
+
+func patternDescription(pattern string) string {
+	if pattern == "" {
+		return "none"
+	}
+	return pattern
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "diff",
+		Short:             "Show changes between two policy states",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
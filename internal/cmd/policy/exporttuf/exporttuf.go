@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporttuf
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	output string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.output,
+		"output",
+		"o",
+		"",
+		"directory to write the TUF repository to",
+	)
+	cmd.MarkFlagRequired("output") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.ExportTUFRepository(cmd.Context(), o.output)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "export-tuf",
+		Short:             "Export the current policy as a TUF repository",
+		Long:              "The 'export-tuf' command writes the repository's current policy in the layout of a TUF repository, so that TUF clients and inspection tools can consume it. The root metadata, including every prior version, and the policy files are written to the 'metadata' subdirectory of the output directory along with generated snapshot and timestamp metadata. A summary of the latest entry for each ref in the RSL is written to 'rsl.json'. As gittuf does not use the snapshot and timestamp roles, their metadata is unsigned. The signatures in the root metadata and policy files are gittuf's DSSE signatures, which are computed over DSSE's pre-authentication encoding of the metadata.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
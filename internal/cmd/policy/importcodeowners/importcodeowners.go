@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package importcodeowners
+
+import (
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p              *persistent.Options
+	policyName     string
+	codeOwnersPath string
+	ownerMapPath   string
+	ownerMappings  []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to add rules to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.codeOwnersPath,
+		"codeowners",
+		".github/CODEOWNERS",
+		"path to CODEOWNERS file",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ownerMapPath,
+		"owner-map",
+		"",
+		"path to JSON file mapping CODEOWNERS owners to principals",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.ownerMappings,
+		"map-owner",
+		[]string{},
+		"mapping of a CODEOWNERS owner to a principal in the form '<owner>=<principal>'",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	ownerPrincipals, err := common.LoadOwnerPrincipals(o.ownerMapPath, o.ownerMappings)
+	if err != nil {
+		return err
+	}
+
+	codeOwnersContents, err := os.ReadFile(o.codeOwnersPath)
+	if err != nil {
+		return err
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.ImportCodeOwners(cmd.Context(), signer, o.policyName, codeOwnersContents, ownerPrincipals, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "import-codeowners",
+		Short:             "Generate file protection rules from a CODEOWNERS file",
+		Long:              `This command allows users to generate file protection rules in the specified policy file from a CODEOWNERS file. Each owner in the CODEOWNERS file must be mapped to a principal defined in the policy file, either using a JSON file passed to --owner-map or using --map-owner. A rule named "codeowners-<n>" is added for the n-th entry of the file if it lists owners, and any one of the entry's owners can approve changes to the matching files. As in CODEOWNERS, only the rule for the last matching entry applies to a file. Note that gittuf rule patterns do not support recursive wildcards, so patterns using "**", patterns for directories such as "docs/", and patterns without a leading or inner slash such as "*.go" are rejected. Use a pattern such as "/docs/*" to protect the files in a directory.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package importgithub
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p             *persistent.Options
+	policyName    string
+	repository    string
+	branches      []string
+	ownerMapPath  string
+	ownerMappings []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to add rules to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.repository,
+		"repository",
+		"",
+		"GitHub repository to import branch protection settings from, of form {owner}/{repo}",
+	)
+	cmd.MarkFlagRequired("repository") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.branches,
+		"branch",
+		[]string{},
+		"branch to import protection settings for, all protected branches are imported if not specified",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ownerMapPath,
+		"owner-map",
+		"",
+		"path to JSON file mapping GitHub users and teams to principals",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.ownerMappings,
+		"map-owner",
+		[]string{},
+		"mapping of a GitHub user or team to a principal in the form '<owner>=<principal>'",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repositoryParts := strings.Split(o.repository, "/")
+	if len(repositoryParts) != 2 {
+		return fmt.Errorf("invalid format for repository, must be {owner}/{repo}")
+	}
+
+	ownerPrincipals, err := common.LoadOwnerPrincipals(o.ownerMapPath, o.ownerMappings)
+	if err != nil {
+		return err
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.ImportGitHubBranchProtection(cmd.Context(), signer, o.policyName, repositoryParts[0], repositoryParts[1], o.branches, ownerPrincipals, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "import-github",
+		Short:             "Generate rules from the branch protection settings of a GitHub repository",
+		Long:              `This command allows users to generate rules in the specified policy file from the branch protection settings of a GitHub repository. A rule named "github-<branch>" is added for each branch. The rule trusts the users and teams allowed to push to the branch, or all collaborators with push access if pushes are not restricted. Each user ("@<login>") and team ("@<owner>/<slug>") must be mapped to a principal defined in the policy file, either using a JSON file passed to --owner-map or using --map-owner. The rule's threshold is one more than the number of approving reviews required on GitHub, and signed commits are required if GitHub requires them. The authentication token for the GitHub API is read from the GITHUB_TOKEN environment variable.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
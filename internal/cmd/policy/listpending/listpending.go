@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package listpending
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	changes, err := repo.ListPendingPolicyChanges(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No pending policy changes")
+		return nil
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.CurrentVersion == 0:
+			fmt.Printf("Policy file %s: added (version %d)\n", change.RoleName, change.StagedVersion)
+		case change.StagedVersion == 0:
+			fmt.Printf("Policy file %s: removed\n", change.RoleName)
+			continue
+		default:
+			fmt.Printf("Policy file %s: version %d -> %d\n", change.RoleName, change.CurrentVersion, change.StagedVersion)
+		}
+
+		if change.Threshold == 0 {
+			fmt.Printf("    Signatures: %d (delegating rule not found)\n", change.Signatures)
+			continue
+		}
+
+		status := "threshold not met"
+		if change.ThresholdMet {
+			status = "threshold met"
+		}
+		fmt.Printf("    Signatures: %d, required valid signatures: %d (%s)\n", change.Signatures, change.Threshold, status)
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "list-pending",
+		Short:             "List policy changes staged but not yet applied",
+		Long:              `This command lists the policy files that have been changed on the policy staging reference but not yet applied to the policy reference. For each change, the number of signatures on the staged policy file and whether it meets the required threshold are displayed. Once all changes meet their thresholds, the staged policy can be applied using "gittuf apply".`,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package listprincipals
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	targetRef string
+	json      bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.targetRef,
+		"target-ref",
+		"policy",
+		"specify which policy ref should be inspected",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.json,
+		"json",
+		false,
+		"print principals as JSON",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	principals, err := repo.ListPrincipals(cmd.Context(), o.targetRef)
+	if err != nil {
+		return err
+	}
+
+	if o.json {
+		principalsJSON, err := json.MarshalIndent(principals, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(principalsJSON))
+		return nil
+	}
+
+	for _, principal := range principals {
+		fmt.Printf("Principal %s:\n", principal.KeyID)
+		fmt.Printf("    Key type: %s\n", principal.KeyType)
+		if len(principal.Roles) == 0 {
+			fmt.Println("    Authorized for: none")
+			continue
+		}
+		fmt.Printf("    Authorized for: %s\n", strings.Join(principal.Roles, ", "))
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "list-principals",
+		Short:             "List principals trusted in the current state",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
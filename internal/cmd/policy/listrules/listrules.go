@@ -3,6 +3,7 @@
 package listrules
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 
 type options struct {
 	targetRef string
+	json      bool
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -21,6 +23,13 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"policy",
 		"specify which policy ref should be inspected",
 	)
+
+	cmd.Flags().BoolVar(
+		&o.json,
+		"json",
+		false,
+		"print rules as JSON",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, _ []string) error {
@@ -34,6 +43,15 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	if o.json {
+		rulesJSON, err := json.MarshalIndent(rules, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(rulesJSON))
+		return nil
+	}
+
 	// Iterate through the rules, they are already in order, and the depth tells us how to indent.
 	// The order is a pre-order traversal of the delegation tree, so that the parent is always before the children.
 
@@ -66,6 +84,18 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		}
 
 		fmt.Println(strings.Repeat("    ", curRule.Depth+1) + fmt.Sprintf("Required valid signatures: %d", curRule.Delegation.Role.Threshold))
+		if curRule.Delegation.RequireSignedCommits {
+			fmt.Println(strings.Repeat("    ", curRule.Depth+1) + "Requires signed commits: true")
+		}
+		if curRule.Delegation.InTotoLayout != nil {
+			fmt.Println(strings.Repeat("    ", curRule.Depth+1) + "Requires in-toto layout: true")
+		}
+		if curRule.Delegation.EnginePolicy != nil {
+			fmt.Println(strings.Repeat("    ", curRule.Depth+1) + fmt.Sprintf("Requires %s engine policy: true", curRule.Delegation.EnginePolicy.Engine))
+		}
+		if curRule.Delegation.CommitConstraints != nil {
+			fmt.Println(strings.Repeat("    ", curRule.Depth+1) + "Requires commit constraints: true")
+		}
 	}
 	return nil
 }
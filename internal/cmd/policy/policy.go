@@ -3,12 +3,33 @@
 package policy
 
 import (
+	"github.com/gittuf/gittuf/internal/cmd/policy/addforgeprincipal"
 	"github.com/gittuf/gittuf/internal/cmd/policy/addkey"
+	"github.com/gittuf/gittuf/internal/cmd/policy/addprincipal"
 	"github.com/gittuf/gittuf/internal/cmd/policy/addrule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/describerule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/diff"
+	"github.com/gittuf/gittuf/internal/cmd/policy/exporttuf"
+	"github.com/gittuf/gittuf/internal/cmd/policy/importcodeowners"
+	"github.com/gittuf/gittuf/internal/cmd/policy/importgithub"
 	i "github.com/gittuf/gittuf/internal/cmd/policy/init"
+	"github.com/gittuf/gittuf/internal/cmd/policy/listpending"
+	"github.com/gittuf/gittuf/internal/cmd/policy/listprincipals"
 	"github.com/gittuf/gittuf/internal/cmd/policy/listrules"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/cmd/policy/proposal"
+	"github.com/gittuf/gittuf/internal/cmd/policy/refreshexpirations"
+	"github.com/gittuf/gittuf/internal/cmd/policy/refreshforgeprincipals"
+	"github.com/gittuf/gittuf/internal/cmd/policy/removeprincipal"
 	"github.com/gittuf/gittuf/internal/cmd/policy/removerule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/removesubmodule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/reorderrules"
+	"github.com/gittuf/gittuf/internal/cmd/policy/requiresignedcommits"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setcommitconstraints"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setenginepolicy"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setintotolayout"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setruleprincipals"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setsubmodule"
 	"github.com/gittuf/gittuf/internal/cmd/policy/sign"
 	"github.com/gittuf/gittuf/internal/cmd/policy/updaterule"
 	"github.com/gittuf/gittuf/internal/cmd/trustpolicy/remote"
@@ -25,11 +46,32 @@ func New() *cobra.Command {
 	o.AddPersistentFlags(cmd)
 
 	cmd.AddCommand(i.New(o))
+	cmd.AddCommand(addforgeprincipal.New(o))
 	cmd.AddCommand(addkey.New(o))
+	cmd.AddCommand(addprincipal.New(o))
 	cmd.AddCommand(addrule.New(o))
+	cmd.AddCommand(describerule.New())
+	cmd.AddCommand(diff.New())
+	cmd.AddCommand(exporttuf.New())
+	cmd.AddCommand(importcodeowners.New(o))
+	cmd.AddCommand(importgithub.New(o))
+	cmd.AddCommand(listpending.New())
+	cmd.AddCommand(listprincipals.New())
 	cmd.AddCommand(listrules.New())
+	cmd.AddCommand(proposal.New(o))
+	cmd.AddCommand(refreshexpirations.New(o))
+	cmd.AddCommand(refreshforgeprincipals.New(o))
 	cmd.AddCommand(remote.New())
+	cmd.AddCommand(removeprincipal.New(o))
 	cmd.AddCommand(removerule.New(o))
+	cmd.AddCommand(removesubmodule.New(o))
+	cmd.AddCommand(reorderrules.New(o))
+	cmd.AddCommand(requiresignedcommits.New(o))
+	cmd.AddCommand(setcommitconstraints.New(o))
+	cmd.AddCommand(setenginepolicy.New(o))
+	cmd.AddCommand(setintotolayout.New(o))
+	cmd.AddCommand(setruleprincipals.New(o))
+	cmd.AddCommand(setsubmodule.New(o))
 	cmd.AddCommand(sign.New(o))
 	cmd.AddCommand(updaterule.New(o))
 
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package create
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	policyName   string
+	metadataPath string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to propose",
+	)
+
+	cmd.Flags().StringVar(
+		&o.metadataPath,
+		"metadata",
+		"",
+		"path to the proposed metadata envelope (defaults to the staged policy file)",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	var env *sslibdsse.Envelope
+	if o.metadataPath != "" {
+		contents, err := os.ReadFile(o.metadataPath)
+		if err != nil {
+			return err
+		}
+
+		env = &sslibdsse.Envelope{}
+		if err := json.Unmarshal(contents, env); err != nil {
+			return err
+		}
+	}
+
+	proposalID, err := repo.CreatePolicyProposal(cmd.Context(), o.policyName, env, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(proposalID)
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "create",
+		Short:             "Propose a policy file to collect signatures for",
+		Long:              `The 'create' command records a proposal for the specified policy file and prints the proposal's ID. By default, the staged version of the policy file is proposed. Alternatively, an unsigned or partially signed metadata envelope can be proposed using "--metadata".`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
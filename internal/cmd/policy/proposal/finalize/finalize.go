@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package finalize
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.FinalizePolicyProposal(cmd.Context(), args[0], true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "finalize <proposal ID>",
+		Short:             "Stage a policy proposal signed by a threshold of keys",
+		Long:              `The 'finalize' command checks that the specified policy proposal is signed by a threshold of the keys trusted for the policy file, and stages the proposed policy file. The staged policy can then be applied using "gittuf apply".`,
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(_ *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	proposals, err := repo.ListPolicyProposals()
+	if err != nil {
+		return err
+	}
+
+	if len(proposals) == 0 {
+		fmt.Println("No policy proposals")
+		return nil
+	}
+
+	for _, proposal := range proposals {
+		fmt.Printf("Proposal %s for policy file %s\n", proposal.ID, proposal.RoleName)
+		for _, signature := range proposal.Envelope.Signatures {
+			fmt.Printf("    Signed by: %s\n", signature.KeyID)
+		}
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List open policy proposals",
+		Long:              "The 'list' command lists the open policy proposals along with the keys that have signed each of them.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
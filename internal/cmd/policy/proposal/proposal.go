@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package proposal
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/cmd/policy/proposal/create"
+	"github.com/gittuf/gittuf/internal/cmd/policy/proposal/finalize"
+	"github.com/gittuf/gittuf/internal/cmd/policy/proposal/list"
+	"github.com/gittuf/gittuf/internal/cmd/policy/proposal/sign"
+	"github.com/spf13/cobra"
+)
+
+func New(persistent *persistent.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "proposal",
+		Short:             "Tools to collect signatures on proposed policy changes",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(create.New())
+	cmd.AddCommand(finalize.New())
+	cmd.AddCommand(list.New())
+	cmd.AddCommand(sign.New(persistent))
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package refreshexpirations
+
+import (
+	"time"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p        *persistent.Options
+	validity time.Duration
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(
+		&o.validity,
+		"validity",
+		policy.DefaultMetadataValidity,
+		"duration from now for which refreshed metadata is valid",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.RefreshPolicyExpirations(cmd.Context(), signer, o.validity, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "refresh-expirations",
+		Short:             "Extend the expiry of policy metadata signed by the signing key",
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
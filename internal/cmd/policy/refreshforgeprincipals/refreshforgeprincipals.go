@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package refreshforgeprincipals
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/forge"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	githubURL  string
+	gitlabURL  string
+	check      bool
+	json       bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to refresh principals in",
+	)
+
+	cmd.Flags().StringVar(
+		&o.githubURL,
+		"github-url",
+		"",
+		"URL of a GitHub Enterprise Server instance",
+	)
+
+	cmd.Flags().StringVar(
+		&o.gitlabURL,
+		"gitlab-url",
+		"",
+		"URL of a self-hosted GitLab instance",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.check,
+		"check",
+		false,
+		"only report drift between pinned and published keys without updating the policy",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.json,
+		"json",
+		false,
+		"print drift as JSON",
+	)
+}
+
+func (o *options) PreRunE(cmd *cobra.Command, args []string) error {
+	if o.check {
+		return nil
+	}
+
+	return common.CheckIfSigningViableWithFlag(cmd, args)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	resolvers := map[string]forge.KeyResolver{}
+	for forgeName, forgeURL := range map[string]string{forge.GitHub: o.githubURL, forge.GitLab: o.gitlabURL} {
+		resolver, err := forge.NewKeyResolver(forgeName, forgeURL)
+		if err != nil {
+			return err
+		}
+		resolvers[forgeName] = resolver
+	}
+
+	drifts, err := o.refresh(cmd, repo, resolvers)
+	if err != nil {
+		return err
+	}
+
+	if o.json {
+		driftsJSON, err := json.MarshalIndent(drifts, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(driftsJSON))
+		return nil
+	}
+
+	for _, drift := range drifts {
+		fmt.Printf("Principal %s (%s):\n", drift.PrincipalName, drift.ForgeAccount)
+		if !drift.HasDrift() {
+			fmt.Println("    Pinned keys match published keys")
+			continue
+		}
+		if len(drift.AddedKeyIDs) > 0 {
+			fmt.Printf("    Published but not pinned: %s\n", strings.Join(drift.AddedKeyIDs, ", "))
+		}
+		if len(drift.RemovedKeyIDs) > 0 {
+			fmt.Printf("    Pinned but no longer published: %s\n", strings.Join(drift.RemovedKeyIDs, ", "))
+		}
+	}
+
+	return nil
+}
+
+func (o *options) refresh(cmd *cobra.Command, repo *repository.Repository, resolvers map[string]forge.KeyResolver) ([]*repository.ForgePrincipalDrift, error) {
+	if o.check {
+		return repo.CheckForgePrincipals(cmd.Context(), o.policyName, resolvers)
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.RefreshForgePrincipals(cmd.Context(), signer, o.policyName, resolvers, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "refresh-forge-principals",
+		Short:             "Update principals added from GitHub or GitLab to match the keys currently published",
+		Long:              `This command allows users to compare the keys pinned for principals added using the "add-forge-principal" command with the keys the corresponding users currently publish on GitHub or GitLab. Any drift is reported, and the pinned keys are updated to match the published keys unless the "--check" flag is set. Currently, API tokens are read from the GITHUB_TOKEN and GITLAB_TOKEN environment variables.`,
+		PreRunE:           o.PreRunE,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
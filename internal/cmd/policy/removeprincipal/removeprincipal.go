@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removeprincipal
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p             *persistent.Options
+	policyName    string
+	principalName string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to remove principal from",
+	)
+
+	cmd.Flags().StringVar(
+		&o.principalName,
+		"principal-name",
+		"",
+		"name of principal",
+	)
+	cmd.MarkFlagRequired("principal-name") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemovePrincipal(cmd.Context(), signer, o.policyName, o.principalName, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "remove-principal",
+		Short:             "Remove a principal from a policy file",
+		Long:              `This command allows users to remove a named principal from the specified policy file. A principal that is trusted by a rule cannot be removed.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
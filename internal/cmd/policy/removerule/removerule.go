@@ -3,8 +3,6 @@
 package removerule
 
 import (
-	"os"
-
 	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
 	"github.com/gittuf/gittuf/internal/policy"
@@ -41,7 +39,7 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	keyBytes, err := os.ReadFile(o.p.SigningKey)
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
 	if err != nil {
 		return err
 	}
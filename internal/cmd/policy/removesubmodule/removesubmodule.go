@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removesubmodule
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p    *persistent.Options
+	path string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.path,
+		"path",
+		"",
+		"path of submodule in the repository",
+	)
+	cmd.MarkFlagRequired("path") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveSubmodulePolicy(cmd.Context(), signer, o.path, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "remove-submodule",
+		Short:             "Stop verifying updates to a submodule's pointer",
+		Long:              `This command removes the policy for the specified submodule from the main policy file, so that updates to its pointer are no longer verified against the submodule's repository.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setcommitconstraints
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p              *persistent.Options
+	policyName     string
+	ruleName       string
+	requireSignOff bool
+	messagePattern string
+	noMergeCommits bool
+	remove         bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().BoolVar(
+		&o.requireSignOff,
+		"require-sign-off",
+		false,
+		"require a Signed-off-by trailer for the author of each commit",
+	)
+
+	cmd.Flags().StringVar(
+		&o.messagePattern,
+		"message-pattern",
+		"",
+		"regular expression the first line of each commit message must match",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.noMergeCommits,
+		"no-merge-commits",
+		false,
+		"disallow merge commits",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.remove,
+		"remove",
+		false,
+		"remove the commit constraints from the rule",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("remove", "require-sign-off")
+	cmd.MarkFlagsMutuallyExclusive("remove", "message-pattern")
+	cmd.MarkFlagsMutuallyExclusive("remove", "no-merge-commits")
+	cmd.MarkFlagsOneRequired("remove", "require-sign-off", "message-pattern", "no-merge-commits")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	if o.remove {
+		return repo.RemoveCommitConstraints(cmd.Context(), signer, o.policyName, o.ruleName, true)
+	}
+
+	constraints := &tuf.CommitConstraints{
+		RequireSignOff: o.requireSignOff,
+		MessagePattern: o.messagePattern,
+		NoMergeCommits: o.noMergeCommits,
+	}
+
+	return repo.SetCommitConstraints(cmd.Context(), signer, o.policyName, o.ruleName, constraints, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-commit-constraints",
+		Short:             "Require commits introduced to refs protected by a rule to meet constraints on their metadata",
+		Long:              `This command configures a rule in the specified policy file so that every commit introduced to the Git references protected by the rule must meet the specified constraints. The commits are identified by walking the history between consecutive RSL entries for each reference during verification. Use --require-sign-off to require a Signed-off-by trailer for each commit's author, as used for the Developer Certificate of Origin. Use --message-pattern to require the first line of each commit message to match a regular expression, for example '^(feat|fix|docs|chore)(\(.+\))?!?: ' for conventional commits. Use --no-merge-commits to require a linear history. The specified constraints replace any constraints already set for the rule. By default, the main policy file is selected. Use --remove to stop requiring the constraints.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
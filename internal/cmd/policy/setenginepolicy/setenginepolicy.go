@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setenginepolicy
+
+import (
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p            *persistent.Options
+	policyName   string
+	ruleName     string
+	engine       string
+	enginePolicy string
+	remove       bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.engine,
+		"engine",
+		policy.RegoEngineName,
+		"policy engine used to evaluate the policy",
+	)
+
+	cmd.Flags().StringVar(
+		&o.enginePolicy,
+		"engine-policy",
+		"",
+		"path to policy evaluated by the policy engine",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.remove,
+		"remove",
+		false,
+		"remove the engine policy from the rule",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("remove", "engine-policy")
+	cmd.MarkFlagsOneRequired("remove", "engine-policy")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	if o.remove {
+		return repo.RemoveEnginePolicy(cmd.Context(), signer, o.policyName, o.ruleName, true)
+	}
+
+	source, err := os.ReadFile(o.enginePolicy)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetEnginePolicy(cmd.Context(), signer, o.policyName, o.ruleName, o.engine, string(source), true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-engine-policy",
+		Short:             "Require changes protected by a rule to be allowed by a policy evaluated by a policy engine",
+		Long:              `This command configures a rule in the specified policy file so that every change protected by the rule must be allowed by the specified policy before its RSL entry is considered verified. The policy is embedded in the policy file and evaluated by the selected policy engine against the details of each change: the rule, the ref, the RSL entry, the changed paths, the metadata of the commits introduced, and a summary of the attestations recorded for the change. By default, policies are written in Rego and evaluated using Open Policy Agent. Rego policies must declare the "gittuf" package and allow a change by setting "allow" to true. Built-in functions that access the network or return different results each time they are called cannot be used, and each evaluation is limited in time. By default, the main policy file is selected. Use --remove to stop requiring the policy.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setintotolayout
+
+import (
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	ruleName   string
+	layout     string
+	layoutKeys []string
+	remove     bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.layout,
+		"layout",
+		"",
+		"path to signed in-toto layout",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.layoutKeys,
+		"layout-key",
+		[]string{},
+		"public key that must have signed the layout",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.remove,
+		"remove",
+		false,
+		"remove the in-toto layout from the rule",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("remove", "layout")
+	cmd.MarkFlagsMutuallyExclusive("remove", "layout-key")
+	cmd.MarkFlagsOneRequired("remove", "layout")
+	cmd.MarkFlagsRequiredTogether("layout", "layout-key")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	if o.remove {
+		return repo.RemoveInTotoLayout(cmd.Context(), signer, o.policyName, o.ruleName, true)
+	}
+
+	layout, err := os.ReadFile(o.layout)
+	if err != nil {
+		return err
+	}
+
+	layoutKeys := make([]*tuf.Key, 0, len(o.layoutKeys))
+	for _, key := range o.layoutKeys {
+		layoutKey, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+		layoutKeys = append(layoutKeys, layoutKey)
+	}
+
+	return repo.SetInTotoLayout(cmd.Context(), signer, o.policyName, o.ruleName, layout, layoutKeys, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-in-toto-layout",
+		Short:             "Require changes protected by a rule to pass verification using an in-toto layout",
+		Long:              `This command configures a rule in the specified policy file so that every change protected by the rule must pass verification using the specified in-toto layout before its RSL entry is considered verified. The layout must be signed by all of the specified layout keys. The link metadata for the layout's steps is loaded from the attestations recorded for each change. Layouts with inspections are not supported, and sublayouts are rejected during verification. By default, the main policy file is selected. Use --remove to stop requiring the layout. The layout's expiry is checked when it is set, and the layout is trusted for as long as the policy records it.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
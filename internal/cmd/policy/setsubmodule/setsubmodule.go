@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setsubmodule
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p            *persistent.Options
+	path         string
+	location     string
+	refName      string
+	verification string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.path,
+		"path",
+		"",
+		"path of submodule in the repository",
+	)
+	cmd.MarkFlagRequired("path") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.location,
+		"location",
+		"",
+		"location of submodule's gittuf-enabled repository, such as its URL",
+	)
+	cmd.MarkFlagRequired("location") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.refName,
+		"ref",
+		"",
+		"only consider RSL entries for the specified ref in the submodule's repository for remote checks",
+	)
+
+	cmd.Flags().StringVar(
+		&o.verification,
+		"verification",
+		tuf.SubmoduleVerificationPropagation,
+		"how submodule pointer updates are verified ('propagation' or 'remote')",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetSubmodulePolicy(cmd.Context(), signer, o.path, o.location, o.refName, o.verification, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-submodule",
+		Short:             "Configure how updates to a submodule's pointer are verified",
+		Long:              `This command configures the main policy file so that every update to the pointer of the specified submodule must correspond to a verified RSL entry in the submodule's own gittuf-enabled repository. With 'propagation' verification, the update must be recorded by a propagation entry from the submodule's repository in this repository's RSL. With 'remote' verification, the submodule's repository is fetched during verification and the RSL entry that recorded the new pointer is verified against the submodule's policy. Submodules are checked when 'gittuf verify-ref' is invoked with '--verify-submodules'.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -3,21 +3,28 @@
 package root
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 
+	"github.com/gittuf/gittuf/internal/audit"
 	"github.com/gittuf/gittuf/internal/cmd/addhooks"
 	"github.com/gittuf/gittuf/internal/cmd/apply"
+	auditcmd "github.com/gittuf/gittuf/internal/cmd/audit"
 	"github.com/gittuf/gittuf/internal/cmd/clone"
 	"github.com/gittuf/gittuf/internal/cmd/dev"
+	"github.com/gittuf/gittuf/internal/cmd/doctor"
 	"github.com/gittuf/gittuf/internal/cmd/policy"
 	"github.com/gittuf/gittuf/internal/cmd/profile"
-	"github.com/gittuf/gittuf/internal/cmd/rsl"
+	rslcmd "github.com/gittuf/gittuf/internal/cmd/rsl"
 	"github.com/gittuf/gittuf/internal/cmd/trust"
 	"github.com/gittuf/gittuf/internal/cmd/verifycommit"
+	"github.com/gittuf/gittuf/internal/cmd/verifynetwork"
 	"github.com/gittuf/gittuf/internal/cmd/verifyref"
 	"github.com/gittuf/gittuf/internal/cmd/verifytag"
 	"github.com/gittuf/gittuf/internal/cmd/version"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +33,8 @@ type options struct {
 	profile           bool
 	cpuProfileFile    string
 	memoryProfileFile string
+	auditLog          string
+	deterministicRSL  bool
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -56,6 +65,20 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"memory.prof",
 		"file to store memory profile",
 	)
+
+	cmd.PersistentFlags().StringVar(
+		&o.auditLog,
+		"audit-log",
+		"",
+		fmt.Sprintf("record gittuf write operations in the specified audit log (default: value of %s)", audit.LogPathKey),
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&o.deterministicRSL,
+		"deterministic-rsl",
+		false,
+		fmt.Sprintf("create RSL entries with normalized committer metadata and timestamps derived from the parent entry (default: value of %s)", rsl.DeterministicEntriesKey),
+	)
 }
 
 func (o *options) PreRunE(_ *cobra.Command, _ []string) error {
@@ -70,6 +93,28 @@ func (o *options) PreRunE(_ *cobra.Command, _ []string) error {
 		Level: level,
 	})))
 
+	// Record write operations if an audit log is configured
+	auditLog := o.auditLog
+	if auditLog == "" {
+		auditLog = os.Getenv(audit.LogPathKey)
+	}
+	if auditLog != "" {
+		if _, err := audit.Enable(auditLog); err != nil {
+			return err
+		}
+	}
+
+	// Create reproducible RSL entries if requested
+	deterministicRSL := o.deterministicRSL
+	if value := os.Getenv(rsl.DeterministicEntriesKey); !deterministicRSL && value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w", rsl.DeterministicEntriesKey, err)
+		}
+		deterministicRSL = enabled
+	}
+	rsl.DeterministicEntries = deterministicRSL
+
 	// Start profiling if flag is set
 	if o.profile {
 		return profile.StartProfiling(o.cpuProfileFile, o.memoryProfileFile)
@@ -92,12 +137,15 @@ func New() *cobra.Command {
 
 	cmd.AddCommand(addhooks.New())
 	cmd.AddCommand(apply.New())
+	cmd.AddCommand(auditcmd.New())
 	cmd.AddCommand(clone.New())
 	cmd.AddCommand(dev.New())
+	cmd.AddCommand(doctor.New())
 	cmd.AddCommand(trust.New())
 	cmd.AddCommand(policy.New())
-	cmd.AddCommand(rsl.New())
+	cmd.AddCommand(rslcmd.New())
 	cmd.AddCommand(verifycommit.New())
+	cmd.AddCommand(verifynetwork.New())
 	cmd.AddCommand(verifyref.New())
 	cmd.AddCommand(verifytag.New())
 	cmd.AddCommand(version.New())
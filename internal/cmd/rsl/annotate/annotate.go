@@ -10,6 +10,7 @@ import (
 
 type options struct {
 	skip    bool
+	unskip  bool
 	message string
 }
 
@@ -22,6 +23,13 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"mark annotated entries as to be skipped",
 	)
 
+	cmd.Flags().BoolVar(
+		&o.unskip,
+		"unskip",
+		false,
+		"reinstate annotated entries that were previously skipped",
+	)
+
 	cmd.Flags().StringVarP(
 		&o.message,
 		"message",
@@ -30,6 +38,8 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"annotation message",
 	)
 	cmd.MarkFlagRequired("message") //nolint:errcheck
+
+	cmd.MarkFlagsMutuallyExclusive("skip", "unskip")
 }
 
 func (o *options) Run(_ *cobra.Command, args []string) error {
@@ -38,6 +48,10 @@ func (o *options) Run(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	if o.unskip {
+		return repo.RecordRSLUnskipAnnotation(args, o.message, true)
+	}
+
 	return repo.RecordRSLAnnotation(args, o.skip, o.message, true)
 }
 
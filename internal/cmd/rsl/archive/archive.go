@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/rsl/archive/create"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/archive/verify"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "archive",
+		Short:             "Tools for archiving old RSL entries",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(create.New())
+	cmd.AddCommand(verify.New())
+
+	return cmd
+}
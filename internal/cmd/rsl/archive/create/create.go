@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package create
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	bundlePath string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.bundlePath,
+		"bundle",
+		"",
+		"path to write a bundle containing the archive and the archived entries",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.ArchiveRSL(cmd.Context(), args[0], o.bundlePath)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "create <checkpoint entry ID>",
+		Short:             "Archive RSL entries before the specified checkpoint entry",
+		Long:              "The 'create' command verifies all refs as of the specified checkpoint entry and records a snapshot of their state. Subsequent verification starts at the checkpoint, trusting the snapshot, rather than walking the archived entries. The archive is local to the repository and is not synced with remotes.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	bundlePath string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.bundlePath,
+		"bundle",
+		"",
+		"path to a bundle written by 'gittuf rsl archive create' to validate instead of the repository's archive",
+	)
+}
+
+func (o *options) Run(_ *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.VerifyRSLArchive(o.bundlePath)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "verify",
+		Short:             "Validate the RSL archive against the RSL",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
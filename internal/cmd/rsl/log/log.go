@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	refName      string
+	limit        int
+	reverse      bool
+	startEntryID string
+	jsonOutput   bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.refName,
+		"ref",
+		"",
+		"only list entries for the specified Git reference",
+	)
+
+	cmd.Flags().IntVarP(
+		&o.limit,
+		"limit",
+		"n",
+		0,
+		"maximum number of entries to list",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.reverse,
+		"reverse",
+		false,
+		"list entries starting from the first entry in the RSL",
+	)
+
+	cmd.Flags().StringVar(
+		&o.startEntryID,
+		"start",
+		"",
+		"ID of the entry to start listing from",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print entries as JSON",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	log, err := repo.ListRSLEntries(cmd.Context(), &repository.ListRSLEntriesOptions{
+		RefName:      o.refName,
+		Limit:        o.limit,
+		Reverse:      o.reverse,
+		StartEntryID: o.startEntryID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.jsonOutput {
+		logJSON, err := json.MarshalIndent(log, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(logJSON))
+		return nil
+	}
+
+	for _, entry := range log.Entries {
+		fmt.Printf("%s %s\n", entry.Type, entry.ID)
+		if entry.Number != 0 {
+			fmt.Printf("    Number:      %d\n", entry.Number)
+		}
+
+		switch entry.Type {
+		case repository.RSLLogReferenceEntry, repository.RSLLogPropagationEntry:
+			fmt.Printf("    Ref:         %s\n", entry.RefName)
+			fmt.Printf("    Target:      %s\n", entry.TargetID)
+			if entry.Deleted {
+				fmt.Printf("    Deleted:     true\n")
+			}
+			if entry.Actor != "" {
+				fmt.Printf("    Actor:       %s\n", entry.Actor)
+			}
+			if entry.ActorURL != "" {
+				fmt.Printf("    Actor URL:   %s\n", entry.ActorURL)
+			}
+			if entry.Skipped {
+				fmt.Printf("    Skipped:     true\n")
+			}
+			if entry.Type == repository.RSLLogPropagationEntry {
+				fmt.Printf("    Upstream:    %s (%s)\n", entry.UpstreamRepository, entry.UpstreamEntryID)
+			}
+		case repository.RSLLogAnnotationEntry:
+			fmt.Printf("    Entries:     %s\n", strings.Join(entry.AnnotatedEntryIDs, ", "))
+			if entry.Skip {
+				fmt.Printf("    Skip:        true\n")
+			}
+			if entry.Unskip {
+				fmt.Printf("    Unskip:      true\n")
+			}
+			fmt.Printf("    Message:     %s\n", entry.Message)
+		}
+
+		if entry.SignerKeyID != "" {
+			fmt.Printf("    Signer:      %s\n", entry.SignerKeyID)
+		}
+
+		for _, annotation := range entry.Annotations {
+			fmt.Printf("    Annotation:  %s\n", annotation.ID)
+			if annotation.Skip {
+				fmt.Printf("        Skip:    true\n")
+			}
+			if annotation.Unskip {
+				fmt.Printf("        Unskip:  true\n")
+			}
+			fmt.Printf("        Message: %s\n", annotation.Message)
+		}
+
+		fmt.Println()
+	}
+
+	if log.NextEntryID != "" {
+		fmt.Printf("More entries available, use --start %s to continue\n", log.NextEntryID)
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "log",
+		Short:             "List the entries in the repository's reference state log",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
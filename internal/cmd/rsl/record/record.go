@@ -3,20 +3,78 @@
 package record
 
 import (
+	"errors"
+
 	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/repository"
 	"github.com/spf13/cobra"
 )
 
-type options struct{}
+var ErrUnsupportedActor = errors.New("actor metadata can only be recorded for a single Git reference that is not deleted")
+
+type options struct {
+	deleted  bool
+	actor    string
+	actorURL string
+	force    bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.deleted,
+		"deleted",
+		false,
+		"record the deletion of the specified Git references",
+	)
 
-func (o *options) AddFlags(_ *cobra.Command) {}
+	cmd.Flags().StringVar(
+		&o.actor,
+		"actor",
+		"",
+		"identity of who or what pushed the Git reference, such as a forge username",
+	)
+
+	cmd.Flags().StringVar(
+		&o.actorURL,
+		"actor-url",
+		"",
+		"URL for the context of the push, such as a CI job URL",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.force,
+		"force",
+		false,
+		"record the entries with a warning even if the signing key is not trusted by the rules protecting the Git references",
+	)
+}
 
 func (o *options) Run(_ *cobra.Command, args []string) error {
 	repo, err := repository.LoadRepository()
 	if err != nil {
 		return err
 	}
+	repo.SetAllowUnauthorizedRSLSigner(o.force)
+
+	if o.actor != "" || o.actorURL != "" {
+		if o.deleted || len(args) > 1 {
+			return ErrUnsupportedActor
+		}
+		return repo.RecordRSLEntryForReferenceWithActor(args[0], o.actor, o.actorURL, true)
+	}
+
+	if o.deleted {
+		for _, refName := range args {
+			if err := repo.RecordRSLEntryForReferenceDeletion(refName, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(args) > 1 {
+		return repo.RecordRSLEntriesForReferences(args, true)
+	}
 
 	return repo.RecordRSLEntryForReference(args[0], true)
 }
@@ -25,8 +83,8 @@ func New() *cobra.Command {
 	o := &options{}
 	cmd := &cobra.Command{
 		Use:               "record",
-		Short:             "Record latest state of a Git reference in the RSL",
-		Args:              cobra.ExactArgs(1),
+		Short:             "Record latest state of one or more Git references in the RSL",
+		Args:              cobra.MinimumNArgs(1),
 		PreRunE:           common.CheckIfSigningViable,
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.Sync(cmd.Context(), args[0])
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "sync <remote>",
+		Short:             "Verify and fast-forward to the RSL at the specified remote",
+		Long:              "The 'sync' command fetches the RSL from the specified remote and verifies the new entries against policy before fast-forwarding the local RSL. The local RSL is left unchanged if verification fails or if the local and remote RSLs have diverged.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
@@ -4,8 +4,11 @@ package rsl
 
 import (
 	"github.com/gittuf/gittuf/internal/cmd/rsl/annotate"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/archive"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/log"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/record"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/remote"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/skipimpact"
 	"github.com/spf13/cobra"
 )
 
@@ -17,8 +20,11 @@ func New() *cobra.Command {
 	}
 
 	cmd.AddCommand(annotate.New())
+	cmd.AddCommand(archive.New())
+	cmd.AddCommand(log.New())
 	cmd.AddCommand(record.New())
 	cmd.AddCommand(remote.New())
+	cmd.AddCommand(skipimpact.New())
 
 	return cmd
 }
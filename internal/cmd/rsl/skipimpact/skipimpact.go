@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package skipimpact
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+var ErrInvalidArguments = errors.New("specify either RSL entry IDs or an annotation ID")
+
+type options struct {
+	annotationID string
+	jsonOutput   bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.annotationID,
+		"annotation",
+		"",
+		"ID of an existing skip annotation to analyze",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print impact as JSON",
+	)
+}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	if (o.annotationID == "") == (len(args) == 0) {
+		return ErrInvalidArguments
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	var impact *repository.SkipImpact
+	if o.annotationID != "" {
+		impact, err = repo.AnalyzeSkipAnnotationImpact(o.annotationID)
+	} else {
+		impact, err = repo.AnalyzeSkipImpact(args)
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.jsonOutput {
+		impactJSON, err := json.MarshalIndent(impact, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(impactJSON))
+		return nil
+	}
+
+	for _, entryID := range impact.AlreadySkippedEntryIDs {
+		fmt.Printf("Entry %s is already skipped\n", entryID)
+	}
+	if len(impact.SkippedEntryIDs) == 0 {
+		fmt.Println("No entries are newly skipped")
+		return nil
+	}
+
+	if impact.PolicyAffected {
+		fmt.Println("Skipping changes the policy or attestations used to verify subsequent entries")
+	}
+
+	if len(impact.RefRollbacks) != 0 {
+		fmt.Println("Refs rolled back:")
+		for _, rollback := range impact.RefRollbacks {
+			if rollback.RolledBackTargetID == "" {
+				fmt.Printf("    %s: %s -> no unskipped entries\n", rollback.RefName, rollback.CurrentTargetID)
+				continue
+			}
+			fmt.Printf("    %s: %s -> %s\n", rollback.RefName, rollback.CurrentTargetID, rollback.RolledBackTargetID)
+		}
+	}
+
+	if len(impact.DependentEntries) != 0 {
+		fmt.Println("Dependent entries:")
+		for _, dependent := range impact.DependentEntries {
+			fmt.Printf("    %s", dependent.EntryID)
+			if dependent.RefName != "" {
+				fmt.Printf(" (%s)", dependent.RefName)
+			}
+			fmt.Printf(": %s %s\n", dependent.Reason, dependent.SkippedEntryID)
+		}
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "skip-impact [<entryID>...]",
+		Short:             "Analyze the impact of skipping RSL entries",
+		Long:              "The 'skip-impact' command reports what changes if the specified RSL entries are skipped, without recording an annotation: the refs whose latest unskipped state is rolled back, the subsequent entries that relied on the skipped entries, and whether the policy used to verify subsequent entries changes. Alternatively, the impact of an existing skip annotation can be analyzed using --annotation.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
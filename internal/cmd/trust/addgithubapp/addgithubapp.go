@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addgithubapp
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p      *persistent.Options
+	appKey string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.appKey,
+		"app-key",
+		"",
+		"GitHub app key to add to root of trust",
+	)
+	cmd.MarkFlagRequired("app-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	rootKeyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(rootKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	appKey, err := common.LoadPublicKey(o.appKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddGitHubApp(cmd.Context(), signer, appKey, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-github-app",
+		Short:             "Add GitHub app key to gittuf root of trust",
+		Long:              `This command allows users to trust the key of a GitHub app to attest to approvals of GitHub pull requests. Approvals attested to by the app count towards the thresholds of rules during verification. Any previously trusted GitHub app key is replaced.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -3,19 +3,34 @@
 package init
 
 import (
-	"os"
-
 	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
 	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/spf13/cobra"
 )
 
 type options struct {
-	p *persistent.Options
+	p         *persistent.Options
+	rootKeys  []string
+	threshold int
 }
 
-func (o *options) AddFlags(_ *cobra.Command) {}
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&o.rootKeys,
+		"root-key",
+		[]string{},
+		"additional root key to trust in the root of trust",
+	)
+
+	cmd.Flags().IntVar(
+		&o.threshold,
+		"threshold",
+		1,
+		"threshold of root keys required to sign root metadata",
+	)
+}
 
 func (o *options) Run(cmd *cobra.Command, _ []string) error {
 	repo, err := repository.LoadRepository()
@@ -23,7 +38,7 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	keyBytes, err := os.ReadFile(o.p.SigningKey)
+	keyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
 	if err != nil {
 		return err
 	}
@@ -32,7 +47,20 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	return repo.InitializeRoot(cmd.Context(), signer, true)
+	if len(o.rootKeys) == 0 && o.threshold == 1 {
+		return repo.InitializeRoot(cmd.Context(), signer, true)
+	}
+
+	rootKeys := []*tuf.Key{}
+	for _, rootKeyPath := range o.rootKeys {
+		rootKey, err := common.LoadPublicKey(rootKeyPath)
+		if err != nil {
+			return err
+		}
+		rootKeys = append(rootKeys, rootKey)
+	}
+
+	return repo.InitializeRootWithKeys(cmd.Context(), signer, rootKeys, o.threshold, true)
 }
 
 func New(persistent *persistent.Options) *cobra.Command {
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removegithubapp
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p *persistent.Options
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	rootKeyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(rootKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveGitHubApp(cmd.Context(), signer, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "remove-github-app",
+		Short:             "Remove GitHub app key from gittuf root of trust",
+		Long:              `This command allows users to stop trusting the GitHub app to attest to approvals of GitHub pull requests.`,
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
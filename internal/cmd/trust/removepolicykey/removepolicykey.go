@@ -3,7 +3,6 @@
 package removepolicykey
 
 import (
-	"os"
 	"strings"
 
 	"github.com/gittuf/gittuf/internal/cmd/common"
@@ -33,7 +32,7 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	rootKeyBytes, err := os.ReadFile(o.p.SigningKey)
+	rootKeyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
 	if err != nil {
 		return err
 	}
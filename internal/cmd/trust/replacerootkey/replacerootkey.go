@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package replacerootkey
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	oldKeyID   string
+	newRootKey string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.oldKeyID,
+		"old-root-key-ID",
+		"",
+		"ID of root key to replace in root of trust",
+	)
+	cmd.MarkFlagRequired("old-root-key-ID") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.newRootKey,
+		"new-root-key",
+		"",
+		"root key to add to root of trust in place of the old key",
+	)
+	cmd.MarkFlagRequired("new-root-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	rootKeyBytes, err := common.ReadKeyBytes(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signer, err := common.LoadSigner(rootKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	newRootKey, err := common.LoadPublicKey(o.newRootKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.ReplaceRootKey(cmd.Context(), signer, o.oldKeyID, newRootKey, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "replace-root-key",
+		Short:             "Replace Root key in gittuf root of trust",
+		PreRunE:           common.CheckIfSigningViableWithFlag,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -3,15 +3,25 @@
 package verifycommit
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/gittuf/gittuf/internal/repository"
 	"github.com/spf13/cobra"
 )
 
-type options struct{}
+type options struct {
+	jsonOutput bool
+}
 
-func (o *options) AddFlags(_ *cobra.Command) {}
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print verification results as JSON",
+	)
+}
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
 	repo, err := repository.LoadRepository()
@@ -19,10 +29,19 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	status := repo.VerifyCommit(cmd.Context(), args...)
+	results := repo.VerifyCommitWithResults(cmd.Context(), args...)
+
+	if o.jsonOutput {
+		resultsJSON, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(resultsJSON))
+		return nil
+	}
 
-	for _, id := range args {
-		fmt.Printf("%s: %s\n", id, status[id])
+	for _, result := range results {
+		fmt.Printf("%s: %s\n", result.ID, result.Message)
 	}
 
 	return nil
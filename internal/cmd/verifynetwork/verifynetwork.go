@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verifynetwork
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	repositoriesFile string
+	policyLocation   string
+	policyRef        string
+	refs             []string
+	latestOnly       bool
+	rootKeys         []string
+	rootThreshold    int
+	jsonOutput       bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.repositoriesFile,
+		"repositories-file",
+		"",
+		"file listing the locations of repositories to verify, one per line",
+	)
+
+	cmd.Flags().StringVar(
+		&o.policyLocation,
+		"policy-location",
+		"",
+		"location of a repository whose policy is shared by all repositories (default: each repository's own policy)",
+	)
+
+	cmd.Flags().StringVar(
+		&o.policyRef,
+		"policy-ref",
+		"",
+		"ref containing the shared policy (default: the standard gittuf policy reference)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.refs,
+		"ref",
+		[]string{},
+		"ref to verify in each repository (default: all refs recorded in each repository's RSL)",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.latestOnly,
+		"latest-only",
+		false,
+		"perform verification against latest entry in the RSL for each ref",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.rootKeys,
+		"root-key",
+		[]string{},
+		"root public key obtained out-of-band that must have signed the initial root of trust",
+	)
+
+	cmd.Flags().IntVar(
+		&o.rootThreshold,
+		"root-threshold",
+		0,
+		"number of root keys specified using --root-key that must have signed the initial root of trust (default: all of them)",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print the report as JSON",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	locations := args
+	if o.repositoriesFile != "" {
+		fileLocations, err := readLocations(o.repositoriesFile)
+		if err != nil {
+			return err
+		}
+		locations = append(locations, fileLocations...)
+	}
+
+	rootKeys := make([]*tuf.Key, 0, len(o.rootKeys))
+	for _, key := range o.rootKeys {
+		rootKey, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+		rootKeys = append(rootKeys, rootKey)
+	}
+
+	report, err := repository.VerifyNetwork(cmd.Context(), locations, &repository.VerifyNetworkOptions{
+		PolicyLocation:            o.policyLocation,
+		PolicyRef:                 o.policyRef,
+		Refs:                      o.refs,
+		LatestOnly:                o.latestOnly,
+		RootTrustAnchors:          rootKeys,
+		RootTrustAnchorsThreshold: o.rootThreshold,
+	})
+	if report == nil {
+		return err
+	}
+
+	if o.jsonOutput {
+		reportJSON, jsonErr := json.MarshalIndent(report, "", "  ")
+		if jsonErr != nil {
+			return jsonErr
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(reportJSON))
+	} else {
+		for _, result := range report.Repositories {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", result.Location, status(result.Verified, result.Error))
+			for _, refResult := range result.Refs {
+				fmt.Fprintf(cmd.OutOrStdout(), "    %s: %s\n", refResult.RefName, status(refResult.Verified, refResult.Error))
+			}
+		}
+	}
+
+	return err
+}
+
+func status(verified bool, errMessage string) string {
+	switch {
+	case verified:
+		return "verified"
+	case errMessage != "":
+		return "failed (" + errMessage + ")"
+	default:
+		return "failed"
+	}
+}
+
+// readLocations reads repository locations from the specified file, ignoring
+// blank lines and lines starting with '#'.
+func readLocations(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	locations := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		locations = append(locations, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "verify-network [location...]",
+		Short:             "Verify a set of repositories, optionally against a shared policy",
+		Long:              "The 'verify-network' command fetches each of the specified repositories into memory and verifies the refs recorded in their RSLs. Repositories can be listed as arguments or in a file. By default, each repository is verified using its own policy. If a policy location is specified, every repository is instead verified against the policy in that repository, which allows an organization to maintain a single policy for many repositories. The results are aggregated into a single report, and the command fails if any repository could not be fetched or any ref could not be verified.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -3,16 +3,44 @@
 package verifyref
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/spf13/cobra"
 )
 
+const (
+	reportFormatJSON  = "json"
+	reportFormatSARIF = "sarif"
+)
+
+var ErrUnknownReportFormat = errors.New("unknown report format, must be one of 'json' or 'sarif'")
+
 type options struct {
-	latestOnly bool
-	fromEntry  string
+	latestOnly    bool
+	fromEntry     string
+	atEntry       string
+	reportFormat  string
+	reportFile    string
+	gracePeriod   time.Duration
+	progress      bool
+	timeout       time.Duration
+	rootKeys      []string
+	rootThreshold int
+	rootPinFile   string
+	fetchRemote   string
+	deepenRemote  string
+	submodules    bool
+	useCache      bool
+	clearCache    bool
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -30,7 +58,117 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		fmt.Sprintf("perform verification from specified RSL entry (developer mode only, set %s=1)", dev.DevModeKey),
 	)
 
+	cmd.Flags().StringVar(
+		&o.atEntry,
+		"at-entry",
+		"",
+		"perform verification of the reference as it was when the specified RSL entry was recorded",
+	)
+
+	cmd.Flags().StringVar(
+		&o.reportFormat,
+		"report-format",
+		"",
+		"write a verification report in the specified format ('json' or 'sarif')",
+	)
+
+	cmd.Flags().StringVar(
+		&o.reportFile,
+		"report-file",
+		"",
+		"path to write the verification report to (default: standard output)",
+	)
+
+	cmd.Flags().DurationVar(
+		&o.gracePeriod,
+		"expiration-grace-period",
+		0,
+		"accept policy metadata for the specified duration past its expiry",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.progress,
+		"progress",
+		false,
+		"print verification progress to standard error",
+	)
+
+	cmd.Flags().DurationVar(
+		&o.timeout,
+		"timeout",
+		0,
+		"abort verification if it takes longer than the specified duration",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.rootKeys,
+		"root-key",
+		[]string{},
+		"root public key obtained out-of-band that must have signed the initial root of trust",
+	)
+
+	cmd.Flags().IntVar(
+		&o.rootThreshold,
+		"root-threshold",
+		0,
+		"number of root keys specified using --root-key that must have signed the initial root of trust (default: all of them)",
+	)
+
+	cmd.Flags().StringVar(
+		&o.rootPinFile,
+		"root-pin-file",
+		"",
+		"file used to pin the initial root keys on first use and verify them subsequently",
+	)
+
+	cmd.Flags().StringVar(
+		&o.fetchRemote,
+		"fetch-missing-from",
+		"",
+		"fetch objects missing locally, such as in partial clones, from the specified remote",
+	)
+
+	cmd.Flags().StringVar(
+		&o.deepenRemote,
+		"deepen-from",
+		"",
+		"fetch the full history from the specified remote if verification reaches the boundary of a shallow clone",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.submodules,
+		"verify-submodules",
+		false,
+		"verify that submodule pointer updates correspond to verified RSL entries in the submodules' repositories, as configured in the policy",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.useCache,
+		"use-cache",
+		false,
+		"skip entries previously verified under the same policy and attestations, and record new results in the local verification cache",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.clearCache,
+		"clear-cache",
+		false,
+		"discard all results in the local verification cache before verifying",
+	)
+
 	cmd.MarkFlagsMutuallyExclusive("latest-only", "from-entry")
+	cmd.MarkFlagsMutuallyExclusive("latest-only", "report-format")
+	cmd.MarkFlagsMutuallyExclusive("from-entry", "report-format")
+	cmd.MarkFlagsMutuallyExclusive("at-entry", "latest-only")
+	cmd.MarkFlagsMutuallyExclusive("at-entry", "from-entry")
+	cmd.MarkFlagsMutuallyExclusive("at-entry", "report-format")
+	cmd.MarkFlagsMutuallyExclusive("root-key", "root-pin-file")
+	cmd.MarkFlagsMutuallyExclusive("verify-submodules", "from-entry")
+	cmd.MarkFlagsMutuallyExclusive("verify-submodules", "at-entry")
+	cmd.MarkFlagsMutuallyExclusive("use-cache", "latest-only")
+	cmd.MarkFlagsMutuallyExclusive("use-cache", "from-entry")
+	cmd.MarkFlagsMutuallyExclusive("use-cache", "at-entry")
+	cmd.MarkFlagsMutuallyExclusive("use-cache", "report-format")
 }
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
@@ -39,15 +177,105 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	repo.SetExpirationGracePeriod(o.gracePeriod)
+
+	rootKeys := make([]*tuf.Key, 0, len(o.rootKeys))
+	for _, key := range o.rootKeys {
+		rootKey, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+		rootKeys = append(rootKeys, rootKey)
+	}
+	repo.SetRootTrustAnchors(rootKeys, o.rootThreshold)
+	repo.SetRootPinFile(o.rootPinFile)
+	repo.SetAutoDeepen(o.deepenRemote)
+
+	ctx := cmd.Context()
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+	if o.fetchRemote != "" {
+		if err := repo.EnableOnDemandFetch(ctx, o.fetchRemote); err != nil {
+			return err
+		}
+	}
+	if o.progress {
+		ctx = policy.WithVerificationProgress(ctx, func(progress policy.VerificationProgress) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Verifying '%s': entry %d of %d (%s), %d signatures checked\n", progress.Target, progress.EntriesProcessed, progress.TotalEntries, progress.EntryID, progress.SignaturesChecked)
+		})
+	}
+
+	if o.clearCache {
+		if err := repo.ClearVerificationCache(); err != nil {
+			return err
+		}
+	}
+
+	err = o.verify(ctx, cmd, repo, args[0])
+	if err == nil && o.submodules {
+		err = repo.VerifySubmodules(ctx, args[0])
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("verification did not complete within %s: %w", o.timeout, err)
+	}
+	return err
+}
+
+func (o *options) verify(ctx context.Context, cmd *cobra.Command, repo *repository.Repository, target string) error {
 	if o.fromEntry != "" {
 		if !dev.InDevMode() {
 			return dev.ErrNotInDevMode
 		}
 
-		return repo.VerifyRefFromEntry(cmd.Context(), args[0], o.fromEntry)
+		return repo.VerifyRefFromEntry(ctx, target, o.fromEntry)
+	}
+
+	if o.atEntry != "" {
+		return repo.VerifyRefAtEntry(ctx, target, o.atEntry)
+	}
+
+	if o.reportFormat != "" {
+		return o.runWithReport(ctx, cmd, repo, target)
+	}
+
+	if o.useCache {
+		return repo.VerifyRefUsingCache(ctx, target)
+	}
+
+	return repo.VerifyRef(ctx, target, o.latestOnly)
+}
+
+// runWithReport performs verification and writes the resulting report. The
+// report is written even if verification fails, after which the verification
+// error is returned.
+func (o *options) runWithReport(ctx context.Context, cmd *cobra.Command, repo *repository.Repository, target string) error {
+	var serialize func(*policy.VerificationReport) ([]byte, error)
+	switch o.reportFormat {
+	case reportFormatJSON:
+		serialize = (*policy.VerificationReport).JSON
+	case reportFormatSARIF:
+		serialize = (*policy.VerificationReport).SARIF
+	default:
+		return ErrUnknownReportFormat
+	}
+
+	report, verificationErr := repo.VerifyRefWithReport(ctx, target)
+
+	reportBytes, err := serialize(report)
+	if err != nil {
+		return err
+	}
+
+	if o.reportFile == "" {
+		fmt.Fprintln(cmd.OutOrStdout(), string(reportBytes))
+	} else if err := os.WriteFile(o.reportFile, reportBytes, 0o644); err != nil { //nolint:gosec
+		return err
 	}
 
-	return repo.VerifyRef(cmd.Context(), args[0], o.latestOnly)
+	return verificationErr
 }
 
 func New() *cobra.Command {
@@ -36,7 +36,9 @@ var (
 			Email: testEmail,
 		},
 	}
-	TestClock = clockwork.NewFakeClockAt(time.Date(1995, time.October, 26, 9, 0, 0, 0, time.UTC))
+	// RSL entries are verified at the time they were recorded, so the test
+	// clock must be after the test GPG keys were created
+	TestClock = clockwork.NewFakeClockAt(time.Date(2024, time.October, 26, 9, 0, 0, 0, time.UTC))
 )
 
 // CreateTestRSLReferenceEntryCommit is a test helper used to create a
@@ -55,6 +57,10 @@ func CreateTestRSLReferenceEntryCommit(t *testing.T, repo *git.Repository, entry
 		fmt.Sprintf("%s: %s", rsl.TargetIDKey, entry.TargetID.String()),
 	}
 
+	if entry.Deleted {
+		lines = append(lines, fmt.Sprintf("%s: true", rsl.DeletedKey))
+	}
+
 	commitMessage := strings.Join(lines, "\n")
 
 	ref, err := repo.Reference(plumbing.ReferenceName(rsl.Ref), true)
@@ -62,16 +68,18 @@ func CreateTestRSLReferenceEntryCommit(t *testing.T, repo *git.Repository, entry
 		t.Fatal(err)
 	}
 
+	entryTime := getTestRSLEntryTime(t, repo, ref.Hash())
+
 	testCommit := &object.Commit{
 		Author: object.Signature{
 			Name:  testName,
 			Email: testEmail,
-			When:  TestClock.Now(),
+			When:  entryTime,
 		},
 		Committer: object.Signature{
 			Name:  testName,
 			Email: testEmail,
-			When:  TestClock.Now(),
+			When:  entryTime,
 		},
 		Message:      commitMessage,
 		TreeHash:     gitinterface.EmptyTree(),
@@ -112,6 +120,14 @@ func CreateTestRSLAnnotationEntryCommit(t *testing.T, repo *git.Repository, anno
 		lines = append(lines, fmt.Sprintf("%s: false", rsl.SkipKey))
 	}
 
+	if annotation.Unskip {
+		lines = append(lines, fmt.Sprintf("%s: true", rsl.UnskipKey))
+	}
+
+	if annotation.Severity != rsl.SeverityNone {
+		lines = append(lines, fmt.Sprintf("%s: %s", rsl.SeverityKey, annotation.Severity))
+	}
+
 	if len(annotation.Message) != 0 {
 		var message strings.Builder
 		messageBlock := pem.Block{
@@ -131,16 +147,18 @@ func CreateTestRSLAnnotationEntryCommit(t *testing.T, repo *git.Repository, anno
 		t.Fatal(err)
 	}
 
+	entryTime := getTestRSLEntryTime(t, repo, ref.Hash())
+
 	testCommit := &object.Commit{
 		Author: object.Signature{
 			Name:  testName,
 			Email: testEmail,
-			When:  TestClock.Now(),
+			When:  entryTime,
 		},
 		Committer: object.Signature{
 			Name:  testName,
 			Email: testEmail,
-			When:  TestClock.Now(),
+			When:  entryTime,
 		},
 		Message:      commitMessage,
 		TreeHash:     plumbing.ZeroHash,
@@ -157,6 +175,29 @@ func CreateTestRSLAnnotationEntryCommit(t *testing.T, repo *git.Repository, anno
 	return commitID
 }
 
+// getTestRSLEntryTime returns the time used for a test RSL entry recorded on
+// top of the specified parent entry. This is the test clock's time, unless the
+// parent entry was recorded later, as entries recorded before their parent
+// entry are rejected during verification.
+func getTestRSLEntryTime(t *testing.T, repo *git.Repository, parentID plumbing.Hash) time.Time {
+	t.Helper()
+
+	entryTime := TestClock.Now()
+	if parentID.IsZero() {
+		return entryTime
+	}
+
+	parentCommit, err := gitinterface.GetCommit(repo, parentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parentCommit.Committer.When.After(entryTime) {
+		return parentCommit.Committer.When
+	}
+
+	return entryTime
+}
+
 // SignTestCommit signs the test commit using the specified key stored in the
 // repository. Note that the GPG key is loaded relative to the package
 // containing the test.
@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fixtures generates repositories with configurable Reference State Log
+// (RSL) shapes. The generated repositories are deterministic: the same options
+// always result in the same objects and references, making them suitable for
+// benchmarking gittuf and for reproducing bug reports. Fixtures can only be
+// generated in gittuf's developer mode.
+package fixtures
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jonboulle/clockwork"
+)
+
+const (
+	// DefaultRemoteName is the remote whose RSL tracker ref records the
+	// diverging RSL history, if any.
+	DefaultRemoteName = "origin"
+
+	fixtureName     = "gittuf fixture"
+	fixtureEmail    = "fixture@gittuf.dev"
+	fixtureFileName = "fixture"
+)
+
+var ErrInvalidFixtureOptions = errors.New("invalid fixture options")
+
+// fixtureEpoch is the timestamp of the first object in every fixture. Each
+// subsequent object is created a second later.
+var fixtureEpoch = time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Options configures the shape of a generated fixture.
+type Options struct {
+	// Refs is the number of branches updated in the fixture. The branches
+	// are named refs/heads/branch-<n>, starting at zero.
+	Refs int
+
+	// Entries is the number of reference entries in the local RSL. Each
+	// entry records a new commit on a randomly chosen branch.
+	Entries int
+
+	// Annotations is the number of annotations in the local RSL that do not
+	// skip the entries they refer to.
+	Annotations int
+
+	// Skips is the number of annotations in the local RSL that mark an
+	// entry as skipped.
+	Skips int
+
+	// Divergence is the number of reference entries in a diverging RSL
+	// history that is recorded in the RSL tracker ref of DefaultRemoteName.
+	// The diverging history forks from the local RSL before its last
+	// Divergence entries, so that both histories have the same number of
+	// entries the other lacks. It is zero if the RSLs must not diverge.
+	Divergence int
+
+	// Seed is the seed for the random choices made while generating the
+	// fixture, such as which branch each entry updates.
+	Seed int64
+}
+
+// Generate builds a fixture in the specified repository, which is expected to
+// be empty. All entries and commits are unsigned, and are authored using a
+// fixed identity and fixed timestamps so that the fixture is reproducible.
+func Generate(repo *git.Repository, opts *Options) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	fixtureClock := clockwork.NewFakeClockAt(fixtureEpoch)
+	fixtureConfig := &config.Config{}
+	fixtureConfig.User.Name = fixtureName
+	fixtureConfig.User.Email = fixtureEmail
+
+	restore, err := gitinterface.UseFixedIdentity(fixtureConfig, fixtureClock)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	g := &generator{
+		repo:  repo,
+		clock: fixtureClock,
+		rng:   rand.New(rand.NewSource(opts.Seed)), //nolint:gosec
+	}
+
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		return err
+	}
+
+	refs := make([]string, 0, opts.Refs)
+	for i := 0; i < opts.Refs; i++ {
+		refs = append(refs, fmt.Sprintf("%sbranch-%d", gitinterface.BranchRefPrefix, i))
+	}
+
+	// The first entry must be a reference entry as there is nothing to
+	// annotate before it
+	kinds := make([]entryKind, 0, opts.Entries+opts.Annotations+opts.Skips)
+	for i := 1; i < opts.Entries; i++ {
+		kinds = append(kinds, referenceEntryKind)
+	}
+	for i := 0; i < opts.Annotations; i++ {
+		kinds = append(kinds, annotationEntryKind)
+	}
+	for i := 0; i < opts.Skips; i++ {
+		kinds = append(kinds, skipEntryKind)
+	}
+	g.rng.Shuffle(len(kinds), func(i, j int) {
+		kinds[i], kinds[j] = kinds[j], kinds[i]
+	})
+	if opts.Entries > 0 {
+		kinds = append([]entryKind{referenceEntryKind}, kinds...)
+	}
+
+	referenceEntryIDs := []plumbing.Hash{}
+	for _, kind := range kinds {
+		switch kind {
+		case referenceEntryKind:
+			refName := refs[g.rng.Intn(len(refs))]
+			commitID, err := g.commit(refName, len(referenceEntryIDs))
+			if err != nil {
+				return err
+			}
+
+			g.tick()
+			if err := rsl.NewReferenceEntry(refName, commitID).Commit(repo, false); err != nil {
+				return err
+			}
+		default:
+			entryID := referenceEntryIDs[g.rng.Intn(len(referenceEntryIDs))]
+
+			message := fmt.Sprintf("Annotate entry '%s'", entryID.String())
+			if kind == skipEntryKind {
+				message = fmt.Sprintf("Skip entry '%s'", entryID.String())
+			}
+
+			g.tick()
+			if err := rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, kind == skipEntryKind, message).Commit(repo, false); err != nil {
+				return err
+			}
+		}
+
+		latestEntry, err := rsl.GetLatestEntry(repo)
+		if err != nil {
+			return err
+		}
+		if kind == referenceEntryKind {
+			referenceEntryIDs = append(referenceEntryIDs, latestEntry.GetID())
+		}
+	}
+
+	if opts.Divergence == 0 {
+		return nil
+	}
+
+	return g.diverge(refs, opts.Divergence)
+}
+
+type entryKind int
+
+const (
+	referenceEntryKind entryKind = iota
+	annotationEntryKind
+	skipEntryKind
+)
+
+type generator struct {
+	repo  *git.Repository
+	clock clockwork.FakeClock
+	rng   *rand.Rand
+}
+
+// commit creates a commit on top of refName's tip that changes the fixture
+// file, and updates refName to point to it.
+func (g *generator) commit(refName string, number int) (plumbing.Hash, error) {
+	treeID, err := g.writeTree(fmt.Sprintf("%s %d\n", refName, number))
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	g.tick()
+	return gitinterface.Commit(g.repo, treeID, refName, fmt.Sprintf("Update %s (%d)", refName, number), false)
+}
+
+// divergingCommit creates a commit on top of parentID that changes the fixture
+// file. No ref is updated. If parentID is zero, the commit has no parent.
+func (g *generator) divergingCommit(refName string, parentID plumbing.Hash, number int) (plumbing.Hash, error) {
+	treeID, err := g.writeTree(fmt.Sprintf("%s %d (diverged)\n", refName, number))
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	g.tick()
+	return gitinterface.CommitWithParent(g.repo, treeID, parentID, fmt.Sprintf("Update %s (%d, diverged)", refName, number), false)
+}
+
+// writeTree writes a tree containing only the fixture file with the specified
+// contents.
+func (g *generator) writeTree(contents string) (plumbing.Hash, error) {
+	blobID, err := gitinterface.WriteBlob(g.repo, []byte(contents))
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return gitinterface.WriteTree(g.repo, []object.TreeEntry{{Name: fixtureFileName, Mode: filemode.Regular, Hash: blobID}})
+}
+
+// diverge records a diverging RSL history in the RSL tracker ref of
+// DefaultRemoteName. The history forks from the local RSL before its last
+// count entries, and contains count reference entries for commits that are
+// not known locally.
+func (g *generator) diverge(refs []string, count int) error {
+	forkEntry, err := rsl.GetLatestEntry(g.repo)
+	if err != nil {
+		return err
+	}
+
+	forkID := forkEntry.GetID()
+	for i := 0; i < count; i++ {
+		parentEntry, err := rsl.GetParentForEntry(g.repo, forkEntry)
+		if err != nil {
+			if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return err
+			}
+
+			// The histories fork before the first entry in the RSL
+			forkID = plumbing.ZeroHash
+			break
+		}
+
+		forkEntry = parentEntry
+		forkID = forkEntry.GetID()
+	}
+
+	entries := make([]rsl.Entry, 0, count)
+	for i := 0; i < count; i++ {
+		refName := refs[g.rng.Intn(len(refs))]
+
+		tipID, err := gitinterface.GetTip(g.repo, refName)
+		if err != nil {
+			if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+				return err
+			}
+			tipID = plumbing.ZeroHash
+		}
+
+		commitID, err := g.divergingCommit(refName, tipID, i)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, rsl.NewReferenceEntry(refName, commitID))
+	}
+
+	g.tick()
+	newEntries, err := rsl.ReplayEntries(g.repo, forkID, entries, false)
+	if err != nil {
+		return err
+	}
+	if len(newEntries) > 0 {
+		forkID = newEntries[len(newEntries)-1].GetID()
+	}
+
+	return g.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(rsl.RemoteTrackerRef(DefaultRemoteName)), forkID))
+}
+
+// tick advances the fixture clock so that consecutive objects have distinct,
+// increasing timestamps.
+func (g *generator) tick() {
+	g.clock.Advance(time.Second)
+}
+
+func (o *Options) validate() error {
+	switch {
+	case o.Refs < 0, o.Entries < 0, o.Annotations < 0, o.Skips < 0, o.Divergence < 0:
+		return fmt.Errorf("%w: counts must not be negative", ErrInvalidFixtureOptions)
+	case o.Entries > 0 && o.Refs == 0:
+		return fmt.Errorf("%w: at least one ref is required to record entries", ErrInvalidFixtureOptions)
+	case o.Entries == 0 && o.Annotations+o.Skips > 0:
+		return fmt.Errorf("%w: annotations require at least one reference entry", ErrInvalidFixtureOptions)
+	case o.Divergence > 0 && o.Refs == 0:
+		return fmt.Errorf("%w: at least one ref is required for a diverging RSL", ErrInvalidFixtureOptions)
+	case o.Divergence > o.Entries+o.Annotations+o.Skips:
+		return fmt.Errorf("%w: divergence cannot exceed the number of local RSL entries", ErrInvalidFixtureOptions)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("not in dev mode", func(t *testing.T) {
+		t.Setenv(dev.DevModeKey, "0")
+
+		repo, err := git.Init(memory.NewStorage(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = Generate(repo, &Options{Refs: 1, Entries: 1})
+		assert.ErrorIs(t, err, dev.ErrNotInDevMode)
+	})
+
+	t.Setenv(dev.DevModeKey, "1")
+
+	t.Run("invalid options", func(t *testing.T) {
+		tests := map[string]*Options{
+			"negative count":              {Refs: 1, Entries: -1},
+			"entries without refs":        {Entries: 1},
+			"annotations without entries": {Refs: 1, Annotations: 1},
+			"divergence too large":        {Refs: 1, Entries: 2, Divergence: 3},
+		}
+
+		for name, opts := range tests {
+			repo, err := git.Init(memory.NewStorage(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = Generate(repo, opts)
+			assert.ErrorIs(t, err, ErrInvalidFixtureOptions, name)
+		}
+	})
+
+	t.Run("RSL shape", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		opts := &Options{Refs: 3, Entries: 20, Annotations: 4, Skips: 2, Seed: 42}
+		if err := Generate(repo, opts); err != nil {
+			t.Fatal(err)
+		}
+
+		refs := map[string]bool{}
+		referenceEntries, annotations, skips := 0, 0, 0
+
+		entry, err := rsl.GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for {
+			switch entry := entry.(type) {
+			case *rsl.ReferenceEntry:
+				referenceEntries++
+				refs[entry.RefName] = true
+
+				tipID, err := repo.Reference(plumbing.ReferenceName(entry.RefName), true)
+				assert.Nil(t, err)
+				assert.False(t, tipID.Hash().IsZero())
+			case *rsl.AnnotationEntry:
+				if entry.Skip {
+					skips++
+				} else {
+					annotations++
+				}
+			}
+
+			entry, err = rsl.GetParentForEntry(repo, entry)
+			if err != nil {
+				assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+				break
+			}
+		}
+
+		assert.Equal(t, opts.Entries, referenceEntries)
+		assert.Equal(t, opts.Annotations, annotations)
+		assert.Equal(t, opts.Skips, skips)
+		assert.LessOrEqual(t, len(refs), opts.Refs)
+
+		_, err = repo.Reference(plumbing.ReferenceName(rsl.RemoteTrackerRef(DefaultRemoteName)), true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	})
+
+	t.Run("divergence", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := Generate(repo, &Options{Refs: 2, Entries: 5, Divergence: 2, Seed: 1}); err != nil {
+			t.Fatal(err)
+		}
+
+		localTip, err := repo.Reference(rsl.Ref, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		remoteTip, err := repo.Reference(plumbing.ReferenceName(rsl.RemoteTrackerRef(DefaultRemoteName)), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		localCommit, err := repo.CommitObject(localTip.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		remoteCommit, err := repo.CommitObject(remoteTip.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mergeBases, err := localCommit.MergeBase(remoteCommit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, mergeBases, 1)
+
+		// Both histories have two entries after the fork
+		forkEntry, err := rsl.GetEntry(repo, mergeBases[0].Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint64(3), forkEntry.(*rsl.ReferenceEntry).Number)
+
+		remoteEntry, err := rsl.GetEntry(repo, remoteTip.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint64(5), remoteEntry.(*rsl.ReferenceEntry).Number)
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		opts := &Options{Refs: 4, Entries: 10, Annotations: 2, Skips: 1, Divergence: 3, Seed: 7}
+
+		generate := func(t *testing.T) *git.Repository {
+			t.Helper()
+
+			repo, err := git.Init(memory.NewStorage(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Generate(repo, opts); err != nil {
+				t.Fatal(err)
+			}
+			return repo
+		}
+
+		repoA := generate(t)
+		repoB := generate(t)
+
+		for _, refName := range []string{rsl.Ref, rsl.RemoteTrackerRef(DefaultRemoteName), "refs/heads/branch-0"} {
+			refA, err := repoA.Reference(plumbing.ReferenceName(refName), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			refB, err := repoB.Reference(plumbing.ReferenceName(refName), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, refA.Hash(), refB.Hash(), refName)
+		}
+	})
+}
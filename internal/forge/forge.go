@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package forge resolves the public keys that users have published on code
+// forges such as GitHub and GitLab, so that they can be pinned in gittuf
+// policy.
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	GitHub = "github"
+	GitLab = "gitlab"
+)
+
+var (
+	ErrUnknownForge        = errors.New("unknown forge (not one of github, gitlab)")
+	ErrInvalidForgeAccount = errors.New("forge account must be of the form <forge>:<username>")
+	ErrUserNotFound        = errors.New("user not found on forge")
+	ErrNoPublishedKeys     = errors.New("user has not published any supported signing keys")
+)
+
+// KeyResolver fetches the signing keys a user has published on a forge.
+type KeyResolver interface {
+	// Forge returns the name of the forge keys are fetched from.
+	Forge() string
+
+	// ResolveKeys returns the SSH and GPG signing keys published by the
+	// specified user. Keys of unsupported types are ignored.
+	ResolveKeys(ctx context.Context, username string) ([]*tuf.Key, error)
+}
+
+// NewKeyResolver returns a KeyResolver for the specified forge. If baseURL is
+// empty, the public instance of the forge is used. Currently, authentication
+// tokens are read from the GITHUB_TOKEN and GITLAB_TOKEN environment
+// variables.
+func NewKeyResolver(forgeName, baseURL string) (KeyResolver, error) {
+	switch forgeName {
+	case GitHub:
+		return newGitHubResolverFromEnv(baseURL)
+	case GitLab:
+		return newGitLabResolverFromEnv(baseURL), nil
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrUnknownForge, forgeName)
+}
+
+// Account returns the identifier of the user's account on the forge, of the
+// form "<forge>:<username>".
+func Account(forgeName, username string) string {
+	return fmt.Sprintf("%s:%s", forgeName, username)
+}
+
+// ParseAccount returns the forge and username in an account identifier
+// created using Account.
+func ParseAccount(account string) (string, string, error) {
+	forgeName, username, found := strings.Cut(account, ":")
+	if !found || forgeName == "" || username == "" {
+		return "", "", ErrInvalidForgeAccount
+	}
+
+	switch forgeName {
+	case GitHub, GitLab:
+		return forgeName, username, nil
+	}
+
+	return "", "", fmt.Errorf("%w: '%s'", ErrUnknownForge, forgeName)
+}
+
+// loadSSHKey returns a tuf.Key for an SSH public key in the authorized_keys
+// format. Keys of types that gittuf cannot verify signatures for are
+// reported as unsupported using a nil key.
+func loadSSHKey(authorizedKey string) (*tuf.Key, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoPublicKey, ok := publicKey.(ssh.CryptoPublicKey)
+	if !ok {
+		slog.Debug(fmt.Sprintf("Ignoring unsupported SSH key of type '%s'...", publicKey.Type()))
+		return nil, nil
+	}
+
+	key, err := sslibsv.NewKey(cryptoPublicKey.CryptoPublicKey())
+	if err != nil {
+		slog.Debug(fmt.Sprintf("Ignoring unsupported SSH key of type '%s'...", publicKey.Type()))
+		return nil, nil //nolint:nilerr
+	}
+
+	return key, nil
+}
+
+// loadGPGKey returns a tuf.Key for an armored GPG public key.
+func loadGPGKey(armoredKey string) (*tuf.Key, error) {
+	return gpg.LoadGPGKeyFromBytes([]byte(armoredKey))
+}
+
+// appendKey adds key to keys unless it's nil or already present.
+func appendKey(keys []*tuf.Key, key *tuf.Key) []*tuf.Key {
+	if key == nil {
+		return keys
+	}
+
+	for _, existingKey := range keys {
+		if existingKey.KeyID == key.KeyID {
+			return keys
+		}
+	}
+
+	return append(keys, key)
+}
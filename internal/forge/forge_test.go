@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/google/go-github/v61/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubResolver(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/alice/ssh_signing_keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]string{{"key": strings.TrimSpace(string(artifacts.SSHED25519PublicSSH))}})
+	})
+	mux.HandleFunc("/users/alice/gpg_keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]string{{"raw_key": string(artifacts.GPGKey1Public)}})
+	})
+	mux.HandleFunc("/users/bob/ssh_signing_keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]string{})
+	})
+	mux.HandleFunc("/users/bob/gpg_keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]string{})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	resolver := NewGitHubResolver(client)
+	assert.Equal(t, GitHub, resolver.Forge())
+
+	keys, err := resolver.ResolveKeys(context.Background(), "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedKeyIDs(t), keyIDs(keys))
+
+	_, err = resolver.ResolveKeys(context.Background(), "bob")
+	assert.ErrorIs(t, err, ErrNoPublishedKeys)
+
+	_, err = resolver.ResolveKeys(context.Background(), "carol")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestGitLabResolver(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+
+		if r.URL.Query().Get("username") != "alice" {
+			writeJSON(t, w, []map[string]any{})
+			return
+		}
+		writeJSON(t, w, []map[string]any{{"id": 1, "username": "alice"}})
+	})
+	mux.HandleFunc("/api/v4/users/1/keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]string{
+			{"key": strings.TrimSpace(string(artifacts.SSHED25519PublicSSH)), "usage_type": "auth_and_signing"},
+			{"key": strings.TrimSpace(string(artifacts.SSHRSAPublicSSH)), "usage_type": "auth"},
+		})
+	})
+	mux.HandleFunc("/api/v4/users/1/gpg_keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]string{{"key": string(artifacts.GPGKey1Public)}})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	resolver := NewGitLabResolver(server.URL+"/", "token", nil)
+	assert.Equal(t, GitLab, resolver.Forge())
+
+	// Authentication-only SSH keys are not included
+	keys, err := resolver.ResolveKeys(context.Background(), "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedKeyIDs(t), keyIDs(keys))
+
+	_, err = resolver.ResolveKeys(context.Background(), "bob")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestParseAccount(t *testing.T) {
+	forgeName, username, err := ParseAccount(Account(GitLab, "alice"))
+	assert.Nil(t, err)
+	assert.Equal(t, GitLab, forgeName)
+	assert.Equal(t, "alice", username)
+
+	_, _, err = ParseAccount("alice")
+	assert.ErrorIs(t, err, ErrInvalidForgeAccount)
+
+	_, _, err = ParseAccount("bitbucket:alice")
+	assert.ErrorIs(t, err, ErrUnknownForge)
+}
+
+func TestNewKeyResolver(t *testing.T) {
+	resolver, err := NewKeyResolver(GitHub, "")
+	assert.Nil(t, err)
+	assert.Equal(t, GitHub, resolver.Forge())
+
+	resolver, err = NewKeyResolver(GitLab, "https://gitlab.example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, GitLab, resolver.Forge())
+
+	_, err = NewKeyResolver("bitbucket", "")
+	assert.ErrorIs(t, err, ErrUnknownForge)
+}
+
+func expectedKeyIDs(t *testing.T) []string {
+	t.Helper()
+
+	sshKey, err := tuf.LoadKeyFromBytes(artifacts.SSHED25519Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(artifacts.GPGKey1Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []string{sshKey.KeyID, gpgKey.KeyID}
+}
+
+func keyIDs(keys []*tuf.Key) []string {
+	ids := []string{}
+	for _, key := range keys {
+		ids = append(ids, key.KeyID)
+	}
+	return ids
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/google/go-github/v61/github"
+)
+
+// GitHubResolver fetches the SSH signing keys and GPG keys published by users
+// on GitHub.
+type GitHubResolver struct {
+	client *github.Client
+}
+
+// NewGitHubResolver returns a GitHubResolver that uses the specified client.
+func NewGitHubResolver(client *github.Client) *GitHubResolver {
+	return &GitHubResolver{client: client}
+}
+
+func newGitHubResolverFromEnv(baseURL string) (*GitHubResolver, error) {
+	client := github.NewClient(nil).WithAuthToken(os.Getenv("GITHUB_TOKEN"))
+	if baseURL != "" {
+		var err error
+		client, err = client.WithEnterpriseURLs(baseURL, baseURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewGitHubResolver(client), nil
+}
+
+// Forge returns the name of the forge, GitHub.
+func (g *GitHubResolver) Forge() string {
+	return GitHub
+}
+
+// ResolveKeys returns the SSH signing keys and GPG keys published by the
+// specified GitHub user. SSH authentication keys are not included.
+func (g *GitHubResolver) ResolveKeys(ctx context.Context, username string) ([]*tuf.Key, error) {
+	keys := []*tuf.Key{}
+
+	options := &github.ListOptions{PerPage: 100}
+	for {
+		page, response, err := g.client.Users.ListSSHSigningKeys(ctx, username, options)
+		if err != nil {
+			return nil, wrapGitHubError(err, username)
+		}
+
+		for _, sshKey := range page {
+			key, err := loadSSHKey(sshKey.GetKey())
+			if err != nil {
+				return nil, err
+			}
+			keys = appendKey(keys, key)
+		}
+
+		if response.NextPage == 0 {
+			break
+		}
+		options.Page = response.NextPage
+	}
+
+	options = &github.ListOptions{PerPage: 100}
+	for {
+		page, response, err := g.client.Users.ListGPGKeys(ctx, username, options)
+		if err != nil {
+			return nil, wrapGitHubError(err, username)
+		}
+
+		for _, gpgKey := range page {
+			if gpgKey.GetRawKey() == "" {
+				continue
+			}
+
+			key, err := loadGPGKey(gpgKey.GetRawKey())
+			if err != nil {
+				return nil, err
+			}
+			keys = appendKey(keys, key)
+		}
+
+		if response.NextPage == 0 {
+			break
+		}
+		options.Page = response.NextPage
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: '%s'", ErrNoPublishedKeys, username)
+	}
+
+	return keys, nil
+}
+
+func wrapGitHubError(err error, username string) error {
+	var responseErr *github.ErrorResponse
+	if errors.As(err, &responseErr) && responseErr.Response != nil && responseErr.Response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: '%s'", ErrUserNotFound, username)
+	}
+
+	return err
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// DefaultGitLabURL is the URL of the public GitLab instance.
+const DefaultGitLabURL = "https://gitlab.com"
+
+// gitLabSSHAuthUsage is the usage type of GitLab SSH keys that cannot be used
+// for signing.
+const gitLabSSHAuthUsage = "auth"
+
+// GitLabResolver fetches the SSH signing keys and GPG keys published by users
+// on a GitLab instance.
+type GitLabResolver struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewGitLabResolver returns a GitLabResolver for the GitLab instance at
+// baseURL. If token is set, it is used to authenticate to the GitLab API.
+func NewGitLabResolver(baseURL, token string, client *http.Client) *GitLabResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &GitLabResolver{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  client,
+	}
+}
+
+func newGitLabResolverFromEnv(baseURL string) *GitLabResolver {
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+
+	return NewGitLabResolver(baseURL, os.Getenv("GITLAB_TOKEN"), nil)
+}
+
+// Forge returns the name of the forge, GitLab.
+func (g *GitLabResolver) Forge() string {
+	return GitLab
+}
+
+// ResolveKeys returns the SSH keys that may be used for signing and the GPG
+// keys published by the specified GitLab user.
+func (g *GitLabResolver) ResolveKeys(ctx context.Context, username string) ([]*tuf.Key, error) {
+	users := []struct {
+		ID int64 `json:"id"`
+	}{}
+	if err := g.get(ctx, fmt.Sprintf("users?username=%s", url.QueryEscape(username)), &users); err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("%w: '%s'", ErrUserNotFound, username)
+	}
+	userID := users[0].ID
+
+	keys := []*tuf.Key{}
+
+	sshKeys := []struct {
+		Key       string `json:"key"`
+		UsageType string `json:"usage_type"`
+	}{}
+	if err := g.get(ctx, fmt.Sprintf("users/%d/keys?per_page=100", userID), &sshKeys); err != nil {
+		return nil, err
+	}
+	for _, sshKey := range sshKeys {
+		if sshKey.UsageType == gitLabSSHAuthUsage {
+			continue
+		}
+
+		key, err := loadSSHKey(sshKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		keys = appendKey(keys, key)
+	}
+
+	gpgKeys := []struct {
+		Key string `json:"key"`
+	}{}
+	if err := g.get(ctx, fmt.Sprintf("users/%d/gpg_keys?per_page=100", userID), &gpgKeys); err != nil {
+		return nil, err
+	}
+	for _, gpgKey := range gpgKeys {
+		key, err := loadGPGKey(gpgKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		keys = appendKey(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: '%s'", ErrNoPublishedKeys, username)
+	}
+
+	return keys, nil
+}
+
+// get fetches the specified GitLab API path and decodes the JSON response into
+// v.
+func (g *GitLabResolver) get(ctx context.Context, path string, v any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v4/%s", g.baseURL, path), nil)
+	if err != nil {
+		return err
+	}
+	if g.token != "" {
+		request.Header.Set("PRIVATE-TOKEN", g.token)
+	}
+
+	response, err := g.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from GitLab for '%s': %s", path, response.Status)
+	}
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
@@ -0,0 +1,376 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+var (
+	ErrUnknownCommand        = errors.New("unknown remote helper command")
+	ErrInvalidCommand        = errors.New("invalid remote helper command")
+	ErrDeletionNotSupported  = errors.New("deleting remote refs is not supported")
+	ErrRefRenameNotSupported = errors.New("pushing to a ref with a different name is not supported")
+	ErrFetchedRefNotInRSL    = errors.New("fetched state of ref does not match latest RSL entry")
+)
+
+// helper implements the Git remote helper protocol. For more information,
+// please consult: https://git-scm.com/docs/gitremote-helpers.
+type helper struct {
+	repo       *repository.Repository
+	remoteName string
+
+	// signRSLEntries determines if RSL entries recorded during pushes are
+	// signed.
+	signRSLEntries bool
+}
+
+func newHelper(repo *repository.Repository, remoteName string, signRSLEntries bool) *helper {
+	return &helper{repo: repo, remoteName: remoteName, signRSLEntries: signRSLEntries}
+}
+
+// Run reads commands from Git on in and writes responses to out until Git
+// signals the end of the session with a blank line.
+func (h *helper) Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	writer := bufio.NewWriter(out)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			return nil
+
+		case line == "capabilities":
+			if _, err := writer.WriteString("fetch\npush\n\n"); err != nil {
+				return err
+			}
+
+		case line == "list" || line == "list for-push":
+			refs, err := h.list(ctx)
+			if err != nil {
+				return err
+			}
+			for _, ref := range refs {
+				if _, err := writer.WriteString(ref + "\n"); err != nil {
+					return err
+				}
+			}
+			if _, err := writer.WriteString("\n"); err != nil {
+				return err
+			}
+
+		case strings.HasPrefix(line, "fetch "):
+			if err := h.fetch(ctx, readBatch(scanner, line)); err != nil {
+				return err
+			}
+			if _, err := writer.WriteString("\n"); err != nil {
+				return err
+			}
+
+		case strings.HasPrefix(line, "push "):
+			for _, result := range h.push(ctx, readBatch(scanner, line)) {
+				if _, err := writer.WriteString(result + "\n"); err != nil {
+					return err
+				}
+			}
+			if _, err := writer.WriteString("\n"); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("%w: '%s'", ErrUnknownCommand, line)
+		}
+
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// list returns the remote's refs in the format expected by Git.
+func (h *helper) list(ctx context.Context) ([]string, error) {
+	remote, err := h.repo.GoGitRepository().Remote(h.remoteName)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Listing refs at '%s'...", h.remoteName))
+	remoteRefs, err := remote.ListContext(ctx, &git.ListOptions{})
+	if err != nil {
+		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	refs := make([]string, 0, len(remoteRefs))
+	for _, ref := range remoteRefs {
+		switch ref.Type() {
+		case plumbing.HashReference:
+			refs = append(refs, fmt.Sprintf("%s %s", ref.Hash().String(), ref.Name().String()))
+		case plumbing.SymbolicReference:
+			refs = append(refs, fmt.Sprintf("@%s %s", ref.Target().String(), ref.Name().String()))
+		}
+	}
+
+	return refs, nil
+}
+
+// fetch synchronizes and verifies the RSL before fetching the requested refs.
+// Each fetched ref must match its latest RSL entry.
+func (h *helper) fetch(ctx context.Context, commands []string) error {
+	repo := h.repo.GoGitRepository()
+
+	requestedRefs := map[string]plumbing.Hash{}
+	for _, command := range commands {
+		fields := strings.Fields(command)
+		if len(fields) != 3 {
+			return fmt.Errorf("%w: '%s'", ErrInvalidCommand, command)
+		}
+		target, refName := plumbing.NewHash(fields[1]), fields[2]
+		if !strings.HasPrefix(refName, gitinterface.RefPrefix) {
+			// Symbolic refs like HEAD are resolved by Git
+			continue
+		}
+		requestedRefs[refName] = target
+	}
+
+	// Git updates the remote tracking refs itself once the fetch is complete.
+	// When cloning, Git expects them to not exist yet, so any that are created
+	// while fetching are removed afterwards.
+	newTrackerRefs := []string{}
+	refSpecs := make([]config.RefSpec, 0, len(requestedRefs))
+	for refName := range requestedRefs {
+		trackerRef := gitinterface.RemoteRef(refName, h.remoteName)
+		if _, err := repo.Reference(plumbing.ReferenceName(trackerRef), false); err != nil {
+			if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+				return err
+			}
+			newTrackerRefs = append(newTrackerRefs, trackerRef)
+		}
+
+		refSpec := fmt.Sprintf("%s:%s", refName, trackerRef)
+		if !strings.HasPrefix(refName, gitinterface.TagRefPrefix) {
+			refSpec = "+" + refSpec
+		}
+		refSpecs = append(refSpecs, config.RefSpec(refSpec))
+	}
+
+	if err := h.syncRSL(ctx); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching %d refs from '%s'...", len(refSpecs), h.remoteName))
+	if err := gitinterface.FetchRefSpec(ctx, repo, h.remoteName, refSpecs); err != nil {
+		return err
+	}
+
+	skipResolver := policy.NewSkipResolver(repo)
+	for refName, target := range requestedRefs {
+		if strings.HasPrefix(refName, "refs/gittuf/") {
+			// gittuf refs were verified when syncing the RSL
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Checking fetched state of '%s' against RSL...", refName))
+		latestEntry, _, err := skipResolver.GetLatestUnskippedReferenceEntryForRef(ctx, refName)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) || errors.Is(err, plumbing.ErrReferenceNotFound) {
+				slog.Warn(fmt.Sprintf("'%s' is not tracked in the RSL and cannot be verified", refName))
+				continue
+			}
+			return err
+		}
+		if latestEntry.TargetID != target {
+			return fmt.Errorf("%w: '%s'", ErrFetchedRefNotInRSL, refName)
+		}
+	}
+
+	for _, trackerRef := range newTrackerRefs {
+		if err := repo.Storer.RemoveReference(plumbing.ReferenceName(trackerRef)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// push records and verifies RSL entries for the requested refs before pushing
+// each ref along with the RSL. The result for each ref is returned in the
+// format expected by Git.
+func (h *helper) push(ctx context.Context, commands []string) []string {
+	results := make([]string, 0, len(commands))
+
+	syncErr := h.syncRSL(ctx)
+	hasPolicy := false
+	if syncErr == nil {
+		hasPolicy, syncErr = h.hasPolicy()
+	}
+
+	for _, command := range commands {
+		refSpec := strings.TrimPrefix(strings.TrimPrefix(command, "push "), "+")
+		src, dst, _ := strings.Cut(refSpec, ":")
+
+		err := syncErr
+		if err == nil {
+			err = h.pushRef(ctx, src, dst, hasPolicy)
+		}
+		if err != nil {
+			results = append(results, fmt.Sprintf("error %s %s", dst, strings.ReplaceAll(err.Error(), "\n", " ")))
+			continue
+		}
+
+		results = append(results, fmt.Sprintf("ok %s", dst))
+	}
+
+	return results
+}
+
+// pushRef records an RSL entry for the ref's current state if necessary,
+// verifies it, and pushes the ref and the RSL. If verification fails, the new
+// entry is removed from the local RSL.
+func (h *helper) pushRef(ctx context.Context, src, dst string, hasPolicy bool) error {
+	repo := h.repo.GoGitRepository()
+
+	if src == "" {
+		return ErrDeletionNotSupported
+	}
+	if src != dst {
+		return ErrRefRenameNotSupported
+	}
+
+	currentTip, err := gitinterface.GetTip(repo, dst)
+	if err != nil {
+		return err
+	}
+
+	previousRSLRef, err := repo.Reference(rsl.Ref, true)
+	if err != nil {
+		return err
+	}
+
+	latestEntry, _, err := policy.NewSkipResolver(repo).GetLatestUnskippedReferenceEntryForRef(ctx, dst)
+	if err != nil && !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return err
+	}
+	if latestEntry == nil || latestEntry.TargetID != currentTip {
+		slog.Debug(fmt.Sprintf("Recording RSL entry for '%s'...", dst))
+		if err := h.repo.RecordRSLEntryForReference(dst, h.signRSLEntries); err != nil {
+			return err
+		}
+	}
+
+	if hasPolicy {
+		slog.Debug(fmt.Sprintf("Verifying '%s'...", dst))
+		if err := h.repo.VerifyRef(ctx, dst, true); err != nil {
+			currentRSLRef, rslErr := repo.Reference(rsl.Ref, true)
+			if rslErr != nil {
+				return errors.Join(err, rslErr)
+			}
+			if currentRSLRef.Hash() != previousRSLRef.Hash() {
+				if rslErr := repo.Storer.CheckAndSetReference(previousRSLRef, currentRSLRef); rslErr != nil {
+					return errors.Join(err, rslErr)
+				}
+			}
+			return err
+		}
+	}
+
+	return h.repo.PushRefAndRSL(ctx, h.remoteName, dst)
+}
+
+// syncRSL verifies and fast-forwards the local RSL to the remote's RSL. If the
+// local RSL doesn't exist yet, it's only created if the remote has an RSL.
+func (h *helper) syncRSL(ctx context.Context) error {
+	repo := h.repo.GoGitRepository()
+
+	remoteRefs, err := h.list(ctx)
+	if err != nil {
+		return err
+	}
+	hasRemoteRSL := false
+	for _, ref := range remoteRefs {
+		if strings.HasSuffix(ref, " "+rsl.Ref) {
+			hasRemoteRSL = true
+			break
+		}
+	}
+	if !hasRemoteRSL {
+		// This is the case when pushing to a new remote, for example
+		slog.Warn(fmt.Sprintf("'%s' does not have an RSL", h.remoteName))
+		return nil
+	}
+
+	initializedRSL := false
+	if _, err := repo.Reference(rsl.Ref, true); err != nil {
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return err
+		}
+
+		slog.Debug("Initializing local RSL...")
+		if err := rsl.InitializeNamespace(repo); err != nil {
+			return err
+		}
+		initializedRSL = true
+	}
+
+	slog.Debug(fmt.Sprintf("Syncing RSL with '%s'...", h.remoteName))
+	if err := h.repo.Sync(ctx, h.remoteName); err != nil {
+		if initializedRSL {
+			// Git doesn't accept refs set to the zero hash, so the
+			// uninitialized RSL must not be left behind
+			if removeErr := repo.Storer.RemoveReference(rsl.Ref); removeErr != nil {
+				return errors.Join(err, removeErr)
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// hasPolicy returns true if the local RSL records a policy to verify against.
+func (h *helper) hasPolicy() (bool, error) {
+	if _, _, err := rsl.GetLatestReferenceEntryForRef(h.repo.GoGitRepository(), policy.PolicyRef); err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// readBatch returns the first command of a batch along with the commands that
+// follow it until the blank line that ends the batch.
+func readBatch(scanner *bufio.Scanner, firstCommand string) []string {
+	commands := []string{firstCommand}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		commands = append(commands, line)
+	}
+
+	return commands
+}
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUnderlyingEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		url              string
+		expectedProtocol string
+	}{
+		"remote repository": {
+			url:              "gittuf://github.com/gittuf/gittuf",
+			expectedProtocol: "https",
+		},
+		"local repository": {
+			url:              "gittuf:///srv/git/gittuf",
+			expectedProtocol: "file",
+		},
+	}
+
+	for name, test := range tests {
+		endpoint, err := transport.NewEndpoint(test.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		underlyingEndpoint := getUnderlyingEndpoint(endpoint)
+		assert.Equal(t, test.expectedProtocol, underlyingEndpoint.Protocol, fmt.Sprintf("unexpected protocol in test '%s'", name))
+		assert.Equal(t, endpoint.Host, underlyingEndpoint.Host, fmt.Sprintf("unexpected host in test '%s'", name))
+		assert.Equal(t, endpoint.Path, underlyingEndpoint.Path, fmt.Sprintf("unexpected path in test '%s'", name))
+		assert.Equal(t, gittufScheme, endpoint.Protocol, fmt.Sprintf("original endpoint modified in test '%s'", name))
+	}
+}
+
+func TestHelper(t *testing.T) {
+	installGittufProtocol()
+
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	setup := func(t *testing.T) (*repository.Repository, *repository.Repository) {
+		t.Helper()
+
+		remoteTmpDir := t.TempDir()
+		remoteR, err := git.PlainInit(remoteTmpDir, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.InitializeNamespace(remoteR); err != nil {
+			t.Fatal(err)
+		}
+		remoteRepo, err := repository.LoadRepositoryAt(remoteTmpDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gitinterface.Commit(remoteR, gitinterface.EmptyTree(), refName, "Initial commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := remoteRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		localTmpDir := t.TempDir()
+		localR, err := git.PlainInit(localTmpDir, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := localR.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{"gittuf://" + remoteTmpDir}}); err != nil {
+			t.Fatal(err)
+		}
+		localRepo, err := repository.LoadRepositoryAt(localTmpDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return remoteRepo, localRepo
+	}
+
+	run := func(t *testing.T, repo *repository.Repository, input string) (string, error) {
+		t.Helper()
+
+		output := &bytes.Buffer{}
+		err := newHelper(repo, remoteName, false).Run(context.Background(), strings.NewReader(input), output)
+		return output.String(), err
+	}
+
+	t.Run("capabilities", func(t *testing.T) {
+		_, localRepo := setup(t)
+
+		output, err := run(t, localRepo, "capabilities\n\n")
+		assert.Nil(t, err)
+		assert.Equal(t, "fetch\npush\n\n", output)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		remoteTip, err := gitinterface.GetTip(remoteRepo.GoGitRepository(), refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output, err := run(t, localRepo, "list\n\n")
+		assert.Nil(t, err)
+		assert.Contains(t, output, fmt.Sprintf("%s %s\n", remoteTip.String(), refName))
+		assert.Contains(t, output, fmt.Sprintf(" %s\n", rsl.Ref))
+		assert.True(t, strings.HasSuffix(output, "\n\n"))
+	})
+
+	t.Run("fetch verified ref", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		remoteTip, err := gitinterface.GetTip(remoteRepo.GoGitRepository(), refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output, err := run(t, localRepo, fmt.Sprintf("fetch %s %s\n\n\n", remoteTip.String(), refName))
+		assert.Nil(t, err)
+		assert.Equal(t, "\n", output)
+
+		_, err = gitinterface.GetCommit(localRepo.GoGitRepository(), remoteTip)
+		assert.Nil(t, err)
+
+		remoteRSLTip, err := gitinterface.GetTip(remoteRepo.GoGitRepository(), rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		localRSLTip, err := gitinterface.GetTip(localRepo.GoGitRepository(), rsl.Ref)
+		assert.Nil(t, err)
+		assert.Equal(t, remoteRSLTip, localRSLTip)
+	})
+
+	t.Run("fetch ref not matching RSL", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		// Update the remote ref without recording it in the RSL
+		remoteTip, err := gitinterface.Commit(remoteRepo.GoGitRepository(), gitinterface.EmptyTree(), refName, "Unrecorded commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = run(t, localRepo, fmt.Sprintf("fetch %s %s\n\n\n", remoteTip.String(), refName))
+		assert.ErrorIs(t, err, ErrFetchedRefNotInRSL)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		remoteTip, err := gitinterface.GetTip(remoteRepo.GoGitRepository(), refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := run(t, localRepo, fmt.Sprintf("fetch %s %s\n\n\n", remoteTip.String(), refName)); err != nil {
+			t.Fatal(err)
+		}
+
+		// Build on the remote's state of the ref
+		localTip, err := gitinterface.CommitWithParents(localRepo.GoGitRepository(), gitinterface.EmptyTree(), []plumbing.Hash{remoteTip}, refName, "Local commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output, err := run(t, localRepo, fmt.Sprintf("push %s:%s\n\n\n", refName, refName))
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("ok %s\n\n", refName), output)
+
+		currentRemoteTip, err := gitinterface.GetTip(remoteRepo.GoGitRepository(), refName)
+		assert.Nil(t, err)
+		assert.Equal(t, localTip, currentRemoteTip)
+
+		latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(remoteRepo.GoGitRepository(), refName)
+		assert.Nil(t, err)
+		assert.Equal(t, localTip, latestEntry.TargetID)
+	})
+
+	t.Run("push unsupported updates", func(t *testing.T) {
+		_, localRepo := setup(t)
+
+		output, err := run(t, localRepo, fmt.Sprintf("push :%s\npush refs/heads/feature:%s\n\n\n", refName, refName))
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("error %s %s\nerror %s %s\n\n", refName, ErrDeletionNotSupported.Error(), refName, ErrRefRenameNotSupported.Error()), output)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		_, localRepo := setup(t)
+
+		_, err := run(t, localRepo, "connect git-upload-pack\n\n")
+		assert.ErrorIs(t, err, ErrUnknownCommand)
+	})
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// git-remote-gittuf is a Git remote helper for remotes configured with the
+// gittuf:// scheme. Git invokes it transparently during fetches and pushes, and
+// it synchronizes and verifies the RSL alongside the requested refs. The
+// gittuf:// scheme maps to https:// for remotes with a host, e.g.,
+// gittuf://github.com/gittuf/gittuf, and to a local path otherwise, e.g.,
+// gittuf:///srv/git/repo.
+//
+// The binary must be named git-remote-gittuf and be available in PATH for Git
+// to find it.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/repository"
+)
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "usage: git-remote-gittuf <remote> <url>")
+		os.Exit(1)
+	}
+
+	installGittufProtocol()
+
+	// Git sets GIT_DIR for remote helpers
+	gitDir := os.Getenv("GIT_DIR")
+	if gitDir == "" {
+		gitDir = "."
+	}
+
+	repo, err := repository.LoadRepositoryAt(gitDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	h := newHelper(repo, os.Args[1], true)
+	if err := h.Run(context.Background(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+)
+
+const gittufScheme = "gittuf"
+
+// gittufTransport lets go-git use remotes with gittuf:// URLs by delegating to
+// the transport for the underlying URL.
+type gittufTransport struct{}
+
+// installGittufProtocol registers the gittuf:// scheme with go-git so that
+// remotes configured with it can be used by the repository package.
+func installGittufProtocol() {
+	client.InstallProtocol(gittufScheme, &gittufTransport{})
+}
+
+func (g *gittufTransport) NewUploadPackSession(endpoint *transport.Endpoint, auth transport.AuthMethod) (transport.UploadPackSession, error) {
+	underlyingEndpoint := getUnderlyingEndpoint(endpoint)
+	underlyingTransport, err := client.NewClient(underlyingEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return underlyingTransport.NewUploadPackSession(underlyingEndpoint, auth)
+}
+
+func (g *gittufTransport) NewReceivePackSession(endpoint *transport.Endpoint, auth transport.AuthMethod) (transport.ReceivePackSession, error) {
+	underlyingEndpoint := getUnderlyingEndpoint(endpoint)
+	underlyingTransport, err := client.NewClient(underlyingEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return underlyingTransport.NewReceivePackSession(underlyingEndpoint, auth)
+}
+
+// getUnderlyingEndpoint returns the endpoint that a gittuf:// endpoint refers
+// to. Endpoints with a host use HTTPS, while endpoints without one refer to a
+// local path.
+func getUnderlyingEndpoint(endpoint *transport.Endpoint) *transport.Endpoint {
+	underlyingEndpoint := *endpoint
+	if endpoint.Host == "" {
+		underlyingEndpoint.Protocol = "file"
+	} else {
+		underlyingEndpoint.Protocol = "https"
+	}
+
+	return &underlyingEndpoint
+}
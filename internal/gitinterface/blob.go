@@ -29,6 +29,27 @@ func ReadBlob(repo *git.Repository, blobID plumbing.Hash) ([]byte, error) {
 	return io.ReadAll(reader)
 }
 
+// ReadBlobs returns the contents of each blob in blobIDs, keyed by blob ID.
+// Objects are read through the repository's object storage, which keeps
+// packfiles open and caches decoded objects across reads, so reading many blobs
+// doesn't incur any per-object setup cost.
+func ReadBlobs(repo *git.Repository, blobIDs []plumbing.Hash) (map[plumbing.Hash][]byte, error) {
+	blobs := make(map[plumbing.Hash][]byte, len(blobIDs))
+	for _, blobID := range blobIDs {
+		if _, has := blobs[blobID]; has {
+			continue
+		}
+
+		contents, err := ReadBlob(repo, blobID)
+		if err != nil {
+			return nil, err
+		}
+		blobs[blobID] = contents
+	}
+
+	return blobs, nil
+}
+
 // WriteBlob creates a blob object with the specified contents and returns the
 // ID of the resultant blob.
 func WriteBlob(repo *git.Repository, contents []byte) (plumbing.Hash, error) {
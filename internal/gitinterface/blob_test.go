@@ -78,6 +78,29 @@ func TestReadBlob(t *testing.T) {
 	})
 }
 
+func TestReadBlobs(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstBlobID, err := WriteBlob(repo, []byte("first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondBlobID, err := WriteBlob(repo, []byte("second"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blobs, err := ReadBlobs(repo, []plumbing.Hash{firstBlobID, secondBlobID, firstBlobID})
+	assert.Nil(t, err)
+	assert.Equal(t, map[plumbing.Hash][]byte{firstBlobID: []byte("first"), secondBlobID: []byte("second")}, blobs)
+
+	_, err = ReadBlobs(repo, []plumbing.Hash{firstBlobID, plumbing.ZeroHash})
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+}
+
 func TestWriteBlob(t *testing.T) {
 	writeContents := []byte("test file write")
 
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const bundleSignature = "# v2 git bundle"
+
+var ErrInvalidBundle = errors.New("invalid or unsupported git bundle")
+
+// WriteBundle writes a self-contained Git bundle (version 2) to w. The bundle
+// records the specified refs and contains all the objects reachable from their
+// targets, so it has no prerequisites.
+func WriteBundle(repo *git.Repository, w io.Writer, refs map[string]plumbing.Hash) error {
+	refNames := make([]string, 0, len(refs))
+	targets := make([]plumbing.Hash, 0, len(refs))
+	for refName, target := range refs {
+		refNames = append(refNames, refName)
+		targets = append(targets, target)
+	}
+	sort.Strings(refNames)
+
+	objects, err := revlist.Objects(repo.Storer, targets, nil)
+	if err != nil {
+		return err
+	}
+
+	lines := []string{bundleSignature}
+	for _, refName := range refNames {
+		lines = append(lines, fmt.Sprintf("%s %s", refs[refName].String(), refName))
+	}
+	if _, err := io.WriteString(w, strings.Join(lines, "\n")+"\n\n"); err != nil {
+		return err
+	}
+
+	_, err = packfile.NewEncoder(w, repo.Storer, false).Encode(objects, 10)
+	return err
+}
+
+// ReadBundle loads a Git bundle written by WriteBundle into an in-memory
+// repository, setting each ref recorded in the bundle. Bundles with
+// prerequisites are not supported as they are not self-contained.
+func ReadBundle(r io.Reader) (*git.Repository, error) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := ImportBundle(repo, r)
+	if err != nil {
+		return nil, err
+	}
+
+	for refName, target := range refs {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), target)); err != nil {
+			return nil, err
+		}
+	}
+
+	return repo, nil
+}
+
+// ImportBundle writes the objects in a Git bundle written by WriteBundle into
+// repo. The refs recorded in the bundle are returned but not set in repo,
+// leaving it to the caller to decide how they are applied.
+func ImportBundle(repo *git.Repository, r io.Reader) (map[string]plumbing.Hash, error) {
+	reader := bufio.NewReader(r)
+
+	signature, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, errors.Join(ErrInvalidBundle, err)
+	}
+	if strings.TrimSpace(signature) != bundleSignature {
+		return nil, ErrInvalidBundle
+	}
+
+	refs := map[string]plumbing.Hash{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, errors.Join(ErrInvalidBundle, err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		target, refName, found := strings.Cut(line, " ")
+		if !found || strings.HasPrefix(target, "-") || !plumbing.IsHash(target) {
+			return nil, ErrInvalidBundle
+		}
+		refs[refName] = plumbing.NewHash(target)
+	}
+
+	if len(refs) > 0 {
+		if err := packfile.UpdateObjectStorage(repo.Storer, reader); err != nil {
+			return nil, errors.Join(ErrInvalidBundle, err)
+		}
+	}
+
+	for _, target := range refs {
+		if _, err := repo.Storer.EncodedObject(plumbing.AnyObject, target); err != nil {
+			return nil, errors.Join(ErrInvalidBundle, err)
+		}
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAndReadBundle(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Write the empty tree as it's used by some commits
+	if _, err := WriteTree(repo, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	blobID, err := WriteBlob(repo, []byte("test file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeID, err := NewTreeBuilder(repo).WriteRootTreeFromBlobIDs(map[string]plumbing.Hash{"dir/file": blobID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstCommitID, err := Commit(repo, treeID, "refs/heads/main", "Initial commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondCommitID, err := Commit(repo, EmptyTree(), "refs/heads/main", "Second commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	featureCommitID, err := Commit(repo, EmptyTree(), "refs/heads/feature", "Feature commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		bundle := &bytes.Buffer{}
+		err := WriteBundle(repo, bundle, map[string]plumbing.Hash{"refs/heads/main": secondCommitID})
+		assert.Nil(t, err)
+		assert.True(t, bytes.HasPrefix(bundle.Bytes(), []byte(bundleSignature+"\n"+secondCommitID.String()+" refs/heads/main\n\n")))
+
+		bundleRepo, err := ReadBundle(bundle)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ref, err := bundleRepo.Reference("refs/heads/main", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, secondCommitID, ref.Hash())
+
+		// History of the ref is included
+		commit, err := GetCommit(bundleRepo, firstCommitID)
+		assert.Nil(t, err)
+		assert.Equal(t, treeID, commit.TreeHash)
+		contents, err := ReadBlob(bundleRepo, blobID)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("test file"), contents)
+
+		// Objects not reachable from the bundle's refs are excluded
+		_, err = GetCommit(bundleRepo, featureCommitID)
+		assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+		_, err = bundleRepo.Reference("refs/heads/feature", true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	})
+
+	t.Run("import into existing repository", func(t *testing.T) {
+		bundle := &bytes.Buffer{}
+		if err := WriteBundle(repo, bundle, map[string]plumbing.Hash{"refs/heads/feature": featureCommitID}); err != nil {
+			t.Fatal(err)
+		}
+
+		targetRepo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refs, err := ImportBundle(targetRepo, bundle)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]plumbing.Hash{"refs/heads/feature": featureCommitID}, refs)
+
+		_, err = GetCommit(targetRepo, featureCommitID)
+		assert.Nil(t, err)
+
+		// Refs are left to the caller
+		_, err = targetRepo.Reference("refs/heads/feature", true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		_, err := ReadBundle(bytes.NewBufferString("# v3 git bundle\n\n"))
+		assert.ErrorIs(t, err, ErrInvalidBundle)
+	})
+
+	t.Run("bundle with prerequisites", func(t *testing.T) {
+		_, err := ReadBundle(bytes.NewBufferString(bundleSignature + "\n-" + firstCommitID.String() + " Initial commit\n\n"))
+		assert.ErrorIs(t, err, ErrInvalidBundle)
+	})
+}
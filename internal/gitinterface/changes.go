@@ -4,13 +4,51 @@ package gitinterface
 
 import (
 	"container/heap"
+	"context"
 	"fmt"
 	"sort"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
+// TreeChangeType identifies how a file changed between two trees.
+type TreeChangeType string
+
+const (
+	TreeChangeAdded    TreeChangeType = "added"
+	TreeChangeModified TreeChangeType = "modified"
+	TreeChangeDeleted  TreeChangeType = "deleted"
+	TreeChangeRenamed  TreeChangeType = "renamed"
+)
+
+// TreeChange describes a change to a single file between two trees. The From
+// fields describe the file in the first tree and are unset for added files.
+// The To fields describe the file in the second tree and are unset for deleted
+// files.
+type TreeChange struct {
+	Type TreeChangeType
+
+	FromPath string
+	FromMode filemode.FileMode
+	FromID   plumbing.Hash
+
+	ToPath string
+	ToMode filemode.FileMode
+	ToID   plumbing.Hash
+}
+
+// Path returns the path of the file in the second tree, or the path in the
+// first tree if the file was deleted.
+func (c *TreeChange) Path() string {
+	if c.Type == TreeChangeDeleted {
+		return c.FromPath
+	}
+	return c.ToPath
+}
+
 // GetCommitFilePaths returns all the file paths of the provided commit object.
 // This strictly enumerates all the files recursively in the commit object's
 // tree.
@@ -162,6 +200,71 @@ func diff(treeA, treeB *object.Tree) ([]string, error) {
 	return paths, nil
 }
 
+// DiffTrees returns the changes to files between the trees identified by
+// treeAID and treeBID, sorted by path. Either tree ID may be the zero hash,
+// which is treated as an empty tree. A change to a file's contents or mode is
+// reported as a modification. If detectRenames is set, a file that is moved,
+// possibly with changes to its contents, is reported as a rename rather than as
+// a deletion and an addition.
+func DiffTrees(repo *git.Repository, treeAID, treeBID plumbing.Hash, detectRenames bool) ([]*TreeChange, error) {
+	var treeA, treeB *object.Tree
+	if !treeAID.IsZero() {
+		tree, err := GetTree(repo, treeAID)
+		if err != nil {
+			return nil, err
+		}
+		treeA = tree
+	}
+	if !treeBID.IsZero() {
+		tree, err := GetTree(repo, treeBID)
+		if err != nil {
+			return nil, err
+		}
+		treeB = tree
+	}
+
+	var options *object.DiffTreeOptions
+	if detectRenames {
+		options = object.DefaultDiffTreeOptions
+	}
+
+	changes, err := object.DiffTreeWithOptions(context.Background(), treeA, treeB, options)
+	if err != nil {
+		return nil, err
+	}
+
+	treeChanges := make([]*TreeChange, 0, len(changes))
+	for _, change := range changes {
+		treeChange := &TreeChange{
+			FromPath: change.From.Name,
+			FromMode: change.From.TreeEntry.Mode,
+			FromID:   change.From.TreeEntry.Hash,
+			ToPath:   change.To.Name,
+			ToMode:   change.To.TreeEntry.Mode,
+			ToID:     change.To.TreeEntry.Hash,
+		}
+
+		switch {
+		case change.From.Name == "":
+			treeChange.Type = TreeChangeAdded
+		case change.To.Name == "":
+			treeChange.Type = TreeChangeDeleted
+		case change.From.Name != change.To.Name:
+			treeChange.Type = TreeChangeRenamed
+		default:
+			treeChange.Type = TreeChangeModified
+		}
+
+		treeChanges = append(treeChanges, treeChange)
+	}
+
+	sort.Slice(treeChanges, func(i, j int) bool {
+		return treeChanges[i].Path() < treeChanges[j].Path()
+	})
+
+	return treeChanges, nil
+}
+
 type diffHeap []string
 
 func (h diffHeap) Len() int           { return len(h) }
@@ -732,3 +732,87 @@ func TestGetFilePathsChangedByCommit(t *testing.T) {
 		assert.Equal(t, []string{"a"}, diffs)
 	})
 }
+
+func TestDiffTrees(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blobIDs := []plumbing.Hash{}
+	for i := 0; i < 3; i++ {
+		blobID, err := WriteBlob(repo, []byte(fmt.Sprintf("contents of file %d", i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		blobIDs = append(blobIDs, blobID)
+	}
+
+	treeBuilder := NewTreeBuilder(repo)
+	treeA, err := treeBuilder.WriteRootTreeFromBlobIDs(map[string]plumbing.Hash{
+		"a":       blobIDs[0],
+		"dir/b":   blobIDs[1],
+		"dir/old": blobIDs[2],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	treeB, err := treeBuilder.WriteRootTreeFromBlobIDs(map[string]plumbing.Hash{
+		"a":       blobIDs[1],
+		"dir/new": blobIDs[2],
+		"c":       blobIDs[0],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("without rename detection", func(t *testing.T) {
+		changes, err := DiffTrees(repo, treeA, treeB, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []*TreeChange{
+			{Type: TreeChangeModified, FromPath: "a", FromMode: filemode.Regular, FromID: blobIDs[0], ToPath: "a", ToMode: filemode.Regular, ToID: blobIDs[1]},
+			{Type: TreeChangeAdded, ToPath: "c", ToMode: filemode.Regular, ToID: blobIDs[0]},
+			{Type: TreeChangeDeleted, FromPath: "dir/b", FromMode: filemode.Regular, FromID: blobIDs[1]},
+			{Type: TreeChangeAdded, ToPath: "dir/new", ToMode: filemode.Regular, ToID: blobIDs[2]},
+			{Type: TreeChangeDeleted, FromPath: "dir/old", FromMode: filemode.Regular, FromID: blobIDs[2]},
+		}, changes)
+	})
+
+	t.Run("with rename detection", func(t *testing.T) {
+		changes, err := DiffTrees(repo, treeA, treeB, true)
+		assert.Nil(t, err)
+		assert.Len(t, changes, 4)
+		assert.Contains(t, changes, &TreeChange{Type: TreeChangeRenamed, FromPath: "dir/old", FromMode: filemode.Regular, FromID: blobIDs[2], ToPath: "dir/new", ToMode: filemode.Regular, ToID: blobIDs[2]})
+	})
+
+	t.Run("mode change", func(t *testing.T) {
+		regularTree, err := WriteTree(repo, []object.TreeEntry{{Name: "script", Mode: filemode.Regular, Hash: blobIDs[0]}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		executableTree, err := WriteTree(repo, []object.TreeEntry{{Name: "script", Mode: filemode.Executable, Hash: blobIDs[0]}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		changes, err := DiffTrees(repo, regularTree, executableTree, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []*TreeChange{
+			{Type: TreeChangeModified, FromPath: "script", FromMode: filemode.Regular, FromID: blobIDs[0], ToPath: "script", ToMode: filemode.Executable, ToID: blobIDs[0]},
+		}, changes)
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		changes, err := DiffTrees(repo, plumbing.ZeroHash, treeA, false)
+		assert.Nil(t, err)
+		assert.Len(t, changes, 3)
+		for _, change := range changes {
+			assert.Equal(t, TreeChangeAdded, change.Type)
+		}
+
+		changes, err = DiffTrees(repo, treeA, treeA, true)
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+}
@@ -5,9 +5,13 @@ package gitinterface
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/smime"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -18,32 +22,84 @@ import (
 )
 
 // Commit creates a new commit in the repo and sets targetRef's HEAD to the
-// commit.
+// commit. The commit is created and signed before anything is written to the
+// repository, and targetRef is only updated once the commit object has been
+// stored successfully. If any step fails, targetRef is left untouched.
 func Commit(repo *git.Repository, treeHash plumbing.Hash, targetRef string, message string, sign bool) (plumbing.Hash, error) {
 	gitConfig, err := getGitConfig(repo)
 	if err != nil {
 		return plumbing.ZeroHash, err
 	}
 
-	targetRefTyped := plumbing.ReferenceName(targetRef)
-	curRef, err := repo.Reference(targetRefTyped, true)
+	curRef, err := getReferenceForUpdate(repo, targetRef)
 	if err != nil {
-		// FIXME: this is a bit messy
-		if errors.Is(err, plumbing.ErrReferenceNotFound) {
-			// Set empty ref
-			if err := repo.Storer.SetReference(plumbing.NewHashReference(targetRefTyped, plumbing.ZeroHash)); err != nil {
-				return plumbing.ZeroHash, err
-			}
-			curRef, err = repo.Reference(targetRefTyped, true)
-			if err != nil {
-				return plumbing.ZeroHash, err
-			}
-		} else {
+		return plumbing.ZeroHash, err
+	}
+
+	commit := CreateCommitObject(gitConfig, treeHash, []plumbing.Hash{curRef.Hash()}, message, clock)
+
+	if sign {
+		signature, err := signCommit(commit)
+		if err != nil {
 			return plumbing.ZeroHash, err
 		}
+		commit.PGPSignature = signature
+	}
+
+	return ApplyCommit(repo, commit, curRef)
+}
+
+// CommitOptions overrides the identities and timestamps recorded in a commit
+// created using CommitWithOptions. The zero value of each field leaves the
+// corresponding value unchanged, so the identity in the user's Git config and
+// the current time are used.
+type CommitOptions struct {
+	// AuthorName and AuthorEmail set the identity of the commit's author.
+	AuthorName  string
+	AuthorEmail string
+
+	// AuthorTime sets the time the commit was authored at.
+	AuthorTime time.Time
+
+	// CommitterName and CommitterEmail set the identity of the commit's
+	// committer.
+	CommitterName  string
+	CommitterEmail string
+
+	// CommitterTime sets the time the commit was committed at.
+	CommitterTime time.Time
+}
+
+// CommitWithCommitter creates a new commit in the repo and sets targetRef's HEAD
+// to the commit, like Commit. However, the commit's committer is set to the
+// specified name and email rather than the identity in the user's Git config,
+// which is still used for the author. This allows automated workflows to use a
+// consistent identity as the committer. Note that if the commit is signed, the
+// signature is still created using the signing key in the user's Git config.
+func CommitWithCommitter(repo *git.Repository, treeHash plumbing.Hash, targetRef, message, committerName, committerEmail string, sign bool) (plumbing.Hash, error) {
+	return CommitWithOptions(repo, treeHash, targetRef, message, &CommitOptions{CommitterName: committerName, CommitterEmail: committerEmail}, sign)
+}
+
+// CommitWithOptions creates a new commit in the repo and sets targetRef's HEAD
+// to the commit, like Commit. The commit's author and committer identities and
+// timestamps are overridden using opts, which may be nil. Setting all of them
+// makes the commit's ID independent of the user's Git config and the current
+// time, which allows reproducing commits such as RSL entries. Note that if the
+// commit is signed, the signature is still created using the signing key in the
+// user's Git config.
+func CommitWithOptions(repo *git.Repository, treeHash plumbing.Hash, targetRef, message string, opts *CommitOptions, sign bool) (plumbing.Hash, error) {
+	gitConfig, err := getGitConfig(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	curRef, err := getReferenceForUpdate(repo, targetRef)
+	if err != nil {
+		return plumbing.ZeroHash, err
 	}
 
 	commit := CreateCommitObject(gitConfig, treeHash, []plumbing.Hash{curRef.Hash()}, message, clock)
+	applyCommitOptions(commit, opts)
 
 	if sign {
 		signature, err := signCommit(commit)
@@ -62,31 +118,28 @@ func Commit(repo *git.Repository, treeHash plumbing.Hash, targetRef string, mess
 // developer mode. In standard workflows, Commit() must be used instead which
 // infers the signing key from the user's Git config.
 func CommitUsingSpecificKey(repo *git.Repository, treeHash plumbing.Hash, targetRef, message string, signingKeyPEMBytes []byte) (plumbing.Hash, error) {
+	return CommitUsingSpecificKeyWithOptions(repo, treeHash, targetRef, message, nil, signingKeyPEMBytes)
+}
+
+// CommitUsingSpecificKeyWithOptions creates a new commit in the repository
+// signed using the PEM encoded SSH or GPG private key, like
+// CommitUsingSpecificKey. The commit's author and committer identities and
+// timestamps are overridden using opts, which may be nil, as in
+// CommitWithOptions.
+func CommitUsingSpecificKeyWithOptions(repo *git.Repository, treeHash plumbing.Hash, targetRef, message string, opts *CommitOptions, signingKeyPEMBytes []byte) (plumbing.Hash, error) {
 	// Fetch gitConfig for author / committer information
 	gitConfig, err := getGitConfig(repo)
 	if err != nil {
 		return plumbing.ZeroHash, err
 	}
 
-	targetRefTyped := plumbing.ReferenceName(targetRef)
-	curRef, err := repo.Reference(targetRefTyped, true)
+	curRef, err := getReferenceForUpdate(repo, targetRef)
 	if err != nil {
-		// FIXME: this is a bit messy
-		if errors.Is(err, plumbing.ErrReferenceNotFound) {
-			// Set empty ref
-			if err := repo.Storer.SetReference(plumbing.NewHashReference(targetRefTyped, plumbing.ZeroHash)); err != nil {
-				return plumbing.ZeroHash, err
-			}
-			curRef, err = repo.Reference(targetRefTyped, true)
-			if err != nil {
-				return plumbing.ZeroHash, err
-			}
-		} else {
-			return plumbing.ZeroHash, err
-		}
+		return plumbing.ZeroHash, err
 	}
 
 	commit := CreateCommitObject(gitConfig, treeHash, []plumbing.Hash{curRef.Hash()}, message, clock)
+	applyCommitOptions(commit, opts)
 
 	commitContents, err := getCommitBytesWithoutSignature(commit)
 	if err != nil {
@@ -101,8 +154,83 @@ func CommitUsingSpecificKey(repo *git.Repository, treeHash plumbing.Hash, target
 	return ApplyCommit(repo, commit, curRef)
 }
 
+// CommitWithParents creates a new commit in the repo with the specified
+// parents and sets targetRef's HEAD to the commit. Unlike Commit, the current
+// tip of targetRef is not implicitly added as a parent, which allows creating
+// merge commits.
+func CommitWithParents(repo *git.Repository, treeHash plumbing.Hash, parentIDs []plumbing.Hash, targetRef, message string, sign bool) (plumbing.Hash, error) {
+	gitConfig, err := getGitConfig(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	curRef, err := getReferenceForUpdate(repo, targetRef)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	for _, parentID := range parentIDs {
+		if _, err := GetCommit(repo, parentID); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	commit := CreateCommitObject(gitConfig, treeHash, parentIDs, message, clock)
+
+	if sign {
+		signature, err := signCommit(commit)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		commit.PGPSignature = signature
+	}
+
+	return ApplyCommit(repo, commit, curRef)
+}
+
+// CommitWithParent creates a new commit in the repo with parentID as its
+// parent. Unlike Commit, no reference is updated, and the new commit's ID is
+// returned so that the caller can build further commits on it. If parentID is
+// the zero hash, the new commit has no parent.
+func CommitWithParent(repo *git.Repository, treeHash, parentID plumbing.Hash, message string, sign bool) (plumbing.Hash, error) {
+	return CommitWithParentAndOptions(repo, treeHash, parentID, message, nil, sign)
+}
+
+// CommitWithParentAndOptions creates a new commit in the repo with parentID as
+// its parent, like CommitWithParent. The commit's author and committer
+// identities and timestamps are overridden using opts, which may be nil, as in
+// CommitWithOptions.
+func CommitWithParentAndOptions(repo *git.Repository, treeHash, parentID plumbing.Hash, message string, opts *CommitOptions, sign bool) (plumbing.Hash, error) {
+	gitConfig, err := getGitConfig(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if !parentID.IsZero() {
+		if _, err := GetCommit(repo, parentID); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	commit := CreateCommitObject(gitConfig, treeHash, []plumbing.Hash{parentID}, message, clock)
+	applyCommitOptions(commit, opts)
+
+	if sign {
+		signature, err := signCommit(commit)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		commit.PGPSignature = signature
+	}
+
+	return WriteCommit(repo, commit)
+}
+
 // ApplyCommit writes a commit object in the repository and updates the
-// specified reference to point to the commit.
+// specified reference to point to the commit. The reference is only updated if
+// it still points to curRef's target. If curRef points to the zero hash, the
+// reference is expected to not exist yet, and it is not updated if it has been
+// created in the meantime.
 func ApplyCommit(repo *git.Repository, commit *object.Commit, curRef *plumbing.Reference) (plumbing.Hash, error) {
 	commitHash, err := WriteCommit(repo, commit)
 	if err != nil {
@@ -110,9 +238,29 @@ func ApplyCommit(repo *git.Repository, commit *object.Commit, curRef *plumbing.R
 	}
 
 	newRef := plumbing.NewHashReference(curRef.Name(), commitHash)
+
+	// A missing reference is read as pointing to the zero hash, so curRef is
+	// compared against even if it points to the zero hash
 	return commitHash, repo.Storer.CheckAndSetReference(newRef, curRef)
 }
 
+// getReferenceForUpdate returns the current state of targetRef. If targetRef
+// doesn't exist, a reference pointing to the zero hash is returned. This
+// placeholder is not written to the repository, so that a failure before the
+// new commit is applied does not leave behind a dangling reference.
+func getReferenceForUpdate(repo *git.Repository, targetRef string) (*plumbing.Reference, error) {
+	targetRefTyped := plumbing.ReferenceName(targetRef)
+	curRef, err := repo.Reference(targetRefTyped, true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return plumbing.NewHashReference(targetRefTyped, plumbing.ZeroHash), nil
+		}
+		return nil, err
+	}
+
+	return curRef, nil
+}
+
 // WriteCommit stores the commit object in the repository's object store,
 // returning the new commit's ID.
 func WriteCommit(repo *git.Repository, commit *object.Commit) (plumbing.Hash, error) {
@@ -127,40 +275,67 @@ func WriteCommit(repo *git.Repository, commit *object.Commit) (plumbing.Hash, er
 // VerifyCommitSignature is used to verify a cryptographic signature associated
 // with commit using TUF public keys.
 func VerifyCommitSignature(ctx context.Context, commit *object.Commit, key *tuf.Key) error {
+	_, err := GetCommitSigningKeyID(ctx, commit, key)
+	return err
+}
+
+// GetCommitSigningKeyID verifies the commit's signature using key, like
+// VerifyCommitSignature, and returns the ID of the key that created the
+// signature. For GPG keys, this is the fingerprint of the primary key or of the
+// subkey that created the signature, while key's ID is always the primary
+// key's fingerprint. For other keys, it is key's ID.
+func GetCommitSigningKeyID(ctx context.Context, commit *object.Commit, key *tuf.Key) (string, error) {
 	switch key.KeyType {
 	case signerverifier.GPGKeyType:
-		if _, err := commit.Verify(key.KeyVal.Public); err != nil {
-			return ErrIncorrectVerificationKey
+		commitContents, err := getCommitBytesWithoutSignature(commit)
+		if err != nil {
+			return "", errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		signingKeyID, err := gpg.VerifySignature(key, commitContents, []byte(commit.PGPSignature), getSignatureVerificationTime(ctx))
+		if err != nil {
+			return "", errors.Join(ErrIncorrectVerificationKey, err)
 		}
 
-		return nil
+		return signingKeyID, nil
 	case signerverifier.RSAKeyType, signerverifier.ECDSAKeyType, signerverifier.ED25519KeyType:
 		commitContents, err := getCommitBytesWithoutSignature(commit)
 		if err != nil {
-			return errors.Join(ErrVerifyingSSHSignature, err)
+			return "", errors.Join(ErrVerifyingSSHSignature, err)
 		}
 		commitSignature := []byte(commit.PGPSignature)
 
 		if err := verifySSHKeySignature(key, commitContents, commitSignature); err != nil {
-			return errors.Join(ErrIncorrectVerificationKey, err)
+			return "", errors.Join(ErrIncorrectVerificationKey, err)
 		}
 
-		return nil
+		return key.KeyID, nil
 	case signerverifier.FulcioKeyType:
 		commitContents, err := getCommitBytesWithoutSignature(commit)
 		if err != nil {
-			return errors.Join(ErrVerifyingSigstoreSignature, err)
+			return "", errors.Join(ErrVerifyingSigstoreSignature, err)
 		}
 		commitSignature := []byte(commit.PGPSignature)
 
 		if err := verifyGitsignSignature(ctx, key, commitContents, commitSignature); err != nil {
-			return errors.Join(ErrIncorrectVerificationKey, err)
+			return "", errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		return key.KeyID, nil
+	case signerverifier.X509KeyType:
+		commitContents, err := getCommitBytesWithoutSignature(commit)
+		if err != nil {
+			return "", errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		if _, err := smime.VerifySignature(key, commitContents, []byte(commit.PGPSignature), getSignatureVerificationTime(ctx)); err != nil {
+			return "", errors.Join(ErrIncorrectVerificationKey, err)
 		}
 
-		return nil
+		return key.KeyID, nil
 	}
 
-	return ErrUnknownSigningMethod
+	return "", ErrUnknownSigningMethod
 }
 
 // CreateCommitObject returns a commit object using the specified parameters.
@@ -190,6 +365,33 @@ func CreateCommitObject(gitConfig *config.Config, treeHash plumbing.Hash, parent
 	return commit
 }
 
+// applyCommitOptions overrides the commit's author and committer identities
+// and timestamps using the fields set in opts, which may be nil.
+func applyCommitOptions(commit *object.Commit, opts *CommitOptions) {
+	if opts == nil {
+		return
+	}
+
+	if opts.AuthorName != "" {
+		commit.Author.Name = opts.AuthorName
+	}
+	if opts.AuthorEmail != "" {
+		commit.Author.Email = opts.AuthorEmail
+	}
+	if !opts.AuthorTime.IsZero() {
+		commit.Author.When = opts.AuthorTime
+	}
+	if opts.CommitterName != "" {
+		commit.Committer.Name = opts.CommitterName
+	}
+	if opts.CommitterEmail != "" {
+		commit.Committer.Email = opts.CommitterEmail
+	}
+	if !opts.CommitterTime.IsZero() {
+		commit.Committer.When = opts.CommitterTime
+	}
+}
+
 // KnowsCommit indicates if the commit under test, identified by commitID, has a
 // path to commit. If commit is the same as the commit under test or if commit
 // is an ancestor of commit under test, KnowsCommit returns true.
@@ -198,12 +400,127 @@ func KnowsCommit(repo *git.Repository, commitID plumbing.Hash, commit *object.Co
 		return true, nil
 	}
 
-	commitUnderTest, err := GetCommit(repo, commitID)
-	if err != nil {
+	ancestors := map[plumbing.Hash]bool{commit.Hash: false}
+	if err := findAncestors(repo, commitID, ancestors); err != nil {
 		return false, err
 	}
 
-	return commit.IsAncestor(commitUnderTest)
+	return ancestors[commit.Hash], nil
+}
+
+// AncestryQuery asks whether the commit identified by AncestorID is an
+// ancestor of the commit identified by DescendantID. Like KnowsCommit, a commit
+// is considered to be its own ancestor.
+type AncestryQuery struct {
+	AncestorID   plumbing.Hash
+	DescendantID plumbing.Hash
+}
+
+// IsAncestorBatch answers the specified ancestry queries, returning the result
+// for each query in the same order. Queries for the same descendant are
+// answered using a single walk of its history, which stops as soon as every
+// ancestor queried for it has been found.
+func IsAncestorBatch(repo *git.Repository, queries []AncestryQuery) ([]bool, error) {
+	results := make([]bool, len(queries))
+
+	ancestorsByDescendant := map[plumbing.Hash]map[plumbing.Hash]bool{}
+	for _, query := range queries {
+		if _, has := ancestorsByDescendant[query.DescendantID]; !has {
+			ancestorsByDescendant[query.DescendantID] = map[plumbing.Hash]bool{}
+		}
+		ancestorsByDescendant[query.DescendantID][query.AncestorID] = false
+	}
+
+	for descendantID, ancestors := range ancestorsByDescendant {
+		if err := findAncestors(repo, descendantID, ancestors); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, query := range queries {
+		results[i] = ancestorsByDescendant[query.DescendantID][query.AncestorID]
+	}
+
+	return results, nil
+}
+
+// findAncestors walks the history of the specified descendant, marking each
+// commit in ancestors that is found. In shallow clones, the walk stops at
+// graft points. If an ancestor isn't found before reaching a graft point,
+// ErrShallowBoundary is returned as the result depends on the missing history.
+func findAncestors(repo *git.Repository, descendantID plumbing.Hash, ancestors map[plumbing.Hash]bool) error {
+	shallowCommits, err := GetShallowCommits(repo)
+	if err != nil {
+		return err
+	}
+	graftPoints := make(map[plumbing.Hash]bool, len(shallowCommits))
+	for _, shallowCommit := range shallowCommits {
+		graftPoints[shallowCommit] = true
+	}
+
+	remaining := len(ancestors)
+	seen := map[plumbing.Hash]bool{descendantID: true}
+	queue := []plumbing.Hash{descendantID}
+	boundaryID := plumbing.ZeroHash
+
+	for len(queue) != 0 && remaining != 0 {
+		commitID := queue[0]
+		queue = queue[1:]
+
+		if _, has := ancestors[commitID]; has {
+			ancestors[commitID] = true
+			remaining--
+		}
+
+		commit, err := GetCommit(repo, commitID)
+		if err != nil {
+			return err
+		}
+
+		if graftPoints[commitID] {
+			if boundaryID.IsZero() {
+				boundaryID = commitID
+			}
+			continue
+		}
+
+		for _, parentID := range commit.ParentHashes {
+			if !seen[parentID] {
+				seen[parentID] = true
+				queue = append(queue, parentID)
+			}
+		}
+	}
+
+	if remaining != 0 && !boundaryID.IsZero() {
+		return fmt.Errorf("%w: parents of commit '%s' are missing", ErrShallowBoundary, boundaryID.String())
+	}
+
+	return nil
+}
+
+// GetMergeBase returns the best common ancestor of the two specified commits.
+// If the commits have no common ancestor, the zero hash is returned. If there
+// are multiple best common ancestors, only one of them is returned.
+func GetMergeBase(repo *git.Repository, commitAID, commitBID plumbing.Hash) (plumbing.Hash, error) {
+	commitA, err := GetCommit(repo, commitAID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	commitB, err := GetCommit(repo, commitBID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	mergeBases, err := commitA.MergeBase(commitB)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if len(mergeBases) == 0 {
+		return plumbing.ZeroHash, nil
+	}
+
+	return mergeBases[0].Hash, nil
 }
 
 // GetCommit returns the requested commit object.
@@ -211,6 +528,22 @@ func GetCommit(repo *git.Repository, commitID plumbing.Hash) (*object.Commit, er
 	return repo.CommitObject(commitID)
 }
 
+// GetCommits returns the requested commit objects in the order of commitIDs.
+// Like ReadBlobs, the objects are read through the repository's object
+// storage and its object cache.
+func GetCommits(repo *git.Repository, commitIDs []plumbing.Hash) ([]*object.Commit, error) {
+	commits := make([]*object.Commit, 0, len(commitIDs))
+	for _, commitID := range commitIDs {
+		commit, err := GetCommit(repo, commitID)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
 func signCommit(commit *object.Commit) (string, error) {
 	commitContents, err := getCommitBytesWithoutSignature(commit)
 	if err != nil {
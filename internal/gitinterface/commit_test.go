@@ -5,6 +5,7 @@ package gitinterface
 import (
 	"bytes"
 	"context"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/hiddeco/sshsig"
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/ssh"
 )
@@ -75,6 +77,336 @@ func TestCreateCommitObject(t *testing.T) {
 	})
 }
 
+func TestCommitWithCommitter(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	treeHash := EmptyTree()
+
+	firstCommitID, err := Commit(repo, treeHash, refName, "Initial commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := CommitWithCommitter(repo, treeHash, refName, "Test commit", "gittuf", "gittuf@example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(refName), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, commitID, ref.Hash())
+
+	commit, err := GetCommit(repo, commitID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "gittuf", commit.Committer.Name)
+	assert.Equal(t, "gittuf@example.com", commit.Committer.Email)
+	assert.NotEqual(t, commit.Committer.Name, commit.Author.Name)
+	assert.Equal(t, []plumbing.Hash{firstCommitID}, commit.ParentHashes)
+}
+
+func TestCommitWithOptions(t *testing.T) {
+	refName := "refs/heads/main"
+	treeHash := EmptyTree()
+
+	authorTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	committerTime := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+	opts := &CommitOptions{
+		AuthorName:     "Jane Doe",
+		AuthorEmail:    "jane.doe@example.com",
+		AuthorTime:     authorTime,
+		CommitterName:  "gittuf",
+		CommitterEmail: "gittuf@example.com",
+		CommitterTime:  committerTime,
+	}
+
+	t.Run("all fields set", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitID, err := CommitWithOptions(repo, treeHash, refName, "Test commit", opts, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ref, err := repo.Reference(plumbing.ReferenceName(refName), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, commitID, ref.Hash())
+
+		commit, err := GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "Jane Doe", commit.Author.Name)
+		assert.Equal(t, "jane.doe@example.com", commit.Author.Email)
+		assert.True(t, authorTime.Equal(commit.Author.When))
+		assert.Equal(t, "gittuf", commit.Committer.Name)
+		assert.Equal(t, "gittuf@example.com", commit.Committer.Email)
+		assert.True(t, committerTime.Equal(commit.Committer.When))
+	})
+
+	t.Run("identical options create identical commits", func(t *testing.T) {
+		firstRepo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		secondRepo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		firstCommitID, err := CommitWithOptions(firstRepo, treeHash, refName, "Test commit", opts, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Change the clock to ensure it's not used for the commit
+		originalClock := clock
+		clock = clockwork.NewFakeClockAt(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+		defer func() {
+			clock = originalClock
+		}()
+
+		secondCommitID, err := CommitWithOptions(secondRepo, treeHash, refName, "Test commit", opts, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, firstCommitID, secondCommitID)
+	})
+
+	t.Run("nil options", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitID, err := CommitWithOptions(repo, treeHash, refName, "Test commit", nil, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commit, err := GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, commit.Author.Name, commit.Committer.Name)
+		assert.Equal(t, commit.Author.Email, commit.Committer.Email)
+		assert.True(t, commit.Author.When.Equal(commit.Committer.When))
+	})
+}
+
+func TestCommitSigningFailure(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	treeHash := EmptyTree()
+
+	commitID, err := Commit(repo, treeHash, refName, "Initial commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Inject a git config with an unknown signing method
+	originalGetGitConfigFromCommand := getGitConfigFromCommand
+	getGitConfigFromCommand = func() (io.Reader, error) {
+		return bytes.NewReader(testConfig4), nil
+	}
+	defer func() {
+		getGitConfigFromCommand = originalGetGitConfigFromCommand
+	}()
+
+	t.Run("existing ref is not updated", func(t *testing.T) {
+		_, err := Commit(repo, treeHash, refName, "Signed commit", true)
+		assert.ErrorIs(t, err, ErrUnknownSigningMethod)
+
+		ref, err := repo.Reference(plumbing.ReferenceName(refName), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, commitID, ref.Hash())
+	})
+
+	t.Run("new ref is not created", func(t *testing.T) {
+		_, err := Commit(repo, treeHash, "refs/heads/feature", "Signed commit", true)
+		assert.ErrorIs(t, err, ErrUnknownSigningMethod)
+
+		_, err = repo.Reference(plumbing.ReferenceName("refs/heads/feature"), true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	})
+}
+
+func TestApplyCommit(t *testing.T) {
+	refName := plumbing.ReferenceName("refs/heads/main")
+
+	repos := map[string]func(t *testing.T) *git.Repository{
+		"in-memory": func(t *testing.T) *git.Repository {
+			t.Helper()
+
+			repo, err := git.Init(memory.NewStorage(), memfs.New())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return repo
+		},
+		"on disk": func(t *testing.T) *git.Repository {
+			t.Helper()
+
+			repo, err := git.PlainInit(t.TempDir(), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return repo
+		},
+	}
+
+	for name, createRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			t.Run("missing ref is created", func(t *testing.T) {
+				repo := createRepo(t)
+
+				commit := CreateCommitObject(testGitConfig, EmptyTree(), []plumbing.Hash{}, "Initial commit", testClock)
+				commitID, err := ApplyCommit(repo, commit, plumbing.NewHashReference(refName, plumbing.ZeroHash))
+				assert.Nil(t, err)
+
+				ref, err := repo.Reference(refName, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, commitID, ref.Hash())
+			})
+
+			t.Run("ref created concurrently is not overwritten", func(t *testing.T) {
+				repo := createRepo(t)
+
+				// The ref is read as missing...
+				curRef := plumbing.NewHashReference(refName, plumbing.ZeroHash)
+
+				// ...but another writer creates it before the commit is
+				// applied
+				concurrentCommit := CreateCommitObject(testGitConfig, EmptyTree(), []plumbing.Hash{}, "Concurrent commit", testClock)
+				concurrentCommitID, err := ApplyCommit(repo, concurrentCommit, curRef)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				commit := CreateCommitObject(testGitConfig, EmptyTree(), []plumbing.Hash{}, "Initial commit", testClock)
+				_, err = ApplyCommit(repo, commit, curRef)
+				assert.NotNil(t, err)
+
+				ref, err := repo.Reference(refName, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, concurrentCommitID, ref.Hash())
+			})
+
+			t.Run("ref updated concurrently is not overwritten", func(t *testing.T) {
+				repo := createRepo(t)
+
+				firstCommit := CreateCommitObject(testGitConfig, EmptyTree(), []plumbing.Hash{}, "Initial commit", testClock)
+				firstCommitID, err := ApplyCommit(repo, firstCommit, plumbing.NewHashReference(refName, plumbing.ZeroHash))
+				if err != nil {
+					t.Fatal(err)
+				}
+				curRef := plumbing.NewHashReference(refName, firstCommitID)
+
+				concurrentCommit := CreateCommitObject(testGitConfig, EmptyTree(), []plumbing.Hash{firstCommitID}, "Concurrent commit", testClock)
+				concurrentCommitID, err := ApplyCommit(repo, concurrentCommit, curRef)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				commit := CreateCommitObject(testGitConfig, EmptyTree(), []plumbing.Hash{firstCommitID}, "Second commit", testClock)
+				_, err = ApplyCommit(repo, commit, curRef)
+				assert.NotNil(t, err)
+
+				ref, err := repo.Reference(refName, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, concurrentCommitID, ref.Hash())
+			})
+		})
+	}
+}
+
+func TestCommitWithParents(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	featureRefName := "refs/heads/feature"
+	treeHash := EmptyTree()
+
+	mainCommitID, err := Commit(repo, treeHash, refName, "Initial commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	featureCommitID, err := Commit(repo, treeHash, featureRefName, "Feature commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("merge commit", func(t *testing.T) {
+		mergeCommitID, err := CommitWithParents(repo, treeHash, []plumbing.Hash{mainCommitID, featureCommitID}, refName, "Merge commit", false)
+		assert.Nil(t, err)
+
+		ref, err := repo.Reference(plumbing.ReferenceName(refName), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, mergeCommitID, ref.Hash())
+
+		mergeCommit, err := GetCommit(repo, mergeCommitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []plumbing.Hash{mainCommitID, featureCommitID}, mergeCommit.ParentHashes)
+		assert.Equal(t, "Merge commit", mergeCommit.Message)
+
+		featureCommit, err := GetCommit(repo, featureCommitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		knows, err := KnowsCommit(repo, mergeCommitID, featureCommit)
+		assert.Nil(t, err)
+		assert.True(t, knows)
+	})
+
+	t.Run("commit with no parents", func(t *testing.T) {
+		commitID, err := CommitWithParents(repo, treeHash, nil, "refs/heads/orphan", "Orphan commit", false)
+		assert.Nil(t, err)
+
+		commit, err := GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Empty(t, commit.ParentHashes)
+	})
+
+	t.Run("unknown parent", func(t *testing.T) {
+		_, err := CommitWithParents(repo, treeHash, []plumbing.Hash{mainCommitID, plumbing.NewHash("abcdef1234567890")}, refName, "Merge commit", false)
+		assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+	})
+}
+
 func TestVerifyCommitSignature(t *testing.T) {
 	gpgSignedCommit := createTestSignedCommit(t)
 
@@ -152,6 +484,12 @@ oYBpMWLgg6AUzpxx9mITZ2EKr4c=
 		assert.Nil(t, err)
 	})
 
+	t.Run("gpg signed commit verified before key was created", func(t *testing.T) {
+		ctx := WithSignatureVerificationTime(context.Background(), time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+		err := VerifyCommitSignature(ctx, gpgSignedCommit, gpgKey)
+		assert.ErrorIs(t, err, ErrIncorrectVerificationKey)
+	})
+
 	// FIXME: fix gitsign testing
 	// t.Run("gitsign signed commit", func(t *testing.T) {
 	// 	err := VerifyCommitSignature(context.Background(), gitsignSignedCommit, fulcioKey)
@@ -185,6 +523,125 @@ oYBpMWLgg6AUzpxx9mITZ2EKr4c=
 	})
 }
 
+func TestGetCommitSigningKeyID(t *testing.T) {
+	gpgSignedCommit := createTestSignedCommit(t)
+	sshCommits := createTestSSHSignedCommits(t)
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := sslibsv.LoadKey(rsaSSHPublicKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingKeyID, err := GetCommitSigningKeyID(context.Background(), gpgSignedCommit, gpgKey)
+	assert.Nil(t, err)
+	assert.Equal(t, gpgKey.KeyID, signingKeyID)
+
+	signingKeyID, err = GetCommitSigningKeyID(context.Background(), sshCommits[0], rsaKey)
+	assert.Nil(t, err)
+	assert.Equal(t, rsaKey.KeyID, signingKeyID)
+
+	_, err = GetCommitSigningKeyID(context.Background(), sshCommits[0], gpgKey)
+	assert.ErrorIs(t, err, ErrIncorrectVerificationKey)
+}
+
+func TestIsAncestorBatch(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := createTestBranchingHistory(t, repo)
+
+	results, err := IsAncestorBatch(repo, []AncestryQuery{
+		{AncestorID: commitIDs["root"], DescendantID: commitIDs["a2"]},
+		{AncestorID: commitIDs["a1"], DescendantID: commitIDs["a2"]},
+		{AncestorID: commitIDs["b1"], DescendantID: commitIDs["a2"]},
+		{AncestorID: commitIDs["a2"], DescendantID: commitIDs["a2"]},
+		{AncestorID: commitIDs["a2"], DescendantID: commitIDs["a1"]},
+		{AncestorID: commitIDs["b1"], DescendantID: commitIDs["merge"]},
+		{AncestorID: commitIDs["a1"], DescendantID: commitIDs["merge"]},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{true, true, false, true, false, true, true}, results)
+
+	results, err = IsAncestorBatch(repo, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, results)
+
+	_, err = IsAncestorBatch(repo, []AncestryQuery{{AncestorID: commitIDs["root"], DescendantID: plumbing.NewHash("abcdef12345678")}})
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+}
+
+func TestGetMergeBase(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := createTestBranchingHistory(t, repo)
+
+	mergeBase, err := GetMergeBase(repo, commitIDs["a2"], commitIDs["b1"])
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs["root"], mergeBase)
+
+	mergeBase, err = GetMergeBase(repo, commitIDs["a1"], commitIDs["a2"])
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs["a1"], mergeBase)
+
+	mergeBase, err = GetMergeBase(repo, commitIDs["merge"], commitIDs["b1"])
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs["b1"], mergeBase)
+
+	unrelatedCommitID, err := CommitWithParent(repo, EmptyTree(), plumbing.ZeroHash, "Unrelated commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mergeBase, err = GetMergeBase(repo, commitIDs["a2"], unrelatedCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.ZeroHash, mergeBase)
+}
+
+// createTestBranchingHistory creates two branches, a1 -> a2 and b1, off a root
+// commit, and a merge commit with a2 and b1 as its parents.
+func createTestBranchingHistory(t *testing.T, repo *git.Repository) map[string]plumbing.Hash {
+	t.Helper()
+
+	emptyTreeHash, err := WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := map[string]plumbing.Hash{}
+	for _, commit := range []struct {
+		name   string
+		parent string
+	}{
+		{name: "root"},
+		{name: "a1", parent: "root"},
+		{name: "a2", parent: "a1"},
+		{name: "b1", parent: "root"},
+	} {
+		commitID, err := CommitWithParent(repo, emptyTreeHash, commitIDs[commit.parent], commit.name, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitIDs[commit.name] = commitID
+	}
+
+	mergeCommit := CreateCommitObject(testGitConfig, emptyTreeHash, []plumbing.Hash{commitIDs["a2"], commitIDs["b1"]}, "merge", testClock)
+	mergeCommitID, err := WriteCommit(repo, mergeCommit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitIDs["merge"] = mergeCommitID
+
+	return commitIDs
+}
+
 func TestKnowsCommit(t *testing.T) {
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
@@ -347,3 +804,34 @@ func createTestSSHSignedCommits(t *testing.T) []*object.Commit {
 
 	return testCommits
 }
+
+func TestGetCommits(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	firstCommitID, err := Commit(repo, EmptyTree(), refName, "First commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondCommitID, err := Commit(repo, EmptyTree(), refName, "Second commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := GetCommits(repo, []plumbing.Hash{secondCommitID, firstCommitID})
+	assert.Nil(t, err)
+	if assert.Len(t, commits, 2) {
+		assert.Equal(t, secondCommitID, commits[0].Hash)
+		assert.Equal(t, firstCommitID, commits[1].Hash)
+	}
+
+	_, err = GetCommits(repo, []plumbing.Hash{firstCommitID, plumbing.ZeroHash})
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+}
@@ -10,8 +10,10 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/jonboulle/clockwork"
 )
 
 var (
@@ -57,6 +59,31 @@ func execGitConfig() (io.Reader, error) {
 	return stdout, nil
 }
 
+// UseFixedIdentity configures gitinterface to author all subsequent commits and
+// tags using the identity in gitConfig and timestamps from fixedClock, rather
+// than the user's Git config and the current time. This makes the objects
+// created deterministic. The returned function restores the original behavior.
+// As the setting is global, it must not be used concurrently with other
+// operations. This is only intended for use in gittuf's developer mode.
+func UseFixedIdentity(gitConfig *config.Config, fixedClock clockwork.Clock) (func(), error) {
+	if !dev.InDevMode() {
+		return nil, dev.ErrNotInDevMode
+	}
+
+	originalGetGitConfig := getGitConfig
+	originalClock := clock
+
+	getGitConfig = func(_ *git.Repository) (*config.Config, error) {
+		return gitConfig, nil
+	}
+	clock = fixedClock
+
+	return func() {
+		getGitConfig = originalGetGitConfig
+		clock = originalClock
+	}, nil
+}
+
 func getRealGitConfig(repo *git.Repository) (*config.Config, error) {
 	return repo.ConfigScoped(config.GlobalScope)
 }
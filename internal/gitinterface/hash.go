@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	formatconfig "github.com/go-git/go-git/v5/plumbing/format/config"
+	"github.com/go-git/go-git/v5/plumbing/hash"
+)
+
+var (
+	ErrInvalidHash             = errors.New("invalid Git object hash")
+	ErrUnsupportedObjectFormat = errors.New("repository object format is not supported by this build of gittuf")
+)
+
+// SupportedObjectFormat returns the object format, i.e. the hash algorithm,
+// that gittuf was built for. go-git selects the hash algorithm at build time,
+// and SHA-256 repositories require building gittuf with the sha256 build tag.
+func SupportedObjectFormat() formatconfig.ObjectFormat {
+	if hash.Size == 32 {
+		return formatconfig.SHA256
+	}
+
+	return formatconfig.SHA1
+}
+
+// GetObjectFormat returns the object format of the repository, as specified
+// by the extensions.objectFormat setting. Repositories that don't set it use
+// SHA-1.
+func GetObjectFormat(repo *git.Repository) (formatconfig.ObjectFormat, error) {
+	config, err := repo.Config()
+	if err != nil {
+		return "", err
+	}
+
+	objectFormat := config.Raw.Section("extensions").Option("objectformat")
+	if objectFormat == "" {
+		return formatconfig.DefaultObjectFormat, nil
+	}
+
+	return formatconfig.ObjectFormat(strings.ToLower(objectFormat)), nil
+}
+
+// CheckObjectFormat returns an error if the repository's object format does
+// not match the object format gittuf was built for.
+func CheckObjectFormat(repo *git.Repository) error {
+	objectFormat, err := GetObjectFormat(repo)
+	if err != nil {
+		return err
+	}
+
+	if objectFormat != SupportedObjectFormat() {
+		return fmt.Errorf("%w: repository uses '%s', expected '%s'", ErrUnsupportedObjectFormat, objectFormat, SupportedObjectFormat())
+	}
+
+	return nil
+}
+
+// NewHash returns the Hash for the hexadecimal representation in s. Unlike
+// plumbing.NewHash, which silently truncates or pads its input, an error is
+// returned if s is not a valid hash for the supported object format.
+func NewHash(s string) (plumbing.Hash, error) {
+	if len(s) != hash.HexSize {
+		return plumbing.ZeroHash, fmt.Errorf("%w: '%s' has length %d, expected %d", ErrInvalidHash, s, len(s), hash.HexSize)
+	}
+
+	if _, err := hex.DecodeString(s); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("%w: '%s' is not hex encoded", ErrInvalidHash, s)
+	}
+
+	return plumbing.NewHash(s), nil
+}
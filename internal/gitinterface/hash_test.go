@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	formatconfig "github.com/go-git/go-git/v5/plumbing/format/config"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectFormat(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objectFormat, err := GetObjectFormat(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, formatconfig.SHA1, objectFormat)
+	assert.Nil(t, CheckObjectFormat(repo))
+
+	config, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Raw.Section("extensions").SetOption("objectFormat", "sha256")
+	if err := repo.SetConfig(config); err != nil {
+		t.Fatal(err)
+	}
+
+	objectFormat, err = GetObjectFormat(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, formatconfig.SHA256, objectFormat)
+	assert.ErrorIs(t, CheckObjectFormat(repo), ErrUnsupportedObjectFormat)
+}
+
+func TestNewHash(t *testing.T) {
+	tests := map[string]struct {
+		hash          string
+		expectedError error
+	}{
+		"valid hash": {
+			hash: EmptyTree().String(),
+		},
+		"truncated hash": {
+			hash:          EmptyTree().String()[:8],
+			expectedError: ErrInvalidHash,
+		},
+		"sha-256 length hash": {
+			hash:          "6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321",
+			expectedError: ErrInvalidHash,
+		},
+		"non-hex hash": {
+			hash:          "zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz",
+			expectedError: ErrInvalidHash,
+		},
+	}
+
+	for name, test := range tests {
+		hash, err := NewHash(test.hash)
+		if test.expectedError != nil {
+			assert.ErrorIs(t, err, test.expectedError, name)
+			assert.Equal(t, plumbing.ZeroHash, hash, name)
+		} else {
+			assert.Nil(t, err, name)
+			assert.Equal(t, test.hash, hash.String(), name)
+		}
+	}
+}
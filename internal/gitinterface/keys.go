@@ -12,11 +12,14 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/hiddeco/sshsig"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/kms"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshagent"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
 	gitsignVerifier "github.com/sigstore/gitsign/pkg/git"
@@ -57,6 +60,30 @@ const (
 	genericPrivateKeyPEMHeader string = "PRIVATE KEY"
 )
 
+type signatureVerificationTimeKey struct{}
+
+// WithSignatureVerificationTime returns a copy of ctx that records the time at
+// which the expiration of GPG keys and X.509 certificates is evaluated when
+// verifying signatures. The time claimed by a signature is controlled by its
+// signer, and so it is never used to evaluate the signer's key. The recorded
+// time isn't trusted either, so GPG key revocations are always evaluated at the
+// current time.
+func WithSignatureVerificationTime(ctx context.Context, verifyAt time.Time) context.Context {
+	return context.WithValue(ctx, signatureVerificationTimeKey{}, verifyAt)
+}
+
+// getSignatureVerificationTime returns the time recorded in ctx using
+// WithSignatureVerificationTime. If no time is recorded, the current time is
+// returned.
+func getSignatureVerificationTime(ctx context.Context) time.Time {
+	verifyAt, has := ctx.Value(signatureVerificationTimeKey{}).(time.Time)
+	if !has || verifyAt.IsZero() {
+		return time.Now()
+	}
+
+	return verifyAt
+}
+
 func GetSigningCommand() (string, []string, error) {
 	var args []string
 
@@ -234,6 +261,13 @@ func signGitObject(contents []byte) (string, error) {
 }
 
 func signGitObjectUsingKey(contents, pemKeyBytes []byte) (string, error) {
+	if kms.IsKeyReference(string(pemKeyBytes)) {
+		return signGitObjectUsingKMSKey(contents, string(pemKeyBytes))
+	}
+	if sshagent.IsKeyReference(string(pemKeyBytes)) {
+		return signGitObjectUsingSSHAgentKey(contents, string(pemKeyBytes))
+	}
+
 	block, _ := pem.Decode(pemKeyBytes)
 	if block == nil {
 		// openpgp implements its own armor-decode method, pem.Decode considers
@@ -284,6 +318,45 @@ func signGitObjectUsingSSHKey(contents, pemKeyBytes []byte) (string, error) {
 	return string(sigBytes), nil
 }
 
+// signGitObjectUsingKMSKey creates an SSH signature using the KMS key
+// referenced by keyRef. The signature can be verified like signatures issued
+// using the ECDSA, RSA, or ED25519 key stored on disk.
+func signGitObjectUsingKMSKey(contents []byte, keyRef string) (string, error) {
+	cryptoSigner, err := kms.LoadCryptoSigner(context.Background(), keyRef)
+	if err != nil {
+		return "", errors.Join(ErrUnableToSign, err)
+	}
+
+	signer, err := ssh.NewSignerFromSigner(cryptoSigner)
+	if err != nil {
+		return "", errors.Join(ErrUnableToSign, err)
+	}
+
+	sshSig, err := sshsig.Sign(bytes.NewReader(contents), signer, sshsig.HashSHA512, namespaceSSHSignature)
+	if err != nil {
+		return "", err
+	}
+
+	return string(sshsig.Armor(sshSig)), nil
+}
+
+// signGitObjectUsingSSHAgentKey creates an SSH signature using the ssh-agent
+// key referenced by keyRef.
+func signGitObjectUsingSSHAgentKey(contents []byte, keyRef string) (string, error) {
+	signer, closeAgent, err := sshagent.LoadSigner(keyRef)
+	if err != nil {
+		return "", errors.Join(ErrUnableToSign, err)
+	}
+	defer closeAgent() //nolint:errcheck
+
+	sshSig, err := sshsig.Sign(bytes.NewReader(contents), signer, sshsig.HashSHA512, namespaceSSHSignature)
+	if err != nil {
+		return "", err
+	}
+
+	return string(sshsig.Armor(sshSig)), nil
+}
+
 // verifyGitsignSignature handles the Sigstore-specific workflow involved in
 // verifying commit or tag signatures issued by gitsign.
 func verifyGitsignSignature(ctx context.Context, key *tuf.Key, data, signature []byte) error {
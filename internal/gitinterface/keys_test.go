@@ -13,6 +13,8 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	format "github.com/go-git/go-git/v5/plumbing/format/config"
 	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/hiddeco/sshsig"
+	"github.com/sigstore/sigstore/pkg/signature/kms/fake"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -176,3 +178,15 @@ func TestGetSigningInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestSignGitObjectUsingKMSKey(t *testing.T) {
+	signature, err := signGitObjectUsingKey([]byte("test data"), []byte(fake.ReferenceScheme))
+	assert.Nil(t, err)
+
+	sshSignature, err := sshsig.Unarmor([]byte(signature))
+	assert.Nil(t, err)
+	assert.Equal(t, namespaceSSHSignature, sshSignature.Namespace)
+
+	_, err = signGitObjectUsingKey([]byte("test data"), []byte("awskms:///unregistered"))
+	assert.ErrorIs(t, err, ErrUnableToSign)
+}
@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+var (
+	ErrRepositoryNotOnDisk = errors.New("operation requires a repository stored on disk")
+	ErrMissingObject       = errors.New("unable to fetch missing object")
+)
+
+// GetPromisorRemotes returns the names of the remotes that objects missing in
+// a partial clone can be fetched from. The remote set in
+// extensions.partialClone is listed first, followed by other remotes with
+// remote.<name>.promisor set, in order of name. Repositories that aren't
+// partial clones have no promisor remotes.
+func GetPromisorRemotes(repo *git.Repository) ([]string, error) {
+	repoConfig, err := repo.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	promisorRemotes := []string{}
+	partialCloneRemote := repoConfig.Raw.Section("extensions").Option("partialclone")
+	if partialCloneRemote != "" {
+		promisorRemotes = append(promisorRemotes, partialCloneRemote)
+	}
+
+	remoteNames := []string{}
+	for _, subsection := range repoConfig.Raw.Section("remote").Subsections {
+		if subsection.Name == partialCloneRemote {
+			continue
+		}
+		if isPromisor, err := strconv.ParseBool(subsection.Option("promisor")); err == nil && isPromisor {
+			remoteNames = append(remoteNames, subsection.Name)
+		}
+	}
+	sort.Strings(remoteNames)
+
+	return append(promisorRemotes, remoteNames...), nil
+}
+
+// IsPartialClone returns true if the repository has promisor remotes, i.e.,
+// objects may be missing locally and must be fetched when needed.
+func IsPartialClone(repo *git.Repository) (bool, error) {
+	promisorRemotes, err := GetPromisorRemotes(repo)
+	if err != nil {
+		return false, err
+	}
+
+	return len(promisorRemotes) > 0, nil
+}
+
+// FetchObjects fetches the specified objects from the remote. This is used to
+// retrieve objects excluded by an earlier partial fetch. The remote must allow
+// requesting objects by their IDs, which is the default with Git's protocol
+// version 2.
+func FetchObjects(ctx context.Context, repo *git.Repository, remoteName string, objectIDs []plumbing.Hash) error {
+	if len(objectIDs) == 0 {
+		return nil
+	}
+
+	gitDir, err := getGitDir(repo)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"-c", "fetch.negotiationAlgorithm=noop", "fetch", "--no-tags", "--no-write-fetch-head", "--recurse-submodules=no", remoteName}
+	for _, objectID := range objectIDs {
+		args = append(args, objectID.String())
+	}
+	if err := execGit(ctx, gitDir, args...); err != nil {
+		return err
+	}
+
+	reindexObjects(repo.Storer)
+	return nil
+}
+
+// NewOnDemandFetchRepository returns a view of repo that fetches objects that
+// are missing locally from the specified remote when they're read. This allows
+// verifying refs fetched using a partial clone filter, as objects such as the
+// policy metadata blobs are only fetched when verification needs them. The
+// context is used for all fetches made by the returned repository.
+func NewOnDemandFetchRepository(ctx context.Context, repo *git.Repository, remoteName string) (*git.Repository, error) {
+	if s, isOnDemand := repo.Storer.(*onDemandFetchStorage); isOnDemand {
+		// Prefer the specified remote over those already configured
+		remoteNames := []string{remoteName}
+		for _, existingRemoteName := range s.remoteNames {
+			if existingRemoteName != remoteName {
+				remoteNames = append(remoteNames, existingRemoteName)
+			}
+		}
+		return newOnDemandFetchRepository(ctx, s.repo, s.gitDir, remoteNames)
+	}
+
+	gitDir, err := getGitDir(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOnDemandFetchRepository(ctx, repo, gitDir, []string{remoteName})
+}
+
+// NewPromisorFetchRepository returns a view of repo that fetches missing
+// objects from the repository's promisor remotes when they're read, like
+// NewOnDemandFetchRepository. Each promisor remote is tried in turn. If the
+// repository isn't a partial clone, it's returned as is.
+func NewPromisorFetchRepository(ctx context.Context, repo *git.Repository) (*git.Repository, error) {
+	if _, isOnDemand := repo.Storer.(*onDemandFetchStorage); isOnDemand {
+		return repo, nil
+	}
+
+	promisorRemotes, err := GetPromisorRemotes(repo)
+	if err != nil {
+		return nil, err
+	}
+	if len(promisorRemotes) == 0 {
+		return repo, nil
+	}
+
+	gitDir, err := getGitDir(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOnDemandFetchRepository(ctx, repo, gitDir, promisorRemotes)
+}
+
+func newOnDemandFetchRepository(ctx context.Context, repo *git.Repository, gitDir string, remoteNames []string) (*git.Repository, error) {
+	s := &onDemandFetchStorage{
+		Storer:      repo.Storer,
+		ctx:         ctx,
+		repo:        repo,
+		remoteNames: remoteNames,
+		gitDir:      gitDir,
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		if !errors.Is(err, git.ErrIsBareRepository) {
+			return nil, err
+		}
+		return git.Open(s, nil)
+	}
+
+	return git.Open(s, worktree.Filesystem)
+}
+
+// onDemandFetchStorage wraps a repository's storage to fetch missing objects
+// from remotes when they're read.
+type onDemandFetchStorage struct {
+	storage.Storer
+
+	ctx         context.Context
+	repo        *git.Repository
+	remoteNames []string
+	gitDir      string
+
+	mu sync.Mutex
+}
+
+func (s *onDemandFetchStorage) EncodedObject(objectType plumbing.ObjectType, objectID plumbing.Hash) (plumbing.EncodedObject, error) {
+	object, err := s.Storer.EncodedObject(objectType, objectID)
+	if !errors.Is(err, plumbing.ErrObjectNotFound) || objectID.IsZero() {
+		return object, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The object may have been fetched while waiting for the lock
+	if object, err := s.Storer.EncodedObject(objectType, objectID); err == nil {
+		return object, nil
+	}
+
+	// The object exists but isn't of the requested type
+	if objectType != plumbing.AnyObject {
+		if _, err := s.Storer.EncodedObject(plumbing.AnyObject, objectID); err == nil {
+			return nil, plumbing.ErrObjectNotFound
+		}
+	}
+
+	fetchErrs := []error{}
+	for _, remoteName := range s.remoteNames {
+		if err := FetchObjects(s.ctx, s.repo, remoteName, []plumbing.Hash{objectID}); err != nil {
+			fetchErrs = append(fetchErrs, fmt.Errorf("unable to fetch from '%s': %w", remoteName, err))
+			continue
+		}
+
+		object, err := s.Storer.EncodedObject(objectType, objectID)
+		if err == nil {
+			return object, nil
+		}
+		fetchErrs = append(fetchErrs, fmt.Errorf("unable to read object fetched from '%s': %w", remoteName, err))
+	}
+
+	// Callers may check for missing objects, so ErrObjectNotFound is retained
+	return nil, fmt.Errorf("%w '%s': %w: %w", ErrMissingObject, objectID.String(), plumbing.ErrObjectNotFound, errors.Join(fetchErrs...))
+}
+
+// PackfileWriter allows packfiles fetched using go-git to be written directly
+// to the wrapped storage.
+func (s *onDemandFetchStorage) PackfileWriter() (io.WriteCloser, error) {
+	packfileWriter, isPackfileWriter := s.Storer.(storer.PackfileWriter)
+	if !isPackfileWriter {
+		return nil, ErrRepositoryNotOnDisk
+	}
+
+	return packfileWriter.PackfileWriter()
+}
+
+// fetchRefSpecWithFilter fetches the refspecs using the Git binary, as go-git
+// does not support partial clone filters.
+func fetchRefSpecWithFilter(ctx context.Context, repo *git.Repository, remoteName string, refs []config.RefSpec, opts *FetchOptions) error {
+	gitDir, err := getGitDir(repo)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"fetch", "--no-tags", "--no-write-fetch-head", "--recurse-submodules=no", fmt.Sprintf("--filter=%s", opts.Filter)}
+	if opts.Depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", opts.Depth))
+	}
+	args = append(args, remoteName)
+	for _, refSpec := range refs {
+		args = append(args, refSpec.String())
+	}
+	if err := execGit(ctx, gitDir, args...); err != nil {
+		return err
+	}
+
+	reindexObjects(repo.Storer)
+	return nil
+}
+
+// getGitDir returns the path to the repository's Git directory.
+func getGitDir(repo *git.Repository) (string, error) {
+	switch s := repo.Storer.(type) {
+	case *filesystem.Storage:
+		return s.Filesystem().Root(), nil
+	case *onDemandFetchStorage:
+		return s.gitDir, nil
+	default:
+		return "", ErrRepositoryNotOnDisk
+	}
+}
+
+// reindexObjects ensures packfiles written by the Git binary are visible to
+// go-git, which otherwise caches the list of packfiles in the repository.
+func reindexObjects(s storage.Storer) {
+	switch s := s.(type) {
+	case *filesystem.Storage:
+		s.Reindex()
+	case *onDemandFetchStorage:
+		reindexObjects(s.Storer)
+	}
+}
+
+func execGit(ctx context.Context, gitDir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--git-dir", gitDir}, args...)...) //nolint:gosec
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchRefSpecWithOptions(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+	refSpecs := []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", refName, refName))}
+
+	remoteDir := t.TempDir()
+	repoRemote, err := git.PlainInit(remoteDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteConfig, err := repoRemote.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteConfig.Raw.Section("uploadpack").SetOption("allowFilter", "true")
+	if err := repoRemote.SetConfig(remoteConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	blobID, err := WriteBlob(repoRemote, []byte("test file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeID, err := WriteTree(repoRemote, []object.TreeEntry{{Name: "file", Mode: filemode.Regular, Hash: blobID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstCommitID, err := Commit(repoRemote, treeID, refName, "Initial commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondCommitID, err := Commit(repoRemote, treeID, refName, "Second commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createLocalRepository := func(t *testing.T) *git.Repository {
+		t.Helper()
+
+		repoLocal, err := git.PlainInit(t.TempDir(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repoLocal.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{"file://" + remoteDir}}); err != nil {
+			t.Fatal(err)
+		}
+		return repoLocal
+	}
+
+	t.Run("fetch with depth", func(t *testing.T) {
+		repoLocal := createLocalRepository(t)
+
+		err := FetchRefSpecWithOptions(context.Background(), repoLocal, remoteName, refSpecs, &FetchOptions{Depth: 1})
+		assert.Nil(t, err)
+
+		tip, err := GetTip(repoLocal, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, secondCommitID, tip)
+
+		_, err = repoLocal.CommitObject(firstCommitID)
+		assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+	})
+
+	t.Run("fetch with filter and read missing objects on demand", func(t *testing.T) {
+		repoLocal := createLocalRepository(t)
+
+		err := FetchRefSpecWithOptions(context.Background(), repoLocal, remoteName, refSpecs, &FetchOptions{Filter: "blob:none"})
+		assert.Nil(t, err)
+
+		tip, err := GetTip(repoLocal, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, secondCommitID, tip)
+
+		_, err = repoLocal.CommitObject(firstCommitID)
+		assert.Nil(t, err)
+
+		_, err = ReadBlob(repoLocal, blobID)
+		assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+
+		onDemandRepo, err := NewOnDemandFetchRepository(context.Background(), repoLocal, remoteName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contents, err := ReadBlob(onDemandRepo, blobID)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("test file"), contents)
+
+		// The object is now available locally
+		_, err = ReadBlob(repoLocal, blobID)
+		assert.Nil(t, err)
+
+		_, err = ReadBlob(onDemandRepo, plumbing.NewHash("abcdef1234567890"))
+		assert.ErrorIs(t, err, ErrMissingObject)
+		assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+
+		// Objects of a different type are not fetched
+		_, err = onDemandRepo.CommitObject(blobID)
+		assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+		assert.NotErrorIs(t, err, ErrMissingObject)
+	})
+
+	t.Run("promisor remotes", func(t *testing.T) {
+		repoLocal := createLocalRepository(t)
+
+		isPartialClone, err := IsPartialClone(repoLocal)
+		assert.Nil(t, err)
+		assert.False(t, isPartialClone)
+
+		promisorRepo, err := NewPromisorFetchRepository(context.Background(), repoLocal)
+		assert.Nil(t, err)
+		assert.Equal(t, repoLocal, promisorRepo)
+
+		if err := FetchRefSpecWithOptions(context.Background(), repoLocal, remoteName, refSpecs, &FetchOptions{Filter: "blob:none"}); err != nil {
+			t.Fatal(err)
+		}
+
+		isPartialClone, err = IsPartialClone(repoLocal)
+		assert.Nil(t, err)
+		assert.True(t, isPartialClone)
+
+		promisorRemotes, err := GetPromisorRemotes(repoLocal)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{remoteName}, promisorRemotes)
+
+		promisorRepo, err = NewPromisorFetchRepository(context.Background(), repoLocal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents, err := ReadBlob(promisorRepo, blobID)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("test file"), contents)
+	})
+
+	t.Run("filter requires repository on disk", func(t *testing.T) {
+		repoLocal, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = FetchRefSpecWithOptions(context.Background(), repoLocal, remoteName, refSpecs, &FetchOptions{Filter: "blob:none"})
+		assert.ErrorIs(t, err, ErrRepositoryNotOnDisk)
+
+		_, err = NewOnDemandFetchRepository(context.Background(), repoLocal, remoteName)
+		assert.ErrorIs(t, err, ErrRepositoryNotOnDisk)
+	})
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var ErrShallowBoundary = errors.New("history beyond the shallow clone boundary is required, fetch more history to proceed")
+
+// GetShallowCommits returns the graft points of a shallow clone, i.e., the
+// commits that are present locally while their parents are not. Repositories
+// that aren't shallow have no graft points.
+func GetShallowCommits(repo *git.Repository) ([]plumbing.Hash, error) {
+	return repo.Storer.Shallow()
+}
+
+// IsShallowClone returns true if the repository's history is truncated at one
+// or more graft points.
+func IsShallowClone(repo *git.Repository) (bool, error) {
+	shallowCommits, err := GetShallowCommits(repo)
+	if err != nil {
+		return false, err
+	}
+
+	return len(shallowCommits) > 0, nil
+}
+
+// IsShallowCommit returns true if the specified commit is a graft point, i.e.,
+// its parents are beyond the shallow clone boundary.
+func IsShallowCommit(repo *git.Repository, commitID plumbing.Hash) (bool, error) {
+	shallowCommits, err := GetShallowCommits(repo)
+	if err != nil {
+		return false, err
+	}
+
+	for _, shallowCommit := range shallowCommits {
+		if shallowCommit == commitID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Deepen fetches more history for the specified refs from the remote. If depth
+// is positive, the history of each ref is extended by that many commits past
+// the shallow clone boundary. Otherwise, the full history is fetched. As
+// go-git cannot deepen an existing clone, the Git binary is used and the
+// repository must be stored on disk.
+func Deepen(ctx context.Context, repo *git.Repository, remoteName string, refNames []string, depth int) error {
+	isShallow, err := IsShallowClone(repo)
+	if err != nil {
+		return err
+	}
+	if !isShallow {
+		return nil
+	}
+
+	gitDir, err := getGitDir(repo)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"fetch", "--no-tags", "--no-write-fetch-head", "--recurse-submodules=no"}
+	if depth > 0 {
+		args = append(args, fmt.Sprintf("--deepen=%d", depth))
+	} else {
+		args = append(args, "--unshallow")
+	}
+	args = append(args, remoteName)
+	args = append(args, refNames...)
+	if err := execGit(ctx, gitDir, args...); err != nil {
+		return err
+	}
+
+	reindexObjects(repo.Storer)
+	return nil
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShallowClone(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	remoteDir := t.TempDir()
+	repoRemote, err := git.PlainInit(remoteDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := []plumbing.Hash{}
+	for i := 0; i < 3; i++ {
+		commitID, err := Commit(repoRemote, EmptyTree(), refName, fmt.Sprintf("Commit %d", i), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitIDs = append(commitIDs, commitID)
+	}
+	firstCommit, err := repoRemote.CommitObject(commitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondCommit, err := repoRemote.CommitObject(commitIDs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repoLocal, err := git.PlainInit(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repoLocal.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{"file://" + remoteDir}}); err != nil {
+		t.Fatal(err)
+	}
+
+	isShallow, err := IsShallowClone(repoLocal)
+	assert.Nil(t, err)
+	assert.False(t, isShallow)
+
+	refSpecs := []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", refName, refName))}
+	if err := FetchRefSpecWithOptions(context.Background(), repoLocal, remoteName, refSpecs, &FetchOptions{Depth: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	isShallow, err = IsShallowClone(repoLocal)
+	assert.Nil(t, err)
+	assert.True(t, isShallow)
+
+	isShallowCommit, err := IsShallowCommit(repoLocal, commitIDs[2])
+	assert.Nil(t, err)
+	assert.True(t, isShallowCommit)
+
+	knows, err := KnowsCommit(repoLocal, commitIDs[2], secondCommit)
+	assert.ErrorIs(t, err, ErrShallowBoundary)
+	assert.False(t, knows)
+
+	// Deepen by one commit
+	err = Deepen(context.Background(), repoLocal, remoteName, []string{refName}, 1)
+	assert.Nil(t, err)
+
+	knows, err = KnowsCommit(repoLocal, commitIDs[2], secondCommit)
+	assert.Nil(t, err)
+	assert.True(t, knows)
+
+	_, err = KnowsCommit(repoLocal, commitIDs[2], firstCommit)
+	assert.ErrorIs(t, err, ErrShallowBoundary)
+
+	// Fetch the full history
+	err = Deepen(context.Background(), repoLocal, remoteName, []string{refName}, 0)
+	assert.Nil(t, err)
+
+	isShallow, err = IsShallowClone(repoLocal)
+	assert.Nil(t, err)
+	assert.False(t, isShallow)
+
+	knows, err = KnowsCommit(repoLocal, commitIDs[2], firstCommit)
+	assert.Nil(t, err)
+	assert.True(t, knows)
+
+	// Deepening a complete clone is a no-op
+	err = Deepen(context.Background(), repoLocal, remoteName, []string{refName}, 0)
+	assert.Nil(t, err)
+}
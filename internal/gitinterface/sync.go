@@ -60,10 +60,42 @@ func Push(ctx context.Context, repo *git.Repository, remoteName string, refs []s
 	return PushRefSpec(ctx, repo, remoteName, refSpecs)
 }
 
+// FetchOptions limits the history and objects transferred when fetching. The
+// zero value fetches all objects reachable from the fetched refs.
+type FetchOptions struct {
+	// Depth limits the fetch to the specified number of commits from the tip
+	// of each fetched ref. A depth of zero fetches the full history.
+	Depth int
+
+	// Filter is a partial clone filter spec, such as "blob:none" or
+	// "tree:0", that excludes objects from the fetch. The remote is
+	// registered as a promisor remote, so that missing objects can be
+	// fetched later. Servers that don't support filtering ignore the filter
+	// and send all objects.
+	Filter string
+}
+
 // FetchRefSpec fetches to the repo from the specified remote using
 // pre-constructed refspecs. For more information on the Git refspec, please
 // consult: https://git-scm.com/book/en/v2/Git-Internals-The-Refspec.
 func FetchRefSpec(ctx context.Context, repo *git.Repository, remoteName string, refs []config.RefSpec) error {
+	return FetchRefSpecWithOptions(ctx, repo, remoteName, refs, nil)
+}
+
+// FetchRefSpecWithOptions fetches to the repo from the specified remote using
+// pre-constructed refspecs, like FetchRefSpec. The fetch is limited using
+// opts, which may be nil. As go-git does not support partial clone filters,
+// fetches using a filter are performed using the Git binary and require the
+// repository to be stored on disk.
+func FetchRefSpecWithOptions(ctx context.Context, repo *git.Repository, remoteName string, refs []config.RefSpec, opts *FetchOptions) error {
+	if opts == nil {
+		opts = &FetchOptions{}
+	}
+
+	if opts.Filter != "" {
+		return fetchRefSpecWithFilter(ctx, repo, remoteName, refs, opts)
+	}
+
 	remote, err := repo.Remote(remoteName)
 	if err != nil {
 		return err
@@ -72,6 +104,7 @@ func FetchRefSpec(ctx context.Context, repo *git.Repository, remoteName string,
 	fetchOpts := &git.FetchOptions{
 		RemoteName: remoteName,
 		RefSpecs:   refs,
+		Depth:      opts.Depth,
 	}
 
 	err = remote.FetchContext(ctx, fetchOpts)
@@ -120,6 +153,38 @@ func CloneAndFetch(ctx context.Context, remoteURL, dir, initialBranch string, re
 	return fetchRefs(ctx, repo, refs, true)
 }
 
+// CloneAndFetchWithoutCheckout clones a repository using the specified URL and
+// additionally fetches the specified refs, like CloneAndFetch. However, the
+// working tree is not populated, allowing the caller to inspect the repository
+// before checking out HEAD using CheckoutHEAD.
+func CloneAndFetchWithoutCheckout(ctx context.Context, remoteURL, dir, initialBranch string, refs []string) (*git.Repository, error) {
+	cloneOptions := createCloneOptions(remoteURL, initialBranch)
+	cloneOptions.NoCheckout = true
+
+	repo, err := git.PlainCloneContext(ctx, dir, false, cloneOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchRefs(ctx, repo, refs, true)
+}
+
+// CheckoutHEAD populates the working tree and the index with the contents of
+// the commit at HEAD, discarding any existing changes.
+func CheckoutHEAD(repo *git.Repository) error {
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	return worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.HardReset})
+}
+
 // CloneAndFetchToMemory clones an in-memory repository using the specified URL
 // and additionally fetches the specified refs.
 func CloneAndFetchToMemory(ctx context.Context, remoteURL, initialBranch string, refs []string) (*git.Repository, error) {
@@ -131,6 +196,23 @@ func CloneAndFetchToMemory(ctx context.Context, remoteURL, initialBranch string,
 	return fetchRefs(ctx, repo, refs, true)
 }
 
+// CloneAndFetchToMemoryWithoutCheckout clones an in-memory repository using
+// the specified URL and additionally fetches the specified refs, like
+// CloneAndFetchToMemory. However, the in-memory working tree is not populated,
+// allowing the caller to inspect the repository before checking out HEAD using
+// CheckoutHEAD.
+func CloneAndFetchToMemoryWithoutCheckout(ctx context.Context, remoteURL, initialBranch string, refs []string) (*git.Repository, error) {
+	cloneOptions := createCloneOptions(remoteURL, initialBranch)
+	cloneOptions.NoCheckout = true
+
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), memfs.New(), cloneOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchRefs(ctx, repo, refs, true)
+}
+
 func createCloneOptions(remoteURL, initialBranch string) *git.CloneOptions {
 	cloneOptions := &git.CloneOptions{
 		URL:      remoteURL,
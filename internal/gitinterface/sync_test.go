@@ -5,12 +5,15 @@ package gitinterface
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/assert"
@@ -652,6 +655,63 @@ func TestCloneAndFetch(t *testing.T) {
 	})
 }
 
+func TestCloneAndFetchWithoutCheckout(t *testing.T) {
+	refName := "refs/heads/main"
+	anotherRefName := "refs/heads/feature"
+	fileName := "README.md"
+
+	remoteTmpDir := t.TempDir()
+	localTmpDir := t.TempDir()
+
+	remoteRepo, err := git.PlainInit(remoteTmpDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blobID, err := WriteBlob(remoteRepo, []byte("Hello, world!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeID, err := WriteTree(remoteRepo, []object.TreeEntry{{Name: fileName, Mode: filemode.Regular, Hash: blobID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainCommitID, err := Commit(remoteRepo, treeID, refName, "Commit to main", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCommitID, err := Commit(remoteRepo, treeID, anotherRefName, "Commit to feature", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remoteRepo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.ReferenceName(refName))); err != nil {
+		t.Fatal(err)
+	}
+
+	localRepo, err := CloneAndFetchWithoutCheckout(context.Background(), remoteTmpDir, localTmpDir, refName, []string{anotherRefName})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localMainCommitID, err := localRepo.ResolveRevision(plumbing.Revision(refName))
+	assert.Nil(t, err)
+	localOtherCommitID, err := localRepo.ResolveRevision(plumbing.Revision(anotherRefName))
+	assert.Nil(t, err)
+	assert.Equal(t, mainCommitID, *localMainCommitID)
+	assert.Equal(t, otherCommitID, *localOtherCommitID)
+
+	// The working tree must not be populated yet
+	_, err = os.Stat(filepath.Join(localTmpDir, fileName))
+	assert.True(t, os.IsNotExist(err))
+
+	err = CheckoutHEAD(localRepo)
+	assert.Nil(t, err)
+
+	contents, err := os.ReadFile(filepath.Join(localTmpDir, fileName))
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello, world!", string(contents))
+}
+
 func TestCloneAndFetchToMemory(t *testing.T) {
 	refName := "refs/heads/main"
 	anotherRefName := "refs/heads/feature"
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/smime"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -20,6 +22,8 @@ import (
 
 var (
 	ErrTagAlreadyExists = errors.New("tag already exists")
+	ErrNotTagReference  = errors.New("reference is not a tag")
+	ErrNotAnnotatedTag  = errors.New("tag is not an annotated tag")
 )
 
 // IsTag returns true if the specified target is a tag in the repository.
@@ -108,8 +112,13 @@ func CreateTagObject(gitConfig *config.Config, targetObj object.Object, name, me
 func VerifyTagSignature(ctx context.Context, tag *object.Tag, key *tuf.Key) error {
 	switch key.KeyType {
 	case signerverifier.GPGKeyType:
-		if _, err := tag.Verify(key.KeyVal.Public); err != nil {
-			return ErrIncorrectVerificationKey
+		tagContents, err := getTagBytesWithoutSignature(tag)
+		if err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		if _, err := gpg.VerifySignature(key, tagContents, []byte(tag.PGPSignature), getSignatureVerificationTime(ctx)); err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
 		}
 
 		return nil
@@ -136,6 +145,17 @@ func VerifyTagSignature(ctx context.Context, tag *object.Tag, key *tuf.Key) erro
 			return errors.Join(ErrIncorrectVerificationKey, err)
 		}
 
+		return nil
+	case signerverifier.X509KeyType:
+		tagContents, err := getTagBytesWithoutSignature(tag)
+		if err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		if _, err := smime.VerifySignature(key, tagContents, []byte(tag.PGPSignature), getSignatureVerificationTime(ctx)); err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
 		return nil
 	}
 
@@ -147,6 +167,34 @@ func GetTag(repo *git.Repository, tagID plumbing.Hash) (*object.Tag, error) {
 	return repo.TagObject(tagID)
 }
 
+// GetTagForReference returns the annotated tag object the specified tag
+// reference points to. If the reference is a lightweight tag, i.e., it points
+// directly to a commit or another object, ErrNotAnnotatedTag is returned.
+func GetTagForReference(repo *git.Repository, refName string) (*object.Tag, error) {
+	absRefName, err := AbsoluteReference(repo, refName)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(absRefName, TagRefPrefix) {
+		return nil, ErrNotTagReference
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(absRefName), true)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := GetTag(repo, ref.Hash())
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil, ErrNotAnnotatedTag
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
 func signTag(tag *object.Tag) (string, error) {
 	tagContents, err := getTagBytesWithoutSignature(tag)
 	if err != nil {
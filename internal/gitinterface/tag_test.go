@@ -71,6 +71,60 @@ func TestTag(t *testing.T) {
 	assert.ErrorIs(t, err, ErrTagAlreadyExists)
 }
 
+func TestGetTagForReference(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock = testClock
+	getGitConfig = func(_ *git.Repository) (*config.Config, error) {
+		return testGitConfig, nil
+	}
+
+	commitID, err := Commit(repo, EmptyTree(), "refs/heads/main", "Initial commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tagHash, err := Tag(repo, commitID, "v0.1.0", "v0.1.0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference("refs/tags/lightweight", commitID)); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("annotated tag, relative ref", func(t *testing.T) {
+		tag, err := GetTagForReference(repo, "v0.1.0")
+		assert.Nil(t, err)
+		assert.Equal(t, tagHash, tag.Hash)
+		assert.Equal(t, commitID, tag.Target)
+	})
+
+	t.Run("annotated tag, absolute ref", func(t *testing.T) {
+		tag, err := GetTagForReference(repo, "refs/tags/v0.1.0")
+		assert.Nil(t, err)
+		assert.Equal(t, tagHash, tag.Hash)
+	})
+
+	t.Run("lightweight tag", func(t *testing.T) {
+		_, err := GetTagForReference(repo, "lightweight")
+		assert.ErrorIs(t, err, ErrNotAnnotatedTag)
+	})
+
+	t.Run("branch", func(t *testing.T) {
+		_, err := GetTagForReference(repo, "refs/heads/main")
+		assert.ErrorIs(t, err, ErrNotTagReference)
+	})
+
+	t.Run("unknown tag", func(t *testing.T) {
+		_, err := GetTagForReference(repo, "refs/tags/unknown")
+		assert.ErrorIs(t, err, ErrReferenceNotFound)
+	})
+}
+
 func TestVerifyTagSignature(t *testing.T) {
 	gpgSignedTag := createTestSignedTag(t)
 
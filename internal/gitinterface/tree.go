@@ -100,7 +100,12 @@ func GetMergeTree(repo *git.Repository, commitAID, commitBID string) (string, er
 		return commitB.TreeHash.String(), nil
 	}
 
-	// go-git does not support three way merges
+	// go-git does not support three way merges, so the Git binary is used,
+	// which requires the repository to be on disk
+	if _, err := getGitDir(repo); err != nil {
+		return "", err
+	}
+
 	command := exec.Command("git", "merge-tree", commitAID, commitBID) //nolint:gosec
 	stdOut, err := command.Output()
 	if err != nil {
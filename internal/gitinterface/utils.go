@@ -11,14 +11,17 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/jonboulle/clockwork"
 )
 
 const (
-	RefPrefix       = "refs/"
-	BranchRefPrefix = "refs/heads/"
-	TagRefPrefix    = "refs/tags/"
-	RemoteRefPrefix = "refs/remotes/"
+	RefPrefix        = "refs/"
+	BranchRefPrefix  = "refs/heads/"
+	TagRefPrefix     = "refs/tags/"
+	RemoteRefPrefix  = "refs/remotes/"
+	NotesRefPrefix   = "refs/notes/"
+	ReplaceRefPrefix = "refs/replace/"
 )
 
 var (
@@ -37,6 +40,17 @@ func GetTip(repo *git.Repository, refName string) (plumbing.Hash, error) {
 	return ref.Hash(), nil
 }
 
+// GetObjectType returns the type of the specified object. This is useful for
+// refs such as replace refs that may point to any kind of object.
+func GetObjectType(repo *git.Repository, objectID plumbing.Hash) (plumbing.ObjectType, error) {
+	obj, err := repo.Storer.EncodedObject(plumbing.AnyObject, objectID)
+	if err != nil {
+		return plumbing.InvalidObject, err
+	}
+
+	return obj.Type(), nil
+}
+
 // ResetCommit sets a Git reference with the name refName to the commit
 // specified by its hash as commitID. Note that the commit must already be in
 // the repository's object store.
@@ -79,7 +93,8 @@ func ResetDueToError(cause error, repo *git.Repository, refName string, commitID
 }
 
 // AbsoluteReference returns the fully qualified reference path for the provided
-// Git ref.
+// Git ref. Branches and tags are checked first, followed by refs in other
+// namespaces, such as notes/commits for refs/notes/commits.
 func AbsoluteReference(repo *git.Repository, target string) (string, error) {
 	if strings.HasPrefix(target, RefPrefix) {
 		return target, nil
@@ -114,9 +129,84 @@ func AbsoluteReference(repo *git.Repository, target string) (string, error) {
 		return "", err
 	}
 
+	// Check if ref in another namespace
+	refName = plumbing.ReferenceName(RefPrefix + target)
+	_, err = repo.Reference(refName, false)
+	if err == nil {
+		return string(refName), nil
+	}
+	if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return "", err
+	}
+
 	return "", ErrReferenceNotFound
 }
 
+// ResolvedRef describes a Git ref resolved by ResolveReference.
+type ResolvedRef struct {
+	// IsSymbolic indicates if the requested ref is a symbolic ref.
+	IsSymbolic bool
+
+	// SymbolicTarget is the ref the requested ref points to if it is
+	// symbolic.
+	SymbolicTarget string
+
+	// ConcreteRef is the ref that ultimately points to a Git object. For a
+	// detached HEAD, this is HEAD itself.
+	ConcreteRef string
+
+	// Hash is the tip of ConcreteRef. This is the zero hash if ConcreteRef
+	// does not exist yet, such as when HEAD points to an unborn branch.
+	Hash plumbing.Hash
+}
+
+// ResolveReference resolves the specified Git ref to both its symbolic and
+// concrete forms. Short ref names are qualified as in AbsoluteReference, while
+// HEAD is inspected as is so that symbolic and detached HEADs can be told
+// apart. Chains of symbolic refs are followed to the concrete ref.
+func ResolveReference(repo *git.Repository, refName string) (*ResolvedRef, error) {
+	if refName != plumbing.HEAD.String() && !strings.HasPrefix(refName, RefPrefix) {
+		absRefName, err := AbsoluteReference(repo, refName)
+		if err != nil {
+			return nil, err
+		}
+		refName = absRefName
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(refName), false)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedRef := &ResolvedRef{}
+	if ref.Type() == plumbing.SymbolicReference {
+		resolvedRef.IsSymbolic = true
+		resolvedRef.SymbolicTarget = ref.Target().String()
+	}
+
+	for i := 0; ref.Type() == plumbing.SymbolicReference; i++ {
+		if i >= storer.MaxResolveRecursion {
+			return nil, storer.ErrMaxResolveRecursion
+		}
+
+		target := ref.Target()
+		ref, err = repo.Reference(target, false)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrReferenceNotFound) {
+				// The symbolic ref points to a ref that doesn't exist yet
+				resolvedRef.ConcreteRef = target.String()
+				resolvedRef.Hash = plumbing.ZeroHash
+				return resolvedRef, nil
+			}
+			return nil, err
+		}
+	}
+
+	resolvedRef.ConcreteRef = ref.Name().String()
+	resolvedRef.Hash = ref.Hash()
+	return resolvedRef, nil
+}
+
 // RefSpec creates a Git refspec for the specified ref.  For more information on
 // the Git refspec, please consult:
 // https://git-scm.com/book/en/v2/Git-Internals-The-Refspec.
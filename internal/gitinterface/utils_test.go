@@ -159,3 +159,181 @@ func TestRefSpec(t *testing.T) {
 		assert.Equal(t, test.expectedRefSpec, refSpec, fmt.Sprintf("unexpected refspec returned in test '%s'", name))
 	}
 }
+
+func TestResolveReference(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// HEAD points to an unborn branch
+	resolvedRef, err := ResolveReference(repo, "HEAD")
+	assert.Nil(t, err)
+	assert.True(t, resolvedRef.IsSymbolic)
+	assert.Equal(t, "refs/heads/master", resolvedRef.SymbolicTarget)
+	assert.Equal(t, "refs/heads/master", resolvedRef.ConcreteRef)
+	assert.Equal(t, plumbing.ZeroHash, resolvedRef.Hash)
+
+	emptyTreeHash, err := WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := Commit(repo, emptyTreeHash, "refs/heads/master", "Test Commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("symbolic HEAD", func(t *testing.T) {
+		resolvedRef, err := ResolveReference(repo, "HEAD")
+		assert.Nil(t, err)
+		assert.True(t, resolvedRef.IsSymbolic)
+		assert.Equal(t, "refs/heads/master", resolvedRef.SymbolicTarget)
+		assert.Equal(t, "refs/heads/master", resolvedRef.ConcreteRef)
+		assert.Equal(t, commitID, resolvedRef.Hash)
+	})
+
+	t.Run("short branch name", func(t *testing.T) {
+		resolvedRef, err := ResolveReference(repo, "master")
+		assert.Nil(t, err)
+		assert.False(t, resolvedRef.IsSymbolic)
+		assert.Empty(t, resolvedRef.SymbolicTarget)
+		assert.Equal(t, "refs/heads/master", resolvedRef.ConcreteRef)
+		assert.Equal(t, commitID, resolvedRef.Hash)
+	})
+
+	t.Run("chain of symbolic refs", func(t *testing.T) {
+		if err := repo.Storer.SetReference(plumbing.NewSymbolicReference("refs/heads/alias", plumbing.HEAD)); err != nil {
+			t.Fatal(err)
+		}
+
+		resolvedRef, err := ResolveReference(repo, "refs/heads/alias")
+		assert.Nil(t, err)
+		assert.True(t, resolvedRef.IsSymbolic)
+		assert.Equal(t, "HEAD", resolvedRef.SymbolicTarget)
+		assert.Equal(t, "refs/heads/master", resolvedRef.ConcreteRef)
+		assert.Equal(t, commitID, resolvedRef.Hash)
+	})
+
+	t.Run("detached HEAD", func(t *testing.T) {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, commitID)); err != nil {
+			t.Fatal(err)
+		}
+
+		resolvedRef, err := ResolveReference(repo, "HEAD")
+		assert.Nil(t, err)
+		assert.False(t, resolvedRef.IsSymbolic)
+		assert.Empty(t, resolvedRef.SymbolicTarget)
+		assert.Equal(t, "HEAD", resolvedRef.ConcreteRef)
+		assert.Equal(t, commitID, resolvedRef.Hash)
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		_, err := ResolveReference(repo, "unknown")
+		assert.ErrorIs(t, err, ErrReferenceNotFound)
+
+		_, err = ResolveReference(repo, "refs/heads/unknown")
+		assert.ErrorIs(t, err, ErrReferenceNotFound)
+	})
+}
+
+func TestAbsoluteReference(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emptyTreeHash, err := WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := Commit(repo, emptyTreeHash, "refs/heads/main", "Test Commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, refName := range []string{"refs/tags/v1", "refs/notes/commits", "refs/custom/review", "refs/heads/notes/shared", "refs/notes/shared"} {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), commitID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		target          string
+		expectedRefName string
+		expectedError   error
+	}{
+		"absolute ref": {
+			target:          "refs/notes/commits",
+			expectedRefName: "refs/notes/commits",
+		},
+		"branch": {
+			target:          "main",
+			expectedRefName: "refs/heads/main",
+		},
+		"tag": {
+			target:          "v1",
+			expectedRefName: "refs/tags/v1",
+		},
+		"notes ref": {
+			target:          "notes/commits",
+			expectedRefName: "refs/notes/commits",
+		},
+		"custom namespace": {
+			target:          "custom/review",
+			expectedRefName: "refs/custom/review",
+		},
+		"branch takes precedence over other namespaces": {
+			target:          "notes/shared",
+			expectedRefName: "refs/heads/notes/shared",
+		},
+		"unknown ref": {
+			target:        "unknown",
+			expectedError: ErrReferenceNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		refName, err := AbsoluteReference(repo, test.target)
+		if test.expectedError != nil {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error in test '%s'", name))
+		} else {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+			assert.Equal(t, test.expectedRefName, refName, fmt.Sprintf("unexpected ref name in test '%s'", name))
+		}
+	}
+}
+
+func TestGetObjectType(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blobID, err := WriteBlob(repo, []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emptyTreeHash, err := WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := Commit(repo, emptyTreeHash, "refs/heads/main", "Test Commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objectType, err := GetObjectType(repo, blobID)
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.BlobObject, objectType)
+
+	objectType, err = GetObjectType(repo, emptyTreeHash)
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.TreeObject, objectType)
+
+	objectType, err = GetObjectType(repo, commitID)
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.CommitObject, objectType)
+
+	_, err = GetObjectType(repo, plumbing.NewHash("abcdef12345678"))
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+}
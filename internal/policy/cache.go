@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	// VerificationCacheRef defines the Git namespace used to persist the
+	// results of prior verifications. It is deliberately outside refs/gittuf/
+	// so that it is local to the repository and is never synced with remotes.
+	VerificationCacheRef = "refs/gittuf-local/verification-cache"
+
+	verificationCacheTreeEntryName = "cache.json"
+	verificationCacheCommitMessage = "Update verification cache"
+
+	// verificationCacheVersion is incremented whenever the way results are
+	// keyed changes. Caches persisted with a different version are discarded
+	// when loaded.
+	verificationCacheVersion = 2
+)
+
+var ErrInvalidVerificationCache = errors.New("invalid verification cache tree structure")
+
+// VerificationCache records the RSL entries that were successfully verified.
+// Each result is keyed by the entry, the policy state used to verify it, and
+// the attestations available at the time. When the policy or the attestations
+// change, including when their RSL entries are skipped, entries are therefore
+// verified again, while entries whose applicable state is unchanged still hit
+// the cache.
+type VerificationCache struct {
+	Version int `json:"version"`
+
+	// Entries maps the ID of each verified RSL entry to the states it was
+	// successfully verified against. A state is identified by the ID of the
+	// policy commit and the ID of the attestations commit, which is the zero
+	// hash if no attestations were recorded.
+	Entries map[string][]string `json:"entries"`
+
+	modified bool
+}
+
+// LoadVerificationCache loads the verification cache persisted in the
+// repository. If the cache does not exist yet, an empty cache is returned.
+func LoadVerificationCache(repo *git.Repository) (*VerificationCache, error) {
+	cache := &VerificationCache{Version: verificationCacheVersion, Entries: map[string][]string{}}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(VerificationCacheRef), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return cache, nil
+		}
+		return nil, err
+	}
+
+	cacheCommit, err := gitinterface.GetCommit(repo, ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	cacheTree, err := gitinterface.GetTree(repo, cacheCommit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cacheTree.Entries) != 1 || cacheTree.Entries[0].Name != verificationCacheTreeEntryName {
+		return nil, ErrInvalidVerificationCache
+	}
+
+	contents, err := gitinterface.ReadBlob(repo, cacheTree.Entries[0].Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(contents, cache); err != nil {
+		return nil, err
+	}
+	if cache.Version != verificationCacheVersion {
+		// Results recorded by other versions are keyed differently, so they
+		// are discarded and the cache is rewritten on the next commit
+		cache.Version = verificationCacheVersion
+		cache.Entries = map[string][]string{}
+		cache.modified = true
+	}
+	if cache.Entries == nil {
+		cache.Entries = map[string][]string{}
+	}
+
+	return cache, nil
+}
+
+// IsVerified returns true if the entry was previously verified successfully
+// using the policy state identified by policyStateID and the attestations
+// identified by attestationsStateID.
+func (c *VerificationCache) IsVerified(entryID, policyStateID, attestationsStateID plumbing.Hash) bool {
+	key := verificationCacheKey(policyStateID, attestationsStateID)
+	for _, id := range c.Entries[entryID.String()] {
+		if id == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetVerified records that the entry was verified successfully using the
+// policy state identified by policyStateID and the attestations identified by
+// attestationsStateID.
+func (c *VerificationCache) SetVerified(entryID, policyStateID, attestationsStateID plumbing.Hash) {
+	if c.IsVerified(entryID, policyStateID, attestationsStateID) {
+		return
+	}
+
+	c.Entries[entryID.String()] = append(c.Entries[entryID.String()], verificationCacheKey(policyStateID, attestationsStateID))
+	c.modified = true
+}
+
+// Invalidate removes all results recorded for the specified entries, so that
+// they are verified again regardless of the policy and attestations used.
+func (c *VerificationCache) Invalidate(entryIDs ...plumbing.Hash) {
+	for _, entryID := range entryIDs {
+		if _, has := c.Entries[entryID.String()]; has {
+			delete(c.Entries, entryID.String())
+			c.modified = true
+		}
+	}
+}
+
+// Commit persists the verification cache in the repository. The commit is
+// never signed as the cache is local to the repository. If the cache has not
+// been modified since it was loaded, no commit is created.
+func (c *VerificationCache) Commit(repo *git.Repository) error {
+	if !c.modified {
+		return nil
+	}
+
+	contents, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, contents)
+	if err != nil {
+		return err
+	}
+
+	treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{
+		{
+			Name: verificationCacheTreeEntryName,
+			Mode: filemode.Regular,
+			Hash: blobID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := gitinterface.Commit(repo, treeID, VerificationCacheRef, verificationCacheCommitMessage, false); err != nil {
+		return err
+	}
+
+	c.modified = false
+	return nil
+}
+
+// ClearVerificationCache removes the verification cache persisted in the
+// repository, so that subsequent verifications check every entry again. It is
+// not an error if the cache does not exist.
+func ClearVerificationCache(repo *git.Repository) error {
+	err := repo.Storer.RemoveReference(plumbing.ReferenceName(VerificationCacheRef))
+	if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func verificationCacheKey(policyStateID, attestationsStateID plumbing.Hash) string {
+	return policyStateID.String() + ":" + attestationsStateID.String()
+}
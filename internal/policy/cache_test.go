@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationCache(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entryID := plumbing.NewHash("abcdef1234567890")
+	policyStateID := plumbing.NewHash("1234567890abcdef")
+	newPolicyStateID := plumbing.NewHash("fedcba0987654321")
+	attestationsStateID := plumbing.NewHash("0987654321fedcba")
+
+	cache, err := LoadVerificationCache(repo)
+	assert.Nil(t, err)
+	assert.Empty(t, cache.Entries)
+	assert.False(t, cache.IsVerified(entryID, policyStateID, plumbing.ZeroHash))
+
+	// Committing an unmodified cache is a no-op
+	err = cache.Commit(repo)
+	assert.Nil(t, err)
+	_, err = repo.Reference(plumbing.ReferenceName(VerificationCacheRef), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	cache.SetVerified(entryID, policyStateID, plumbing.ZeroHash)
+	assert.True(t, cache.IsVerified(entryID, policyStateID, plumbing.ZeroHash))
+	assert.False(t, cache.IsVerified(entryID, newPolicyStateID, plumbing.ZeroHash))
+
+	err = cache.Commit(repo)
+	assert.Nil(t, err)
+
+	cache, err = LoadVerificationCache(repo)
+	assert.Nil(t, err)
+	assert.True(t, cache.IsVerified(entryID, policyStateID, plumbing.ZeroHash))
+	assert.False(t, cache.IsVerified(entryID, newPolicyStateID, plumbing.ZeroHash))
+
+	cache.SetVerified(entryID, newPolicyStateID, plumbing.ZeroHash)
+	err = cache.Commit(repo)
+	assert.Nil(t, err)
+
+	cache, err = LoadVerificationCache(repo)
+	assert.Nil(t, err)
+	assert.True(t, cache.IsVerified(entryID, policyStateID, plumbing.ZeroHash))
+	assert.True(t, cache.IsVerified(entryID, newPolicyStateID, plumbing.ZeroHash))
+
+	// Results are also keyed by the attestations used
+	assert.False(t, cache.IsVerified(entryID, policyStateID, attestationsStateID))
+	cache.SetVerified(entryID, policyStateID, attestationsStateID)
+	assert.True(t, cache.IsVerified(entryID, policyStateID, attestationsStateID))
+
+	cache.Invalidate(entryID)
+	assert.False(t, cache.IsVerified(entryID, policyStateID, plumbing.ZeroHash))
+	assert.False(t, cache.IsVerified(entryID, policyStateID, attestationsStateID))
+	err = cache.Commit(repo)
+	assert.Nil(t, err)
+
+	cache, err = LoadVerificationCache(repo)
+	assert.Nil(t, err)
+	assert.Empty(t, cache.Entries)
+
+	cache.SetVerified(entryID, policyStateID, plumbing.ZeroHash)
+	err = cache.Commit(repo)
+	assert.Nil(t, err)
+
+	err = ClearVerificationCache(repo)
+	assert.Nil(t, err)
+	_, err = repo.Reference(plumbing.ReferenceName(VerificationCacheRef), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	// Clearing a cache that does not exist is a no-op
+	err = ClearVerificationCache(repo)
+	assert.Nil(t, err)
+}
+
+func TestLoadVerificationCacheWithOldVersion(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entryID := plumbing.NewHash("abcdef1234567890")
+	policyStateID := plumbing.NewHash("1234567890abcdef")
+
+	// Results recorded before the cache was versioned were keyed by the
+	// policy state alone
+	cache := &VerificationCache{Entries: map[string][]string{entryID.String(): {policyStateID.String()}}, modified: true}
+	if err := cache.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = LoadVerificationCache(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, verificationCacheVersion, cache.Version)
+	assert.Empty(t, cache.Entries)
+	assert.False(t, cache.IsVerified(entryID, policyStateID, plumbing.ZeroHash))
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// MetadataChange captures a policy metadata file that differs between two
+// policy states.
+type MetadataChange struct {
+	RoleName string `json:"roleName"`
+
+	// KeyIDs contains the IDs of the keys that signed the changed metadata.
+	// It is empty if the metadata was removed.
+	KeyIDs []string `json:"keyIDs,omitempty"`
+
+	Removed bool `json:"removed,omitempty"`
+}
+
+// GetMetadataChanges returns the metadata files changed in the policy state
+// recorded in the entry, relative to the state recorded in the previous entry
+// for the same ref, sorted by role name. The states are not verified, so the
+// changes must only be used for inspection, such as when auditing.
+func GetMetadataChanges(repo *git.Repository, entry *rsl.ReferenceEntry) ([]*MetadataChange, error) {
+	state, err := loadStateForEntry(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	previousEnvelopes := map[string]*sslibdsse.Envelope{}
+	previousEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, entry.RefName, entry.ID)
+	if err == nil {
+		previousState, err := loadStateForEntry(repo, previousEntry)
+		if err != nil {
+			return nil, err
+		}
+		previousEnvelopes = previousState.envelopes()
+	} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return nil, err
+	}
+
+	envelopes := state.envelopes()
+	roleNames := make([]string, 0, len(envelopes)+len(previousEnvelopes))
+	for roleName := range envelopes {
+		roleNames = append(roleNames, roleName)
+	}
+	for roleName := range previousEnvelopes {
+		if _, has := envelopes[roleName]; !has {
+			roleNames = append(roleNames, roleName)
+		}
+	}
+	sort.Strings(roleNames)
+
+	changes := []*MetadataChange{}
+	for _, roleName := range roleNames {
+		env, has := envelopes[roleName]
+		if !has {
+			changes = append(changes, &MetadataChange{RoleName: roleName, Removed: true})
+			continue
+		}
+
+		if previousEnv, has := previousEnvelopes[roleName]; has {
+			equal, err := envelopesEqual(env, previousEnv)
+			if err != nil {
+				return nil, err
+			}
+			if equal {
+				continue
+			}
+		}
+
+		keyIDs := make([]string, 0, len(env.Signatures))
+		for _, signature := range env.Signatures {
+			keyIDs = append(keyIDs, signature.KeyID)
+		}
+		changes = append(changes, &MetadataChange{RoleName: roleName, KeyIDs: keyIDs})
+	}
+
+	return changes, nil
+}
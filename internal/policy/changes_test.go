@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetadataChanges(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := signer.KeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := GetMetadataChanges(repo, policyEntry)
+	assert.Nil(t, err)
+	assert.Equal(t, []*MetadataChange{
+		{RoleName: RootRoleName, KeyIDs: []string{keyID}},
+		{RoleName: TargetsRoleName, KeyIDs: []string{keyID}},
+	}, changes)
+
+	state.TargetsEnvelope = nil
+	if err := state.Commit(repo, "Remove rule file", false); err != nil {
+		t.Fatal(err)
+	}
+	stagingEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err = GetMetadataChanges(repo, stagingEntry)
+	assert.Nil(t, err)
+	assert.Equal(t, []*MetadataChange{{RoleName: TargetsRoleName, Removed: true}}, changes)
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// CodeOwnersRulePrefix is the prefix used for the names of rules generated
+// from a CODEOWNERS file.
+const CodeOwnersRulePrefix = "codeowners-"
+
+var (
+	ErrInvalidCodeOwnersEntry       = errors.New("invalid CODEOWNERS entry")
+	ErrUnmappedCodeOwner            = errors.New("CODEOWNERS owner is not mapped to a principal")
+	ErrUnsupportedCodeOwnersPattern = errors.New("CODEOWNERS pattern cannot be expressed as a gittuf rule pattern")
+)
+
+// CodeOwnersEntry represents a single line in a CODEOWNERS file, mapping a
+// path pattern to one or more owners.
+type CodeOwnersEntry struct {
+	Pattern string
+	Owners  []string
+}
+
+// ParseCodeOwners parses the contents of a CODEOWNERS file. Blank lines and
+// comments are ignored. Entries that do not list any owners are retained as
+// they unset ownership for matching paths.
+func ParseCodeOwners(contents []byte) ([]*CodeOwnersEntry, error) {
+	entries := []*CodeOwnersEntry{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := scanner.Text()
+		if index := strings.Index(line, "#"); index != -1 {
+			if index == 0 || line[index-1] != '\\' {
+				line = line[:index]
+			}
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		entry := &CodeOwnersEntry{Pattern: strings.ReplaceAll(fields[0], `\#`, "#"), Owners: []string{}}
+		for _, owner := range fields[1:] {
+			if !strings.Contains(owner, "@") {
+				return nil, fmt.Errorf("%w on line %d: '%s' is not a user, team, or email address", ErrInvalidCodeOwnersEntry, lineNumber, owner)
+			}
+			entry.Owners = append(entry.Owners, owner)
+		}
+
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CodeOwnersPatternToRulePattern converts a CODEOWNERS path pattern into a
+// gittuf file rule pattern. gittuf patterns are matched against the full path
+// of a file and their wildcards do not match "/". Patterns that CODEOWNERS
+// applies to more paths than a gittuf pattern can express are rejected rather
+// than converted into a pattern that protects fewer files. These are patterns
+// with recursive wildcards, patterns for directories, which apply to all
+// nested files, and patterns without a leading or inner slash, which apply in
+// every directory. A pattern such as "docs/*" must be used instead of "docs/"
+// to protect the files in a directory.
+func CodeOwnersPatternToRulePattern(pattern string) (string, error) {
+	switch {
+	case strings.Contains(pattern, "**"):
+		return "", fmt.Errorf("%w: '%s' uses a recursive wildcard", ErrUnsupportedCodeOwnersPattern, pattern)
+	case strings.HasSuffix(pattern, "/"):
+		return "", fmt.Errorf("%w: '%s' applies to all files nested in a directory", ErrUnsupportedCodeOwnersPattern, pattern)
+	case !strings.Contains(pattern, "/"):
+		return "", fmt.Errorf("%w: '%s' applies to files in every directory", ErrUnsupportedCodeOwnersPattern, pattern)
+	}
+
+	return fmt.Sprintf("file:%s", strings.TrimPrefix(pattern, "/")), nil
+}
+
+// ImportCodeOwners adds a rule to TargetsMetadata for each entry from a
+// CODEOWNERS file. The owners of each entry are mapped to principals defined
+// in the same TargetsMetadata using ownerPrincipals, and the signature of any
+// one owner satisfies the rule. As the last matching entry in a CODEOWNERS
+// file takes precedence, the rules are added in reverse order and are
+// terminating, so only the rule for the last matching entry applies to a file.
+// Entries without owners are skipped, so the rules for earlier matching entries
+// continue to protect their files. Rules are named using CodeOwnersRulePrefix
+// and the entry's position in the file. If any entry's pattern cannot be
+// expressed as a gittuf rule pattern, no rules are added.
+func ImportCodeOwners(targetsMetadata *tuf.TargetsMetadata, entries []*CodeOwnersEntry, ownerPrincipals map[string]string) (*tuf.TargetsMetadata, error) {
+	for index := len(entries) - 1; index >= 0; index-- {
+		entry := entries[index]
+		if len(entry.Owners) == 0 {
+			continue
+		}
+
+		rulePattern, err := CodeOwnersPatternToRulePattern(entry.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", index+1, err)
+		}
+
+		principalNames := []string{}
+		for _, owner := range entry.Owners {
+			principalName, has := ownerPrincipals[owner]
+			if !has {
+				return nil, fmt.Errorf("%w: '%s'", ErrUnmappedCodeOwner, owner)
+			}
+			if _, has := targetsMetadata.Delegations.Principals[principalName]; !has {
+				return nil, fmt.Errorf("%w: '%s'", ErrPrincipalNotFound, principalName)
+			}
+
+			if !slices.Contains(principalNames, principalName) {
+				principalNames = append(principalNames, principalName)
+			}
+		}
+
+		ruleName := fmt.Sprintf("%s%d", CodeOwnersRulePrefix, index+1)
+		targetsMetadata, err = AddDelegation(targetsMetadata, ruleName, []*tuf.Key{}, []string{rulePattern}, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		targetsMetadata, err = SetDelegationPrincipals(targetsMetadata, ruleName, principalNames)
+		if err != nil {
+			return nil, err
+		}
+
+		// The new rule is the last one before the allow rule
+		targetsMetadata.Delegations.Roles[len(targetsMetadata.Delegations.Roles)-2].Terminating = true
+	}
+
+	return targetsMetadata, nil
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		contents := []byte(`# Default owners
+*       @org/maintainers
+
+/docs/  @alice docs@example.com # documentation
+src/**/*.go @bob @org/maintainers
+/vendor/
+`)
+
+		expectedEntries := []*CodeOwnersEntry{
+			{Pattern: "*", Owners: []string{"@org/maintainers"}},
+			{Pattern: "/docs/", Owners: []string{"@alice", "docs@example.com"}},
+			{Pattern: "src/**/*.go", Owners: []string{"@bob", "@org/maintainers"}},
+			{Pattern: "/vendor/", Owners: []string{}},
+		}
+
+		entries, err := ParseCodeOwners(contents)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedEntries, entries)
+	})
+
+	t.Run("invalid owner", func(t *testing.T) {
+		_, err := ParseCodeOwners([]byte("*.go alice\n"))
+		assert.ErrorIs(t, err, ErrInvalidCodeOwnersEntry)
+	})
+}
+
+func TestCodeOwnersPatternToRulePattern(t *testing.T) {
+	t.Run("supported patterns", func(t *testing.T) {
+		tests := map[string]string{
+			"/docs/*":          "file:docs/*",
+			"docs/*":           "file:docs/*",
+			"/src/*.go":        "file:src/*.go",
+			"/src/*/README.md": "file:src/*/README.md",
+			"/README.md":       "file:README.md",
+		}
+
+		for pattern, expectedRulePattern := range tests {
+			rulePattern, err := CodeOwnersPatternToRulePattern(pattern)
+			assert.Nil(t, err, pattern)
+			assert.Equal(t, expectedRulePattern, rulePattern, pattern)
+		}
+	})
+
+	t.Run("unsupported patterns", func(t *testing.T) {
+		// Each of these applies to nested paths in CODEOWNERS
+		for _, pattern := range []string{"*", "/", "*.go", "README.md", "/docs/", "docs/", "src/**/*.go", "/**"} {
+			_, err := CodeOwnersPatternToRulePattern(pattern)
+			assert.ErrorIs(t, err, ErrUnsupportedCodeOwnersPattern, pattern)
+		}
+	})
+}
+
+func TestImportCodeOwners(t *testing.T) {
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTargetsMetadata := func(t *testing.T) *tuf.TargetsMetadata {
+		t.Helper()
+
+		targetsMetadata := InitializeTargetsMetadata()
+		targetsMetadata, err := AddPrincipal(targetsMetadata, "maintainers", []*tuf.Key{key1, key2}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddPrincipal(targetsMetadata, "alice", []*tuf.Key{key1}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return targetsMetadata
+	}
+
+	entries := []*CodeOwnersEntry{
+		{Pattern: "/docs/*", Owners: []string{"@org/maintainers"}},
+		{Pattern: "/docs/vendor.md", Owners: []string{}},
+		{Pattern: "/docs/api.md", Owners: []string{"@alice", "alice@example.com", "@org/maintainers"}},
+	}
+	ownerPrincipals := map[string]string{
+		"@org/maintainers":  "maintainers",
+		"@alice":            "alice",
+		"alice@example.com": "alice",
+	}
+
+	t.Run("successful import", func(t *testing.T) {
+		targetsMetadata, err := ImportCodeOwners(newTargetsMetadata(t), entries, ownerPrincipals)
+		assert.Nil(t, err)
+
+		// The last matching entry takes precedence, so rules are added in
+		// reverse order and are terminating
+		rules := targetsMetadata.Delegations.Roles
+		assert.Equal(t, 3, len(rules))
+		assert.Equal(t, "codeowners-3", rules[0].Name)
+		assert.Equal(t, []string{"file:docs/api.md"}, rules[0].Paths)
+		assert.Equal(t, []string{"alice", "maintainers"}, rules[0].Principals)
+		assert.Equal(t, 1, rules[0].Threshold)
+		assert.True(t, rules[0].Terminating)
+		assert.Equal(t, "codeowners-1", rules[1].Name)
+		assert.Equal(t, []string{"file:docs/*"}, rules[1].Paths)
+		assert.Equal(t, []string{"maintainers"}, rules[1].Principals)
+		assert.True(t, rules[1].Terminating)
+		assert.Equal(t, AllowRuleName, rules[2].Name)
+	})
+
+	t.Run("unsupported pattern", func(t *testing.T) {
+		unsupportedEntries := append([]*CodeOwnersEntry{{Pattern: "*", Owners: []string{"@org/maintainers"}}}, entries...)
+
+		_, err := ImportCodeOwners(newTargetsMetadata(t), unsupportedEntries, ownerPrincipals)
+		assert.ErrorIs(t, err, ErrUnsupportedCodeOwnersPattern)
+	})
+
+	t.Run("unmapped owner", func(t *testing.T) {
+		_, err := ImportCodeOwners(newTargetsMetadata(t), entries, map[string]string{"@org/maintainers": "maintainers"})
+		assert.ErrorIs(t, err, ErrUnmappedCodeOwner)
+	})
+
+	t.Run("undefined principal", func(t *testing.T) {
+		_, err := ImportCodeOwners(newTargetsMetadata(t), entries[:1], map[string]string{"@org/maintainers": "unknown"})
+		assert.ErrorIs(t, err, ErrPrincipalNotFound)
+	})
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const signOffTrailerKey = "Signed-off-by"
+
+var (
+	ErrInvalidCommitConstraints = errors.New("invalid commit constraints")
+	ErrCommitConstraintViolated = errors.New("commit does not meet commit constraints")
+)
+
+// validateCommitConstraints checks that at least one constraint is set and that
+// the message pattern, if any, is a valid regular expression.
+func validateCommitConstraints(constraints *tuf.CommitConstraints) error {
+	if constraints == nil || (!constraints.RequireSignOff && constraints.MessagePattern == "" && !constraints.NoMergeCommits) {
+		return fmt.Errorf("%w: no constraints specified", ErrInvalidCommitConstraints)
+	}
+
+	if constraints.MessagePattern != "" {
+		if _, err := regexp.Compile(constraints.MessagePattern); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidCommitConstraints, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyEntryCommitConstraints checks the commits introduced by the entry
+// against the commit constraints of the rules protecting the entry's ref. The
+// names of the rules whose constraints were not met but were waived are
+// returned.
+func verifyEntryCommitConstraints(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) ([]string, error) {
+	hasCommitConstraints, err := policy.hasCommitConstraints()
+	if err != nil {
+		return nil, err
+	}
+	if !hasCommitConstraints {
+		return nil, nil
+	}
+
+	targetType, err := gitinterface.GetObjectType(repo, entry.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if targetType != plumbing.CommitObject {
+		return nil, nil
+	}
+
+	verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+	waivedRules := []string{}
+	for _, verifier := range verifiers {
+		if verifier.commitConstraints == nil {
+			continue
+		}
+
+		if commits == nil {
+			commits, err = getCommits(repo, entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		slog.Debug(fmt.Sprintf("Checking commit constraints for rule '%s'...", verifier.name))
+		err := checkCommitConstraints(verifier.commitConstraints, commits)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrCommitConstraintViolated) {
+			return nil, err
+		}
+
+		waivedRule, waiverErr := findWaivedRule(ctx, repo, policy, entry, []*Verifier{verifier})
+		if waiverErr != nil {
+			return nil, waiverErr
+		}
+		if waivedRule == "" {
+			return nil, fmt.Errorf("checking commit constraints for rule '%s' failed, %w", verifier.name, err)
+		}
+		waivedRules = append(waivedRules, waivedRule)
+	}
+
+	return waivedRules, nil
+}
+
+// checkCommitConstraints checks that each of the commits meets the constraints.
+func checkCommitConstraints(constraints *tuf.CommitConstraints, commits []*object.Commit) error {
+	var messagePattern *regexp.Regexp
+	if constraints.MessagePattern != "" {
+		var err error
+		messagePattern, err = regexp.Compile(constraints.MessagePattern)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidCommitConstraints, err)
+		}
+	}
+
+	for _, commit := range commits {
+		if constraints.NoMergeCommits && len(commit.ParentHashes) > 1 {
+			return fmt.Errorf("%w: commit '%s' is a merge commit", ErrCommitConstraintViolated, commit.Hash.String())
+		}
+
+		if constraints.RequireSignOff && !hasSignOff(commit) {
+			return fmt.Errorf("%w: commit '%s' is not signed off by its author '%s'", ErrCommitConstraintViolated, commit.Hash.String(), commit.Author.Email)
+		}
+
+		if messagePattern != nil {
+			subject, _, _ := strings.Cut(commit.Message, "\n")
+			if !messagePattern.MatchString(subject) {
+				return fmt.Errorf("%w: message of commit '%s' does not match '%s'", ErrCommitConstraintViolated, commit.Hash.String(), constraints.MessagePattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasSignOff returns true if the trailers of the commit's message include a
+// Signed-off-by trailer with the email of the commit's author.
+func hasSignOff(commit *object.Commit) bool {
+	for _, trailer := range getMessageTrailers(commit.Message) {
+		key, value, found := strings.Cut(trailer, ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(key), signOffTrailerKey) {
+			continue
+		}
+
+		start := strings.LastIndex(value, "<")
+		end := strings.LastIndex(value, ">")
+		if start == -1 || end < start {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(value[start+1:end]), commit.Author.Email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getMessageTrailers returns the lines of the last paragraph of the message,
+// which holds its trailers. A message with a single paragraph has no trailers.
+func getMessageTrailers(message string) []string {
+	paragraphs := strings.Split(strings.TrimSpace(message), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSpace(paragraphs[len(paragraphs)-1]), "\n")
+}
+
+// hasCommitConstraints returns true if any rule in the state has commit
+// constraints.
+func (s *State) hasCommitConstraints() (bool, error) {
+	for _, policyName := range s.policyNames() {
+		targetsMetadata, err := s.GetTargetsMetadata(policyName)
+		if err != nil {
+			return false, err
+		}
+
+		for _, delegation := range targetsMetadata.Delegations.Roles {
+			if delegation.CommitConstraints != nil {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
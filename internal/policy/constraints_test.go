@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCommitConstraints(t *testing.T) {
+	author := object.Signature{Name: "Jane Doe", Email: "jane.doe@example.com"}
+
+	signedOffCommit := &object.Commit{
+		Hash:         plumbing.NewHash("1111111111111111111111111111111111111111"),
+		Author:       author,
+		Message:      "feat: add widget\n\nThe widget is new.\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n",
+		ParentHashes: []plumbing.Hash{plumbing.NewHash("2222222222222222222222222222222222222222")},
+	}
+	otherSignOffCommit := &object.Commit{
+		Hash:    plumbing.NewHash("3333333333333333333333333333333333333333"),
+		Author:  author,
+		Message: "fix: repair widget\n\nSigned-off-by: John Doe <john.doe@example.com>\n",
+	}
+	noTrailersCommit := &object.Commit{
+		Hash:    plumbing.NewHash("4444444444444444444444444444444444444444"),
+		Author:  author,
+		Message: "Signed-off-by: Jane Doe <jane.doe@example.com>\n",
+	}
+	mergeCommit := &object.Commit{
+		Hash:    plumbing.NewHash("5555555555555555555555555555555555555555"),
+		Author:  author,
+		Message: "Merge branch 'feature'\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n",
+		ParentHashes: []plumbing.Hash{
+			plumbing.NewHash("2222222222222222222222222222222222222222"),
+			plumbing.NewHash("6666666666666666666666666666666666666666"),
+		},
+	}
+
+	tests := map[string]struct {
+		constraints *tuf.CommitConstraints
+		commits     []*object.Commit
+		expectedErr error
+	}{
+		"signed off": {
+			constraints: &tuf.CommitConstraints{RequireSignOff: true},
+			commits:     []*object.Commit{signedOffCommit},
+		},
+		"signed off by someone other than the author": {
+			constraints: &tuf.CommitConstraints{RequireSignOff: true},
+			commits:     []*object.Commit{signedOffCommit, otherSignOffCommit},
+			expectedErr: ErrCommitConstraintViolated,
+		},
+		"sign-off outside trailers": {
+			constraints: &tuf.CommitConstraints{RequireSignOff: true},
+			commits:     []*object.Commit{noTrailersCommit},
+			expectedErr: ErrCommitConstraintViolated,
+		},
+		"message matches pattern": {
+			constraints: &tuf.CommitConstraints{MessagePattern: `^(feat|fix)(\(.+\))?!?: `},
+			commits:     []*object.Commit{signedOffCommit, otherSignOffCommit},
+		},
+		"message does not match pattern": {
+			constraints: &tuf.CommitConstraints{MessagePattern: `^(feat|fix)(\(.+\))?!?: `},
+			commits:     []*object.Commit{signedOffCommit, mergeCommit},
+			expectedErr: ErrCommitConstraintViolated,
+		},
+		"pattern only matches first line": {
+			constraints: &tuf.CommitConstraints{MessagePattern: `^Signed-off-by`},
+			commits:     []*object.Commit{signedOffCommit},
+			expectedErr: ErrCommitConstraintViolated,
+		},
+		"no merge commits": {
+			constraints: &tuf.CommitConstraints{NoMergeCommits: true},
+			commits:     []*object.Commit{signedOffCommit, otherSignOffCommit},
+		},
+		"merge commit": {
+			constraints: &tuf.CommitConstraints{NoMergeCommits: true},
+			commits:     []*object.Commit{signedOffCommit, mergeCommit},
+			expectedErr: ErrCommitConstraintViolated,
+		},
+		"merge commit allowed": {
+			constraints: &tuf.CommitConstraints{RequireSignOff: true},
+			commits:     []*object.Commit{mergeCommit},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkCommitConstraints(test.constraints, test.commits)
+			if test.expectedErr == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateCommitConstraints(t *testing.T) {
+	err := validateCommitConstraints(&tuf.CommitConstraints{NoMergeCommits: true})
+	assert.Nil(t, err)
+
+	err = validateCommitConstraints(&tuf.CommitConstraints{})
+	assert.ErrorIs(t, err, ErrInvalidCommitConstraints)
+
+	err = validateCommitConstraints(nil)
+	assert.ErrorIs(t, err, ErrInvalidCommitConstraints)
+
+	err = validateCommitConstraints(&tuf.CommitConstraints{MessagePattern: "(unclosed"})
+	assert.ErrorIs(t, err, ErrInvalidCommitConstraints)
+}
+
+func TestVerifyEntryCommitConstraints(t *testing.T) {
+	refName := "refs/heads/main"
+
+	setConstraints := func(t *testing.T, state *State, ruleName string, constraints *tuf.CommitConstraints) {
+		t.Helper()
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetCommitConstraints(targetsMetadata, ruleName, constraints)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	t.Run("constraints met", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setConstraints(t, state, "protect-main", &tuf.CommitConstraints{MessagePattern: "^Test commit$", NoMergeCommits: true})
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("constraints not met", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setConstraints(t, state, "protect-main", &tuf.CommitConstraints{RequireSignOff: true})
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrCommitConstraintViolated)
+	})
+
+	t.Run("constraints do not apply to file rules", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setConstraints(t, state, "protect-files-1-and-2", &tuf.CommitConstraints{RequireSignOff: true})
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// PolicyDiff captures the differences between two policy states.
+type PolicyDiff struct {
+	// From and To are the IDs of the RSL entries for the compared policy
+	// states.
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	AddedRules    []*RuleDescription `json:"addedRules"`
+	RemovedRules  []*RuleDescription `json:"removedRules"`
+	ModifiedRules []*RuleChange      `json:"modifiedRules"`
+
+	AddedKeys    []*Principal `json:"addedKeys"`
+	RemovedKeys  []*Principal `json:"removedKeys"`
+	ModifiedKeys []*KeyChange `json:"modifiedKeys"`
+
+	ThresholdChanges []*ThresholdChange `json:"thresholdChanges"`
+}
+
+// RuleChange captures a rule that exists in both policy states but differs in
+// its patterns, authorized keys or principals, threshold, signed commit
+// requirement, or the policy file that declares it.
+type RuleChange struct {
+	Name   string           `json:"name"`
+	Before *RuleDescription `json:"before"`
+	After  *RuleDescription `json:"after"`
+}
+
+// KeyChange captures a key trusted in both policy states whose authorized
+// roles differ.
+type KeyChange struct {
+	KeyID        string   `json:"keyID"`
+	AddedRoles   []string `json:"addedRoles"`
+	RemovedRoles []string `json:"removedRoles"`
+}
+
+// ThresholdChange captures a change to the threshold of the root role, the
+// targets role, or a rule.
+type ThresholdChange struct {
+	RoleName string `json:"roleName"`
+	Before   int    `json:"before"`
+	After    int    `json:"after"`
+}
+
+// IsEmpty returns true if the compared policy states have no differences.
+func (d *PolicyDiff) IsEmpty() bool {
+	return len(d.AddedRules) == 0 && len(d.RemovedRules) == 0 && len(d.ModifiedRules) == 0 &&
+		len(d.AddedKeys) == 0 && len(d.RemovedKeys) == 0 && len(d.ModifiedKeys) == 0 &&
+		len(d.ThresholdChanges) == 0
+}
+
+// GetPolicyDiff compares the policy states identified by fromID and toID. Each
+// ID may be either the ID of a policy RSL entry or the ID of a commit on the
+// policy ref.
+func GetPolicyDiff(ctx context.Context, repo *git.Repository, fromID, toID plumbing.Hash) (*PolicyDiff, error) {
+	fromEntry, err := findPolicyEntry(repo, fromID)
+	if err != nil {
+		return nil, err
+	}
+	toEntry, err := findPolicyEntry(repo, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromState, err := LoadState(ctx, repo, fromEntry)
+	if err != nil {
+		return nil, err
+	}
+	toState, err := LoadState(ctx, repo, toEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := DiffStates(fromState, toState)
+	if err != nil {
+		return nil, err
+	}
+	diff.From = fromEntry.ID.String()
+	diff.To = toEntry.ID.String()
+
+	return diff, nil
+}
+
+// DiffStates compares two policy states.
+func DiffStates(from, to *State) (*PolicyDiff, error) {
+	diff := &PolicyDiff{
+		AddedRules:       []*RuleDescription{},
+		RemovedRules:     []*RuleDescription{},
+		ModifiedRules:    []*RuleChange{},
+		AddedKeys:        []*Principal{},
+		RemovedKeys:      []*Principal{},
+		ModifiedKeys:     []*KeyChange{},
+		ThresholdChanges: []*ThresholdChange{},
+	}
+
+	if err := diff.compareThresholds(from, to); err != nil {
+		return nil, err
+	}
+	if err := diff.compareRules(from, to); err != nil {
+		return nil, err
+	}
+	if err := diff.compareKeys(from, to); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+func (d *PolicyDiff) compareThresholds(from, to *State) error {
+	fromRootMetadata, err := from.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	toRootMetadata, err := to.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+
+	for _, roleName := range []string{RootRoleName, TargetsRoleName} {
+		before := fromRootMetadata.Roles[roleName].Threshold
+		after := toRootMetadata.Roles[roleName].Threshold
+		if before != after {
+			d.ThresholdChanges = append(d.ThresholdChanges, &ThresholdChange{RoleName: roleName, Before: before, After: after})
+		}
+	}
+
+	return nil
+}
+
+func (d *PolicyDiff) compareRules(from, to *State) error {
+	fromRules, err := from.ruleDescriptions()
+	if err != nil {
+		return err
+	}
+	toRules, err := to.ruleDescriptions()
+	if err != nil {
+		return err
+	}
+
+	for _, ruleName := range sortedKeys(toRules) {
+		after := toRules[ruleName]
+		before, has := fromRules[ruleName]
+		if !has {
+			d.AddedRules = append(d.AddedRules, after)
+			continue
+		}
+
+		if before.Threshold != after.Threshold {
+			d.ThresholdChanges = append(d.ThresholdChanges, &ThresholdChange{RoleName: ruleName, Before: before.Threshold, After: after.Threshold})
+		}
+
+		if !rulesEqual(before, after) {
+			d.ModifiedRules = append(d.ModifiedRules, &RuleChange{Name: ruleName, Before: before, After: after})
+		}
+	}
+
+	for _, ruleName := range sortedKeys(fromRules) {
+		if _, has := toRules[ruleName]; !has {
+			d.RemovedRules = append(d.RemovedRules, fromRules[ruleName])
+		}
+	}
+
+	return nil
+}
+
+func (d *PolicyDiff) compareKeys(from, to *State) error {
+	fromPrincipals, err := from.principals()
+	if err != nil {
+		return err
+	}
+	toPrincipals, err := to.principals()
+	if err != nil {
+		return err
+	}
+
+	fromPrincipalsMap := map[string]*Principal{}
+	for _, principal := range fromPrincipals {
+		fromPrincipalsMap[principal.KeyID] = principal
+	}
+	toPrincipalsMap := map[string]*Principal{}
+	for _, principal := range toPrincipals {
+		toPrincipalsMap[principal.KeyID] = principal
+	}
+
+	// Principals are sorted by key ID
+	for _, after := range toPrincipals {
+		before, has := fromPrincipalsMap[after.KeyID]
+		if !has {
+			d.AddedKeys = append(d.AddedKeys, after)
+			continue
+		}
+
+		change := &KeyChange{KeyID: after.KeyID, AddedRoles: []string{}, RemovedRoles: []string{}}
+		for _, role := range after.Roles {
+			if !slices.Contains(before.Roles, role) {
+				change.AddedRoles = append(change.AddedRoles, role)
+			}
+		}
+		for _, role := range before.Roles {
+			if !slices.Contains(after.Roles, role) {
+				change.RemovedRoles = append(change.RemovedRoles, role)
+			}
+		}
+		if len(change.AddedRoles) > 0 || len(change.RemovedRoles) > 0 {
+			d.ModifiedKeys = append(d.ModifiedKeys, change)
+		}
+	}
+
+	for _, before := range fromPrincipals {
+		if _, has := toPrincipalsMap[before.KeyID]; !has {
+			d.RemovedKeys = append(d.RemovedKeys, before)
+		}
+	}
+
+	return nil
+}
+
+// findPolicyEntry returns the policy RSL entry identified by id, which may be
+// either the ID of the RSL entry or the ID of the policy commit it records.
+func findPolicyEntry(repo *git.Repository, id plumbing.Hash) (*rsl.ReferenceEntry, error) {
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	for {
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return nil, ErrPolicyNotFound
+			}
+			return nil, err
+		}
+
+		if entry.ID == id || entry.TargetID == id {
+			return entry, nil
+		}
+
+		entry, _, err = rsl.GetLatestReferenceEntryForRefBefore(repo, PolicyRef, entry.ID)
+	}
+}
+
+// rulesEqual checks if two descriptions of a rule are equivalent, ignoring
+// the expiry of the policy files declaring them.
+func rulesEqual(a, b *RuleDescription) bool {
+	if a.PolicyName != b.PolicyName || a.Threshold != b.Threshold || a.RequireSignedCommits != b.RequireSignedCommits || a.InTotoLayoutDigest != b.InTotoLayoutDigest || a.EnginePolicyEngine != b.EnginePolicyEngine || a.EnginePolicyDigest != b.EnginePolicyDigest {
+		return false
+	}
+
+	if a.RequireSignOff != b.RequireSignOff || a.CommitMessagePattern != b.CommitMessagePattern || a.NoMergeCommits != b.NoMergeCommits {
+		return false
+	}
+
+	if !slices.Equal(a.Patterns, b.Patterns) || !slices.Equal(a.Principals, b.Principals) || !slices.Equal(a.InTotoLayoutKeyIDs, b.InTotoLayoutKeyIDs) {
+		return false
+	}
+
+	aKeyIDs := set.NewSet[string]()
+	for _, key := range a.Keys {
+		aKeyIDs.Add(key.KeyID)
+	}
+	bKeyIDs := set.NewSet[string]()
+	for _, key := range b.Keys {
+		if !aKeyIDs.Has(key.KeyID) {
+			return false
+		}
+		bKeyIDs.Add(key.KeyID)
+	}
+
+	return aKeyIDs.Len() == bKeyIDs.Len()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPolicyDiff(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+	firstEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "protect-main", []*tuf.Key{gpgKey}, []string{"git:refs/heads/main", "git:refs/heads/develop"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = RemoveDelegation(targetsMetadata, "protect-files-1-and-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-release", []*tuf.Key{releaseKey}, []string{"git:refs/heads/release"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.TargetsEnvelope = targetsEnv
+
+	if err := state.Commit(repo, "Update test state", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(testCtx, repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	secondEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("RSL entry and policy commit IDs", func(t *testing.T) {
+		diff, err := GetPolicyDiff(testCtx, repo, firstEntry.ID, secondEntry.TargetID)
+		assert.Nil(t, err)
+		assert.Equal(t, firstEntry.ID.String(), diff.From)
+		assert.Equal(t, secondEntry.ID.String(), diff.To)
+
+		assert.Equal(t, 1, len(diff.AddedRules))
+		assert.Equal(t, "protect-release", diff.AddedRules[0].Name)
+
+		assert.Equal(t, 1, len(diff.RemovedRules))
+		assert.Equal(t, "protect-files-1-and-2", diff.RemovedRules[0].Name)
+
+		assert.Equal(t, 1, len(diff.ModifiedRules))
+		assert.Equal(t, "protect-main", diff.ModifiedRules[0].Name)
+		assert.Equal(t, []string{"git:refs/heads/main"}, diff.ModifiedRules[0].Before.Patterns)
+		assert.Equal(t, []string{"git:refs/heads/main", "git:refs/heads/develop"}, diff.ModifiedRules[0].After.Patterns)
+
+		assert.Equal(t, 1, len(diff.AddedKeys))
+		assert.Equal(t, releaseKey.KeyID, diff.AddedKeys[0].KeyID)
+		assert.Empty(t, diff.RemovedKeys)
+
+		assert.Equal(t, []*KeyChange{{KeyID: gpgKey.KeyID, AddedRoles: []string{}, RemovedRoles: []string{"protect-files-1-and-2"}}}, diff.ModifiedKeys)
+		assert.Empty(t, diff.ThresholdChanges)
+		assert.False(t, diff.IsEmpty())
+	})
+
+	t.Run("same policy", func(t *testing.T) {
+		diff, err := GetPolicyDiff(testCtx, repo, secondEntry.ID, secondEntry.TargetID)
+		assert.Nil(t, err)
+		assert.True(t, diff.IsEmpty())
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		_, err := GetPolicyDiff(testCtx, repo, plumbing.ZeroHash, secondEntry.ID)
+		assert.ErrorIs(t, err, ErrPolicyNotFound)
+	})
+}
+
+func TestDiffStatesThresholds(t *testing.T) {
+	from := createTestStateWithPolicy(t)
+	to := createTestStateWithThresholdPolicy(t)
+
+	approverKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := DiffStates(from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, []*ThresholdChange{{RoleName: "protect-main", Before: 1, After: 2}}, diff.ThresholdChanges)
+	assert.Equal(t, 1, len(diff.ModifiedRules))
+	assert.Equal(t, 1, len(diff.AddedKeys))
+	assert.Equal(t, approverKey.KeyID, diff.AddedKeys[0].KeyID)
+	assert.Equal(t, []string{"protect-main"}, diff.AddedKeys[0].Roles)
+}
@@ -0,0 +1,360 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+const (
+	// DefaultEngineEvaluationTimeout is the default limit on the time taken
+	// to evaluate a single engine policy.
+	DefaultEngineEvaluationTimeout = 5 * time.Second
+
+	// MaxEnginePolicySize is the maximum size in bytes of an engine policy's
+	// source, as it is embedded in the policy metadata.
+	MaxEnginePolicySize = 64 * 1024
+)
+
+var (
+	ErrUnknownPolicyEngine           = errors.New("unknown policy engine")
+	ErrPolicyEngineExists            = errors.New("policy engine with the same name is already registered")
+	ErrInvalidEnginePolicy           = errors.New("invalid engine policy")
+	ErrEnginePolicyTooLarge          = errors.New("engine policy exceeds maximum permitted size")
+	ErrEnginePolicyDenied            = errors.New("change denied by engine policy")
+	ErrEnginePolicyEvaluationFailed  = errors.New("unable to evaluate engine policy")
+	ErrEnginePolicyEvaluationTimeout = errors.New("engine policy evaluation timed out")
+)
+
+// EngineEvaluationTimeout is the limit on the time taken to evaluate a single
+// engine policy. Policies that take longer are treated as denying the change.
+var EngineEvaluationTimeout = DefaultEngineEvaluationTimeout
+
+// PolicyEngine is the extension point for evaluating policies embedded in
+// rules using languages other than gittuf's key and threshold based rules.
+// Engines are identified by the name recorded alongside each policy. Engines
+// must be deterministic and must not access the network or the filesystem, so
+// that every verifier reaches the same result.
+type PolicyEngine interface {
+	// Validate checks that the policy's source can be evaluated by the
+	// engine. It is invoked when the policy is added to a rule.
+	Validate(ctx context.Context, source string) error
+
+	// Evaluate returns nil if the policy allows the change described by
+	// input. If the policy does not allow the change, ErrEnginePolicyDenied
+	// is returned.
+	Evaluate(ctx context.Context, source string, input *EngineInput) error
+}
+
+var (
+	policyEngines      = map[string]PolicyEngine{RegoEngineName: &regoEngine{}}
+	policyEnginesMutex sync.RWMutex
+)
+
+// RegisterPolicyEngine adds engine to the set of engines available to evaluate
+// policies, using the specified name.
+func RegisterPolicyEngine(name string, engine PolicyEngine) error {
+	policyEnginesMutex.Lock()
+	defer policyEnginesMutex.Unlock()
+
+	if _, has := policyEngines[name]; has {
+		return ErrPolicyEngineExists
+	}
+
+	policyEngines[name] = engine
+	return nil
+}
+
+// getPolicyEngine returns the registered engine with the specified name.
+func getPolicyEngine(name string) (PolicyEngine, error) {
+	policyEnginesMutex.RLock()
+	defer policyEnginesMutex.RUnlock()
+
+	engine, has := policyEngines[name]
+	if !has {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownPolicyEngine, name)
+	}
+
+	return engine, nil
+}
+
+// EngineInput describes a change protected by a rule with an engine policy. It
+// is the input the policy is evaluated against.
+type EngineInput struct {
+	// Rule is the name of the rule the policy is recorded in.
+	Rule string `json:"rule"`
+
+	// Ref is the Git reference updated by the change.
+	Ref string `json:"ref"`
+
+	// Entry is the RSL entry that records the change.
+	Entry *EngineInputEntry `json:"entry"`
+
+	// ChangedPaths are the paths of the files changed since the previous
+	// entry for the ref.
+	ChangedPaths []string `json:"changed_paths"`
+
+	// Commits are the commits introduced to the ref by the change.
+	Commits []*EngineInputCommit `json:"commits"`
+
+	// Attestations summarizes the attestations recorded for the change.
+	Attestations *EngineInputAttestations `json:"attestations"`
+}
+
+// EngineInputEntry describes the RSL entry that records a change.
+type EngineInputEntry struct {
+	ID       string `json:"id"`
+	Number   uint64 `json:"number"`
+	TargetID string `json:"target_id"`
+	Actor    string `json:"actor,omitempty"`
+	ActorURL string `json:"actor_url,omitempty"`
+}
+
+// EngineInputCommit describes a commit introduced by a change.
+type EngineInputCommit struct {
+	ID        string                `json:"id"`
+	Parents   []string              `json:"parents"`
+	Author    *EngineInputSignature `json:"author"`
+	Committer *EngineInputSignature `json:"committer"`
+	Message   string                `json:"message"`
+	Signed    bool                  `json:"signed"`
+}
+
+// EngineInputSignature describes the author or committer of a commit. The
+// time is formatted using RFC 3339.
+type EngineInputSignature struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Time  string `json:"time"`
+}
+
+// EngineInputAttestations summarizes the attestations recorded for a change.
+type EngineInputAttestations struct {
+	// ReferenceAuthorization indicates if a reference authorization is
+	// recorded for the change. Its signatures are not verified.
+	ReferenceAuthorization bool `json:"reference_authorization"`
+
+	// GitHubPullRequestApprovers are the approvers of the change recorded
+	// by a GitHub pull request approval signed by a trusted GitHub app.
+	GitHubPullRequestApprovers []string `json:"github_pull_request_approvers"`
+
+	// InTotoLinks are the file names of the in-toto links recorded for the
+	// change.
+	InTotoLinks []string `json:"in_toto_links"`
+}
+
+// verifyEntryEnginePolicies evaluates the engine policies of the rules that
+// protect the ref or any of the files changed by the entry. The names of the
+// rules whose policies denied the change but were waived are returned.
+func verifyEntryEnginePolicies(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) ([]string, error) {
+	hasEnginePolicy, err := policy.hasEnginePolicy()
+	if err != nil {
+		return nil, err
+	}
+	if !hasEnginePolicy {
+		return nil, nil
+	}
+
+	targetType, err := gitinterface.GetObjectType(repo, entry.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if targetType != plumbing.CommitObject {
+		return nil, nil
+	}
+
+	verifiers, err := getEntryVerifiers(repo, policy, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	var input *EngineInput
+	waivedRules := []string{}
+	for _, verifier := range verifiers {
+		if verifier.enginePolicy == nil {
+			continue
+		}
+
+		if input == nil {
+			input, err = getEngineInput(ctx, repo, policy, attestationsState, entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+		input.Rule = verifier.name
+
+		slog.Debug(fmt.Sprintf("Evaluating %s policy for rule '%s'...", verifier.enginePolicy.Engine, verifier.name))
+		err := evaluateEnginePolicy(ctx, verifier.enginePolicy.Engine, verifier.enginePolicy.Source, input)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrEnginePolicyDenied) {
+			return nil, err
+		}
+
+		waivedRule, waiverErr := findWaivedRule(ctx, repo, policy, entry, []*Verifier{verifier})
+		if waiverErr != nil {
+			return nil, waiverErr
+		}
+		if waivedRule == "" {
+			return nil, fmt.Errorf("evaluating %s policy for rule '%s' failed, %w", verifier.enginePolicy.Engine, verifier.name, err)
+		}
+		waivedRules = append(waivedRules, waivedRule)
+	}
+
+	return waivedRules, nil
+}
+
+// evaluateEnginePolicy evaluates the policy using the named engine, limiting
+// the evaluation to EngineEvaluationTimeout.
+func evaluateEnginePolicy(ctx context.Context, engineName, source string, input *EngineInput) error {
+	engine, err := getPolicyEngine(engineName)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, EngineEvaluationTimeout)
+	defer cancel()
+
+	err = engine.Evaluate(ctx, source, input)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// A policy that doesn't complete in time can't allow the change, even
+		// if the engine didn't stop evaluating it
+		return fmt.Errorf("%w: %w", ErrEnginePolicyDenied, ErrEnginePolicyEvaluationTimeout)
+	}
+	return err
+}
+
+// validateEnginePolicy checks that the policy can be evaluated by the named
+// engine.
+func validateEnginePolicy(ctx context.Context, engineName, source string) error {
+	if len(source) > MaxEnginePolicySize {
+		return ErrEnginePolicyTooLarge
+	}
+
+	engine, err := getPolicyEngine(engineName)
+	if err != nil {
+		return err
+	}
+
+	return engine.Validate(ctx, source)
+}
+
+// getEngineInput describes the change recorded by the entry for evaluation by
+// engine policies.
+func getEngineInput(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) (*EngineInput, error) {
+	input := &EngineInput{
+		Ref: entry.RefName,
+		Entry: &EngineInputEntry{
+			ID:       entry.ID.String(),
+			Number:   entry.Number,
+			TargetID: entry.TargetID.String(),
+			Actor:    entry.Actor,
+			ActorURL: entry.ActorURL,
+		},
+		ChangedPaths: []string{},
+		Commits:      []*EngineInputCommit{},
+		Attestations: &EngineInputAttestations{
+			GitHubPullRequestApprovers: []string{},
+			InTotoLinks:                []string{},
+		},
+	}
+
+	changedPaths, err := getChangedPaths(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+	input.ChangedPaths = append(input.ChangedPaths, changedPaths...)
+
+	commits, err := getCommits(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+	for _, commit := range commits {
+		parents := make([]string, 0, len(commit.ParentHashes))
+		for _, parentID := range commit.ParentHashes {
+			parents = append(parents, parentID.String())
+		}
+
+		input.Commits = append(input.Commits, &EngineInputCommit{
+			ID:      commit.Hash.String(),
+			Parents: parents,
+			Author: &EngineInputSignature{
+				Name:  commit.Author.Name,
+				Email: commit.Author.Email,
+				Time:  commit.Author.When.Format(time.RFC3339),
+			},
+			Committer: &EngineInputSignature{
+				Name:  commit.Committer.Name,
+				Email: commit.Committer.Email,
+				Time:  commit.Committer.When.Format(time.RFC3339),
+			},
+			Message: commit.Message,
+			Signed:  commit.PGPSignature != "",
+		})
+	}
+
+	if attestationsState == nil {
+		return input, nil
+	}
+
+	fromID, targetTreeID, err := getEntryChange(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	authorization, err := attestationsState.GetReferenceAuthorizationFor(repo, entry.RefName, fromID, targetTreeID)
+	if err != nil {
+		if !errors.Is(err, attestations.ErrAuthorizationNotFound) {
+			return nil, err
+		}
+	}
+	input.Attestations.ReferenceAuthorization = authorization != nil
+
+	approvers, err := getGitHubPullRequestApprovers(ctx, repo, policy, attestationsState, entry)
+	if err != nil {
+		return nil, err
+	}
+	input.Attestations.GitHubPullRequestApprovers = append(input.Attestations.GitHubPullRequestApprovers, approvers...)
+
+	links, err := attestationsState.GetInTotoLinksFor(repo, entry.RefName, fromID, targetTreeID)
+	if err != nil {
+		return nil, err
+	}
+	for linkName := range links {
+		input.Attestations.InTotoLinks = append(input.Attestations.InTotoLinks, linkName)
+	}
+	slices.Sort(input.Attestations.InTotoLinks)
+
+	return input, nil
+}
+
+// hasEnginePolicy returns true if any rule in the state has an engine policy.
+func (s *State) hasEnginePolicy() (bool, error) {
+	for _, policyName := range s.policyNames() {
+		targetsMetadata, err := s.GetTargetsMetadata(policyName)
+		if err != nil {
+			return false, err
+		}
+
+		for _, delegation := range targetsMetadata.Delegations.Roles {
+			if delegation.EnginePolicy != nil {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRegoAllowMainPolicy = `package gittuf
+
+import rego.v1
+
+default allow := false
+
+allow if {
+	input.ref == "refs/heads/main"
+	every commit in input.commits {
+		commit.signed
+	}
+}
+`
+
+	testRegoAllowFeaturePolicy = `package gittuf
+
+import rego.v1
+
+allow if input.ref == "refs/heads/feature"
+`
+)
+
+func TestRegoEngine(t *testing.T) {
+	engine := &regoEngine{}
+
+	t.Run("allowed", func(t *testing.T) {
+		err := engine.Evaluate(testCtx, testRegoAllowMainPolicy, &EngineInput{Ref: "refs/heads/main", Commits: []*EngineInputCommit{{Signed: true}}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		err := engine.Evaluate(testCtx, testRegoAllowMainPolicy, &EngineInput{Ref: "refs/heads/main", Commits: []*EngineInputCommit{{Signed: false}}})
+		assert.ErrorIs(t, err, ErrEnginePolicyDenied)
+	})
+
+	t.Run("undefined allow", func(t *testing.T) {
+		err := engine.Evaluate(testCtx, testRegoAllowFeaturePolicy, &EngineInput{Ref: "refs/heads/main"})
+		assert.ErrorIs(t, err, ErrEnginePolicyDenied)
+	})
+
+	t.Run("wrong package", func(t *testing.T) {
+		err := engine.Validate(testCtx, "package other\n\nallow := true\n")
+		assert.ErrorIs(t, err, ErrInvalidEnginePolicy)
+		assert.ErrorIs(t, err, ErrRegoPackageMismatch)
+	})
+
+	t.Run("invalid syntax", func(t *testing.T) {
+		err := engine.Validate(testCtx, "package gittuf\n\nallow := {\n")
+		assert.ErrorIs(t, err, ErrInvalidEnginePolicy)
+	})
+
+	t.Run("deterministic built-in functions", func(t *testing.T) {
+		for _, call := range []string{
+			`count(input.commits) > 0`,
+			`regex.match("^feat: ", input.commits[0].message)`,
+			`time.parse_rfc3339_ns(input.commits[0].author.time) > 0`,
+		} {
+			err := engine.Validate(testCtx, "package gittuf\n\nimport rego.v1\n\nallow if "+call+"\n")
+			assert.Nil(t, err, call)
+		}
+	})
+
+	t.Run("unsafe built-in functions", func(t *testing.T) {
+		for _, call := range []string{
+			`http.send({"method": "GET", "url": "https://example.com"})`,
+			`net.lookup_ip_addr("example.com")`,
+			`time.now_ns()`,
+			`rand.intn("gittuf", 10)`,
+			`uuid.rfc4122("gittuf")`,
+			`opa.runtime()`,
+			`crypto.x509.parse_and_verify_certificates("")`,
+		} {
+			err := engine.Validate(testCtx, "package gittuf\n\nimport rego.v1\n\nallow if "+call+"\n")
+			assert.ErrorIs(t, err, ErrInvalidEnginePolicy, call)
+			assert.ErrorContains(t, err, "undefined function", call)
+		}
+	})
+}
+
+func TestGetRegoCapabilities(t *testing.T) {
+	capabilities, err := getRegoCapabilities()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, capabilities.AllowNet)
+	assert.NotEmpty(t, capabilities.Builtins)
+	for _, builtin := range capabilities.Builtins {
+		assert.False(t, builtin.IsNondeterministic(), builtin.Name)
+		assert.False(t, regoForbiddenBuiltins[builtin.Name], builtin.Name)
+	}
+}
+
+func TestEvaluateEnginePolicy(t *testing.T) {
+	t.Run("unknown engine", func(t *testing.T) {
+		err := evaluateEnginePolicy(testCtx, "unknown", testRegoAllowMainPolicy, &EngineInput{})
+		assert.ErrorIs(t, err, ErrUnknownPolicyEngine)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		currentTimeout := EngineEvaluationTimeout
+		EngineEvaluationTimeout = time.Nanosecond
+		defer func() {
+			EngineEvaluationTimeout = currentTimeout
+		}()
+
+		err := evaluateEnginePolicy(testCtx, RegoEngineName, testRegoAllowMainPolicy, &EngineInput{Ref: "refs/heads/main"})
+		assert.ErrorIs(t, err, ErrEnginePolicyDenied)
+		assert.ErrorIs(t, err, ErrEnginePolicyEvaluationTimeout)
+	})
+}
+
+func TestValidateEnginePolicy(t *testing.T) {
+	err := validateEnginePolicy(testCtx, RegoEngineName, testRegoAllowMainPolicy)
+	assert.Nil(t, err)
+
+	err = validateEnginePolicy(testCtx, RegoEngineName, testRegoAllowMainPolicy+"# "+strings.Repeat("a", MaxEnginePolicySize))
+	assert.ErrorIs(t, err, ErrEnginePolicyTooLarge)
+
+	err = validateEnginePolicy(testCtx, "unknown", testRegoAllowMainPolicy)
+	assert.ErrorIs(t, err, ErrUnknownPolicyEngine)
+}
+
+func TestRegisterPolicyEngine(t *testing.T) {
+	err := RegisterPolicyEngine(RegoEngineName, &regoEngine{})
+	assert.ErrorIs(t, err, ErrPolicyEngineExists)
+}
+
+func TestVerifyEntryEnginePolicies(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// The policy is set for the rule protecting the files changed by the
+	// test commit
+	setPolicy := func(t *testing.T, state *State, source string) {
+		t.Helper()
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetEnginePolicy(testCtx, targetsMetadata, "protect-files-1-and-2", RegoEngineName, source)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setPolicy(t, state, testRegoAllowMainPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setPolicy(t, state, testRegoAllowFeaturePolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrEnginePolicyDenied)
+	})
+
+	t.Run("input describes change", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		input, err := getEngineInput(testCtx, repo, nil, nil, entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, refName, input.Ref)
+		assert.Equal(t, entryID.String(), input.Entry.ID)
+		assert.Equal(t, commitIDs[1].String(), input.Entry.TargetID)
+		assert.Len(t, input.Commits, 2)
+		assert.Contains(t, input.ChangedPaths, "1")
+		for _, commit := range input.Commits {
+			assert.True(t, commit.Signed)
+		}
+		assert.False(t, input.Attestations.ReferenceAuthorization)
+		assert.Empty(t, input.Attestations.InTotoLinks)
+	})
+
+	t.Run("policy does not apply to unprotected files", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setPolicy(t, state, testRegoAllowFeaturePolicy)
+
+		// The first two commits change files 1 and 2, so record them in a
+		// separate entry from the third commit that only adds file 3
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(refName, commitIDs[1]), gpgKeyBytes)
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[2])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultMetadataValidity is the period for which newly created or refreshed
+// policy metadata is valid.
+const DefaultMetadataValidity = 365 * 24 * time.Hour
+
+var ErrMetadataExpired = errors.New("policy metadata has expired")
+
+// VerifyExpiration checks that none of the metadata in the state has expired
+// at the specified time. Metadata is considered expired only once the grace
+// period has elapsed past its expiry. Metadata without an expiry is never
+// considered expired.
+func (s *State) VerifyExpiration(now time.Time, gracePeriod time.Duration) error {
+	for _, roleName := range append([]string{RootRoleName}, s.policyNames()...) {
+		expires, err := s.GetMetadataExpiration(roleName)
+		if err != nil {
+			return err
+		}
+
+		if expires.IsZero() {
+			continue
+		}
+
+		if now.After(expires.Add(gracePeriod)) {
+			return fmt.Errorf("%w: '%s' expired at %s", ErrMetadataExpired, roleName, expires.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// GetMetadataExpiration returns the expiry of the specified role's metadata.
+// If the metadata does not declare an expiry, the zero time is returned.
+func (s *State) GetMetadataExpiration(roleName string) (time.Time, error) {
+	var expires string
+	if roleName == RootRoleName {
+		rootMetadata, err := s.GetRootMetadata()
+		if err != nil {
+			return time.Time{}, err
+		}
+		expires = rootMetadata.Expires
+	} else {
+		targetsMetadata, err := s.GetTargetsMetadata(roleName)
+		if err != nil {
+			return time.Time{}, err
+		}
+		expires = targetsMetadata.Expires
+	}
+
+	if expires == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, expires)
+}
+
+// GetRolesForKey returns the names of the roles with metadata in the state
+// that the specified key is authorized to sign. The root role, if
+// applicable, is always first.
+func (s *State) GetRolesForKey(keyID string) ([]string, error) {
+	principals, err := s.principals()
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames := []string{}
+	for _, principal := range principals {
+		if principal.KeyID != keyID {
+			continue
+		}
+
+		for _, roleName := range principal.Roles {
+			if roleName == RootRoleName || s.HasTargetsRole(roleName) {
+				roleNames = append(roleNames, roleName)
+			}
+		}
+	}
+
+	return roleNames, nil
+}
+
+// MetadataExpiration records the expiry of a role's metadata.
+type MetadataExpiration struct {
+	RoleName string
+	Expires  time.Time
+}
+
+// GetMetadataExpirations returns the expiry of the metadata of every role in
+// the state, starting with the root role followed by the top level policy file
+// and the delegated policy files in sorted order. Roles whose metadata does not
+// declare an expiry are omitted.
+func (s *State) GetMetadataExpirations() ([]*MetadataExpiration, error) {
+	expirations := []*MetadataExpiration{}
+	for _, roleName := range append([]string{RootRoleName}, s.policyNames()...) {
+		expires, err := s.GetMetadataExpiration(roleName)
+		if err != nil {
+			return nil, err
+		}
+
+		if expires.IsZero() {
+			continue
+		}
+
+		expirations = append(expirations, &MetadataExpiration{RoleName: roleName, Expires: expires})
+	}
+
+	return expirations, nil
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyExpiration(t *testing.T) {
+	state := createTestStateWithDelegatedPolicies(t)
+	now := time.Now()
+
+	err := state.VerifyExpiration(now, 0)
+	assert.Nil(t, err)
+
+	// Metadata is created with an expiry one year from now
+	err = state.VerifyExpiration(now.AddDate(2, 0, 0), 0)
+	assert.ErrorIs(t, err, ErrMetadataExpired)
+
+	err = state.VerifyExpiration(now.AddDate(2, 0, 0), 2*DefaultMetadataValidity)
+	assert.Nil(t, err)
+}
+
+func TestGetMetadataExpiration(t *testing.T) {
+	state := createTestStateWithDelegatedPolicies(t)
+
+	targetsMetadata, err := state.GetTargetsMetadata("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedExpiry, err := time.Parse(time.RFC3339, targetsMetadata.Expires)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expires, err := state.GetMetadataExpiration("1")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedExpiry, expires)
+
+	_, err = state.GetMetadataExpiration("2")
+	assert.ErrorIs(t, err, ErrMetadataNotFound)
+}
+
+func TestGetMetadataExpirations(t *testing.T) {
+	state := createTestStateWithDelegatedPolicies(t)
+
+	expirations, err := state.GetMetadataExpirations()
+	assert.Nil(t, err)
+
+	roleNames := []string{}
+	for _, expiration := range expirations {
+		roleNames = append(roleNames, expiration.RoleName)
+
+		expires, err := state.GetMetadataExpiration(expiration.RoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expires, expiration.Expires)
+	}
+	assert.Equal(t, []string{RootRoleName, TargetsRoleName, "1"}, roleNames)
+}
+
+func TestGetRolesForKey(t *testing.T) {
+	state := createTestStateWithDelegatedPolicies(t)
+
+	key, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rule 2 has no metadata of its own
+	roleNames, err := state.GetRolesForKey(key.KeyID)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{RootRoleName, TargetsRoleName, "1"}, roleNames)
+
+	// Rules 3 and 4 have no metadata of their own
+	roleNames, err = state.GetRolesForKey(gpgKey.KeyID)
+	assert.Nil(t, err)
+	assert.Empty(t, roleNames)
+}
@@ -306,6 +306,86 @@ func createTestStateWithThresholdPolicy(t *testing.T) *State {
 	return state
 }
 
+func createTestStateWithThresholdPolicyAndGitHubApp(t *testing.T) *State {
+	t.Helper()
+
+	state := createTestStateWithThresholdPolicy(t)
+
+	appKey, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = AddGitHubAppKey(rootMetadata, appKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.RootEnvelope = rootEnv
+
+	return state
+}
+
+func createTestStateWithSignedCommitsPolicy(t *testing.T) *State {
+	t.Helper()
+
+	state := createTestStateWithPolicy(t)
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the file rule so that commit signatures are only checked due to
+	// the Git namespace rule
+	targetsMetadata, err = RemoveDelegation(targetsMetadata, "protect-files-1-and-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetRequireSignedCommits(targetsMetadata, "protect-main", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.TargetsEnvelope = targetsEnv
+
+	if err := state.loadRuleNames(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
 func createTestStateWithTagPolicy(t *testing.T) *State {
 	t.Helper()
 
@@ -344,6 +424,60 @@ func createTestStateWithTagPolicy(t *testing.T) *State {
 	return state
 }
 
+// createTestStateWithNamespacePolicy returns a policy that protects refs in
+// namespaces other than refs/heads and refs/tags. The notes and replace
+// namespaces are protected using the GPG key, while all refs one level below
+// any namespace are also protected using the targets1 key.
+func createTestStateWithNamespacePolicy(t *testing.T) *State {
+	t.Helper()
+
+	state := createTestStateWithPolicy(t)
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets1Key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-notes", []*tuf.Key{gpgKey}, []string{"git:refs/notes/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-replace", []*tuf.Key{gpgKey}, []string{"git:refs/replace/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-namespaces", []*tuf.Key{targets1Key}, []string{"git:refs/*/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.TargetsEnvelope = targetsEnv
+
+	if err := state.loadRuleNames(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
 func createTestStateWithTagPolicyForUnauthorizedTest(t *testing.T) *State {
 	t.Helper()
 
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+)
+
+// RuleDescription captures the details of a single rule in the policy.
+type RuleDescription struct {
+	Name string `json:"name"`
+
+	// PolicyName is the name of the policy file that declares the rule.
+	PolicyName string `json:"policyName"`
+
+	Patterns             []string   `json:"patterns"`
+	Keys                 []*tuf.Key `json:"keys"`
+	Principals           []string   `json:"principals,omitempty"`
+	Threshold            int        `json:"threshold"`
+	RequireSignedCommits bool       `json:"requireSignedCommits"`
+
+	// InTotoLayoutDigest is the SHA-256 digest of the in-toto layout that
+	// must verify for changes protected by the rule, if any.
+	InTotoLayoutDigest string   `json:"inTotoLayoutDigest,omitempty"`
+	InTotoLayoutKeyIDs []string `json:"inTotoLayoutKeyIDs,omitempty"`
+
+	// EnginePolicyEngine and EnginePolicyDigest are the policy engine and
+	// the SHA-256 digest of the engine policy that must allow changes
+	// protected by the rule, if any.
+	EnginePolicyEngine string `json:"enginePolicyEngine,omitempty"`
+	EnginePolicyDigest string `json:"enginePolicyDigest,omitempty"`
+
+	// RequireSignOff, CommitMessagePattern and NoMergeCommits are the
+	// constraints on commits introduced to the refs protected by the rule.
+	RequireSignOff       bool   `json:"requireSignOff,omitempty"`
+	CommitMessagePattern string `json:"commitMessagePattern,omitempty"`
+	NoMergeCommits       bool   `json:"noMergeCommits,omitempty"`
+
+	// Expires is the expiry of the policy file that declares the rule.
+	Expires string `json:"expires"`
+}
+
+// Principal captures a key trusted in the policy along with the roles it is
+// authorized for. A principal may be trusted in a policy file without being
+// authorized for any rule, in which case Roles is empty.
+type Principal struct {
+	KeyID   string `json:"keyID"`
+	KeyType string `json:"keyType"`
+	Scheme  string `json:"scheme"`
+
+	// Roles contains the names of the roles the principal is authorized for.
+	// This includes the root and targets roles as well as rules.
+	Roles []string `json:"roles"`
+}
+
+// DescribeRule returns the details of the specified rule in the policy state
+// identified by targetRef.
+func DescribeRule(ctx context.Context, repo *git.Repository, targetRef, ruleName string) (*RuleDescription, error) {
+	state, err := LoadCurrentState(ctx, repo, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := state.ruleDescriptions()
+	if err != nil {
+		return nil, err
+	}
+
+	rule, has := rules[ruleName]
+	if !has {
+		return nil, ErrDelegationNotFound
+	}
+
+	return rule, nil
+}
+
+// ListPrincipals returns all the keys trusted in the policy state identified
+// by targetRef, sorted by key ID.
+func ListPrincipals(ctx context.Context, repo *git.Repository, targetRef string) ([]*Principal, error) {
+	state, err := LoadCurrentState(ctx, repo, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.principals()
+}
+
+// ruleDescriptions returns the details of all the rules in the state keyed by
+// rule name.
+func (s *State) ruleDescriptions() (map[string]*RuleDescription, error) {
+	rules := map[string]*RuleDescription{}
+	for _, policyName := range s.policyNames() {
+		targetsMetadata, err := s.GetTargetsMetadata(policyName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delegation := range targetsMetadata.Delegations.Roles {
+			if delegation.Name == AllowRuleName {
+				continue
+			}
+
+			keys := []*tuf.Key{}
+			for _, keyID := range delegation.KeyIDs {
+				if key, has := targetsMetadata.Delegations.Keys[keyID]; has {
+					keys = append(keys, key)
+				}
+			}
+
+			rule := &RuleDescription{
+				Name:                 delegation.Name,
+				PolicyName:           policyName,
+				Patterns:             delegation.Paths,
+				Keys:                 keys,
+				Principals:           delegation.Principals,
+				Threshold:            delegation.Threshold,
+				RequireSignedCommits: delegation.RequireSignedCommits,
+				Expires:              targetsMetadata.Expires,
+			}
+			if delegation.InTotoLayout != nil {
+				layoutDigest := sha256.Sum256(delegation.InTotoLayout.Layout)
+				rule.InTotoLayoutDigest = hex.EncodeToString(layoutDigest[:])
+				rule.InTotoLayoutKeyIDs = delegation.InTotoLayout.KeyIDs
+			}
+			if delegation.EnginePolicy != nil {
+				policyDigest := sha256.Sum256([]byte(delegation.EnginePolicy.Source))
+				rule.EnginePolicyEngine = delegation.EnginePolicy.Engine
+				rule.EnginePolicyDigest = hex.EncodeToString(policyDigest[:])
+			}
+			if delegation.CommitConstraints != nil {
+				rule.RequireSignOff = delegation.CommitConstraints.RequireSignOff
+				rule.CommitMessagePattern = delegation.CommitConstraints.MessagePattern
+				rule.NoMergeCommits = delegation.CommitConstraints.NoMergeCommits
+			}
+			rules[delegation.Name] = rule
+		}
+	}
+
+	return rules, nil
+}
+
+// principals returns all the keys trusted in the state, sorted by key ID.
+func (s *State) principals() ([]*Principal, error) {
+	principals := map[string]*Principal{}
+	addPrincipal := func(key *tuf.Key) {
+		if _, has := principals[key.KeyID]; has {
+			return
+		}
+		principals[key.KeyID] = &Principal{
+			KeyID:   key.KeyID,
+			KeyType: key.KeyType,
+			Scheme:  key.Scheme,
+			Roles:   []string{},
+		}
+	}
+	addRole := func(keyID, roleName string) {
+		if principal, has := principals[keyID]; has {
+			principal.Roles = append(principal.Roles, roleName)
+		}
+	}
+
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range rootMetadata.Keys {
+		addPrincipal(key)
+	}
+	for _, roleName := range []string{RootRoleName, TargetsRoleName} {
+		if role, has := rootMetadata.Roles[roleName]; has {
+			for _, keyID := range role.KeyIDs {
+				addRole(keyID, roleName)
+			}
+		}
+	}
+
+	for _, policyName := range s.policyNames() {
+		targetsMetadata, err := s.GetTargetsMetadata(policyName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range targetsMetadata.Delegations.Keys {
+			addPrincipal(key)
+		}
+		for _, delegation := range targetsMetadata.Delegations.Roles {
+			if delegation.Name == AllowRuleName {
+				continue
+			}
+
+			for _, keyID := range delegation.KeyIDs {
+				addRole(keyID, delegation.Name)
+			}
+		}
+	}
+
+	allPrincipals := make([]*Principal, 0, len(principals))
+	for _, principal := range principals {
+		allPrincipals = append(allPrincipals, principal)
+	}
+	sort.Slice(allPrincipals, func(i, j int) bool {
+		return allPrincipals[i].KeyID < allPrincipals[j].KeyID
+	})
+
+	return allPrincipals, nil
+}
+
+// policyNames returns the names of all the policy files in the state, starting
+// with the top level targets role followed by the delegated roles in sorted
+// order.
+func (s *State) policyNames() []string {
+	if s.TargetsEnvelope == nil {
+		return []string{}
+	}
+
+	delegatedRoleNames := make([]string, 0, len(s.DelegationEnvelopes))
+	for roleName := range s.DelegationEnvelopes {
+		delegatedRoleNames = append(delegatedRoleNames, roleName)
+	}
+	sort.Strings(delegatedRoleNames)
+
+	return append([]string{TargetsRoleName}, delegatedRoleNames...)
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeRule(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithDelegatedPolicies)
+
+	delegatedMetadata, err := state.GetTargetsMetadata("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("top level rule", func(t *testing.T) {
+		rule, err := DescribeRule(context.Background(), repo, PolicyRef, "2")
+		assert.Nil(t, err)
+		assert.Equal(t, "2", rule.Name)
+		assert.Equal(t, TargetsRoleName, rule.PolicyName)
+		assert.Equal(t, []string{"file:2/*"}, rule.Patterns)
+		assert.Equal(t, 1, rule.Threshold)
+		assert.Equal(t, 1, len(rule.Keys))
+		assert.Equal(t, "52e3b8e73279d6ebdd62a5016e2725ff284f569665eb92ccb145d83817a02997", rule.Keys[0].KeyID)
+		assert.NotEmpty(t, rule.Expires)
+	})
+
+	t.Run("delegated rule", func(t *testing.T) {
+		rule, err := DescribeRule(context.Background(), repo, PolicyRef, "3")
+		assert.Nil(t, err)
+		assert.Equal(t, "1", rule.PolicyName)
+		assert.Equal(t, []string{"file:1/subpath1/*"}, rule.Patterns)
+		assert.Equal(t, "157507bbe151e378ce8126c1dcfe043cdd2db96e", rule.Keys[0].KeyID)
+		assert.Equal(t, delegatedMetadata.Expires, rule.Expires)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		_, err := DescribeRule(context.Background(), repo, PolicyRef, "5")
+		assert.ErrorIs(t, err, ErrDelegationNotFound)
+	})
+}
+
+func TestListPrincipals(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithDelegatedPolicies)
+
+	principals, err := ListPrincipals(context.Background(), repo, PolicyRef)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(principals))
+
+	// Principals are sorted by key ID
+	assert.Equal(t, "157507bbe151e378ce8126c1dcfe043cdd2db96e", principals[0].KeyID)
+	assert.Equal(t, []string{"3", "4"}, principals[0].Roles)
+
+	assert.Equal(t, "52e3b8e73279d6ebdd62a5016e2725ff284f569665eb92ccb145d83817a02997", principals[1].KeyID)
+	assert.Equal(t, []string{RootRoleName, TargetsRoleName, "1", "2"}, principals[1].Roles)
+}
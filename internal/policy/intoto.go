@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/in-toto/in-toto-golang/in_toto"
+)
+
+var (
+	ErrInvalidInTotoLayout            = errors.New("invalid in-toto layout")
+	ErrInTotoInspectionsNotSupported  = errors.New("in-toto layouts with inspections are not supported")
+	ErrInTotoSublayoutsNotSupported   = errors.New("in-toto sublayouts are not supported")
+	ErrInTotoLayoutVerificationFailed = errors.New("in-toto layout verification failed")
+)
+
+// verifyEntryInTotoLayouts verifies the in-toto layouts of the rules that
+// protect the ref or any of the files changed by the entry. The link metadata
+// for each layout's steps is loaded from the attestations recorded for the
+// entry's change. The names of the rules whose layouts failed verification but
+// were waived are returned.
+func verifyEntryInTotoLayouts(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) ([]string, error) {
+	hasLayout, err := policy.hasInTotoLayout()
+	if err != nil {
+		return nil, err
+	}
+	if !hasLayout {
+		return nil, nil
+	}
+
+	targetType, err := gitinterface.GetObjectType(repo, entry.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if targetType != plumbing.CommitObject {
+		return nil, nil
+	}
+
+	entryVerifiers, err := getEntryVerifiers(repo, policy, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiers := []*Verifier{}
+	for _, verifier := range entryVerifiers {
+		if verifier.inTotoLayout != nil {
+			verifiers = append(verifiers, verifier)
+		}
+	}
+	if len(verifiers) == 0 {
+		return nil, nil
+	}
+
+	links := map[string][]byte{}
+	if attestationsState != nil {
+		fromID, targetTreeID, err := getEntryChange(repo, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		links, err = attestationsState.GetInTotoLinksFor(repo, entry.RefName, fromID, targetTreeID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	linkDir, err := os.MkdirTemp("", "gittuf-in-toto-links-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(linkDir) //nolint:errcheck
+
+	for linkName, linkBytes := range links {
+		if err := os.WriteFile(filepath.Join(linkDir, filepath.Base(linkName)), linkBytes, 0o600); err != nil {
+			return nil, err
+		}
+	}
+
+	waivedRules := []string{}
+	for _, verifier := range verifiers {
+		slog.Debug(fmt.Sprintf("Verifying in-toto layout for rule '%s'...", verifier.name))
+		err := verifyInTotoLayout(verifier.inTotoLayout, verifier.inTotoLayoutKeys, linkDir)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrInTotoLayoutVerificationFailed) {
+			return nil, err
+		}
+
+		waivedRule, waiverErr := findWaivedRule(ctx, repo, policy, entry, []*Verifier{verifier})
+		if waiverErr != nil {
+			return nil, waiverErr
+		}
+		if waivedRule == "" {
+			return nil, fmt.Errorf("verifying in-toto layout for rule '%s' failed, %w", verifier.name, err)
+		}
+		waivedRules = append(waivedRules, waivedRule)
+	}
+
+	return waivedRules, nil
+}
+
+// getEntryVerifiers returns the verifiers for the entry's ref and for the files
+// changed by the entry. Each rule is only returned once.
+func getEntryVerifiers(repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) ([]*Verifier, error) {
+	verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+	if err != nil {
+		return nil, err
+	}
+
+	// Notes refs record notes about other objects in trees keyed by object
+	// ID rather than the repository's files, so file rules don't apply to them
+	if !strings.HasPrefix(entry.RefName, gitinterface.NotesRefPrefix) {
+		commits, err := getCommits(repo, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		seenPaths := map[string]bool{}
+		for _, commit := range commits {
+			paths, err := gitinterface.GetFilePathsChangedByCommit(repo, commit)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, path := range paths {
+				if seenPaths[path] {
+					continue
+				}
+				seenPaths[path] = true
+
+				fileVerifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", fileRuleScheme, path))
+				if err != nil {
+					return nil, err
+				}
+				verifiers = append(verifiers, fileVerifiers...)
+			}
+		}
+	}
+
+	entryVerifiers := []*Verifier{}
+	seenRules := map[string]bool{}
+	for _, verifier := range verifiers {
+		if seenRules[verifier.name] {
+			continue
+		}
+		seenRules[verifier.name] = true
+		entryVerifiers = append(entryVerifiers, verifier)
+	}
+
+	return entryVerifiers, nil
+}
+
+// hasInTotoLayout returns true if any rule in the state has an in-toto layout.
+func (s *State) hasInTotoLayout() (bool, error) {
+	for _, policyName := range s.policyNames() {
+		targetsMetadata, err := s.GetTargetsMetadata(policyName)
+		if err != nil {
+			return false, err
+		}
+
+		for _, delegation := range targetsMetadata.Delegations.Roles {
+			if delegation.InTotoLayout != nil {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// validateInTotoLayout checks that the layout is a supported in-toto layout
+// that is signed by all of the specified keys and has not expired.
+func validateInTotoLayout(layoutBytes []byte, layoutKeys []*tuf.Key) error {
+	layoutEnv, layout, err := loadInTotoLayout(layoutBytes)
+	if err != nil {
+		return err
+	}
+
+	if err := in_toto.VerifyLayoutSignatures(layoutEnv, getInTotoKeys(layoutKeys)); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidInTotoLayout, err)
+	}
+
+	if err := in_toto.VerifyLayoutExpiration(layout); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidInTotoLayout, err)
+	}
+
+	return nil
+}
+
+// verifyInTotoLayout verifies the layout using the link metadata in linkDir.
+// This follows in-toto's final product verification, except that inspections
+// and sublayouts are not supported as they may run arbitrary commands. The
+// layout's expiry is checked when it is added to the policy rather than here,
+// as the layout is trusted for as long as the policy that records it.
+func verifyInTotoLayout(layoutBytes []byte, layoutKeys []*tuf.Key, linkDir string) error {
+	layoutEnv, layout, err := loadInTotoLayout(layoutBytes)
+	if err != nil {
+		return err
+	}
+
+	if err := in_toto.VerifyLayoutSignatures(layoutEnv, getInTotoKeys(layoutKeys)); err != nil {
+		return fmt.Errorf("%w: %w", ErrInTotoLayoutVerificationFailed, err)
+	}
+
+	rootCertPool, intermediateCertPool, err := in_toto.LoadLayoutCertificates(layout, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInTotoLayoutVerificationFailed, err)
+	}
+
+	stepsMetadata, err := in_toto.LoadLinksForLayout(layout, linkDir)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInTotoLayoutVerificationFailed, err)
+	}
+
+	stepsMetadataVerified, err := in_toto.VerifyLinkSignatureThesholds(layout, stepsMetadata, rootCertPool, intermediateCertPool)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInTotoLayoutVerificationFailed, err)
+	}
+
+	for _, linksPerStep := range stepsMetadataVerified {
+		for _, linkEnv := range linksPerStep {
+			if _, isLayout := linkEnv.GetPayload().(in_toto.Layout); isLayout {
+				return ErrInTotoSublayoutsNotSupported
+			}
+		}
+	}
+
+	stepsMetadataReduced, err := in_toto.ReduceStepsMetadata(layout, stepsMetadataVerified)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInTotoLayoutVerificationFailed, err)
+	}
+
+	steps := make([]any, 0, len(layout.Steps))
+	for _, step := range layout.Steps {
+		steps = append(steps, step)
+	}
+	if err := in_toto.VerifyArtifacts(steps, stepsMetadataReduced); err != nil {
+		return fmt.Errorf("%w: %w", ErrInTotoLayoutVerificationFailed, err)
+	}
+
+	return nil
+}
+
+// loadInTotoLayout parses the signed in-toto layout. Layouts may be signed
+// using in-toto's metablock format or a DSSE envelope.
+func loadInTotoLayout(layoutBytes []byte) (in_toto.Metadata, in_toto.Layout, error) {
+	// in-toto only loads metadata from files
+	layoutFile, err := os.CreateTemp("", "gittuf-in-toto-layout-")
+	if err != nil {
+		return nil, in_toto.Layout{}, err
+	}
+	defer os.Remove(layoutFile.Name()) //nolint:errcheck
+
+	if _, err := layoutFile.Write(layoutBytes); err != nil {
+		layoutFile.Close() //nolint:errcheck
+		return nil, in_toto.Layout{}, err
+	}
+	if err := layoutFile.Close(); err != nil {
+		return nil, in_toto.Layout{}, err
+	}
+
+	layoutEnv, err := in_toto.LoadMetadata(layoutFile.Name())
+	if err != nil {
+		return nil, in_toto.Layout{}, fmt.Errorf("%w: %w", ErrInvalidInTotoLayout, err)
+	}
+
+	layout, isLayout := layoutEnv.GetPayload().(in_toto.Layout)
+	if !isLayout {
+		return nil, in_toto.Layout{}, fmt.Errorf("%w: %w", ErrInvalidInTotoLayout, in_toto.ErrNotLayout)
+	}
+
+	if len(layout.Inspect) != 0 {
+		return nil, in_toto.Layout{}, ErrInTotoInspectionsNotSupported
+	}
+
+	return layoutEnv, layout, nil
+}
+
+// getInTotoKeys converts the keys to in-toto's key format, keyed by key ID.
+func getInTotoKeys(keys []*tuf.Key) map[string]in_toto.Key {
+	inTotoKeys := make(map[string]in_toto.Key, len(keys))
+	for _, key := range keys {
+		inTotoKeys[key.KeyID] = in_toto.Key{
+			KeyID:               key.KeyID,
+			KeyIDHashAlgorithms: key.KeyIDHashAlgorithms,
+			KeyType:             key.KeyType,
+			KeyVal: in_toto.KeyVal{
+				Public:      key.KeyVal.Public,
+				Certificate: key.KeyVal.Certificate,
+			},
+			Scheme: key.Scheme,
+		}
+	}
+
+	return inTotoKeys
+}
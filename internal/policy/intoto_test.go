@@ -0,0 +1,328 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testInTotoStepName       = "build"
+	testInTotoArtifactDigest = "2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func TestVerifyInTotoLayout(t *testing.T) {
+	layoutKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layout := createTestInTotoLayout(t, nil)
+
+	t.Run("successful verification", func(t *testing.T) {
+		linkDir := writeTestInTotoLinks(t, createTestInTotoLink(t, targets1KeyBytes, "foo"))
+
+		err := verifyInTotoLayout(layout, []*tuf.Key{layoutKey}, linkDir)
+		assert.Nil(t, err)
+	})
+
+	t.Run("missing link", func(t *testing.T) {
+		linkDir := writeTestInTotoLinks(t)
+
+		err := verifyInTotoLayout(layout, []*tuf.Key{layoutKey}, linkDir)
+		assert.ErrorIs(t, err, ErrInTotoLayoutVerificationFailed)
+	})
+
+	t.Run("link from unauthorized functionary", func(t *testing.T) {
+		linkDir := writeTestInTotoLinks(t, createTestInTotoLink(t, targets2KeyBytes, "foo"))
+
+		err := verifyInTotoLayout(layout, []*tuf.Key{layoutKey}, linkDir)
+		assert.ErrorIs(t, err, ErrInTotoLayoutVerificationFailed)
+	})
+
+	t.Run("layout not signed by layout key", func(t *testing.T) {
+		linkDir := writeTestInTotoLinks(t, createTestInTotoLink(t, targets1KeyBytes, "foo"))
+
+		err := verifyInTotoLayout(layout, []*tuf.Key{otherKey}, linkDir)
+		assert.ErrorIs(t, err, ErrInTotoLayoutVerificationFailed)
+	})
+
+	t.Run("disallowed product", func(t *testing.T) {
+		layout := createTestInTotoLayout(t, [][]string{{"ALLOW", "foo"}, {"DISALLOW", "*"}})
+		linkDir := writeTestInTotoLinks(t, createTestInTotoLink(t, targets1KeyBytes, "bar"))
+
+		err := verifyInTotoLayout(layout, []*tuf.Key{layoutKey}, linkDir)
+		assert.ErrorIs(t, err, ErrInTotoLayoutVerificationFailed)
+	})
+
+	t.Run("layout with inspections", func(t *testing.T) {
+		layout := createTestInTotoLayoutWithInspection(t)
+		linkDir := writeTestInTotoLinks(t, createTestInTotoLink(t, targets1KeyBytes, "foo"))
+
+		err := verifyInTotoLayout(layout, []*tuf.Key{layoutKey}, linkDir)
+		assert.ErrorIs(t, err, ErrInTotoInspectionsNotSupported)
+	})
+
+	t.Run("not a layout", func(t *testing.T) {
+		linkDir := writeTestInTotoLinks(t)
+
+		err := verifyInTotoLayout(createTestInTotoLink(t, rootKeyBytes, "foo"), []*tuf.Key{layoutKey}, linkDir)
+		assert.ErrorIs(t, err, ErrInvalidInTotoLayout)
+	})
+}
+
+func TestVerifyEntryInTotoLayouts(t *testing.T) {
+	refName := "refs/heads/main"
+
+	layoutKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The layout is set for the rule protecting the files changed by the
+	// test commit
+	setLayout := func(t *testing.T, state *State) {
+		t.Helper()
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetInTotoLayout(targetsMetadata, "protect-files-1-and-2", createTestInTotoLayout(t, nil), []*tuf.Key{layoutKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	t.Run("successful verification", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setLayout(t, state)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := currentAttestations.SetInTotoLink(repo, createTestInTotoLink(t, targets1KeyBytes, "foo"), refName, plumbing.ZeroHash.String(), commit.TreeHash.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add in-toto link", false); err != nil {
+			t.Fatal(err)
+		}
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unsuccessful verification without links", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setLayout(t, state)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrInTotoLayoutVerificationFailed)
+	})
+
+	t.Run("layout does not apply to unprotected files", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setLayout(t, state)
+
+		// The first two commits change files 1 and 2, so record them in a
+		// separate entry from the third commit that only adds file 3
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(refName, commitIDs[1]), gpgKeyBytes)
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[2])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+}
+
+// createTestInTotoLayout returns a layout with a single step that must be
+// performed by the functionary using targets1KeyBytes. The layout is signed
+// using rootKeyBytes.
+func createTestInTotoLayout(t *testing.T, expectedProducts [][]string) []byte {
+	t.Helper()
+
+	return signTestInTotoLayout(t, createTestInTotoLayoutMetadata(t, expectedProducts))
+}
+
+func createTestInTotoLayoutWithInspection(t *testing.T) []byte {
+	t.Helper()
+
+	layout := createTestInTotoLayoutMetadata(t, nil)
+	layout.Inspect = []in_toto.Inspection{{
+		Type:            "inspection",
+		Run:             []string{"true"},
+		SupplyChainItem: in_toto.SupplyChainItem{Name: "inspect"},
+	}}
+
+	return signTestInTotoLayout(t, layout)
+}
+
+func createTestInTotoLayoutMetadata(t *testing.T, expectedProducts [][]string) in_toto.Layout {
+	t.Helper()
+
+	functionaryKey := loadTestInTotoKey(t, targets1KeyBytes)
+	functionaryKey.KeyVal.Private = ""
+
+	return in_toto.Layout{
+		Type:    "layout",
+		Expires: time.Now().AddDate(1, 0, 0).UTC().Format(in_toto.ISO8601DateSchema),
+		Keys:    map[string]in_toto.Key{functionaryKey.KeyID: functionaryKey},
+		Steps: []in_toto.Step{{
+			Type:      "step",
+			PubKeys:   []string{functionaryKey.KeyID},
+			Threshold: 1,
+			SupplyChainItem: in_toto.SupplyChainItem{
+				Name:             testInTotoStepName,
+				ExpectedProducts: expectedProducts,
+			},
+		}},
+		Inspect: []in_toto.Inspection{},
+	}
+}
+
+func signTestInTotoLayout(t *testing.T, layout in_toto.Layout) []byte {
+	t.Helper()
+
+	metablock := &in_toto.Metablock{Signed: layout}
+	if err := metablock.Sign(loadTestInTotoKey(t, rootKeyBytes)); err != nil {
+		t.Fatal(err)
+	}
+
+	layoutBytes, err := json.Marshal(metablock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return layoutBytes
+}
+
+// createTestInTotoLink returns link metadata for the layout's step recording
+// the specified product, signed using the specified key.
+func createTestInTotoLink(t *testing.T, keyBytes []byte, productName string) []byte {
+	t.Helper()
+
+	metablock := &in_toto.Metablock{Signed: in_toto.Link{
+		Type:        "link",
+		Name:        testInTotoStepName,
+		Materials:   map[string]any{},
+		Products:    map[string]any{productName: map[string]any{"sha256": testInTotoArtifactDigest}},
+		ByProducts:  map[string]any{},
+		Command:     []string{},
+		Environment: map[string]any{},
+	}}
+	if err := metablock.Sign(loadTestInTotoKey(t, keyBytes)); err != nil {
+		t.Fatal(err)
+	}
+
+	linkBytes, err := json.Marshal(metablock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return linkBytes
+}
+
+// writeTestInTotoLinks writes the links to a temporary directory using the
+// file names in-toto expects.
+func writeTestInTotoLinks(t *testing.T, links ...[]byte) string {
+	t.Helper()
+
+	linkDir := t.TempDir()
+	for _, link := range links {
+		metablock := &struct {
+			Signatures []in_toto.Signature `json:"signatures"`
+		}{}
+		if err := json.Unmarshal(link, metablock); err != nil {
+			t.Fatal(err)
+		}
+
+		linkName := testInTotoStepName + "." + metablock.Signatures[0].KeyID[:8] + ".link"
+		if err := os.WriteFile(filepath.Join(linkDir, linkName), link, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return linkDir
+}
+
+// loadTestInTotoKey loads the test key in in-toto's key format. The key ID is
+// the one computed by gittuf, and in-toto expects ed25519 private keys to
+// include the public key.
+func loadTestInTotoKey(t *testing.T, keyBytes []byte) in_toto.Key {
+	t.Helper()
+
+	key := in_toto.Key{}
+	if err := json.Unmarshal(keyBytes, &key); err != nil {
+		t.Fatal(err)
+	}
+
+	tufKey, err := tuf.LoadKeyFromBytes(keyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key.KeyID = tufKey.KeyID
+	key.KeyVal.Private += key.KeyVal.Public
+
+	return key
+}
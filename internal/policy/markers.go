@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	// VerificationMarkersRef defines the Git namespace used to persist the
+	// last RSL entry successfully verified for each ref. Like the verification
+	// cache, it is deliberately outside refs/gittuf/ so that it is local to the
+	// repository and is never synced with remotes.
+	VerificationMarkersRef = "refs/gittuf-local/verification-markers"
+
+	verificationMarkersTreeEntryName = "markers.json"
+	verificationMarkersCommitMessage = "Update verification markers"
+)
+
+var (
+	ErrInvalidVerificationMarkers = errors.New("invalid verification markers tree structure")
+	ErrMarkedEntryNotInRSL        = errors.New("marked entry is not in the RSL")
+)
+
+// VerificationMarker records the last RSL entry for a ref that was
+// successfully verified, along with the latest policy RSL entry at the time of
+// verification.
+type VerificationMarker struct {
+	EntryID       string `json:"entryID"`
+	PolicyEntryID string `json:"policyEntryID"`
+}
+
+// VerificationMarkers records a VerificationMarker for each verified ref. It
+// allows subsequent verifications to resume from the marked entry rather than
+// verifying the entire RSL again.
+type VerificationMarkers struct {
+	Markers map[string]*VerificationMarker `json:"markers"`
+
+	modified bool
+}
+
+// LoadVerificationMarkers loads the verification markers persisted in the
+// repository. If no markers exist yet, an empty set is returned.
+func LoadVerificationMarkers(repo *git.Repository) (*VerificationMarkers, error) {
+	markers := &VerificationMarkers{Markers: map[string]*VerificationMarker{}}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(VerificationMarkersRef), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return markers, nil
+		}
+		return nil, err
+	}
+
+	markersCommit, err := gitinterface.GetCommit(repo, ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	markersTree, err := gitinterface.GetTree(repo, markersCommit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(markersTree.Entries) != 1 || markersTree.Entries[0].Name != verificationMarkersTreeEntryName {
+		return nil, ErrInvalidVerificationMarkers
+	}
+
+	contents, err := gitinterface.ReadBlob(repo, markersTree.Entries[0].Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(contents, markers); err != nil {
+		return nil, err
+	}
+	if markers.Markers == nil {
+		markers.Markers = map[string]*VerificationMarker{}
+	}
+
+	return markers, nil
+}
+
+// GetMarker returns the marker for the specified ref if the ref was verified
+// under the policy recorded in the RSL entry identified by policyEntryID. If
+// the ref has no marker or the policy changed since the marker was recorded,
+// nil is returned.
+func (m *VerificationMarkers) GetMarker(refName string, policyEntryID plumbing.Hash) *VerificationMarker {
+	marker, has := m.Markers[refName]
+	if !has {
+		return nil
+	}
+
+	if marker.PolicyEntryID != policyEntryID.String() {
+		return nil
+	}
+
+	return marker
+}
+
+// SetMarker records that the ref was successfully verified up to the
+// specified entry under the policy recorded in the RSL entry identified by
+// policyEntryID.
+func (m *VerificationMarkers) SetMarker(refName string, entryID, policyEntryID plumbing.Hash) {
+	marker := &VerificationMarker{
+		EntryID:       entryID.String(),
+		PolicyEntryID: policyEntryID.String(),
+	}
+
+	if existing, has := m.Markers[refName]; has && *existing == *marker {
+		return
+	}
+
+	m.Markers[refName] = marker
+	m.modified = true
+}
+
+// RemoveMarker deletes the marker for the specified ref, if one exists.
+func (m *VerificationMarkers) RemoveMarker(refName string) {
+	if _, has := m.Markers[refName]; !has {
+		return
+	}
+
+	delete(m.Markers, refName)
+	m.modified = true
+}
+
+// Commit persists the verification markers in the repository. The commit is
+// never signed as the markers are local to the repository. If the markers have
+// not been modified since they were loaded, no commit is created.
+func (m *VerificationMarkers) Commit(repo *git.Repository) error {
+	if !m.modified {
+		return nil
+	}
+
+	contents, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, contents)
+	if err != nil {
+		return err
+	}
+
+	treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{
+		{
+			Name: verificationMarkersTreeEntryName,
+			Mode: filemode.Regular,
+			Hash: blobID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := gitinterface.Commit(repo, treeID, VerificationMarkersRef, verificationMarkersCommitMessage, false); err != nil {
+		return err
+	}
+
+	m.modified = false
+	return nil
+}
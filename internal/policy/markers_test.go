@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationMarkers(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	entryID := plumbing.NewHash("abcdef1234567890")
+	newEntryID := plumbing.NewHash("0987654321fedcba")
+	policyEntryID := plumbing.NewHash("1234567890abcdef")
+	newPolicyEntryID := plumbing.NewHash("fedcba0987654321")
+
+	markers, err := LoadVerificationMarkers(repo)
+	assert.Nil(t, err)
+	assert.Empty(t, markers.Markers)
+	assert.Nil(t, markers.GetMarker(refName, policyEntryID))
+
+	// Committing unmodified markers is a no-op
+	err = markers.Commit(repo)
+	assert.Nil(t, err)
+	_, err = repo.Reference(plumbing.ReferenceName(VerificationMarkersRef), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	markers.SetMarker(refName, entryID, policyEntryID)
+	assert.Equal(t, entryID.String(), markers.GetMarker(refName, policyEntryID).EntryID)
+	assert.Nil(t, markers.GetMarker(refName, newPolicyEntryID))
+
+	err = markers.Commit(repo)
+	assert.Nil(t, err)
+
+	markers, err = LoadVerificationMarkers(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, entryID.String(), markers.GetMarker(refName, policyEntryID).EntryID)
+
+	markers.SetMarker(refName, newEntryID, newPolicyEntryID)
+	err = markers.Commit(repo)
+	assert.Nil(t, err)
+
+	markers, err = LoadVerificationMarkers(repo)
+	assert.Nil(t, err)
+	assert.Nil(t, markers.GetMarker(refName, policyEntryID))
+	assert.Equal(t, newEntryID.String(), markers.GetMarker(refName, newPolicyEntryID).EntryID)
+
+	markers.RemoveMarker(refName)
+	err = markers.Commit(repo)
+	assert.Nil(t, err)
+
+	markers, err = LoadVerificationMarkers(repo)
+	assert.Nil(t, err)
+	assert.Empty(t, markers.Markers)
+}
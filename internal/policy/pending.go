@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// PendingChange describes a policy metadata file that differs between the
+// policy staging ref and the policy ref, i.e., a change that will take effect
+// when the staged policy is applied.
+type PendingChange struct {
+	// RoleName is the name of the changed metadata file.
+	RoleName string
+
+	// CurrentVersion is the version of the metadata in the policy ref. It is
+	// zero if the metadata is being added.
+	CurrentVersion int
+
+	// StagedVersion is the version of the metadata in the policy staging ref.
+	// It is zero if the metadata is being removed.
+	StagedVersion int
+
+	// Signatures is the number of signatures on the staged metadata.
+	Signatures int
+
+	// Threshold is the number of valid signatures required for the staged
+	// metadata, as declared by its delegating role in the staged policy. It is
+	// zero if the metadata is being removed or its delegating role cannot be
+	// found.
+	Threshold int
+
+	// ThresholdMet indicates if the staged metadata has a threshold of valid
+	// signatures.
+	ThresholdMet bool
+}
+
+// GetPendingChanges returns the policy metadata files that differ between the
+// policy staging ref and the policy ref. For each, the number of signatures on
+// the staged metadata and whether it meets its threshold are also returned.
+// This allows multiple parties to coordinate the signing of a staged policy
+// before it is applied.
+func GetPendingChanges(ctx context.Context, repo *git.Repository) ([]*PendingChange, error) {
+	stagedState, err := LoadCurrentState(ctx, repo, PolicyStagingRef)
+	if err != nil {
+		return nil, err
+	}
+
+	currentState, err := LoadCurrentState(ctx, repo, PolicyRef)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+
+		// No policy has been applied yet, so all staged metadata is pending
+		currentState = nil
+	}
+
+	stagedEnvelopes := stagedState.envelopes()
+	currentEnvelopes := map[string]*sslibdsse.Envelope{}
+	if currentState != nil {
+		currentEnvelopes = currentState.envelopes()
+	}
+
+	roleNames := []string{}
+	for roleName := range stagedEnvelopes {
+		roleNames = append(roleNames, roleName)
+	}
+	for roleName := range currentEnvelopes {
+		if _, has := stagedEnvelopes[roleName]; !has {
+			roleNames = append(roleNames, roleName)
+		}
+	}
+	sort.Strings(roleNames)
+
+	changes := []*PendingChange{}
+	for _, roleName := range roleNames {
+		stagedEnv, isStaged := stagedEnvelopes[roleName]
+		currentEnv, isCurrent := currentEnvelopes[roleName]
+
+		if isStaged && isCurrent {
+			equal, err := envelopesEqual(stagedEnv, currentEnv)
+			if err != nil {
+				return nil, err
+			}
+			if equal {
+				continue
+			}
+		}
+
+		change := &PendingChange{RoleName: roleName}
+
+		if isCurrent {
+			change.CurrentVersion, err = currentState.getMetadataVersion(roleName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isStaged {
+			change.StagedVersion, err = stagedState.getMetadataVersion(roleName)
+			if err != nil {
+				return nil, err
+			}
+			change.Signatures = len(stagedEnv.Signatures)
+
+			verifier, err := stagedState.getVerifierForRole(roleName)
+			if err != nil {
+				return nil, err
+			}
+			if verifier != nil {
+				change.Threshold = verifier.Threshold()
+				change.ThresholdMet = verifier.Verify(ctx, nil, stagedEnv) == nil
+			}
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// envelopes returns all the metadata envelopes in the state keyed by the name
+// of the corresponding role.
+func (s *State) envelopes() map[string]*sslibdsse.Envelope {
+	envelopes := map[string]*sslibdsse.Envelope{RootRoleName: s.RootEnvelope}
+	if s.TargetsEnvelope != nil {
+		envelopes[TargetsRoleName] = s.TargetsEnvelope
+	}
+	for roleName, env := range s.DelegationEnvelopes {
+		envelopes[roleName] = env
+	}
+
+	return envelopes
+}
+
+// getMetadataVersion returns the version of the specified role's metadata.
+func (s *State) getMetadataVersion(roleName string) (int, error) {
+	if roleName == RootRoleName {
+		rootMetadata, err := s.GetRootMetadata()
+		if err != nil {
+			return 0, err
+		}
+		return rootMetadata.Version, nil
+	}
+
+	targetsMetadata, err := s.GetTargetsMetadata(roleName)
+	if err != nil {
+		return 0, err
+	}
+	return targetsMetadata.Version, nil
+}
+
+// getVerifierForRole returns the verifier for the specified role's metadata
+// as declared by its delegating role in the state. If the delegating role
+// cannot be found, nil is returned.
+func (s *State) getVerifierForRole(roleName string) (*Verifier, error) {
+	switch roleName {
+	case RootRoleName:
+		return s.getRootVerifier()
+	case TargetsRoleName:
+		return s.getTargetsVerifier()
+	}
+
+	delegatingRoleNames := []string{TargetsRoleName}
+	for delegatedRoleName := range s.DelegationEnvelopes {
+		delegatingRoleNames = append(delegatingRoleNames, delegatedRoleName)
+	}
+
+	for _, delegatingRoleName := range delegatingRoleNames {
+		if !s.HasTargetsRole(delegatingRoleName) {
+			continue
+		}
+
+		delegatingMetadata, err := s.GetTargetsMetadata(delegatingRoleName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delegation := range delegatingMetadata.Delegations.Roles {
+			if delegation.Name != roleName {
+				continue
+			}
+
+			return newVerifierForDelegation(delegation, delegatingMetadata.Delegations.Keys, delegatingMetadata.Delegations.Principals), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// envelopesEqual checks if two envelopes have the same payload and signatures.
+func envelopesEqual(a, b *sslibdsse.Envelope) (bool, error) {
+	aBytes, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+
+	bBytes, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+
+	return string(aBytes) == string(bBytes), nil
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPendingChanges(t *testing.T) {
+	t.Run("no policy applied", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+		if err := attestations.InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		state := createTestStateWithPolicy(t)
+		if err := state.Commit(repo, "Create test state", false); err != nil {
+			t.Fatal(err)
+		}
+
+		changes, err := GetPendingChanges(testCtx, repo)
+		assert.Nil(t, err)
+		assert.Equal(t, []*PendingChange{
+			{RoleName: RootRoleName, StagedVersion: 1, Signatures: 1, Threshold: 1, ThresholdMet: true},
+			{RoleName: TargetsRoleName, StagedVersion: 1, Signatures: 1, Threshold: 1, ThresholdMet: true},
+		}, changes)
+	})
+
+	t.Run("no pending changes", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		changes, err := GetPendingChanges(testCtx, repo)
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("staged targets change", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "protect-feature", []*tuf.Key{key}, []string{"git:refs/heads/feature"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+		// Stage the change without any signatures
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+		if err := state.Commit(repo, "Add rule", false); err != nil {
+			t.Fatal(err)
+		}
+
+		changes, err := GetPendingChanges(testCtx, repo)
+		assert.Nil(t, err)
+		assert.Equal(t, []*PendingChange{
+			{RoleName: TargetsRoleName, CurrentVersion: 1, StagedVersion: 2, Signatures: 0, Threshold: 1, ThresholdMet: false},
+		}, changes)
+
+		// Sign the staged change
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+		if err := state.Commit(repo, "Sign rule file", false); err != nil {
+			t.Fatal(err)
+		}
+
+		changes, err = GetPendingChanges(testCtx, repo)
+		assert.Nil(t, err)
+		assert.Equal(t, []*PendingChange{
+			{RoleName: TargetsRoleName, CurrentVersion: 1, StagedVersion: 2, Signatures: 1, Threshold: 1, ThresholdMet: true},
+		}, changes)
+
+		// Applying the staged policy leaves no pending changes
+		if err := Apply(testCtx, repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		changes, err = GetPendingChanges(testCtx, repo)
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("staged delegated role", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		state := createTestStateWithDelegatedPolicies(t)
+		if err := state.Commit(repo, "Add delegated policy", false); err != nil {
+			t.Fatal(err)
+		}
+
+		changes, err := GetPendingChanges(testCtx, repo)
+		assert.Nil(t, err)
+		assert.Equal(t, []*PendingChange{
+			{RoleName: "1", StagedVersion: 1, Signatures: 1, Threshold: 1, ThresholdMet: true},
+			{RoleName: TargetsRoleName, CurrentVersion: 1, StagedVersion: 1, Signatures: 1, Threshold: 1, ThresholdMet: true},
+		}, changes)
+	})
+}
@@ -36,6 +36,9 @@ const (
 	// TargetsRoleName defines the expected name for the top level gittuf policy file.
 	TargetsRoleName = "targets"
 
+	// GitHubAppRoleName defines the expected name for the role in the root of trust trusted to attest to GitHub pull request approvals.
+	GitHubAppRoleName = "github-app"
+
 	// DefaultCommitMessage defines the fallback message to use when updating the policy ref if an action specific message is unavailable.
 	DefaultCommitMessage = "Update policy state"
 
@@ -57,6 +60,7 @@ var (
 	ErrDuplicatedRuleName         = errors.New("two rules with same name found in policy")
 	ErrUnableToMatchRootKeys      = errors.New("unable to match root public keys, gittuf policy is in a broken state")
 	ErrNotAncestor                = errors.New("cannot apply changes since policy is not an ancestor of the policy staging")
+	ErrNoMatchingKey              = errors.New("no key in policy matches signature")
 )
 
 // InitializeNamespace creates a git ref for the policy. Initially, the entry
@@ -92,8 +96,8 @@ type State struct {
 }
 
 type DelegationWithDepth struct {
-	Delegation tuf.Delegation
-	Depth      int
+	Delegation tuf.Delegation `json:"delegation"`
+	Depth      int            `json:"depth"`
 }
 
 // LoadState returns the State of the repository's policy corresponding to the
@@ -213,6 +217,47 @@ func (s *State) PublicKeys() (map[string]*tuf.Key, error) {
 	return allKeys, nil
 }
 
+// ResolveKeyForSignature identifies the key in the policy state that was used
+// to sign the specified commit or tag. The signature is checked against each
+// public key in the state, in order of key ID, and the first key that verifies
+// it is returned. If no key matches, ErrNoMatchingKey is returned. Note that
+// this only resolves the signer; whether the signer is authorized for a
+// particular ref or file must still be determined using the state's verifiers.
+func (s *State) ResolveKeyForSignature(ctx context.Context, gitObject object.Object) (*tuf.Key, error) {
+	keys, err := s.PublicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	keyIDs := make([]string, 0, len(keys))
+	for keyID := range keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+
+	for _, keyID := range keyIDs {
+		key := keys[keyID]
+
+		switch o := gitObject.(type) {
+		case *object.Commit:
+			err = gitinterface.VerifyCommitSignature(ctx, o, key)
+		case *object.Tag:
+			err = gitinterface.VerifyTagSignature(ctx, o, key)
+		default:
+			return nil, ErrUnknownObjectType
+		}
+
+		if err == nil {
+			return key, nil
+		}
+		if !errors.Is(err, gitinterface.ErrUnknownSigningMethod) && !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
+			return nil, err
+		}
+	}
+
+	return nil, ErrNoMatchingKey
+}
+
 // FindPublicKeysForPath identifies the trusted keys for the path. If the path
 // protected in gittuf policy, the trusted keys are returned.
 //
@@ -302,9 +347,10 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 	}
 
 	allPublicKeys := targetsMetadata.Delegations.Keys
-	// each entry is a list of delegations from a particular metadata file
-	groupedDelegations := [][]tuf.Delegation{
-		targetsMetadata.Delegations.Roles,
+	// each entry is a list of delegations from a particular metadata file,
+	// along with the principals declared in that file
+	groupedDelegations := []*tuf.Delegations{
+		targetsMetadata.Delegations,
 	}
 
 	seenRoles := map[string]bool{TargetsRoleName: true}
@@ -317,7 +363,11 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 			return verifiers, nil
 		}
 
-		currentDelegationGroup = groupedDelegations[0]
+		// Principals are only resolved in the metadata file that declares
+		// the delegation, so delegated files can't redefine principals
+		// trusted by their parents
+		currentPrincipals := groupedDelegations[0].Principals
+		currentDelegationGroup = groupedDelegations[0].Roles
 		groupedDelegations = groupedDelegations[1:]
 
 		for {
@@ -330,22 +380,9 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 			currentDelegationGroup = currentDelegationGroup[1:]
 
 			if delegation.Matches(path) {
-				verifier := &Verifier{
-					name:      delegation.Name,
-					keys:      make([]*tuf.Key, 0, len(delegation.KeyIDs)),
-					threshold: delegation.Threshold,
-				}
-				for _, keyID := range delegation.KeyIDs {
-					key := allPublicKeys[keyID]
-					verifier.keys = append(verifier.keys, key)
-				}
-				verifiers = append(verifiers, verifier)
-
-				if _, seen := seenRoles[delegation.Name]; seen {
-					continue
-				}
+				verifiers = append(verifiers, newVerifierForDelegation(delegation, allPublicKeys, currentPrincipals))
 
-				if s.HasTargetsRole(delegation.Name) {
+				if _, seen := seenRoles[delegation.Name]; !seen && s.HasTargetsRole(delegation.Name) {
 					delegatedMetadata, err := s.GetTargetsMetadata(delegation.Name)
 					if err != nil {
 						return nil, err
@@ -359,19 +396,80 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 
 					// Add the current metadata's further delegations upfront to
 					// be depth-first
-					groupedDelegations = append([][]tuf.Delegation{delegatedMetadata.Delegations.Roles}, groupedDelegations...)
+					groupedDelegations = append([]*tuf.Delegations{delegatedMetadata.Delegations}, groupedDelegations...)
+				}
 
-					if delegation.Terminating {
-						// Stop processing current delegation group, but proceed
-						// with other groups
-						break
-					}
+				if delegation.Terminating {
+					// Stop processing current delegation group, but proceed
+					// with other groups. This applies to rules without
+					// delegated metadata as well.
+					break
 				}
 			}
 		}
 	}
 }
 
+// GetAuthorizedKeysForRef returns the set of keys that can authorize changes to
+// the specified ref along with the threshold of signatures required. The
+// delegation graph is resolved transitively to identify all rules that protect
+// the ref. As any one of these rules can authorize a change, the returned keys
+// are the union of the keys trusted by the rules and the returned threshold is
+// the lowest threshold among the rules. If the ref is not protected by any
+// rule, no keys are returned and the threshold is zero.
+func (s *State) GetAuthorizedKeysForRef(refName string) ([]*tuf.Key, int, error) {
+	verifiers, err := s.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, refName))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(verifiers) == 0 {
+		return nil, 0, nil
+	}
+
+	keys := []*tuf.Key{}
+	seenKeys := set.NewSet[string]()
+	threshold := verifiers[0].Threshold()
+	for _, verifier := range verifiers {
+		if verifier.Threshold() < threshold {
+			threshold = verifier.Threshold()
+		}
+
+		for _, key := range verifier.Keys() {
+			if seenKeys.Has(key.KeyID) {
+				continue
+			}
+			seenKeys.Add(key.KeyID)
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].KeyID < keys[j].KeyID
+	})
+
+	return keys, threshold, nil
+}
+
+// FindUnprotectedRefs returns the refs in refNames that are not covered by any
+// rule in the policy state. Entries for such refs are not restricted by policy,
+// and are therefore out of the policy's scope.
+func (s *State) FindUnprotectedRefs(refNames []string) ([]string, error) {
+	unprotectedRefs := []string{}
+	for _, refName := range refNames {
+		verifiers, err := s.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, refName))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(verifiers) == 0 {
+			unprotectedRefs = append(unprotectedRefs, refName)
+		}
+	}
+
+	return unprotectedRefs, nil
+}
+
 // Verify verifies the contents of the State for internal consistency.
 // Specifically, it checks that the root keys in the root role match the ones
 // stored on disk in the state. Further, it also verifies the signatures of the
@@ -420,6 +518,10 @@ func (s *State) Verify(ctx context.Context) error {
 
 	delegationsQueue := targetsMetadata.Delegations.Roles
 	delegationKeys := targetsMetadata.Delegations.Keys
+	delegationPrincipals := map[string]*tuf.Principal{}
+	for principalName, principal := range targetsMetadata.Delegations.Principals {
+		delegationPrincipals[principalName] = principal
+	}
 	for {
 		// The last entry in the queue is always the allow rule, which we don't
 		// process during DFS
@@ -435,17 +537,7 @@ func (s *State) Verify(ctx context.Context) error {
 
 			env := s.DelegationEnvelopes[delegation.Name]
 
-			keys := []*tuf.Key{}
-			for _, keyID := range delegation.KeyIDs {
-				keys = append(keys, delegationKeys[keyID])
-			}
-
-			verifier := &Verifier{
-				name:      delegation.Name,
-				keys:      keys,
-				threshold: delegation.Threshold,
-			}
-
+			verifier := newVerifierForDelegation(delegation, delegationKeys, delegationPrincipals)
 			if err := verifier.Verify(ctx, nil, env); err != nil {
 				return err
 			}
@@ -459,6 +551,9 @@ func (s *State) Verify(ctx context.Context) error {
 			for keyID, key := range delegatedMetadata.Delegations.Keys {
 				delegationKeys[keyID] = key
 			}
+			for principalName, principal := range delegatedMetadata.Delegations.Principals {
+				delegationPrincipals[principalName] = principal
+			}
 		}
 	}
 
@@ -893,6 +988,29 @@ func (s *State) getTargetsVerifier() (*Verifier, error) {
 	return verifier, nil
 }
 
+// getGitHubAppVerifier returns a verifier for the keys trusted to attest to
+// GitHub pull request approvals. If the root of trust does not trust a GitHub
+// app, no verifier is returned.
+func (s *State) getGitHubAppVerifier() (*Verifier, error) {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	appRole, has := rootMetadata.Roles[GitHubAppRoleName]
+	if !has {
+		return nil, nil
+	}
+
+	verifier := &Verifier{name: GitHubAppRoleName, keys: make([]*tuf.Key, 0, len(appRole.KeyIDs))}
+	for _, keyID := range appRole.KeyIDs {
+		verifier.keys = append(verifier.keys, rootMetadata.Keys[keyID])
+	}
+	verifier.threshold = appRole.Threshold
+
+	return verifier, nil
+}
+
 // verifySuccessiveRootsAndLoadLatestPolicyState loads all policy entries before
 // the requested entry and verifies roots successively. The latest policy state
 // is returned. If the requested policy state is prior to the first policy entry
@@ -977,6 +1095,9 @@ func loadStateForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (*State,
 	if entry.RefName != PolicyRef && entry.RefName != PolicyStagingRef {
 		return nil, rsl.ErrRSLEntryDoesNotMatchRef
 	}
+	if entry.Deleted {
+		return nil, fmt.Errorf("%w: entry '%s' records the deletion of '%s'", rsl.ErrCannotDeleteGittufRef, entry.ID.String(), entry.RefName)
+	}
 
 	policyCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
 	if err != nil {
@@ -1020,11 +1141,20 @@ func loadStateForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (*State,
 		return nil, err
 	}
 
+	blobIDs := make([]plumbing.Hash, 0, len(metadataTree.Entries)+len(keysTree.Entries))
 	for _, entry := range metadataTree.Entries {
-		contents, err := gitinterface.ReadBlob(repo, entry.Hash)
-		if err != nil {
-			return nil, err
-		}
+		blobIDs = append(blobIDs, entry.Hash)
+	}
+	for _, entry := range keysTree.Entries {
+		blobIDs = append(blobIDs, entry.Hash)
+	}
+	blobs, err := gitinterface.ReadBlobs(repo, blobIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range metadataTree.Entries {
+		contents := blobs[entry.Hash]
 
 		env := &sslibdsse.Envelope{}
 		if err := json.Unmarshal(contents, env); err != nil {
@@ -1046,10 +1176,7 @@ func loadStateForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (*State,
 	}
 
 	for _, entry := range keysTree.Entries {
-		contents, err := gitinterface.ReadBlob(repo, entry.Hash)
-		if err != nil {
-			return nil, err
-		}
+		contents := blobs[entry.Hash]
 
 		key, err := tuf.LoadKeyFromBytes(contents)
 		if err != nil {
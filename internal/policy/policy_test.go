@@ -5,8 +5,10 @@ package policy
 import (
 	"context"
 	"fmt"
+	"sort"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
@@ -416,6 +418,65 @@ func TestStateFindVerifiersForPath(t *testing.T) {
 		}
 	})
 
+	t.Run("with policy for other namespaces", func(t *testing.T) {
+		state := createTestStateWithNamespacePolicy(t)
+
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targets1Key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		protectMain := &Verifier{name: "protect-main", keys: []*tuf.Key{gpgKey}, threshold: 1}
+		protectNotes := &Verifier{name: "protect-notes", keys: []*tuf.Key{gpgKey}, threshold: 1}
+		protectReplace := &Verifier{name: "protect-replace", keys: []*tuf.Key{gpgKey}, threshold: 1}
+		protectNamespaces := &Verifier{name: "protect-namespaces", keys: []*tuf.Key{targets1Key}, threshold: 1}
+
+		// Verifiers are returned in the order of the rules in the policy
+		tests := map[string]struct {
+			path      string
+			verifiers []*Verifier
+		}{
+			"verifiers for notes ref": {
+				path:      "git:refs/notes/commits",
+				verifiers: []*Verifier{protectNotes, protectNamespaces},
+			},
+			"verifiers for replace ref": {
+				path:      "git:refs/replace/2ba0e2b3f1c6f3e9c1b6c3a4e5d6f7a8b9c0d1e2",
+				verifiers: []*Verifier{protectReplace, protectNamespaces},
+			},
+			"verifiers for custom namespace": {
+				path:      "git:refs/custom/review",
+				verifiers: []*Verifier{protectNamespaces},
+			},
+			"verifiers for branch also matched by namespace rule": {
+				path:      "git:refs/heads/main",
+				verifiers: []*Verifier{protectMain, protectNamespaces},
+			},
+			"verifiers for nested ref in custom namespace": {
+				path:      "git:refs/custom/team/review",
+				verifiers: []*Verifier{},
+			},
+			"verifiers for nested notes ref": {
+				path:      "git:refs/notes/team/reviews",
+				verifiers: []*Verifier{},
+			},
+			"verifiers for file with same name as notes ref": {
+				path:      "file:refs/notes/commits",
+				verifiers: []*Verifier{},
+			},
+		}
+
+		for name, test := range tests {
+			verifiers, err := state.FindVerifiersForPath(test.path)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+			assert.Equal(t, test.verifiers, verifiers, fmt.Sprintf("policy verifiers for path '%s' don't match expected verifiers in test '%s'", test.path, name))
+		}
+	})
+
 	t.Run("without policy", func(t *testing.T) {
 		state := createTestStateWithOnlyRoot(t)
 
@@ -425,6 +486,117 @@ func TestStateFindVerifiersForPath(t *testing.T) {
 	})
 }
 
+func TestStateGetAuthorizedKeysForRef(t *testing.T) {
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	approverKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("with policy", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+
+		keys, threshold, err := state.GetAuthorizedKeysForRef("refs/heads/main")
+		assert.Nil(t, err)
+		assert.Equal(t, []*tuf.Key{gpgKey}, keys)
+		assert.Equal(t, 1, threshold)
+
+		keys, threshold, err = state.GetAuthorizedKeysForRef("refs/heads/unprotected")
+		assert.Nil(t, err)
+		assert.Nil(t, keys)
+		assert.Equal(t, 0, threshold)
+	})
+
+	t.Run("with threshold policy", func(t *testing.T) {
+		state := createTestStateWithThresholdPolicy(t)
+
+		expectedKeys := []*tuf.Key{gpgKey, approverKey}
+		sort.Slice(expectedKeys, func(i, j int) bool {
+			return expectedKeys[i].KeyID < expectedKeys[j].KeyID
+		})
+
+		keys, threshold, err := state.GetAuthorizedKeysForRef("refs/heads/main")
+		assert.Nil(t, err)
+		assert.Equal(t, expectedKeys, keys)
+		assert.Equal(t, 2, threshold)
+	})
+
+	t.Run("without policy", func(t *testing.T) {
+		state := createTestStateWithOnlyRoot(t)
+
+		_, _, err := state.GetAuthorizedKeysForRef("refs/heads/main")
+		assert.ErrorIs(t, err, ErrMetadataNotFound)
+	})
+}
+
+func TestStateResolveKeyForSignature(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("commit signed by key in policy", func(t *testing.T) {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := state.ResolveKeyForSignature(testCtx, commit)
+		assert.Nil(t, err)
+		assert.Equal(t, gpgKey.KeyID, key.KeyID)
+	})
+
+	t.Run("commit signed by key not in policy", func(t *testing.T) {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = state.ResolveKeyForSignature(testCtx, commit)
+		assert.ErrorIs(t, err, ErrNoMatchingKey)
+	})
+
+	t.Run("unsigned commit", func(t *testing.T) {
+		commit := gitinterface.CreateCommitObject(common.TestGitConfig, gitinterface.EmptyTree(), nil, "Unsigned commit", common.TestClock)
+
+		_, err := state.ResolveKeyForSignature(testCtx, commit)
+		assert.ErrorIs(t, err, ErrNoMatchingKey)
+	})
+
+	t.Run("tag signed by key in policy", func(t *testing.T) {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		tagID := common.CreateTestSignedTag(t, repo, "v1", commitIDs[0], gpgKeyBytes)
+		tag, err := gitinterface.GetTag(repo, tagID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := state.ResolveKeyForSignature(testCtx, tag)
+		assert.Nil(t, err)
+		assert.Equal(t, gpgKey.KeyID, key.KeyID)
+	})
+}
+
+func TestStateFindUnprotectedRefs(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	unprotectedRefs, err := state.FindUnprotectedRefs([]string{"refs/heads/main", "refs/heads/feature", "refs/tags/v1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"refs/heads/feature", "refs/tags/v1"}, unprotectedRefs)
+
+	unprotectedRefs, err = state.FindUnprotectedRefs([]string{"refs/heads/main"})
+	assert.Nil(t, err)
+	assert.Empty(t, unprotectedRefs)
+}
+
 func TestStateFindPublicKeysForPath(t *testing.T) {
 	state := createTestStateWithPolicy(t)
 
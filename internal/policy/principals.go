@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+var (
+	ErrPrincipalNotFound = errors.New("principal not found in policy")
+	ErrPrincipalInUse    = errors.New("principal is trusted by one or more rules")
+	ErrPrincipalNameUsed = errors.New("principal name is already used as a key ID")
+)
+
+// AddPrincipal defines a named principal in TargetsMetadata that aggregates
+// the specified keys. The principal is considered to have signed when the
+// threshold of its keys have signed. If a principal with the same name exists,
+// it is replaced.
+func AddPrincipal(targetsMetadata *tuf.TargetsMetadata, principalName string, keys []*tuf.Key, threshold int) (*tuf.TargetsMetadata, error) {
+	if _, has := targetsMetadata.Delegations.Keys[principalName]; has {
+		return nil, ErrPrincipalNameUsed
+	}
+
+	keyIDs := []string{}
+	for _, key := range keys {
+		if slices.Contains(keyIDs, key.KeyID) {
+			continue
+		}
+
+		targetsMetadata.Delegations.AddKey(key)
+		keyIDs = append(keyIDs, key.KeyID)
+	}
+
+	if threshold < 1 || len(keyIDs) < threshold {
+		return nil, ErrCannotMeetThreshold
+	}
+
+	targetsMetadata.Delegations.AddPrincipal(&tuf.Principal{
+		Name:      principalName,
+		KeyIDs:    keyIDs,
+		Threshold: threshold,
+	})
+
+	return targetsMetadata, nil
+}
+
+// SetPrincipalForgeAccount records the forge account whose published keys are
+// pinned for the specified principal in TargetsMetadata.
+func SetPrincipalForgeAccount(targetsMetadata *tuf.TargetsMetadata, principalName, forgeAccount string) (*tuf.TargetsMetadata, error) {
+	principal, has := targetsMetadata.Delegations.Principals[principalName]
+	if !has {
+		return nil, ErrPrincipalNotFound
+	}
+
+	principal.ForgeAccount = forgeAccount
+	return targetsMetadata, nil
+}
+
+// RemovePrincipal deletes a principal from TargetsMetadata. A principal that
+// is trusted by a rule cannot be removed.
+func RemovePrincipal(targetsMetadata *tuf.TargetsMetadata, principalName string) (*tuf.TargetsMetadata, error) {
+	if _, has := targetsMetadata.Delegations.Principals[principalName]; !has {
+		return nil, ErrPrincipalNotFound
+	}
+
+	for _, delegation := range targetsMetadata.Delegations.Roles {
+		if slices.Contains(delegation.Principals, principalName) {
+			return nil, ErrPrincipalInUse
+		}
+	}
+
+	delete(targetsMetadata.Delegations.Principals, principalName)
+	if len(targetsMetadata.Delegations.Principals) == 0 {
+		targetsMetadata.Delegations.Principals = nil
+	}
+
+	return targetsMetadata, nil
+}
+
+// SetDelegationPrincipals sets the principals trusted by the specified
+// delegation in TargetsMetadata. The principals must be defined in the same
+// TargetsMetadata.
+func SetDelegationPrincipals(targetsMetadata *tuf.TargetsMetadata, ruleName string, principalNames []string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for _, principalName := range principalNames {
+		if _, has := targetsMetadata.Delegations.Principals[principalName]; !has {
+			return nil, ErrPrincipalNotFound
+		}
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			if len(principalNames) == 0 {
+				targetsMetadata.Delegations.Roles[i].Principals = nil
+			} else {
+				targetsMetadata.Delegations.Roles[i].Principals = principalNames
+			}
+			return targetsMetadata, nil
+		}
+	}
+
+	return nil, ErrDelegationNotFound
+}
@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddPrincipal(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddPrincipal(targetsMetadata, "release-team", []*tuf.Key{key1, key2}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, &tuf.Principal{Name: "release-team", KeyIDs: []string{key1.KeyID, key2.KeyID}, Threshold: 2}, targetsMetadata.Delegations.Principals["release-team"])
+	assert.Contains(t, targetsMetadata.Delegations.Keys, key1.KeyID)
+	assert.Contains(t, targetsMetadata.Delegations.Keys, key2.KeyID)
+
+	// Existing principal is replaced
+	targetsMetadata, err = AddPrincipal(targetsMetadata, "release-team", []*tuf.Key{key1}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key1.KeyID}, targetsMetadata.Delegations.Principals["release-team"].KeyIDs)
+
+	_, err = AddPrincipal(targetsMetadata, "alice", []*tuf.Key{key1, key1}, 2)
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+
+	_, err = AddPrincipal(targetsMetadata, key1.KeyID, []*tuf.Key{key2}, 1)
+	assert.ErrorIs(t, err, ErrPrincipalNameUsed)
+}
+
+func TestSetPrincipalForgeAccount(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddPrincipal(targetsMetadata, "alice", []*tuf.Key{key}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetPrincipalForgeAccount(targetsMetadata, "alice", "github:alice")
+	assert.Nil(t, err)
+	assert.Equal(t, "github:alice", targetsMetadata.Delegations.Principals["alice"].ForgeAccount)
+
+	_, err = SetPrincipalForgeAccount(targetsMetadata, "bob", "github:bob")
+	assert.ErrorIs(t, err, ErrPrincipalNotFound)
+}
+
+func TestRemovePrincipal(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddPrincipal(targetsMetadata, "alice", []*tuf.Key{key}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-main", []*tuf.Key{}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = SetDelegationPrincipals(targetsMetadata, "protect-main", []string{"alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = RemovePrincipal(targetsMetadata, "alice")
+	assert.ErrorIs(t, err, ErrPrincipalInUse)
+
+	targetsMetadata, err = SetDelegationPrincipals(targetsMetadata, "protect-main", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = RemovePrincipal(targetsMetadata, "alice")
+	assert.Nil(t, err)
+	assert.Nil(t, targetsMetadata.Delegations.Principals)
+
+	_, err = RemovePrincipal(targetsMetadata, "alice")
+	assert.ErrorIs(t, err, ErrPrincipalNotFound)
+}
+
+func TestSetDelegationPrincipals(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddPrincipal(targetsMetadata, "alice", []*tuf.Key{key}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-main", []*tuf.Key{}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetDelegationPrincipals(targetsMetadata, "protect-main", []string{"alice"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice"}, targetsMetadata.Delegations.Roles[0].Principals)
+
+	_, err = SetDelegationPrincipals(targetsMetadata, "protect-main", []string{"bob"})
+	assert.ErrorIs(t, err, ErrPrincipalNotFound)
+
+	_, err = SetDelegationPrincipals(targetsMetadata, "protect-release", []string{"alice"})
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetDelegationPrincipals(targetsMetadata, AllowRuleName, []string{"alice"})
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestVerifierWithPrincipals(t *testing.T) {
+	rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata := InitializeTargetsMetadata()
+	targetsMetadata, err = AddPrincipal(targetsMetadata, "release-team", []*tuf.Key{key1, key2}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-main", []*tuf.Key{rootKey}, []string{"git:refs/heads/main"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = SetDelegationPrincipals(targetsMetadata, "protect-main", []string{"release-team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := newVerifierForDelegation(targetsMetadata.Delegations.Roles[0], targetsMetadata.Delegations.Keys, targetsMetadata.Delegations.Principals)
+	assert.Equal(t, 3, len(verifier.Keys()))
+
+	tests := map[string]struct {
+		keys          [][]byte
+		expectedError error
+	}{
+		"principal and key signed": {
+			keys: [][]byte{rootKeyBytes, targets1KeyBytes, targets2KeyBytes},
+		},
+		"principal threshold unmet": {
+			// The principal counts only when both its keys have signed
+			keys:          [][]byte{rootKeyBytes, targets1KeyBytes},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"only principal signed": {
+			keys:          [][]byte{targets1KeyBytes, targets2KeyBytes},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			env, err := dsse.CreateEnvelope(targetsMetadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, keyBytes := range test.keys {
+				signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+				if err != nil {
+					t.Fatal(err)
+				}
+				env, err = dsse.SignEnvelope(context.Background(), env, signer)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = verifier.Verify(context.Background(), nil, env)
+			if test.expectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestVerifierWithOverlappingPrincipals(t *testing.T) {
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// key1 belongs to both principals
+	targetsMetadata := InitializeTargetsMetadata()
+	targetsMetadata, err = AddPrincipal(targetsMetadata, "alice", []*tuf.Key{key1}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddPrincipal(targetsMetadata, "release-team", []*tuf.Key{key1, key2}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-main", []*tuf.Key{}, []string{"git:refs/heads/main"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = SetDelegationPrincipals(targetsMetadata, "protect-main", []string{"alice", "release-team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := newVerifierForDelegation(targetsMetadata.Delegations.Roles[0], targetsMetadata.Delegations.Keys, targetsMetadata.Delegations.Principals)
+
+	tests := map[string]struct {
+		keys          [][]byte
+		expectedError error
+	}{
+		"shared key counts once": {
+			keys:          [][]byte{targets1KeyBytes},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"distinct keys for each principal": {
+			keys: [][]byte{targets1KeyBytes, targets2KeyBytes},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			env, err := dsse.CreateEnvelope(targetsMetadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, keyBytes := range test.keys {
+				signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+				if err != nil {
+					t.Fatal(err)
+				}
+				env, err = dsse.SignEnvelope(context.Background(), env, signer)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = verifier.Verify(context.Background(), nil, env)
+			if test.expectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+		})
+	}
+}
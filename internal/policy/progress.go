@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// VerificationProgress describes how far a ref's verification has progressed.
+type VerificationProgress struct {
+	// Target is the ref being verified.
+	Target string
+
+	// EntryID is the ID of the RSL entry that has been reached.
+	EntryID string
+
+	// EntriesProcessed is the number of RSL entries reached so far, including
+	// the current entry. TotalEntries is the number of entries in the range
+	// being verified.
+	EntriesProcessed int
+	TotalEntries     int
+
+	// SignaturesChecked is the number of signatures on Git objects and
+	// attestations checked so far.
+	SignaturesChecked int
+}
+
+// ProgressFunc is invoked with the verification's progress as each RSL entry
+// is reached. It is called synchronously, so it must return promptly.
+type ProgressFunc func(VerificationProgress)
+
+type progressTrackerKey struct{}
+
+// progressTracker accumulates a verification's progress and reports it using
+// the callback. The nil tracker is valid and ignores all updates, so callers
+// need not check whether progress is being tracked.
+type progressTracker struct {
+	callback ProgressFunc
+	progress VerificationProgress
+}
+
+// WithVerificationProgress returns a copy of ctx that causes verifications
+// performed using it to report their progress using callback.
+func WithVerificationProgress(ctx context.Context, callback ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressTrackerKey{}, &progressTracker{callback: callback})
+}
+
+// getProgressTracker returns the progress tracker in ctx, if there is one.
+func getProgressTracker(ctx context.Context) *progressTracker {
+	tracker, _ := ctx.Value(progressTrackerKey{}).(*progressTracker)
+	return tracker
+}
+
+// start records that the specified number of entries are to be verified for
+// target.
+func (p *progressTracker) start(target string, totalEntries int) {
+	if p == nil {
+		return
+	}
+
+	p.progress.Target = target
+	p.progress.TotalEntries = totalEntries
+	p.progress.EntriesProcessed = 0
+}
+
+// entryReached records that verification has reached the specified entry and
+// reports the updated progress.
+func (p *progressTracker) entryReached(entryID plumbing.Hash) {
+	if p == nil {
+		return
+	}
+
+	p.progress.EntryID = entryID.String()
+	p.progress.EntriesProcessed++
+	p.callback(p.progress)
+}
+
+// signaturesChecked records that the specified number of signatures were
+// checked.
+func (p *progressTracker) signaturesChecked(count int) {
+	if p == nil {
+		return
+	}
+
+	p.progress.SignaturesChecked += count
+}
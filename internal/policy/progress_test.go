@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationProgress(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+	entryIDs := []string{}
+	for i := 0; i < 3; i++ {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryIDs = append(entryIDs, common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes).String())
+	}
+
+	t.Run("full verification", func(t *testing.T) {
+		updates := []VerificationProgress{}
+		ctx := WithVerificationProgress(context.Background(), func(progress VerificationProgress) {
+			updates = append(updates, progress)
+		})
+
+		_, err := VerifyRefFull(ctx, repo, refName)
+		assert.Nil(t, err)
+
+		// The range also includes the initial policy entry
+		assert.Len(t, updates, 4)
+		for i, update := range updates {
+			assert.Equal(t, refName, update.Target)
+			assert.Equal(t, i+1, update.EntriesProcessed)
+			assert.Equal(t, 4, update.TotalEntries)
+		}
+		for i, entryID := range entryIDs {
+			assert.Equal(t, entryID, updates[i+1].EntryID)
+		}
+
+		// Signatures are checked for each entry for the ref
+		assert.Greater(t, updates[2].SignaturesChecked, updates[1].SignaturesChecked)
+		assert.Greater(t, updates[3].SignaturesChecked, updates[2].SignaturesChecked)
+	})
+
+	t.Run("latest entry only", func(t *testing.T) {
+		updates := []VerificationProgress{}
+		ctx := WithVerificationProgress(context.Background(), func(progress VerificationProgress) {
+			updates = append(updates, progress)
+		})
+
+		_, err := VerifyRef(ctx, repo, refName)
+		assert.Nil(t, err)
+		assert.Len(t, updates, 1)
+		assert.Equal(t, entryIDs[2], updates[0].EntryID)
+		assert.Equal(t, 1, updates[0].TotalEntries)
+	})
+
+	t.Run("canceled verification", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := VerifyRefFull(ctx, repo, refName)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const (
+	// ProposalsRef defines the Git namespace used to collect signatures on
+	// proposed policy metadata before it is staged.
+	ProposalsRef = "refs/gittuf/policy-proposals"
+
+	defaultProposalsCommitMessage = "Update policy proposals"
+)
+
+var (
+	ErrProposalNotFound        = errors.New("policy proposal not found")
+	ErrInvalidProposal         = errors.New("invalid policy proposal")
+	ErrProposalThresholdNotMet = errors.New("policy proposal is not signed by a threshold of authorized keys")
+	ErrProposalOutdated        = errors.New("policy proposal is older than the staged policy metadata")
+)
+
+// Proposal is a proposed version of a policy metadata file. The proposal's
+// envelope may be unsigned or partially signed when it is created. Signatures
+// are added to it over time, and once it is signed by a threshold of the keys
+// trusted for the metadata, it can be accepted into the staged policy.
+type Proposal struct {
+	// ID identifies the proposal. It is the Git blob ID of the proposed
+	// metadata, so proposing the same metadata twice results in the same ID.
+	ID string `json:"-"`
+
+	// RoleName is the name of the metadata file the proposal replaces.
+	RoleName string `json:"roleName"`
+
+	// Envelope contains the proposed metadata and the signatures collected so
+	// far.
+	Envelope *sslibdsse.Envelope `json:"envelope"`
+}
+
+// NewProposal returns a proposal to replace the specified role's metadata with
+// the metadata in the envelope. Any signatures in the envelope are retained.
+func NewProposal(roleName string, env *sslibdsse.Envelope) (*Proposal, error) {
+	id, err := getProposalID(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Proposal{ID: id, RoleName: roleName, Envelope: env}, nil
+}
+
+// Proposals contains the open policy proposals in the repository.
+type Proposals struct {
+	proposals map[string]*Proposal
+}
+
+// LoadCurrentProposals loads the open policy proposals recorded by the latest
+// entry for ProposalsRef in the RSL. If there is no such entry, an empty set
+// of proposals is returned.
+func LoadCurrentProposals(repo *git.Repository) (*Proposals, error) {
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo, ProposalsRef)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+
+		return &Proposals{proposals: map[string]*Proposal{}}, nil
+	}
+
+	proposalsCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	proposalsTree, err := gitinterface.GetTree(repo, proposalsCommit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := map[string]*Proposal{}
+	for _, treeEntry := range proposalsTree.Entries {
+		contents, err := gitinterface.ReadBlob(repo, treeEntry.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		proposal := &Proposal{}
+		if err := json.Unmarshal(contents, proposal); err != nil {
+			return nil, errors.Join(ErrInvalidProposal, err)
+		}
+		if proposal.Envelope == nil {
+			return nil, ErrInvalidProposal
+		}
+
+		proposal.ID, err = getProposalID(proposal.Envelope)
+		if err != nil {
+			return nil, err
+		}
+		if proposal.ID != treeEntry.Name {
+			return nil, fmt.Errorf("%w: proposal '%s' does not match its metadata", ErrInvalidProposal, treeEntry.Name)
+		}
+
+		proposals[proposal.ID] = proposal
+	}
+
+	return &Proposals{proposals: proposals}, nil
+}
+
+// Get returns the proposal with the specified ID.
+func (p *Proposals) Get(id string) (*Proposal, error) {
+	proposal, has := p.proposals[id]
+	if !has {
+		return nil, ErrProposalNotFound
+	}
+
+	return proposal, nil
+}
+
+// List returns all the open proposals ordered by ID.
+func (p *Proposals) List() []*Proposal {
+	proposals := make([]*Proposal, 0, len(p.proposals))
+	for _, proposal := range p.proposals {
+		proposals = append(proposals, proposal)
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ID < proposals[j].ID
+	})
+
+	return proposals
+}
+
+// Set adds the proposal, replacing any existing proposal with the same ID.
+func (p *Proposals) Set(proposal *Proposal) {
+	p.proposals[proposal.ID] = proposal
+}
+
+// Remove removes the proposal with the specified ID.
+func (p *Proposals) Remove(id string) error {
+	if _, has := p.proposals[id]; !has {
+		return ErrProposalNotFound
+	}
+
+	delete(p.proposals, id)
+	return nil
+}
+
+// Commit writes the proposals to ProposalsRef and records the new state in the
+// RSL.
+func (p *Proposals) Commit(repo *git.Repository, commitMessage string, signCommit bool) error {
+	if len(commitMessage) == 0 {
+		commitMessage = defaultProposalsCommitMessage
+	}
+
+	treeEntries := make([]object.TreeEntry, 0, len(p.proposals))
+	for id, proposal := range p.proposals {
+		contents, err := json.Marshal(proposal)
+		if err != nil {
+			return err
+		}
+
+		blobID, err := gitinterface.WriteBlob(repo, contents)
+		if err != nil {
+			return err
+		}
+
+		treeEntries = append(treeEntries, object.TreeEntry{
+			Name: id,
+			Mode: filemode.Regular,
+			Hash: blobID,
+		})
+	}
+
+	treeID, err := gitinterface.WriteTree(repo, treeEntries)
+	if err != nil {
+		return err
+	}
+
+	originalCommitID := plumbing.ZeroHash
+	ref, err := repo.Reference(plumbing.ReferenceName(ProposalsRef), true)
+	if err != nil {
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return err
+		}
+
+		// The namespace is created with the first proposal
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(ProposalsRef), plumbing.ZeroHash)); err != nil {
+			return err
+		}
+	} else {
+		originalCommitID = ref.Hash()
+	}
+
+	commitID, err := gitinterface.Commit(repo, treeID, ProposalsRef, commitMessage, signCommit)
+	if err != nil {
+		return err
+	}
+
+	// We must reset to original proposals commit if err != nil from here onwards.
+
+	if err := rsl.NewReferenceEntry(ProposalsRef, commitID).Commit(repo, signCommit); err != nil {
+		return gitinterface.ResetDueToError(err, repo, ProposalsRef, originalCommitID)
+	}
+
+	return nil
+}
+
+// AcceptProposal replaces the proposal's metadata in the state with the
+// proposed metadata. The proposal must be signed by a threshold of the keys
+// trusted for the metadata in the state, and it must not be older than the
+// metadata in the state. A proposal for the root of trust must also be signed
+// by a threshold of the keys it trusts for the root of trust, and the state's
+// root public keys are updated to match.
+func (s *State) AcceptProposal(ctx context.Context, proposal *Proposal) error {
+	verifier, err := s.getVerifierForRole(proposal.RoleName)
+	if err != nil {
+		return err
+	}
+	if verifier == nil {
+		return ErrDelegationNotFound
+	}
+
+	proposedVersion, err := getProposedVersion(proposal.Envelope)
+	if err != nil {
+		return err
+	}
+	if _, has := s.envelopes()[proposal.RoleName]; has {
+		currentVersion, err := s.getMetadataVersion(proposal.RoleName)
+		if err != nil {
+			return err
+		}
+		if proposedVersion < currentVersion {
+			return ErrProposalOutdated
+		}
+	}
+
+	if err := verifier.Verify(ctx, nil, proposal.Envelope); err != nil {
+		return errors.Join(ErrProposalThresholdNotMet, err)
+	}
+
+	switch proposal.RoleName {
+	case RootRoleName:
+		proposedState := &State{RootEnvelope: proposal.Envelope}
+		rootKeys, err := proposedState.GetRootKeys()
+		if err != nil {
+			return err
+		}
+		proposedState.RootPublicKeys = rootKeys
+
+		rootVerifier, err := proposedState.getRootVerifier()
+		if err != nil {
+			return err
+		}
+		if err := rootVerifier.Verify(ctx, nil, proposal.Envelope); err != nil {
+			return errors.Join(ErrProposalThresholdNotMet, err)
+		}
+
+		s.RootEnvelope = proposal.Envelope
+		s.RootPublicKeys = rootKeys
+	case TargetsRoleName:
+		s.TargetsEnvelope = proposal.Envelope
+	default:
+		if s.DelegationEnvelopes == nil {
+			s.DelegationEnvelopes = map[string]*sslibdsse.Envelope{}
+		}
+		s.DelegationEnvelopes[proposal.RoleName] = proposal.Envelope
+	}
+
+	// The proposed metadata may change the state's rules
+	s.verifiersCache = nil
+	return s.loadRuleNames()
+}
+
+// getProposalID returns the Git blob ID of the envelope's payload.
+func getProposalID(env *sslibdsse.Envelope) (string, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return "", errors.Join(ErrInvalidProposal, err)
+	}
+
+	return plumbing.ComputeHash(plumbing.BlobObject, payload).String(), nil
+}
+
+// getProposedVersion returns the version of the metadata in the envelope.
+func getProposedVersion(env *sslibdsse.Envelope) (int, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return 0, errors.Join(ErrInvalidProposal, err)
+	}
+
+	metadata := struct {
+		Version int `json:"version"`
+	}{}
+	if err := json.Unmarshal(payload, &metadata); err != nil {
+		return 0, errors.Join(ErrInvalidProposal, err)
+	}
+
+	return metadata.Version, nil
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProposals(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+	proposals, err := LoadCurrentProposals(repo)
+	assert.Nil(t, err)
+	assert.Empty(t, proposals.List())
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposal, err := NewProposal(TargetsRoleName, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, proposal.ID)
+
+	// The same metadata results in the same proposal ID
+	sameProposal, err := NewProposal(TargetsRoleName, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, proposal.ID, sameProposal.ID)
+
+	proposals.Set(proposal)
+	if err := proposals.Commit(repo, "Add proposal", false); err != nil {
+		t.Fatal(err)
+	}
+
+	proposals, err = LoadCurrentProposals(repo)
+	assert.Nil(t, err)
+	loadedProposal, err := proposals.Get(proposal.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, proposal, loadedProposal)
+	assert.Equal(t, []*Proposal{proposal}, proposals.List())
+
+	_, err = proposals.Get("unknown")
+	assert.ErrorIs(t, err, ErrProposalNotFound)
+
+	err = proposals.Remove(proposal.ID)
+	assert.Nil(t, err)
+	err = proposals.Remove(proposal.ID)
+	assert.ErrorIs(t, err, ErrProposalNotFound)
+	if err := proposals.Commit(repo, "Remove proposal", false); err != nil {
+		t.Fatal(err)
+	}
+
+	proposals, err = LoadCurrentProposals(repo)
+	assert.Nil(t, err)
+	assert.Empty(t, proposals.List())
+}
+
+func TestStateAcceptProposal(t *testing.T) {
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createProposal := func(t *testing.T, state *State, versionIncrement int, sign bool) *Proposal {
+		t.Helper()
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = RemoveDelegation(targetsMetadata, "protect-main")
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata.SetVersion(targetsMetadata.Version + versionIncrement)
+
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sign {
+			env, err = dsse.SignEnvelope(testCtx, env, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		proposal, err := NewProposal(TargetsRoleName, env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return proposal
+	}
+
+	t.Run("threshold met", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		proposal := createProposal(t, state, 1, true)
+
+		err := state.AcceptProposal(testCtx, proposal)
+		assert.Nil(t, err)
+		assert.Equal(t, proposal.Envelope, state.TargetsEnvelope)
+
+		verifiers, err := state.FindVerifiersForPath("git:refs/heads/main")
+		assert.Nil(t, err)
+		assert.Empty(t, verifiers)
+	})
+
+	t.Run("threshold not met", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		originalEnv := state.TargetsEnvelope
+		proposal := createProposal(t, state, 1, false)
+
+		err := state.AcceptProposal(testCtx, proposal)
+		assert.ErrorIs(t, err, ErrProposalThresholdNotMet)
+		assert.Equal(t, originalEnv, state.TargetsEnvelope)
+	})
+
+	t.Run("proposal older than state", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		proposal := createProposal(t, state, -1, true)
+
+		err := state.AcceptProposal(testCtx, proposal)
+		assert.ErrorIs(t, err, ErrProposalOutdated)
+	})
+
+	t.Run("unknown policy file", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		proposal := createProposal(t, state, 1, true)
+		proposal.RoleName = "unknown"
+
+		err := state.AcceptProposal(testCtx, proposal)
+		assert.ErrorIs(t, err, ErrDelegationNotFound)
+	})
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+const (
+	// RegoEngineName identifies policies written in Rego and evaluated using
+	// Open Policy Agent.
+	RegoEngineName = "rego"
+
+	// RegoPackage is the package that Rego policies must declare. A policy
+	// allows a change if it sets `allow` to true.
+	RegoPackage = "gittuf"
+
+	regoModuleName = "gittuf.rego"
+	regoQuery      = "data.gittuf.allow"
+)
+
+var ErrRegoPackageMismatch = errors.New("rego policy must declare package " + RegoPackage)
+
+// regoCapabilitiesVersion is the OPA release whose built-in functions are
+// available to Rego policies. The capabilities are pinned so that updating OPA
+// doesn't make new built-in functions available to policies.
+const regoCapabilitiesVersion = "v0.63.0"
+
+// regoForbiddenBuiltins are built-in functions that depend on the current time
+// but aren't marked as nondeterministic by OPA. Together with the
+// nondeterministic built-in functions, such as those that access the network or
+// the environment, they are not available to Rego policies, as they would allow
+// verifiers to reach different results for the same change.
+var regoForbiddenBuiltins = map[string]bool{
+	"crypto.x509.parse_and_verify_certificates":              true,
+	"crypto.x509.parse_and_verify_certificates_with_options": true,
+}
+
+// getRegoCapabilities returns the capabilities that Rego policies are compiled
+// with. Only the deterministic built-in functions of regoCapabilitiesVersion
+// that aren't forbidden are available, and network access is not permitted.
+var getRegoCapabilities = sync.OnceValues(func() (*ast.Capabilities, error) {
+	capabilities, err := ast.LoadCapabilitiesVersion(regoCapabilitiesVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	builtins := make([]*ast.Builtin, 0, len(capabilities.Builtins))
+	for _, builtin := range capabilities.Builtins {
+		if builtin.IsNondeterministic() || regoForbiddenBuiltins[builtin.Name] {
+			continue
+		}
+		builtins = append(builtins, builtin)
+	}
+	capabilities.Builtins = builtins
+	capabilities.AllowNet = []string{}
+
+	return capabilities, nil
+})
+
+// regoEngine evaluates policies written in Rego.
+type regoEngine struct{}
+
+// Validate checks that the source is a Rego module that declares the expected
+// package and compiles using only the available built-in functions.
+func (e *regoEngine) Validate(ctx context.Context, source string) error {
+	_, err := e.prepare(ctx, source)
+	return err
+}
+
+// Evaluate evaluates the policy in source against input. The change is allowed
+// if the policy sets `allow` to true. Evaluation stops if ctx is done.
+func (e *regoEngine) Evaluate(ctx context.Context, source string, input *EngineInput) error {
+	query, err := e.prepare(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	results, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEnginePolicyEvaluationFailed, err)
+	}
+
+	if !results.Allowed() {
+		return ErrEnginePolicyDenied
+	}
+
+	return nil
+}
+
+func (e *regoEngine) prepare(ctx context.Context, source string) (rego.PreparedEvalQuery, error) {
+	module, err := ast.ParseModule(regoModuleName, source)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("%w: %w", ErrInvalidEnginePolicy, err)
+	}
+	if module == nil || module.Package.Path.String() != fmt.Sprintf("data.%s", RegoPackage) {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("%w: %w", ErrInvalidEnginePolicy, ErrRegoPackageMismatch)
+	}
+
+	capabilities, err := getRegoCapabilities()
+	if err != nil {
+		return rego.PreparedEvalQuery{}, err
+	}
+
+	query, err := rego.New(
+		rego.Query(regoQuery),
+		rego.Module(regoModuleName, source),
+		rego.Capabilities(capabilities),
+		rego.StrictBuiltinErrors(true),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("%w: %w", ErrInvalidEnginePolicy, err)
+	}
+
+	return query, nil
+}
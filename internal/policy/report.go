@@ -0,0 +1,357 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+const (
+	sarifSchema         = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion        = "2.1.0"
+	sarifToolName       = "gittuf"
+	sarifInformationURI = "https://gittuf.dev"
+
+	sarifRuleUnauthorizedEntry = "gittuf/unauthorized-entry"
+	sarifRuleSkippedEntry      = "gittuf/skipped-entry"
+	sarifRuleWaivedEntry       = "gittuf/waived-entry"
+)
+
+// EntryStatus records the outcome of verifying a single RSL entry.
+type EntryStatus string
+
+const (
+	// EntryStatusVerified indicates the entry was successfully verified.
+	EntryStatusVerified EntryStatus = "verified"
+
+	// EntryStatusCached indicates the entry was not verified again as it was
+	// previously verified using the same policy state.
+	EntryStatusCached EntryStatus = "cached"
+
+	// EntryStatusFailed indicates the entry did not meet the applicable
+	// policy.
+	EntryStatusFailed EntryStatus = "failed"
+
+	// EntryStatusSkipped indicates the entry has been revoked using an
+	// annotation, and was therefore not required to meet the applicable
+	// policy.
+	EntryStatusSkipped EntryStatus = "skipped"
+
+	// EntryStatusWaived indicates the entry did not meet one or more rules of
+	// the applicable policy, but their failure was accepted using
+	// verification waivers.
+	EntryStatusWaived EntryStatus = "waived"
+)
+
+// EntryResult captures the verification result for a single RSL entry.
+type EntryResult struct {
+	EntryID  string      `json:"entryID"`
+	RefName  string      `json:"refName"`
+	TargetID string      `json:"targetID"`
+	PolicyID string      `json:"policyID"`
+	Status   EntryStatus `json:"status"`
+
+	// Rules contains the names of the rules in the applicable policy that
+	// protect the entry's ref.
+	Rules []string `json:"rules,omitempty"`
+
+	// WaivedRules contains the names of the rules whose failure was accepted
+	// using verification waivers.
+	WaivedRules []string `json:"waivedRules,omitempty"`
+
+	// SignerKeyID is the ID of the policy key that signed the entry. It is
+	// only recorded for verified entries.
+	SignerKeyID string `json:"signerKeyID,omitempty"`
+
+	// SigningSubkeyID is the fingerprint of the GPG subkey that created the
+	// entry's signature, if the entry was signed by a subkey of the key
+	// identified by SignerKeyID.
+	SigningSubkeyID string `json:"signingSubkeyID,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// VerificationReport captures the results of verifying the RSL entries for a
+// ref. In addition to the overall outcome, it records the result for each
+// entry and the policy states used during verification, allowing the report to
+// be consumed by other tools such as CI systems.
+type VerificationReport struct {
+	Target   string `json:"target"`
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+
+	// PolicyIDs contains the IDs of the policy states used during
+	// verification, in the order they were applied.
+	PolicyIDs []string `json:"policyIDs"`
+
+	Entries []*EntryResult `json:"entries"`
+}
+
+// NewVerificationReport returns an empty report for the specified target ref.
+func NewVerificationReport(target string) *VerificationReport {
+	return &VerificationReport{
+		Target:    target,
+		PolicyIDs: []string{},
+		Entries:   []*EntryResult{},
+	}
+}
+
+// SetResult records the overall outcome of verification using the specified
+// error.
+func (r *VerificationReport) SetResult(err error) {
+	r.Verified = err == nil
+	r.Error = ""
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// JSON serializes the report as JSON.
+func (r *VerificationReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// SARIF serializes the report using the Static Analysis Results Interchange
+// Format (SARIF) v2.1.0. Each failed, skipped, or waived entry is reported as a result
+// whose logical location is the entry's ref.
+func (r *VerificationReport) SARIF() ([]byte, error) {
+	results := []sarifResult{}
+	for _, entry := range r.Entries {
+		var (
+			ruleID  string
+			level   string
+			message string
+		)
+
+		switch entry.Status {
+		case EntryStatusFailed:
+			ruleID = sarifRuleUnauthorizedEntry
+			level = "error"
+			message = fmt.Sprintf("RSL entry '%s' for '%s' does not meet gittuf policy: %s", entry.EntryID, entry.RefName, entry.Error)
+		case EntryStatusSkipped:
+			ruleID = sarifRuleSkippedEntry
+			level = "note"
+			message = fmt.Sprintf("RSL entry '%s' for '%s' has been revoked", entry.EntryID, entry.RefName)
+			if entry.Error != "" {
+				message = fmt.Sprintf("%s: %s", message, entry.Error)
+			}
+		case EntryStatusWaived:
+			ruleID = sarifRuleWaivedEntry
+			level = "warning"
+			message = fmt.Sprintf("RSL entry '%s' for '%s' does not meet gittuf policy, but the failure of rules '%s' is waived", entry.EntryID, entry.RefName, strings.Join(entry.WaivedRules, "', '"))
+		default:
+			continue
+		}
+
+		results = append(results, sarifResult{
+			RuleID:  ruleID,
+			Level:   level,
+			Message: sarifMessage{Text: message},
+			Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+				Name:               entry.RefName,
+				FullyQualifiedName: fmt.Sprintf("%s@%s", entry.RefName, entry.EntryID),
+				Kind:               "object",
+			}}}},
+			Properties: map[string]string{
+				"entryID":  entry.EntryID,
+				"targetID": entry.TargetID,
+				"policyID": entry.PolicyID,
+			},
+		})
+	}
+
+	// Verification can fail before any entry is evaluated, for example when
+	// the policy cannot be loaded
+	if !r.Verified && len(results) == 0 && r.Error != "" {
+		results = append(results, sarifResult{
+			RuleID:  sarifRuleUnauthorizedEntry,
+			Level:   "error",
+			Message: sarifMessage{Text: fmt.Sprintf("verifying '%s' failed: %s", r.Target, r.Error)},
+			Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+				Name:               r.Target,
+				FullyQualifiedName: r.Target,
+				Kind:               "object",
+			}}}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifInformationURI,
+				Rules: []sarifRule{
+					{
+						ID:               sarifRuleUnauthorizedEntry,
+						ShortDescription: sarifMessage{Text: "RSL entry does not meet gittuf policy"},
+					},
+					{
+						ID:               sarifRuleSkippedEntry,
+						ShortDescription: sarifMessage{Text: "RSL entry has been revoked"},
+					},
+					{
+						ID:               sarifRuleWaivedEntry,
+						ShortDescription: sarifMessage{Text: "RSL entry's policy violation has been waived"},
+					},
+				},
+			}},
+			Results: results,
+		}},
+	}
+
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// addPolicy records the use of the specified policy state. The policy state is
+// not recorded again if it's the one currently in use, as happens when
+// verification starts with the policy's own RSL entry.
+func (r *VerificationReport) addPolicy(policyID plumbing.Hash) {
+	if r == nil {
+		return
+	}
+
+	if len(r.PolicyIDs) > 0 && r.PolicyIDs[len(r.PolicyIDs)-1] == policyID.String() {
+		return
+	}
+
+	r.PolicyIDs = append(r.PolicyIDs, policyID.String())
+}
+
+// addEntry records the result for the specified entry. The rules protecting
+// the entry's ref are identified using the applicable policy.
+func (r *VerificationReport) addEntry(entry *rsl.ReferenceEntry, policy *State, policyID plumbing.Hash, status EntryStatus, err error) {
+	if r == nil {
+		return
+	}
+
+	result := &EntryResult{
+		EntryID:  entry.ID.String(),
+		RefName:  entry.RefName,
+		TargetID: entry.TargetID.String(),
+		PolicyID: policyID.String(),
+		Status:   status,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	if policy != nil {
+		verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+		if err == nil {
+			for _, verifier := range verifiers {
+				result.Rules = append(result.Rules, verifier.Name())
+			}
+		}
+	}
+
+	r.Entries = append(r.Entries, result)
+}
+
+// addWaivedEntry records the entry as having passed verification only because
+// the failure of the specified rules was waived.
+func (r *VerificationReport) addWaivedEntry(entry *rsl.ReferenceEntry, policy *State, policyID plumbing.Hash, waivedRules []string) {
+	if r == nil {
+		return
+	}
+
+	r.addEntry(entry, policy, policyID, EntryStatusWaived, nil)
+	r.Entries[len(r.Entries)-1].WaivedRules = waivedRules
+}
+
+// addEntrySigner records the policy key that signed the most recently added
+// entry, as well as the GPG subkey used, if any. The signer is identified on a
+// best-effort basis among the keys of the rules protecting the entry's ref.
+func (r *VerificationReport) addEntrySigner(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) {
+	if r == nil || len(r.Entries) == 0 {
+		return
+	}
+
+	verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+	if err != nil {
+		return
+	}
+
+	ctx, err = withEntryVerificationTime(ctx, repo, entry)
+	if err != nil {
+		return
+	}
+
+	entryCommit, err := gitinterface.GetCommit(repo, entry.ID)
+	if err != nil {
+		return
+	}
+
+	result := r.Entries[len(r.Entries)-1]
+	for _, verifier := range verifiers {
+		for _, key := range verifier.keys {
+			signingKeyID, err := gitinterface.GetCommitSigningKeyID(ctx, entryCommit, key)
+			if err != nil {
+				continue
+			}
+
+			result.SignerKeyID = key.KeyID
+			if signingKeyID != key.KeyID {
+				result.SigningSubkeyID = signingKeyID
+			}
+			return
+		}
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
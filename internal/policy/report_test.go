@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationReport(t *testing.T) {
+	report := NewVerificationReport("refs/heads/main")
+	report.PolicyIDs = []string{"policy-1"}
+	report.Entries = []*EntryResult{
+		{
+			EntryID:  "entry-1",
+			RefName:  "refs/heads/main",
+			TargetID: "target-1",
+			PolicyID: "policy-1",
+			Status:   EntryStatusVerified,
+			Rules:    []string{"protect-main"},
+		},
+		{
+			EntryID:  "entry-2",
+			RefName:  "refs/heads/main",
+			TargetID: "target-2",
+			PolicyID: "policy-1",
+			Status:   EntryStatusSkipped,
+		},
+		{
+			EntryID:  "entry-3",
+			RefName:  "refs/heads/main",
+			TargetID: "target-3",
+			PolicyID: "policy-1",
+			Status:   EntryStatusFailed,
+			Error:    ErrUnauthorizedSignature.Error(),
+		},
+		{
+			EntryID:     "entry-4",
+			RefName:     "refs/heads/main",
+			TargetID:    "target-4",
+			PolicyID:    "policy-1",
+			Status:      EntryStatusWaived,
+			Rules:       []string{"protect-main"},
+			WaivedRules: []string{"protect-main"},
+		},
+	}
+	report.SetResult(ErrUnauthorizedSignature)
+
+	t.Run("set result", func(t *testing.T) {
+		report := NewVerificationReport("refs/heads/main")
+
+		report.SetResult(errors.New("failed"))
+		assert.False(t, report.Verified)
+		assert.Equal(t, "failed", report.Error)
+
+		report.SetResult(nil)
+		assert.True(t, report.Verified)
+		assert.Empty(t, report.Error)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		reportBytes, err := report.JSON()
+		assert.Nil(t, err)
+
+		decodedReport := &VerificationReport{}
+		if err := json.Unmarshal(reportBytes, decodedReport); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, report, decodedReport)
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		reportBytes, err := report.SARIF()
+		assert.Nil(t, err)
+
+		log := &sarifLog{}
+		if err := json.Unmarshal(reportBytes, log); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, sarifVersion, log.Version)
+		assert.Len(t, log.Runs, 1)
+		assert.Equal(t, sarifToolName, log.Runs[0].Tool.Driver.Name)
+
+		// Only the skipped, failed, and waived entries are reported
+		results := log.Runs[0].Results
+		assert.Len(t, results, 3)
+		assert.Equal(t, sarifRuleSkippedEntry, results[0].RuleID)
+		assert.Equal(t, "note", results[0].Level)
+		assert.Equal(t, "entry-2", results[0].Properties["entryID"])
+		assert.Equal(t, sarifRuleUnauthorizedEntry, results[1].RuleID)
+		assert.Equal(t, "error", results[1].Level)
+		assert.Equal(t, "entry-3", results[1].Properties["entryID"])
+		assert.Equal(t, "refs/heads/main", results[1].Locations[0].LogicalLocations[0].Name)
+		assert.Equal(t, sarifRuleWaivedEntry, results[2].RuleID)
+		assert.Equal(t, "warning", results[2].Level)
+		assert.Contains(t, results[2].Message.Text, "protect-main")
+	})
+
+	t.Run("sarif without entries", func(t *testing.T) {
+		report := NewVerificationReport("refs/heads/main")
+		report.SetResult(errors.New("unable to load policy"))
+
+		reportBytes, err := report.SARIF()
+		assert.Nil(t, err)
+
+		log := &sarifLog{}
+		if err := json.Unmarshal(reportBytes, log); err != nil {
+			t.Fatal(err)
+		}
+
+		results := log.Runs[0].Results
+		assert.Len(t, results, 1)
+		assert.Equal(t, sarifRuleUnauthorizedEntry, results[0].RuleID)
+		assert.Contains(t, results[0].Message.Text, "unable to load policy")
+	})
+}
@@ -16,6 +16,8 @@ var (
 	ErrTargetsMetadataNil  = errors.New("targetsMetadata not found")
 	ErrTargetsKeyNil       = errors.New("targetsKey is nil")
 	ErrKeyIDEmpty          = errors.New("keyID is empty")
+	ErrRootKeyExists       = errors.New("root key is already trusted")
+	ErrGitHubAppKeyNil     = errors.New("GitHub app key is nil")
 )
 
 // InitializeRootMetadata initializes a new instance of tuf.RootMetadata with
@@ -35,6 +37,44 @@ func InitializeRootMetadata(key *tuf.Key) *tuf.RootMetadata {
 	return rootMetadata
 }
 
+// InitializeRootMetadataWithThreshold initializes a new instance of
+// tuf.RootMetadata like InitializeRootMetadata. However, all the provided keys
+// are trusted for the Root role, and the specified threshold of signatures is
+// required.
+func InitializeRootMetadataWithThreshold(keys []*tuf.Key, threshold int) (*tuf.RootMetadata, error) {
+	keyIDs := []string{}
+	for _, key := range keys {
+		found := false
+		for _, keyID := range keyIDs {
+			if keyID == key.KeyID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			keyIDs = append(keyIDs, key.KeyID)
+		}
+	}
+
+	if threshold < 1 || len(keyIDs) < threshold {
+		return nil, ErrCannotMeetThreshold
+	}
+
+	rootMetadata := tuf.NewRootMetadata()
+	rootMetadata.SetVersion(1)
+	rootMetadata.SetExpires(time.Now().AddDate(1, 0, 0).Format(time.RFC3339))
+	for _, key := range keys {
+		rootMetadata.AddKey(key)
+	}
+
+	rootMetadata.AddRole(RootRoleName, tuf.Role{
+		KeyIDs:    keyIDs,
+		Threshold: threshold,
+	})
+
+	return rootMetadata, nil
+}
+
 // AddRootKey adds rootKey as a trusted public key in rootMetadata for the
 // Root role.
 func AddRootKey(rootMetadata *tuf.RootMetadata, rootKey *tuf.Key) *tuf.RootMetadata {
@@ -81,6 +121,48 @@ func DeleteRootKey(rootMetadata *tuf.RootMetadata, keyID string) (*tuf.RootMetad
 	return rootMetadata, nil
 }
 
+// ReplaceRootKey replaces the trusted Root public key identified by oldKeyID
+// with newRootKey in rootMetadata. Unlike removing the old key and adding the
+// new key, the number of trusted Root keys is unchanged, so a key can be
+// rotated even when the number of Root keys is equal to the threshold. Like
+// DeleteRootKey, the old key entry itself is not removed.
+func ReplaceRootKey(rootMetadata *tuf.RootMetadata, oldKeyID string, newRootKey *tuf.Key) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if len(oldKeyID) == 0 {
+		return nil, ErrKeyIDEmpty
+	}
+
+	rootRole, ok := rootMetadata.Roles[RootRoleName]
+	if !ok {
+		return nil, ErrRootKeyNil
+	}
+
+	index := -1
+	for i, keyID := range rootRole.KeyIDs {
+		if keyID == newRootKey.KeyID {
+			return nil, ErrRootKeyExists
+		}
+		if keyID == oldKeyID {
+			index = i
+		}
+	}
+	if index == -1 {
+		return nil, ErrRootKeyNil
+	}
+
+	rootMetadata.AddKey(newRootKey)
+
+	keyIDs := make([]string, len(rootRole.KeyIDs))
+	copy(keyIDs, rootRole.KeyIDs)
+	keyIDs[index] = newRootKey.KeyID
+	rootRole.KeyIDs = keyIDs
+	rootMetadata.Roles[RootRoleName] = rootRole
+
+	return rootMetadata, nil
+}
+
 // AddTargetsKey adds the 'targetsKey' as a trusted public key in 'rootMetadata'
 // for the top level Targets role.
 func AddTargetsKey(rootMetadata *tuf.RootMetadata, targetsKey *tuf.Key) (*tuf.RootMetadata, error) {
@@ -147,6 +229,39 @@ func DeleteTargetsKey(rootMetadata *tuf.RootMetadata, keyID string) (*tuf.RootMe
 	return rootMetadata, nil
 }
 
+// AddGitHubAppKey sets appKey as the key trusted in rootMetadata to attest to
+// approvals of GitHub pull requests. Any previously trusted GitHub app key is
+// replaced.
+func AddGitHubAppKey(rootMetadata *tuf.RootMetadata, appKey *tuf.Key) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if appKey == nil {
+		return nil, ErrGitHubAppKeyNil
+	}
+
+	rootMetadata.AddKey(appKey)
+	rootMetadata.AddRole(GitHubAppRoleName, tuf.Role{
+		KeyIDs:    []string{appKey.KeyID},
+		Threshold: 1,
+	})
+
+	return rootMetadata, nil
+}
+
+// DeleteGitHubAppKey removes the role trusted to attest to approvals of GitHub
+// pull requests from rootMetadata. Like DeleteRootKey, the key entry itself is
+// not removed.
+func DeleteGitHubAppKey(rootMetadata *tuf.RootMetadata) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+
+	delete(rootMetadata.Roles, GitHubAppRoleName)
+
+	return rootMetadata, nil
+}
+
 // UpdateRootThreshold sets the threshold for the Root role.
 func UpdateRootThreshold(rootMetadata *tuf.RootMetadata, threshold int) (*tuf.RootMetadata, error) {
 	rootRole, ok := rootMetadata.Roles[RootRoleName]
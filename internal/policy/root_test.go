@@ -22,6 +22,32 @@ func TestInitializeRootMetadata(t *testing.T) {
 	assert.Equal(t, []string{key.KeyID}, rootMetadata.Roles[RootRoleName].KeyIDs)
 }
 
+func TestInitializeRootMetadataWithThreshold(t *testing.T) {
+	key1, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := InitializeRootMetadataWithThreshold([]*tuf.Key{key1, key2}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, rootMetadata.Version)
+	assert.Equal(t, key1, rootMetadata.Keys[key1.KeyID])
+	assert.Equal(t, key2, rootMetadata.Keys[key2.KeyID])
+	assert.Equal(t, 2, rootMetadata.Roles[RootRoleName].Threshold)
+	assert.Equal(t, []string{key1.KeyID, key2.KeyID}, rootMetadata.Roles[RootRoleName].KeyIDs)
+
+	// Duplicate keys are only counted once
+	_, err = InitializeRootMetadataWithThreshold([]*tuf.Key{key1, key1}, 2)
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+
+	_, err = InitializeRootMetadataWithThreshold([]*tuf.Key{key1}, 0)
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+}
+
 func TestAddRootKey(t *testing.T) {
 	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
 	if err != nil {
@@ -69,6 +95,36 @@ func TestRemoveRootKey(t *testing.T) {
 	assert.Nil(t, rootMetadata)
 }
 
+func TestReplaceRootKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	newRootKey, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The only root key can be rotated
+	rootMetadata, err = ReplaceRootKey(rootMetadata, key.KeyID, newRootKey)
+	assert.Nil(t, err)
+	assert.Equal(t, newRootKey, rootMetadata.Keys[newRootKey.KeyID])
+	assert.Equal(t, []string{newRootKey.KeyID}, rootMetadata.Roles[RootRoleName].KeyIDs)
+	assert.Equal(t, 1, rootMetadata.Roles[RootRoleName].Threshold)
+
+	_, err = ReplaceRootKey(rootMetadata, key.KeyID, key)
+	assert.ErrorIs(t, err, ErrRootKeyNil)
+
+	_, err = ReplaceRootKey(rootMetadata, newRootKey.KeyID, newRootKey)
+	assert.ErrorIs(t, err, ErrRootKeyExists)
+
+	_, err = ReplaceRootKey(rootMetadata, "", key)
+	assert.ErrorIs(t, err, ErrKeyIDEmpty)
+}
+
 func TestAddTargetsKey(t *testing.T) {
 	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
 	if err != nil {
@@ -94,6 +150,59 @@ func TestAddTargetsKey(t *testing.T) {
 	assert.Equal(t, []string{targetsKey.KeyID}, rootMetadata.Roles[TargetsRoleName].KeyIDs)
 }
 
+func TestAddGitHubAppKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	appKey, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = AddGitHubAppKey(nil, appKey)
+	assert.ErrorIs(t, err, ErrRootMetadataNil)
+
+	_, err = AddGitHubAppKey(rootMetadata, nil)
+	assert.ErrorIs(t, err, ErrGitHubAppKeyNil)
+
+	rootMetadata, err = AddGitHubAppKey(rootMetadata, appKey)
+	assert.Nil(t, err)
+	assert.Equal(t, appKey, rootMetadata.Keys[appKey.KeyID])
+	assert.Equal(t, []string{appKey.KeyID}, rootMetadata.Roles[GitHubAppRoleName].KeyIDs)
+	assert.Equal(t, 1, rootMetadata.Roles[GitHubAppRoleName].Threshold)
+}
+
+func TestDeleteGitHubAppKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	appKey, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = AddGitHubAppKey(rootMetadata, appKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = DeleteGitHubAppKey(nil)
+	assert.ErrorIs(t, err, ErrRootMetadataNil)
+
+	rootMetadata, err = DeleteGitHubAppKey(rootMetadata)
+	assert.Nil(t, err)
+	assert.NotContains(t, rootMetadata.Roles, GitHubAppRoleName)
+	assert.Contains(t, rootMetadata.Roles, RootRoleName)
+}
+
 func TestDeleteTargetsKey(t *testing.T) {
 	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
 	if err != nil {
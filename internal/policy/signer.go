@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var ErrUnauthorizedRSLEntrySigner = errors.New("RSL entry is not signed by a key trusted by the rules protecting the reference")
+
+// VerifyRSLEntrySigner checks that signedCommit is signed by a key trusted by
+// at least one of the rules protecting refName. It is intended to be used
+// before recording an RSL entry for refName, with a commit signed using the
+// same key as the entry, to identify entries that will not pass verification.
+// As only the key is checked, an entry that passes this check may still fail
+// verification, for example if a rule requires a threshold of signatures or
+// protects the files changed by the entry. If no rule protects refName, any
+// signature is accepted.
+func (s *State) VerifyRSLEntrySigner(ctx context.Context, refName string, signedCommit *object.Commit) error {
+	verifiers, err := s.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, refName))
+	if err != nil {
+		return err
+	}
+	if len(verifiers) == 0 {
+		return nil
+	}
+
+	ruleNames := make([]string, 0, len(verifiers))
+	for _, verifier := range verifiers {
+		keyID, err := verifier.verifyGitObjectSignature(ctx, signedCommit)
+		if err != nil {
+			return err
+		}
+		if keyID != "" {
+			return nil
+		}
+
+		ruleNames = append(ruleNames, fmt.Sprintf("'%s'", verifier.name))
+	}
+
+	return fmt.Errorf("%w: rules protecting '%s': %s", ErrUnauthorizedRSLEntrySigner, refName, strings.Join(ruleNames, ", "))
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyRSLEntrySigner(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/authorized", 1, gpgKeyBytes)
+	authorizedCommit, err := gitinterface.GetCommit(repo, authorizedCommitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unauthorizedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/unauthorized", 1, gpgUnauthorizedKeyBytes)
+	unauthorizedCommit, err := gitinterface.GetCommit(repo, unauthorizedCommitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := createTestStateWithPolicy(t)
+
+	t.Run("authorized signer", func(t *testing.T) {
+		err := state.VerifyRSLEntrySigner(testCtx, "refs/heads/main", authorizedCommit)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		err := state.VerifyRSLEntrySigner(testCtx, "refs/heads/main", unauthorizedCommit)
+		assert.ErrorIs(t, err, ErrUnauthorizedRSLEntrySigner)
+		assert.ErrorContains(t, err, "'protect-main'")
+	})
+
+	t.Run("unprotected ref", func(t *testing.T) {
+		err := state.VerifyRSLEntrySigner(testCtx, "refs/heads/feature", unauthorizedCommit)
+		assert.Nil(t, err)
+	})
+}
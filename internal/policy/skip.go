@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// SkipResolver determines if RSL reference entries are skipped by the
+// annotations that refer to them. When an entry's annotations do not include
+// an unskip, any skip annotation skips the entry. Otherwise, the annotations
+// conflict, and only skip and unskip annotations signed by a key trusted to
+// update the entry's ref are considered. Unauthorized annotations are ignored,
+// so an entry with conflicting annotations is not skipped if none of them are
+// authorized. In either case, the most recent skip or unskip annotation
+// considered determines if the entry is skipped. Workflows that act on the
+// skip status of entries use a SkipResolver so that they agree with policy
+// verification.
+type SkipResolver struct {
+	repo         *git.Repository
+	policy       *State
+	policyLoaded bool
+}
+
+// NewSkipResolver returns a SkipResolver that identifies the keys trusted to
+// update a ref using the repository's current policy. The policy is only loaded
+// once conflicting annotations are encountered. If the repository has no
+// policy, no annotation is authorized.
+func NewSkipResolver(repo *git.Repository) *SkipResolver {
+	return &SkipResolver{repo: repo}
+}
+
+// newSkipResolverForPolicy returns a SkipResolver that identifies the keys
+// trusted to update a ref using the specified policy, such as the policy
+// applicable at the entries being verified.
+func newSkipResolverForPolicy(repo *git.Repository, policy *State) *SkipResolver {
+	return &SkipResolver{repo: repo, policy: policy, policyLoaded: true}
+}
+
+// IsSkipped returns true if the entry is skipped by the annotations, which are
+// expected to be in order of occurrence.
+func (s *SkipResolver) IsSkipped(ctx context.Context, entry *rsl.ReferenceEntry, annotations []*rsl.AnnotationEntry) (bool, error) {
+	hasUnskip := false
+	for _, annotation := range annotations {
+		if annotation.Unskip {
+			hasUnskip = true
+			break
+		}
+	}
+	if !hasUnskip {
+		// Without an unskip, any skip annotation skips the entry
+		return entry.SkippedBy(annotations), nil
+	}
+
+	slog.Debug(fmt.Sprintf("Resolving conflicting annotations for entry '%s'...", entry.ID.String()))
+	policy, err := s.loadPolicy(ctx)
+	if err != nil {
+		return false, err
+	}
+	if policy == nil {
+		return false, nil
+	}
+
+	verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+	if err != nil && !errors.Is(err, ErrMetadataNotFound) {
+		return false, err
+	}
+
+	authorizedAnnotations := []*rsl.AnnotationEntry{}
+	for _, annotation := range annotations {
+		if !annotation.Skip && !annotation.Unskip {
+			continue
+		}
+
+		annotationCommit, err := gitinterface.GetCommit(s.repo, annotation.ID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, verifier := range verifiers {
+			keyID, err := verifier.verifyGitObjectSignature(ctx, annotationCommit)
+			if err != nil {
+				return false, err
+			}
+			if keyID != "" {
+				authorizedAnnotations = append(authorizedAnnotations, annotation)
+				break
+			}
+		}
+	}
+
+	return entry.SkippedBy(authorizedAnnotations), nil
+}
+
+// GetLatestUnskippedReferenceEntryForRef returns the latest reference entry for
+// the ref that is not skipped, like rsl.GetLatestUnskippedReferenceEntryForRef,
+// resolving conflicting annotations as described for SkipResolver.
+func (s *SkipResolver) GetLatestUnskippedReferenceEntryForRef(ctx context.Context, refName string) (*rsl.ReferenceEntry, []*rsl.AnnotationEntry, error) {
+	return s.GetLatestUnskippedReferenceEntryForRefBefore(ctx, refName, plumbing.ZeroHash)
+}
+
+// GetLatestUnskippedReferenceEntryForRefBefore returns the latest reference
+// entry for the ref before the anchor that is not skipped, like
+// rsl.GetLatestUnskippedReferenceEntryForRefBefore, resolving conflicting
+// annotations as described for SkipResolver.
+func (s *SkipResolver) GetLatestUnskippedReferenceEntryForRefBefore(ctx context.Context, refName string, anchor plumbing.Hash) (*rsl.ReferenceEntry, []*rsl.AnnotationEntry, error) {
+	for {
+		latestEntry, annotations, err := rsl.GetLatestReferenceEntryForRefBefore(s.repo, refName, anchor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		skipped, err := s.IsSkipped(ctx, latestEntry, annotations)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !skipped {
+			return latestEntry, annotations, nil
+		}
+
+		anchor = latestEntry.ID
+	}
+}
+
+// loadPolicy returns the policy used to identify authorized annotations,
+// loading the repository's current policy if necessary. If the repository has
+// no policy, nil is returned.
+func (s *SkipResolver) loadPolicy(ctx context.Context) (*State, error) {
+	if s.policyLoaded {
+		return s.policy, nil
+	}
+
+	slog.Debug("Loading current policy to resolve conflicting annotations...")
+	policy, err := LoadCurrentState(ctx, s.repo, PolicyRef)
+	if err != nil && !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return nil, err
+	}
+
+	s.policy = policy
+	s.policyLoaded = true
+	return s.policy, nil
+}
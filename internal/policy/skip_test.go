@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipResolver(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// annotate records a skip or unskip annotation for the entry signed using
+	// the specified key
+	annotate := func(t *testing.T, repo *git.Repository, entryID plumbing.Hash, skip bool, keyBytes []byte) *rsl.AnnotationEntry {
+		t.Helper()
+
+		annotation := rsl.NewUnskipAnnotationEntry([]plumbing.Hash{entryID}, "reinstate entry")
+		if skip {
+			annotation = rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, true, "invalid entry")
+		}
+		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, keyBytes)
+
+		entry, err := rsl.GetEntry(repo, annotationID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entry.(*rsl.AnnotationEntry)
+	}
+
+	setup := func(t *testing.T) (*git.Repository, *rsl.ReferenceEntry, *rsl.ReferenceEntry) {
+		t.Helper()
+
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		entries := []*rsl.ReferenceEntry{}
+		for _, commitID := range commitIDs {
+			entry := rsl.NewReferenceEntry(refName, commitID)
+			entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+			entries = append(entries, entry)
+		}
+
+		return repo, entries[0], entries[1]
+	}
+
+	t.Run("skip without conflict", func(t *testing.T) {
+		repo, _, entry := setup(t)
+
+		annotations := []*rsl.AnnotationEntry{annotate(t, repo, entry.ID, true, gpgUnauthorizedKeyBytes)}
+
+		skipped, err := NewSkipResolver(repo).IsSkipped(testCtx, entry, annotations)
+		assert.Nil(t, err)
+		assert.True(t, skipped)
+	})
+
+	t.Run("unauthorized unskip is ignored", func(t *testing.T) {
+		repo, _, entry := setup(t)
+
+		annotations := []*rsl.AnnotationEntry{
+			annotate(t, repo, entry.ID, true, gpgKeyBytes),
+			annotate(t, repo, entry.ID, false, gpgUnauthorizedKeyBytes),
+		}
+
+		skipped, err := NewSkipResolver(repo).IsSkipped(testCtx, entry, annotations)
+		assert.Nil(t, err)
+		assert.True(t, skipped)
+
+		annotations = append(annotations, annotate(t, repo, entry.ID, false, gpgKeyBytes))
+
+		skipped, err = NewSkipResolver(repo).IsSkipped(testCtx, entry, annotations)
+		assert.Nil(t, err)
+		assert.False(t, skipped)
+	})
+
+	t.Run("conflicting unauthorized annotations are ignored", func(t *testing.T) {
+		repo, _, entry := setup(t)
+
+		annotations := []*rsl.AnnotationEntry{
+			annotate(t, repo, entry.ID, true, gpgUnauthorizedKeyBytes),
+			annotate(t, repo, entry.ID, false, gpgUnauthorizedKeyBytes),
+			annotate(t, repo, entry.ID, true, gpgUnauthorizedKeyBytes),
+		}
+
+		skipped, err := NewSkipResolver(repo).IsSkipped(testCtx, entry, annotations)
+		assert.Nil(t, err)
+		assert.False(t, skipped)
+	})
+
+	t.Run("latest unskipped entry", func(t *testing.T) {
+		repo, firstEntry, secondEntry := setup(t)
+
+		annotate(t, repo, secondEntry.ID, true, gpgKeyBytes)
+		annotate(t, repo, secondEntry.ID, false, gpgUnauthorizedKeyBytes)
+
+		latestEntry, _, err := NewSkipResolver(repo).GetLatestUnskippedReferenceEntryForRef(testCtx, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, firstEntry.ID, latestEntry.ID)
+
+		annotate(t, repo, secondEntry.ID, false, gpgKeyBytes)
+
+		latestEntry, _, err = NewSkipResolver(repo).GetLatestUnskippedReferenceEntryForRef(testCtx, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, secondEntry.ID, latestEntry.ID)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		annotations := []*rsl.AnnotationEntry{annotate(t, repo, entry.ID, true, gpgKeyBytes)}
+
+		skipped, err := NewSkipResolver(repo).IsSkipped(testCtx, entry, annotations)
+		assert.Nil(t, err)
+		assert.True(t, skipped)
+
+		// No annotation is authorized without a policy
+		annotations = append(annotations, annotate(t, repo, entry.ID, false, gpgKeyBytes), annotate(t, repo, entry.ID, true, gpgKeyBytes))
+
+		skipped, err = NewSkipResolver(repo).IsSkipped(testCtx, entry, annotations)
+		assert.Nil(t, err)
+		assert.False(t, skipped)
+	})
+}
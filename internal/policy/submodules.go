@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+var (
+	ErrInvalidSubmodulePolicy  = errors.New("invalid submodule policy")
+	ErrSubmodulePolicyNotFound = errors.New("submodule policy not found")
+)
+
+// SetSubmodulePolicy records how updates to the pointer of the submodule at
+// the specified path are verified in TargetsMetadata. An existing policy for
+// the submodule is replaced.
+func SetSubmodulePolicy(targetsMetadata *tuf.TargetsMetadata, submodulePath string, submodulePolicy *tuf.SubmodulePolicy) (*tuf.TargetsMetadata, error) {
+	submodulePath, err := cleanSubmodulePath(submodulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if submodulePolicy.Location == "" {
+		return nil, fmt.Errorf("%w: location of submodule's repository must be specified", ErrInvalidSubmodulePolicy)
+	}
+
+	switch submodulePolicy.Verification {
+	case tuf.SubmoduleVerificationPropagation, tuf.SubmoduleVerificationRemote:
+	default:
+		return nil, fmt.Errorf("%w: unknown verification mode '%s'", ErrInvalidSubmodulePolicy, submodulePolicy.Verification)
+	}
+
+	targetsMetadata.AddSubmodulePolicy(submodulePath, submodulePolicy)
+	return targetsMetadata, nil
+}
+
+// RemoveSubmodulePolicy deletes the policy for the submodule at the specified
+// path from TargetsMetadata.
+func RemoveSubmodulePolicy(targetsMetadata *tuf.TargetsMetadata, submodulePath string) (*tuf.TargetsMetadata, error) {
+	submodulePath, err := cleanSubmodulePath(submodulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, has := targetsMetadata.Submodules[submodulePath]; !has {
+		return nil, fmt.Errorf("%w: '%s'", ErrSubmodulePolicyNotFound, submodulePath)
+	}
+
+	delete(targetsMetadata.Submodules, submodulePath)
+	if len(targetsMetadata.Submodules) == 0 {
+		targetsMetadata.Submodules = nil
+	}
+
+	return targetsMetadata, nil
+}
+
+// cleanSubmodulePath normalizes a submodule's path to match how it's recorded
+// in Git trees.
+func cleanSubmodulePath(submodulePath string) (string, error) {
+	cleaned := path.Clean(submodulePath)
+	if submodulePath == "" || cleaned == "." || cleaned == ".." || path.IsAbs(cleaned) || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: invalid submodule path '%s'", ErrInvalidSubmodulePolicy, submodulePath)
+	}
+
+	return cleaned, nil
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmodulePolicy(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	submodulePolicy := &tuf.SubmodulePolicy{
+		Location:     "https://example.com/lib.git",
+		RefName:      "refs/heads/main",
+		Verification: tuf.SubmoduleVerificationRemote,
+	}
+	targetsMetadata, err := SetSubmodulePolicy(targetsMetadata, "vendor/lib/", submodulePolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*tuf.SubmodulePolicy{"vendor/lib": submodulePolicy}, targetsMetadata.Submodules)
+
+	_, err = SetSubmodulePolicy(targetsMetadata, "../lib", submodulePolicy)
+	assert.ErrorIs(t, err, ErrInvalidSubmodulePolicy)
+
+	_, err = SetSubmodulePolicy(targetsMetadata, "lib", &tuf.SubmodulePolicy{Location: "https://example.com/lib.git", Verification: "unknown"})
+	assert.ErrorIs(t, err, ErrInvalidSubmodulePolicy)
+
+	_, err = SetSubmodulePolicy(targetsMetadata, "lib", &tuf.SubmodulePolicy{Verification: tuf.SubmoduleVerificationPropagation})
+	assert.ErrorIs(t, err, ErrInvalidSubmodulePolicy)
+
+	_, err = RemoveSubmodulePolicy(targetsMetadata, "lib")
+	assert.ErrorIs(t, err, ErrSubmodulePolicyNotFound)
+
+	targetsMetadata, err = RemoveSubmodulePolicy(targetsMetadata, "vendor/lib")
+	assert.Nil(t, err)
+	assert.Nil(t, targetsMetadata.Submodules)
+}
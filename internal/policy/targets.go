@@ -3,15 +3,22 @@
 package policy
 
 import (
+	"context"
 	"errors"
+	"path"
 	"time"
 
+	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/tuf"
 )
 
 const AllowRuleName = "gittuf-allow-rule"
 
-var ErrCannotManipulateAllowRule = errors.New("cannot change in-built gittuf-allow-rule")
+var (
+	ErrCannotManipulateAllowRule = errors.New("cannot change in-built gittuf-allow-rule")
+	ErrMissingRules              = errors.New("some rules are missing")
+	ErrOrphanedRules             = errors.New("change would leave existing rules unreachable")
+)
 
 // InitializeTargetsMetadata creates a new instance of TargetsMetadata.
 func InitializeTargetsMetadata() *tuf.TargetsMetadata {
@@ -97,6 +104,153 @@ func UpdateDelegation(targetsMetadata *tuf.TargetsMetadata, ruleName string, aut
 	return targetsMetadata, nil
 }
 
+// SetRequireSignedCommits toggles whether the specified delegation in
+// TargetsMetadata requires each commit introduced to a protected Git reference
+// to be signed by one of the delegation's authorized keys.
+func SetRequireSignedCommits(targetsMetadata *tuf.TargetsMetadata, ruleName string, requireSignedCommits bool) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			targetsMetadata.Delegations.Roles[i].RequireSignedCommits = requireSignedCommits
+			return targetsMetadata, nil
+		}
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetInTotoLayout sets the in-toto layout that must verify for changes
+// protected by the specified delegation in TargetsMetadata. The layout must be
+// signed by all of the layout keys, which are added to TargetsMetadata.
+func SetInTotoLayout(targetsMetadata *tuf.TargetsMetadata, ruleName string, layout []byte, layoutKeys []*tuf.Key) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	if err := validateInTotoLayout(layout, layoutKeys); err != nil {
+		return nil, err
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			keyIDs := make([]string, 0, len(layoutKeys))
+			for _, key := range layoutKeys {
+				targetsMetadata.Delegations.AddKey(key)
+				keyIDs = append(keyIDs, key.KeyID)
+			}
+
+			targetsMetadata.Delegations.Roles[i].InTotoLayout = &tuf.InTotoLayout{
+				Layout: layout,
+				KeyIDs: keyIDs,
+			}
+			return targetsMetadata, nil
+		}
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// RemoveInTotoLayout removes the in-toto layout from the specified delegation
+// in TargetsMetadata.
+func RemoveInTotoLayout(targetsMetadata *tuf.TargetsMetadata, ruleName string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			targetsMetadata.Delegations.Roles[i].InTotoLayout = nil
+			return targetsMetadata, nil
+		}
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetEnginePolicy sets the policy that must allow changes protected by the
+// specified delegation in TargetsMetadata. The policy is evaluated using the
+// named policy engine, and it must be valid for the engine.
+func SetEnginePolicy(ctx context.Context, targetsMetadata *tuf.TargetsMetadata, ruleName, engine, source string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	if err := validateEnginePolicy(ctx, engine, source); err != nil {
+		return nil, err
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			targetsMetadata.Delegations.Roles[i].EnginePolicy = &tuf.EnginePolicy{
+				Engine: engine,
+				Source: source,
+			}
+			return targetsMetadata, nil
+		}
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// RemoveEnginePolicy removes the engine policy from the specified delegation in
+// TargetsMetadata.
+func RemoveEnginePolicy(targetsMetadata *tuf.TargetsMetadata, ruleName string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			targetsMetadata.Delegations.Roles[i].EnginePolicy = nil
+			return targetsMetadata, nil
+		}
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetCommitConstraints sets the constraints that each commit introduced to the
+// Git references protected by the specified delegation in TargetsMetadata must
+// meet. At least one constraint must be set.
+func SetCommitConstraints(targetsMetadata *tuf.TargetsMetadata, ruleName string, constraints *tuf.CommitConstraints) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	if err := validateCommitConstraints(constraints); err != nil {
+		return nil, err
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			targetsMetadata.Delegations.Roles[i].CommitConstraints = constraints
+			return targetsMetadata, nil
+		}
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// RemoveCommitConstraints removes the commit constraints from the specified
+// delegation in TargetsMetadata.
+func RemoveCommitConstraints(targetsMetadata *tuf.TargetsMetadata, ruleName string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			targetsMetadata.Delegations.Roles[i].CommitConstraints = nil
+			return targetsMetadata, nil
+		}
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
 // RemoveDelegation deletes a delegation entry from TargetsMetadata.
 func RemoveDelegation(targetsMetadata *tuf.TargetsMetadata, ruleName string) (*tuf.TargetsMetadata, error) {
 	if ruleName == AllowRuleName {
@@ -116,7 +270,89 @@ func RemoveDelegation(targetsMetadata *tuf.TargetsMetadata, ruleName string) (*t
 	return targetsMetadata, nil
 }
 
-// AddKeyToTargets adds public keys to the specified targets metadata.
+// ReorderDelegations changes the order of the delegations in TargetsMetadata
+// to match the order of the specified rule names. All delegations other than
+// the allow rule must be specified exactly once. The allow rule always remains
+// the last delegation.
+func ReorderDelegations(targetsMetadata *tuf.TargetsMetadata, ruleNames []string) (*tuf.TargetsMetadata, error) {
+	currentDelegations := map[string]tuf.Delegation{}
+	for _, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == AllowRuleName {
+			continue
+		}
+		currentDelegations[delegation.Name] = delegation
+	}
+
+	reorderedDelegations := []tuf.Delegation{}
+	seenRuleNames := set.NewSet[string]()
+	for _, ruleName := range ruleNames {
+		if ruleName == AllowRuleName {
+			return nil, ErrCannotManipulateAllowRule
+		}
+
+		if seenRuleNames.Has(ruleName) {
+			return nil, ErrDuplicatedRuleName
+		}
+		seenRuleNames.Add(ruleName)
+
+		delegation, has := currentDelegations[ruleName]
+		if !has {
+			return nil, ErrDelegationNotFound
+		}
+		reorderedDelegations = append(reorderedDelegations, delegation)
+	}
+
+	if len(reorderedDelegations) != len(currentDelegations) {
+		return nil, ErrMissingRules
+	}
+
+	targetsMetadata.Delegations.Roles = append(reorderedDelegations, AllowRule())
+
+	return targetsMetadata, nil
+}
+
+// ValidateDelegationPatterns checks that amending the patterns of the
+// specified rule does not leave any rules delegated to by it unreachable. Each
+// pattern of a delegated rule must be matched by one of the new patterns. If
+// the rule does not have its own metadata in the state, the patterns are
+// always valid.
+func (s *State) ValidateDelegationPatterns(ruleName string, rulePatterns []string) error {
+	if !s.HasTargetsRole(ruleName) {
+		return nil
+	}
+
+	delegatedMetadata, err := s.GetTargetsMetadata(ruleName)
+	if err != nil {
+		return err
+	}
+
+	for _, delegation := range delegatedMetadata.Delegations.Roles {
+		if delegation.Name == AllowRuleName {
+			continue
+		}
+
+		for _, delegatedPattern := range delegation.Paths {
+			if !patternsMatch(rulePatterns, delegatedPattern) {
+				return ErrOrphanedRules
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateDelegationRemoval checks that removing the specified rule does not
+// leave its own metadata, and therefore any rules delegated to by it,
+// unreachable.
+func (s *State) ValidateDelegationRemoval(ruleName string) error {
+	if s.HasTargetsRole(ruleName) {
+		return ErrOrphanedRules
+	}
+
+	return nil
+}
+
+// AddKeyToTargets adds public keys to the specified targets metadata.// AddKeyToTargets adds public keys to the specified targets metadata.
 func AddKeyToTargets(targetsMetadata *tuf.TargetsMetadata, authorizedKeys []*tuf.Key) (*tuf.TargetsMetadata, error) {
 	for _, key := range authorizedKeys {
 		targetsMetadata.Delegations.AddKey(key)
@@ -137,3 +373,14 @@ func AllowRule() tuf.Delegation {
 		},
 	}
 }
+
+// patternsMatch checks if the target pattern is matched by any of the
+// specified patterns.
+func patternsMatch(patterns []string, target string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
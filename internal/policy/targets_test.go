@@ -87,6 +87,150 @@ func TestUpdateDelegation(t *testing.T) {
 	}, targetsMetadata.Delegations.Roles[0])
 }
 
+func TestSetRequireSignedCommits(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequireSignedCommits)
+
+	targetsMetadata, err = SetRequireSignedCommits(targetsMetadata, "test-rule", true)
+	assert.Nil(t, err)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireSignedCommits)
+
+	// The option must survive updates to the rule
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireSignedCommits)
+
+	targetsMetadata, err = SetRequireSignedCommits(targetsMetadata, "test-rule", false)
+	assert.Nil(t, err)
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequireSignedCommits)
+
+	_, err = SetRequireSignedCommits(targetsMetadata, "does-not-exist", true)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetRequireSignedCommits(targetsMetadata, AllowRuleName, true)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetInTotoLayout(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layoutKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layout := createTestInTotoLayout(t, nil)
+
+	// The layout must be signed by the layout keys
+	_, err = SetInTotoLayout(targetsMetadata, "test-rule", layout, []*tuf.Key{key})
+	assert.ErrorIs(t, err, ErrInvalidInTotoLayout)
+
+	_, err = SetInTotoLayout(targetsMetadata, "test-rule", createTestInTotoLayoutWithInspection(t), []*tuf.Key{layoutKey})
+	assert.ErrorIs(t, err, ErrInTotoInspectionsNotSupported)
+
+	targetsMetadata, err = SetInTotoLayout(targetsMetadata, "test-rule", layout, []*tuf.Key{layoutKey})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{layoutKey.KeyID}, targetsMetadata.Delegations.Roles[0].InTotoLayout.KeyIDs)
+	assert.Contains(t, targetsMetadata.Delegations.Keys, layoutKey.KeyID)
+
+	targetsMetadata, err = RemoveInTotoLayout(targetsMetadata, "test-rule")
+	assert.Nil(t, err)
+	assert.Nil(t, targetsMetadata.Delegations.Roles[0].InTotoLayout)
+
+	_, err = SetInTotoLayout(targetsMetadata, "does-not-exist", layout, []*tuf.Key{layoutKey})
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = RemoveInTotoLayout(targetsMetadata, AllowRuleName)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetEnginePolicy(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = SetEnginePolicy(testCtx, targetsMetadata, "test-rule", RegoEngineName, "package other\n")
+	assert.ErrorIs(t, err, ErrInvalidEnginePolicy)
+
+	_, err = SetEnginePolicy(testCtx, targetsMetadata, "test-rule", "unknown", testRegoAllowMainPolicy)
+	assert.ErrorIs(t, err, ErrUnknownPolicyEngine)
+
+	targetsMetadata, err = SetEnginePolicy(testCtx, targetsMetadata, "test-rule", RegoEngineName, testRegoAllowMainPolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, &tuf.EnginePolicy{Engine: RegoEngineName, Source: testRegoAllowMainPolicy}, targetsMetadata.Delegations.Roles[0].EnginePolicy)
+
+	targetsMetadata, err = RemoveEnginePolicy(targetsMetadata, "test-rule")
+	assert.Nil(t, err)
+	assert.Nil(t, targetsMetadata.Delegations.Roles[0].EnginePolicy)
+
+	_, err = SetEnginePolicy(testCtx, targetsMetadata, "does-not-exist", RegoEngineName, testRegoAllowMainPolicy)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = RemoveEnginePolicy(targetsMetadata, AllowRuleName)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetCommitConstraints(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = SetCommitConstraints(targetsMetadata, "test-rule", &tuf.CommitConstraints{})
+	assert.ErrorIs(t, err, ErrInvalidCommitConstraints)
+
+	constraints := &tuf.CommitConstraints{RequireSignOff: true, MessagePattern: "^feat: ", NoMergeCommits: true}
+	targetsMetadata, err = SetCommitConstraints(targetsMetadata, "test-rule", constraints)
+	assert.Nil(t, err)
+	assert.Equal(t, constraints, targetsMetadata.Delegations.Roles[0].CommitConstraints)
+
+	targetsMetadata, err = RemoveCommitConstraints(targetsMetadata, "test-rule")
+	assert.Nil(t, err)
+	assert.Nil(t, targetsMetadata.Delegations.Roles[0].CommitConstraints)
+
+	_, err = SetCommitConstraints(targetsMetadata, "does-not-exist", constraints)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = RemoveCommitConstraints(targetsMetadata, AllowRuleName)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
 func TestRemoveDelegation(t *testing.T) {
 	targetsMetadata := InitializeTargetsMetadata()
 
@@ -108,6 +252,93 @@ func TestRemoveDelegation(t *testing.T) {
 	assert.Contains(t, targetsMetadata.Delegations.Keys, key.KeyID)
 }
 
+func TestReorderDelegations(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ruleName := range []string{"rule-1", "rule-2", "rule-3"} {
+		targetsMetadata, err = AddDelegation(targetsMetadata, ruleName, []*tuf.Key{key}, []string{ruleName}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		ruleNames     []string
+		expectedError error
+	}{
+		"all rules reordered": {
+			ruleNames: []string{"rule-3", "rule-1", "rule-2"},
+		},
+		"rule missing": {
+			ruleNames:     []string{"rule-3", "rule-1"},
+			expectedError: ErrMissingRules,
+		},
+		"rule duplicated": {
+			ruleNames:     []string{"rule-3", "rule-1", "rule-1"},
+			expectedError: ErrDuplicatedRuleName,
+		},
+		"unknown rule": {
+			ruleNames:     []string{"rule-3", "rule-1", "rule-4"},
+			expectedError: ErrDelegationNotFound,
+		},
+		"allow rule included": {
+			ruleNames:     []string{"rule-3", "rule-1", "rule-2", AllowRuleName},
+			expectedError: ErrCannotManipulateAllowRule,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reorderedMetadata, err := ReorderDelegations(targetsMetadata, test.ruleNames)
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+
+			assert.Nil(t, err)
+			ruleNames := []string{}
+			for _, delegation := range reorderedMetadata.Delegations.Roles {
+				ruleNames = append(ruleNames, delegation.Name)
+			}
+			assert.Equal(t, append(test.ruleNames, AllowRuleName), ruleNames)
+		})
+	}
+}
+
+func TestValidateDelegationPatterns(t *testing.T) {
+	state := createTestStateWithDelegatedPolicies(t)
+
+	// Rule 1 delegates to rules protecting file:1/subpath1/* and
+	// file:1/subpath2/*
+	err := state.ValidateDelegationPatterns("1", []string{"file:1/*/*"})
+	assert.Nil(t, err)
+
+	err = state.ValidateDelegationPatterns("1", []string{"file:1/subpath1/*", "file:1/subpath2/*"})
+	assert.Nil(t, err)
+
+	err = state.ValidateDelegationPatterns("1", []string{"file:1/subpath1/*"})
+	assert.ErrorIs(t, err, ErrOrphanedRules)
+
+	// Rule 2 has no delegations of its own
+	err = state.ValidateDelegationPatterns("2", []string{"file:3/*"})
+	assert.Nil(t, err)
+}
+
+func TestValidateDelegationRemoval(t *testing.T) {
+	state := createTestStateWithDelegatedPolicies(t)
+
+	err := state.ValidateDelegationRemoval("1")
+	assert.ErrorIs(t, err, ErrOrphanedRules)
+
+	err = state.ValidateDelegationRemoval("2")
+	assert.Nil(t, err)
+}
+
 func TestAddKeyToTargets(t *testing.T) {
 	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
 	if err != nil {
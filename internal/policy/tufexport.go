@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const (
+	tufSnapshotRoleName  = "snapshot"
+	tufTimestampRoleName = "timestamp"
+)
+
+// tufMetadata is the format of a metadata file in a TUF repository.
+type tufMetadata struct {
+	Signed     map[string]any  `json:"signed"`
+	Signatures []*tufSignature `json:"signatures"`
+}
+
+type tufSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// ExportTUFMetadata renders the policy state recorded in the specified entry
+// as the metadata of a TUF repository, returning the contents of each metadata
+// file keyed by its name. Every version of the root metadata up to the entry is
+// included as "<version>.root.json" so that clients can update their trusted
+// root one version at a time. Each policy file is included both as
+// "<role>.json" and as "<version>.<role>.json", matching TUF's consistent
+// snapshots. As gittuf does not use the snapshot and timestamp roles, their
+// metadata is generated from the policy files and left unsigned. The version
+// of both is the entry's number in the RSL, and they expire with the earliest
+// expiring policy metadata.
+//
+// The signatures in the exported root and policy files are the signatures on
+// gittuf's DSSE envelopes. They are computed over DSSE's pre-authentication
+// encoding of the payload rather than the canonical JSON of the signed
+// metadata, so clients must account for this to verify them.
+func ExportTUFMetadata(ctx context.Context, repo *git.Repository, entry *rsl.ReferenceEntry) (map[string][]byte, error) {
+	slog.Debug("Loading policy state...")
+	state, err := LoadState(ctx, repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+
+	slog.Debug("Rendering root metadata history...")
+	rootEnvelopes, err := getRootEnvelopeHistory(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+	for version, env := range rootEnvelopes {
+		contents, err := renderTUFMetadata(env)
+		if err != nil {
+			return nil, err
+		}
+		files[fmt.Sprintf("%d.%s.json", version, RootRoleName)] = contents
+	}
+	rootContents, err := renderTUFMetadata(state.RootEnvelope)
+	if err != nil {
+		return nil, err
+	}
+	files[RootRoleName+".json"] = rootContents
+
+	slog.Debug("Rendering policy metadata...")
+	envelopes := state.envelopes()
+	snapshotMeta := map[string]any{}
+	for _, roleName := range state.policyNames() {
+		version, err := state.getMetadataVersion(roleName)
+		if err != nil {
+			return nil, err
+		}
+
+		contents, err := renderTUFMetadata(envelopes[roleName])
+		if err != nil {
+			return nil, err
+		}
+
+		fileName := url.PathEscape(roleName) + ".json"
+		files[fileName] = contents
+		files[fmt.Sprintf("%d.%s", version, fileName)] = contents
+		snapshotMeta[fileName] = map[string]any{"version": version}
+	}
+
+	expirations, err := state.GetMetadataExpirations()
+	if err != nil {
+		return nil, err
+	}
+	var expires time.Time
+	for _, expiration := range expirations {
+		if expires.IsZero() || expiration.Expires.Before(expires) {
+			expires = expiration.Expires
+		}
+	}
+	expiresString := ""
+	if !expires.IsZero() {
+		expiresString = expires.UTC().Format(time.RFC3339)
+	}
+
+	version := entry.Number
+	if version == 0 {
+		// The entry predates entry numbering
+		version = 1
+	}
+
+	slog.Debug("Generating snapshot and timestamp metadata...")
+	snapshotContents, err := marshalTUFMetadata(&tufMetadata{
+		Signed: map[string]any{
+			"_type":        tufSnapshotRoleName,
+			"spec_version": rootMetadata.SpecVersion,
+			"version":      version,
+			"expires":      expiresString,
+			"meta":         snapshotMeta,
+		},
+		Signatures: []*tufSignature{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	files[tufSnapshotRoleName+".json"] = snapshotContents
+	files[fmt.Sprintf("%d.%s.json", version, tufSnapshotRoleName)] = snapshotContents
+
+	snapshotHash := sha256.Sum256(snapshotContents)
+	timestampContents, err := marshalTUFMetadata(&tufMetadata{
+		Signed: map[string]any{
+			"_type":        tufTimestampRoleName,
+			"spec_version": rootMetadata.SpecVersion,
+			"version":      version,
+			"expires":      expiresString,
+			"meta": map[string]any{
+				tufSnapshotRoleName + ".json": map[string]any{
+					"version": version,
+					"length":  len(snapshotContents),
+					"hashes":  map[string]string{"sha256": hex.EncodeToString(snapshotHash[:])},
+				},
+			},
+		},
+		Signatures: []*tufSignature{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	files[tufTimestampRoleName+".json"] = timestampContents
+
+	return files, nil
+}
+
+// getRootEnvelopeHistory returns the envelope of every version of the root
+// metadata recorded in the policy up to the specified entry, keyed by version.
+func getRootEnvelopeHistory(repo *git.Repository, entry *rsl.ReferenceEntry) (map[int]*sslibdsse.Envelope, error) {
+	iterator, err := rsl.NewEntryIteratorWithFilter(repo, entry.RefName, rsl.ReferenceEntryType)
+	if err != nil {
+		return nil, err
+	}
+	if err := iterator.Seek(entry.ID); err != nil {
+		return nil, err
+	}
+
+	envelopes := map[int]*sslibdsse.Envelope{}
+	for {
+		policyEntry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		state, err := loadStateForEntry(repo, policyEntry.(*rsl.ReferenceEntry))
+		if err != nil {
+			return nil, err
+		}
+
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, has := envelopes[rootMetadata.Version]; !has {
+			envelopes[rootMetadata.Version] = state.RootEnvelope
+		}
+	}
+
+	return envelopes, nil
+}
+
+// renderTUFMetadata converts a gittuf metadata envelope into a TUF metadata
+// file. gittuf records the metadata type as "type" while TUF uses "_type", and
+// DSSE signatures are base64 encoded while TUF signatures are hex encoded.
+func renderTUFMetadata(env *sslibdsse.Envelope) ([]byte, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	signed := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&signed); err != nil {
+		return nil, err
+	}
+	if metadataType, has := signed["type"]; has {
+		delete(signed, "type")
+		signed["_type"] = metadataType
+	}
+
+	signatures := make([]*tufSignature, 0, len(env.Signatures))
+	for _, signature := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, &tufSignature{KeyID: signature.KeyID, Sig: hex.EncodeToString(sig)})
+	}
+
+	return marshalTUFMetadata(&tufMetadata{Signed: signed, Signatures: signatures})
+}
+
+func marshalTUFMetadata(metadata *tufMetadata) ([]byte, error) {
+	return json.MarshalIndent(metadata, "", "  ")
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportTUFMetadata(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+	// Record a second version of the root metadata
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata.SetVersion(rootMetadata.Version + 1)
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.RootEnvelope = rootEnv
+	if err := state.Commit(repo, "Update root", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(testCtx, repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ExportTUFMetadata(testCtx, repo, entry)
+	assert.Nil(t, err)
+
+	fileNames := []string{}
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+	assert.ElementsMatch(t, []string{
+		"1.root.json", "2.root.json", "root.json",
+		"targets.json", "1.targets.json",
+		fmt.Sprintf("%d.snapshot.json", entry.Number), "snapshot.json",
+		"timestamp.json",
+	}, fileNames)
+	assert.Equal(t, files["2.root.json"], files["root.json"])
+	assert.Equal(t, files["1.targets.json"], files["targets.json"])
+
+	root := &tufMetadata{}
+	if err := json.Unmarshal(files["root.json"], root); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "root", root.Signed["_type"])
+	assert.NotContains(t, root.Signed, "type")
+	assert.Len(t, root.Signatures, 1)
+	assert.Equal(t, rootEnv.Signatures[0].KeyID, root.Signatures[0].KeyID)
+
+	snapshot := &tufMetadata{}
+	if err := json.Unmarshal(files["snapshot.json"], snapshot); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "snapshot", snapshot.Signed["_type"])
+	assert.Equal(t, float64(entry.Number), snapshot.Signed["version"])
+	assert.Equal(t, map[string]any{
+		"targets.json": map[string]any{"version": float64(1)},
+	}, snapshot.Signed["meta"])
+	assert.Empty(t, snapshot.Signatures)
+
+	timestamp := &tufMetadata{}
+	if err := json.Unmarshal(files["timestamp.json"], timestamp); err != nil {
+		t.Fatal(err)
+	}
+	snapshotHash := sha256.Sum256(files["snapshot.json"])
+	assert.Equal(t, map[string]any{
+		"snapshot.json": map[string]any{
+			"version": float64(entry.Number),
+			"length":  float64(len(files["snapshot.json"])),
+			"hashes":  map[string]any{"sha256": hex.EncodeToString(snapshotHash[:])},
+		},
+	}, timestamp.Signed["meta"])
+	assert.Equal(t, snapshot.Signed["expires"], timestamp.Signed["expires"])
+}
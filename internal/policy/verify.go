@@ -7,9 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
@@ -46,6 +49,12 @@ var (
 	ErrUnknownObjectType       = errors.New("unknown object type passed to verify signature")
 	ErrInvalidVerifier         = errors.New("verifier has invalid parameters (is threshold 0?)")
 	ErrVerifierConditionsUnmet = errors.New("verifier's key and threshold constraints not met")
+	ErrRootChainBroken         = errors.New("chain of root metadata versions is broken")
+	ErrUntrustedInitialRoot    = errors.New("initial root of trust is not signed by a threshold of the trusted root keys")
+	ErrInvalidRootThreshold    = errors.New("threshold for trusted root keys must be between one and the number of keys")
+	ErrInconsistentGittufRefs  = errors.New("policy reference and RSL are inconsistent")
+	ErrUnverifiedPropagation   = errors.New("ref was last updated by a propagation entry, which must be followed by a reference entry for the ref")
+	ErrEntryTimeBeforeParent   = errors.New("RSL entry was recorded before its parent entry")
 )
 
 // VerifyRef verifies the signature on the latest RSL entry for the target ref
@@ -61,7 +70,44 @@ func VerifyRef(ctx context.Context, repo *git.Repository, target string) (plumbi
 
 	// Find latest entry for target
 	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
-	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, target)
+	latestEntry, err := getLatestReferenceEntryForRefWithoutPropagation(repo, target)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	// Find latest set of attestations
+	slog.Debug("Loading current set of attestations...")
+	attestationsState, err := attestations.LoadCurrentAttestations(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	progress := getProgressTracker(ctx)
+	progress.start(target, 1)
+	progress.entryReached(latestEntry.ID)
+
+	slog.Debug("Verifying entry...")
+	return latestEntry.TargetID, verifyEntry(ctx, repo, policyState, attestationsState, latestEntry)
+}
+
+// VerifyRefUsingExternalPolicy verifies the signature on the latest RSL entry
+// for the target ref using the latest policy recorded in a separate
+// repository. The policy state is loaded from policyRef in policyRepo's RSL
+// rather than from the repository being verified. This allows a single policy
+// to be maintained centrally and applied to many repositories. The expected Git
+// ID for the ref in the latest RSL entry is returned if the policy verification
+// is successful.
+func VerifyRefUsingExternalPolicy(ctx context.Context, repo, policyRepo *git.Repository, policyRef, target string) (plumbing.Hash, error) {
+	// Get latest policy entry from the external repository
+	slog.Debug(fmt.Sprintf("Loading policy from external repository's '%s'...", policyRef))
+	policyState, err := LoadCurrentState(ctx, policyRepo, policyRef)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	// Find latest entry for target
+	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
+	latestEntry, err := getLatestReferenceEntryForRefWithoutPropagation(repo, target)
 	if err != nil {
 		return plumbing.ZeroHash, err
 	}
@@ -73,6 +119,10 @@ func VerifyRef(ctx context.Context, repo *git.Repository, target string) (plumbi
 		return plumbing.ZeroHash, err
 	}
 
+	progress := getProgressTracker(ctx)
+	progress.start(target, 1)
+	progress.entryReached(latestEntry.ID)
+
 	slog.Debug("Verifying entry...")
 	return latestEntry.TargetID, verifyEntry(ctx, repo, policyState, attestationsState, latestEntry)
 }
@@ -90,15 +140,246 @@ func VerifyRefFull(ctx context.Context, repo *git.Repository, target string) (pl
 
 	// Find latest entry for target
 	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
-	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, target)
+	latestEntry, err := getLatestReferenceEntryForRefWithoutPropagation(repo, target)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Identifying initial policy and attestations entries...")
+	initialPolicyEntry, initialAttestationsEntry, err := getInitialEntries(repo, firstEntry)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	// Do a relative verify from start entry to the latest entry
+	slog.Debug("Verifying all entries...")
+	return latestEntry.TargetID, VerifyRelativeForRef(ctx, repo, initialPolicyEntry, initialAttestationsEntry, firstEntry, latestEntry, target)
+}
+
+// getInitialEntries returns the policy and attestations entries applicable at
+// the first entry of the RSL. Typically, the first entry is for the policy and
+// no attestations exist yet. If the RSL has been archived, the first entry is
+// the archive's checkpoint, and the policy and attestations entries recorded
+// in the archive are trusted instead.
+func getInitialEntries(repo *git.Repository, firstEntry *rsl.ReferenceEntry) (*rsl.ReferenceEntry, *rsl.ReferenceEntry, error) {
+	archive, err := rsl.LoadArchive(repo)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLArchiveNotFound) {
+			return firstEntry, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	policyEntry := archive.GetReferenceEntry(PolicyRef)
+	if policyEntry == nil {
+		return nil, nil, ErrPolicyNotFound
+	}
+
+	return policyEntry, archive.GetReferenceEntry(attestations.Ref), nil
+}
+
+// VerifyRefFullWithReport verifies the entire RSL for the target ref from the
+// first entry, like VerifyRefFull. In addition, a report capturing the result
+// for each entry and the policy states used is returned. The report is
+// returned even when verification fails, with the failure recorded in it. The
+// expected Git ID for the ref in the latest RSL entry is returned if the policy
+// verification is successful.
+func VerifyRefFullWithReport(ctx context.Context, repo *git.Repository, target string) (plumbing.Hash, *VerificationReport, error) {
+	report := NewVerificationReport(target)
+
+	// Trace RSL back to the start
+	slog.Debug("Identifying first RSL entry...")
+	firstEntry, _, err := rsl.GetFirstEntry(repo)
+	if err != nil {
+		report.SetResult(err)
+		return plumbing.ZeroHash, report, err
+	}
+
+	// Find latest entry for target
+	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
+	latestEntry, err := getLatestReferenceEntryForRefWithoutPropagation(repo, target)
+	if err != nil {
+		report.SetResult(err)
+		return plumbing.ZeroHash, report, err
+	}
+
+	slog.Debug("Identifying initial policy and attestations entries...")
+	initialPolicyEntry, initialAttestationsEntry, err := getInitialEntries(repo, firstEntry)
+	if err != nil {
+		report.SetResult(err)
+		return plumbing.ZeroHash, report, err
+	}
+
+	slog.Debug("Verifying all entries...")
+	_, err = verifyRelativeForRef(ctx, repo, initialPolicyEntry, initialAttestationsEntry, firstEntry, latestEntry, target, nil, report)
+	report.SetResult(err)
+	return latestEntry.TargetID, report, err
+}
+
+// VerifyRefFullUsingCache verifies the entire RSL for the target ref from the
+// first entry, like VerifyRefFull. Results are persisted in the repository's
+// verification cache, keyed by the entry, the policy state used to verify it,
+// and the attestations available at the time. Entries that were previously
+// verified under the same policy state and attestations are not verified
+// again. The first RSL entry and the latest entry for the ref are
+// identified using the repository's RSL index, which is updated as needed. The
+// expected Git ID for the ref in the latest RSL entry is returned if the policy
+// verification is successful.
+func VerifyRefFullUsingCache(ctx context.Context, repo *git.Repository, target string) (plumbing.Hash, error) {
+	slog.Debug("Loading verification cache...")
+	cache, err := LoadVerificationCache(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Updating RSL index...")
+	index, err := rsl.UpdateIndex(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Identifying first RSL entry...")
+	firstEntry, err := rsl.GetFirstEntryUsingIndex(repo, index)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	// Find latest entry for target
+	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
+	latestEntry, err := rsl.GetLatestReferenceEntryForRefUsingIndex(repo, index, target)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	latestRSLEntry, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := checkPropagationEntriesAfter(repo, target, latestEntry, latestRSLEntry.GetID()); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Identifying initial policy and attestations entries...")
+	initialPolicyEntry, initialAttestationsEntry, err := getInitialEntries(repo, firstEntry)
 	if err != nil {
 		return plumbing.ZeroHash, err
 	}
 
-	// Do a relative verify from start entry to the latest entry (firstEntry here == policyEntry)
-	// Also, attestations is initially nil because we haven't seen any yet
 	slog.Debug("Verifying all entries...")
-	return latestEntry.TargetID, VerifyRelativeForRef(ctx, repo, firstEntry, nil, firstEntry, latestEntry, target)
+	_, verificationErr := verifyRelativeForRef(ctx, repo, initialPolicyEntry, initialAttestationsEntry, firstEntry, latestEntry, target, cache, nil)
+
+	// Entries verified before any failure are still valid results, so the
+	// cache is persisted either way
+	slog.Debug("Updating verification cache...")
+	if err := cache.Commit(repo); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return latestEntry.TargetID, verificationErr
+}
+
+// VerifyRefIncremental verifies the RSL for the target ref starting from the
+// last entry previously verified for it, as recorded in the repository's
+// verification markers. If the ref has no marker, the marked entry cannot be
+// loaded or is no longer in the RSL, or the policy has changed since the marker
+// was recorded, the entire RSL is verified like VerifyRefFull. On success, the
+// ref's marker is updated to its latest entry. As verification waivers expire,
+// the marker is not moved past an entry whose failure was waived, so that the
+// entry is verified again the next time. The expected Git ID for the ref in the latest RSL entry
+// is returned if the policy verification is successful.
+func VerifyRefIncremental(ctx context.Context, repo *git.Repository, target string) (plumbing.Hash, error) {
+	slog.Debug("Loading verification markers...")
+	markers, err := LoadVerificationMarkers(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Identifying latest policy entry...")
+	latestPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return plumbing.ZeroHash, ErrPolicyNotFound
+		}
+		return plumbing.ZeroHash, err
+	}
+
+	// Find latest entry for target
+	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
+	latestEntry, err := getLatestReferenceEntryForRefWithoutPropagation(repo, target)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	var (
+		markedEntry       *rsl.ReferenceEntry
+		lastUnwaivedEntry *rsl.ReferenceEntry
+	)
+	if marker := markers.GetMarker(target, latestPolicyEntry.ID); marker != nil {
+		if marker.EntryID == latestEntry.ID.String() {
+			slog.Debug("Latest entry previously verified using current policy, skipping...")
+			return latestEntry.TargetID, nil
+		}
+
+		markedEntry, err = loadMarkedEntry(repo, target, marker)
+		if err != nil {
+			slog.Debug(fmt.Sprintf("Unable to load marked entry, verifying all entries: %s", err.Error()))
+			markedEntry = nil
+		}
+	}
+
+	if markedEntry == nil {
+		slog.Debug("Identifying first RSL entry...")
+		firstEntry, _, err := rsl.GetFirstEntry(repo)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		slog.Debug("Identifying initial policy and attestations entries...")
+		initialPolicyEntry, initialAttestationsEntry, err := getInitialEntries(repo, firstEntry)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		slog.Debug("Verifying all entries...")
+		lastUnwaivedEntry, err = verifyRelativeForRef(ctx, repo, initialPolicyEntry, initialAttestationsEntry, firstEntry, latestEntry, target, nil, nil)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	} else {
+		// The policy hasn't changed since the marker was recorded, so the
+		// latest policy entry is the one applicable at the marked entry
+		slog.Debug("Identifying applicable attestations entry...")
+		attestationsEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, attestations.Ref, markedEntry.ID)
+		if err != nil {
+			if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return plumbing.ZeroHash, err
+			}
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying entries from marked entry '%s'...", markedEntry.ID.String()))
+		lastUnwaivedEntry, err = verifyRelativeForRef(ctx, repo, latestPolicyEntry, attestationsEntry, markedEntry, latestEntry, target, nil, nil)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	if lastUnwaivedEntry == nil {
+		// The failure of the first entry verified was waived, so the marker
+		// can't be advanced
+		slog.Debug("Verification relied on waivers, not updating verification markers...")
+		return latestEntry.TargetID, nil
+	}
+	if lastUnwaivedEntry.ID != latestEntry.ID {
+		// Waivers expire, so the marker must not move past a waived entry
+		slog.Debug(fmt.Sprintf("Verification relied on waivers, setting marker to entry '%s'...", lastUnwaivedEntry.ID.String()))
+	}
+
+	slog.Debug("Updating verification markers...")
+	markers.SetMarker(target, lastUnwaivedEntry.ID, latestPolicyEntry.ID)
+	if err := markers.Commit(repo); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return latestEntry.TargetID, nil
 }
 
 // VerifyRefFromEntry performs verification for the reference from a specific
@@ -121,7 +402,7 @@ func VerifyRefFromEntry(ctx context.Context, repo *git.Repository, target string
 
 	// Find latest entry for target
 	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
-	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, target)
+	latestEntry, err := getLatestReferenceEntryForRefWithoutPropagation(repo, target)
 	if err != nil {
 		return plumbing.ZeroHash, err
 	}
@@ -147,50 +428,192 @@ func VerifyRefFromEntry(ctx context.Context, repo *git.Repository, target string
 	return latestEntry.TargetID, VerifyRelativeForRef(ctx, repo, policyEntry, attestationsEntry, fromEntry, latestEntry, target)
 }
 
+// VerifyRefAtEntry verifies the target ref as it was when the specified RSL
+// entry was recorded. The RSL is verified from the first entry up to the
+// latest entry for the ref at or before the specified entry, so the policy
+// states in effect at the time are used and later entries are ignored. The
+// expected Git ID for the ref as of the specified entry is returned if the
+// policy verification is successful.
+func VerifyRefAtEntry(ctx context.Context, repo *git.Repository, target string, entryID plumbing.Hash) (plumbing.Hash, error) {
+	slog.Debug(fmt.Sprintf("Identifying RSL entry for '%s' as of '%s'...", target, entryID.String()))
+	atEntry, err := getLatestReferenceEntryForRefAtOrBefore(repo, target, entryID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := checkPropagationEntriesAfter(repo, target, atEntry, entryID); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Identifying first RSL entry...")
+	firstEntry, _, err := rsl.GetFirstEntry(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Identifying initial policy and attestations entries...")
+	initialPolicyEntry, initialAttestationsEntry, err := getInitialEntries(repo, firstEntry)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Verifying all entries...")
+	return atEntry.TargetID, VerifyRelativeForRef(ctx, repo, initialPolicyEntry, initialAttestationsEntry, firstEntry, atEntry, target)
+}
+
+// LoadStateAtEntry returns the policy state that was in effect when the
+// specified RSL entry was recorded.
+func LoadStateAtEntry(ctx context.Context, repo *git.Repository, entryID plumbing.Hash) (*State, error) {
+	policyEntry, err := getLatestReferenceEntryForRefAtOrBefore(repo, PolicyRef, entryID)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, ErrPolicyNotFound
+		}
+		return nil, err
+	}
+
+	return LoadState(ctx, repo, policyEntry)
+}
+
+// getLatestReferenceEntryForRefAtOrBefore returns the latest reference entry
+// for refName that is either the specified entry itself or precedes it.
+func getLatestReferenceEntryForRefAtOrBefore(repo *git.Repository, refName string, entryID plumbing.Hash) (*rsl.ReferenceEntry, error) {
+	entry, err := rsl.GetEntry(repo, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry); isReferenceEntry && referenceEntry.RefName == refName {
+		return referenceEntry, nil
+	}
+
+	referenceEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, refName, entryID)
+	return referenceEntry, err
+}
+
+// getLatestReferenceEntryForRefWithoutPropagation returns the latest reference
+// entry for the target ref. An error is returned if a propagation entry for the
+// ref is recorded after it.
+func getLatestReferenceEntryForRefWithoutPropagation(repo *git.Repository, target string) (*rsl.ReferenceEntry, error) {
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, target)
+	if err != nil {
+		return nil, err
+	}
+
+	latestRSLEntry, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPropagationEntriesAfter(repo, target, latestEntry, latestRSLEntry.GetID()); err != nil {
+		return nil, err
+	}
+
+	return latestEntry, nil
+}
+
+// checkPropagationEntriesAfter returns ErrUnverifiedPropagation if a
+// propagation entry for the target ref is recorded after the specified
+// reference entry, up to and including the RSL entry with ID tipID. A
+// propagation entry updates the ref to the state recorded in an upstream
+// repository's RSL, which is not verified using this repository's policy. The
+// update is only accepted once a reference entry for the ref is recorded after
+// the propagation entry, as that entry is verified like any other.
+func checkPropagationEntriesAfter(repo *git.Repository, target string, entry *rsl.ReferenceEntry, tipID plumbing.Hash) error {
+	iterator, err := rsl.GetEntry(repo, tipID)
+	if err != nil {
+		return err
+	}
+
+	for iterator.GetID() != entry.ID {
+		if propagationEntry, isPropagationEntry := iterator.(*rsl.PropagationEntry); isPropagationEntry && propagationEntry.RefName == target {
+			return fmt.Errorf("%w: propagation entry '%s' for '%s'", ErrUnverifiedPropagation, propagationEntry.ID.String(), target)
+		}
+
+		iterator, err = rsl.GetParentForEntry(repo, iterator)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // VerifyRelativeForRef verifies the RSL between specified start and end entries
 // using the provided policy entry for the first entry.
 //
 // TODO: should the policy entry be inferred from the specified first entry?
 func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPolicyEntry, initialAttestationsEntry, firstEntry, lastEntry *rsl.ReferenceEntry, target string) error {
+	_, err := verifyRelativeForRef(ctx, repo, initialPolicyEntry, initialAttestationsEntry, firstEntry, lastEntry, target, nil, nil)
+	return err
+}
+
+// verifyRelativeForRef implements VerifyRelativeForRef. If cache is specified,
+// entries previously verified under the applicable policy state are not
+// verified again, and newly verified entries are recorded in the cache. If
+// report is specified, the result for each entry and the policy states used are
+// recorded in it. On success, the last entry for the target that passed
+// verification before the first entry whose failure was waived is returned.
+// This is the latest entry for the target if no failures were waived, and nil
+// if the failure of the first entry for the target was waived.
+func verifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPolicyEntry, initialAttestationsEntry, firstEntry, lastEntry *rsl.ReferenceEntry, target string, cache *VerificationCache, report *VerificationReport) (*rsl.ReferenceEntry, error) {
 	var (
-		currentPolicy       *State
-		currentAttestations *attestations.Attestations
+		currentPolicy         *State
+		currentPolicyID       plumbing.Hash
+		currentAttestations   *attestations.Attestations
+		currentAttestationsID plumbing.Hash
+
+		// lastUnwaivedEntry is updated until an entry's failure is waived
+		lastUnwaivedEntry *rsl.ReferenceEntry
+		waived            bool
 	)
 
 	// Load policy applicable at firstEntry
 	slog.Debug("Loading initial policy...")
 	state, err := LoadState(ctx, repo, initialPolicyEntry)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	currentPolicy = state
+	currentPolicyID = initialPolicyEntry.TargetID
+	report.addPolicy(currentPolicyID)
 
 	if initialAttestationsEntry != nil {
 		slog.Debug("Loading attestations...")
 		attestationsState, err := attestations.LoadAttestationsForEntry(repo, initialAttestationsEntry)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		currentAttestations = attestationsState
+		currentAttestationsID = initialAttestationsEntry.TargetID
 	}
 
 	// Enumerate RSL entries between firstEntry and lastEntry, ignoring irrelevant ones
 	slog.Debug("Identifying all entries in range...")
 	entries, annotations, err := rsl.GetReferenceEntriesInRangeForRef(repo, firstEntry.ID, lastEntry.ID, target)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	progress := getProgressTracker(ctx)
+	progress.start(target, len(entries))
+
 	// Verify each entry, looking for a fix when an invalid entry is encountered
 	var invalidEntry *rsl.ReferenceEntry
 	var verificationErr error
 	for len(entries) != 0 {
 		if invalidEntry == nil {
+			// Verification may be long-running, so stop if the caller is no
+			// longer interested
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
 			// Pop entry from queue
 			entry := entries[0]
 			entries = entries[1:]
 
 			slog.Debug(fmt.Sprintf("Verifying entry '%s'...", entry.ID.String()))
+			progress.entryReached(entry.ID)
 
 			slog.Debug("Checking if entry is for policy staging reference...")
 			if entry.RefName == PolicyStagingRef {
@@ -201,16 +624,18 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 				// TODO: this is repetition if the firstEntry is for policy
 				newPolicy, err := loadStateForEntry(repo, entry)
 				if err != nil {
-					return err
+					return nil, err
 				}
 
 				slog.Debug("Verifying new policy using current policy...")
 				if err := currentPolicy.VerifyNewState(ctx, newPolicy); err != nil {
-					return err
+					return nil, err
 				}
 
 				slog.Debug("Updating current policy...")
 				currentPolicy = newPolicy
+				currentPolicyID = entry.TargetID
+				report.addPolicy(currentPolicyID)
 				continue
 			}
 
@@ -218,19 +643,39 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 			if entry.RefName == attestations.Ref {
 				newAttestationsState, err := attestations.LoadAttestationsForEntry(repo, entry)
 				if err != nil {
-					return err
+					return nil, err
 				}
 
 				currentAttestations = newAttestationsState
+				currentAttestationsID = entry.TargetID
+				continue
+			}
+
+			if cache != nil && cache.IsVerified(entry.ID, currentPolicyID, currentAttestationsID) {
+				slog.Debug("Entry previously verified using current policy and attestations, skipping...")
+				report.addEntry(entry, currentPolicy, currentPolicyID, EntryStatusCached, nil)
+				if !waived {
+					lastUnwaivedEntry = entry
+				}
 				continue
 			}
 
 			slog.Debug("Verifying changes...")
-			if err := verifyEntry(ctx, repo, currentPolicy, currentAttestations, entry); err != nil {
+			waivedRules, err := verifyEntryWithWaivers(ctx, repo, currentPolicy, currentAttestations, entry)
+			if err != nil {
 				slog.Debug("Violation found, checking if entry has been revoked...")
 				// If the invalid entry is never marked as skipped, we return err
-				if !entry.SkippedBy(annotations[entry.ID]) {
-					return err
+				skipped, skipErr := newSkipResolverForPolicy(repo, currentPolicy).IsSkipped(ctx, entry, annotations[entry.ID])
+				if skipErr != nil {
+					return nil, skipErr
+				}
+				if !skipped {
+					report.addEntry(entry, currentPolicy, currentPolicyID, EntryStatusFailed, err)
+					return nil, err
+				}
+				report.addEntry(entry, currentPolicy, currentPolicyID, EntryStatusSkipped, err)
+				if !waived {
+					lastUnwaivedEntry = entry
 				}
 
 				// The invalid entry's been marked as skipped but we still need
@@ -241,7 +686,20 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 
 				if len(entries) == 0 {
 					// Fix entry does not exist after revoking annotation
-					return verificationErr
+					return nil, verificationErr
+				}
+			} else if len(waivedRules) != 0 {
+				// Waivers expire, so the entry is not cached as verified
+				report.addWaivedEntry(entry, currentPolicy, currentPolicyID, waivedRules)
+				waived = true
+			} else {
+				report.addEntry(entry, currentPolicy, currentPolicyID, EntryStatusVerified, nil)
+				if !waived {
+					lastUnwaivedEntry = entry
+				}
+				report.addEntrySigner(ctx, repo, currentPolicy, entry)
+				if cache != nil {
+					cache.SetVerified(entry.ID, currentPolicyID, currentAttestationsID)
 				}
 			}
 			continue
@@ -265,21 +723,29 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 
 		// 1. What's the last good state?
 		slog.Debug("Identifying last valid state...")
-		lastGoodEntry, lastGoodEntryAnnotations, err := rsl.GetLatestUnskippedReferenceEntryForRefBefore(repo, invalidEntry.RefName, invalidEntry.ID)
+		skipResolver := newSkipResolverForPolicy(repo, currentPolicy)
+		lastGoodEntry, lastGoodEntryAnnotations, err := skipResolver.GetLatestUnskippedReferenceEntryForRefBefore(ctx, invalidEntry.RefName, invalidEntry.ID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		slog.Debug("Verifying identified last valid entry has not been revoked...")
-		if lastGoodEntry.SkippedBy(lastGoodEntryAnnotations) {
-			return ErrLastGoodEntryIsSkipped
-		}
-		lastGoodEntryCommit, err := gitinterface.GetCommit(repo, lastGoodEntry.TargetID)
+		lastGoodEntrySkipped, err := skipResolver.IsSkipped(ctx, lastGoodEntry, lastGoodEntryAnnotations)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if lastGoodEntrySkipped {
+			return nil, ErrLastGoodEntryIsSkipped
 		}
 		// gittuf requires the fix to point to a commit that is tree-same as the
-		// last good state
-		lastGoodTreeID := lastGoodEntryCommit.TreeHash
+		// last good state. If the last good state is the ref's deletion, the
+		// fix must delete the ref again.
+		var lastGoodTreeID plumbing.Hash
+		if !lastGoodEntry.Deleted {
+			lastGoodTreeID, err = getTreeIDForFix(repo, lastGoodEntry.TargetID)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		// 2. What entries do we have in the current verification set for the
 		// ref? The first one that is tree-same as lastGoodEntry's commit is the
@@ -302,20 +768,32 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 				continue
 			}
 
-			newEntryCommit, err := gitinterface.GetCommit(repo, newEntry.TargetID)
-			if err != nil {
-				return err
+			if err := ctx.Err(); err != nil {
+				return nil, err
 			}
+			progress.entryReached(newEntry.ID)
 
 			slog.Debug("Checking if entry is tree-same with last valid state...")
-			if newEntryCommit.TreeHash == lastGoodTreeID {
+			isFix := lastGoodEntry.Deleted && newEntry.Deleted
+			if !newEntry.Deleted {
+				newEntryTreeID, err := getTreeIDForFix(repo, newEntry.TargetID)
+				if err != nil {
+					return nil, err
+				}
+				isFix = newEntryTreeID == lastGoodTreeID
+			}
+			if isFix {
 				// Fix found, we append the rest of the current verification set
 				// to the new entry queue
 				// But first, we must check that this fix hasn't been skipped
 				// If it has been skipped, it's not actually a fix and we need
 				// to keep looking
 				slog.Debug("Verifying potential fix entry has not been revoked...")
-				if !newEntry.SkippedBy(annotations[newEntry.ID]) {
+				skipped, err := skipResolver.IsSkipped(ctx, newEntry, annotations[newEntry.ID])
+				if err != nil {
+					return nil, err
+				}
+				if !skipped {
 					slog.Debug("Fix entry found, proceeding with regular verification workflow...")
 					fixed = true
 					newEntryQueue = append(newEntryQueue, entries...)
@@ -326,20 +804,27 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 			// newEntry is not tree-same / commit-same, so it is automatically
 			// invalid, check that it's been marked as revoked
 			slog.Debug("Checking non-fix entry has been revoked as well...")
-			if !newEntry.SkippedBy(annotations[newEntry.ID]) {
+			skipped, err := skipResolver.IsSkipped(ctx, newEntry, annotations[newEntry.ID])
+			if err != nil {
+				return nil, err
+			}
+			if !skipped {
 				invalidIntermediateEntries = append(invalidIntermediateEntries, newEntry)
+				report.addEntry(newEntry, currentPolicy, currentPolicyID, EntryStatusFailed, ErrInvalidEntryNotSkipped)
+			} else {
+				report.addEntry(newEntry, currentPolicy, currentPolicyID, EntryStatusSkipped, nil)
 			}
 		}
 
 		if !fixed {
 			// If we haven't found a fix, return the original error
-			return verificationErr
+			return nil, verificationErr
 		}
 
 		if len(invalidIntermediateEntries) != 0 {
 			// We may have found a fix but if an invalid intermediate entry
 			// wasn't skipped, return error
-			return ErrInvalidEntryNotSkipped
+			return nil, ErrInvalidEntryNotSkipped
 		}
 
 		// Reset these trackers to continue verification with rest of the queue
@@ -350,7 +835,29 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 		entries = newEntryQueue
 	}
 
-	return nil
+	return lastUnwaivedEntry, nil
+}
+
+// ObjectVerificationResult records the outcome of verifying the signature on
+// a single Git object using gittuf policy.
+type ObjectVerificationResult struct {
+	// ID is the identifier the object was requested with, such as a commit
+	// ID, a tag name, or a reference.
+	ID string `json:"id"`
+
+	// ObjectID is the ID of the Git object the identifier was resolved to. It
+	// is empty if the identifier could not be resolved.
+	ObjectID string `json:"objectID,omitempty"`
+
+	// Verified indicates if the object's signature was verified successfully.
+	Verified bool `json:"verified"`
+
+	// KeyID is the ID of the trusted key that the object's signature was
+	// verified with, if known.
+	KeyID string `json:"keyID,omitempty"`
+
+	// Message describes the outcome of verification.
+	Message string `json:"message"`
 }
 
 // VerifyCommit verifies the signature on the specified commits (identified by
@@ -364,85 +871,101 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 // use this function.
 func VerifyCommit(ctx context.Context, repo *git.Repository, ids ...string) map[string]string {
 	status := make(map[string]string, len(ids))
-	commits := make(map[string]*object.Commit, len(ids))
+	for _, result := range VerifyCommitObjects(ctx, repo, ids...) {
+		status[result.ID] = result.Message
+	}
 
-	for _, id := range ids {
-		if gitinterface.IsTag(repo, id) {
+	return status
+}
+
+// VerifyCommitObjects verifies the signature on the specified commits like
+// VerifyCommit. A structured result is returned for each of the submitted IDs,
+// in the order they were submitted.
+func VerifyCommitObjects(ctx context.Context, repo *git.Repository, ids ...string) []*ObjectVerificationResult {
+	results := make([]*ObjectVerificationResult, 0, len(ids))
+
+	for _, id := range ids {
+		result := &ObjectVerificationResult{ID: id}
+		results = append(results, result)
+
+		if gitinterface.IsTag(repo, id) {
 			// we do this because ResolveRevision returns a tag's commit object.
 			// For tags, we want to verify the signature on the tag object
 			// rather than the underlying commit.
-			status[id] = nonCommitMessage
+			result.Message = nonCommitMessage
 			continue
 		}
 
 		rev, err := repo.ResolveRevision(plumbing.Revision(id))
 		if err != nil {
-			status[id] = unableToResolveRevisionMessage
+			result.Message = unableToResolveRevisionMessage
 			continue
 		}
 		commit, err := gitinterface.GetCommit(repo, *rev)
 		if err != nil {
 			if errors.Is(err, plumbing.ErrObjectNotFound) {
-				status[id] = nonCommitMessage
+				result.Message = nonCommitMessage
 			} else {
-				status[id] = err.Error()
+				result.Message = err.Error()
 			}
 			continue
 		}
-		commits[id] = commit
+		result.ObjectID = commit.Hash.String()
+
+		verifyCommitObject(ctx, repo, commit, result)
 	}
 
-	for id, commit := range commits {
-		verified := false
-		if len(commit.PGPSignature) == 0 {
-			status[id] = noSignatureMessage
-			continue
-		}
+	return results
+}
 
-		commitPolicy, err := GetStateForCommit(ctx, repo, commit)
-		if err != nil {
-			status[id] = fmt.Sprintf(unableToLoadPolicyMessageFmt, err.Error())
-			continue
-		}
-		if commitPolicy == nil {
-			status[id] = unableToFindPolicyMessage
-			continue
-		}
+// verifyCommitObject verifies the commit's signature using the keys in the
+// policy applicable to the commit, recording the outcome in result.
+func verifyCommitObject(ctx context.Context, repo *git.Repository, commit *object.Commit, result *ObjectVerificationResult) {
+	if len(commit.PGPSignature) == 0 {
+		result.Message = noSignatureMessage
+		return
+	}
 
-		// TODO: Add `applyFilePolicies` flag that uses the commitPolicy to
-		// check that the commit signature is from a key trusted for all the
-		// paths modified by the commit.
+	commitPolicy, err := GetStateForCommit(ctx, repo, commit)
+	if err != nil {
+		result.Message = fmt.Sprintf(unableToLoadPolicyMessageFmt, err.Error())
+		return
+	}
+	if commitPolicy == nil {
+		result.Message = unableToFindPolicyMessage
+		return
+	}
 
-		keys, err := commitPolicy.PublicKeys()
-		if err != nil {
-			status[id] = fmt.Sprintf(unableToLoadPolicyMessageFmt, err.Error())
-			continue
-		}
-		for _, key := range keys {
-			err = gitinterface.VerifyCommitSignature(ctx, commit, key)
-			if err == nil {
-				verified = true
-				status[id] = fmt.Sprintf(goodSignatureMessageFmt, key.KeyType, key.KeyID)
-				break
-			}
+	// TODO: Add `applyFilePolicies` flag that uses the commitPolicy to
+	// check that the commit signature is from a key trusted for all the
+	// paths modified by the commit.
 
-			if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
-				// We encounter this for key types that can be used for gittuf
-				// policy metadata but not Git objects
-				continue
-			}
+	keys, err := commitPolicy.PublicKeys()
+	if err != nil {
+		result.Message = fmt.Sprintf(unableToLoadPolicyMessageFmt, err.Error())
+		return
+	}
+	for _, key := range keys {
+		err = gitinterface.VerifyCommitSignature(ctx, commit, key)
+		if err == nil {
+			result.Verified = true
+			result.KeyID = key.KeyID
+			result.Message = fmt.Sprintf(goodSignatureMessageFmt, key.KeyType, key.KeyID)
+			return
+		}
 
-			if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
-				status[id] = fmt.Sprintf(errorVerifyingSignatureMessageFmt, key.KeyType, key.KeyID, err.Error())
-			}
+		if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
+			// We encounter this for key types that can be used for gittuf
+			// policy metadata but not Git objects
+			continue
 		}
 
-		if !verified {
-			status[id] = noPublicKeyMessage
+		if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
+			result.Message = fmt.Sprintf(errorVerifyingSignatureMessageFmt, key.KeyType, key.KeyID, err.Error())
 		}
 	}
 
-	return status
+	result.Message = noPublicKeyMessage
 }
 
 // VerifyTag verifies the signature on the RSL entries for the specified tags.
@@ -451,18 +974,33 @@ func VerifyCommit(ctx context.Context, repo *git.Repository, ids ...string) map[
 // applicable policy are used to verify the signatures.
 func VerifyTag(ctx context.Context, repo *git.Repository, ids []string) map[string]string {
 	status := make(map[string]string, len(ids))
+	for _, result := range VerifyTagObjects(ctx, repo, ids) {
+		status[result.ID] = result.Message
+	}
+
+	return status
+}
+
+// VerifyTagObjects verifies the specified tags like VerifyTag. A structured
+// result is returned for each of the submitted IDs, in the order they were
+// submitted.
+func VerifyTagObjects(ctx context.Context, repo *git.Repository, ids []string) []*ObjectVerificationResult {
+	results := make([]*ObjectVerificationResult, 0, len(ids))
 
 	for _, id := range ids {
+		result := &ObjectVerificationResult{ID: id}
+		results = append(results, result)
+
 		// Check if id is tag name or hash of tag obj
 		absPath, err := gitinterface.AbsoluteReference(repo, id)
 		if err == nil {
 			if !strings.HasPrefix(absPath, gitinterface.TagRefPrefix) {
-				status[id] = nonTagMessage
+				result.Message = nonTagMessage
 				continue
 			}
 		} else {
 			if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
-				status[id] = err.Error()
+				result.Message = err.Error()
 				continue
 			}
 
@@ -470,7 +1008,7 @@ func VerifyTag(ctx context.Context, repo *git.Repository, ids []string) map[stri
 			// verifyTagEntry also finds the tag object, wasteful?
 			tagObj, err := gitinterface.GetTag(repo, plumbing.NewHash(id))
 			if err != nil {
-				status[id] = nonTagMessage
+				result.Message = nonTagMessage
 				continue
 			}
 			absPath = string(plumbing.NewTagReferenceName(tagObj.Name))
@@ -478,35 +1016,38 @@ func VerifyTag(ctx context.Context, repo *git.Repository, ids []string) map[stri
 
 		entry, _, err := rsl.GetLatestReferenceEntryForRef(repo, absPath)
 		if err != nil {
-			status[id] = unableToFindRSLEntryMessage
+			result.Message = unableToFindRSLEntryMessage
 			continue
 		}
+		result.ObjectID = entry.TargetID.String()
 
 		if _, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, absPath, entry.GetID()); err == nil {
-			status[id] = multipleTagRSLEntriesFoundMessage
+			result.Message = multipleTagRSLEntriesFoundMessage
 			continue
 		}
 
 		policyEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, PolicyRef, entry.ID)
 		if err != nil {
-			status[id] = fmt.Sprintf(unableToLoadPolicyMessageFmt, err.Error())
+			result.Message = fmt.Sprintf(unableToLoadPolicyMessageFmt, err.Error())
 			continue
 		}
 
 		policy, err := LoadState(ctx, repo, policyEntry)
 		if err != nil {
-			status[id] = fmt.Sprintf(unableToLoadPolicyMessageFmt, err.Error())
+			result.Message = fmt.Sprintf(unableToLoadPolicyMessageFmt, err.Error())
 			continue
 		}
 
-		if err := verifyTagEntry(ctx, repo, policy, entry); err == nil {
-			status[id] = goodTagSignatureMessage
-		} else {
-			status[id] = err.Error()
+		if err := verifyTagEntry(ctx, repo, policy, entry); err != nil {
+			result.Message = err.Error()
+			continue
 		}
+
+		result.Verified = true
+		result.Message = goodTagSignatureMessage
 	}
 
-	return status
+	return results
 }
 
 // VerifyNewState ensures that when a new policy is encountered, its root role
@@ -520,6 +1061,404 @@ func (s *State) VerifyNewState(ctx context.Context, newPolicy *State) error {
 	return rootVerifier.Verify(ctx, nil, newPolicy.RootEnvelope)
 }
 
+// FindEntriesOutOfPolicyScope returns the RSL reference entries recorded for
+// refs that are not covered by any rule in the policy applicable at the time
+// of the entry. Such entries are out of the policy's scope, which is distinct
+// from being unauthorized: no rule restricts who may update the ref. This
+// allows identifying entries recorded for arbitrary ref names, e.g., to add
+// noise to the RSL. Entries for gittuf namespaces and entries recorded before
+// the first policy are not considered.
+func FindEntriesOutOfPolicyScope(ctx context.Context, repo *git.Repository) ([]*rsl.ReferenceEntry, error) {
+	firstPolicyEntry, _, err := rsl.GetFirstReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, ErrPolicyNotFound
+		}
+		return nil, err
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, _, err := rsl.GetReferenceEntriesInRangeForRef(repo, firstPolicyEntry.ID, latestEntry.GetID(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	currentPolicy, err := LoadState(ctx, repo, firstPolicyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	outOfScopeEntries := []*rsl.ReferenceEntry{}
+	for _, entry := range entries[1:] {
+		if entry.RefName == PolicyRef {
+			newPolicy, err := loadStateForEntry(repo, entry)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := currentPolicy.VerifyNewState(ctx, newPolicy); err != nil {
+				return nil, err
+			}
+
+			currentPolicy = newPolicy
+			continue
+		}
+
+		if strings.HasPrefix(entry.RefName, "refs/gittuf/") {
+			continue
+		}
+
+		unprotectedRefs, err := currentPolicy.FindUnprotectedRefs([]string{entry.RefName})
+		if err != nil {
+			return nil, err
+		}
+		if len(unprotectedRefs) != 0 {
+			slog.Debug(fmt.Sprintf("Entry '%s' for '%s' is out of policy scope", entry.ID.String(), entry.RefName))
+			outOfScopeEntries = append(outOfScopeEntries, entry)
+		}
+	}
+
+	return outOfScopeEntries, nil
+}
+
+// VerifyGittufRefsConsistency checks that the policy reference and the RSL
+// agree with each other. This catches a remote that serves a policy reference
+// and an RSL from different histories. Every RSL entry for the policy reference
+// must record a commit that exists on the policy reference, with the latest
+// entry recording its current tip. Conversely, every commit on the policy
+// reference must be recorded in the RSL for either the policy or the policy
+// staging reference. The first inconsistency found is returned.
+func VerifyGittufRefsConsistency(repo *git.Repository) error {
+	policyTip := plumbing.ZeroHash
+	ref, err := repo.Reference(plumbing.ReferenceName(PolicyRef), true)
+	if err != nil {
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return err
+		}
+	} else {
+		policyTip = ref.Hash()
+	}
+
+	// Collect all policy entries in the RSL, latest first
+	policyEntries := []*rsl.ReferenceEntry{}
+	recordedPolicyCommits := map[plumbing.Hash]bool{}
+
+	iteratorT, err := rsl.GetLatestEntry(repo)
+	if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) && !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return err
+	}
+	for iteratorT != nil {
+		if entry, isReferenceEntry := iteratorT.(*rsl.ReferenceEntry); isReferenceEntry {
+			switch entry.RefName {
+			case PolicyRef:
+				policyEntries = append(policyEntries, entry)
+				recordedPolicyCommits[entry.TargetID] = true
+			case PolicyStagingRef:
+				recordedPolicyCommits[entry.TargetID] = true
+			}
+		}
+
+		iteratorT, err = rsl.GetParentForEntry(repo, iteratorT)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return err
+		}
+	}
+
+	if len(policyEntries) == 0 {
+		if !policyTip.IsZero() {
+			return fmt.Errorf("%w: policy reference points to '%s' but RSL has no entries for policy", ErrInconsistentGittufRefs, policyTip.String())
+		}
+		return nil
+	}
+
+	if policyEntries[0].TargetID != policyTip {
+		return fmt.Errorf("%w: latest RSL entry '%s' for policy records '%s' but policy reference points to '%s'", ErrInconsistentGittufRefs, policyEntries[0].ID.String(), policyEntries[0].TargetID.String(), policyTip.String())
+	}
+
+	policyTipCommit, err := gitinterface.GetCommit(repo, policyTip)
+	if err != nil {
+		return fmt.Errorf("%w: unable to load policy reference tip '%s': %w", ErrInconsistentGittufRefs, policyTip.String(), err)
+	}
+
+	for _, entry := range policyEntries[1:] {
+		entryCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
+		if err != nil {
+			return fmt.Errorf("%w: RSL entry '%s' for policy records '%s' which is not present in the repository", ErrInconsistentGittufRefs, entry.ID.String(), entry.TargetID.String())
+		}
+
+		knows, err := gitinterface.KnowsCommit(repo, policyTip, entryCommit)
+		if err != nil {
+			return err
+		}
+		if !knows {
+			return fmt.Errorf("%w: RSL entry '%s' for policy records '%s' which is not on policy reference with tip '%s'", ErrInconsistentGittufRefs, entry.ID.String(), entry.TargetID.String(), policyTip.String())
+		}
+	}
+
+	// Walk the policy reference's history, each commit must be recorded in
+	// the RSL
+	iterator := policyTipCommit
+	for {
+		if !recordedPolicyCommits[iterator.Hash] {
+			return fmt.Errorf("%w: commit '%s' on policy reference with tip '%s' is not recorded in RSL", ErrInconsistentGittufRefs, iterator.Hash.String(), policyTip.String())
+		}
+
+		if len(iterator.ParentHashes) == 0 {
+			break
+		}
+
+		parentID := iterator.ParentHashes[0]
+		iterator, err = gitinterface.GetCommit(repo, parentID)
+		if err != nil {
+			return fmt.Errorf("%w: unable to load commit '%s' on policy reference with tip '%s': %w", ErrInconsistentGittufRefs, parentID.String(), policyTip.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyRootChain walks the sequence of root metadata versions recorded in the
+// policy namespace and verifies that each new version is signed by a threshold
+// of the keys trusted in the prior version. The chain is anchored at the root
+// in the first policy entry. If pinnedRootKeys is specified, the initial root
+// must be signed by at least pinnedThreshold of those keys. The threshold
+// declared by the initial root is not used for this check, as the initial root
+// is not yet trusted. Otherwise, the initial root is trusted on first use. If
+// the chain is broken, the returned error identifies the root version at which
+// verification failed.
+func VerifyRootChain(ctx context.Context, repo *git.Repository, pinnedRootKeys []*tuf.Key, pinnedThreshold int) error {
+	firstPolicyEntry, _, err := rsl.GetFirstReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		return err
+	}
+
+	latestPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		return err
+	}
+
+	policyEntries, _, err := rsl.GetReferenceEntriesInRangeForRef(repo, firstPolicyEntry.ID, latestPolicyEntry.ID, PolicyRef)
+	if err != nil {
+		return err
+	}
+
+	verifiedState, err := loadStateForEntry(repo, firstPolicyEntry)
+	if err != nil {
+		return err
+	}
+	verifiedRootMetadata, err := verifiedState.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+
+	if len(pinnedRootKeys) > 0 {
+		slog.Debug("Verifying initial root of trust using pinned root keys...")
+		if err := verifyRootSignedByKeys(ctx, verifiedState, pinnedRootKeys, pinnedThreshold); err != nil {
+			return fmt.Errorf("%w: initial root version %d is not signed by pinned root keys: %w", ErrRootChainBroken, verifiedRootMetadata.Version, err)
+		}
+	} else {
+		slog.Debug(fmt.Sprintf("Trusting root of trust for initial policy '%s'...", firstPolicyEntry.ID))
+	}
+
+	_, err = verifyRootChainFrom(ctx, repo, verifiedState, policyEntries[1:])
+	return err
+}
+
+// verifyRootChainFrom verifies the roots of trust in the policy entries, in
+// order of occurrence, starting from the trusted root in verifiedState. Entries
+// for other refs are ignored. The state of the last policy entry is returned.
+func verifyRootChainFrom(ctx context.Context, repo *git.Repository, verifiedState *State, entries []*rsl.ReferenceEntry) (*State, error) {
+	verifiedRootMetadata, err := verifiedState.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.RefName != PolicyRef {
+			continue
+		}
+
+		underTestState, err := loadStateForEntry(repo, entry)
+		if err != nil {
+			return nil, err
+		}
+		underTestRootMetadata, err := underTestState.GetRootMetadata()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case underTestRootMetadata.Version < verifiedRootMetadata.Version:
+			return nil, fmt.Errorf("%w: root version %d in policy '%s' is older than trusted root version %d", ErrRootChainBroken, underTestRootMetadata.Version, entry.ID.String(), verifiedRootMetadata.Version)
+		case underTestRootMetadata.Version == verifiedRootMetadata.Version:
+			if underTestState.RootEnvelope.Payload != verifiedState.RootEnvelope.Payload {
+				return nil, fmt.Errorf("%w: root version %d in policy '%s' was modified without incrementing its version", ErrRootChainBroken, underTestRootMetadata.Version, entry.ID.String())
+			}
+
+			// The root is unchanged, so there's nothing to verify
+			verifiedState = underTestState
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying root version %d in policy '%s'...", underTestRootMetadata.Version, entry.ID.String()))
+		if err := verifiedState.VerifyNewState(ctx, underTestState); err != nil {
+			return nil, fmt.Errorf("%w: root version %d in policy '%s' is not signed by threshold of root version %d: %w", ErrRootChainBroken, underTestRootMetadata.Version, entry.ID.String(), verifiedRootMetadata.Version, err)
+		}
+
+		verifiedState = underTestState
+		verifiedRootMetadata = underTestRootMetadata
+	}
+
+	return verifiedState, nil
+}
+
+// VerifyNewGittufEntries verifies the policy and attestations entries among
+// the specified reference entries, which are expected to be the entries
+// recorded after the RSL's last trusted entry, in order of occurrence. The
+// roots of trust of the new policy states are verified starting from the latest
+// policy entry that precedes the new entries, and each new state must be
+// validly signed. If no policy entry precedes the new entries, the root of
+// trust of the first new policy state is trusted on first use. Each new
+// attestations entry is verified using the rules protecting the attestations
+// reference in the policy applicable at the entry.
+func VerifyNewGittufEntries(ctx context.Context, repo *git.Repository, entries []*rsl.ReferenceEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var verifiedState *State
+	trustedPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, PolicyRef, entries[0].ID)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return err
+		}
+	} else {
+		verifiedState, err = loadStateForEntry(repo, trustedPolicyEntry)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Deleted && rsl.IsGittufRef(entry.RefName) {
+			return fmt.Errorf("%w: entry '%s' records the deletion of '%s'", rsl.ErrCannotDeleteGittufRef, entry.ID.String(), entry.RefName)
+		}
+
+		switch entry.RefName {
+		case PolicyRef:
+			if verifiedState == nil {
+				slog.Debug(fmt.Sprintf("Trusting root of trust for initial policy '%s'...", entry.ID.String()))
+				verifiedState, err = loadStateForEntry(repo, entry)
+			} else {
+				verifiedState, err = verifyRootChainFrom(ctx, repo, verifiedState, []*rsl.ReferenceEntry{entry})
+			}
+			if err != nil {
+				return err
+			}
+
+			slog.Debug(fmt.Sprintf("Verifying metadata in policy '%s'...", entry.ID.String()))
+			if err := verifiedState.Verify(ctx); err != nil {
+				return fmt.Errorf("policy '%s' has invalidly signed metadata: %w", entry.ID.String(), err)
+			}
+
+		case attestations.Ref:
+			slog.Debug(fmt.Sprintf("Verifying attestations '%s'...", entry.ID.String()))
+			if _, err := attestations.LoadAttestationsForEntry(repo, entry); err != nil {
+				return err
+			}
+
+			if verifiedState == nil {
+				// There's no policy to verify the entry against
+				continue
+			}
+			if err := verifyAttestationsEntry(ctx, repo, verifiedState, entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// VerifyInitialRoot verifies that the root of trust in the first policy entry
+// in the RSL is signed by at least threshold of trustedRootKeys. The keys and
+// threshold are expected to be obtained out-of-band, as the initial root is
+// not yet trusted to declare them. This authenticates the starting point of
+// verification, which is otherwise trusted on first use.
+func VerifyInitialRoot(ctx context.Context, repo *git.Repository, trustedRootKeys []*tuf.Key, threshold int) error {
+	initialState, initialRootMetadata, err := loadInitialRoot(repo)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyRootSignedByKeys(ctx, initialState, trustedRootKeys, threshold); err != nil {
+		return fmt.Errorf("%w: initial root version %d: %w", ErrUntrustedInitialRoot, initialRootMetadata.Version, err)
+	}
+
+	return nil
+}
+
+// GetInitialRootKeys returns the keys and threshold trusted for the root of
+// trust in the first policy entry in the RSL. This is used to pin the initial
+// root keys when they are trusted on first use.
+func GetInitialRootKeys(repo *git.Repository) ([]*tuf.Key, int, error) {
+	initialState, initialRootMetadata, err := loadInitialRoot(repo)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rootKeys, err := initialState.GetRootKeys()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rootKeys, initialRootMetadata.Roles[RootRoleName].Threshold, nil
+}
+
+// loadInitialRoot returns the state and root metadata for the first policy
+// entry in the RSL.
+func loadInitialRoot(repo *git.Repository) (*State, *tuf.RootMetadata, error) {
+	firstPolicyEntry, _, err := rsl.GetFirstReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	initialState, err := loadStateForEntry(repo, firstPolicyEntry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	initialRootMetadata, err := initialState.GetRootMetadata()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return initialState, initialRootMetadata, nil
+}
+
+// verifyRootSignedByKeys verifies that the state's root envelope is signed by
+// at least threshold of the specified keys. The threshold is supplied by the
+// caller alongside the keys rather than read from the root being verified.
+func verifyRootSignedByKeys(ctx context.Context, state *State, keys []*tuf.Key, threshold int) error {
+	if threshold < 1 || threshold > len(keys) {
+		return fmt.Errorf("%w: threshold %d for %d keys", ErrInvalidRootThreshold, threshold, len(keys))
+	}
+
+	verifier := &Verifier{
+		keys:      keys,
+		threshold: threshold,
+	}
+
+	return verifier.Verify(ctx, nil, state.RootEnvelope)
+}
+
 // verifyEntry is a helper to verify an entry's signature using the specified
 // policy. The specified policy is used for the RSL entry itself. However, for
 // commit signatures, verifyEntry checks when the commit was first introduced
@@ -527,23 +1466,140 @@ func (s *State) VerifyNewState(ctx context.Context, newPolicy *State) error {
 // commit's first entry into the repository. If the commit is brand new to the
 // repository, the specified policy is used.
 func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) error {
+	_, err := verifyEntryWithWaivers(ctx, repo, policy, attestationsState, entry)
+	return err
+}
+
+// withEntryVerificationTime returns a copy of ctx in which the expiration of
+// GPG keys and X.509 certificates is evaluated at the time the entry was
+// recorded in the RSL, rather than at the time claimed by the signatures being
+// verified. The RSL has no trusted timestamp: the entry's committer time is
+// chosen by whoever recorded the entry. It is only bounded by its parent
+// entry's committer time, as an entry that claims to be recorded before its
+// parent is rejected, and by the current time, as an entry that claims to be
+// recorded in the future is evaluated at the current time. As the committer
+// time can still be set to any time in between, revocations are always
+// evaluated at the current time regardless of the time recorded in ctx.
+//
+// Deterministic entries record timestamps derived from the Unix epoch rather
+// than the time they were created, so they are evaluated at the current time
+// when rsl.DeterministicEntries is set or the entry was committed using the
+// deterministic identity.
+func withEntryVerificationTime(ctx context.Context, repo *git.Repository, entry *rsl.ReferenceEntry) (context.Context, error) {
+	entryCommit, err := gitinterface.GetCommit(repo, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entryCommit.ParentHashes) > 0 && !entryCommit.ParentHashes[0].IsZero() {
+		parentCommit, err := gitinterface.GetCommit(repo, entryCommit.ParentHashes[0])
+		if err != nil {
+			return nil, err
+		}
+
+		if entryCommit.Committer.When.Before(parentCommit.Committer.When) {
+			return nil, fmt.Errorf("%w: entry '%s' was recorded at '%s', but its parent entry '%s' was recorded at '%s'", ErrEntryTimeBeforeParent, entry.ID.String(), entryCommit.Committer.When.String(), parentCommit.Hash.String(), parentCommit.Committer.When.String())
+		}
+	}
+
+	if rsl.DeterministicEntries || (entryCommit.Committer.Name == rsl.DeterministicEntryName && entryCommit.Committer.Email == rsl.DeterministicEntryEmail) {
+		return ctx, nil
+	}
+
+	verifyAt := entryCommit.Committer.When
+	if now := time.Now(); verifyAt.After(now) {
+		verifyAt = now
+	}
+
+	return gitinterface.WithSignatureVerificationTime(ctx, verifyAt), nil
+}
+
+// verifyEntryWithWaivers is like verifyEntry, but additionally returns the
+// names of the rules whose failure was accepted for the entry using a
+// verification waiver. A rule's failure is only waived once none of the
+// entry's verifiers are met.
+func verifyEntryWithWaivers(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) ([]string, error) {
+	if entry.Deleted && rsl.IsGittufRef(entry.RefName) {
+		return nil, fmt.Errorf("%w: entry '%s' records the deletion of '%s'", rsl.ErrCannotDeleteGittufRef, entry.ID.String(), entry.RefName)
+	}
+
 	if entry.RefName == PolicyRef || entry.RefName == attestations.Ref {
-		return nil
+		return nil, nil
+	}
+
+	ctx, err := withEntryVerificationTime(ctx, repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Deleted {
+		return nil, verifyDeletionEntry(ctx, repo, policy, entry)
 	}
 
 	if strings.HasPrefix(entry.RefName, gitinterface.TagRefPrefix) {
-		return verifyTagEntry(ctx, repo, policy, entry)
+		return nil, verifyTagEntry(ctx, repo, policy, entry)
 	}
 
+	waivedRules, err := verifyEntrySignaturesWithWaivers(ctx, repo, policy, attestationsState, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// The commits introduced by the entry must meet the commit constraints of
+	// the rules protecting the ref
+	waivedConstraintRules, err := verifyEntryCommitConstraints(ctx, repo, policy, entry)
+	if err != nil {
+		return nil, err
+	}
+	for _, waivedRule := range waivedConstraintRules {
+		if !slices.Contains(waivedRules, waivedRule) {
+			waivedRules = append(waivedRules, waivedRule)
+		}
+	}
+
+	// The entry's change must also pass the in-toto layouts of the rules
+	// protecting it
+	waivedLayoutRules, err := verifyEntryInTotoLayouts(ctx, repo, policy, attestationsState, entry)
+	if err != nil {
+		return nil, err
+	}
+	for _, waivedRule := range waivedLayoutRules {
+		if !slices.Contains(waivedRules, waivedRule) {
+			waivedRules = append(waivedRules, waivedRule)
+		}
+	}
+
+	// Finally, the entry's change must be allowed by the engine policies of
+	// the rules protecting it
+	waivedEngineRules, err := verifyEntryEnginePolicies(ctx, repo, policy, attestationsState, entry)
+	if err != nil {
+		return nil, err
+	}
+	for _, waivedRule := range waivedEngineRules {
+		if !slices.Contains(waivedRules, waivedRule) {
+			waivedRules = append(waivedRules, waivedRule)
+		}
+	}
+
+	return waivedRules, nil
+}
+
+// verifyEntrySignaturesWithWaivers verifies that the entry and the commits it
+// introduces are signed as required by the rules protecting the entry's ref
+// and the files it changes. The names of the rules whose failure was waived
+// are returned.
+func verifyEntrySignaturesWithWaivers(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) ([]string, error) {
+
 	var (
 		gitNamespaceVerified  = false
 		pathNamespaceVerified = true // Assume paths are verified until we find out otherwise
+		waivedRules           = []string{}
 	)
 
 	// Find authorized verifiers for entry's ref
 	verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// No verifiers => no restrictions for the git namespace
@@ -554,52 +1610,115 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 	// Find commit object for the RSL entry
 	commitObj, err := gitinterface.GetCommit(repo, entry.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var authorizationAttestation *sslibdsse.Envelope
 	if attestationsState != nil {
 		authorizationAttestation, err = getAuthorizationAttestation(repo, attestationsState, entry)
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+
+	if authorizationAttestation == nil && requiresMultipleSignatures(verifiers) {
+		authorizationAttestation, err = getRSLEntryCoSignature(repo, entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var approverKeyIDs []string
+	if attestationsState != nil {
+		approverKeyIDs, err = getGitHubPullRequestApprovers(ctx, repo, policy, attestationsState, entry)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	// Use each verifier to verify signature
 	for _, verifier := range verifiers {
-		err := verifier.Verify(ctx, commitObj, authorizationAttestation)
+		err := verifier.verifyWithApprovers(ctx, commitObj, authorizationAttestation, approverKeyIDs)
 		if err == nil {
 			// Signature verification succeeded
 			gitNamespaceVerified = true
 			break
 		} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
 			// Unexpected error
-			return err
+			return nil, err
 		}
 		// Haven't found a valid verifier, continue with next
 	}
 
 	if !gitNamespaceVerified {
-		return fmt.Errorf("verifying Git namespace policies failed, %w", ErrUnauthorizedSignature)
+		waivedRule, err := findWaivedRule(ctx, repo, policy, entry, verifiers)
+		if err != nil {
+			return nil, err
+		}
+		if waivedRule == "" {
+			return nil, fmt.Errorf("verifying Git namespace policies failed, %w", ErrUnauthorizedSignature)
+		}
+		waivedRules = append(waivedRules, waivedRule)
 	}
 
-	hasFileRule, err := policy.hasFileRule()
-	if err != nil {
-		return err
+	// Notes refs record notes about other objects in trees keyed by object
+	// ID rather than the repository's files, so file rules don't apply to them
+	hasFileRule := false
+	if !strings.HasPrefix(entry.RefName, gitinterface.NotesRefPrefix) {
+		hasFileRule, err = policy.hasFileRule()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if !hasFileRule {
-		return nil
+	commitSignatureVerifiers := getCommitSignatureVerifiers(verifiers)
+
+	if !hasFileRule && len(commitSignatureVerifiers) == 0 {
+		return waivedRules, nil
 	}
 
-	// Verify modified files
+	// Refs such as replace refs may point to trees and blobs, which have no
+	// commits to verify
+	targetType, err := gitinterface.GetObjectType(repo, entry.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if targetType != plumbing.CommitObject {
+		return waivedRules, nil
+	}
 
-	// First, get all commits between the current and last entry for the ref.
+	// Get all commits between the current and last entry for the ref.
 	commits, err := getCommits(repo, entry) // note: this is ordered by commit ID
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	// Verify commit signatures if the ref's rules require it
+	if len(commitSignatureVerifiers) > 0 {
+		if err := verifyCommitSignatures(ctx, commitSignatureVerifiers, commits); err != nil {
+			if !errors.Is(err, ErrUnauthorizedSignature) {
+				return nil, err
+			}
+
+			waivedRule, waiverErr := findWaivedRule(ctx, repo, policy, entry, commitSignatureVerifiers)
+			if waiverErr != nil {
+				return nil, waiverErr
+			}
+			if waivedRule == "" {
+				return nil, err
+			}
+			if !slices.Contains(waivedRules, waivedRule) {
+				waivedRules = append(waivedRules, waivedRule)
+			}
+		}
+	}
+
+	if !hasFileRule {
+		return waivedRules, nil
+	}
+
+	// Verify modified files
+
 	commitsVerified := make([]bool, len(commits))
 	for i, commit := range commits {
 		// Assume the commit's paths are verified, if a path is left unverified,
@@ -608,15 +1727,15 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 
 		paths, err := gitinterface.GetFilePathsChangedByCommit(repo, commit)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		pathsVerified := make([]bool, len(paths))
-		verifiedUsing := "" // this will be set after one successful verification of the commit to avoid repeated signature verification
+		var verifiedUsing *Verifier // this will be set after one successful verification of the commit to avoid repeated signature verification
 		for j, path := range paths {
 			verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", fileRuleScheme, path))
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			if len(verifiers) == 0 {
@@ -624,18 +1743,16 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 				continue
 			}
 
-			if len(verifiedUsing) > 0 {
+			if verifiedUsing != nil {
 				// We've already verified and identified commit signature, we
 				// can just check if that verifier is trusted for the new path.
-				// If not found, we don't make any assumptions about it being a
-				// failure in case of name mismatches. So, the signature check
-				// proceeds as usual.
-				//
-				// FIXME: this is probably a vuln as a rule name may re-occur
-				// without being met by a target delegation in different
-				// policies
+				// The verifiers must match in name as well as in keys and
+				// threshold so that a rule name re-occurring with different
+				// constraints isn't treated as already met. If not found, we
+				// don't make any assumptions about it being a failure. So, the
+				// signature check proceeds as usual.
 				for _, verifier := range verifiers {
-					if verifier.Name() == verifiedUsing {
+					if verifier.equals(verifiedUsing) {
 						pathsVerified[j] = true
 						break
 					}
@@ -647,15 +1764,28 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 			}
 
 			for _, verifier := range verifiers {
-				err := verifier.Verify(ctx, commit, authorizationAttestation)
+				err := verifier.verifyWithApprovers(ctx, commit, authorizationAttestation, approverKeyIDs)
 				if err == nil {
 					// Signature verification succeeded
 					pathsVerified[j] = true
-					verifiedUsing = verifier.Name()
+					verifiedUsing = verifier
 					break
 				} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
 					// Unexpected error
-					return err
+					return nil, err
+				}
+			}
+
+			if !pathsVerified[j] {
+				waivedRule, err := findWaivedRule(ctx, repo, policy, entry, verifiers)
+				if err != nil {
+					return nil, err
+				}
+				if waivedRule != "" {
+					pathsVerified[j] = true
+					if !slices.Contains(waivedRules, waivedRule) {
+						waivedRules = append(waivedRules, waivedRule)
+					}
 				}
 			}
 		}
@@ -680,12 +1810,88 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 	}
 
 	if !pathNamespaceVerified {
-		return fmt.Errorf("verifying file namespace policies failed, %w", ErrUnauthorizedSignature)
+		return nil, fmt.Errorf("verifying file namespace policies failed, %w", ErrUnauthorizedSignature)
+	}
+
+	return waivedRules, nil
+}
+
+// verifyDeletionEntry verifies an entry that records the deletion of a ref. As
+// a deletion introduces no commits, only the entry's signature is verified
+// against the verifiers for the ref. A ref recorded again after its deletion
+// is verified as though it were the ref's first entry, as the deletion
+// entry's target is the zero hash.
+func verifyDeletionEntry(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) error {
+	verified, err := verifyEntryUsingRefRules(ctx, repo, policy, entry)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return fmt.Errorf("verifying deletion of '%s' failed, %w", entry.RefName, ErrUnauthorizedSignature)
 	}
 
 	return nil
 }
 
+// verifyAttestationsEntry verifies the entry for the attestations reference
+// using the rules protecting the reference in the policy. If no rules protect
+// the reference, the entry is not restricted.
+func verifyAttestationsEntry(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) error {
+	ctx, err := withEntryVerificationTime(ctx, repo, entry)
+	if err != nil {
+		return err
+	}
+
+	verified, err := verifyEntryUsingRefRules(ctx, repo, policy, entry)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return fmt.Errorf("verifying attestations entry '%s' failed, %w", entry.ID.String(), ErrUnauthorizedSignature)
+	}
+
+	return nil
+}
+
+// verifyEntryUsingRefRules checks if the entry is signed as required by one of
+// the rules protecting the entry's ref. If no rules protect the ref, the entry
+// is considered verified.
+func verifyEntryUsingRefRules(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) (bool, error) {
+	verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+	if err != nil {
+		return false, err
+	}
+
+	// No verifiers => no restrictions for the git namespace
+	if len(verifiers) == 0 {
+		return true, nil
+	}
+
+	commitObj, err := gitinterface.GetCommit(repo, entry.ID)
+	if err != nil {
+		return false, err
+	}
+
+	var coSignature *sslibdsse.Envelope
+	if requiresMultipleSignatures(verifiers) {
+		coSignature, err = getRSLEntryCoSignature(repo, entry)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for _, verifier := range verifiers {
+		err := verifier.Verify(ctx, commitObj, coSignature)
+		if err == nil {
+			return true, nil
+		} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
 func verifyTagEntry(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) error {
 	// 1. Find authorized public keys for tag's RSL entry
 	trustedKeys, err := policy.FindPublicKeysForPath(ctx, fmt.Sprintf("git:%s", entry.RefName))
@@ -712,6 +1918,7 @@ func verifyTagEntry(ctx context.Context, repo *git.Repository, policy *State, en
 	}
 
 	// 3. Use each trusted key to verify signature
+	getProgressTracker(ctx).signaturesChecked(1)
 	rslEntryVerified := false
 	for _, key := range trustedKeys {
 		err := gitinterface.VerifyCommitSignature(ctx, commitObj, key)
@@ -732,90 +1939,318 @@ func verifyTagEntry(ctx context.Context, repo *git.Repository, policy *State, en
 		// Haven't found a valid key, continue with next key
 	}
 
-	if !rslEntryVerified {
-		return fmt.Errorf("verifying RSL entry failed, %w", ErrUnauthorizedSignature)
+	if !rslEntryVerified {
+		return fmt.Errorf("verifying RSL entry failed, %w", ErrUnauthorizedSignature)
+	}
+
+	// 4. Verify tag object
+	tagObjVerified := false
+	tagObj, err := gitinterface.GetTag(repo, entry.TargetID)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			// The entry's target is not a tag object, so the tag is a
+			// lightweight tag that cannot be verified
+			return fmt.Errorf("%w: %s", gitinterface.ErrNotAnnotatedTag, entry.RefName)
+		}
+		return err
+	}
+
+	entryTagRef, err := repo.Reference(plumbing.ReferenceName(entry.RefName), true)
+	if err != nil {
+		return err
+	}
+
+	if entry.TargetID != entryTagRef.Hash() && entry.TargetID != tagObj.Target {
+		return fmt.Errorf("verifying RSL entry failed, tag reference set to unexpected target")
+	}
+
+	if len(tagObj.PGPSignature) == 0 {
+		return fmt.Errorf(noSignatureMessage)
+	}
+
+	getProgressTracker(ctx).signaturesChecked(1)
+	for _, key := range trustedKeys {
+		err := gitinterface.VerifyTagSignature(ctx, tagObj, key)
+		if err == nil {
+			// Signature verification succeeded
+			tagObjVerified = true
+			break
+		}
+		if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
+			// We encounter this for key types that can be used for gittuf
+			// policy metadata but not Git objects
+			continue
+		}
+		if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
+			// Unexpected error
+			return err
+		}
+		// Haven't found a valid key, continue with next key
+	}
+
+	if !tagObjVerified {
+		return fmt.Errorf("verifying tag object's signature failed, %w", ErrUnauthorizedSignature)
+	}
+
+	return nil
+}
+
+func getAuthorizationAttestation(repo *git.Repository, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) (*sslibdsse.Envelope, error) {
+	fromID, targetTreeID, err := getEntryChange(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	attestation, err := attestationsState.GetReferenceAuthorizationFor(repo, entry.RefName, fromID, targetTreeID)
+	if err != nil {
+		if errors.Is(err, attestations.ErrAuthorizationNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return attestation, nil
+}
+
+// getGitHubPullRequestApprovers returns the IDs of the keys of the reviewers
+// who approved the change recorded in the RSL entry on GitHub. The approvers
+// are only returned if the GitHub pull request approval attestation is signed
+// by the GitHub app trusted in the policy's root of trust.
+func getGitHubPullRequestApprovers(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) ([]string, error) {
+	appVerifier, err := policy.getGitHubAppVerifier()
+	if err != nil {
+		return nil, err
+	}
+	if appVerifier == nil {
+		// No GitHub app is trusted
+		return nil, nil
+	}
+
+	fromID, targetTreeID, err := getEntryChange(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	env, approval, err := attestationsState.GetGitHubPullRequestApprovalAttestationFor(repo, entry.RefName, fromID, targetTreeID)
+	if err != nil {
+		if errors.Is(err, attestations.ErrGitHubPullRequestApprovalNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if err := appVerifier.Verify(ctx, nil, env); err != nil {
+		if errors.Is(err, ErrVerifierConditionsUnmet) {
+			slog.Debug(fmt.Sprintf("Ignoring GitHub pull request approval for '%s' not signed by trusted GitHub app", entry.RefName))
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return approval.Approvers, nil
+}
+
+// getEntryChange returns the revision the entry's ref moved from and the tree
+// of the revision it moved to. These identify the change in authorization and
+// approval attestations.
+func getEntryChange(repo *git.Repository, entry *rsl.ReferenceEntry) (string, string, error) {
+	fromID := plumbing.ZeroHash
+
+	priorRefEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, entry.RefName, entry.ID)
+	if err == nil {
+		fromID = priorRefEntry.TargetID
+	} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return "", "", err
 	}
 
-	// 4. Verify tag object
-	tagObjVerified := false
-	tagObj, err := gitinterface.GetTag(repo, entry.TargetID)
+	currentCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
 	if err != nil {
-		// Likely indicates the ref is not pointing to a tag object
-		// What about lightweight tags?
-		return err
+		return "", "", err
 	}
 
-	entryTagRef, err := repo.Reference(plumbing.ReferenceName(entry.RefName), true)
+	return fromID.String(), currentCommit.TreeHash.String(), nil
+}
+
+// getRSLEntryCoSignature returns the attestation holding co-signatures for the
+// RSL entry, if one exists. Co-signatures are issued after the entry is
+// recorded, so they are loaded from the latest attestations rather than the
+// attestations in place when the entry was recorded.
+func getRSLEntryCoSignature(repo *git.Repository, entry *rsl.ReferenceEntry) (*sslibdsse.Envelope, error) {
+	latestAttestations, err := attestations.LoadCurrentAttestations(repo)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if entry.TargetID != entryTagRef.Hash() && entry.TargetID != tagObj.Target {
-		return fmt.Errorf("verifying RSL entry failed, tag reference set to unexpected target")
+	attestation, err := latestAttestations.GetRSLEntryCoSignatureFor(repo, entry.RefName, entry.ID.String(), entry.TargetID.String())
+	if err != nil {
+		if errors.Is(err, attestations.ErrCoSignatureNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
 	}
 
-	if len(tagObj.PGPSignature) == 0 {
-		return fmt.Errorf(noSignatureMessage)
+	return attestation, nil
+}
+
+// findWaivedRule returns the name of the first of the verifiers whose failure
+// for the entry is accepted by a valid verification waiver. An empty name is
+// returned if none of the verifiers' failures are waived. Like co-signatures,
+// waivers are issued after the entry is recorded, so they are loaded from the
+// latest attestations.
+func findWaivedRule(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry, verifiers []*Verifier) (string, error) {
+	latestAttestations, err := attestations.LoadCurrentAttestations(repo)
+	if err != nil {
+		return "", err
 	}
 
-	for _, key := range trustedKeys {
-		err := gitinterface.VerifyTagSignature(ctx, tagObj, key)
-		if err == nil {
-			// Signature verification succeeded
-			tagObjVerified = true
-			break
+	for _, verifier := range verifiers {
+		waived, err := isRuleWaived(ctx, repo, policy, latestAttestations, entry, verifier.Name())
+		if err != nil {
+			return "", err
 		}
-		if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
-			// We encounter this for key types that can be used for gittuf
-			// policy metadata but not Git objects
-			continue
+		if waived {
+			return verifier.Name(), nil
 		}
-		if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
-			// Unexpected error
-			return err
+	}
+
+	return "", nil
+}
+
+// isRuleWaived checks if the attestations contain a waiver for the failure of
+// the rule for the entry that has not expired and is signed by a threshold of
+// the keys trusted for the top level rule file.
+func isRuleWaived(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry, ruleName string) (bool, error) {
+	env, waiver, err := attestationsState.GetVerificationWaiverFor(repo, entry.RefName, entry.ID.String(), ruleName)
+	if err != nil {
+		if errors.Is(err, attestations.ErrVerificationWaiverNotFound) {
+			return false, nil
 		}
-		// Haven't found a valid key, continue with next key
+		return false, err
 	}
 
-	if !tagObjVerified {
-		return fmt.Errorf("verifying tag object's signature failed, %w", ErrUnauthorizedSignature)
+	expires, err := waiver.ExpiresAt()
+	if err != nil {
+		return false, err
+	}
+	if time.Now().After(expires) {
+		slog.Warn(fmt.Sprintf("Waiver for rule '%s' for RSL entry '%s' expired at %s", ruleName, entry.ID.String(), waiver.Expires))
+		return false, nil
 	}
 
-	return nil
-}
+	targetsVerifier, err := policy.getTargetsVerifier()
+	if err != nil {
+		return false, err
+	}
+	if err := targetsVerifier.Verify(ctx, nil, env); err != nil {
+		if errors.Is(err, ErrVerifierConditionsUnmet) {
+			slog.Warn(fmt.Sprintf("Waiver for rule '%s' for RSL entry '%s' is not signed by a threshold of policy administrators", ruleName, entry.ID.String()))
+			return false, nil
+		}
+		return false, err
+	}
 
-func getAuthorizationAttestation(repo *git.Repository, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) (*sslibdsse.Envelope, error) {
-	firstEntry := false
+	slog.Warn(fmt.Sprintf("Failure of rule '%s' for RSL entry '%s' is waived until %s: %s", ruleName, entry.ID.String(), waiver.Expires, waiver.Justification))
+	return true, nil
+}
 
-	priorRefEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, entry.RefName, entry.ID)
+// loadMarkedEntry loads the RSL entry recorded in the marker, ensuring it is a
+// reference entry for the target ref. The entry must also still be in the RSL,
+// i.e., it must be the RSL's tip or one of its ancestors. A marked entry that
+// is no longer in the RSL, such as after the RSL was reconciled with a remote,
+// was not verified as part of the current RSL.
+func loadMarkedEntry(repo *git.Repository, target string, marker *VerificationMarker) (*rsl.ReferenceEntry, error) {
+	entryID, err := gitinterface.NewHash(marker.EntryID)
 	if err != nil {
-		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
-			return nil, err
-		}
+		return nil, err
+	}
 
-		firstEntry = true
+	entry, err := rsl.GetEntry(repo, entryID)
+	if err != nil {
+		return nil, err
 	}
 
-	fromID := plumbing.ZeroHash
-	if !firstEntry {
-		fromID = priorRefEntry.TargetID
+	referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+	if !isReferenceEntry || referenceEntry.RefName != target {
+		return nil, ErrInvalidVerificationMarkers
 	}
 
-	currentCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
+	tipID, err := gitinterface.GetTip(repo, rsl.Ref)
 	if err != nil {
 		return nil, err
 	}
-
-	attestation, err := attestationsState.GetReferenceAuthorizationFor(repo, entry.RefName, fromID.String(), currentCommit.TreeHash.String())
+	entryCommit, err := gitinterface.GetCommit(repo, entryID)
 	if err != nil {
-		if errors.Is(err, attestations.ErrAuthorizationNotFound) {
-			return nil, nil
+		return nil, err
+	}
+	inRSL, err := gitinterface.KnowsCommit(repo, tipID, entryCommit)
+	if err != nil {
+		return nil, err
+	}
+	if !inRSL {
+		return nil, ErrMarkedEntryNotInRSL
+	}
+
+	return referenceEntry, nil
+}
+
+// requiresMultipleSignatures returns true if any of the verifiers has a
+// threshold greater than one.
+func requiresMultipleSignatures(verifiers []*Verifier) bool {
+	for _, verifier := range verifiers {
+		if verifier.Threshold() > 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getCommitSignatureVerifiers returns the subset of verifiers whose rules
+// require each commit to be signed by an authorized key. As only a single
+// signature is present on a commit, the returned verifiers have a threshold of
+// one.
+func getCommitSignatureVerifiers(verifiers []*Verifier) []*Verifier {
+	commitSignatureVerifiers := []*Verifier{}
+	for _, verifier := range verifiers {
+		if !verifier.RequireSignedCommits() {
+			continue
 		}
 
-		return nil, err
+		commitSignatureVerifiers = append(commitSignatureVerifiers, &Verifier{
+			name:      verifier.name,
+			keys:      verifier.keys,
+			threshold: 1,
+		})
 	}
 
-	return attestation, nil
+	return commitSignatureVerifiers
+}
+
+// verifyCommitSignatures checks that each of the specified commits is signed by
+// a key trusted by at least one of the verifiers.
+func verifyCommitSignatures(ctx context.Context, verifiers []*Verifier, commits []*object.Commit) error {
+	for _, commit := range commits {
+		verified := false
+		for _, verifier := range verifiers {
+			err := verifier.Verify(ctx, commit, nil)
+			if err == nil {
+				verified = true
+				break
+			} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
+				return err
+			}
+		}
+
+		if !verified {
+			return fmt.Errorf("verifying signature of commit '%s' failed, %w", commit.Hash.String(), ErrUnauthorizedSignature)
+		}
+	}
+
+	return nil
 }
 
 // getCommits identifies the commits introduced to the entry's ref since the
@@ -840,6 +2275,27 @@ func getCommits(repo *git.Repository, entry *rsl.ReferenceEntry) ([]*object.Comm
 	return gitinterface.GetCommitsBetweenRange(repo, entry.TargetID, priorRefEntry.TargetID)
 }
 
+// getTreeIDForFix returns the ID of the tree that must be matched by an entry
+// that fixes a ref pointing to the specified target. For commits, this is the
+// commit's tree. Refs such as replace refs may also point to other objects, in
+// which case the fix must point to the same object.
+func getTreeIDForFix(repo *git.Repository, targetID plumbing.Hash) (plumbing.Hash, error) {
+	targetType, err := gitinterface.GetObjectType(repo, targetID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if targetType != plumbing.CommitObject {
+		return targetID, nil
+	}
+
+	commit, err := gitinterface.GetCommit(repo, targetID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return commit.TreeHash, nil
+}
+
 // getChangedPaths identifies the paths of all the files changed using the
 // specified RSL entry. The entry's commit ID is compared with the commit ID
 // from the previous RSL entry for the same namespace.
@@ -877,9 +2333,84 @@ func getChangedPaths(repo *git.Repository, entry *rsl.ReferenceEntry) ([]string,
 }
 
 type Verifier struct {
-	name      string
-	keys      []*tuf.Key
-	threshold int
+	name                 string
+	keys                 []*tuf.Key
+	threshold            int
+	requireSignedCommits bool
+
+	// inTotoLayout is the signed in-toto layout that must verify for changes
+	// protected by the verifier, and inTotoLayoutKeys are the keys that must
+	// have signed it.
+	inTotoLayout     []byte
+	inTotoLayoutKeys []*tuf.Key
+
+	// enginePolicy is the policy that must be allowed by its policy engine
+	// for changes protected by the verifier.
+	enginePolicy *tuf.EnginePolicy
+
+	// commitConstraints are checked for the commits introduced to the refs
+	// protected by the verifier.
+	commitConstraints *tuf.CommitConstraints
+
+	// principals are the named principals trusted by the verifier. Their keys
+	// are also included in keys. Each principal counts towards the threshold
+	// once a threshold of its own keys have signed.
+	principals []*tuf.Principal
+}
+
+// newVerifierForDelegation returns a verifier for the delegation. The
+// delegation's keys and principals are resolved using the specified keys and
+// principals. Principals that cannot be resolved are ignored.
+func newVerifierForDelegation(delegation tuf.Delegation, keys map[string]*tuf.Key, principals map[string]*tuf.Principal) *Verifier {
+	verifier := &Verifier{
+		name:                 delegation.Name,
+		keys:                 make([]*tuf.Key, 0, len(delegation.KeyIDs)),
+		threshold:            delegation.Threshold,
+		requireSignedCommits: delegation.RequireSignedCommits,
+	}
+	for _, keyID := range delegation.KeyIDs {
+		verifier.keys = append(verifier.keys, keys[keyID])
+	}
+
+	if delegation.InTotoLayout != nil {
+		verifier.inTotoLayout = delegation.InTotoLayout.Layout
+		for _, keyID := range delegation.InTotoLayout.KeyIDs {
+			if key, has := keys[keyID]; has {
+				verifier.inTotoLayoutKeys = append(verifier.inTotoLayoutKeys, key)
+			}
+		}
+	}
+
+	verifier.enginePolicy = delegation.EnginePolicy
+	verifier.commitConstraints = delegation.CommitConstraints
+
+	if len(delegation.Principals) == 0 {
+		return verifier
+	}
+
+	keyIDs := set.NewSet[string]()
+	for _, keyID := range delegation.KeyIDs {
+		keyIDs.Add(keyID)
+	}
+	for _, principalName := range delegation.Principals {
+		principal, has := principals[principalName]
+		if !has {
+			continue
+		}
+
+		verifier.principals = append(verifier.principals, principal)
+		for _, keyID := range principal.KeyIDs {
+			key, has := keys[keyID]
+			if !has || keyIDs.Has(keyID) {
+				continue
+			}
+
+			keyIDs.Add(keyID)
+			verifier.keys = append(verifier.keys, key)
+		}
+	}
+
+	return verifier
 }
 
 func (v *Verifier) Name() string {
@@ -894,81 +2425,93 @@ func (v *Verifier) Threshold() int {
 	return v.threshold
 }
 
+func (v *Verifier) RequireSignedCommits() bool {
+	return v.requireSignedCommits
+}
+
+// equals checks if two verifiers represent the same rule, i.e., they have the
+// same name, keys, principals, and threshold.
+func (v *Verifier) equals(other *Verifier) bool {
+	if v.name != other.name || v.threshold != other.threshold {
+		return false
+	}
+
+	keyIDs := set.NewSet[string]()
+	for _, key := range v.keys {
+		keyIDs.Add(key.KeyID)
+	}
+	otherKeyIDs := set.NewSet[string]()
+	for _, key := range other.keys {
+		if !keyIDs.Has(key.KeyID) {
+			return false
+		}
+		otherKeyIDs.Add(key.KeyID)
+	}
+	if keyIDs.Len() != otherKeyIDs.Len() {
+		return false
+	}
+
+	if len(v.principals) != len(other.principals) {
+		return false
+	}
+	for i, principal := range v.principals {
+		otherPrincipal := other.principals[i]
+		if principal.Name != otherPrincipal.Name || principal.Threshold != otherPrincipal.Threshold || !slices.Equal(principal.KeyIDs, otherPrincipal.KeyIDs) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Verify is used to check for a threshold of signatures using the verifier. The
 // threshold of signatures may be met using a combination of at most one Git
 // signature and signatures embedded in a DSSE envelope. Verify does not inspect
 // the envelope's payload, but instead only verifies the signatures. The caller
 // must ensure the validity of the envelope's contents.
 func (v *Verifier) Verify(ctx context.Context, gitObject object.Object, env *sslibdsse.Envelope) error {
+	return v.verifyWithApprovers(ctx, gitObject, env, nil)
+}
+
+// verifyWithApprovers is like Verify, but additionally counts the verifier's
+// keys listed in approverKeyIDs as having signed. The caller must ensure the
+// approvers were attested to by a trusted party, such as the GitHub app trusted
+// in the root of trust.
+func (v *Verifier) verifyWithApprovers(ctx context.Context, gitObject object.Object, env *sslibdsse.Envelope, approverKeyIDs []string) error {
 	if v.threshold < 1 || len(v.keys) < 1 {
 		return ErrInvalidVerifier
 	}
 
-	if gitObject == nil {
-		if env == nil {
-			// Nothing to verify, but fail closed
-			return ErrVerifierConditionsUnmet
-		} else if len(env.Signatures) < v.threshold {
-			// Envelope doesn't have enough signatures to meet threshold
-			return ErrVerifierConditionsUnmet
-		}
-	} else {
-		if env == nil {
-			if v.threshold > 1 {
-				// Single valid signature at most, so cannot meet threshold
-				return ErrVerifierConditionsUnmet
-			}
-		} else {
-			if (1 + len(env.Signatures)) < v.threshold {
-				// Combining the attestation and the git object we still do not
-				// have sufficient signatures
-				return ErrVerifierConditionsUnmet
-			}
+	approvedKeyIDs := set.NewSet[string]()
+	for _, key := range v.keys {
+		if slices.Contains(approverKeyIDs, key.KeyID) {
+			approvedKeyIDs.Add(key.KeyID)
 		}
 	}
 
-	var keyIDUsed string
-	gitObjectVerified := false
+	// Count the signatures available before verifying any of them, failing
+	// closed if there is nothing to verify
+	available := approvedKeyIDs.Len()
+	if gitObject != nil {
+		available++
+	}
+	if env != nil {
+		available += len(env.Signatures)
+	}
+	if available < v.threshold {
+		return ErrVerifierConditionsUnmet
+	}
+
+	if len(v.principals) > 0 || approvedKeyIDs.Len() > 0 {
+		return v.verifyWithPrincipals(ctx, gitObject, env, approvedKeyIDs)
+	}
 
 	// First, verify the gitObject's signature if one is presented
-	if gitObject != nil {
-		switch o := gitObject.(type) {
-		case *object.Commit:
-			for _, key := range v.keys {
-				err := gitinterface.VerifyCommitSignature(ctx, o, key)
-				if err == nil {
-					// Signature verification succeeded
-					keyIDUsed = key.KeyID
-					gitObjectVerified = true
-					break
-				}
-				if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
-					continue
-				}
-				if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
-					return err
-				}
-			}
-		case *object.Tag:
-			for _, key := range v.keys {
-				err := gitinterface.VerifyTagSignature(ctx, o, key)
-				if err == nil {
-					// Signature verification succeeded
-					keyIDUsed = key.KeyID
-					gitObjectVerified = true
-					break
-				}
-				if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
-					continue
-				}
-				if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
-					return err
-				}
-			}
-		default:
-			return ErrUnknownObjectType
-		}
+	keyIDUsed, err := v.verifyGitObjectSignature(ctx, gitObject)
+	if err != nil {
+		return err
 	}
+	gitObjectVerified := keyIDUsed != ""
 
 	// If threshold is 1 and the Git signature is verified, we can return
 	if v.threshold == 1 && gitObjectVerified {
@@ -997,9 +2540,130 @@ func (v *Verifier) Verify(ctx context.Context, gitObject object.Object, env *ssl
 		verifiers = append(verifiers, verifier)
 	}
 
+	if env != nil {
+		getProgressTracker(ctx).signaturesChecked(len(env.Signatures))
+	}
 	if err := dsse.VerifyEnvelope(ctx, env, verifiers, envelopeThreshold); err != nil {
 		return ErrVerifierConditionsUnmet
 	}
 
 	return nil
 }
+
+// verifyWithPrincipals checks for a threshold of signatures when the verifier
+// trusts principals or when approvals are attested to. Each principal whose own
+// threshold of keys have signed, and each other trusted key that has signed,
+// counts once towards the verifier's threshold. A key that belongs to several
+// principals is only used to satisfy one of them, with principals considered in
+// order. Keys in approvedKeyIDs are treated as having signed.
+func (v *Verifier) verifyWithPrincipals(ctx context.Context, gitObject object.Object, env *sslibdsse.Envelope, approvedKeyIDs *set.Set[string]) error {
+	verifiedKeyIDs := set.NewSet[string]()
+	for _, key := range v.keys {
+		if approvedKeyIDs.Has(key.KeyID) {
+			verifiedKeyIDs.Add(key.KeyID)
+		}
+	}
+
+	keyIDUsed, err := v.verifyGitObjectSignature(ctx, gitObject)
+	if err != nil {
+		return err
+	}
+	if keyIDUsed != "" {
+		verifiedKeyIDs.Add(keyIDUsed)
+	}
+
+	if env != nil {
+		getProgressTracker(ctx).signaturesChecked(len(env.Signatures))
+		for _, key := range v.keys {
+			if verifiedKeyIDs.Has(key.KeyID) {
+				continue
+			}
+
+			verifier, err := signerverifier.NewSignerVerifierFromTUFKey(key) //nolint:staticcheck
+			if err != nil {
+				if errors.Is(err, common.ErrUnknownKeyType) {
+					continue
+				}
+				return err
+			}
+
+			if err := dsse.VerifyEnvelope(ctx, env, []sslibdsse.Verifier{verifier}, 1); err == nil {
+				verifiedKeyIDs.Add(key.KeyID)
+			}
+		}
+	}
+
+	// Each verified key counts towards the threshold at most once, so a key
+	// that belongs to several principals only helps satisfy one of them
+	satisfied := 0
+	principalKeyIDs := set.NewSet[string]()
+	usedKeyIDs := set.NewSet[string]()
+	for _, principal := range v.principals {
+		availableKeyIDs := []string{}
+		for _, keyID := range principal.KeyIDs {
+			principalKeyIDs.Add(keyID)
+			if verifiedKeyIDs.Has(keyID) && !usedKeyIDs.Has(keyID) {
+				availableKeyIDs = append(availableKeyIDs, keyID)
+			}
+		}
+
+		if len(availableKeyIDs) >= principal.Threshold {
+			for _, keyID := range availableKeyIDs[:principal.Threshold] {
+				usedKeyIDs.Add(keyID)
+			}
+			satisfied++
+		}
+	}
+
+	for _, key := range v.keys {
+		if !principalKeyIDs.Has(key.KeyID) && verifiedKeyIDs.Has(key.KeyID) {
+			satisfied++
+		}
+	}
+
+	if satisfied < v.threshold {
+		return ErrVerifierConditionsUnmet
+	}
+
+	return nil
+}
+
+// verifyGitObjectSignature returns the ID of the verifier's key that signed
+// the gitObject. If gitObject is nil or is not signed by any of the
+// verifier's keys, an empty string is returned.
+func (v *Verifier) verifyGitObjectSignature(ctx context.Context, gitObject object.Object) (string, error) {
+	if gitObject == nil {
+		return "", nil
+	}
+	getProgressTracker(ctx).signaturesChecked(1)
+
+	var verifySignature func(context.Context, *tuf.Key) error
+	switch o := gitObject.(type) {
+	case *object.Commit:
+		verifySignature = func(ctx context.Context, key *tuf.Key) error {
+			return gitinterface.VerifyCommitSignature(ctx, o, key)
+		}
+	case *object.Tag:
+		verifySignature = func(ctx context.Context, key *tuf.Key) error {
+			return gitinterface.VerifyTagSignature(ctx, o, key)
+		}
+	default:
+		return "", ErrUnknownObjectType
+	}
+
+	for _, key := range v.keys {
+		err := verifySignature(ctx, key)
+		if err == nil {
+			// Signature verification succeeded
+			return key.KeyID, nil
+		}
+		if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
+			continue
+		}
+		if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
@@ -3,12 +3,17 @@
 package policy
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/gitinterface"
@@ -47,7 +52,9 @@ var (
 			Email: testEmail,
 		},
 	}
-	testClock = clockwork.NewFakeClockAt(time.Date(1995, time.October, 26, 9, 0, 0, 0, time.UTC))
+	// RSL entries are verified at the time they were recorded, so the test
+	// clock must be after the test GPG keys were created
+	testClock = clockwork.NewFakeClockAt(time.Date(2024, time.October, 26, 9, 0, 0, 0, time.UTC))
 )
 
 func TestVerifyRef(t *testing.T) {
@@ -88,6 +95,358 @@ func TestVerifyRefFull(t *testing.T) {
 	assert.Equal(t, commitIDs[0], currentTip)
 }
 
+func TestVerifyRefWithPropagationEntry(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	// The ref is updated to the state recorded in an upstream repository,
+	// which isn't verified using the policy
+	if err := rsl.NewPropagationEntry(refName, commitIDs[1], "https://example.com/upstream", commitIDs[1]).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := VerifyRef(context.Background(), repo, refName)
+	assert.ErrorIs(t, err, ErrUnverifiedPropagation)
+
+	_, err = VerifyRefFull(context.Background(), repo, refName)
+	assert.ErrorIs(t, err, ErrUnverifiedPropagation)
+
+	// A reference entry recorded after the propagation entry is verified
+	entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+	common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	currentTip, err := VerifyRef(context.Background(), repo, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs[1], currentTip)
+
+	currentTip, err = VerifyRefFull(context.Background(), repo, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs[1], currentTip)
+}
+
+func TestVerifyRefFullWithReport(t *testing.T) {
+	refName := "refs/heads/main"
+
+	t.Run("successful verification", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		currentTip, report, err := VerifyRefFullWithReport(context.Background(), repo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, commitIDs[0], currentTip)
+
+		assert.True(t, report.Verified)
+		assert.Empty(t, report.Error)
+		assert.Len(t, report.PolicyIDs, 1)
+		assert.Len(t, report.Entries, 1)
+		assert.Equal(t, entryID.String(), report.Entries[0].EntryID)
+		assert.Equal(t, refName, report.Entries[0].RefName)
+		assert.Equal(t, commitIDs[0].String(), report.Entries[0].TargetID)
+		assert.Equal(t, report.PolicyIDs[0], report.Entries[0].PolicyID)
+		assert.Equal(t, EntryStatusVerified, report.Entries[0].Status)
+		assert.Equal(t, []string{"protect-main"}, report.Entries[0].Rules)
+		assert.Equal(t, "157507bbe151e378ce8126c1dcfe043cdd2db96e", report.Entries[0].SignerKeyID)
+		assert.Empty(t, report.Entries[0].SigningSubkeyID)
+	})
+
+	t.Run("unsuccessful verification", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		_, report, err := VerifyRefFullWithReport(context.Background(), repo, refName)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+
+		assert.False(t, report.Verified)
+		assert.Equal(t, err.Error(), report.Error)
+		assert.Len(t, report.Entries, 1)
+		assert.Equal(t, entryID.String(), report.Entries[0].EntryID)
+		assert.Equal(t, EntryStatusFailed, report.Entries[0].Status)
+		assert.Equal(t, err.Error(), report.Entries[0].Error)
+	})
+
+	t.Run("no entries for ref", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		_, report, err := VerifyRefFullWithReport(context.Background(), repo, refName)
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+		assert.False(t, report.Verified)
+		assert.Empty(t, report.Entries)
+	})
+}
+
+func TestVerifyRefFullUsingCache(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currentTip, err := VerifyRefFullUsingCache(testCtx, repo, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs[0], currentTip)
+
+	cache, err := LoadVerificationCache(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, cache.IsVerified(entryID, policyEntry.TargetID, plumbing.ZeroHash))
+	cacheRef, err := repo.Reference(plumbing.ReferenceName(VerificationCacheRef), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Verifying again hits the cache, so the cache isn't updated
+	currentTip, err = VerifyRefFullUsingCache(testCtx, repo, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs[0], currentTip)
+	newCacheRef, err := repo.Reference(plumbing.ReferenceName(VerificationCacheRef), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, cacheRef.Hash(), newCacheRef.Hash())
+
+	// Update the policy, subsequent entries must be verified using the new
+	// policy
+	if err := state.Commit(repo, "Update policy", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(testCtx, repo, false); err != nil {
+		t.Fatal(err)
+	}
+	newPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	newEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	currentTip, err = VerifyRefFullUsingCache(testCtx, repo, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs[0], currentTip)
+
+	cache, err = LoadVerificationCache(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, cache.IsVerified(entryID, policyEntry.TargetID, plumbing.ZeroHash))
+	assert.False(t, cache.IsVerified(newEntryID, policyEntry.TargetID, plumbing.ZeroHash))
+	assert.True(t, cache.IsVerified(newEntryID, newPolicyEntry.TargetID, plumbing.ZeroHash))
+
+	// Record attestations, subsequent entries must be verified using them
+	currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := currentAttestations.Commit(repo, "Initial attestations", false); err != nil {
+		t.Fatal(err)
+	}
+	attestationsEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, attestations.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	attestedEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	currentTip, err = VerifyRefFullUsingCache(testCtx, repo, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs[0], currentTip)
+
+	cache, err = LoadVerificationCache(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, cache.IsVerified(newEntryID, newPolicyEntry.TargetID, plumbing.ZeroHash))
+	assert.False(t, cache.IsVerified(attestedEntryID, newPolicyEntry.TargetID, plumbing.ZeroHash))
+	assert.True(t, cache.IsVerified(attestedEntryID, newPolicyEntry.TargetID, attestationsEntry.TargetID))
+}
+
+func TestVerifyRefIncremental(t *testing.T) {
+	refName := "refs/heads/main"
+
+	t.Run("resume from marker", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		latestPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		currentTip, err := VerifyRefIncremental(context.Background(), repo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, commitIDs[0], currentTip)
+
+		markers, err := LoadVerificationMarkers(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, &VerificationMarker{EntryID: entryID.String(), PolicyEntryID: latestPolicyEntry.ID.String()}, markers.GetMarker(refName, latestPolicyEntry.ID))
+
+		// Unchanged RSL is not verified again
+		currentTip, err = VerifyRefIncremental(context.Background(), repo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, commitIDs[0], currentTip)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		currentTip, err = VerifyRefIncremental(context.Background(), repo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, commitIDs[0], currentTip)
+
+		markers, err = LoadVerificationMarkers(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entryID.String(), markers.GetMarker(refName, latestPolicyEntry.ID).EntryID)
+	})
+
+	t.Run("marker not updated on failure", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		latestPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		_, err = VerifyRefIncremental(context.Background(), repo, refName)
+		assert.Nil(t, err)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		_, err = VerifyRefIncremental(context.Background(), repo, refName)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+
+		markers, err := LoadVerificationMarkers(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entryID.String(), markers.GetMarker(refName, latestPolicyEntry.ID).EntryID)
+	})
+
+	t.Run("marker invalidated by policy change", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		_, err := VerifyRefIncremental(context.Background(), repo, refName)
+		assert.Nil(t, err)
+
+		// Record a new policy state
+		if err := state.Commit(repo, "Update policy", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(testCtx, repo, false); err != nil {
+			t.Fatal(err)
+		}
+		latestPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		markers, err := LoadVerificationMarkers(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Nil(t, markers.GetMarker(refName, latestPolicyEntry.ID))
+
+		currentTip, err := VerifyRefIncremental(context.Background(), repo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, commitIDs[0], currentTip)
+
+		markers, err = LoadVerificationMarkers(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotNil(t, markers.GetMarker(refName, latestPolicyEntry.ID))
+	})
+
+	t.Run("marked entry no longer in RSL", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		latestPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		_, err = VerifyRefIncremental(context.Background(), repo, refName)
+		assert.Nil(t, err)
+
+		// Rewrite the RSL so that the marked entry is replaced by an
+		// unauthorized entry, followed by an authorized entry
+		markedEntry, err := rsl.GetEntry(repo, entryID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parentEntry, err := rsl.GetParentForEntry(repo, markedEntry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, parentEntry.GetID())); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		markers, err := LoadVerificationMarkers(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = loadMarkedEntry(repo, refName, markers.GetMarker(refName, latestPolicyEntry.ID))
+		assert.ErrorIs(t, err, ErrMarkedEntryNotInRSL)
+
+		// All entries are verified, including the unauthorized entry
+		_, err = VerifyRefIncremental(context.Background(), repo, refName)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+}
+
 func TestVerifyRefFromEntry(t *testing.T) {
 	repo, _ := createTestRepository(t, createTestStateWithPolicy)
 	refName := "refs/heads/main"
@@ -117,6 +476,57 @@ func TestVerifyRefFromEntry(t *testing.T) {
 	assert.Equal(t, commitIDs[1], currentTip)
 }
 
+func TestVerifyRefAtEntry(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// No policy violation
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+	goodEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+	goodTip := commitIDs[1]
+
+	// Entry for another ref, recorded before the violation
+	otherRefName := "refs/heads/feature"
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(otherRefName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, otherRefName, 1, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(otherRefName, commitIDs[0])
+	otherEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	// Policy violation
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	violatingEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+	t.Run("at non-violating entry", func(t *testing.T) {
+		tip, err := VerifyRefAtEntry(testCtx, repo, refName, goodEntryID)
+		assert.Nil(t, err)
+		assert.Equal(t, goodTip, tip)
+	})
+
+	t.Run("at entry for another ref", func(t *testing.T) {
+		tip, err := VerifyRefAtEntry(testCtx, repo, refName, otherEntryID)
+		assert.Nil(t, err)
+		assert.Equal(t, goodTip, tip)
+	})
+
+	t.Run("at violating entry", func(t *testing.T) {
+		_, err := VerifyRefAtEntry(testCtx, repo, refName, violatingEntryID)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("ref not yet recorded", func(t *testing.T) {
+		_, err := VerifyRefAtEntry(testCtx, repo, otherRefName, goodEntryID)
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+	})
+}
+
 func TestVerifyRelativeForRef(t *testing.T) {
 	t.Run("no recovery", func(t *testing.T) {
 		repo, _ := createTestRepository(t, createTestStateWithPolicy)
@@ -730,10 +1140,156 @@ func TestVerifyRelativeForRef(t *testing.T) {
 	})
 }
 
-func TestVerifyCommit(t *testing.T) {
+func TestVerifyRelativeForRefWithUnskip(t *testing.T) {
 	repo, _ := createTestRepository(t, createTestStateWithPolicy)
 	refName := "refs/heads/main"
-	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+	validCommitID := commitIDs[0]
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	invalidEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+	// Skip the invalid entry and fix the ref
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), validCommitID)); err != nil {
+		t.Fatal(err)
+	}
+	annotation := rsl.NewAnnotationEntry([]plumbing.Hash{invalidEntryID}, true, "invalid entry")
+	common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+	// Annotations are only considered if they are within the verified range,
+	// so the valid state is recorded after each annotation
+	recordValidEntry := func() *rsl.ReferenceEntry {
+		entry := rsl.NewReferenceEntry(refName, validCommitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		return entry
+	}
+
+	err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, recordValidEntry(), refName)
+	assert.Nil(t, err)
+
+	// Unskip by an unauthorized signer does not override the authorized skip
+	annotation = rsl.NewUnskipAnnotationEntry([]plumbing.Hash{invalidEntryID}, "reinstate entry")
+	common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgUnauthorizedKeyBytes)
+
+	err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, recordValidEntry(), refName)
+	assert.Nil(t, err)
+
+	// Unskip by an authorized signer reinstates the invalid entry
+	annotation = rsl.NewUnskipAnnotationEntry([]plumbing.Hash{invalidEntryID}, "reinstate entry")
+	common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+
+	err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, recordValidEntry(), refName)
+	assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+
+	// The most recent authorized annotation takes effect
+	annotation = rsl.NewAnnotationEntry([]plumbing.Hash{invalidEntryID}, true, "invalid entry")
+	common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+
+	err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, recordValidEntry(), refName)
+	assert.Nil(t, err)
+}
+
+func TestVerifyRelativeForRefWithDeletion(t *testing.T) {
+	t.Run("authorized deletion and resurrection", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		entry = rsl.NewReferenceDeletionEntry(refName)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, entry, refName)
+		assert.Nil(t, err)
+
+		// The resurrected ref's commits are all verified, as for a new ref
+		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, entry, refName)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unauthorized deletion", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		entry = rsl.NewReferenceDeletionEntry(refName)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("unauthorized resurrection", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		entry = rsl.NewReferenceDeletionEntry(refName)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+}
+
+func TestVerifyCommit(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -784,6 +1340,71 @@ func TestVerifyCommit(t *testing.T) {
 	assert.Equal(t, expectedStatus, status)
 }
 
+func TestVerifyCommitObjects(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	unrecordedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+	expectedResults := []*ObjectVerificationResult{
+		{
+			ID:       commitIDs[0].String(),
+			ObjectID: commitIDs[0].String(),
+			Verified: true,
+			KeyID:    gpgKey.KeyID,
+			Message:  fmt.Sprintf(goodSignatureMessageFmt, gpgKey.KeyType, gpgKey.KeyID),
+		},
+		{
+			ID:       refName,
+			ObjectID: unrecordedCommitIDs[0].String(),
+			Message:  unableToFindPolicyMessage,
+		},
+		{
+			ID:      "does-not-exist",
+			Message: unableToResolveRevisionMessage,
+		},
+	}
+	results := VerifyCommitObjects(testCtx, repo, commitIDs[0].String(), refName, "does-not-exist")
+	assert.Equal(t, expectedResults, results)
+}
+
+func TestVerifyTagObjects(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	tagName := "v1"
+	tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[0], gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
+	common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	expectedResults := []*ObjectVerificationResult{
+		{
+			ID:       tagName,
+			ObjectID: tagID.String(),
+			Verified: true,
+			Message:  goodTagSignatureMessage,
+		},
+		{
+			ID:      refName,
+			Message: nonTagMessage,
+		},
+	}
+	results := VerifyTagObjects(testCtx, repo, []string{tagName, refName})
+	assert.Equal(t, expectedResults, results)
+}
+
 func TestVerifyTag(t *testing.T) {
 	t.Run("normal test", func(t *testing.T) {
 		repo, _ := createTestRepository(t, createTestStateWithPolicy)
@@ -889,246 +1510,1165 @@ func TestVerifyEntry(t *testing.T) {
 	t.Run("successful verification with higher threshold", func(t *testing.T) {
 		repo, state := createTestRepository(t, createTestStateWithThresholdPolicy)
 
-		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
-		if err != nil {
-			t.Fatal(err)
-		}
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Create authorization for this change
+		authorization, err := attestations.NewReferenceAuthorization(refName, plumbing.ZeroHash.String(), commit.TreeHash.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, plumbing.ZeroHash.String(), commit.TreeHash.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add authorization", false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("verification with higher threshold using RSL entry co-signature", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		// The entry's signature alone does not meet the threshold
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+
+		// Co-sign the entry after it's recorded
+		coSignature, err := attestations.NewRSLEntryCoSignature(refName, entryID.String(), commitIDs[0].String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(coSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.SetRSLEntryCoSignature(repo, env, refName, entryID.String(), commitIDs[0].String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add co-signature", false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("verification with higher threshold using GitHub pull request approval", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubApp)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		approverKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		approval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, plumbing.ZeroHash.String(), commit.TreeHash.String(), []string{approverKey.KeyID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(approval)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		// The approval is not counted when it isn't signed by the GitHub app
+		unsignedAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := unsignedAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, refName, plumbing.ZeroHash.String(), commit.TreeHash.String()); err != nil {
+			t.Fatal(err)
+		}
+		err = verifyEntry(testCtx, repo, state, unsignedAttestations, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+
+		appSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets2KeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, appSigner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, refName, plumbing.ZeroHash.String(), commit.TreeHash.String()); err != nil {
+			t.Fatal(err)
+		}
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unauthorized change to protected file", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		// The RSL entry is signed by an authorized key, but the commit that
+		// modifies the protected file "1" is not
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(context.Background(), repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("successful verification with signed commits required", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithSignedCommitsPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(context.Background(), repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unsuccessful verification with signed commits required", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithSignedCommitsPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(context.Background(), repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("successful verification of notes ref without file rules", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithNamespacePolicy)
+
+		// The notes commits touch protected file paths but aren't signed by
+		// the authorized key, as file rules don't apply to notes refs
+		notesRefName := "refs/notes/commits"
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, notesRefName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(notesRefName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+
+		// The same change to a branch must meet the file rules
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("unsuccessful verification of notes ref", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithNamespacePolicy)
+
+		notesRefName := "refs/notes/commits"
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, notesRefName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(notesRefName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("successful verification of replace ref pointing to blob", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithNamespacePolicy)
+
+		blobID, err := gitinterface.WriteBlob(repo, []byte("replacement"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		replaceRefName := gitinterface.ReplaceRefPrefix + blobID.String()
+		entry := rsl.NewReferenceEntry(replaceRefName, blobID)
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unsuccessful verification of ref in custom namespace", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithNamespacePolicy)
+
+		// refs/custom/* is only protected by the rule trusting targets1, so
+		// the GPG key trusted for notes and replace refs isn't sufficient
+		customRefName := "refs/custom/review"
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, customRefName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(customRefName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	// FIXME: test for file policy passing for situations where a commit is seen
+	// by the RSL before its signing key is rotated out. This commit should be
+	// trusted for merges under the new policy because it predates the policy
+	// change. This only applies to fast forwards, any other commits that make
+	// the same semantic change will result in a new commit with a new
+	// signature, unseen by the RSL.
+}
+
+func TestVerifyEntryVerificationTime(t *testing.T) {
+	refName := "refs/heads/main"
+
+	t.Run("backdated entry signed with expired key", func(t *testing.T) {
+		// The key expired a day before the test clock's time
+		keyCreatedAt := common.TestClock.Now().Add(-48 * time.Hour)
+		keyConfig := &packet.Config{
+			Algorithm:       packet.PubKeyAlgoEdDSA,
+			Time:            func() time.Time { return keyCreatedAt },
+			KeyLifetimeSecs: 24 * 60 * 60,
+		}
+		entity, err := openpgp.NewEntity(testName, "", testEmail, keyConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKeyBytes := &bytes.Buffer{}
+		writer, err := armor.Encode(publicKeyBytes, openpgp.PublicKeyType, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := entity.Serialize(writer); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		expiredKey, err := gpg.LoadGPGKeyFromBytes(publicKeyBytes.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		repo, state := createTestRepository(t, func(t *testing.T) *State {
+			t.Helper()
+
+			state := createTestStateWithPolicy(t)
+
+			targetsMetadata := InitializeTargetsMetadata()
+			targetsMetadata, err := AddDelegation(targetsMetadata, "protect-main", []*tuf.Key{expiredKey}, []string{"git:refs/heads/main"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state.TargetsEnvelope = targetsEnv
+
+			if err := state.loadRuleNames(); err != nil {
+				t.Fatal(err)
+			}
+
+			return state
+		})
+
+		// createEntry records an entry for the ref at the specified time,
+		// signed using the expired key with a signature backdated to when the
+		// key was valid
+		signedAt := keyCreatedAt.Add(time.Hour)
+		createEntry := func(t *testing.T, at time.Time) (*rsl.ReferenceEntry, *object.Commit) {
+			t.Helper()
+
+			commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+			entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+
+			ref, err := repo.Reference(plumbing.ReferenceName(rsl.Ref), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entryCommit := &object.Commit{
+				Author:       object.Signature{Name: testName, Email: testEmail, When: at},
+				Committer:    object.Signature{Name: testName, Email: testEmail, When: at},
+				Message:      fmt.Sprintf("%s\n\n%s: %s\n%s: %s", rsl.ReferenceEntryHeader, rsl.RefKey, refName, rsl.TargetIDKey, commitIDs[0].String()),
+				TreeHash:     gitinterface.EmptyTree(),
+				ParentHashes: []plumbing.Hash{ref.Hash()},
+			}
+
+			encodedCommit := repo.Storer.NewEncodedObject()
+			if err := entryCommit.EncodeWithoutSignature(encodedCommit); err != nil {
+				t.Fatal(err)
+			}
+			reader, err := encodedCommit.Reader()
+			if err != nil {
+				t.Fatal(err)
+			}
+			signature := &strings.Builder{}
+			if err := openpgp.ArmoredDetachSign(signature, entity, reader, &packet.Config{Time: func() time.Time { return signedAt }}); err != nil {
+				t.Fatal(err)
+			}
+			entryCommit.PGPSignature = signature.String()
+
+			entry.ID, err = gitinterface.ApplyCommit(repo, entryCommit, ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return entry, entryCommit
+		}
+
+		// The entry claims to be recorded while the key was valid, before its
+		// parent entry
+		entry, entryCommit := createEntry(t, signedAt)
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrEntryTimeBeforeParent)
+
+		// The entry is instead recorded with its parent entry's time, when the
+		// key had expired
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(rsl.Ref), entryCommit.ParentHashes[0])); err != nil {
+			t.Fatal(err)
+		}
+		parentCommit, err := gitinterface.GetCommit(repo, entryCommit.ParentHashes[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, _ = createEntry(t, parentCommit.Committer.When)
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("deterministic entries", func(t *testing.T) {
+		rsl.DeterministicEntries = true
+		t.Cleanup(func() { rsl.DeterministicEntries = false })
+
+		// The RSL's entries are recorded at the Unix epoch, before the GPG key
+		// was created
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		if err := entry.CommitUsingSpecificKey(repo, gpgKeyBytes); err != nil {
+			t.Fatal(err)
+		}
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+
+		// Deterministic entries are identified by their committer when
+		// verified without deterministic entries enabled
+		rsl.DeterministicEntries = false
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+}
+
+func TestVerifyTagEntry(t *testing.T) {
+	t.Run("no tag specific policy", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		tagName := "v1"
+		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+
+		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("lightweight tag", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		tagRefName := plumbing.NewTagReferenceName("v1")
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(tagRefName, commitIDs[0])); err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(string(tagRefName), commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		assert.ErrorIs(t, err, gitinterface.ErrNotAnnotatedTag)
+	})
+
+	t.Run("with tag specific policy", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createTestStateWithTagPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		tagName := "v1"
+		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+
+		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("with tag specific policy, unauthorized", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createTestStateWithTagPolicyForUnauthorizedTest)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		tagName := "v1"
+		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+
+		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+}
+
+func TestGetCommits(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+	refName := "refs/heads/main"
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// FIXME: this setup with RSL entries can be formalized using another
+	// helper like createTestStateWithPolicy. The RSL could then also
+	// incorporate policy changes and so on.
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgKeyBytes)
+	firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+	firstEntry.ID = firstEntryID
+
+	secondEntry := rsl.NewReferenceEntry(refName, commitIDs[4])
+	secondEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, secondEntry, gpgKeyBytes)
+	secondEntry.ID = secondEntryID
+
+	expectedCommitIDs := []plumbing.Hash{commitIDs[1], commitIDs[2], commitIDs[3], commitIDs[4]}
+	expectedCommits := make([]*object.Commit, 0, len(expectedCommitIDs))
+	for _, commitID := range expectedCommitIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedCommits = append(expectedCommits, commit)
+	}
+
+	sort.Slice(expectedCommits, func(i, j int) bool {
+		return expectedCommits[i].ID().String() < expectedCommits[j].ID().String()
+	})
+
+	commits, err := getCommits(repo, secondEntry)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedCommits, commits)
+}
+
+func TestGetTreeIDForFix(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/main", 1, gpgKeyBytes)
+	commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, []byte("replacement"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	treeID, err := getTreeIDForFix(repo, commitIDs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, commit.TreeHash, treeID)
+
+	treeID, err = getTreeIDForFix(repo, blobID)
+	assert.Nil(t, err)
+	assert.Equal(t, blobID, treeID)
+}
+
+func TestGetChangedPaths(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+	refName := "refs/heads/main"
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// FIXME: this setup with RSL entries can be formalized using another
+	// helper like createTestStateWithPolicy. The RSL could then also
+	// incorporate policy changes and so on.
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+	entries := []*rsl.ReferenceEntry{}
+	for _, commitID := range commitIDs {
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		entries = append(entries, entry)
+	}
+
+	changedPaths, err := getChangedPaths(repo, entries[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// First commit's tree has a single file, 1.
+	assert.Equal(t, []string{"1"}, changedPaths)
+
+	changedPaths, err = getChangedPaths(repo, entries[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Second commit's tree has two files, 1 and 2. Only 2 is new.
+	assert.Equal(t, []string{"2"}, changedPaths)
+}
+
+func TestStateVerifyNewState(t *testing.T) {
+	t.Run("valid policy transition", func(t *testing.T) {
+		currentPolicy := createTestStateWithOnlyRoot(t)
+		newPolicy := createTestStateWithOnlyRoot(t)
+
+		err := currentPolicy.VerifyNewState(context.Background(), newPolicy)
+		assert.Nil(t, err)
+	})
+
+	t.Run("invalid policy transition", func(t *testing.T) {
+		currentPolicy := createTestStateWithOnlyRoot(t)
+
+		// Create invalid state
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootMetadata := InitializeRootMetadata(key)
+
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newPolicy := &State{
+			RootPublicKeys:      []*tuf.Key{key},
+			RootEnvelope:        rootEnv,
+			DelegationEnvelopes: map[string]*sslibdsse.Envelope{},
+		}
+
+		err = currentPolicy.VerifyNewState(context.Background(), newPolicy)
+		assert.ErrorIs(t, err, ErrVerifierConditionsUnmet)
+	})
+}
+
+func TestFindEntriesOutOfPolicyScope(t *testing.T) {
+	t.Run("no policy", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = FindEntriesOutOfPolicyScope(testCtx, repo)
+		assert.ErrorIs(t, err, ErrPolicyNotFound)
+	})
+
+	t.Run("entries in and out of scope", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		refName := "refs/heads/main"
+		unprotectedRefName := "refs/heads/feature"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, unprotectedRefName, 1, gpgKeyBytes)
+		unprotectedEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(unprotectedRefName, commitIDs[0]), gpgKeyBytes)
+
+		// Policy updates are not considered
+		if err := state.Commit(repo, "Update policy", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(testCtx, repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := FindEntriesOutOfPolicyScope(testCtx, repo)
+		assert.Nil(t, err)
+		if assert.Equal(t, 1, len(entries)) {
+			assert.Equal(t, unprotectedEntryID, entries[0].ID)
+			assert.Equal(t, unprotectedRefName, entries[0].RefName)
+		}
+	})
+}
+
+func TestVerifyGittufRefsConsistency(t *testing.T) {
+	// createPolicyCommit creates a commit on top of the current policy tip
+	// without recording it in the RSL, and returns its ID.
+	createPolicyCommit := func(t *testing.T, repo *git.Repository, parentID plumbing.Hash) plumbing.Hash {
+		t.Helper()
+
+		parentCommit, err := gitinterface.GetCommit(repo, parentID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitID, err := gitinterface.CommitWithParents(repo, parentCommit.TreeHash, []plumbing.Hash{parentID}, "refs/heads/scratch", "Unrecorded policy commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return commitID
+	}
+
+	setPolicyRef := func(t *testing.T, repo *git.Repository, commitID plumbing.Hash) {
+		t.Helper()
+
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(PolicyRef, commitID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyGittufRefsConsistency(repo)
+		assert.Nil(t, err)
+	})
+
+	t.Run("consistent refs", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		if err := state.Commit(repo, "Update policy", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(testCtx, repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := VerifyGittufRefsConsistency(repo)
+		assert.Nil(t, err)
+	})
+
+	t.Run("policy ref ahead of RSL", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		setPolicyRef(t, repo, createPolicyCommit(t, repo, policyEntry.TargetID))
+
+		err = VerifyGittufRefsConsistency(repo)
+		assert.ErrorIs(t, err, ErrInconsistentGittufRefs)
+		assert.Contains(t, err.Error(), policyEntry.ID.String())
+	})
+
+	t.Run("RSL entry not on policy ref", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		policyCommit, err := gitinterface.GetCommit(repo, policyEntry.TargetID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Create a policy history unrelated to the one in the RSL
+		unrelatedCommitID, err := gitinterface.CommitWithParents(repo, policyCommit.TreeHash, nil, "refs/heads/scratch", "Unrelated policy commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		setPolicyRef(t, repo, unrelatedCommitID)
+		if err := rsl.NewReferenceEntry(PolicyRef, unrelatedCommitID).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyGittufRefsConsistency(repo)
+		assert.ErrorIs(t, err, ErrInconsistentGittufRefs)
+		assert.Contains(t, err.Error(), policyEntry.ID.String())
+		assert.Contains(t, err.Error(), unrelatedCommitID.String())
+	})
+
+	t.Run("policy commit not recorded in RSL", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		unrecordedCommitID := createPolicyCommit(t, repo, policyEntry.TargetID)
+		recordedCommitID := createPolicyCommit(t, repo, unrecordedCommitID)
+		setPolicyRef(t, repo, recordedCommitID)
+		if err := rsl.NewReferenceEntry(PolicyRef, recordedCommitID).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyGittufRefsConsistency(repo)
+		assert.ErrorIs(t, err, ErrInconsistentGittufRefs)
+		assert.Contains(t, err.Error(), unrecordedCommitID.String())
+		assert.Contains(t, err.Error(), recordedCommitID.String())
+	})
+}
+
+func TestVerifyRootChain(t *testing.T) {
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRootKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	untrustedRootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets2KeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrustedRootKey, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// createRotatedState returns a state whose root of trust is rootKey at the
+	// specified version, signed by all the specified signers.
+	createRotatedState := func(t *testing.T, rootKey *tuf.Key, version int, signers ...sslibdsse.SignerVerifier) *State {
+		t.Helper()
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		rootMetadata := InitializeRootMetadata(rootKey)
+		rootMetadata.SetVersion(version)
 
-		commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
 		if err != nil {
 			t.Fatal(err)
 		}
-
-		// Create authorization for this change
-		authorization, err := attestations.NewReferenceAuthorization(refName, plumbing.ZeroHash.String(), commit.TreeHash.String())
-		if err != nil {
-			t.Fatal(err)
+		for _, signer := range signers {
+			rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
 		}
 
-		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
-		if err != nil {
-			t.Fatal(err)
-		}
-		env, err := dsse.CreateEnvelope(authorization)
-		if err != nil {
-			t.Fatal(err)
-		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
-		if err != nil {
-			t.Fatal(err)
+		return &State{
+			RootPublicKeys: []*tuf.Key{rootKey},
+			RootEnvelope:   rootEnv,
 		}
+	}
 
-		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, plumbing.ZeroHash.String(), commit.TreeHash.String()); err != nil {
-			t.Fatal(err)
-		}
-		if err := currentAttestations.Commit(repo, "Add authorization", false); err != nil {
+	applyState := func(t *testing.T, repo *git.Repository, state *State) {
+		t.Helper()
+
+		if err := state.Commit(repo, "Update root", false); err != nil {
 			t.Fatal(err)
 		}
-
-		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
-		if err != nil {
+		if err := Apply(testCtx, repo, false); err != nil {
 			t.Fatal(err)
 		}
+	}
 
-		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+	t.Run("no rotation", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		err := VerifyRootChain(testCtx, repo, nil, 0)
 		assert.Nil(t, err)
 	})
 
-	// FIXME: test for file policy passing for situations where a commit is seen
-	// by the RSL before its signing key is rotated out. This commit should be
-	// trusted for merges under the new policy because it predates the policy
-	// change. This only applies to fast forwards, any other commits that make
-	// the same semantic change will result in a new commit with a new
-	// signature, unseen by the RSL.
-}
-
-func TestVerifyTagEntry(t *testing.T) {
-	t.Run("no tag specific policy", func(t *testing.T) {
-		repo, policy := createTestRepository(t, createTestStateWithPolicy)
-		refName := "refs/heads/main"
+	t.Run("valid rotation", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
-		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		// Rotate root from rootKey to newRootKey
+		applyState(t, repo, createRotatedState(t, newRootKey, 2, rootSigner, newRootSigner))
 
-		tagName := "v1"
-		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+		// Update policy without changing the root
+		applyState(t, repo, createRotatedState(t, newRootKey, 2, rootSigner, newRootSigner))
 
-		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		err := VerifyRootChain(testCtx, repo, nil, 0)
+		assert.Nil(t, err)
 
-		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		err = VerifyRootChain(testCtx, repo, []*tuf.Key{rootKey}, 1)
 		assert.Nil(t, err)
 	})
 
-	t.Run("with tag specific policy", func(t *testing.T) {
-		repo, policy := createTestRepository(t, createTestStateWithTagPolicy)
-		refName := "refs/heads/main"
+	t.Run("pinned root keys do not match initial root", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
-		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		err := VerifyRootChain(testCtx, repo, []*tuf.Key{untrustedRootKey}, 1)
+		assert.ErrorIs(t, err, ErrRootChainBroken)
+		assert.Contains(t, err.Error(), "initial root version 1")
+	})
 
-		tagName := "v1"
-		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+	t.Run("pinned threshold not met by initial root", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		// The initial root declares a threshold of 1, which must not lower
+		// the pinned threshold
+		err := VerifyRootChain(testCtx, repo, []*tuf.Key{untrustedRootKey, rootKey}, 2)
+		assert.ErrorIs(t, err, ErrRootChainBroken)
+	})
 
-		err := verifyTagEntry(context.Background(), repo, policy, entry)
-		assert.Nil(t, err)
+	t.Run("rotation not signed by prior root", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
+
+		applyState(t, repo, createRotatedState(t, newRootKey, 2, rootSigner, newRootSigner))
+		applyState(t, repo, createRotatedState(t, untrustedRootKey, 3, untrustedRootSigner))
+
+		err := VerifyRootChain(testCtx, repo, nil, 0)
+		assert.ErrorIs(t, err, ErrRootChainBroken)
+		assert.ErrorIs(t, err, ErrVerifierConditionsUnmet)
+		assert.Contains(t, err.Error(), "root version 3")
 	})
 
-	t.Run("with tag specific policy, unauthorized", func(t *testing.T) {
-		repo, policy := createTestRepository(t, createTestStateWithTagPolicyForUnauthorizedTest)
-		refName := "refs/heads/main"
+	t.Run("root modified without version increment", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
-		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		applyState(t, repo, createRotatedState(t, newRootKey, 1, rootSigner, newRootSigner))
 
-		tagName := "v1"
-		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+		err := VerifyRootChain(testCtx, repo, nil, 0)
+		assert.ErrorIs(t, err, ErrRootChainBroken)
+		assert.Contains(t, err.Error(), "root version 1")
+	})
 
-		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+	t.Run("root version rolled back", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-		err := verifyTagEntry(context.Background(), repo, policy, entry)
-		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+		applyState(t, repo, createRotatedState(t, newRootKey, 3, rootSigner, newRootSigner))
+		applyState(t, repo, createRotatedState(t, newRootKey, 2, newRootSigner))
+
+		err := VerifyRootChain(testCtx, repo, nil, 0)
+		assert.ErrorIs(t, err, ErrRootChainBroken)
+		assert.Contains(t, err.Error(), "root version 2")
 	})
 }
 
-func TestGetCommits(t *testing.T) {
-	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+func TestVerifyNewGittufEntries(t *testing.T) {
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	refName := "refs/heads/main"
+	newRootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRootKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+	untrustedRootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets2KeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrustedRootKey, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
 		t.Fatal(err)
 	}
 
-	// FIXME: this setup with RSL entries can be formalized using another
-	// helper like createTestStateWithPolicy. The RSL could then also
-	// incorporate policy changes and so on.
-	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgKeyBytes)
-	firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
-	firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
-	firstEntry.ID = firstEntryID
+	// applyRotatedState records a state whose root of trust is rootKey at the
+	// specified version, signed by all the specified signers.
+	applyRotatedState := func(t *testing.T, repo *git.Repository, rootKey *tuf.Key, version int, signers ...sslibdsse.SignerVerifier) {
+		t.Helper()
 
-	secondEntry := rsl.NewReferenceEntry(refName, commitIDs[4])
-	secondEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, secondEntry, gpgKeyBytes)
-	secondEntry.ID = secondEntryID
+		rootMetadata := InitializeRootMetadata(rootKey)
+		rootMetadata.SetVersion(version)
 
-	expectedCommitIDs := []plumbing.Hash{commitIDs[1], commitIDs[2], commitIDs[3], commitIDs[4]}
-	expectedCommits := make([]*object.Commit, 0, len(expectedCommitIDs))
-	for _, commitID := range expectedCommitIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
 		if err != nil {
 			t.Fatal(err)
 		}
+		for _, signer := range signers {
+			rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
 
-		expectedCommits = append(expectedCommits, commit)
+		state := &State{
+			RootPublicKeys: []*tuf.Key{rootKey},
+			RootEnvelope:   rootEnv,
+		}
+		if err := state.Commit(repo, "Update root", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(testCtx, repo, false); err != nil {
+			t.Fatal(err)
+		}
 	}
 
-	sort.Slice(expectedCommits, func(i, j int) bool {
-		return expectedCommits[i].ID().String() < expectedCommits[j].ID().String()
-	})
-
-	commits, err := getCommits(repo, secondEntry)
-	assert.Nil(t, err)
-	assert.Equal(t, expectedCommits, commits)
-}
-
-func TestGetChangedPaths(t *testing.T) {
-	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	// getEntriesAfter returns the reference entries recorded after stopID.
+	getEntriesAfter := func(t *testing.T, repo *git.Repository, stopID plumbing.Hash) []*rsl.ReferenceEntry {
+		t.Helper()
 
-	refName := "refs/heads/main"
+		entries := []*rsl.ReferenceEntry{}
+		entry, err := rsl.GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for entry.GetID() != stopID {
+			if referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry); isReferenceEntry {
+				entries = append([]*rsl.ReferenceEntry{referenceEntry}, entries...)
+			}
+
+			entry, err = rsl.GetParentForEntry(repo, entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
 
-	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
-		t.Fatal(err)
+		return entries
 	}
 
-	// FIXME: this setup with RSL entries can be formalized using another
-	// helper like createTestStateWithPolicy. The RSL could then also
-	// incorporate policy changes and so on.
-	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
-	entries := []*rsl.ReferenceEntry{}
-	for _, commitID := range commitIDs {
-		entry := rsl.NewReferenceEntry(refName, commitID)
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+	t.Run("no new entries", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-		entries = append(entries, entry)
-	}
+		err := VerifyNewGittufEntries(testCtx, repo, nil)
+		assert.Nil(t, err)
+	})
 
-	changedPaths, err := getChangedPaths(repo, entries[0])
-	if err != nil {
-		t.Fatal(err)
-	}
-	// First commit's tree has a single file, 1.
-	assert.Equal(t, []string{"1"}, changedPaths)
+	t.Run("valid rotation", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-	changedPaths, err = getChangedPaths(repo, entries[1])
-	if err != nil {
-		t.Fatal(err)
-	}
-	// Second commit's tree has two files, 1 and 2. Only 2 is new.
-	assert.Equal(t, []string{"2"}, changedPaths)
-}
+		trustedEntry, err := rsl.GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-func TestStateVerifyNewState(t *testing.T) {
-	t.Run("valid policy transition", func(t *testing.T) {
-		currentPolicy := createTestStateWithOnlyRoot(t)
-		newPolicy := createTestStateWithOnlyRoot(t)
+		applyRotatedState(t, repo, newRootKey, 2, rootSigner, newRootSigner)
+		applyRotatedState(t, repo, newRootKey, 2, rootSigner, newRootSigner)
 
-		err := currentPolicy.VerifyNewState(context.Background(), newPolicy)
+		err = VerifyNewGittufEntries(testCtx, repo, getEntriesAfter(t, repo, trustedEntry.GetID()))
 		assert.Nil(t, err)
 	})
 
-	t.Run("invalid policy transition", func(t *testing.T) {
-		currentPolicy := createTestStateWithOnlyRoot(t)
+	t.Run("rotation not signed by trusted root", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
 
-		// Create invalid state
-		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+		trustedEntry, err := rsl.GetLatestEntry(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
-		if err != nil {
-			t.Fatal(err)
-		}
+		applyRotatedState(t, repo, untrustedRootKey, 2, untrustedRootSigner)
 
-		rootMetadata := InitializeRootMetadata(key)
+		err = VerifyNewGittufEntries(testCtx, repo, getEntriesAfter(t, repo, trustedEntry.GetID()))
+		assert.ErrorIs(t, err, ErrRootChainBroken)
+		assert.ErrorIs(t, err, ErrVerifierConditionsUnmet)
+	})
 
-		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	t.Run("rotation verified from trusted root", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
+
+		applyRotatedState(t, repo, newRootKey, 2, rootSigner, newRootSigner)
+
+		trustedEntry, err := rsl.GetLatestEntry(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
-		rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+
+		// The new root is signed by the initial root but not by the trusted
+		// root that replaced it
+		applyRotatedState(t, repo, untrustedRootKey, 3, rootSigner, untrustedRootSigner)
+
+		err = VerifyNewGittufEntries(testCtx, repo, getEntriesAfter(t, repo, trustedEntry.GetID()))
+		assert.ErrorIs(t, err, ErrRootChainBroken)
+		assert.Contains(t, err.Error(), "root version 3")
+	})
+
+	t.Run("deletion of policy", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
+
+		trustedEntry, err := rsl.GetLatestEntry(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
-		newPolicy := &State{
-			RootPublicKeys:      []*tuf.Key{key},
-			RootEnvelope:        rootEnv,
-			DelegationEnvelopes: map[string]*sslibdsse.Envelope{},
-		}
 
-		err = currentPolicy.VerifyNewState(context.Background(), newPolicy)
-		assert.ErrorIs(t, err, ErrVerifierConditionsUnmet)
+		// The test helper doesn't check the entry, as a remote RSL wouldn't
+		// have been checked either
+		common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceDeletionEntry(PolicyRef), gpgKeyBytes)
+
+		err = VerifyNewGittufEntries(testCtx, repo, getEntriesAfter(t, repo, trustedEntry.GetID()))
+		assert.ErrorIs(t, err, rsl.ErrCannotDeleteGittufRef)
+
+		_, err = LoadCurrentState(testCtx, repo, PolicyRef)
+		assert.ErrorIs(t, err, rsl.ErrCannotDeleteGittufRef)
+	})
+}
+
+func TestVerifyInitialRoot(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
+
+	rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrustedRootKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("trusted root keys", func(t *testing.T) {
+		err := VerifyInitialRoot(testCtx, repo, []*tuf.Key{rootKey}, 1)
+		assert.Nil(t, err)
+
+		err = VerifyInitialRoot(testCtx, repo, []*tuf.Key{untrustedRootKey, rootKey}, 1)
+		assert.Nil(t, err)
+	})
+
+	t.Run("untrusted root keys", func(t *testing.T) {
+		err := VerifyInitialRoot(testCtx, repo, []*tuf.Key{untrustedRootKey}, 1)
+		assert.ErrorIs(t, err, ErrUntrustedInitialRoot)
+	})
+
+	t.Run("trusted threshold not met", func(t *testing.T) {
+		err := VerifyInitialRoot(testCtx, repo, []*tuf.Key{untrustedRootKey, rootKey}, 2)
+		assert.ErrorIs(t, err, ErrUntrustedInitialRoot)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		err := VerifyInitialRoot(testCtx, repo, []*tuf.Key{rootKey}, 0)
+		assert.ErrorIs(t, err, ErrInvalidRootThreshold)
+
+		err = VerifyInitialRoot(testCtx, repo, []*tuf.Key{rootKey}, 2)
+		assert.ErrorIs(t, err, ErrInvalidRootThreshold)
+	})
+
+	t.Run("no root keys", func(t *testing.T) {
+		err := VerifyInitialRoot(testCtx, repo, nil, 1)
+		assert.ErrorIs(t, err, ErrUntrustedInitialRoot)
+	})
+
+	t.Run("initial root keys", func(t *testing.T) {
+		keys, threshold, err := GetInitialRootKeys(repo)
+		assert.Nil(t, err)
+		assert.Equal(t, []*tuf.Key{rootKey}, keys)
+		assert.Equal(t, 1, threshold)
 	})
 }
 
@@ -1300,3 +2840,23 @@ func TestVerifier(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifierEquals(t *testing.T) {
+	rootPubKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := &Verifier{name: "rule", keys: []*tuf.Key{rootPubKey, targetsPubKey}, threshold: 1}
+
+	assert.True(t, verifier.equals(&Verifier{name: "rule", keys: []*tuf.Key{targetsPubKey, rootPubKey}, threshold: 1}))
+	assert.False(t, verifier.equals(&Verifier{name: "other-rule", keys: []*tuf.Key{rootPubKey, targetsPubKey}, threshold: 1}))
+	assert.False(t, verifier.equals(&Verifier{name: "rule", keys: []*tuf.Key{rootPubKey, targetsPubKey}, threshold: 2}))
+	assert.False(t, verifier.equals(&Verifier{name: "rule", keys: []*tuf.Key{rootPubKey}, threshold: 1}))
+	assert.False(t, verifier.equals(&Verifier{name: "rule", keys: []*tuf.Key{rootPubKey, rootPubKey}, threshold: 1}))
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var ErrArchiveCheckpointBeforePolicy = errors.New("RSL cannot be archived at an entry before the first policy entry")
+
+// ArchiveRSL archives the RSL entries before the specified checkpoint entry.
+// All refs are verified as of the checkpoint before the archive is created, as
+// verification subsequently starts at the checkpoint and trusts the state of
+// each ref recorded in the archive. If bundlePath is specified, a bundle
+// containing the archive and the archived entries is also written, so that the
+// archive can be validated elsewhere using VerifyRSLArchive.
+func (r *Repository) ArchiveRSL(ctx context.Context, entryID, bundlePath string) error {
+	existingArchive, err := rsl.LoadArchive(r.r)
+	if err != nil && !errors.Is(err, rsl.ErrRSLArchiveNotFound) {
+		return err
+	}
+
+	slog.Debug("Computing state of refs at checkpoint...")
+	archive, err := rsl.NewArchive(r.r, plumbing.NewHash(entryID))
+	if err != nil {
+		return err
+	}
+
+	if archive.GetReferenceEntry(policy.PolicyRef) == nil {
+		return ErrArchiveCheckpointBeforePolicy
+	}
+
+	archivedTipID := plumbing.NewHash(archive.ArchivedTipID)
+
+	slog.Debug("Verifying policy at checkpoint...")
+	if _, err := policy.LoadStateAtEntry(ctx, r.r, archivedTipID); err != nil {
+		return err
+	}
+
+	refNames := make([]string, 0, len(archive.References))
+	for refName := range archive.References {
+		if strings.HasPrefix(refName, "refs/gittuf/") {
+			continue
+		}
+		if existingArchive != nil {
+			if existing, has := existingArchive.References[refName]; has && *existing == *archive.References[refName] {
+				// The ref was verified when the existing archive was created
+				continue
+			}
+		}
+		refNames = append(refNames, refName)
+	}
+	slices.Sort(refNames)
+
+	for _, refName := range refNames {
+		slog.Debug(fmt.Sprintf("Verifying '%s' at checkpoint...", refName))
+		if _, err := policy.VerifyRefAtEntry(ctx, r.r, refName, archivedTipID); err != nil {
+			return fmt.Errorf("unable to verify '%s' at checkpoint: %w", refName, err)
+		}
+	}
+
+	slog.Debug("Recording archive...")
+	if err := archive.Commit(r.r); err != nil {
+		return err
+	}
+
+	if bundlePath == "" {
+		return nil
+	}
+
+	archiveID, err := gitinterface.GetTip(r.r, rsl.ArchiveRef)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Writing archive bundle to '%s'...", bundlePath))
+	bundleFile, err := os.Create(bundlePath)
+	if err != nil {
+		return err
+	}
+
+	if err := gitinterface.WriteBundle(r.r, bundleFile, map[string]plumbing.Hash{rsl.ArchiveRef: archiveID}); err != nil {
+		bundleFile.Close() //nolint:errcheck
+		return err
+	}
+
+	// The bundle may be truncated if it isn't flushed successfully
+	return bundleFile.Close()
+}
+
+// VerifyRSLArchive validates the RSL archive against the RSL, checking that
+// the archive's checkpoint is in the RSL and that the recorded state of each
+// ref matches the archived entries. If bundlePath is specified, the archive in
+// the bundle written by ArchiveRSL is validated instead of the repository's
+// archive. The bundle's objects are imported into the repository.
+func (r *Repository) VerifyRSLArchive(bundlePath string) error {
+	var (
+		archive *rsl.Archive
+		err     error
+	)
+
+	if bundlePath == "" {
+		slog.Debug("Loading RSL archive...")
+		archive, err = rsl.LoadArchive(r.r)
+		if err != nil {
+			return err
+		}
+	} else {
+		slog.Debug(fmt.Sprintf("Importing archive bundle from '%s'...", bundlePath))
+		bundleFile, err := os.Open(bundlePath)
+		if err != nil {
+			return err
+		}
+		defer bundleFile.Close() //nolint:errcheck
+
+		refs, err := gitinterface.ImportBundle(r.r, bundleFile)
+		if err != nil {
+			return err
+		}
+
+		archiveID, has := refs[rsl.ArchiveRef]
+		if !has {
+			return rsl.ErrRSLArchiveNotFound
+		}
+
+		archive, err = rsl.LoadArchiveFromCommit(r.r, archiveID)
+		if err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("Validating RSL archive...")
+	return archive.Validate(r.r)
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveRSL(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	entryIDs := []plumbing.Hash{}
+	for i := 0; i < 3; i++ {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryIDs = append(entryIDs, common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes))
+	}
+
+	firstPolicyEntry, _, err := rsl.GetFirstReferenceEntryForRef(repo.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("checkpoint before policy", func(t *testing.T) {
+		err := repo.ArchiveRSL(testCtx, firstPolicyEntry.ID.String(), "")
+		assert.ErrorIs(t, err, ErrArchiveCheckpointBeforePolicy)
+	})
+
+	_, err = rsl.LoadArchive(repo.r)
+	assert.ErrorIs(t, err, rsl.ErrRSLArchiveNotFound)
+
+	bundlePath := filepath.Join(t.TempDir(), "archive.bundle")
+	if err := repo.ArchiveRSL(testCtx, entryIDs[1].String(), bundlePath); err != nil {
+		t.Fatal(err)
+	}
+
+	firstEntry, _, err := rsl.GetFirstEntry(repo.r)
+	assert.Nil(t, err)
+	assert.Equal(t, entryIDs[1], firstEntry.ID)
+
+	assert.Nil(t, repo.VerifyRSLArchive(""))
+	assert.Nil(t, repo.VerifyRSLArchive(bundlePath))
+	assert.Nil(t, repo.VerifyRef(testCtx, refName, false))
+
+	t.Run("entries after checkpoint are still verified", func(t *testing.T) {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+		err := repo.VerifyRef(testCtx, refName, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/dev"
@@ -19,7 +20,10 @@ import (
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 )
 
-var ErrNotSigningKey = errors.New("expected signing key")
+var (
+	ErrNotSigningKey        = errors.New("expected signing key")
+	ErrPullRequestNotMerged = errors.New("GitHub pull request is not merged")
+)
 
 var githubClient *github.Client
 
@@ -34,23 +38,80 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 		return dev.ErrNotInDevMode
 	}
 
-	var err error
+	targetRef, fromID, toID, err := r.getMergeChange(targetRef, featureRef)
+	if err != nil {
+		return err
+	}
 
-	targetRef, err = gitinterface.AbsoluteReference(r.r, targetRef)
+	slog.Debug("Loading current set of attestations...")
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
 	if err != nil {
 		return err
 	}
 
-	featureRef, err = gitinterface.AbsoluteReference(r.r, featureRef)
+	// Does a reference authorization already exist for the parameters?
+	hasAuthorization := false
+	env, err := allAttestations.GetReferenceAuthorizationFor(r.r, targetRef, fromID, toID)
+	if err == nil {
+		slog.Debug("Found existing reference authorization...")
+		hasAuthorization = true
+	} else if !errors.Is(err, attestations.ErrAuthorizationNotFound) {
+		return err
+	}
+
+	if !hasAuthorization {
+		// Create a new reference authorization and embed in env
+		slog.Debug("Creating new reference authorization...")
+		statement, err := attestations.NewReferenceAuthorization(targetRef, fromID, toID)
+		if err != nil {
+			return err
+		}
+
+		env, err = dsse.CreateEnvelope(statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	keyID, err := signer.KeyID()
 	if err != nil {
 		return err
 	}
 
-	var (
-		fromID          string
-		featureCommitID string
-		toID            string
-	)
+	slog.Debug(fmt.Sprintf("Signing reference authorization using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetReferenceAuthorization(r.r, env, targetRef, fromID, toID); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add reference authorization for '%s' from '%s' to '%s'", targetRef, fromID, toID)
+
+	slog.Debug("Committing attestations...")
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// getMergeChange identifies the change to the target ref that merging the
+// feature ref into it would make. The target ref is returned as an absolute
+// ref along with the from ID, identified using the last RSL entry for the
+// target ref, and the to ID, which is that of the expected Git tree created by
+// the merge. The commit used to calculate the merge tree ID is identified
+// using the RSL for the feature ref.
+func (r *Repository) getMergeChange(targetRef, featureRef string) (string, string, string, error) {
+	targetRef, err := gitinterface.AbsoluteReference(r.r, targetRef)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	featureRef, err = gitinterface.AbsoluteReference(r.r, featureRef)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var fromID string
 
 	slog.Debug("Identifying current status of target Git reference...")
 	latestTargetEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, targetRef)
@@ -58,7 +119,7 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 		fromID = latestTargetEntry.TargetID.String()
 	} else {
 		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
-			return err
+			return "", "", "", err
 		}
 		fromID = plumbing.ZeroHash.String()
 	}
@@ -66,18 +127,41 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 	slog.Debug("Identifying current status of feature Git reference...")
 	latestFeatureEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, featureRef)
 	if err != nil {
-		// We don't have an RSL entry for the feature ref to use to approve the
-		// merge
-		return err
+		// We don't have an RSL entry for the feature ref to use to compute
+		// the merge
+		return "", "", "", err
 	}
-	featureCommitID = latestFeatureEntry.TargetID.String()
 
 	slog.Debug("Computing expected merge tree...")
-	mergeTreeID, err := gitinterface.GetMergeTree(r.r, fromID, featureCommitID)
+	mergeTreeID, err := gitinterface.GetMergeTree(r.r, fromID, latestFeatureEntry.TargetID.String())
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return targetRef, fromID, mergeTreeID, nil
+}
+
+// AddRSLEntryCoSignature co-signs the RSL reference entry with the specified
+// ID. Co-signatures are combined with the entry's own signature when verifying
+// the entry against a rule that requires a threshold of signatures greater
+// than one. Currently, this is limited to developer mode.
+func (r *Repository) AddRSLEntryCoSignature(ctx context.Context, signer sslibdsse.SignerVerifier, entryID string, signCommit bool) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	slog.Debug("Loading RSL entry...")
+	entry, err := rsl.GetEntry(r.r, plumbing.NewHash(entryID))
 	if err != nil {
 		return err
 	}
-	toID = mergeTreeID
+	referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+	if !isReferenceEntry {
+		return rsl.ErrInvalidRSLEntry
+	}
+
+	refName := referenceEntry.RefName
+	targetID := referenceEntry.TargetID.String()
 
 	slog.Debug("Loading current set of attestations...")
 	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
@@ -85,20 +169,14 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 		return err
 	}
 
-	// Does a reference authorization already exist for the parameters?
-	hasAuthorization := false
-	env, err := allAttestations.GetReferenceAuthorizationFor(r.r, targetRef, fromID, toID)
-	if err == nil {
-		slog.Debug("Found existing reference authorization...")
-		hasAuthorization = true
-	} else if !errors.Is(err, attestations.ErrAuthorizationNotFound) {
-		return err
-	}
+	env, err := allAttestations.GetRSLEntryCoSignatureFor(r.r, refName, entryID, targetID)
+	if err != nil {
+		if !errors.Is(err, attestations.ErrCoSignatureNotFound) {
+			return err
+		}
 
-	if !hasAuthorization {
-		// Create a new reference authorization and embed in env
-		slog.Debug("Creating new reference authorization...")
-		statement, err := attestations.NewReferenceAuthorization(targetRef, fromID, toID)
+		slog.Debug("Creating new RSL entry co-signature...")
+		statement, err := attestations.NewRSLEntryCoSignature(refName, entryID, targetID)
 		if err != nil {
 			return err
 		}
@@ -114,17 +192,17 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 		return err
 	}
 
-	slog.Debug(fmt.Sprintf("Signing reference authorization using '%s'...", keyID))
+	slog.Debug(fmt.Sprintf("Co-signing RSL entry using '%s'...", keyID))
 	env, err = dsse.SignEnvelope(ctx, env, signer)
 	if err != nil {
 		return err
 	}
 
-	if err := allAttestations.SetReferenceAuthorization(r.r, env, targetRef, fromID, toID); err != nil {
+	if err := allAttestations.SetRSLEntryCoSignature(r.r, env, refName, entryID, targetID); err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Add reference authorization for '%s' from '%s' to '%s'", targetRef, fromID, toID)
+	commitMessage := fmt.Sprintf("Add co-signature for RSL entry '%s' by '%s'", entryID, keyID)
 
 	slog.Debug("Committing attestations...")
 	return allAttestations.Commit(r.r, commitMessage, signCommit)
@@ -200,6 +278,63 @@ func (r *Repository) RemoveReferenceAuthorization(ctx context.Context, signer ss
 	return allAttestations.Commit(r.r, commitMessage, signCommit)
 }
 
+// ReferenceApproval describes a change to a Git reference authorized using a
+// reference authorization attestation, along with the IDs of the keys that
+// signed the attestation.
+type ReferenceApproval struct {
+	TargetRef      string   `json:"targetRef"`
+	FromRevisionID string   `json:"fromRevisionID"`
+	TargetTreeID   string   `json:"targetTreeID"`
+	Approvers      []string `json:"approvers"`
+}
+
+// GetReferenceAuthorizations returns the approvals recorded using reference
+// authorization attestations for the specified target ref. Note that the
+// approvers are identified using the key IDs recorded with each signature. The
+// signatures themselves are checked against the applicable policy only during
+// verification.
+func (r *Repository) GetReferenceAuthorizations(targetRef string) ([]*ReferenceApproval, error) {
+	targetRef, err := gitinterface.AbsoluteReference(r.r, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Loading current set of attestations...")
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	envs, err := allAttestations.GetReferenceAuthorizationsForRef(r.r, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := []*ReferenceApproval{}
+	for _, env := range envs {
+		authorization, err := attestations.ParseReferenceAuthorization(env)
+		if err != nil {
+			return nil, err
+		}
+
+		approvers := []string{}
+		for _, signature := range env.Signatures {
+			if !slices.Contains(approvers, signature.KeyID) {
+				approvers = append(approvers, signature.KeyID)
+			}
+		}
+
+		approvals = append(approvals, &ReferenceApproval{
+			TargetRef:      authorization.TargetRef,
+			FromRevisionID: authorization.FromRevisionID,
+			TargetTreeID:   authorization.TargetTreeID,
+			Approvers:      approvers,
+		})
+	}
+
+	return approvals, nil
+}
+
 // AddGitHubPullRequestAttestationForCommit identifies the pull request for a
 // specified commit ID and triggers AddGitHubPullRequestAttestationForNumber for
 // that pull request. Currently, the authentication token for the GitHub API is
@@ -308,6 +443,148 @@ func (r *Repository) addGitHubPullRequestAttestation(ctx context.Context, signer
 	return allAttestations.Commit(r.r, commitMessage, signCommit)
 }
 
+// AddGitHubPullRequestApprovalAttestation records the approvals of the
+// specified merged GitHub pull request in an attestation signed by the GitHub
+// app's key. The approved change is identified using the pull request's merge
+// commit, which must be available locally: the base branch is recorded as
+// moving from the merge commit's first parent to the merge commit's tree.
+// GitHub reviewers are identified as "@<login>" in approverKeyIDs, which maps
+// each reviewer to the ID of their key in the policy. Reviewers whose latest
+// review is not an approval, and reviewers without a mapped key, are not
+// recorded. Currently, the authentication token for the GitHub API is read
+// from the GITHUB_TOKEN environment variable, and this is limited to developer
+// mode.
+func (r *Repository) AddGitHubPullRequestApprovalAttestation(ctx context.Context, signer sslibdsse.SignerVerifier, owner, repository string, pullRequestNumber int, approverKeyIDs map[string]string, signCommit bool) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	client := getGitHubClient()
+
+	slog.Debug(fmt.Sprintf("Inspecting GitHub pull request %d...", pullRequestNumber))
+	pullRequest, _, err := client.PullRequests.Get(ctx, owner, repository, pullRequestNumber)
+	if err != nil {
+		return err
+	}
+	if pullRequest.MergedAt == nil {
+		return ErrPullRequestNotMerged
+	}
+
+	targetRef := plumbing.NewBranchReferenceName(pullRequest.GetBase().GetRef()).String()
+
+	mergeCommitID, err := gitinterface.NewHash(pullRequest.GetMergeCommitSHA())
+	if err != nil {
+		return err
+	}
+	mergeCommit, err := gitinterface.GetCommit(r.r, mergeCommitID)
+	if err != nil {
+		return err
+	}
+
+	fromID := plumbing.ZeroHash.String()
+	if len(mergeCommit.ParentHashes) > 0 {
+		fromID = mergeCommit.ParentHashes[0].String()
+	}
+	toID := mergeCommit.TreeHash.String()
+
+	slog.Debug("Identifying approving reviews...")
+	approvers, err := listGitHubPullRequestApprovers(ctx, client, owner, repository, pullRequestNumber)
+	if err != nil {
+		return err
+	}
+
+	keyIDs := []string{}
+	for _, approver := range approvers {
+		keyID, has := approverKeyIDs[approver]
+		if !has {
+			slog.Debug(fmt.Sprintf("Skipping approval by '%s' as no key is mapped to the reviewer...", approver))
+			continue
+		}
+
+		if !slices.Contains(keyIDs, keyID) {
+			keyIDs = append(keyIDs, keyID)
+		}
+	}
+
+	slog.Debug("Creating GitHub pull request approval attestation...")
+	statement, err := attestations.NewGitHubPullRequestApprovalAttestation(targetRef, fromID, toID, keyIDs)
+	if err != nil {
+		return err
+	}
+
+	env, err := dsse.CreateEnvelope(statement)
+	if err != nil {
+		return err
+	}
+
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing GitHub pull request approval attestation using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetGitHubPullRequestApprovalAttestation(r.r, env, targetRef, fromID, toID); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add GitHub pull request approval attestation for '%s' from '%s' to '%s'\n\nSource: https://github.com/%s/%s/pull/%d\n", targetRef, fromID, toID, owner, repository, pullRequestNumber)
+
+	slog.Debug("Committing attestations...")
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// listGitHubPullRequestApprovers returns the reviewers of the pull request
+// whose latest review is an approval. Reviewers are identified as "@<login>".
+func listGitHubPullRequestApprovers(ctx context.Context, client *github.Client, owner, repository string, pullRequestNumber int) ([]string, error) {
+	options := &github.ListOptions{PerPage: 100}
+
+	// Reviews are listed in chronological order, so a later review by the same
+	// reviewer overrides an earlier one
+	latestStates := map[string]string{}
+	reviewers := []string{}
+	for {
+		page, response, err := client.PullRequests.ListReviews(ctx, owner, repository, pullRequestNumber, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, review := range page {
+			reviewer := fmt.Sprintf("@%s", review.GetUser().GetLogin())
+			switch review.GetState() {
+			case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+				if _, has := latestStates[reviewer]; !has {
+					reviewers = append(reviewers, reviewer)
+				}
+				latestStates[reviewer] = review.GetState()
+			}
+		}
+
+		if response.NextPage == 0 {
+			break
+		}
+		options.Page = response.NextPage
+	}
+
+	approvers := []string{}
+	for _, reviewer := range reviewers {
+		if latestStates[reviewer] == "APPROVED" {
+			approvers = append(approvers, reviewer)
+		}
+	}
+
+	return approvers, nil
+}
+
 func getGitHubClient() *github.Client {
 	if githubClient == nil {
 		githubClient = github.NewClient(nil).WithAuthToken(os.Getenv("GITHUB_TOKEN"))
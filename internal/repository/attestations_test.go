@@ -11,8 +11,12 @@ import (
 	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -115,6 +119,14 @@ func TestAddAndRemoveReferenceAuthorization(t *testing.T) {
 	assert.Equal(t, firstKeyID, env.Signatures[0].KeyID)
 	assert.Equal(t, secondKeyID, env.Signatures[1].KeyID)
 
+	approvals, err := repo.GetReferenceAuthorizations(targetRef)
+	assert.Nil(t, err)
+	assert.Equal(t, []*ReferenceApproval{{TargetRef: absTargetRef, FromRevisionID: fromCommitID, TargetTreeID: targetTreeID, Approvers: []string{firstKeyID, secondKeyID}}}, approvals)
+
+	approvals, err = repo.GetReferenceAuthorizations(featureRef)
+	assert.Nil(t, err)
+	assert.Empty(t, approvals)
+
 	// Remove second authorization attestation signature
 	err = repo.RemoveReferenceAuthorization(context.Background(), secondSigner, absTargetRef, fromCommitID, targetTreeID, false)
 	assert.Nil(t, err)
@@ -131,3 +143,55 @@ func TestAddAndRemoveReferenceAuthorization(t *testing.T) {
 	assert.Len(t, env.Signatures, 1)
 	assert.Equal(t, firstKeyID, env.Signatures[0].KeyID)
 }
+
+func TestAddRSLEntryCoSignature(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+	if err := repo.InitializeNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, r, entry, gpgKeyBytes)
+
+	firstSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.AddRSLEntryCoSignature(testCtx, firstSigner, entryID.String(), false)
+	assert.ErrorIs(t, err, dev.ErrNotInDevMode)
+
+	t.Setenv(dev.DevModeKey, "1")
+
+	err = repo.AddRSLEntryCoSignature(testCtx, firstSigner, entryID.String(), false)
+	assert.Nil(t, err)
+	err = repo.AddRSLEntryCoSignature(testCtx, secondSigner, entryID.String(), false)
+	assert.Nil(t, err)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := allAttestations.GetRSLEntryCoSignatureFor(r, refName, entryID.String(), commitIDs[0].String())
+	assert.Nil(t, err)
+	assert.Len(t, env.Signatures, 2)
+
+	// Only reference entries can be co-signed
+	latestEntry, err := rsl.GetLatestEntry(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotationID := common.CreateTestRSLAnnotationEntryCommit(t, r, rsl.NewAnnotationEntry([]plumbing.Hash{latestEntry.GetID()}, false, "test"), gpgKeyBytes)
+	err = repo.AddRSLEntryCoSignature(testCtx, firstSigner, annotationID.String(), false)
+	assert.ErrorIs(t, err, rsl.ErrInvalidRSLEntry)
+}
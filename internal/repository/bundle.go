@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// BundleManifestSuffix is appended to the path of a bundle to determine where
+// its manifest is stored.
+const BundleManifestSuffix = ".manifest.json"
+
+var ErrBundleManifestMismatch = errors.New("bundle contents do not match manifest")
+
+// BundleManifest describes the contents of a bundle exported by ExportBundle.
+type BundleManifest struct {
+	// FromEntryID is the ID of the first RSL entry in the exported range.
+	FromEntryID string `json:"fromEntryID"`
+
+	// ToEntryID is the ID of the last RSL entry in the exported range. The
+	// bundle's RSL ends at this entry.
+	ToEntryID string `json:"toEntryID"`
+
+	// Refs maps each ref in the bundle to its target. This includes the gittuf
+	// refs and every ref with an RSL entry in the exported range.
+	Refs map[string]string `json:"refs"`
+}
+
+// ExportBundle writes a self-contained Git bundle to bundlePath that can be
+// verified offline using VerifyBundle. The bundle contains the RSL up to toID,
+// the policy and attestations as of toID, and every ref with a reference entry
+// between fromID and toID set to its latest target in that range. All objects
+// reachable from these are included. A manifest describing the bundle is
+// written alongside it, at bundlePath with BundleManifestSuffix appended.
+func (r *Repository) ExportBundle(bundlePath, fromID, toID string) error {
+	fromEntryID := plumbing.NewHash(fromID)
+	toEntryID := plumbing.NewHash(toID)
+
+	slog.Debug("Loading first entry in range...")
+	fromEntry, err := rsl.GetEntry(r.r, fromEntryID)
+	if err != nil {
+		return err
+	}
+	if _, isReferenceEntry := fromEntry.(*rsl.ReferenceEntry); !isReferenceEntry {
+		return rsl.ErrInvalidRSLEntry
+	}
+
+	slog.Debug("Identifying first RSL entry...")
+	firstEntry, _, err := rsl.GetFirstEntry(r.r)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Identifying refs to include in bundle...")
+	entries, _, err := rsl.GetReferenceEntriesInRange(r.r, firstEntry.ID, toEntryID)
+	if err != nil {
+		return err
+	}
+
+	refs := map[string]plumbing.Hash{rsl.Ref: toEntryID}
+
+	// Entries before the checkpoint of an archived RSL are not walked, so the
+	// policy and attestations are seeded using the archive
+	archive, err := rsl.LoadArchive(r.r)
+	if err != nil && !errors.Is(err, rsl.ErrRSLArchiveNotFound) {
+		return err
+	}
+	if archive != nil {
+		for _, refName := range []string{policy.PolicyRef, attestations.Ref} {
+			if archivedEntry := archive.GetReferenceEntry(refName); archivedEntry != nil && !archivedEntry.TargetID.IsZero() {
+				refs[refName] = archivedEntry.TargetID
+			}
+		}
+	}
+
+	inRange := false
+	for _, entry := range entries {
+		if entry.ID == fromEntryID {
+			inRange = true
+		}
+
+		isGittufRef := entry.RefName == policy.PolicyRef || entry.RefName == attestations.Ref
+		if !isGittufRef && (!inRange || strings.HasPrefix(entry.RefName, "refs/gittuf/")) {
+			continue
+		}
+
+		if entry.TargetID.IsZero() {
+			delete(refs, entry.RefName)
+			continue
+		}
+		refs[entry.RefName] = entry.TargetID
+	}
+	if !inRange {
+		return rsl.ErrRSLEntryNotFound
+	}
+
+	manifest := &BundleManifest{
+		FromEntryID: fromEntryID.String(),
+		ToEntryID:   toEntryID.String(),
+		Refs:        make(map[string]string, len(refs)),
+	}
+	for refName, target := range refs {
+		manifest.Refs[refName] = target.String()
+	}
+
+	slog.Debug(fmt.Sprintf("Writing bundle to '%s'...", bundlePath))
+	bundleFile, err := os.Create(bundlePath)
+	if err != nil {
+		return err
+	}
+
+	if err := gitinterface.WriteBundle(r.r, bundleFile, refs); err != nil {
+		bundleFile.Close() //nolint:errcheck
+		return err
+	}
+
+	// The bundle may be truncated if it isn't flushed successfully
+	if err := bundleFile.Close(); err != nil {
+		return err
+	}
+
+	manifestContents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Writing manifest to '%s'...", bundlePath+BundleManifestSuffix))
+	return os.WriteFile(bundlePath+BundleManifestSuffix, manifestContents, 0o644) //nolint:gosec
+}
+
+// VerifyBundle verifies a bundle exported by ExportBundle without network
+// access. The bundle's contents must match its manifest, and the initial root
+// of trust in the bundle must be signed by the key in rootKeyBytes. Each ref in
+// the bundle is then verified against the RSL and policy contained in the
+// bundle.
+func VerifyBundle(ctx context.Context, bundlePath string, rootKeyBytes []byte) error {
+	rootKey, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Loading manifest for bundle '%s'...", bundlePath))
+	manifestContents, err := os.ReadFile(bundlePath + BundleManifestSuffix)
+	if err != nil {
+		return err
+	}
+	manifest := &BundleManifest{}
+	if err := json.Unmarshal(manifestContents, manifest); err != nil {
+		return err
+	}
+
+	slog.Debug("Loading bundle...")
+	bundleFile, err := os.Open(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer bundleFile.Close() //nolint:errcheck
+
+	r, err := gitinterface.ReadBundle(bundleFile)
+	if err != nil {
+		return err
+	}
+	repo := &Repository{r: r}
+
+	slog.Debug("Checking bundle contents against manifest...")
+	if manifest.Refs[rsl.Ref] != manifest.ToEntryID {
+		return fmt.Errorf("%w: RSL does not end at '%s'", ErrBundleManifestMismatch, manifest.ToEntryID)
+	}
+	bundleRefs, err := r.References()
+	if err != nil {
+		return err
+	}
+	bundleRefCount := 0
+	if err := bundleRefs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		bundleRefCount++
+		if manifest.Refs[ref.Name().String()] != ref.Hash().String() {
+			return fmt.Errorf("%w: unexpected target for '%s'", ErrBundleManifestMismatch, ref.Name().String())
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if bundleRefCount != len(manifest.Refs) {
+		return fmt.Errorf("%w: bundle is missing refs", ErrBundleManifestMismatch)
+	}
+
+	if err := repo.VerifyGittufRefsConsistency(); err != nil {
+		return err
+	}
+
+	if err := repo.VerifyRootChain(ctx, []*tuf.Key{rootKey}, 1); err != nil {
+		return err
+	}
+
+	for refName := range manifest.Refs {
+		if strings.HasPrefix(refName, "refs/gittuf/") {
+			continue
+		}
+
+		if err := repo.VerifyRef(ctx, refName, false); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("Bundle verification successful!")
+	return nil
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAndVerifyBundle(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 2, gpgKeyBytes)
+	lastEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[1]), gpgKeyBytes)
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "gittuf.bundle")
+	if err := repo.ExportBundle(bundlePath, firstEntryID.String(), lastEntryID.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	manifestContents, err := os.ReadFile(bundlePath + BundleManifestSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := &BundleManifest{}
+	if err := json.Unmarshal(manifestContents, manifest); err != nil {
+		t.Fatal(err)
+	}
+	expectedManifest := &BundleManifest{
+		FromEntryID: firstEntryID.String(),
+		ToEntryID:   lastEntryID.String(),
+		Refs: map[string]string{
+			rsl.Ref:          lastEntryID.String(),
+			policy.PolicyRef: policyEntry.TargetID.String(),
+			refName:          commitIDs[1].String(),
+		},
+	}
+	assert.Equal(t, expectedManifest, manifest)
+
+	t.Run("successful verification", func(t *testing.T) {
+		err := VerifyBundle(testCtx, bundlePath, rootPubKeyBytes)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unexpected root key", func(t *testing.T) {
+		err := VerifyBundle(testCtx, bundlePath, targetsPubKeyBytes)
+		assert.ErrorIs(t, err, policy.ErrRootChainBroken)
+	})
+
+	t.Run("manifest does not match bundle", func(t *testing.T) {
+		tamperedManifest := *manifest
+		tamperedManifest.Refs = map[string]string{
+			rsl.Ref:          lastEntryID.String(),
+			policy.PolicyRef: policyEntry.TargetID.String(),
+			refName:          commitIDs[0].String(),
+		}
+		tamperedContents, err := json.Marshal(tamperedManifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tamperedBundlePath := filepath.Join(t.TempDir(), "gittuf.bundle")
+		bundleContents, err := os.ReadFile(bundlePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(tamperedBundlePath, bundleContents, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(tamperedBundlePath+BundleManifestSuffix, tamperedContents, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyBundle(testCtx, tamperedBundlePath, rootPubKeyBytes)
+		assert.ErrorIs(t, err, ErrBundleManifestMismatch)
+	})
+
+	t.Run("unknown first entry", func(t *testing.T) {
+		err := repo.ExportBundle(filepath.Join(t.TempDir(), "gittuf.bundle"), plumbing.NewHash("abcdef1234567890").String(), lastEntryID.String())
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+	})
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// ImportCodeOwners is the interface for a user to generate file protection
+// rules in a gittuf policy file from the contents of a CODEOWNERS file. Each
+// owner listed in the file must be mapped to a principal defined in the
+// policy file using ownerPrincipals.
+func (r *Repository) ImportCodeOwners(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, codeOwnersContents []byte, ownerPrincipals map[string]string, signCommit bool) error {
+	slog.Debug("Parsing CODEOWNERS file...")
+	entries, err := policy.ParseCodeOwners(codeOwnersContents)
+	if err != nil {
+		return err
+	}
+
+	state, targetsMetadata, err := r.loadTargetsMetadata(ctx, targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Checking if rules with same names exist...")
+	for index := range entries {
+		if state.HasRuleName(fmt.Sprintf("%s%d", policy.CodeOwnersRulePrefix, index+1)) {
+			return policy.ErrDuplicatedRuleName
+		}
+	}
+
+	slog.Debug("Adding rules to rule file...")
+	targetsMetadata, err = policy.ImportCodeOwners(targetsMetadata, entries, ownerPrincipals)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Import CODEOWNERS rules into policy '%s'", targetsRoleName)
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, signCommit)
+}
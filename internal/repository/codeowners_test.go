@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCodeOwners(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddPrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "docs-team", []*tuf.Key{gpgKey}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddPrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "maintainers", []*tuf.Key{gpgKey}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	ownerPrincipals := map[string]string{"@org/docs": "docs-team", "@org/maintainers": "maintainers"}
+
+	// Directory patterns apply to nested files, which gittuf patterns can't
+	// express
+	err = r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, []byte("/docs/ @org/docs\n"), ownerPrincipals, false)
+	assert.ErrorIs(t, err, policy.ErrUnsupportedCodeOwnersPattern)
+
+	// The catch-all entry for the directory comes first, so the later entry
+	// takes precedence for the file it matches
+	codeOwners := []byte("/docs/* @org/maintainers\n/docs/api.md @org/docs\n")
+
+	err = r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, codeOwners, map[string]string{"@org/maintainers": "maintainers"}, false)
+	assert.ErrorIs(t, err, policy.ErrUnmappedCodeOwner)
+
+	err = r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, codeOwners, ownerPrincipals, false)
+	assert.Nil(t, err)
+
+	rule, err := r.DescribeRule(testCtx, policy.PolicyStagingRef, "codeowners-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"file:docs/*"}, rule.Patterns)
+	assert.Equal(t, []string{"maintainers"}, rule.Principals)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][]string{
+		"file:docs/api.md":         {"codeowners-2"},
+		"file:docs/guide.md":       {"codeowners-1"},
+		"file:docs/guide/intro.md": {},
+	}
+	for path, expectedRuleNames := range tests {
+		verifiers, err := state.FindVerifiersForPath(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ruleNames := []string{}
+		for _, verifier := range verifiers {
+			ruleNames = append(ruleNames, verifier.Name())
+		}
+		assert.Equal(t, expectedRuleNames, ruleNames, path)
+	}
+
+	err = r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, codeOwners, ownerPrincipals, false)
+	assert.ErrorIs(t, err, policy.ErrDuplicatedRuleName)
+}
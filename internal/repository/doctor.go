@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// DefaultDoctorExpiryWindow is the period before the expiry of policy metadata
+// during which Doctor warns that the metadata must be refreshed.
+const DefaultDoctorExpiryWindow = 30 * 24 * time.Hour
+
+// DoctorSeverity indicates how serious a problem identified by Doctor is.
+type DoctorSeverity string
+
+const (
+	// DoctorSeverityInfo is used for findings that do not affect
+	// verification but may be unexpected.
+	DoctorSeverityInfo DoctorSeverity = "info"
+
+	// DoctorSeverityWarning is used for findings that will cause problems
+	// if they are not addressed.
+	DoctorSeverityWarning DoctorSeverity = "warning"
+
+	// DoctorSeverityError is used for findings that cause verification or
+	// other gittuf workflows to fail.
+	DoctorSeverityError DoctorSeverity = "error"
+)
+
+// The checks performed by Doctor.
+const (
+	DoctorCheckMissingRSL         = "missing-rsl"
+	DoctorCheckUnsignedEntry      = "unsigned-entry"
+	DoctorCheckDanglingAnnotation = "dangling-annotation"
+	DoctorCheckStaleRemoteTracker = "stale-remote-tracker"
+	DoctorCheckMissingPolicy      = "missing-policy"
+	DoctorCheckPolicyExpiry       = "policy-expiry"
+)
+
+// DoctorFinding records a problem identified by Doctor.
+type DoctorFinding struct {
+	// Check identifies the check that produced the finding.
+	Check string `json:"check"`
+
+	Severity DoctorSeverity `json:"severity"`
+
+	// Subject identifies what the finding is about, such as an RSL entry
+	// ID, a remote, or a policy file.
+	Subject string `json:"subject,omitempty"`
+
+	Message string `json:"message"`
+
+	// Fix suggests how the problem can be addressed.
+	Fix string `json:"fix,omitempty"`
+}
+
+// Doctor inspects the repository for common problems with its gittuf metadata
+// and returns the findings, grouped by check in the following order: missing
+// RSL, RSL entries (unsigned entries for refs protected by the applicable
+// policy and annotations that refer to entries not before them in the RSL, in
+// order of occurrence), remote RSL trackers that are out of sync with the
+// local RSL, and missing or expiring policy metadata. An empty set of findings
+// is returned for a healthy repository.
+func (r *Repository) Doctor(ctx context.Context) ([]*DoctorFinding, error) {
+	findings := []*DoctorFinding{}
+
+	slog.Debug("Checking RSL exists...")
+	if _, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true); err != nil {
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, err
+		}
+
+		// Without an RSL, none of the other checks apply
+		return append(findings, &DoctorFinding{
+			Check:    DoctorCheckMissingRSL,
+			Severity: DoctorSeverityError,
+			Subject:  rsl.Ref,
+			Message:  "repository does not have an RSL",
+			Fix:      "fetch the RSL from a remote using 'gittuf rsl remote pull <remote>', or create it using 'gittuf rsl record <ref>'",
+		}), nil
+	}
+
+	slog.Debug("Inspecting RSL entries...")
+	entryFindings, err := r.doctorRSLEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, entryFindings...)
+
+	slog.Debug("Inspecting remote RSL trackers...")
+	trackerFindings, err := r.doctorRemoteTrackers()
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, trackerFindings...)
+
+	slog.Debug("Inspecting policy expiry...")
+	policyFindings, err := r.doctorPolicyExpiry(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, policyFindings...)
+
+	return findings, nil
+}
+
+// doctorRSLEntries walks the RSL from its first entry, identifying unsigned
+// reference entries for refs protected by the policy applicable at each entry
+// and annotations that refer to entries that do not precede them.
+func (r *Repository) doctorRSLEntries(ctx context.Context) ([]*DoctorFinding, error) {
+	iterator, err := rsl.NewEntryIterator(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []rsl.Entry{}
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	findings := []*DoctorFinding{}
+	seenEntryIDs := map[plumbing.Hash]bool{}
+	var state *policy.State
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+
+		switch entry := entry.(type) {
+		case *rsl.ReferenceEntry:
+			// Refs are only protected once the policy declares rules
+			if state != nil && state.HasTargetsRole(policy.TargetsRoleName) {
+				authorizedKeys, _, err := state.GetAuthorizedKeysForRef(entry.RefName)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(authorizedKeys) != 0 {
+					commit, err := gitinterface.GetCommit(r.r, entry.ID)
+					if err != nil {
+						return nil, err
+					}
+
+					if len(commit.PGPSignature) == 0 {
+						findings = append(findings, &DoctorFinding{
+							Check:    DoctorCheckUnsignedEntry,
+							Severity: DoctorSeverityError,
+							Subject:  entry.ID.String(),
+							Message:  fmt.Sprintf("RSL entry for '%s' is not signed but the ref is protected by the policy", entry.RefName),
+							Fix:      fmt.Sprintf("skip the entry using 'gittuf rsl annotate --skip -m <message> %s' and record a signed entry for the ref using 'gittuf rsl record %s'", entry.ID.String(), entry.RefName),
+						})
+					}
+				}
+			}
+
+			if entry.RefName == policy.PolicyRef {
+				slog.Debug(fmt.Sprintf("Loading policy from entry '%s'...", entry.ID.String()))
+				state, err = policy.LoadState(ctx, r.r, entry)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case *rsl.AnnotationEntry:
+			for _, entryID := range entry.RSLEntryIDs {
+				if seenEntryIDs[entryID] {
+					continue
+				}
+
+				findings = append(findings, &DoctorFinding{
+					Check:    DoctorCheckDanglingAnnotation,
+					Severity: DoctorSeverityWarning,
+					Subject:  entry.ID.String(),
+					Message:  fmt.Sprintf("annotation refers to '%s', which is not an earlier entry in the RSL, and is ignored for it", entryID.String()),
+					Fix:      "record a new annotation that refers to the intended entry using 'gittuf rsl annotate'",
+				})
+			}
+		}
+
+		seenEntryIDs[entry.GetID()] = true
+	}
+
+	return findings, nil
+}
+
+// doctorRemoteTrackers compares the local RSL with the RSL last fetched from
+// each remote, and identifies trackers for remotes that no longer exist.
+func (r *Repository) doctorRemoteTrackers() ([]*DoctorFinding, error) {
+	localTip, err := gitinterface.GetTip(r.r, rsl.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := r.r.References()
+	if err != nil {
+		return nil, err
+	}
+	defer refs.Close()
+
+	trackers := map[string]plumbing.Hash{}
+	if err := refs.ForEach(func(ref *plumbing.Reference) error {
+		refName := ref.Name().String()
+		if refName == rsl.Ref || !rsl.IsRSLRef(refName) {
+			return nil
+		}
+
+		remoteName := strings.TrimSuffix(strings.TrimPrefix(refName, "refs/remotes/"), "/gittuf/reference-state-log")
+		trackers[remoteName] = ref.Hash()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	remoteNames := make([]string, 0, len(trackers))
+	for remoteName := range trackers {
+		remoteNames = append(remoteNames, remoteName)
+	}
+	sort.Strings(remoteNames)
+
+	findings := []*DoctorFinding{}
+	for _, remoteName := range remoteNames {
+		trackerRef := rsl.RemoteTrackerRef(remoteName)
+		remoteTip := trackers[remoteName]
+
+		if _, err := r.r.Remote(remoteName); err != nil {
+			findings = append(findings, &DoctorFinding{
+				Check:    DoctorCheckStaleRemoteTracker,
+				Severity: DoctorSeverityWarning,
+				Subject:  remoteName,
+				Message:  fmt.Sprintf("'%s' tracks the RSL of a remote that no longer exists", trackerRef),
+				Fix:      fmt.Sprintf("delete the tracker using 'git update-ref -d %s'", trackerRef),
+			})
+			continue
+		}
+
+		if remoteTip == localTip || remoteTip.IsZero() || localTip.IsZero() {
+			continue
+		}
+
+		mergeBase, err := gitinterface.GetMergeBase(r.r, localTip, remoteTip)
+		if err != nil {
+			return nil, err
+		}
+
+		switch mergeBase {
+		case localTip:
+			findings = append(findings, &DoctorFinding{
+				Check:    DoctorCheckStaleRemoteTracker,
+				Severity: DoctorSeverityWarning,
+				Subject:  remoteName,
+				Message:  fmt.Sprintf("local RSL is behind the RSL fetched from '%s'", remoteName),
+				Fix:      fmt.Sprintf("update the local RSL using 'gittuf rsl remote pull %s'", remoteName),
+			})
+		case remoteTip:
+			findings = append(findings, &DoctorFinding{
+				Check:    DoctorCheckStaleRemoteTracker,
+				Severity: DoctorSeverityInfo,
+				Subject:  remoteName,
+				Message:  fmt.Sprintf("local RSL has entries that have not been pushed to '%s'", remoteName),
+				Fix:      fmt.Sprintf("push the local RSL using 'gittuf rsl remote push %s'", remoteName),
+			})
+		default:
+			findings = append(findings, &DoctorFinding{
+				Check:    DoctorCheckStaleRemoteTracker,
+				Severity: DoctorSeverityError,
+				Subject:  remoteName,
+				Message:  fmt.Sprintf("local RSL has diverged from the RSL fetched from '%s'", remoteName),
+				Fix:      "reconcile the local RSL with the remote RSL before pushing",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// doctorPolicyExpiry identifies policy metadata that has expired or expires
+// within DefaultDoctorExpiryWindow of now. Metadata that has expired but is
+// still accepted due to the repository's expiration grace period is reported
+// as a warning.
+func (r *Repository) doctorPolicyExpiry(ctx context.Context, now time.Time) ([]*DoctorFinding, error) {
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+
+		return []*DoctorFinding{{
+			Check:    DoctorCheckMissingPolicy,
+			Severity: DoctorSeverityInfo,
+			Subject:  policy.PolicyRef,
+			Message:  "repository does not have a gittuf policy, so changes to refs are not verified",
+			Fix:      "create a policy using 'gittuf trust init' and 'gittuf policy init', then apply it using 'gittuf apply'",
+		}}, nil
+	}
+
+	expirations, err := state.GetMetadataExpirations()
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []*DoctorFinding{}
+	for _, expiration := range expirations {
+		finding := &DoctorFinding{
+			Check:   DoctorCheckPolicyExpiry,
+			Subject: expiration.RoleName,
+			Fix:     "extend the expiry using 'gittuf policy refresh-expirations' and apply the policy using 'gittuf apply'",
+		}
+		expires := expiration.Expires.Format(time.RFC3339)
+
+		switch {
+		case now.After(expiration.Expires.Add(r.expirationGracePeriod)):
+			finding.Severity = DoctorSeverityError
+			finding.Message = fmt.Sprintf("policy metadata '%s' expired at %s", expiration.RoleName, expires)
+		case now.After(expiration.Expires):
+			finding.Severity = DoctorSeverityWarning
+			finding.Message = fmt.Sprintf("policy metadata '%s' expired at %s and is only accepted due to the expiration grace period", expiration.RoleName, expires)
+		case now.Add(DefaultDoctorExpiryWindow).After(expiration.Expires):
+			finding.Severity = DoctorSeverityWarning
+			finding.Message = fmt.Sprintf("policy metadata '%s' expires at %s", expiration.RoleName, expires)
+		default:
+			continue
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctor(t *testing.T) {
+	t.Run("missing RSL", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := &Repository{r: repo}
+
+		findings, err := r.Doctor(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{DoctorCheckMissingRSL}, doctorChecks(findings))
+		assert.Equal(t, DoctorSeverityError, findings[0].Severity)
+	})
+
+	t.Run("missing policy", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := &Repository{r: repo}
+
+		if err := rsl.InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		findings, err := r.Doctor(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{DoctorCheckMissingPolicy}, doctorChecks(findings))
+		assert.Equal(t, DoctorSeverityInfo, findings[0].Severity)
+	})
+
+	t.Run("RSL entries", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		findings, err := r.Doctor(testCtx)
+		assert.Nil(t, err)
+		assert.Empty(t, findings)
+
+		if _, err := gitinterface.Commit(r.r, gitinterface.EmptyTree(), "refs/heads/main", "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gitinterface.Commit(r.r, gitinterface.EmptyTree(), "refs/heads/feature", "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Only main is protected by the policy
+		if err := r.RecordRSLEntriesForReferences([]string{"main", "feature"}, false); err != nil {
+			t.Fatal(err)
+		}
+		mainEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, "refs/heads/main")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Annotations must refer to earlier entries
+		missingEntryID := plumbing.NewHash("abcdef1234567890")
+		message := fmt.Sprintf("%s\n\n%s: %s\n%s: true", rsl.AnnotationEntryHeader, rsl.EntryIDKey, missingEntryID.String(), rsl.SkipKey)
+		annotationID, err := gitinterface.Commit(r.r, gitinterface.EmptyTree(), rsl.Ref, message, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		findings, err = r.Doctor(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{DoctorCheckUnsignedEntry, DoctorCheckDanglingAnnotation}, doctorChecks(findings))
+		assert.Equal(t, mainEntry.ID.String(), findings[0].Subject)
+		assert.Equal(t, DoctorSeverityError, findings[0].Severity)
+		assert.Equal(t, annotationID.String(), findings[1].Subject)
+		assert.Equal(t, DoctorSeverityWarning, findings[1].Severity)
+	})
+
+	t.Run("remote trackers", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		localTip, err := gitinterface.GetTip(r.r, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gitinterface.Commit(r.r, gitinterface.EmptyTree(), "refs/heads/feature", "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.RecordRSLEntryForReference("feature", false); err != nil {
+			t.Fatal(err)
+		}
+		newLocalTip, err := gitinterface.GetTip(r.r, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trackerRef := rsl.RemoteTrackerRef("origin")
+		if err := r.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(trackerRef), localTip)); err != nil {
+			t.Fatal(err)
+		}
+
+		// The tracker's remote does not exist
+		findings, err := r.Doctor(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{DoctorCheckStaleRemoteTracker}, doctorChecks(findings))
+		assert.Equal(t, DoctorSeverityWarning, findings[0].Severity)
+		assert.Contains(t, findings[0].Fix, "git update-ref -d")
+
+		if _, err := r.r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://example.com/repo.git"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Local RSL is ahead of the remote
+		findings, err = r.Doctor(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{DoctorCheckStaleRemoteTracker}, doctorChecks(findings))
+		assert.Equal(t, DoctorSeverityInfo, findings[0].Severity)
+
+		// Local RSL is behind the remote
+		if err := r.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(trackerRef), newLocalTip)); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.r.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, localTip)); err != nil {
+			t.Fatal(err)
+		}
+		findings, err = r.Doctor(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{DoctorCheckStaleRemoteTracker}, doctorChecks(findings))
+		assert.Equal(t, DoctorSeverityWarning, findings[0].Severity)
+		assert.Contains(t, findings[0].Fix, "gittuf rsl remote pull origin")
+
+		// Local RSL matches the remote
+		if err := r.r.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, newLocalTip)); err != nil {
+			t.Fatal(err)
+		}
+		findings, err = r.Doctor(testCtx)
+		assert.Nil(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("policy expiry", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		// Metadata is created with an expiry one year from now
+		findings, err := r.doctorPolicyExpiry(testCtx, time.Now().AddDate(0, 11, 20))
+		assert.Nil(t, err)
+		assert.Len(t, findings, 2)
+		for _, finding := range findings {
+			assert.Equal(t, DoctorCheckPolicyExpiry, finding.Check)
+			assert.Equal(t, DoctorSeverityWarning, finding.Severity)
+		}
+
+		findings, err = r.doctorPolicyExpiry(testCtx, time.Now().AddDate(2, 0, 0))
+		assert.Nil(t, err)
+		assert.Len(t, findings, 2)
+		assert.Equal(t, DoctorSeverityError, findings[0].Severity)
+
+		r.SetExpirationGracePeriod(2 * 365 * 24 * time.Hour)
+		findings, err = r.doctorPolicyExpiry(testCtx, time.Now().AddDate(2, 0, 0))
+		assert.Nil(t, err)
+		assert.Len(t, findings, 2)
+		assert.Equal(t, DoctorSeverityWarning, findings[0].Severity)
+	})
+}
+
+func doctorChecks(findings []*DoctorFinding) []string {
+	checks := []string{}
+	for _, finding := range findings {
+		checks = append(checks, finding.Check)
+	}
+	return checks
+}
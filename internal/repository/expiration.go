@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-git/v5"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrNoMetadataToRefresh = errors.New("no unexpired policy metadata can be refreshed using the presented key")
+
+// SetExpirationGracePeriod sets the period past the expiry of policy metadata
+// during which the metadata is still accepted when verifying the repository.
+func (r *Repository) SetExpirationGracePeriod(gracePeriod time.Duration) {
+	r.expirationGracePeriod = gracePeriod
+}
+
+// RefreshPolicyExpirations extends the expiry of the policy metadata the
+// signer is authorized to sign. The expiry of each such metadata file that has
+// not yet expired is set to the specified validity period from now, and the
+// file is re-signed using the signer. As the metadata changes, any other
+// signatures on it are dropped and must be added again by the other key
+// holders using the corresponding sign commands. Expired metadata is not
+// refreshed.
+func (r *Repository) RefreshPolicyExpirations(ctx context.Context, signer sslibdsse.SignerVerifier, validity time.Duration, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	roleNames, err := state.GetRolesForKey(keyID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expires := now.Add(validity).Format(time.RFC3339)
+
+	refreshedRoleNames := []string{}
+	for _, roleName := range roleNames {
+		currentExpiry, err := state.GetMetadataExpiration(roleName)
+		if err != nil {
+			return err
+		}
+		if !currentExpiry.IsZero() && now.After(currentExpiry) {
+			slog.Debug(fmt.Sprintf("Skipping expired metadata '%s'...", roleName))
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Refreshing expiry of '%s' using '%s'...", roleName, keyID))
+		var env *sslibdsse.Envelope
+		if roleName == policy.RootRoleName {
+			rootMetadata, err := state.GetRootMetadata()
+			if err != nil {
+				return err
+			}
+			rootMetadata.SetExpires(expires)
+			rootMetadata.SetVersion(rootMetadata.Version + 1)
+
+			env, err = dsse.CreateEnvelope(rootMetadata)
+			if err != nil {
+				return err
+			}
+		} else {
+			targetsMetadata, err := state.GetTargetsMetadata(roleName)
+			if err != nil {
+				return err
+			}
+			targetsMetadata.SetExpires(expires)
+			targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+			env, err = dsse.CreateEnvelope(targetsMetadata)
+			if err != nil {
+				return err
+			}
+		}
+
+		env, err = dsse.SignEnvelope(ctx, env, signer)
+		if err != nil {
+			return err
+		}
+
+		switch roleName {
+		case policy.RootRoleName:
+			state.RootEnvelope = env
+		case policy.TargetsRoleName:
+			state.TargetsEnvelope = env
+		default:
+			state.DelegationEnvelopes[roleName] = env
+		}
+
+		refreshedRoleNames = append(refreshedRoleNames, roleName)
+	}
+
+	if len(refreshedRoleNames) == 0 {
+		return ErrNoMetadataToRefresh
+	}
+
+	commitMessage := fmt.Sprintf("Refresh expiry of policy metadata '%s'", strings.Join(refreshedRoleNames, "', '"))
+
+	slog.Debug("Committing policy...")
+	return state.Commit(r.r, commitMessage, signCommit)
+}
+
+// verifyPolicyExpiration checks that the current policy recorded in policyRef
+// of policyRepo has not expired, accounting for the repository's expiration
+// grace period.
+func (r *Repository) verifyPolicyExpiration(ctx context.Context, policyRepo *git.Repository, policyRef string) error {
+	slog.Debug("Verifying policy has not expired...")
+	state, err := policy.LoadCurrentState(ctx, policyRepo, policyRef)
+	if err != nil {
+		return err
+	}
+
+	return state.VerifyExpiration(time.Now(), r.expirationGracePeriod)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshPolicyExpirations(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := policy.LoadCurrentState(testCtx, repo.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalVersion := targetsMetadata.Version
+	originalRootExpiry, err := state.GetMetadataExpiration(policy.RootRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RefreshPolicyExpirations(testCtx, targetsSigner, 2*policy.DefaultMetadataValidity, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, repo.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, originalVersion+1, targetsMetadata.Version)
+
+	targetsExpiry, err := state.GetMetadataExpiration(policy.TargetsRoleName)
+	assert.Nil(t, err)
+	assert.True(t, targetsExpiry.After(time.Now().Add(policy.DefaultMetadataValidity)))
+
+	// The targets key is not authorized to sign root metadata
+	rootExpiry, err := state.GetMetadataExpiration(policy.RootRoleName)
+	assert.Nil(t, err)
+	assert.Equal(t, originalRootExpiry, rootExpiry)
+
+	// Verification fails once the policy expires, unless within the grace
+	// period
+	err = repo.RefreshPolicyExpirations(testCtx, targetsSigner, -time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.ApplyPolicy(testCtx, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyRef(testCtx, refName, true)
+	assert.ErrorIs(t, err, policy.ErrMetadataExpired)
+
+	repo.SetExpirationGracePeriod(2 * time.Hour)
+	err = repo.VerifyRef(testCtx, refName, true)
+	assert.Nil(t, err)
+
+	// Expired metadata cannot be refreshed
+	err = repo.RefreshPolicyExpirations(testCtx, targetsSigner, policy.DefaultMetadataValidity, false)
+	assert.ErrorIs(t, err, ErrNoMetadataToRefresh)
+}
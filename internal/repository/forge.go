@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/forge"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrNoForgeResolver = errors.New("no key resolver available for forge")
+
+// ForgePrincipalDrift records the differences between the keys pinned for a
+// principal and the keys currently published by the principal's forge
+// account.
+type ForgePrincipalDrift struct {
+	PrincipalName string `json:"principalName"`
+	ForgeAccount  string `json:"forgeAccount"`
+
+	// AddedKeyIDs contains the IDs of keys published on the forge that are
+	// not pinned for the principal.
+	AddedKeyIDs []string `json:"addedKeyIDs"`
+
+	// RemovedKeyIDs contains the IDs of keys pinned for the principal that
+	// are no longer published on the forge.
+	RemovedKeyIDs []string `json:"removedKeyIDs"`
+}
+
+// HasDrift returns true if the pinned keys differ from the published keys.
+func (d *ForgePrincipalDrift) HasDrift() bool {
+	return len(d.AddedKeyIDs) > 0 || len(d.RemovedKeyIDs) > 0
+}
+
+// AddForgePrincipal is the interface for a user to define a named principal in
+// a gittuf policy file using the signing keys published by the specified user
+// on a forge. The keys are fetched using resolver and pinned in the policy, so
+// changes on the forge do not affect the policy until the principal is
+// refreshed using RefreshForgePrincipals.
+func (r *Repository) AddForgePrincipal(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, principalName string, resolver forge.KeyResolver, username string, threshold int, signCommit bool) error {
+	state, targetsMetadata, err := r.loadTargetsMetadata(ctx, targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	forgeAccount := forge.Account(resolver.Forge(), username)
+	slog.Debug(fmt.Sprintf("Fetching keys published by '%s'...", forgeAccount))
+	keys, err := resolver.ResolveKeys(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding principal to rule file...")
+	targetsMetadata, err = policy.AddPrincipal(targetsMetadata, principalName, keys, threshold)
+	if err != nil {
+		return err
+	}
+	targetsMetadata, err = policy.SetPrincipalForgeAccount(targetsMetadata, principalName, forgeAccount)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add principal '%s' for '%s' to policy '%s'", principalName, forgeAccount, targetsRoleName)
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, signCommit)
+}
+
+// CheckForgePrincipals compares the keys pinned for each principal in the
+// specified gittuf policy file that was added from a forge account with the
+// keys currently published on the forge. The resolvers are keyed by forge
+// name. The drift for each such principal is returned, sorted by principal
+// name, whether or not the keys differ.
+func (r *Repository) CheckForgePrincipals(ctx context.Context, targetsRoleName string, resolvers map[string]forge.KeyResolver) ([]*ForgePrincipalDrift, error) {
+	_, targetsMetadata, err := r.loadTargetsMetadata(ctx, targetsRoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts, _, err := checkForgePrincipals(ctx, targetsMetadata, resolvers)
+	return drifts, err
+}
+
+// RefreshForgePrincipals is the interface for a user to update the keys pinned
+// for the principals in the specified gittuf policy file that were added from
+// forge accounts, so that they match the keys currently published on the
+// forge. Each principal's threshold is retained. The drift for each principal
+// is returned. If no principal has drifted, the policy is not updated.
+func (r *Repository) RefreshForgePrincipals(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, resolvers map[string]forge.KeyResolver, signCommit bool) ([]*ForgePrincipalDrift, error) {
+	state, targetsMetadata, err := r.loadTargetsMetadata(ctx, targetsRoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts, publishedKeys, err := checkForgePrincipals(ctx, targetsMetadata, resolvers)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := false
+	for _, drift := range drifts {
+		if !drift.HasDrift() {
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Updating keys pinned for principal '%s'...", drift.PrincipalName))
+		principal := targetsMetadata.Delegations.Principals[drift.PrincipalName]
+		targetsMetadata, err = policy.AddPrincipal(targetsMetadata, drift.PrincipalName, publishedKeys[drift.PrincipalName], principal.Threshold)
+		if err != nil {
+			return nil, fmt.Errorf("unable to refresh principal '%s': %w", drift.PrincipalName, err)
+		}
+		targetsMetadata, err = policy.SetPrincipalForgeAccount(targetsMetadata, drift.PrincipalName, drift.ForgeAccount)
+		if err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+
+	if !updated {
+		slog.Debug("Pinned keys match published keys, nothing to refresh")
+		return drifts, nil
+	}
+
+	commitMessage := fmt.Sprintf("Refresh forge principals in policy '%s'", targetsRoleName)
+	if err := r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, signCommit); err != nil {
+		return nil, err
+	}
+
+	return drifts, nil
+}
+
+// checkForgePrincipals returns the drift for each principal in targetsMetadata
+// added from a forge account, as well as the keys currently published for each
+// such principal.
+func checkForgePrincipals(ctx context.Context, targetsMetadata *tuf.TargetsMetadata, resolvers map[string]forge.KeyResolver) ([]*ForgePrincipalDrift, map[string][]*tuf.Key, error) {
+	drifts := []*ForgePrincipalDrift{}
+	publishedKeys := map[string][]*tuf.Key{}
+
+	for _, principal := range targetsMetadata.Delegations.Principals {
+		if principal.ForgeAccount == "" {
+			continue
+		}
+
+		forgeName, username, err := forge.ParseAccount(principal.ForgeAccount)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolver, has := resolvers[forgeName]
+		if !has {
+			return nil, nil, fmt.Errorf("%w: '%s'", ErrNoForgeResolver, forgeName)
+		}
+
+		slog.Debug(fmt.Sprintf("Fetching keys published by '%s'...", principal.ForgeAccount))
+		keys, err := resolver.ResolveKeys(ctx, username)
+		if err != nil {
+			return nil, nil, err
+		}
+		publishedKeys[principal.Name] = keys
+
+		drift := &ForgePrincipalDrift{
+			PrincipalName: principal.Name,
+			ForgeAccount:  principal.ForgeAccount,
+			AddedKeyIDs:   []string{},
+			RemovedKeyIDs: []string{},
+		}
+		publishedKeyIDs := []string{}
+		for _, key := range keys {
+			publishedKeyIDs = append(publishedKeyIDs, key.KeyID)
+			if !slices.Contains(principal.KeyIDs, key.KeyID) {
+				drift.AddedKeyIDs = append(drift.AddedKeyIDs, key.KeyID)
+			}
+		}
+		for _, keyID := range principal.KeyIDs {
+			if !slices.Contains(publishedKeyIDs, keyID) {
+				drift.RemovedKeyIDs = append(drift.RemovedKeyIDs, keyID)
+			}
+		}
+
+		drifts = append(drifts, drift)
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].PrincipalName < drifts[j].PrincipalName
+	})
+
+	return drifts, publishedKeys, nil
+}
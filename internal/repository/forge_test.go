@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/forge"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+type testKeyResolver struct {
+	keys map[string][]*tuf.Key
+}
+
+func (t *testKeyResolver) Forge() string {
+	return forge.GitHub
+}
+
+func (t *testKeyResolver) ResolveKeys(_ context.Context, username string) ([]*tuf.Key, error) {
+	keys, has := t.keys[username]
+	if !has {
+		return nil, forge.ErrUserNotFound
+	}
+	return keys, nil
+}
+
+func TestForgePrincipals(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := &testKeyResolver{keys: map[string][]*tuf.Key{"alice": {gpgKey}}}
+	resolvers := map[string]forge.KeyResolver{forge.GitHub: resolver}
+
+	err = r.AddForgePrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "alice", resolver, "bob", 1, false)
+	assert.ErrorIs(t, err, forge.ErrUserNotFound)
+
+	err = r.AddForgePrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "alice", resolver, "alice", 1, false)
+	assert.Nil(t, err)
+
+	drifts, err := r.CheckForgePrincipals(testCtx, policy.TargetsRoleName, resolvers)
+	assert.Nil(t, err)
+	assert.Len(t, drifts, 1)
+	assert.Equal(t, "github:alice", drifts[0].ForgeAccount)
+	assert.False(t, drifts[0].HasDrift())
+
+	// alice rotates their key on the forge
+	resolver.keys["alice"] = []*tuf.Key{targetsPubKey}
+
+	drifts, err = r.CheckForgePrincipals(testCtx, policy.TargetsRoleName, resolvers)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{targetsPubKey.KeyID}, drifts[0].AddedKeyIDs)
+	assert.Equal(t, []string{gpgKey.KeyID}, drifts[0].RemovedKeyIDs)
+
+	_, err = r.CheckForgePrincipals(testCtx, policy.TargetsRoleName, map[string]forge.KeyResolver{})
+	assert.ErrorIs(t, err, ErrNoForgeResolver)
+
+	drifts, err = r.RefreshForgePrincipals(testCtx, targetsSigner, policy.TargetsRoleName, resolvers, false)
+	assert.Nil(t, err)
+	assert.True(t, drifts[0].HasDrift())
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal := targetsMetadata.Delegations.Principals["alice"]
+	assert.Equal(t, []string{targetsPubKey.KeyID}, principal.KeyIDs)
+	assert.Equal(t, "github:alice", principal.ForgeAccount)
+
+	drifts, err = r.CheckForgePrincipals(testCtx, policy.TargetsRoleName, resolvers)
+	assert.Nil(t, err)
+	assert.False(t, drifts[0].HasDrift())
+}
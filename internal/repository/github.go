@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/google/go-github/v61/github"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// GitHubBranchProtectionRulePrefix is the prefix used for the names of rules
+// generated from GitHub branch protection settings.
+const GitHubBranchProtectionRulePrefix = "github-"
+
+var (
+	ErrNoProtectedBranches   = errors.New("no protected branches found in GitHub repository")
+	ErrUnmappedGitHubAccount = errors.New("GitHub user or team is not mapped to a principal")
+)
+
+// ImportGitHubBranchProtection is the interface for a user to generate rules
+// in a gittuf policy file from the branch protection settings of a GitHub
+// repository. If no branches are specified, all protected branches are
+// imported. For each branch, a rule is created that trusts the principals
+// mapped to the users and teams allowed to push to the branch, or to all
+// collaborators with push access if pushes are not restricted. GitHub users
+// are identified as "@<login>" and teams as "@<owner>/<slug>" in
+// ownerPrincipals, matching the format used in CODEOWNERS files. The rule's
+// threshold accounts for the pusher and the number of approving reviews
+// required on GitHub, and signed commits are required if GitHub requires
+// them. Currently, the authentication token for the GitHub API is read from
+// the GITHUB_TOKEN environment variable.
+func (r *Repository) ImportGitHubBranchProtection(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, owner, repository string, branches []string, ownerPrincipals map[string]string, signCommit bool) error {
+	client := getGitHubClient()
+
+	if len(branches) == 0 {
+		slog.Debug("Identifying protected branches in GitHub repository...")
+		var err error
+		branches, err = listGitHubProtectedBranches(ctx, client, owner, repository)
+		if err != nil {
+			return err
+		}
+
+		if len(branches) == 0 {
+			return ErrNoProtectedBranches
+		}
+	}
+
+	state, targetsMetadata, err := r.loadTargetsMetadata(ctx, targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	var pushCollaborators []string
+	for _, branch := range branches {
+		ruleName := GitHubBranchProtectionRulePrefix + branch
+		if state.HasRuleName(ruleName) {
+			return fmt.Errorf("%w: '%s'", policy.ErrDuplicatedRuleName, ruleName)
+		}
+
+		slog.Debug(fmt.Sprintf("Inspecting protection for branch '%s'...", branch))
+		protection, _, err := client.Repositories.GetBranchProtection(ctx, owner, repository, branch)
+		if err != nil {
+			return err
+		}
+
+		var accounts []string
+		if protection.Restrictions != nil {
+			for _, user := range protection.Restrictions.Users {
+				accounts = append(accounts, fmt.Sprintf("@%s", user.GetLogin()))
+			}
+			for _, team := range protection.Restrictions.Teams {
+				accounts = append(accounts, fmt.Sprintf("@%s/%s", owner, team.GetSlug()))
+			}
+		} else {
+			if pushCollaborators == nil {
+				slog.Debug("Identifying collaborators with push access...")
+				pushCollaborators, err = listGitHubPushCollaborators(ctx, client, owner, repository)
+				if err != nil {
+					return err
+				}
+			}
+			accounts = pushCollaborators
+		}
+
+		principalNames := []string{}
+		for _, account := range accounts {
+			principalName, has := ownerPrincipals[account]
+			if !has {
+				return fmt.Errorf("%w: '%s'", ErrUnmappedGitHubAccount, account)
+			}
+
+			if !slices.Contains(principalNames, principalName) {
+				principalNames = append(principalNames, principalName)
+			}
+		}
+
+		// GitHub's approval count does not include the pusher, so we add one
+		threshold := 1
+		if protection.RequiredPullRequestReviews != nil {
+			threshold += protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+		}
+		if len(principalNames) < threshold {
+			return fmt.Errorf("%w for branch '%s'", policy.ErrCannotMeetThreshold, branch)
+		}
+
+		slog.Debug(fmt.Sprintf("Adding rule '%s' to rule file...", ruleName))
+		targetsMetadata, err = policy.AddDelegation(targetsMetadata, ruleName, []*tuf.Key{}, []string{fmt.Sprintf("git:refs/heads/%s", branch)}, threshold)
+		if err != nil {
+			return err
+		}
+
+		targetsMetadata, err = policy.SetDelegationPrincipals(targetsMetadata, ruleName, principalNames)
+		if err != nil {
+			return err
+		}
+
+		if protection.RequiredSignatures.GetEnabled() {
+			targetsMetadata, err = policy.SetRequireSignedCommits(targetsMetadata, ruleName, true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	commitMessage := fmt.Sprintf("Import GitHub branch protection rules into policy '%s'\n\nSource: https://github.com/%s/%s\n", targetsRoleName, owner, repository)
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, signCommit)
+}
+
+func listGitHubProtectedBranches(ctx context.Context, client *github.Client, owner, repository string) ([]string, error) {
+	protected := true
+	options := &github.BranchListOptions{Protected: &protected, ListOptions: github.ListOptions{PerPage: 100}}
+
+	branches := []string{}
+	for {
+		page, response, err := client.Repositories.ListBranches(ctx, owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, branch := range page {
+			branches = append(branches, branch.GetName())
+		}
+
+		if response.NextPage == 0 {
+			return branches, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+func listGitHubPushCollaborators(ctx context.Context, client *github.Client, owner, repository string) ([]string, error) {
+	options := &github.ListCollaboratorsOptions{Permission: "push", ListOptions: github.ListOptions{PerPage: 100}}
+
+	collaborators := []string{}
+	for {
+		page, response, err := client.Repositories.ListCollaborators(ctx, owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range page {
+			collaborators = append(collaborators, fmt.Sprintf("@%s", user.GetLogin()))
+		}
+
+		if response.NextPage == 0 {
+			return collaborators, nil
+		}
+		options.Page = response.NextPage
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/google/go-github/v61/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportGitHubBranchProtection(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/gittuf/demo/branches", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"name": "main", "protected": true}, {"name": "release", "protected": true}]`)
+	})
+	mux.HandleFunc("/repos/gittuf/demo/branches/main/protection", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{
+			"required_pull_request_reviews": {"required_approving_review_count": 1},
+			"restrictions": {"users": [{"login": "alice"}], "teams": [{"slug": "maintainers"}]},
+			"required_signatures": {"enabled": true}
+		}`)
+	})
+	mux.HandleFunc("/repos/gittuf/demo/branches/release/protection", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/repos/gittuf/demo/collaborators", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"login": "alice"}, {"login": "bob"}]`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	githubClient = client
+	t.Cleanup(func() { githubClient = nil })
+
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddPrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "alice", []*tuf.Key{gpgKey}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddPrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "maintainers", []*tuf.Key{targetsPubKey}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	ownerPrincipals := map[string]string{
+		"@alice":              "alice",
+		"@gittuf/maintainers": "maintainers",
+	}
+
+	err = r.ImportGitHubBranchProtection(testCtx, targetsSigner, policy.TargetsRoleName, "gittuf", "demo", nil, ownerPrincipals, false)
+	assert.ErrorIs(t, err, ErrUnmappedGitHubAccount)
+
+	ownerPrincipals["@bob"] = "maintainers"
+	err = r.ImportGitHubBranchProtection(testCtx, targetsSigner, policy.TargetsRoleName, "gittuf", "demo", nil, ownerPrincipals, false)
+	assert.Nil(t, err)
+
+	rule, err := r.DescribeRule(testCtx, policy.PolicyStagingRef, "github-main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"git:refs/heads/main"}, rule.Patterns)
+	assert.Equal(t, []string{"alice", "maintainers"}, rule.Principals)
+	assert.Equal(t, 2, rule.Threshold)
+	assert.True(t, rule.RequireSignedCommits)
+
+	rule, err = r.DescribeRule(testCtx, policy.PolicyStagingRef, "github-release")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"git:refs/heads/release"}, rule.Patterns)
+	assert.Equal(t, []string{"alice", "maintainers"}, rule.Principals)
+	assert.Equal(t, 1, rule.Threshold)
+	assert.False(t, rule.RequireSignedCommits)
+
+	err = r.ImportGitHubBranchProtection(testCtx, targetsSigner, policy.TargetsRoleName, "gittuf", "demo", []string{"main"}, ownerPrincipals, false)
+	assert.ErrorIs(t, err, policy.ErrDuplicatedRuleName)
+}
@@ -3,14 +3,32 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"os"
 	"path"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	// HooksRef defines the Git namespace used to store gittuf-managed hooks.
+	// Each hook is stored as a blob named after the hook type.
+	HooksRef = "refs/gittuf/hooks"
+
+	hooksCommitMessage = "Update gittuf hooks"
 )
 
+var ErrHookModified = errors.New("installed hook does not match gittuf hook")
+
 type ErrHookExists struct {
 	HookType HookType
 }
@@ -21,28 +39,173 @@ func (e *ErrHookExists) Error() string {
 
 type HookType string
 
-var HookPrePush = HookType("pre-push")
+var (
+	HookPrePush   = HookType("pre-push")
+	HookPostMerge = HookType("post-merge")
+)
+
+// managedHooks lists the hooks installed by InstallHooks in the order they are
+// stored in the hooks tree.
+var managedHooks = []HookType{HookPostMerge, HookPrePush}
+
+// GetHookScript returns the gittuf-managed script for the hook type, if one
+// exists.
+func GetHookScript(hookType HookType) ([]byte, bool) {
+	switch hookType {
+	case HookPrePush:
+		return prePushHookScript, true
+	case HookPostMerge:
+		return postMergeHookScript, true
+	}
+
+	return nil, false
+}
+
+// InstallHooks writes the gittuf-managed pre-push and post-merge hooks to the
+// repository's .git/hooks folder. The pre-push hook records RSL entries for the
+// pushed refs and the post-merge hook fetches the remote RSL. Existing hooks
+// are not overwritten unless force is set. The hooks are only installed
+// locally, RecordHooks must be used to record them in the repository.
+func (r *Repository) InstallHooks(force bool) error {
+	hooksDir, err := r.getHooksDir()
+	if err != nil {
+		return err
+	}
+
+	for _, hookType := range managedHooks {
+		script, _ := GetHookScript(hookType)
+
+		// Hooks that are already installed are not rewritten, so the force
+		// flag isn't needed to reinstall hooks
+		contents, err := os.ReadFile(path.Join(hooksDir, string(hookType)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err == nil && string(contents) == string(script) {
+			continue
+		}
+		if err := r.UpdateHook(hookType, script, force); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordHooks stores the gittuf-managed hooks in HooksRef and records the ref
+// in the RSL, so that VerifyHooks can check the integrity of the installed
+// hooks. The RSL entry is not created if the stored hooks are up to date.
+func (r *Repository) RecordHooks(signCommit bool) error {
+	entries := []object.TreeEntry{}
+	for _, hookType := range managedHooks {
+		script, _ := GetHookScript(hookType)
+
+		slog.Debug(fmt.Sprintf("Storing '%s' hook...", hookType))
+		blobID, err := gitinterface.WriteBlob(r.r, script)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, object.TreeEntry{
+			Name: string(hookType),
+			Mode: filemode.Executable,
+			Hash: blobID,
+		})
+	}
+
+	treeID, err := gitinterface.WriteTree(r.r, entries)
+	if err != nil {
+		return err
+	}
+
+	currentTip, err := gitinterface.GetTip(r.r, HooksRef)
+	if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return err
+	}
+	if !currentTip.IsZero() {
+		currentCommit, err := gitinterface.GetCommit(r.r, currentTip)
+		if err != nil {
+			return err
+		}
+		if currentCommit.TreeHash == treeID {
+			slog.Debug("Stored hooks are up to date")
+			return nil
+		}
+	}
+
+	slog.Debug("Updating hooks reference...")
+	commitID, err := gitinterface.Commit(r.r, treeID, HooksRef, hooksCommitMessage, signCommit)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Recording hooks reference in RSL...")
+	return rsl.NewReferenceEntry(HooksRef, commitID).Commit(r.r, signCommit)
+}
+
+// VerifyHooks checks that the hooks stored in HooksRef match the latest RSL
+// entry for the ref, and that each stored hook is installed unmodified in the
+// repository's .git/hooks folder.
+func (r *Repository) VerifyHooks() error {
+	slog.Debug("Loading latest RSL entry for hooks...")
+	entry, _, err := policy.NewSkipResolver(r.r).GetLatestUnskippedReferenceEntryForRef(context.Background(), HooksRef)
+	if err != nil {
+		return err
+	}
+
+	currentTip, err := gitinterface.GetTip(r.r, HooksRef)
+	if err != nil {
+		return err
+	}
+	if entry.TargetID != currentTip {
+		return fmt.Errorf("%w: '%s'", ErrRefStateDoesNotMatchRSL, HooksRef)
+	}
+
+	hooksCommit, err := gitinterface.GetCommit(r.r, currentTip)
+	if err != nil {
+		return err
+	}
+	hooksTree, err := gitinterface.GetTree(r.r, hooksCommit.TreeHash)
+	if err != nil {
+		return err
+	}
+
+	hooksDir, err := r.getHooksDir()
+	if err != nil {
+		return err
+	}
+
+	for _, treeEntry := range hooksTree.Entries {
+		slog.Debug(fmt.Sprintf("Verifying '%s' hook...", treeEntry.Name))
+		expected, err := gitinterface.ReadBlob(r.r, treeEntry.Hash)
+		if err != nil {
+			return err
+		}
+
+		installed, err := os.ReadFile(path.Join(hooksDir, treeEntry.Name))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("%w: '%s' is not installed", ErrHookModified, treeEntry.Name)
+			}
+			return err
+		}
+
+		if string(installed) != string(expected) {
+			return fmt.Errorf("%w: '%s'", ErrHookModified, treeEntry.Name)
+		}
+	}
+
+	return nil
+}
 
 // UpdateHook updates a git hook in the repositorie's .git/hooks folder.
 // Existing hook files are not overwritten, unless force flag is set.
 func (r *Repository) UpdateHook(hookType HookType, content []byte, force bool) error {
-	// TODO: rely on go-git to find .git folder, once
-	// https://github.com/go-git/go-git/issues/977 is available.
-	// Note, until then gittuf does not support separate git dir.
-
 	slog.Debug("Adding gittuf hooks...")
 
-	slog.Debug("Loading repository worktree...")
-	tree, err := r.r.Worktree()
+	hookFolder, err := r.getHooksDir()
 	if err != nil {
-		return fmt.Errorf("reading worktree: %w", err)
+		return err
 	}
-	if tree == nil {
-		return fmt.Errorf("worktree is nil, can't update hooks")
-	}
-
-	repoRoot := tree.Filesystem.Root()
-	hookFolder := path.Join(repoRoot, ".git", "hooks")
 	if err := os.MkdirAll(hookFolder, 0o750); err != nil {
 		return fmt.Errorf("making sure folder exist: %w", err)
 	}
@@ -66,6 +229,23 @@ func (r *Repository) UpdateHook(hookType HookType, content []byte, force bool) e
 	return nil
 }
 
+func (r *Repository) getHooksDir() (string, error) {
+	// TODO: rely on go-git to find .git folder, once
+	// https://github.com/go-git/go-git/issues/977 is available.
+	// Note, until then gittuf does not support separate git dir.
+
+	slog.Debug("Loading repository worktree...")
+	tree, err := r.r.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("reading worktree: %w", err)
+	}
+	if tree == nil {
+		return "", fmt.Errorf("worktree is nil, can't update hooks")
+	}
+
+	return path.Join(tree.Filesystem.Root(), ".git", "hooks"), nil
+}
+
 func doesFileExist(path string) (bool, error) {
 	_, err := os.Stat(path)
 	if err != nil {
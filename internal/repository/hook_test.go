@@ -7,7 +7,10 @@ import (
 	"path"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -72,3 +75,76 @@ func TestUpdatePrePushHook(t *testing.T) {
 		assert.Equal(t, []byte("new hook script"), content)
 	})
 }
+
+func TestInstallHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	repo, err := git.PlainInit(tmpDir, false)
+	require.NoError(t, err)
+	r := &Repository{r: repo}
+
+	err = r.InstallHooks(false)
+	require.NoError(t, err)
+
+	hooksDir := path.Join(tmpDir, ".git", "hooks")
+	for _, hookType := range []HookType{HookPrePush, HookPostMerge} {
+		script, has := GetHookScript(hookType)
+		require.True(t, has)
+
+		contents, err := os.ReadFile(path.Join(hooksDir, string(hookType)))
+		require.NoError(t, err)
+		assert.Equal(t, script, contents)
+	}
+
+	// Installing hooks does not record them
+	_, err = gitinterface.GetTip(repo, HooksRef)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	_, err = rsl.GetLatestEntry(repo)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	err = r.RecordHooks(false)
+	require.NoError(t, err)
+
+	hooksTip, err := gitinterface.GetTip(repo, HooksRef)
+	require.NoError(t, err)
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo, HooksRef)
+	require.NoError(t, err)
+	assert.Equal(t, hooksTip, entry.TargetID)
+
+	err = r.VerifyHooks()
+	assert.NoError(t, err)
+
+	// Reinstalling unmodified hooks does not require force, and recording
+	// unmodified hooks does not create a new RSL entry
+	err = r.InstallHooks(false)
+	assert.NoError(t, err)
+	err = r.RecordHooks(false)
+	assert.NoError(t, err)
+	latestEntry, err := rsl.GetLatestEntry(repo)
+	require.NoError(t, err)
+	assert.Equal(t, entry.ID, latestEntry.GetID())
+
+	err = os.WriteFile(path.Join(hooksDir, string(HookPrePush)), []byte("modified hook script"), 0o700) // nolint:gosec
+	require.NoError(t, err)
+
+	err = r.VerifyHooks()
+	assert.ErrorIs(t, err, ErrHookModified)
+
+	err = r.InstallHooks(false)
+	var hookErr *ErrHookExists
+	if assert.ErrorAs(t, err, &hookErr) {
+		assert.Equal(t, HookPrePush, hookErr.HookType)
+	}
+
+	err = r.InstallHooks(true)
+	assert.NoError(t, err)
+
+	err = r.VerifyHooks()
+	assert.NoError(t, err)
+
+	err = os.Remove(path.Join(hooksDir, string(HookPostMerge)))
+	require.NoError(t, err)
+
+	err = r.VerifyHooks()
+	assert.ErrorIs(t, err, ErrHookModified)
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+var prePushHookScript = []byte(`#!/bin/sh
+set -e
+
+remote="$1"
+url="$2"
+
+if ! command -v gittuf > /dev/null
+then
+    echo "gittuf could not be found"
+    echo "Download from: https://github.com/gittuf/gittuf/releases/latest"
+    exit 1
+fi
+
+echo "Pulling RSL from ${remote}."
+gittuf rsl remote pull ${remote}
+
+while read local_ref local_sha remote_ref remote_sha
+do
+    # The local object ID is all zeroes, whatever the object format, when the
+    # remote ref is being deleted, so there's nothing to record
+    case "${local_sha}" in
+        *[!0]*) ;;
+        *) continue ;;
+    esac
+
+    echo "Creating new RSL record for ${local_ref}."
+    gittuf rsl record ${local_ref}
+done
+
+echo "Pushing RSL to ${remote}."
+gittuf rsl remote push ${remote}
+`)
+
+var postMergeHookScript = []byte(`#!/bin/sh
+set -e
+
+if ! command -v gittuf > /dev/null
+then
+    echo "gittuf could not be found"
+    echo "Download from: https://github.com/gittuf/gittuf/releases/latest"
+    exit 1
+fi
+
+branch=$(git symbolic-ref --quiet --short HEAD || true)
+remote=$(git config "branch.${branch}.remote" || echo "origin")
+
+echo "Pulling RSL from ${remote}."
+gittuf rsl remote pull ${remote}
+`)
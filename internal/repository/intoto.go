@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/dev"
+)
+
+// AddInTotoLink records the in-toto link metadata as evidence for the change
+// to the target ref made by merging the feature ref into it. The change is
+// identified in the same way as for reference authorizations. The link is
+// verified using the in-toto layouts of the rules protecting the change when
+// the change is recorded in the RSL. The link is expected to be signed by the
+// functionary that performed the step. Currently, this is limited to
+// developer mode.
+func (r *Repository) AddInTotoLink(targetRef, featureRef string, link []byte, signCommit bool) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	targetRef, fromID, toID, err := r.getMergeChange(targetRef, featureRef)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current set of attestations...")
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	linkName, err := allAttestations.SetInTotoLink(r.r, link, targetRef, fromID, toID)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add in-toto link '%s' for '%s' from '%s' to '%s'", linkName, targetRef, fromID, toID)
+
+	slog.Debug("Committing attestations...")
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddInTotoLink(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+	if err := repo.InitializeNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+
+	targetRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, featureRef, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, r, rsl.NewReferenceEntry(featureRef, commitIDs[0]), gpgKeyBytes)
+
+	commit, err := gitinterface.GetCommit(r, commitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	link := []byte(`{"signed":{"_type":"link","name":"build","materials":{},"products":{},"byproducts":{},"command":[],"environment":{}},"signatures":[{"keyid":"3333333333333333333333333333333333333333333333333333333333333333","sig":"00"}]}`)
+
+	err = repo.AddInTotoLink(targetRef, featureRef, link, false)
+	assert.ErrorIs(t, err, dev.ErrNotInDevMode)
+
+	t.Setenv(dev.DevModeKey, "1")
+
+	err = repo.AddInTotoLink(targetRef, featureRef, link, false)
+	assert.Nil(t, err)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The link is recorded for merging the feature ref into the target ref,
+	// which has no RSL entries yet
+	links, err := allAttestations.GetInTotoLinksFor(r, targetRef, plumbing.ZeroHash.String(), commit.TreeHash.String())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"build.33333333.link": link}, links)
+}
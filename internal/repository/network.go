@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrNoRepositoriesToVerify    = errors.New("no repositories specified to verify")
+	ErrNetworkVerificationFailed = errors.New("verification failed for one or more repositories")
+)
+
+// VerifyNetworkOptions configures VerifyNetwork.
+type VerifyNetworkOptions struct {
+	// PolicyLocation is the location of a repository whose policy is shared
+	// by all the repositories being verified. If it is not set, each
+	// repository is verified using its own policy.
+	PolicyLocation string
+
+	// PolicyRef is the ref in the shared policy repository that contains the
+	// policy. If it is not set, the standard gittuf policy reference is used.
+	PolicyRef string
+
+	// Refs are the refs verified in each repository. If none are specified,
+	// every ref recorded in a repository's RSL, excluding gittuf's own refs
+	// and refs whose latest entry records their deletion, is verified.
+	Refs []string
+
+	// LatestOnly limits verification to the latest RSL entry for each ref.
+	// Verification using a shared policy always checks the latest entry only.
+	LatestOnly bool
+
+	// RootTrustAnchors are root public keys, obtained out-of-band, that must
+	// have signed the initial root of trust of the policy used. See
+	// SetRootTrustAnchors.
+	RootTrustAnchors []*tuf.Key
+
+	// RootTrustAnchorsThreshold is the number of RootTrustAnchors that must
+	// have signed the initial root of trust. If 0, all of them must have.
+	RootTrustAnchorsThreshold int
+}
+
+// NetworkVerificationReport aggregates the results of verifying a set of
+// repositories.
+type NetworkVerificationReport struct {
+	// PolicyLocation is the location of the shared policy repository, if one
+	// was used.
+	PolicyLocation string `json:"policyLocation,omitempty"`
+
+	// Verified is true if every ref in every repository was verified
+	// successfully.
+	Verified bool `json:"verified"`
+
+	// Repositories contains the results for each repository, in the order
+	// they were specified.
+	Repositories []*NetworkRepositoryResult `json:"repositories"`
+}
+
+// NetworkRepositoryResult records the results of verifying a single
+// repository in the network.
+type NetworkRepositoryResult struct {
+	Location string `json:"location"`
+	Verified bool   `json:"verified"`
+
+	// Error is set if the repository could not be fetched or its refs could
+	// not be identified. Errors for individual refs are recorded in Refs.
+	Error string `json:"error,omitempty"`
+
+	Refs []*NetworkRefResult `json:"refs,omitempty"`
+}
+
+// NetworkRefResult records the result of verifying a single ref.
+type NetworkRefResult struct {
+	RefName  string `json:"refName"`
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// VerifyNetwork fetches each of the repositories at the specified locations
+// into memory and verifies their refs, either using each repository's own
+// policy or using the policy of a shared repository. Failures for individual
+// repositories and refs are recorded in the returned report, and
+// ErrNetworkVerificationFailed is returned alongside the report if any
+// occurred. Other errors, such as failing to fetch the shared policy, are
+// returned without a report.
+func VerifyNetwork(ctx context.Context, locations []string, opts *VerifyNetworkOptions) (*NetworkVerificationReport, error) {
+	if len(locations) == 0 {
+		return nil, ErrNoRepositoriesToVerify
+	}
+	if opts == nil {
+		opts = &VerifyNetworkOptions{}
+	}
+
+	report := &NetworkVerificationReport{PolicyLocation: opts.PolicyLocation, Verified: true}
+
+	var policyRepository *Repository
+	if opts.PolicyLocation != "" {
+		slog.Debug(fmt.Sprintf("Fetching shared policy from '%s'...", opts.PolicyLocation))
+		repo, err := fetchToMemory(ctx, opts.PolicyLocation)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch shared policy from '%s': %w", opts.PolicyLocation, err)
+		}
+		policyRepository = &Repository{r: repo}
+	}
+
+	failures := 0
+	for _, location := range locations {
+		result := verifyNetworkRepository(ctx, location, policyRepository, opts)
+		if !result.Verified {
+			failures++
+			report.Verified = false
+		}
+		report.Repositories = append(report.Repositories, result)
+	}
+
+	if failures != 0 {
+		return report, fmt.Errorf("%w: %d of %d repositories", ErrNetworkVerificationFailed, failures, len(locations))
+	}
+
+	return report, nil
+}
+
+// verifyNetworkRepository fetches and verifies a single repository in the
+// network.
+func verifyNetworkRepository(ctx context.Context, location string, policyRepository *Repository, opts *VerifyNetworkOptions) *NetworkRepositoryResult {
+	result := &NetworkRepositoryResult{Location: location, Verified: true}
+
+	slog.Debug(fmt.Sprintf("Fetching repository '%s'...", location))
+	repo, err := fetchToMemory(ctx, location)
+	if err != nil {
+		result.Verified = false
+		result.Error = err.Error()
+		return result
+	}
+	r := &Repository{r: repo}
+	r.SetRootTrustAnchors(opts.RootTrustAnchors, opts.RootTrustAnchorsThreshold)
+
+	refNames := opts.Refs
+	if len(refNames) == 0 {
+		refNames, err = getRecordedRefs(repo)
+		if err != nil {
+			result.Verified = false
+			result.Error = err.Error()
+			return result
+		}
+	}
+
+	for _, refName := range refNames {
+		slog.Debug(fmt.Sprintf("Verifying '%s' in '%s'...", refName, location))
+
+		var err error
+		if policyRepository != nil {
+			err = r.VerifyRefUsingExternalPolicy(ctx, refName, policyRepository, opts.PolicyRef)
+		} else {
+			err = r.VerifyRef(ctx, refName, opts.LatestOnly)
+		}
+
+		refResult := &NetworkRefResult{RefName: refName, Verified: err == nil}
+		if err != nil {
+			result.Verified = false
+			refResult.Error = err.Error()
+		}
+		result.Refs = append(result.Refs, refResult)
+	}
+
+	return result
+}
+
+// getRecordedRefs returns the names of the refs recorded in the repository's
+// RSL in sorted order, excluding gittuf's own refs and refs whose latest entry
+// records their deletion.
+func getRecordedRefs(repo *git.Repository) ([]string, error) {
+	iterator, err := rsl.NewEntryIterator(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	refNames := []string{}
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry || seen[referenceEntry.RefName] {
+			continue
+		}
+		// Entries are iterated from the latest, so this is the ref's latest
+		// entry
+		seen[referenceEntry.RefName] = true
+
+		if strings.HasPrefix(referenceEntry.RefName, "refs/gittuf/") || referenceEntry.TargetID == plumbing.ZeroHash {
+			continue
+		}
+		refNames = append(refNames, referenceEntry.RefName)
+	}
+
+	sort.Strings(refNames)
+	return refNames, nil
+}
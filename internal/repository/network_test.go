@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyNetwork(t *testing.T) {
+	refName := "refs/heads/main"
+
+	createRepository := func(t *testing.T) string {
+		t.Helper()
+
+		location := t.TempDir()
+		r := createTestRepositoryWithPolicy(t, location)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		return location
+	}
+
+	t.Run("each repository's own policy", func(t *testing.T) {
+		locations := []string{createRepository(t), createRepository(t)}
+
+		report, err := VerifyNetwork(testCtx, locations, nil)
+		assert.Nil(t, err)
+		assert.True(t, report.Verified)
+		assert.Empty(t, report.PolicyLocation)
+		assert.Len(t, report.Repositories, 2)
+		for i, result := range report.Repositories {
+			assert.Equal(t, locations[i], result.Location)
+			assert.True(t, result.Verified)
+			assert.Equal(t, []*NetworkRefResult{{RefName: refName, Verified: true}}, result.Refs)
+		}
+	})
+
+	t.Run("shared policy", func(t *testing.T) {
+		policyLocation := createRepository(t)
+		location := createRepository(t)
+
+		report, err := VerifyNetwork(testCtx, []string{location}, &VerifyNetworkOptions{PolicyLocation: policyLocation, Refs: []string{refName}})
+		assert.Nil(t, err)
+		assert.True(t, report.Verified)
+		assert.Equal(t, policyLocation, report.PolicyLocation)
+		assert.Equal(t, []*NetworkRefResult{{RefName: refName, Verified: true}}, report.Repositories[0].Refs)
+
+		_, err = VerifyNetwork(testCtx, []string{location}, &VerifyNetworkOptions{PolicyLocation: filepath.Join(t.TempDir(), "missing")})
+		assert.NotNil(t, err)
+		assert.NotErrorIs(t, err, ErrNetworkVerificationFailed)
+	})
+
+	t.Run("failures are aggregated", func(t *testing.T) {
+		location := createRepository(t)
+		missingLocation := filepath.Join(t.TempDir(), "missing")
+
+		// Move the ref away from the state recorded in the RSL
+		brokenLocation := t.TempDir()
+		broken := createTestRepositoryWithPolicy(t, brokenLocation)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, broken.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, broken.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+		common.AddNTestCommitsToSpecifiedRef(t, broken.r, refName, 1, gpgKeyBytes)
+
+		report, err := VerifyNetwork(testCtx, []string{location, brokenLocation, missingLocation}, &VerifyNetworkOptions{Refs: []string{refName}})
+		assert.ErrorIs(t, err, ErrNetworkVerificationFailed)
+		assert.False(t, report.Verified)
+		assert.Len(t, report.Repositories, 3)
+
+		assert.True(t, report.Repositories[0].Verified)
+
+		assert.False(t, report.Repositories[1].Verified)
+		assert.Empty(t, report.Repositories[1].Error)
+		assert.Len(t, report.Repositories[1].Refs, 1)
+		assert.False(t, report.Repositories[1].Refs[0].Verified)
+		assert.Equal(t, ErrRefStateDoesNotMatchRSL.Error(), report.Repositories[1].Refs[0].Error)
+
+		assert.False(t, report.Repositories[2].Verified)
+		assert.NotEmpty(t, report.Repositories[2].Error)
+		assert.Empty(t, report.Repositories[2].Refs)
+	})
+
+	t.Run("no repositories", func(t *testing.T) {
+		_, err := VerifyNetwork(testCtx, nil, nil)
+		assert.ErrorIs(t, err, ErrNoRepositoriesToVerify)
+	})
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartialClone(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	remoteDir := t.TempDir()
+	remote := createTestRepositoryWithPolicy(t, remoteDir)
+
+	remoteConfig, err := remote.r.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteConfig.Raw.Section("uploadpack").SetOption("allowFilter", "true")
+	if err := remote.r.SetConfig(remoteConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := remote.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, remote.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, remote.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	// Create a blobless clone, which excludes the policy metadata
+	localDir := t.TempDir()
+	local, err := git.PlainInit(localDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := local.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{"file://" + remoteDir}}); err != nil {
+		t.Fatal(err)
+	}
+	refSpecs := []config.RefSpec{
+		config.RefSpec(refName + ":" + refName),
+		"refs/gittuf/*:refs/gittuf/*",
+	}
+	if err := gitinterface.FetchRefSpecWithOptions(testCtx, local, remoteName, refSpecs, &gitinterface.FetchOptions{Filter: "blob:none"}); err != nil {
+		t.Fatal(err)
+	}
+
+	promisorRemotes, err := gitinterface.GetPromisorRemotes(local)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{remoteName}, promisorRemotes)
+
+	t.Run("verification fails without fetching missing objects", func(t *testing.T) {
+		repo, err := git.PlainOpen(localDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := &Repository{r: repo}
+
+		err = r.VerifyRef(testCtx, refName, false)
+		assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+	})
+
+	t.Run("RSL and policy flows", func(t *testing.T) {
+		r, err := LoadRepositoryAt(localDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = r.VerifyRef(testCtx, refName, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyRef)
+		assert.Nil(t, err)
+		assert.True(t, state.HasTargetsRole(policy.TargetsRoleName))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 1, gpgKeyBytes)
+		if err := r.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, commitIDs[0], entry.TargetID)
+
+		// The entry isn't signed, so verification of the latest entry fails
+		// on the RSL entry's signature rather than on missing objects
+		err = r.VerifyRef(testCtx, refName, true)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+	})
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
 )
 
 var (
@@ -22,10 +24,22 @@ var (
 // PushPolicy pushes the local gittuf policy to the specified remote. As this
 // push defaults to fast-forward only, divergent policy states are detected.
 // Note that this also pushes the RSL as the policy cannot change without an
-// update to the RSL.
+// update to the RSL. Policy proposals are also pushed if there are any.
 func (r *Repository) PushPolicy(ctx context.Context, remoteName string) error {
+	transport, err := r.getTransport(remoteName)
+	if err != nil {
+		return errors.Join(ErrPushingPolicy, err)
+	}
+
+	refNames := []string{policy.PolicyRef, policy.PolicyStagingRef, rsl.Ref}
+	if _, err := r.r.Reference(plumbing.ReferenceName(policy.ProposalsRef), true); err == nil {
+		refNames = append(refNames, policy.ProposalsRef)
+	} else if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return errors.Join(ErrPushingPolicy, err)
+	}
+
 	slog.Debug(fmt.Sprintf("Pushing policy and RSL references to %s...", remoteName))
-	if err := gitinterface.Push(ctx, r.r, remoteName, []string{policy.PolicyRef, policy.PolicyStagingRef, rsl.Ref}); err != nil {
+	if err := transport.Push(ctx, r.r, refNames); err != nil {
 		return errors.Join(ErrPushingPolicy, err)
 	}
 
@@ -36,8 +50,13 @@ func (r *Repository) PushPolicy(ctx context.Context, remoteName string) error {
 // marked as fast forward only to detect divergence. Note that this also fetches
 // the RSL as the policy must be updated in sync with the RSL.
 func (r *Repository) PullPolicy(ctx context.Context, remoteName string) error {
+	transport, err := r.getTransport(remoteName)
+	if err != nil {
+		return errors.Join(ErrPullingPolicy, err)
+	}
+
 	slog.Debug(fmt.Sprintf("Pulling policy and RSL references from %s...", remoteName))
-	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{policy.PolicyRef, policy.PolicyStagingRef, rsl.Ref}, true); err != nil {
+	if err := transport.Pull(ctx, r.r, []string{policy.PolicyRef, policy.PolicyStagingRef, rsl.Ref}); err != nil {
 		return errors.Join(ErrPullingPolicy, err)
 	}
 
@@ -48,9 +67,80 @@ func (r *Repository) ApplyPolicy(ctx context.Context, signRSLEntry bool) error {
 	return policy.Apply(ctx, r.r, signRSLEntry)
 }
 
+// ListPendingPolicyChanges returns the policy metadata that has been changed
+// on the policy staging ref but not yet applied to the policy ref, along with
+// the signature status of each change.
+func (r *Repository) ListPendingPolicyChanges(ctx context.Context) ([]*policy.PendingChange, error) {
+	slog.Debug("Comparing staged policy with current policy...")
+	return policy.GetPendingChanges(ctx, r.r)
+}
+
 func (r *Repository) ListRules(ctx context.Context, targetRef string) ([]*policy.DelegationWithDepth, error) {
 	if strings.HasPrefix(targetRef, "refs/gittuf/") {
 		return policy.ListRules(ctx, r.r, targetRef)
 	}
 	return policy.ListRules(ctx, r.r, "refs/gittuf/"+targetRef)
 }
+
+// DescribeRule returns the details of the specified rule in the policy
+// identified by targetRef.
+func (r *Repository) DescribeRule(ctx context.Context, targetRef, ruleName string) (*policy.RuleDescription, error) {
+	if strings.HasPrefix(targetRef, "refs/gittuf/") {
+		return policy.DescribeRule(ctx, r.r, targetRef, ruleName)
+	}
+	return policy.DescribeRule(ctx, r.r, "refs/gittuf/"+targetRef, ruleName)
+}
+
+// ListPrincipals returns the keys trusted in the policy identified by
+// targetRef along with the roles each key is authorized for.
+func (r *Repository) ListPrincipals(ctx context.Context, targetRef string) ([]*policy.Principal, error) {
+	if strings.HasPrefix(targetRef, "refs/gittuf/") {
+		return policy.ListPrincipals(ctx, r.r, targetRef)
+	}
+	return policy.ListPrincipals(ctx, r.r, "refs/gittuf/"+targetRef)
+}
+
+// DiffPolicy compares the policy states identified by fromID and toID. Each ID
+// may be either the ID of a policy RSL entry or the ID of a policy commit. If
+// toID is empty, the policy is compared with the current policy.
+func (r *Repository) DiffPolicy(ctx context.Context, fromID, toID string) (*policy.PolicyDiff, error) {
+	toEntryID := plumbing.NewHash(toID)
+	if toID == "" {
+		latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+		if err != nil {
+			return nil, err
+		}
+		toEntryID = latestEntry.ID
+	}
+
+	slog.Debug("Comparing policy states...")
+	return policy.GetPolicyDiff(ctx, r.r, plumbing.NewHash(fromID), toEntryID)
+}
+
+// GetAuthorizedKeysForRef returns the keys that are authorized to change the
+// specified ref in the repository's current policy, along with the threshold
+// of signatures required. This can be used to check if a key can authorize a
+// change to a ref before attempting the change.
+func (r *Repository) GetAuthorizedKeysForRef(ctx context.Context, refName string) ([]*tuf.Key, int, error) {
+	if !strings.HasPrefix(refName, gitinterface.RefPrefix) {
+		absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+		if err != nil {
+			if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+				return nil, 0, err
+			}
+
+			// The ref may not exist locally, assume it's a branch
+			absRefName = string(plumbing.NewBranchReferenceName(refName))
+		}
+		refName = absRefName
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	slog.Debug(fmt.Sprintf("Identifying authorized keys for '%s'...", refName))
+	return state.GetAuthorizedKeysForRef(refName)
+}
@@ -8,6 +8,9 @@ import (
 
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -149,3 +152,60 @@ func TestPullPolicy(t *testing.T) {
 		assert.ErrorIs(t, err, ErrPullingPolicy)
 	})
 }
+
+func TestListPendingPolicyChanges(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	changes, err := r.ListPendingPolicyChanges(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.RemoveDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", false); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err = r.ListPendingPolicyChanges(testCtx)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, policy.TargetsRoleName, changes[0].RoleName)
+	assert.Equal(t, changes[0].CurrentVersion+1, changes[0].StagedVersion)
+	assert.True(t, changes[0].ThresholdMet)
+
+	if err := r.ApplyPolicy(testCtx, false); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err = r.ListPendingPolicyChanges(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestGetAuthorizedKeysForRef(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, threshold, err := repo.GetAuthorizedKeysForRef(testCtx, "refs/heads/main")
+	assert.Nil(t, err)
+	assert.Equal(t, []*tuf.Key{gpgKey}, keys)
+	assert.Equal(t, 1, threshold)
+
+	// main doesn't exist locally, so it's treated as a branch
+	keys, threshold, err = repo.GetAuthorizedKeysForRef(testCtx, "main")
+	assert.Nil(t, err)
+	assert.Equal(t, []*tuf.Key{gpgKey}, keys)
+	assert.Equal(t, 1, threshold)
+
+	keys, threshold, err = repo.GetAuthorizedKeysForRef(testCtx, "refs/heads/release")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+	assert.Equal(t, 0, threshold)
+}
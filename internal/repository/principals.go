@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// AddPrincipal is the interface for a user to define a named principal, such
+// as a person or a team, in a gittuf policy file. The principal aggregates the
+// specified keys and is considered to have signed when the threshold of its
+// keys have signed. Rules in the same policy file can then trust the principal
+// instead of individual keys.
+func (r *Repository) AddPrincipal(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, principalName string, keys []*tuf.Key, threshold int, signCommit bool) error {
+	state, targetsMetadata, err := r.loadTargetsMetadata(ctx, targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding principal to rule file...")
+	targetsMetadata, err = policy.AddPrincipal(targetsMetadata, principalName, keys, threshold)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add principal '%s' to policy '%s'", principalName, targetsRoleName)
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, signCommit)
+}
+
+// RemovePrincipal is the interface for a user to remove a named principal
+// from a gittuf policy file. A principal trusted by a rule cannot be removed.
+func (r *Repository) RemovePrincipal(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, principalName string, signCommit bool) error {
+	state, targetsMetadata, err := r.loadTargetsMetadata(ctx, targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Removing principal from rule file...")
+	targetsMetadata, err = policy.RemovePrincipal(targetsMetadata, principalName)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Remove principal '%s' from policy '%s'", principalName, targetsRoleName)
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, signCommit)
+}
+
+// SetRulePrincipals is the interface for a user to set the principals trusted
+// by a rule in a gittuf policy file, in addition to the rule's keys.
+func (r *Repository) SetRulePrincipals(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, principalNames []string, signCommit bool) error {
+	state, targetsMetadata, err := r.loadTargetsMetadata(ctx, targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting principals for rule...")
+	targetsMetadata, err = policy.SetDelegationPrincipals(targetsMetadata, ruleName, principalNames)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Set principals for rule '%s' in policy '%s' to '%s'", ruleName, targetsRoleName, strings.Join(principalNames, "', '"))
+	if len(principalNames) == 0 {
+		commitMessage = fmt.Sprintf("Remove principals from rule '%s' in policy '%s'", ruleName, targetsRoleName)
+	}
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, signCommit)
+}
+
+func (r *Repository) loadTargetsMetadata(ctx context.Context, targetsRoleName string) (*policy.State, *tuf.TargetsMetadata, error) {
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return nil, nil, policy.ErrMetadataNotFound
+	}
+
+	// TODO: verify is role can be signed using the presented key. This requires
+	// the user to pass in the delegating role as well as we do not want to
+	// assume which role is the delegating role (diamond delegations are legal).
+	// See: https://github.com/gittuf/gittuf/issues/246.
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return state, targetsMetadata, nil
+}
+
+func (r *Repository) updateTargetsMetadata(ctx context.Context, state *policy.State, signer sslibdsse.SignerVerifier, targetsRoleName string, targetsMetadata *tuf.TargetsMetadata, commitMessage string, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	slog.Debug("Committing policy...")
+	return state.Commit(r.r, commitMessage, signCommit)
+}
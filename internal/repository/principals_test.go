@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipals(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddPrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "developers", []*tuf.Key{gpgKey, targetsPubKey}, 1, false)
+	assert.Nil(t, err)
+
+	err = r.SetRulePrincipals(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []string{"developers"}, false)
+	assert.Nil(t, err)
+
+	rule, err := r.DescribeRule(testCtx, policy.PolicyStagingRef, "protect-main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"developers"}, rule.Principals)
+
+	err = r.RemovePrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "developers", false)
+	assert.ErrorIs(t, err, policy.ErrPrincipalInUse)
+
+	// Verification of main continues to succeed with the principal trusted
+	if err := r.ApplyPolicy(testCtx, false); err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	if err := r.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, r.r, entry, gpgKeyBytes)
+
+	err = r.VerifyRef(testCtx, refName, true)
+	assert.Nil(t, err)
+
+	err = r.SetRulePrincipals(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", nil, false)
+	assert.Nil(t, err)
+
+	err = r.RemovePrincipal(testCtx, targetsSigner, policy.TargetsRoleName, "developers", false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, targetsMetadata.Delegations.Principals)
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrPropagationTargetMismatch = errors.New("local ref does not match target recorded in upstream RSL entry")
+	ErrUpstreamEntrySkipped      = errors.New("upstream RSL entry has been skipped")
+)
+
+// RecordPropagationEntry records that localRefName mirrors upstreamRefName in
+// the upstream repository. The latest unskipped entry for upstreamRefName in
+// the upstream RSL is propagated, and localRefName must already point to the
+// target recorded in that entry. upstreamLocation identifies the upstream
+// repository, typically by its URL, and is recorded in the entry. As the
+// upstream entry is not verified using this repository's policy, verifying
+// localRefName fails until a reference entry for it is recorded after the
+// propagation entry.
+func (r *Repository) RecordPropagationEntry(upstreamRepository *Repository, upstreamLocation, upstreamRefName, localRefName string, signCommit bool) error {
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, err := gitinterface.AbsoluteReference(r.r, localRefName)
+	if err != nil {
+		return err
+	}
+
+	if rsl.IsRSLRef(absRefName) {
+		return rsl.ErrCannotRecordRSLRef
+	}
+
+	slog.Debug(fmt.Sprintf("Loading latest upstream entry for '%s'...", upstreamRefName))
+	upstreamEntry, _, err := policy.NewSkipResolver(upstreamRepository.r).GetLatestUnskippedReferenceEntryForRef(context.Background(), upstreamRefName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Loading current state of '%s'...", absRefName))
+	currentTip, err := gitinterface.GetTip(r.r, absRefName)
+	if err != nil {
+		return err
+	}
+	if currentTip != upstreamEntry.TargetID {
+		return fmt.Errorf("%w: '%s' is at '%s', upstream entry records '%s'", ErrPropagationTargetMismatch, absRefName, currentTip.String(), upstreamEntry.TargetID.String())
+	}
+
+	slog.Debug("Creating RSL propagation entry...")
+	return rsl.NewPropagationEntry(absRefName, upstreamEntry.TargetID, upstreamLocation, upstreamEntry.ID).Commit(r.r, signCommit)
+}
+
+// VerifyPropagationEntry verifies the propagation entry with the specified ID
+// against the upstream repository. The upstream entry it records must be a
+// reference entry in the upstream RSL that has not been skipped, and its target
+// must match the propagation entry's target.
+func (r *Repository) VerifyPropagationEntry(upstreamRepository *Repository, entryID string) error {
+	slog.Debug("Loading propagation entry...")
+	entry, err := rsl.GetEntry(r.r, plumbing.NewHash(entryID))
+	if err != nil {
+		return err
+	}
+	propagationEntry, isPropagationEntry := entry.(*rsl.PropagationEntry)
+	if !isPropagationEntry {
+		return rsl.ErrInvalidRSLEntry
+	}
+
+	slog.Debug(fmt.Sprintf("Loading upstream entry '%s'...", propagationEntry.UpstreamEntryID.String()))
+	upstreamEntry, err := rsl.GetEntry(upstreamRepository.r, propagationEntry.UpstreamEntryID)
+	if err != nil {
+		return err
+	}
+	upstreamReferenceEntry, isReferenceEntry := upstreamEntry.(*rsl.ReferenceEntry)
+	if !isReferenceEntry {
+		return rsl.ErrInvalidRSLEntry
+	}
+
+	if upstreamReferenceEntry.TargetID != propagationEntry.TargetID {
+		return fmt.Errorf("%w: propagation entry records '%s', upstream entry records '%s'", ErrPropagationTargetMismatch, propagationEntry.TargetID.String(), upstreamReferenceEntry.TargetID.String())
+	}
+
+	// Loading the range from the upstream entry to the upstream RSL's tip also
+	// checks that the upstream entry is part of the upstream RSL
+	slog.Debug("Checking if upstream entry has been skipped...")
+	upstreamLatestEntry, err := rsl.GetLatestEntry(upstreamRepository.r)
+	if err != nil {
+		return err
+	}
+	entries, err := rsl.GetReferenceEntriesInRangeWithSkipStatus(upstreamRepository.r, upstreamReferenceEntry.ID, upstreamLatestEntry.GetID(), upstreamReferenceEntry.RefName)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 || entries[0].ID != upstreamReferenceEntry.ID {
+		return rsl.ErrRSLEntryNotFound
+	}
+	skipped, err := policy.NewSkipResolver(upstreamRepository.r).IsSkipped(context.Background(), entries[0].ReferenceEntry, entries[0].Annotations)
+	if err != nil {
+		return err
+	}
+	if skipped {
+		return ErrUpstreamEntrySkipped
+	}
+
+	return nil
+}
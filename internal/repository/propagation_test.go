@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndVerifyPropagationEntry(t *testing.T) {
+	upstreamLocation := "https://example.com/upstream/repository"
+	targetID := plumbing.NewHash("abcdef1234567890")
+
+	newRepository := func(t *testing.T) *Repository {
+		t.Helper()
+
+		r, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		repo := &Repository{r: r}
+
+		if err := rsl.InitializeNamespace(repo.r); err != nil {
+			t.Fatal(err)
+		}
+
+		return repo
+	}
+
+	upstreamRepo := newRepository(t)
+	if err := rsl.NewReferenceEntry("refs/heads/main", targetID).Commit(upstreamRepo.r, false); err != nil {
+		t.Fatal(err)
+	}
+	upstreamEntry, err := rsl.GetLatestEntry(upstreamRepo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("successful propagation", func(t *testing.T) {
+		repo := newRepository(t)
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference("refs/heads/vendor", targetID)); err != nil {
+			t.Fatal(err)
+		}
+
+		err := repo.RecordPropagationEntry(upstreamRepo, upstreamLocation, "refs/heads/main", "refs/heads/vendor", false)
+		assert.Nil(t, err)
+
+		entry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		propagationEntry, isPropagationEntry := entry.(*rsl.PropagationEntry)
+		if !isPropagationEntry {
+			t.Fatal("invalid entry type")
+		}
+		assert.Equal(t, upstreamEntry.GetID(), propagationEntry.UpstreamEntryID)
+		assert.Equal(t, upstreamLocation, propagationEntry.UpstreamRepository)
+
+		err = repo.VerifyPropagationEntry(upstreamRepo, entry.GetID().String())
+		assert.Nil(t, err)
+	})
+
+	t.Run("local ref does not match upstream", func(t *testing.T) {
+		repo := newRepository(t)
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference("refs/heads/vendor", plumbing.NewHash("1234567890abcdef"))); err != nil {
+			t.Fatal(err)
+		}
+
+		err := repo.RecordPropagationEntry(upstreamRepo, upstreamLocation, "refs/heads/main", "refs/heads/vendor", false)
+		assert.ErrorIs(t, err, ErrPropagationTargetMismatch)
+	})
+
+	t.Run("verify reference entry", func(t *testing.T) {
+		repo := newRepository(t)
+		if err := rsl.NewReferenceEntry("refs/heads/vendor", targetID).Commit(repo.r, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.VerifyPropagationEntry(upstreamRepo, entry.GetID().String())
+		assert.ErrorIs(t, err, rsl.ErrInvalidRSLEntry)
+	})
+
+	t.Run("target does not match upstream entry", func(t *testing.T) {
+		repo := newRepository(t)
+		if err := rsl.NewPropagationEntry("refs/heads/vendor", plumbing.NewHash("1234567890abcdef"), upstreamLocation, upstreamEntry.GetID()).Commit(repo.r, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.VerifyPropagationEntry(upstreamRepo, entry.GetID().String())
+		assert.ErrorIs(t, err, ErrPropagationTargetMismatch)
+	})
+
+	t.Run("upstream entry skipped", func(t *testing.T) {
+		upstreamRepo := newRepository(t)
+		if err := rsl.NewReferenceEntry("refs/heads/main", targetID).Commit(upstreamRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+		upstreamEntry, err := rsl.GetLatestEntry(upstreamRepo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		repo := newRepository(t)
+		if err := rsl.NewPropagationEntry("refs/heads/vendor", targetID, upstreamLocation, upstreamEntry.GetID()).Commit(repo.r, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := rsl.NewAnnotationEntry([]plumbing.Hash{upstreamEntry.GetID()}, true, "revoke").Commit(upstreamRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.VerifyPropagationEntry(upstreamRepo, entry.GetID().String())
+		assert.ErrorIs(t, err, ErrUpstreamEntrySkipped)
+	})
+}
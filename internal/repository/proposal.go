@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// CreatePolicyProposal records a proposal to replace the specified policy
+// file with the metadata in env, which may be unsigned or partially signed. If
+// env is nil, the policy file's current metadata on the policy staging ref is
+// proposed. If the same metadata has already been proposed, the signatures in
+// env are added to the existing proposal. The proposal's ID is returned.
+func (r *Repository) CreatePolicyProposal(ctx context.Context, roleName string, env *sslibdsse.Envelope, signCommit bool) (string, error) {
+	if env == nil {
+		slog.Debug("Loading current policy...")
+		state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+		if err != nil {
+			return "", err
+		}
+
+		if roleName == policy.RootRoleName {
+			env = state.RootEnvelope
+		} else {
+			if !state.HasTargetsRole(roleName) {
+				return "", policy.ErrMetadataNotFound
+			}
+			if roleName == policy.TargetsRoleName {
+				env = state.TargetsEnvelope
+			} else {
+				env = state.DelegationEnvelopes[roleName]
+			}
+		}
+	}
+
+	proposal, err := policy.NewProposal(roleName, env)
+	if err != nil {
+		return "", err
+	}
+
+	slog.Debug("Loading current policy proposals...")
+	proposals, err := policy.LoadCurrentProposals(r.r)
+	if err != nil {
+		return "", err
+	}
+
+	if existing, err := proposals.Get(proposal.ID); err == nil {
+		if existing.RoleName != roleName {
+			return "", fmt.Errorf("%w: metadata has already been proposed for policy '%s'", policy.ErrInvalidProposal, existing.RoleName)
+		}
+
+		slog.Debug("Adding signatures to existing proposal...")
+		for _, signature := range env.Signatures {
+			if !hasSignatureFromKey(existing.Envelope, signature.KeyID) {
+				existing.Envelope.Signatures = append(existing.Envelope.Signatures, signature)
+			}
+		}
+		proposal = existing
+	}
+	proposals.Set(proposal)
+
+	commitMessage := fmt.Sprintf("Propose metadata '%s' for policy '%s'", proposal.ID, roleName)
+
+	slog.Debug("Committing policy proposals...")
+	if err := proposals.Commit(r.r, commitMessage, signCommit); err != nil {
+		return "", err
+	}
+
+	return proposal.ID, nil
+}
+
+// AddSignatureToProposal adds the signer's signature to the specified policy
+// proposal. Any existing signature from the signer is replaced.
+func (r *Repository) AddSignatureToProposal(ctx context.Context, signer sslibdsse.SignerVerifier, proposalID string, signCommit bool) error {
+	slog.Debug("Loading current policy proposals...")
+	proposals, err := policy.LoadCurrentProposals(r.r)
+	if err != nil {
+		return err
+	}
+
+	proposal, err := proposals.Get(proposalID)
+	if err != nil {
+		return err
+	}
+
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing proposal using '%s'...", keyID))
+	proposal.Envelope, err = dsse.SignEnvelope(ctx, proposal.Envelope, signer)
+	if err != nil {
+		return err
+	}
+	proposals.Set(proposal)
+
+	commitMessage := fmt.Sprintf("Add signature from key '%s' to proposal '%s' for policy '%s'", keyID, proposalID, proposal.RoleName)
+
+	slog.Debug("Committing policy proposals...")
+	return proposals.Commit(r.r, commitMessage, signCommit)
+}
+
+// FinalizePolicyProposal stages the metadata in the specified proposal once it
+// is signed by a threshold of the keys trusted for the policy file in the
+// staged policy. The proposal is then removed. The staged policy can be
+// applied as usual using ApplyPolicy.
+func (r *Repository) FinalizePolicyProposal(ctx context.Context, proposalID string, signCommit bool) error {
+	slog.Debug("Loading current policy proposals...")
+	proposals, err := policy.LoadCurrentProposals(r.r)
+	if err != nil {
+		return err
+	}
+
+	proposal, err := proposals.Get(proposalID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying proposal's signatures...")
+	if err := state.AcceptProposal(ctx, proposal); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Finalize proposal '%s' for policy '%s'", proposalID, proposal.RoleName)
+
+	slog.Debug("Committing policy...")
+	if err := state.Commit(r.r, commitMessage, signCommit); err != nil {
+		return err
+	}
+
+	if err := proposals.Remove(proposalID); err != nil {
+		return err
+	}
+
+	slog.Debug("Committing policy proposals...")
+	return proposals.Commit(r.r, commitMessage, signCommit)
+}
+
+// ListPolicyProposals returns the open policy proposals ordered by ID.
+func (r *Repository) ListPolicyProposals() ([]*policy.Proposal, error) {
+	slog.Debug("Loading current policy proposals...")
+	proposals, err := policy.LoadCurrentProposals(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	return proposals.List(), nil
+}
+
+// hasSignatureFromKey checks if the envelope has a signature from the
+// specified key.
+func hasSignatureFromKey(env *sslibdsse.Envelope, keyID string) bool {
+	for _, signature := range env.Signatures {
+		if signature.KeyID == keyID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyProposals(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondTargetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey3Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondTargetsKey, err := tuf.LoadKeyFromBytes(artifacts.SSLibKey3Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Require two signatures on the top level rule file
+	if err := r.AddTopLevelTargetsKey(testCtx, rootSigner, secondTargetsKey, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UpdateTopLevelTargetsThreshold(testCtx, rootSigner, 2, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.RemoveDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", false); err != nil {
+		t.Fatal(err)
+	}
+
+	proposals, err := r.ListPolicyProposals()
+	assert.Nil(t, err)
+	assert.Empty(t, proposals)
+
+	proposalID, err := r.CreatePolicyProposal(testCtx, policy.TargetsRoleName, nil, false)
+	assert.Nil(t, err)
+
+	proposals, err = r.ListPolicyProposals()
+	assert.Nil(t, err)
+	assert.Len(t, proposals, 1)
+	assert.Equal(t, proposalID, proposals[0].ID)
+	assert.Equal(t, policy.TargetsRoleName, proposals[0].RoleName)
+	assert.Len(t, proposals[0].Envelope.Signatures, 1)
+
+	// Proposing the same metadata again doesn't create a new proposal
+	sameProposalID, err := r.CreatePolicyProposal(testCtx, policy.TargetsRoleName, nil, false)
+	assert.Nil(t, err)
+	assert.Equal(t, proposalID, sameProposalID)
+
+	err = r.FinalizePolicyProposal(testCtx, proposalID, false)
+	assert.ErrorIs(t, err, policy.ErrProposalThresholdNotMet)
+
+	err = r.AddSignatureToProposal(testCtx, secondTargetsSigner, "unknown", false)
+	assert.ErrorIs(t, err, policy.ErrProposalNotFound)
+
+	err = r.AddSignatureToProposal(testCtx, secondTargetsSigner, proposalID, false)
+	assert.Nil(t, err)
+
+	proposals, err = r.ListPolicyProposals()
+	assert.Nil(t, err)
+	assert.Len(t, proposals[0].Envelope.Signatures, 2)
+
+	err = r.FinalizePolicyProposal(testCtx, proposalID, false)
+	assert.Nil(t, err)
+
+	proposals, err = r.ListPolicyProposals()
+	assert.Nil(t, err)
+	assert.Empty(t, proposals)
+
+	// The finalized proposal is staged, and can be applied
+	err = r.ApplyPolicy(testCtx, false)
+	assert.Nil(t, err)
+
+	keys, _, err := r.GetAuthorizedKeysForRef(testCtx, "refs/heads/main")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5/plumbing"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// AddSLSAProvenance attaches the SLSA provenance envelope for the artifact with
+// the specified SHA-256 digest to the Git object the artifact was built from.
+// The target may be a Git reference, in which case the provenance is attached
+// to the object the reference points to, or the ID of a commit or tag. The
+// envelope is expected to be signed by the build system. Currently, this is
+// limited to developer mode.
+func (r *Repository) AddSLSAProvenance(target, artifactDigest string, env *sslibdsse.Envelope, signCommit bool) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	gitObjectID, err := r.resolveAttestationTarget(target)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current set of attestations...")
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetSLSAProvenance(r.r, env, gitObjectID.String(), artifactDigest); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add SLSA provenance for artifact '%s' built from '%s'", artifactDigest, gitObjectID.String())
+
+	slog.Debug("Committing attestations...")
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// GetSLSAProvenance returns the SLSA provenance envelopes attached to the Git
+// object identified by target, keyed by the digest of each artifact. The
+// signatures on the envelopes are not verified.
+func (r *Repository) GetSLSAProvenance(target string) (map[string]*sslibdsse.Envelope, error) {
+	gitObjectID, err := r.resolveAttestationTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Loading current set of attestations...")
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	return allAttestations.GetSLSAProvenanceForObject(r.r, gitObjectID.String())
+}
+
+// resolveAttestationTarget returns the ID of the Git object identified by
+// target, which may be a Git reference or an object ID.
+func (r *Repository) resolveAttestationTarget(target string) (plumbing.Hash, error) {
+	refName, err := gitinterface.AbsoluteReference(r.r, target)
+	if err == nil {
+		ref, err := r.r.Reference(plumbing.ReferenceName(refName), true)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		return ref.Hash(), nil
+	}
+	if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+		return plumbing.ZeroHash, err
+	}
+
+	gitObjectID, err := gitinterface.NewHash(target)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if _, err := r.r.Object(plumbing.AnyObject, gitObjectID); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return gitObjectID, nil
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAndGetSLSAProvenance(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+	if err := repo.InitializeNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, refName, 1, gpgKeyBytes)
+
+	artifactDigest := "2222222222222222222222222222222222222222222222222222222222222222"
+	provenance := `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"artifact","digest":{"sha256":"` + artifactDigest + `"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{}}`
+	env := &sslibdsse.Envelope{
+		PayloadType: "application/vnd.in-toto+json",
+		Payload:     base64.StdEncoding.EncodeToString([]byte(provenance)),
+		Signatures:  []sslibdsse.Signature{{KeyID: "builder", Sig: "c2lnbmF0dXJl"}},
+	}
+
+	err = repo.AddSLSAProvenance("main", artifactDigest, env, false)
+	assert.ErrorIs(t, err, dev.ErrNotInDevMode)
+
+	t.Setenv(dev.DevModeKey, "1")
+
+	err = repo.AddSLSAProvenance("main", artifactDigest, env, false)
+	assert.Nil(t, err)
+
+	// The provenance can be queried using the ref or the commit ID
+	envs, err := repo.GetSLSAProvenance(refName)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*sslibdsse.Envelope{artifactDigest: env}, envs)
+
+	envs, err = repo.GetSLSAProvenance(commitIDs[0].String())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*sslibdsse.Envelope{artifactDigest: env}, envs)
+
+	_, err = repo.GetSLSAProvenance("does-not-exist")
+	assert.NotNil(t, err)
+}
@@ -3,14 +3,21 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
 var (
@@ -20,21 +27,108 @@ var (
 
 type Repository struct {
 	r *git.Repository
+
+	// expirationGracePeriod is the period past the expiry of policy metadata
+	// during which the metadata is still accepted when verifying.
+	expirationGracePeriod time.Duration
+
+	// rootTrustAnchors contains the out-of-band root keys expected to have
+	// signed the initial root of trust, set using SetRootTrustAnchors.
+	rootTrustAnchors []*tuf.Key
+
+	// rootTrustAnchorsThreshold is the number of rootTrustAnchors that must
+	// have signed the initial root of trust.
+	rootTrustAnchorsThreshold int
+
+	// rootPinFile is the path of the file used to pin the initial root keys
+	// on first use, set using SetRootPinFile.
+	rootPinFile string
+
+	// transports contains the transports set for specific remotes using
+	// SetTransport.
+	transports map[string]Transport
+
+	// autoDeepenRemoteName is the remote that more history is fetched from
+	// when verification reaches the boundary of a shallow clone, set using
+	// SetAutoDeepen.
+	autoDeepenRemoteName string
+
+	// allowUnauthorizedRSLSigner indicates that RSL entries are recorded even
+	// if the signing key is not trusted for the reference, set using
+	// SetAllowUnauthorizedRSLSigner.
+	allowUnauthorizedRSLSigner bool
 }
 
 func LoadRepository() (*Repository, error) {
+	return LoadRepositoryAt(".")
+}
+
+// LoadRepositoryAt loads the Git repository at the specified path. The path
+// may be within the repository's worktree or be its Git directory.
+func LoadRepositoryAt(path string) (*Repository, error) {
 	slog.Debug("Loading Git repository...")
 
-	repo, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{DetectDotGit: true})
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Checking repository object format...")
+	if err := gitinterface.CheckObjectFormat(repo); err != nil {
+		return nil, err
+	}
+
+	promisorRemotes, err := gitinterface.GetPromisorRemotes(repo)
 	if err != nil {
 		return nil, err
 	}
+	if len(promisorRemotes) > 0 {
+		// Objects missing in partial clones are fetched as they're needed
+		slog.Debug(fmt.Sprintf("Repository is a partial clone, missing objects will be fetched from '%s'", strings.Join(promisorRemotes, "', '")))
+		repo, err = gitinterface.NewPromisorFetchRepository(context.Background(), repo)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &Repository{
 		r: repo,
 	}, nil
 }
 
+// NewInMemoryRepository returns an empty Repository backed by go-git's
+// in-memory storage, with an in-memory working tree. Nothing is written to
+// disk, and operations supported for in-memory repositories, such as recording
+// and walking the RSL and verifying refs against the policy, do not invoke the
+// Git binary. This makes the repository suitable for tests and sandboxed
+// services. Operations that require the Git binary, such as fetching with
+// partial clone filters, return gitinterface.ErrRepositoryNotOnDisk. See
+// CloneOptions.InMemory to clone a remote repository into memory.
+func NewInMemoryRepository() (*Repository, error) {
+	slog.Debug("Creating in-memory Git repository...")
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		r: repo,
+	}, nil
+}
+
+// GoGitRepository returns the underlying go-git Repository. This is an escape
+// hatch for advanced integrations that need Git operations not exposed by
+// Repository.
+//
+// UNSTABLE: this accessor may change or be removed in a future release as the
+// Git backend evolves. Modifying gittuf namespaces (the RSL, policy, and
+// attestations refs) directly via the returned Repository bypasses gittuf's
+// checks and can leave the repository in a state that fails verification.
+func (r *Repository) GoGitRepository() *git.Repository {
+	return r.r
+}
+
 func (r *Repository) InitializeNamespaces() error {
 	slog.Debug(fmt.Sprintf("Initializing RSL reference '%s'...", rsl.Ref))
 	if err := rsl.InitializeNamespace(r.r); err != nil {
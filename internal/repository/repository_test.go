@@ -6,6 +6,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-billy/v5/memfs"
@@ -31,6 +34,43 @@ func TestInitializeNamespaces(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestNewInMemoryRepository(t *testing.T) {
+	t.Setenv(dev.DevModeKey, "1")
+
+	r, err := NewInMemoryRepository()
+	assert.Nil(t, err)
+	assert.IsType(t, &memory.Storage{}, r.r.Storer)
+
+	if err := r.InitializeNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := gitinterface.Commit(r.r, gitinterface.EmptyTree(), "refs/heads/main", "Test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.RecordRSLEntryForReference("main", false)
+	assert.Nil(t, err)
+
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, "refs/heads/main")
+	assert.Nil(t, err)
+	assert.Equal(t, commitID, entry.TargetID)
+
+	// Operations that need the Git binary are not supported
+	_, err = gitinterface.GetMergeTree(r.r, commitID.String(), commitID.String())
+	assert.ErrorIs(t, err, gitinterface.ErrRepositoryNotOnDisk)
+}
+
+func TestGoGitRepository(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Repository{r: repo}
+	assert.Equal(t, repo, r.GoGitRepository())
+}
+
 func TestUnauthorizedKey(t *testing.T) {
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
@@ -54,7 +54,59 @@ func (r *Repository) InitializeRoot(ctx context.Context, signer sslibdsse.Signer
 	return state.Commit(r.r, commitMessage, signCommit)
 }
 
+// InitializeRootWithKeys is the interface for the user to create the
+// repository's root of trust with multiple root keys and a threshold of
+// signatures. The signer's key is always trusted as a root key. When the
+// threshold is greater than one, the initial root metadata must be signed by
+// the other root key holders using SignRoot before it can be applied.
+func (r *Repository) InitializeRootWithKeys(ctx context.Context, signer sslibdsse.SignerVerifier, rootKeys []*tuf.Key, threshold int, signCommit bool) error {
+	rawKey := signer.Public()
+	publicKey, err := sslibsv.NewKey(rawKey)
+	if err != nil {
+		return err
+	}
+
+	allRootKeys := []*tuf.Key{publicKey}
+	for _, key := range rootKeys {
+		if key.KeyID != publicKey.KeyID {
+			allRootKeys = append(allRootKeys, key)
+		}
+	}
+
+	slog.Debug("Creating initial root metadata...")
+	rootMetadata, err := policy.InitializeRootMetadataWithThreshold(allRootKeys, threshold)
+	if err != nil {
+		return err
+	}
+
+	if err := r.InitializeNamespaces(); err != nil {
+		return err
+	}
+
+	env, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing initial root metadata using '%s'...", publicKey.KeyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	state := &policy.State{
+		RootPublicKeys: allRootKeys,
+		RootEnvelope:   env,
+	}
+
+	commitMessage := "Initialize root of trust"
+
+	slog.Debug("Committing policy...")
+	return state.Commit(r.r, commitMessage, signCommit)
+}
+
 // AddRootKey is the interface for the user to add an authorized key
+// for the Root role.// AddRootKey is the interface for the user to add an authorized key
 // for the Root role.
 func (r *Repository) AddRootKey(ctx context.Context, signer sslibdsse.SignerVerifier, newRootKey *tuf.Key, signCommit bool) error {
 	rootKeyID, err := signer.KeyID()
@@ -128,6 +180,44 @@ func (r *Repository) RemoveRootKey(ctx context.Context, signer sslibdsse.SignerV
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
 }
 
+// ReplaceRootKey is the interface for the user to rotate a key trusted to sign
+// the Root role. The key identified by oldKeyID is replaced by newRootKey
+// without changing the number of root keys or the threshold.
+func (r *Repository) ReplaceRootKey(ctx context.Context, signer sslibdsse.SignerVerifier, oldKeyID string, newRootKey *tuf.Key, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Replacing root key...")
+	rootMetadata, err = policy.ReplaceRootKey(rootMetadata, oldKeyID, newRootKey)
+	if err != nil {
+		return err
+	}
+
+	newRootPublicKeys := []*tuf.Key{}
+	for _, key := range state.RootPublicKeys {
+		if key.KeyID != oldKeyID {
+			newRootPublicKeys = append(newRootPublicKeys, key)
+		}
+	}
+	state.RootPublicKeys = append(newRootPublicKeys, newRootKey)
+
+	commitMessage := fmt.Sprintf("Replace root key '%s' with '%s' in root", oldKeyID, newRootKey.KeyID)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
 // AddTopLevelTargetsKey is the interface for the user to add an authorized key
 // for the top level Targets role / policy file.
 func (r *Repository) AddTopLevelTargetsKey(ctx context.Context, signer sslibdsse.SignerVerifier, targetsKey *tuf.Key, signCommit bool) error {
@@ -186,6 +276,65 @@ func (r *Repository) RemoveTopLevelTargetsKey(ctx context.Context, signer sslibd
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
 }
 
+// AddGitHubApp is the interface for the user to trust the key of a GitHub app
+// to attest to approvals of GitHub pull requests. Approvals attested to by the
+// app count towards the thresholds of rules during verification.
+func (r *Repository) AddGitHubApp(ctx context.Context, signer sslibdsse.SignerVerifier, appKey *tuf.Key, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding GitHub app key...")
+	rootMetadata, err = policy.AddGitHubAppKey(rootMetadata, appKey)
+	if err != nil {
+		return fmt.Errorf("failed to add GitHub app key: %w", err)
+	}
+
+	commitMessage := fmt.Sprintf("Add GitHub app key '%s' to root", appKey.KeyID)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// RemoveGitHubApp is the interface for the user to stop trusting the GitHub
+// app to attest to approvals of GitHub pull requests.
+func (r *Repository) RemoveGitHubApp(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Removing GitHub app key...")
+	rootMetadata, err = policy.DeleteGitHubAppKey(rootMetadata)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := "Remove GitHub app key from root"
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
 // UpdateRootThreshold sets the threshold of valid signatures required for the
 // Root role.
 func (r *Repository) UpdateRootThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, threshold int, signCommit bool) error {
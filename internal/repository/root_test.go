@@ -10,6 +10,7 @@ import (
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
 	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/stretchr/testify/assert"
 )
@@ -41,6 +42,49 @@ func TestInitializeRoot(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestInitializeRootWithKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	repo, err := git.PlainInit(tempDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &Repository{r: repo}
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootKey, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondRootKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.InitializeRootWithKeys(testCtx, signer, []*tuf.Key{secondRootKey}, 3, false)
+	assert.ErrorIs(t, err, policy.ErrCannotMeetThreshold)
+
+	err = r.InitializeRootWithKeys(testCtx, signer, []*tuf.Key{rootKey, secondRootKey}, 2, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{rootKey.KeyID, secondRootKey.KeyID}, rootMetadata.Roles[policy.RootRoleName].KeyIDs)
+	assert.Equal(t, 2, rootMetadata.Roles[policy.RootRoleName].Threshold)
+	assert.Equal(t, 2, len(state.RootPublicKeys))
+	assert.Equal(t, 1, len(state.RootEnvelope.Signatures))
+
+	err = dsse.VerifyEnvelope(testCtx, state.RootEnvelope, []sslibdsse.Verifier{signer}, 1)
+	assert.Nil(t, err)
+}
+
 func TestAddRootKey(t *testing.T) {
 	r, keyBytes := createTestRepositoryWithRoot(t, "")
 
@@ -166,6 +210,54 @@ func TestRemoveRootKey(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestReplaceRootKey(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootKey, err := tuf.LoadKeyFromBytes(keyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRootKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The only root key can be replaced even though it cannot be removed
+	err = r.RemoveRootKey(testCtx, originalSigner, rootKey.KeyID, false)
+	assert.ErrorIs(t, err, policy.ErrCannotMeetThreshold)
+
+	err = r.ReplaceRootKey(testCtx, originalSigner, rootKey.KeyID, newRootKey, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, rootMetadata.Version)
+	assert.Equal(t, []string{newRootKey.KeyID}, rootMetadata.Roles[policy.RootRoleName].KeyIDs)
+	assert.Equal(t, 1, len(state.RootPublicKeys))
+	assert.Equal(t, newRootKey.KeyID, state.RootPublicKeys[0].KeyID)
+
+	// The change is signed by the outgoing key
+	err = dsse.VerifyEnvelope(testCtx, state.RootEnvelope, []sslibdsse.Verifier{originalSigner}, 1)
+	assert.Nil(t, err)
+
+	// The outgoing key is no longer trusted
+	err = r.ReplaceRootKey(testCtx, originalSigner, newRootKey.KeyID, rootKey, false)
+	assert.ErrorIs(t, err, ErrUnauthorizedKey)
+}
+
 func TestAddTopLevelTargetsKey(t *testing.T) {
 	r, keyBytes := createTestRepositoryWithRoot(t, "")
 
@@ -260,6 +352,45 @@ func TestRemoveTopLevelTargetsKey(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestAddAndRemoveGitHubApp(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	sv, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	appKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddGitHubApp(testCtx, sv, appKey, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rootMetadata.Version)
+	assert.Equal(t, []string{appKey.KeyID}, rootMetadata.Roles[policy.GitHubAppRoleName].KeyIDs)
+
+	err = r.RemoveGitHubApp(testCtx, sv, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = state.GetRootMetadata()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, rootMetadata.Version)
+	assert.NotContains(t, rootMetadata.Roles, policy.GitHubAppRoleName)
+}
+
 func TestUpdateRootThreshold(t *testing.T) {
 	r, _ := createTestRepositoryWithRoot(t, "")
 
@@ -6,24 +6,43 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/go-git/go-git/v5/storage/transactional"
 )
 
 var (
-	ErrCommitNotInRef = errors.New("specified commit is not in ref")
-	ErrPushingRSL     = errors.New("unable to push RSL")
-	ErrPullingRSL     = errors.New("unable to pull RSL")
+	ErrCommitNotInRef     = errors.New("specified commit is not in ref")
+	ErrPushingRSL         = errors.New("unable to push RSL")
+	ErrPullingRSL         = errors.New("unable to pull RSL")
+	ErrRSLRollback        = errors.New("local RSL does not contain pinned RSL tip, possible rollback detected")
+	ErrInvalidRemoteRSL   = errors.New("remote RSL is invalid")
+	ErrRSLEntryRolledBack = errors.New("remote RSL has diverged, removed new entry from local RSL")
+	ErrRefStateNotInRSL   = errors.New("current state of ref is not recorded in local RSL")
+	ErrRefNotDeleted      = errors.New("cannot record deletion of ref that still exists")
+	ErrRSLDiverged        = errors.New("local and remote RSLs have diverged, reconcile before syncing")
+	ErrConflictingRefs    = errors.New("local and remote RSLs record conflicting updates to the same ref")
 )
 
 // RecordRSLEntryForReference is the interface for the user to add an RSL entry
-// for the specified Git reference.
+// for the specified Git reference. The RSL is only updated once the new entry
+// has been created and signed successfully, so a failure leaves the RSL
+// unchanged. If the entry is signed, the signing key is first checked against
+// the current policy, and the entry is not recorded if the key is not trusted
+// by the rules protecting the reference, unless SetAllowUnauthorizedRSLSigner
+// is used.
 func (r *Repository) RecordRSLEntryForReference(refName string, signCommit bool) error {
 	slog.Debug("Identifying absolute reference path...")
 	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
@@ -31,6 +50,10 @@ func (r *Repository) RecordRSLEntryForReference(refName string, signCommit bool)
 		return err
 	}
 
+	if rsl.IsRSLRef(absRefName) {
+		return rsl.ErrCannotRecordRSLRef
+	}
+
 	slog.Debug(fmt.Sprintf("Loading current state of '%s'...", absRefName))
 	ref, err := r.r.Reference(plumbing.ReferenceName(absRefName), true)
 	if err != nil {
@@ -46,13 +69,235 @@ func (r *Repository) RecordRSLEntryForReference(refName string, signCommit bool)
 		return nil
 	}
 
-	// TODO: once policy verification is in place, the signing key used by
-	// signCommit must be verified for the refName in the delegation tree.
+	if signCommit {
+		if err := r.checkRSLEntrySigner(context.Background(), absRefName); err != nil {
+			return err
+		}
+	}
 
 	slog.Debug("Creating RSL reference entry...")
 	return rsl.NewReferenceEntry(absRefName, ref.Hash()).Commit(r.r, signCommit)
 }
 
+// RecordRSLEntryForReferenceWithActor adds an RSL entry for the specified Git
+// reference, like RecordRSLEntryForReference, that also records who or what
+// pushed the reference. The actor, such as a forge username, and the actor URL,
+// such as the URL of a CI job, are optional and informational only; they allow
+// audits to correlate the entry with the context it was created in.
+func (r *Repository) RecordRSLEntryForReferenceWithActor(refName, actor, actorURL string, signCommit bool) error {
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return err
+	}
+
+	if rsl.IsRSLRef(absRefName) {
+		return rsl.ErrCannotRecordRSLRef
+	}
+
+	slog.Debug(fmt.Sprintf("Loading current state of '%s'...", absRefName))
+	ref, err := r.r.Reference(plumbing.ReferenceName(absRefName), true)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Checking for existing entry for reference with same target...")
+	isDuplicate, err := r.isDuplicateEntry(absRefName, ref.Hash())
+	if err != nil {
+		return err
+	}
+	if isDuplicate {
+		return nil
+	}
+
+	if signCommit {
+		if err := r.checkRSLEntrySigner(context.Background(), absRefName); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("Creating RSL reference entry...")
+	entry := rsl.NewReferenceEntry(absRefName, ref.Hash())
+	entry.Actor = actor
+	entry.ActorURL = actorURL
+	return entry.Commit(r.r, signCommit)
+}
+
+// RecordRSLEntryForReferenceDeletion records the deletion of the specified Git
+// reference in the RSL. The reference must have been deleted locally and must
+// have been recorded in the RSL before. As the reference no longer exists, a
+// relative name is resolved against the refs recorded in the RSL.
+// If the RSL already records the deletion, no new entry is created. The
+// deletion of references in the gittuf namespace can't be recorded.
+func (r *Repository) RecordRSLEntryForReferenceDeletion(refName string, signCommit bool) error {
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, latestEntry, err := r.resolveDeletedReference(refName)
+	if err != nil {
+		return err
+	}
+
+	if rsl.IsRSLRef(absRefName) {
+		return rsl.ErrCannotRecordRSLRef
+	}
+	if rsl.IsGittufRef(absRefName) {
+		return rsl.ErrCannotDeleteGittufRef
+	}
+
+	slog.Debug(fmt.Sprintf("Checking '%s' has been deleted...", absRefName))
+	if _, err := r.r.Reference(plumbing.ReferenceName(absRefName), true); err == nil {
+		return ErrRefNotDeleted
+	} else if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return err
+	}
+
+	if latestEntry.Deleted {
+		slog.Debug("Deletion already recorded in RSL")
+		return nil
+	}
+
+	slog.Debug("Creating RSL reference deletion entry...")
+	return rsl.NewReferenceDeletionEntry(absRefName).Commit(r.r, signCommit)
+}
+
+// resolveDeletedReference returns the absolute name of the specified reference
+// and its latest entry in the RSL. Full reference names are used as is, while
+// other names are checked against the branches, tags, and refs in other
+// namespaces recorded in the RSL, in that order.
+func (r *Repository) resolveDeletedReference(refName string) (string, *rsl.ReferenceEntry, error) {
+	candidates := []string{refName}
+	if !strings.HasPrefix(refName, gitinterface.RefPrefix) {
+		candidates = []string{gitinterface.BranchRefPrefix + refName, gitinterface.TagRefPrefix + refName, gitinterface.RefPrefix + refName}
+	}
+
+	for _, candidate := range candidates {
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, candidate)
+		if err == nil {
+			return candidate, entry, nil
+		}
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return "", nil, err
+		}
+	}
+
+	return "", nil, rsl.ErrRSLEntryNotFound
+}
+
+// RecordRSLEntriesForReferences records the latest state of each of the
+// specified Git references in the RSL. References whose current state is
+// already recorded are ignored, as are repeated references. The entries are
+// created together, so that either all of them or none of them are added to
+// the RSL.
+func (r *Repository) RecordRSLEntriesForReferences(refNames []string, signCommit bool) error {
+	refTargets := []rsl.RefTarget{}
+	seenRefs := map[string]bool{}
+	for _, refName := range refNames {
+		slog.Debug(fmt.Sprintf("Identifying absolute reference path for '%s'...", refName))
+		absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+		if err != nil {
+			return err
+		}
+
+		if rsl.IsRSLRef(absRefName) {
+			return rsl.ErrCannotRecordRSLRef
+		}
+
+		if seenRefs[absRefName] {
+			continue
+		}
+		seenRefs[absRefName] = true
+
+		slog.Debug(fmt.Sprintf("Loading current state of '%s'...", absRefName))
+		ref, err := r.r.Reference(plumbing.ReferenceName(absRefName), true)
+		if err != nil {
+			return err
+		}
+
+		isDuplicate, err := r.isDuplicateEntry(absRefName, ref.Hash())
+		if err != nil {
+			return err
+		}
+		if isDuplicate {
+			slog.Debug(fmt.Sprintf("State of '%s' already recorded, skipping...", absRefName))
+			continue
+		}
+
+		refTargets = append(refTargets, rsl.RefTarget{RefName: absRefName, TargetID: ref.Hash()})
+	}
+
+	if signCommit && len(refTargets) != 0 {
+		targetRefNames := make([]string, 0, len(refTargets))
+		for _, refTarget := range refTargets {
+			targetRefNames = append(targetRefNames, refTarget.RefName)
+		}
+		if err := r.checkRSLEntrySigner(context.Background(), targetRefNames...); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Creating %d RSL reference entries...", len(refTargets)))
+	return rsl.CommitReferenceEntries(r.r, rsl.NewReferenceEntries(refTargets), signCommit)
+}
+
+// RecordRSLEntryForReferenceWithArtifactDigest adds an RSL entry for the
+// specified Git reference that also records the digest of an external artifact,
+// such as a release tarball built from the reference's target. The digest must
+// be of the form 'sha256:<hex digest>'.
+func (r *Repository) RecordRSLEntryForReferenceWithArtifactDigest(refName, artifactDigest string, signCommit bool) error {
+	if err := rsl.ValidateArtifactDigest(artifactDigest); err != nil {
+		return err
+	}
+
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Loading current state of '%s'...", absRefName))
+	ref, err := r.r.Reference(plumbing.ReferenceName(absRefName), true)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Checking for existing entry for reference with same target and artifact digest...")
+	latestUnskippedEntry, _, err := policy.NewSkipResolver(r.r).GetLatestUnskippedReferenceEntryForRef(context.Background(), absRefName)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return err
+		}
+	} else if latestUnskippedEntry.TargetID == ref.Hash() && latestUnskippedEntry.ArtifactDigest == artifactDigest {
+		return nil
+	}
+
+	if signCommit {
+		if err := r.checkRSLEntrySigner(context.Background(), absRefName); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("Creating RSL reference entry...")
+	return rsl.NewReferenceEntryWithArtifactDigest(absRefName, ref.Hash(), artifactDigest).Commit(r.r, signCommit)
+}
+
+// VerifyArtifactForReference checks that the artifact matches the artifact
+// digest recorded in the latest unskipped RSL entry for the specified Git
+// reference.
+func (r *Repository) VerifyArtifactForReference(refName string, artifact io.Reader) error {
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Loading latest RSL entry for '%s'...", absRefName))
+	entry, _, err := policy.NewSkipResolver(r.r).GetLatestUnskippedReferenceEntryForRef(context.Background(), absRefName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying artifact against recorded digest...")
+	return entry.VerifyArtifact(artifact)
+}
+
 // RecordRSLEntryForReferenceAtTarget is a special version of
 // RecordRSLEntryForReference used for evaluation. It is only invoked when
 // gittuf is explicitly set in developer mode.
@@ -90,6 +335,25 @@ func (r *Repository) RecordRSLAnnotation(rslEntryIDs []string, skip bool, messag
 	return rsl.NewAnnotationEntry(rslEntryHashes, skip, message).Commit(r.r, signCommit)
 }
 
+// RecordRSLUnskipAnnotation is the interface for the user to add an RSL
+// annotation that reinstates one or more prior RSL entries that were skipped.
+func (r *Repository) RecordRSLUnskipAnnotation(rslEntryIDs []string, message string, signCommit bool) error {
+	rslEntryHashes := []plumbing.Hash{}
+	for _, id := range rslEntryIDs {
+		rslEntryHashes = append(rslEntryHashes, plumbing.NewHash(id))
+	}
+
+	slog.Debug("Creating RSL unskip annotation entry...")
+	return rsl.NewUnskipAnnotationEntry(rslEntryHashes, message).Commit(r.r, signCommit)
+}
+
+// FsckRSL inspects every entry in the RSL and returns findings for entries that
+// could not be created today, such as annotations with oversized messages.
+func (r *Repository) FsckRSL() ([]*rsl.FsckFinding, error) {
+	slog.Debug("Inspecting RSL entries...")
+	return rsl.FsckRSL(r.r)
+}
+
 // CheckRemoteRSLForUpdates checks if the RSL at the specified remote
 // repository has updated in comparison with the local repository's RSL. This is
 // done by fetching the remote RSL to the local repository's remote RSL tracker.
@@ -98,11 +362,28 @@ func (r *Repository) RecordRSLAnnotation(rslEntryIDs []string, skip bool, messag
 // there is an update and the second return value indicates if the two RSLs have
 // diverged and need to be reconciled.
 func (r *Repository) CheckRemoteRSLForUpdates(ctx context.Context, remoteName string) (bool, bool, error) {
+	return r.checkRemoteRSLForUpdates(ctx, remoteName, false)
+}
+
+// CheckRemoteRSLForUpdatesWithIntegrityCheck is similar to
+// CheckRemoteRSLForUpdates. However, before comparing the local and remote
+// RSLs, it verifies that the remote RSL entries not present locally form a
+// valid chain. This ensures a corrupt or malicious remote RSL is rejected
+// rather than being reported as an update or a divergence.
+func (r *Repository) CheckRemoteRSLForUpdatesWithIntegrityCheck(ctx context.Context, remoteName string) (bool, bool, error) {
+	return r.checkRemoteRSLForUpdates(ctx, remoteName, true)
+}
+
+func (r *Repository) checkRemoteRSLForUpdates(ctx context.Context, remoteName string, verifyIntegrity bool) (bool, bool, error) {
 	trackerRef := rsl.RemoteTrackerRef(remoteName)
-	rslRemoteRefSpec := []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", rsl.Ref, trackerRef))}
+
+	rslTransport, err := r.getTransport(remoteName)
+	if err != nil {
+		return false, false, err
+	}
 
 	slog.Debug("Updating remote RSL tracker...")
-	if err := gitinterface.FetchRefSpec(ctx, r.r, remoteName, rslRemoteRefSpec); err != nil {
+	if err := rslTransport.Fetch(ctx, r.r, []string{rsl.Ref}); err != nil {
 		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
 			// Check if remote is empty and exit appropriately
 			return false, false, nil
@@ -120,6 +401,13 @@ func (r *Repository) CheckRemoteRSLForUpdates(ctx context.Context, remoteName st
 		return false, false, err
 	}
 
+	if verifyIntegrity {
+		slog.Debug("Verifying integrity of remote RSL...")
+		if err := r.verifyRemoteRSLIntegrity(localRefState.Hash(), remoteRefState.Hash()); err != nil {
+			return false, false, err
+		}
+	}
+
 	// Check if local is nil and exit appropriately
 	if localRefState.Hash().IsZero() {
 		// Local RSL has not been populated but remote is not zero
@@ -134,33 +422,21 @@ func (r *Repository) CheckRemoteRSLForUpdates(ctx context.Context, remoteName st
 		return false, false, nil
 	}
 
-	// Next, check if remote is ahead of local
-	remoteCommit, err := gitinterface.GetCommit(r.r, remoteRefState.Hash())
-	if err != nil {
-		return false, false, err
-	}
-	localCommit, err := gitinterface.GetCommit(r.r, localRefState.Hash())
+	// Next, use the merge base to check if one is ahead of the other
+	mergeBase, err := gitinterface.GetMergeBase(r.r, localRefState.Hash(), remoteRefState.Hash())
 	if err != nil {
 		return false, false, err
 	}
 
-	knows, err := gitinterface.KnowsCommit(r.r, remoteCommit.Hash, localCommit)
-	if err != nil {
-		return false, false, err
-	}
-	if knows {
+	// If local is the merge base, remote is ahead of local
+	if mergeBase == localRefState.Hash() {
 		slog.Debug("Remote RSL is ahead of local RSL")
 		return true, false, nil
 	}
 
-	// If not ancestor, local may be ahead or they may have diverged
-	// If remote is ancestor, only local is ahead, no updates
-	// If remote is not ancestor, the two have diverged, local needs to pull updates
-	knows, err = gitinterface.KnowsCommit(r.r, localCommit.Hash, remoteCommit)
-	if err != nil {
-		return false, false, err
-	}
-	if knows {
+	// If remote is the merge base, only local is ahead, no updates
+	// Otherwise, the two have diverged, local needs to pull updates
+	if mergeBase == remoteRefState.Hash() {
 		slog.Debug("Local RSL is ahead of remote RSL")
 		return false, false, nil
 	}
@@ -172,30 +448,1045 @@ func (r *Repository) CheckRemoteRSLForUpdates(ctx context.Context, remoteName st
 // PushRSL pushes the local RSL to the specified remote. As this push defaults
 // to fast-forward only, divergent RSL states are detected.
 func (r *Repository) PushRSL(ctx context.Context, remoteName string) error {
+	transport, err := r.getTransport(remoteName)
+	if err != nil {
+		return errors.Join(ErrPushingRSL, err)
+	}
+
+	slog.Debug(fmt.Sprintf("Pushing RSL reference to '%s'...", remoteName))
+	if err := transport.Push(ctx, r.r, []string{rsl.Ref}); err != nil {
+		return errors.Join(ErrPushingRSL, err)
+	}
+
+	return nil
+}
+
+// PushRefAndRSL pushes the specified Git reference and the RSL to the
+// specified remote in a single atomic push. Either both are updated at the
+// remote or, if either update is rejected, neither is. This ensures the remote
+// never has a state of the ref that is not recorded in its RSL. The current
+// state of the ref must be recorded in the local RSL. As the push is
+// fast-forward only, divergent states of the ref or the RSL are detected. If
+// the RSL is exchanged with the remote using an alternate transport, the ref
+// is pushed before the RSL and the two updates are not atomic.
+func (r *Repository) PushRefAndRSL(ctx context.Context, remoteName, refName string) error {
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Checking that current state of '%s' is recorded in RSL...", absRefName))
+	currentTip, err := gitinterface.GetTip(r.r, absRefName)
+	if err != nil {
+		return err
+	}
+	latestEntry, _, err := policy.NewSkipResolver(r.r).GetLatestUnskippedReferenceEntryForRef(ctx, absRefName)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return ErrRefStateNotInRSL
+		}
+		return err
+	}
+	if latestEntry.TargetID != currentTip {
+		return ErrRefStateNotInRSL
+	}
+
+	transport, err := r.getTransport(remoteName)
+	if err != nil {
+		return errors.Join(ErrPushingRSL, err)
+	}
+
+	if nativeTransport, isGitTransport := transport.(*gitTransport); isGitTransport && nativeTransport.targetRemoteName == remoteName {
+		slog.Debug(fmt.Sprintf("Pushing '%s' and RSL reference to '%s'...", absRefName, remoteName))
+		if err := gitinterface.Push(ctx, r.r, remoteName, []string{absRefName, rsl.Ref}); err != nil {
+			return errors.Join(ErrPushingRSL, err)
+		}
+
+		return nil
+	}
+
+	// The RSL is exchanged over a separate channel, so the two pushes can't be
+	// atomic. The ref is pushed first so that the remote's RSL never records a
+	// state of the ref that the remote doesn't have.
+	slog.Debug(fmt.Sprintf("Pushing '%s' to '%s'...", absRefName, remoteName))
+	if err := gitinterface.Push(ctx, r.r, remoteName, []string{absRefName}); err != nil {
+		return errors.Join(ErrPushingRSL, err)
+	}
+
 	slog.Debug(fmt.Sprintf("Pushing RSL reference to '%s'...", remoteName))
-	if err := gitinterface.Push(ctx, r.r, remoteName, []string{rsl.Ref}); err != nil {
+	if err := transport.Push(ctx, r.r, []string{rsl.Ref}); err != nil {
 		return errors.Join(ErrPushingRSL, err)
 	}
 
 	return nil
 }
 
+// RecordAndPushRSLEntry records an RSL entry for the specified Git reference
+// and pushes the RSL to the specified remote. If the push is rejected because
+// the remote RSL has diverged from the local RSL, the newly recorded entry is
+// removed from the local RSL so that it can be recorded again after the RSLs
+// are reconciled. If the push fails for any other reason, the entry is retained
+// in the local RSL and can be pushed later.
+func (r *Repository) RecordAndPushRSLEntry(ctx context.Context, refName, remoteName string, signCommit bool) error {
+	previousRSLRef, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+	if err != nil {
+		return err
+	}
+
+	if err := r.RecordRSLEntryForReference(refName, signCommit); err != nil {
+		return err
+	}
+
+	currentRSLRef, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+	if err != nil {
+		return err
+	}
+
+	pushErr := r.PushRSL(ctx, remoteName)
+	if pushErr == nil {
+		return nil
+	}
+
+	if currentRSLRef.Hash() == previousRSLRef.Hash() {
+		// No new entry was recorded, so there's nothing to remove
+		return pushErr
+	}
+
+	slog.Debug("Checking if remote RSL has diverged...")
+	_, hasDiverged, err := r.CheckRemoteRSLForUpdates(ctx, remoteName)
+	if err != nil || !hasDiverged {
+		return errors.Join(fmt.Errorf("%w: entry '%s' was retained in local RSL", pushErr, currentRSLRef.Hash().String()), err)
+	}
+
+	slog.Debug(fmt.Sprintf("Removing entry '%s' from local RSL...", currentRSLRef.Hash().String()))
+	if err := r.r.Storer.CheckAndSetReference(plumbing.NewHashReference(plumbing.ReferenceName(rsl.Ref), previousRSLRef.Hash()), currentRSLRef); err != nil {
+		return errors.Join(pushErr, fmt.Errorf("unable to remove entry '%s' from local RSL: %w", currentRSLRef.Hash().String(), err))
+	}
+
+	return errors.Join(ErrRSLEntryRolledBack, pushErr)
+}
+
 // PullRSL pulls RSL contents from the specified remote to the local RSL. The
 // fetch is marked as fast forward only to detect RSL divergence.
 func (r *Repository) PullRSL(ctx context.Context, remoteName string) error {
+	transport, err := r.getTransport(remoteName)
+	if err != nil {
+		return errors.Join(ErrPullingRSL, err)
+	}
+
 	slog.Debug(fmt.Sprintf("Pulling RSL reference from '%s'...", remoteName))
-	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{rsl.Ref}, true); err != nil {
+	if err := transport.Pull(ctx, r.r, []string{rsl.Ref}); err != nil {
+		return errors.Join(ErrPullingRSL, err)
+	}
+
+	return nil
+}
+
+// Sync fast-forwards the local RSL to the RSL at the specified remote. Unlike
+// PullRSL, the local RSL is not updated until the remote's new entries have
+// been verified. The remote RSL is fetched into its remote tracker ref and
+// checked for integrity, and the refs recorded in the new entries are fetched
+// to their remote tracking refs. New policy and attestations entries are
+// verified starting from the locally trusted policy, and each other ref is then
+// verified against policy in an in-memory overlay of the repository. The local
+// RSL and gittuf refs are only fast-forwarded to the remote's tip if every ref
+// verifies. If the two RSLs
+// have diverged, ErrRSLDiverged is returned and ReconcileRSL must be used
+// instead.
+func (r *Repository) Sync(ctx context.Context, remoteName string) error {
+	slog.Debug(fmt.Sprintf("Checking RSL at '%s' for updates...", remoteName))
+	hasUpdates, hasDiverged, err := r.CheckRemoteRSLForUpdatesWithIntegrityCheck(ctx, remoteName)
+	if err != nil {
 		return errors.Join(ErrPullingRSL, err)
 	}
+	if hasDiverged {
+		return ErrRSLDiverged
+	}
+	if !hasUpdates {
+		slog.Debug("Local RSL is up to date")
+		return nil
+	}
+
+	localRef, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+	if err != nil {
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return err
+		}
+
+		// The local RSL is expected to not exist when it's fast-forwarded
+		localRef = plumbing.NewHashReference(rsl.Ref, plumbing.ZeroHash)
+	}
+	remoteRef, err := r.r.Reference(plumbing.ReferenceName(rsl.RemoteTrackerRef(remoteName)), true)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Identifying new RSL entries...")
+	newEntries, err := getReferenceEntriesAfter(r.r, remoteRef.Hash(), localRef.Hash())
+	if err != nil {
+		return err
+	}
+
+	if err := r.fetchRefsForNewEntries(ctx, remoteName, newEntries); err != nil {
+		return err
+	}
+
+	if err := r.verifyRSLUpdate(ctx, remoteName, remoteRef.Hash(), localRef.Hash()); err != nil {
+		return err
+	}
+
+	slog.Debug("Fast-forwarding local RSL...")
+	if err := r.r.Storer.CheckAndSetReference(plumbing.NewHashReference(rsl.Ref, remoteRef.Hash()), localRef); err != nil {
+		return err
+	}
+
+	// Keep the local gittuf refs consistent with the updated RSL
+	refNames, _, latestEntries := groupEntriesByRef(newEntries)
+	for _, refName := range refNames {
+		if !strings.HasPrefix(refName, "refs/gittuf/") || latestEntries[refName].Deleted {
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Updating '%s'...", refName))
+		if err := r.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), latestEntries[refName].TargetID)); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// groupEntriesByRef returns the refs that the entries are for, in order of
+// their first entry, along with the first and latest entry for each ref.
+func groupEntriesByRef(entries []*rsl.ReferenceEntry) ([]string, map[string]*rsl.ReferenceEntry, map[string]*rsl.ReferenceEntry) {
+	refNames := []string{}
+	firstEntries := map[string]*rsl.ReferenceEntry{}
+	latestEntries := map[string]*rsl.ReferenceEntry{}
+	for _, entry := range entries {
+		if _, seen := firstEntries[entry.RefName]; !seen {
+			refNames = append(refNames, entry.RefName)
+			firstEntries[entry.RefName] = entry
+		}
+		latestEntries[entry.RefName] = entry
+	}
+
+	return refNames, firstEntries, latestEntries
+}
+
+// fetchRefsForNewEntries fetches the refs recorded in the new entries from
+// remoteName, as verifying the entries needs their objects.
+func (r *Repository) fetchRefsForNewEntries(ctx context.Context, remoteName string, newEntries []*rsl.ReferenceEntry) error {
+	refNames, _, latestEntries := groupEntriesByRef(newEntries)
+
+	refSpecs := []config.RefSpec{}
+	gittufRefSpecs := []config.RefSpec{}
+	for _, refName := range refNames {
+		if latestEntries[refName].Deleted {
+			continue
+		}
+
+		refSpec := fmt.Sprintf("%s:%s", refName, gitinterface.RemoteRef(refName, remoteName))
+		if !strings.HasPrefix(refName, gitinterface.TagRefPrefix) {
+			// Tags are fetched to the local tag ref and must not be overwritten
+			refSpec = "+" + refSpec
+		}
+		if strings.HasPrefix(refName, "refs/gittuf/") {
+			gittufRefSpecs = append(gittufRefSpecs, config.RefSpec(refSpec))
+			continue
+		}
+		refSpecs = append(refSpecs, config.RefSpec(refSpec))
+	}
+	if len(refSpecs) > 0 {
+		slog.Debug(fmt.Sprintf("Fetching %d refs recorded in new RSL entries...", len(refSpecs)))
+		if err := gitinterface.FetchRefSpec(ctx, r.r, remoteName, refSpecs); err != nil {
+			return errors.Join(ErrPullingRSL, err)
+		}
+	}
+	if len(gittufRefSpecs) > 0 {
+		fetchOptions, err := r.getFetchOptions(remoteName)
+		if err != nil {
+			return err
+		}
+
+		// The full history of gittuf refs isn't needed as their states
+		// are recorded in the RSL, so only the filter applies
+		slog.Debug(fmt.Sprintf("Fetching %d gittuf refs recorded in new RSL entries...", len(gittufRefSpecs)))
+		if err := gitinterface.FetchRefSpecWithOptions(ctx, r.r, remoteName, gittufRefSpecs, &gitinterface.FetchOptions{Filter: fetchOptions.Filter}); err != nil {
+			return errors.Join(ErrPullingRSL, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyNewEntries verifies the new entries recorded in the RSL of repo. The
+// new policy and attestations entries are verified starting from the locally
+// trusted policy, and each other ref is then verified against policy from its
+// first new entry.
+func verifyNewEntries(ctx context.Context, repo *git.Repository, newEntries []*rsl.ReferenceEntry) error {
+	// The local gittuf refs are updated to the new policy and attestations, so
+	// they must be verified starting from the locally trusted policy
+	slog.Debug("Verifying new policy and attestations entries...")
+	if err := policy.VerifyNewGittufEntries(ctx, repo, newEntries); err != nil {
+		return fmt.Errorf("unable to verify new gittuf entries: %w", err)
+	}
+
+	if _, _, err := rsl.GetLatestReferenceEntryForRef(repo, policy.PolicyRef); err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return err
+		}
+
+		// There's no policy to verify against
+		slog.Debug("No policy found, skipping verification of new RSL entries...")
+	} else {
+		refNames, firstEntries, _ := groupEntriesByRef(newEntries)
+		for _, refName := range refNames {
+			if strings.HasPrefix(refName, "refs/gittuf/") {
+				continue
+			}
+
+			slog.Debug(fmt.Sprintf("Verifying new RSL entries for '%s'...", refName))
+			if err := verifyRefFromNewEntry(ctx, repo, refName, firstEntries[refName]); err != nil {
+				return fmt.Errorf("unable to verify new RSL entries for '%s': %w", refName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getReferenceEntriesAfter returns the reference entries recorded after
+// stopID up to and including tipID, in order of occurrence. If stopID is the
+// zero hash, all reference entries up to tipID are returned.
+func getReferenceEntriesAfter(repo *git.Repository, tipID, stopID plumbing.Hash) ([]*rsl.ReferenceEntry, error) {
+	allEntries, err := getEntriesAfter(repo, tipID, stopID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*rsl.ReferenceEntry{}
+	for _, entry := range allEntries {
+		if entry, isReferenceEntry := entry.(*rsl.ReferenceEntry); isReferenceEntry {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// getEntriesAfter returns the entries of all types recorded after stopID up
+// to and including tipID, in order of occurrence. If stopID is the zero hash,
+// all entries up to tipID are returned.
+func getEntriesAfter(repo *git.Repository, tipID, stopID plumbing.Hash) ([]rsl.Entry, error) {
+	entries := []rsl.Entry{}
+
+	iteratorT, err := rsl.GetEntry(repo, tipID)
+	if err != nil {
+		return nil, err
+	}
+	for iteratorT.GetID() != stopID {
+		entries = append(entries, iteratorT)
+
+		iteratorT, err = rsl.GetParentForEntry(repo, iteratorT)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+// verifyRefFromNewEntry verifies the ref from its first new RSL entry. If no
+// policy was recorded before that entry, the ref is verified from the start of
+// the RSL instead.
+func verifyRefFromNewEntry(ctx context.Context, repo *git.Repository, refName string, firstEntry *rsl.ReferenceEntry) error {
+	if _, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, policy.PolicyRef, firstEntry.ID); err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return err
+		}
+
+		_, err := policy.VerifyRefFull(ctx, repo, refName)
+		return err
+	}
+
+	_, err := policy.VerifyRefFromEntry(ctx, repo, refName, firstEntry.ID)
+	return err
+}
+
+// RSLReconciliation describes how ReconcileRSL reconciled the local RSL with a
+// remote RSL.
+type RSLReconciliation struct {
+	// RemoteTip is the tip of the remote RSL that local entries are
+	// re-applied on top of.
+	RemoteTip plumbing.Hash
+
+	// MergeBase is the latest entry common to the local and remote RSLs. It
+	// is the zero hash if the RSLs have no common entry.
+	MergeBase plumbing.Hash
+
+	// RewrittenEntries contains the local-only entries that are re-applied on
+	// top of the remote tip, in order of occurrence.
+	RewrittenEntries []*RewrittenRSLEntry
+}
+
+// RewrittenRSLEntry records a local-only RSL entry that was re-applied on top
+// of the remote RSL.
+type RewrittenRSLEntry struct {
+	// OriginalEntry is the local-only entry.
+	OriginalEntry rsl.Entry
+
+	// NewID is the ID of the recreated entry. It is the zero hash for a dry
+	// run.
+	NewID plumbing.Hash
+}
+
+// ReconcileRSL reconciles the local RSL with the RSL at the specified remote.
+// If the two RSLs have diverged, the entries only present in the local RSL are
+// recreated on top of the remote RSL's tip, in order, and signed again if
+// signCommit is set. Annotations that refer to recreated entries are updated
+// to refer to their new IDs. If a ref is updated in both RSLs, each local
+// update must descend from the ref's target in the remote RSL, otherwise
+// ErrConflictingRefs is returned as re-applying the local update would roll
+// back the remote's update. If the remote RSL is ahead of the local RSL, the
+// local RSL is fast-forwarded. In either case, the refs recorded in the
+// remote-only entries are fetched and the resulting RSL is verified against
+// policy, like Sync, before the local RSL is updated. If dryRun is set, the
+// local RSL is not modified and the returned reconciliation reports the entries
+// that would be rewritten. Otherwise, the registered RSL entry hooks, such as
+// the audit log, are invoked for the recreated entries.
+// If rsl.DeterministicEntries is set, unsigned entries recreated on top of the
+// same remote tip have the same IDs regardless of who reconciles the RSL.
+func (r *Repository) ReconcileRSL(ctx context.Context, remoteName string, signCommit, dryRun bool) (*RSLReconciliation, error) {
+	slog.Debug("Checking remote RSL for updates...")
+	hasUpdates, hasDiverged, err := r.CheckRemoteRSLForUpdatesWithIntegrityCheck(ctx, remoteName)
+	if err != nil {
+		return nil, err
+	}
+
+	localRef, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := &RSLReconciliation{RewrittenEntries: []*RewrittenRSLEntry{}}
+	if !hasUpdates {
+		slog.Debug("Local RSL is up to date with remote RSL")
+		return reconciliation, nil
+	}
+
+	remoteRef, err := r.r.Reference(plumbing.ReferenceName(rsl.RemoteTrackerRef(remoteName)), true)
+	if err != nil {
+		return nil, err
+	}
+	reconciliation.RemoteTip = remoteRef.Hash()
+
+	if !hasDiverged {
+		reconciliation.MergeBase = localRef.Hash()
+		if dryRun {
+			return reconciliation, nil
+		}
+
+		remoteEntries, err := getReferenceEntriesAfter(r.r, remoteRef.Hash(), localRef.Hash())
+		if err != nil {
+			return nil, err
+		}
+		if err := r.fetchRefsForNewEntries(ctx, remoteName, remoteEntries); err != nil {
+			return nil, err
+		}
+		if err := r.verifyRSLUpdate(ctx, remoteName, remoteRef.Hash(), localRef.Hash()); err != nil {
+			return nil, err
+		}
+
+		slog.Debug("Fast-forwarding local RSL to remote RSL...")
+		if err := r.r.Storer.CheckAndSetReference(plumbing.NewHashReference(plumbing.ReferenceName(rsl.Ref), remoteRef.Hash()), localRef); err != nil {
+			return nil, err
+		}
+		return reconciliation, nil
+	}
+
+	slog.Debug("Identifying divergence point of local and remote RSLs...")
+	mergeBase, err := r.getRSLMergeBase(localRef.Hash(), remoteRef.Hash())
+	if err != nil {
+		return nil, err
+	}
+	reconciliation.MergeBase = mergeBase
+
+	slog.Debug("Identifying local-only RSL entries...")
+	localEntries, err := getEntriesAfter(r.r, localRef.Hash(), mergeBase)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Identifying remote-only RSL entries...")
+	remoteEntries, err := getEntriesAfter(r.r, remoteRef.Hash(), mergeBase)
+	if err != nil {
+		return nil, err
+	}
+	remoteReferenceEntries, err := getReferenceEntriesAfter(r.r, remoteRef.Hash(), mergeBase)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.fetchRefsForNewEntries(ctx, remoteName, remoteReferenceEntries); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Checking local-only RSL entries for conflicts...")
+	if err := checkForConflictingRefUpdates(r.r, remoteEntries, localEntries); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range localEntries {
+		reconciliation.RewrittenEntries = append(reconciliation.RewrittenEntries, &RewrittenRSLEntry{OriginalEntry: entry})
+	}
+	if dryRun {
+		return reconciliation, nil
+	}
+
+	slog.Debug("Re-applying local-only RSL entries...")
+	newEntries, err := rsl.ReplayEntries(r.r, remoteRef.Hash(), localEntries, signCommit)
+	if err != nil {
+		return nil, err
+	}
+	parentID := remoteRef.Hash()
+	for i, newEntry := range newEntries {
+		reconciliation.RewrittenEntries[i].NewID = newEntry.GetID()
+		parentID = newEntry.GetID()
+	}
+
+	if err := r.verifyRSLUpdate(ctx, remoteName, parentID, mergeBase); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Updating local RSL...")
+	if err := rsl.RecordReplayedEntries(r.r, localRef.Hash(), newEntries); err != nil {
+		return nil, err
+	}
+
+	return reconciliation, nil
+}
+
+// verifyRSLUpdate verifies the entries recorded after trustedID up to and
+// including newTip against policy, like Sync. The entries are verified in an
+// in-memory overlay of the repository whose RSL is set to newTip, so the local
+// RSL is not modified. The objects of the refs recorded in the entries are
+// expected to be available locally, while objects excluded when fetching gittuf
+// refs are fetched as verification needs them.
+func (r *Repository) verifyRSLUpdate(ctx context.Context, remoteName string, newTip, trustedID plumbing.Hash) error {
+	baseRepo, err := r.getOnDemandFetchRepository(ctx, remoteName)
+	if err != nil {
+		return err
+	}
+
+	overlay, err := git.Open(transactional.NewStorage(baseRepo.Storer, memory.NewStorage()), nil)
+	if err != nil {
+		return err
+	}
+	if err := overlay.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, newTip)); err != nil {
+		return err
+	}
+
+	newEntries, err := getReferenceEntriesAfter(overlay, newTip, trustedID)
+	if err != nil {
+		return err
+	}
+
+	return verifyNewEntries(ctx, overlay, newEntries)
+}
+
+// checkForConflictingRefUpdates checks that the local-only entries can be
+// re-applied on top of the remote-only entries, both in order of occurrence.
+// If a ref is updated by both, each local update must descend from the ref's
+// prior target, starting from its latest target in the remote-only entries.
+// Otherwise, re-applying the local update would roll back the remote's update.
+func checkForConflictingRefUpdates(repo *git.Repository, remoteEntries, localEntries []rsl.Entry) error {
+	targets := map[string]plumbing.Hash{}
+	for _, entry := range remoteEntries {
+		if refName, targetID, isRefUpdate := getRefUpdate(entry); isRefUpdate {
+			targets[refName] = targetID
+		}
+	}
+
+	for _, entry := range localEntries {
+		refName, targetID, isRefUpdate := getRefUpdate(entry)
+		if !isRefUpdate {
+			continue
+		}
+
+		previousTargetID, updatedByRemote := targets[refName]
+		if !updatedByRemote || previousTargetID == targetID {
+			continue
+		}
+
+		conflictErr := fmt.Errorf("%w: local entry '%s' for '%s' does not descend from '%s'", ErrConflictingRefs, entry.GetID().String(), refName, previousTargetID.String())
+		if previousTargetID.IsZero() || targetID.IsZero() {
+			// One of the updates deletes the ref
+			return conflictErr
+		}
+
+		previousCommit, err := gitinterface.GetCommit(repo, previousTargetID)
+		if err != nil {
+			// The prior target is not a commit the local target can
+			// descend from
+			return conflictErr
+		}
+		knows, err := gitinterface.KnowsCommit(repo, targetID, previousCommit)
+		if err != nil {
+			return err
+		}
+		if !knows {
+			return conflictErr
+		}
+
+		targets[refName] = targetID
+	}
+
+	return nil
+}
+
+// getRefUpdate returns the ref and target recorded by a reference or
+// propagation entry.
+func getRefUpdate(entry rsl.Entry) (string, plumbing.Hash, bool) {
+	switch entry := entry.(type) {
+	case *rsl.ReferenceEntry:
+		return entry.RefName, entry.TargetID, true
+	case *rsl.PropagationEntry:
+		return entry.RefName, entry.TargetID, true
+	}
+
+	return "", plumbing.ZeroHash, false
+}
+
+// RefUpdateEvaluation contains the result of simulating an update to a ref
+// using EvaluateRefUpdate.
+type RefUpdateEvaluation struct {
+	// BasisRSLTip is the RSL entry that the update was evaluated against. It
+	// is the zero hash if the update was evaluated against an empty RSL.
+	BasisRSLTip plumbing.Hash
+
+	// PreviousTarget is the target of the ref as recorded in the RSL at
+	// BasisRSLTip. It is the zero hash if the ref has no entries.
+	PreviousTarget plumbing.Hash
+
+	// Rollback is true if the new target does not descend from
+	// PreviousTarget, i.e., the update rewinds or rewrites the ref's history
+	// as recorded at BasisRSLTip.
+	Rollback bool
+}
+
+// EvaluateRefUpdate simulates recording an RSL entry that updates refName to
+// newTarget and verifies the simulated entry using gittuf policy. The entry is
+// created in an in-memory overlay of the repository's storage, so the local RSL
+// and the ref are not modified.
+//
+// The RSL state that the update is evaluated against depends on
+// useLocalRSLTip. If set, the local RSL tip is used, including entries that
+// have not yet been pushed to remoteName. This matches what will happen once
+// the local RSL is pushed. Otherwise, the remote RSL is fetched and the last
+// state common to the local and remote RSLs is used, ignoring unpushed local
+// entries. Fetching the remote RSL updates its remote tracker ref and adds the
+// fetched objects to the repository. Note that the basis affects rollback
+// detection: an update is compared to the ref's latest target at the chosen
+// basis, so an unpushed local entry for the ref may be the difference between
+// a fast-forward and a rollback.
+//
+// The evaluation is returned even when policy verification fails, along with
+// the verification error.
+func (r *Repository) EvaluateRefUpdate(ctx context.Context, remoteName, refName, newTarget string, useLocalRSLTip, signCommit bool) (*RefUpdateEvaluation, error) {
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return nil, err
+	}
+	targetID := plumbing.NewHash(newTarget)
+
+	slog.Debug("Identifying RSL state to evaluate update against...")
+	basis, err := r.getRefUpdateBasis(ctx, remoteName, useLocalRSLTip)
+	if err != nil {
+		return nil, err
+	}
+	evaluation := &RefUpdateEvaluation{BasisRSLTip: basis}
+
+	// The simulated entry is written to the in-memory overlay, leaving the RSL
+	// and the ref untouched
+	overlay, err := git.Open(transactional.NewStorage(r.r.Storer, memory.NewStorage()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := overlay.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, basis)); err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Identifying prior target of '%s'...", absRefName))
+	previousEntry, _, err := rsl.GetLatestReferenceEntryForRef(overlay, absRefName)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+	} else {
+		evaluation.PreviousTarget = previousEntry.TargetID
+
+		if !previousEntry.TargetID.IsZero() {
+			previousCommit, err := gitinterface.GetCommit(overlay, previousEntry.TargetID)
+			if err != nil {
+				return nil, err
+			}
+			knows, err := gitinterface.KnowsCommit(overlay, targetID, previousCommit)
+			if err != nil {
+				return nil, err
+			}
+			evaluation.Rollback = !knows
+		}
+	}
+
+	slog.Debug("Simulating RSL entry for update...")
+	// The simulated entry is never recorded, so the entry hooks aren't invoked
+	if err := rsl.NewReferenceEntry(absRefName, targetID).CommitWithoutHooks(overlay, signCommit); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Verifying simulated update...")
+	_, err = policy.VerifyRef(ctx, overlay, absRefName)
+	return evaluation, err
+}
+
+// getRefUpdateBasis returns the RSL tip that a ref update is evaluated
+// against. See EvaluateRefUpdate for details.
+func (r *Repository) getRefUpdateBasis(ctx context.Context, remoteName string, useLocalRSLTip bool) (plumbing.Hash, error) {
+	localRef, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if useLocalRSLTip {
+		return localRef.Hash(), nil
+	}
+
+	// Update the remote tracker
+	if _, _, err := r.CheckRemoteRSLForUpdates(ctx, remoteName); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	remoteRef, err := r.r.Reference(plumbing.ReferenceName(rsl.RemoteTrackerRef(remoteName)), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			// Remote has no RSL, so there is no common state
+			return plumbing.ZeroHash, nil
+		}
+		return plumbing.ZeroHash, err
+	}
+
+	return r.getRSLMergeBase(localRef.Hash(), remoteRef.Hash())
+}
+
+// getRSLMergeBase returns the latest RSL entry common to the two specified RSL
+// tips. If either tip is the zero hash or the two have no common entry, the
+// zero hash is returned.
+func (r *Repository) getRSLMergeBase(localTip, remoteTip plumbing.Hash) (plumbing.Hash, error) {
+	if localTip.IsZero() || remoteTip.IsZero() {
+		return plumbing.ZeroHash, nil
+	}
+
+	return gitinterface.GetMergeBase(r.r, localTip, remoteTip)
+}
+
+// verifyRemoteRSLIntegrity checks that the remote RSL entries that are new
+// relative to the local RSL form a valid chain.
+func (r *Repository) verifyRemoteRSLIntegrity(localTip, remoteTip plumbing.Hash) error {
+	if remoteTip.IsZero() {
+		return nil
+	}
+
+	basis, err := r.getRSLMergeBase(localTip, remoteTip)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRemoteRSL, err)
+	}
+
+	if err := rsl.VerifyRSLChainIntegrity(r.r, basis, remoteTip); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRemoteRSL, err)
+	}
+
+	return nil
+}
+
+// VerifyAgainstPinnedTip checks that the local RSL contains the specified
+// pinned tip, i.e., the local RSL's tip is either the pinned entry or a
+// descendant of it. The pinned tip is expected to be stored out-of-band by the
+// user as the last known good state of the RSL. If the local RSL does not
+// contain the pinned tip, ErrRSLRollback is returned.
+func (r *Repository) VerifyAgainstPinnedTip(pinnedTip plumbing.Hash) error {
+	slog.Debug("Loading current state of RSL...")
+	localTip, err := gitinterface.GetTip(r.r, rsl.Ref)
+	if err != nil {
+		return err
+	}
+
+	if localTip == pinnedTip {
+		slog.Debug("Local RSL tip matches pinned tip")
+		return nil
+	}
+
+	slog.Debug(fmt.Sprintf("Checking if local RSL contains pinned tip '%s'...", pinnedTip.String()))
+	pinnedCommit, err := gitinterface.GetCommit(r.r, pinnedTip)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			// The pinned entry isn't even available locally
+			return ErrRSLRollback
+		}
+		return err
+	}
+
+	if localTip.IsZero() {
+		return ErrRSLRollback
+	}
+
+	knows, err := gitinterface.KnowsCommit(r.r, localTip, pinnedCommit)
+	if err != nil {
+		return err
+	}
+	if !knows {
+		return ErrRSLRollback
+	}
+
+	return nil
+}
+
+// RevocationLogEntry records a single skip annotation in the RSL along with the
+// reference entries it revokes.
+type RevocationLogEntry struct {
+	// Annotation is the skip annotation that revokes RevokedEntries.
+	Annotation *rsl.AnnotationEntry
+
+	// RevokedEntries contains the reference entries the annotation refers to.
+	RevokedEntries []*rsl.ReferenceEntry
+
+	// SignerKeyID contains the ID of the key that signed the annotation. It is
+	// only set when the signature could be verified using the policy
+	// applicable at the annotation.
+	SignerKeyID string
+
+	// SignerVerified indicates if the annotation's signer could be verified.
+	SignerVerified bool
+}
+
+// GetRevocationLog returns all the skip annotations in the RSL in order of
+// occurrence. Each annotation's referenced entries are resolved to reference
+// entries. The signer of each annotation is identified using the policy
+// applicable at the annotation, and annotations whose signer could not be
+// verified are marked as such.
+func (r *Repository) GetRevocationLog(ctx context.Context) ([]*RevocationLogEntry, error) {
+	slog.Debug("Identifying skip annotations in RSL...")
+	iterator, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	skipAnnotations := []*rsl.AnnotationEntry{}
+	for {
+		if annotation, isAnnotation := iterator.(*rsl.AnnotationEntry); isAnnotation && annotation.Skip {
+			skipAnnotations = append(skipAnnotations, annotation)
+		}
+
+		iterator, err = rsl.GetParentForEntry(r.r, iterator)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	revocationLog := make([]*RevocationLogEntry, 0, len(skipAnnotations))
+	// Walk skip annotations in reverse so the log is in order of occurrence
+	for i := len(skipAnnotations) - 1; i >= 0; i-- {
+		annotation := skipAnnotations[i]
+
+		revocation := &RevocationLogEntry{
+			Annotation:     annotation,
+			RevokedEntries: []*rsl.ReferenceEntry{},
+		}
+
+		slog.Debug(fmt.Sprintf("Resolving entries revoked by annotation '%s'...", annotation.ID.String()))
+		for _, entryID := range annotation.RSLEntryIDs {
+			entry, err := rsl.GetEntry(r.r, entryID)
+			if err != nil {
+				return nil, err
+			}
+
+			if referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry); isReferenceEntry {
+				revocation.RevokedEntries = append(revocation.RevokedEntries, referenceEntry)
+			}
+		}
+
+		slog.Debug(fmt.Sprintf("Identifying signer of annotation '%s'...", annotation.ID.String()))
+		keyID, err := r.identifyEntrySigner(ctx, annotation)
+		if err != nil {
+			return nil, err
+		}
+		if keyID != "" {
+			revocation.SignerKeyID = keyID
+			revocation.SignerVerified = true
+		}
+
+		revocationLog = append(revocationLog, revocation)
+	}
+
+	return revocationLog, nil
+}
+
+// BuildAnnotationTimeline returns the annotations that refer to the specified
+// RSL entry in order of occurrence, along with each annotation's effect on the
+// entry. The signer of each annotation is identified using the policy
+// applicable at the annotation.
+func (r *Repository) BuildAnnotationTimeline(ctx context.Context, entryID string) ([]*rsl.AnnotationEvent, error) {
+	slog.Debug(fmt.Sprintf("Building annotation timeline for entry '%s'...", entryID))
+	timeline, err := rsl.BuildAnnotationTimeline(r.r, plumbing.NewHash(entryID))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range timeline {
+		slog.Debug(fmt.Sprintf("Identifying signer of annotation '%s'...", event.Annotation.ID.String()))
+		keyID, err := r.identifyEntrySigner(ctx, event.Annotation)
+		if err != nil {
+			return nil, err
+		}
+		if keyID != "" {
+			event.SignerKeyID = keyID
+			event.SignerVerified = true
+		}
+	}
+
+	return timeline, nil
+}
+
+// GetEntriesAuthorizedByKey returns the reference entries between fromID and
+// toID that were signed by the specified key while the key was authorized to
+// change the entry's ref. Each entry is checked against the policy applicable
+// at that entry, so entries the key signed without being trusted for the ref
+// are not returned. Entries for refs not protected by any rule are also
+// excluded, as the key's signature was not needed to authorize them.
+func (r *Repository) GetEntriesAuthorizedByKey(ctx context.Context, keyID, fromID, toID string) ([]*rsl.ReferenceEntry, error) {
+	slog.Debug("Loading entries in range...")
+	entries, _, err := rsl.GetReferenceEntriesInRange(r.r, plumbing.NewHash(fromID), plumbing.NewHash(toID))
+	if err != nil {
+		return nil, err
+	}
+
+	states := map[plumbing.Hash]*policy.State{}
+	authorizedEntries := []*rsl.ReferenceEntry{}
+	for _, entry := range entries {
+		commit, err := gitinterface.GetCommit(r.r, entry.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(commit.PGPSignature) == 0 {
+			continue
+		}
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(r.r, policy.PolicyRef, entry.ID)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		state, loaded := states[policyEntry.ID]
+		if !loaded {
+			slog.Debug(fmt.Sprintf("Loading policy from entry '%s'...", policyEntry.ID.String()))
+			state, err = policy.LoadState(ctx, r.r, policyEntry)
+			if err != nil {
+				return nil, err
+			}
+			states[policyEntry.ID] = state
+		}
+
+		signer, err := state.ResolveKeyForSignature(ctx, commit)
+		if err != nil {
+			if errors.Is(err, policy.ErrNoMatchingKey) {
+				continue
+			}
+			return nil, err
+		}
+		if signer.KeyID != keyID {
+			continue
+		}
+
+		authorizedKeys, _, err := state.GetAuthorizedKeysForRef(entry.RefName)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range authorizedKeys {
+			if key.KeyID == keyID {
+				authorizedEntries = append(authorizedEntries, entry)
+				break
+			}
+		}
+	}
+
+	return authorizedEntries, nil
+}
+
+// identifyEntrySigner returns the ID of the key that signed the RSL entry. The
+// keys are loaded from the policy applicable at the entry. If the signature
+// cannot be verified using any key, or if no policy is applicable, an empty
+// key ID is returned.
+func (r *Repository) identifyEntrySigner(ctx context.Context, entry rsl.Entry) (string, error) {
+	return r.identifyEntrySignerUsingStates(ctx, entry, map[plumbing.Hash]*policy.State{})
+}
+
+// identifyEntrySignerUsingStates is identifyEntrySigner with the policy states
+// loaded so far indexed by their policy entry IDs. Newly loaded states are
+// added to states, which avoids reloading them when identifying the signers of
+// many entries.
+func (r *Repository) identifyEntrySignerUsingStates(ctx context.Context, entry rsl.Entry, states map[plumbing.Hash]*policy.State) (string, error) {
+	commit, err := gitinterface.GetCommit(r.r, entry.GetID())
+	if err != nil {
+		return "", err
+	}
+
+	if len(commit.PGPSignature) == 0 {
+		return "", nil
+	}
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(r.r, policy.PolicyRef, entry.GetID())
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	state, loaded := states[policyEntry.ID]
+	if !loaded {
+		state, err = policy.LoadState(ctx, r.r, policyEntry)
+		if err != nil {
+			return "", err
+		}
+		states[policyEntry.ID] = state
+	}
+
+	key, err := state.ResolveKeyForSignature(ctx, commit)
+	if err != nil {
+		if errors.Is(err, policy.ErrNoMatchingKey) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return key.KeyID, nil
+}
+
 // isDuplicateEntry checks if the latest unskipped entry for the ref has the
 // same target ID Note that it's legal for the RSL to have target A, then B,
 // then A again, this is not considered a duplicate entry
 func (r *Repository) isDuplicateEntry(refName string, targetID plumbing.Hash) (bool, error) {
-	latestUnskippedEntry, _, err := rsl.GetLatestUnskippedReferenceEntryForRef(r.r, refName)
+	latestUnskippedEntry, _, err := policy.NewSkipResolver(r.r).GetLatestUnskippedReferenceEntryForRef(context.Background(), refName)
 	if err != nil {
 		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
 			return false, nil
@@ -203,5 +1494,5 @@ func (r *Repository) isDuplicateEntry(refName string, targetID plumbing.Hash) (b
 		return false, err
 	}
 
-	return latestUnskippedEntry.TargetID == targetID, nil
+	return !latestUnskippedEntry.Deleted && latestUnskippedEntry.TargetID == targetID, nil
 }
@@ -3,15 +3,20 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -101,6 +106,335 @@ func TestRecordRSLEntryForReference(t *testing.T) {
 	}
 	// check that a duplicate entry has not been created
 	assert.Equal(t, entry.GetID(), entryType.GetID())
+
+	// Refs in other namespaces can be recorded using their name relative to
+	// refs/
+	ref = plumbing.NewHashReference(plumbing.ReferenceName("refs/notes/commits"), testHash)
+	if err := repo.r.Storer.SetReference(ref); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.RecordRSLEntryForReference("notes/commits", false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, ok = latestEntry.(*rsl.ReferenceEntry)
+	if !ok {
+		t.Fatal(fmt.Errorf("invalid entry type"))
+	}
+	assert.Equal(t, "refs/notes/commits", entry.RefName)
+	assert.Equal(t, testHash, entry.TargetID)
+}
+
+func TestRecordRSLEntryForReferenceSigningFailure(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := plumbing.NewHashReference(plumbing.ReferenceName("refs/heads/main"), plumbing.ZeroHash)
+	if err := repo.r.Storer.SetReference(ref); err != nil {
+		t.Fatal(err)
+	}
+
+	rslRef, err := repo.r.Reference(rsl.Ref, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Inject a signing failure by configuring an unknown signing method
+	gitConfigPath := filepath.Join(t.TempDir(), "gitconfig")
+	if err := os.WriteFile(gitConfigPath, []byte("[gpg]\n\tformat = unknown\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", gitConfigPath)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	err = repo.RecordRSLEntryForReference("refs/heads/main", true)
+	assert.ErrorIs(t, err, gitinterface.ErrUnknownSigningMethod)
+
+	currentRSLRef, err := repo.r.Reference(rsl.Ref, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, rslRef.Hash(), currentRSLRef.Hash())
+}
+
+func TestRecordRSLEntryForReferenceDeletion(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/feature"
+	testHash := plumbing.NewHash("abcdef1234567890")
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), testHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unknown to the RSL
+	err = repo.RecordRSLEntryForReferenceDeletion("feature", false)
+	assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+
+	if err := repo.RecordRSLEntryForReference(refName, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ref still exists
+	err = repo.RecordRSLEntryForReferenceDeletion("feature", false)
+	assert.ErrorIs(t, err, ErrRefNotDeleted)
+
+	if err := repo.r.Storer.RemoveReference(plumbing.ReferenceName(refName)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLEntryForReferenceDeletion("feature", false)
+	assert.Nil(t, err)
+
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, refName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, entry.Deleted)
+	assert.Equal(t, plumbing.ZeroHash, entry.TargetID)
+
+	// Recording the deletion again is a no-op
+	err = repo.RecordRSLEntryForReferenceDeletion(refName, false)
+	assert.Nil(t, err)
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entry.ID, latestEntry.GetID())
+
+	// Resurrecting the ref at its previous target is recorded
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), testHash)); err != nil {
+		t.Fatal(err)
+	}
+	err = repo.RecordRSLEntryForReference(refName, false)
+	assert.Nil(t, err)
+
+	entry, _, err = rsl.GetLatestReferenceEntryForRef(repo.r, refName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, entry.Deleted)
+	assert.Equal(t, testHash, entry.TargetID)
+
+	// The deletion of gittuf refs can't be recorded
+	gittufRefName := "refs/gittuf/attestations"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(gittufRefName), testHash)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RecordRSLEntryForReference(gittufRefName, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.r.Storer.RemoveReference(plumbing.ReferenceName(gittufRefName)); err != nil {
+		t.Fatal(err)
+	}
+	err = repo.RecordRSLEntryForReferenceDeletion(gittufRefName, false)
+	assert.ErrorIs(t, err, rsl.ErrCannotDeleteGittufRef)
+}
+
+func TestRecordRSLEntriesForReferences(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	testHash := plumbing.NewHash("abcdef1234567890")
+	for _, refName := range []string{"refs/heads/main", "refs/heads/feature", "refs/tags/v1"} {
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), testHash)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// main is already recorded
+	if err := repo.RecordRSLEntryForReference("refs/heads/main", false); err != nil {
+		t.Fatal(err)
+	}
+	mainEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLEntriesForReferences([]string{"main", "feature", "refs/heads/feature", "refs/tags/v1"}, false)
+	assert.Nil(t, err)
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, _, err := rsl.GetReferenceEntriesInRange(repo.r, mainEntry.GetID(), latestEntry.GetID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "refs/heads/main", entries[0].RefName)
+	assert.Equal(t, "refs/heads/feature", entries[1].RefName)
+	assert.Equal(t, "refs/tags/v1", entries[2].RefName)
+
+	err = repo.RecordRSLEntriesForReferences([]string{"main", rsl.Ref}, false)
+	assert.ErrorIs(t, err, rsl.ErrCannotRecordRSLRef)
+}
+
+func TestRecordRSLEntryForReferenceWithActor(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := gitinterface.Commit(repo.r, gitinterface.EmptyTree(), "refs/heads/main", "Test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actorURL := "https://ci.example.com/jobs/1"
+	err = repo.RecordRSLEntryForReferenceWithActor("main", "github:alice", actorURL, false)
+	assert.Nil(t, err)
+
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, "refs/heads/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, commitID, entry.TargetID)
+	assert.Equal(t, "github:alice", entry.Actor)
+	assert.Equal(t, actorURL, entry.ActorURL)
+
+	// The state is already recorded, so no new entry is created
+	err = repo.RecordRSLEntryForReferenceWithActor("main", "github:bob", "", false)
+	assert.Nil(t, err)
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entry.ID, latestEntry.GetID())
+
+	if _, err := gitinterface.Commit(repo.r, gitinterface.EmptyTree(), "refs/heads/main", "Test commit", false); err != nil {
+		t.Fatal(err)
+	}
+	err = repo.RecordRSLEntryForReferenceWithActor("main", "github:alice", "ci.example.com/jobs/2", false)
+	assert.ErrorIs(t, err, rsl.ErrInvalidActorURL)
+}
+
+func TestRecordRSLEntryForReferenceWithArtifactDigest(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := gitinterface.Commit(repo.r, gitinterface.EmptyTree(), "refs/heads/main", "Test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference("refs/tags/v1.0.0", commitID)); err != nil {
+		t.Fatal(err)
+	}
+
+	artifact := []byte("release artifact")
+	artifactDigest, err := rsl.ComputeArtifactDigest(bytes.NewReader(artifact))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLEntryForReferenceWithArtifactDigest("refs/tags/v1.0.0", "sha256:abcdef", false)
+	assert.ErrorIs(t, err, rsl.ErrInvalidArtifactDigest)
+
+	if err := repo.RecordRSLEntryForReferenceWithArtifactDigest("refs/tags/v1.0.0", artifactDigest, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, "refs/tags/v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, commitID, entry.TargetID)
+	assert.Equal(t, artifactDigest, entry.ArtifactDigest)
+
+	// Recording the same target and digest again does not create a new entry
+	if err := repo.RecordRSLEntryForReferenceWithArtifactDigest("refs/tags/v1.0.0", artifactDigest, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entry.ID, latestEntry.GetID())
+
+	err = repo.VerifyArtifactForReference("refs/tags/v1.0.0", bytes.NewReader(artifact))
+	assert.Nil(t, err)
+
+	err = repo.VerifyArtifactForReference("refs/tags/v1.0.0", bytes.NewReader([]byte("tampered artifact")))
+	assert.ErrorIs(t, err, rsl.ErrArtifactDigestMismatch)
+}
+
+func TestRecordRSLEntryForRSLReference(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference("refs/heads/main", plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RecordRSLEntryForReference("refs/heads/main", false); err != nil {
+		t.Fatal(err)
+	}
+
+	rslRef, err := repo.r.Reference(rsl.Ref, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLEntryForReference(rsl.Ref, false)
+	assert.ErrorIs(t, err, rsl.ErrCannotRecordRSLRef)
+
+	currentRSLRef, err := repo.r.Reference(rsl.Ref, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, rslRef.Hash(), currentRSLRef.Hash())
 }
 
 func TestRecordRSLEntryForReferenceAtTarget(t *testing.T) {
@@ -238,24 +572,109 @@ func TestRecordRSLAnnotation(t *testing.T) {
 	assert.True(t, annotation.Skip)
 }
 
-func TestCheckRemoteRSLForUpdates(t *testing.T) {
-	remoteName := "origin"
-	refName := "refs/heads/main"
-	anotherRefName := "refs/heads/feature"
+func TestRecordRSLUnskipAnnotation(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	t.Run("remote has updates for local", func(t *testing.T) {
-		tmpDir, err := os.MkdirTemp("", "gittuf")
-		if err != nil {
-			t.Fatal(err)
-		}
-		defer os.RemoveAll(tmpDir) //nolint:errcheck
+	repo := &Repository{r: r}
 
-		// Simulate remote actions
-		remoteR, err := git.PlainInit(tmpDir, false)
-		if err != nil {
-			t.Fatal(err)
-		}
-		remoteRepo := &Repository{r: remoteR}
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference("refs/heads/main", plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RecordRSLEntryForReference("refs/heads/main", false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entryID := entry.GetID()
+
+	if err := repo.RecordRSLAnnotation([]string{entryID.String()}, true, "skip annotation", false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLUnskipAnnotation([]string{entryID.String()}, "unskip annotation", false)
+	assert.Nil(t, err)
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotation := latestEntry.(*rsl.AnnotationEntry)
+	assert.Equal(t, "unskip annotation", annotation.Message)
+	assert.Equal(t, []plumbing.Hash{entryID}, annotation.RSLEntryIDs)
+	assert.False(t, annotation.Skip)
+	assert.True(t, annotation.Unskip)
+
+	referenceEntry, _, err := rsl.GetLatestUnskippedReferenceEntryForRef(repo.r, "refs/heads/main")
+	assert.Nil(t, err)
+	assert.Equal(t, entryID, referenceEntry.ID)
+}
+
+func TestRecordRSLAnnotationMessageTooLarge(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference("refs/heads/main", plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RecordRSLEntryForReference("refs/heads/main", false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLAnnotation([]string{latestEntry.GetID().String()}, true, strings.Repeat("a", rsl.DefaultMaxAnnotationMessageSize+1), false)
+	assert.ErrorIs(t, err, rsl.ErrAnnotationMessageTooLarge)
+
+	currentEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, latestEntry.GetID(), currentEntry.GetID())
+
+	findings, err := repo.FsckRSL()
+	assert.Nil(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestCheckRemoteRSLForUpdates(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+	anotherRefName := "refs/heads/feature"
+
+	t.Run("remote has updates for local", func(t *testing.T) {
+		tmpDir, err := os.MkdirTemp("", "gittuf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(tmpDir) //nolint:errcheck
+
+		// Simulate remote actions
+		remoteR, err := git.PlainInit(tmpDir, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		remoteRepo := &Repository{r: remoteR}
 
 		// We can't use remoteRepo.InitializeNamespaces() as it'll create zero
 		// namespace for policy, an issue when syncing.
@@ -445,6 +864,78 @@ func TestCheckRemoteRSLForUpdates(t *testing.T) {
 	})
 }
 
+func TestCheckRemoteRSLForUpdatesWithIntegrityCheck(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	setup := func(t *testing.T) (*Repository, *Repository) {
+		t.Helper()
+
+		tmpDir := t.TempDir()
+
+		// Simulate remote actions
+		remoteR, err := git.PlainInit(tmpDir, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		remoteRepo := &Repository{r: remoteR}
+
+		if err := rsl.InitializeNamespace(remoteRepo.r); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := remoteRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Clone remote repository
+		localR, err := gitinterface.CloneAndFetchToMemory(context.Background(), tmpDir, refName, []string{rsl.Ref})
+		if err != nil {
+			t.Fatal(err)
+		}
+		localRepo := &Repository{r: localR}
+
+		return remoteRepo, localRepo
+	}
+
+	t.Run("remote has valid updates for local", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		if _, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), refName, "Another test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := remoteRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		hasUpdates, hasDiverged, err := localRepo.CheckRemoteRSLForUpdatesWithIntegrityCheck(context.Background(), remoteName)
+		assert.Nil(t, err)
+		assert.True(t, hasUpdates)
+		assert.False(t, hasDiverged)
+	})
+
+	t.Run("remote has invalid entry", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		// Add a commit to the remote RSL that is not an RSL entry
+		if _, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), rsl.Ref, "Not an RSL entry", false); err != nil {
+			t.Fatal(err)
+		}
+
+		// The unverified check reports an update
+		hasUpdates, _, err := localRepo.CheckRemoteRSLForUpdates(context.Background(), remoteName)
+		assert.Nil(t, err)
+		assert.True(t, hasUpdates)
+
+		_, _, err = localRepo.CheckRemoteRSLForUpdatesWithIntegrityCheck(context.Background(), remoteName)
+		assert.ErrorIs(t, err, ErrInvalidRemoteRSL)
+		assert.ErrorIs(t, err, rsl.ErrInvalidRSLChain)
+	})
+}
+
 func TestPushRSL(t *testing.T) {
 	remoteName := "origin"
 
@@ -503,18 +994,20 @@ func TestPushRSL(t *testing.T) {
 	})
 }
 
-func TestPullRSL(t *testing.T) {
+func TestPushRefAndRSL(t *testing.T) {
 	remoteName := "origin"
+	refName := "refs/heads/main"
 
-	t.Run("successful pull", func(t *testing.T) {
-		remoteTmpDir := t.TempDir()
-		remoteRepo := createTestRepositoryWithPolicy(t, remoteTmpDir)
+	setup := func(t *testing.T) (*Repository, *git.Repository) {
+		t.Helper()
 
-		localRepoR, err := git.Init(memory.NewStorage(), memfs.New())
+		remoteTmpDir := t.TempDir()
+		remoteRepo, err := git.PlainInit(remoteTmpDir, true)
 		if err != nil {
 			t.Fatal(err)
 		}
-		localRepo := &Repository{r: localRepoR}
+
+		localRepo := createTestRepositoryWithPolicy(t, "")
 		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
 			Name: remoteName,
 			URLs: []string{remoteTmpDir},
@@ -522,34 +1015,122 @@ func TestPullRSL(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err = localRepo.PullRSL(context.Background(), remoteName)
+		if _, err := gitinterface.Commit(localRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+
+		return localRepo, remoteRepo
+	}
+
+	t.Run("successful push", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		if err := localRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := localRepo.PushRefAndRSL(testCtx, remoteName, refName)
 		assert.Nil(t, err)
 
-		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, rsl.Ref)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, refName)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, rsl.Ref)
+	})
 
-		// No updates, successful pull
-		err = localRepo.PullRSL(context.Background(), remoteName)
+	t.Run("ref state not recorded in RSL", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		err := localRepo.PushRefAndRSL(testCtx, remoteName, refName)
+		assert.ErrorIs(t, err, ErrRefStateNotInRSL)
+
+		if err := localRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gitinterface.Commit(localRepo.r, gitinterface.EmptyTree(), refName, "Another commit", false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.PushRefAndRSL(testCtx, remoteName, refName)
+		assert.ErrorIs(t, err, ErrRefStateNotInRSL)
+
+		_, err = remoteRepo.Reference(plumbing.ReferenceName(refName), true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	})
+
+	t.Run("RSL rejected, ref not updated", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		if err := rsl.InitializeNamespace(remoteRepo); err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.NewReferenceEntry(policy.PolicyRef, plumbing.ZeroHash).Commit(remoteRepo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := localRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := localRepo.PushRefAndRSL(testCtx, remoteName, refName)
+		assert.ErrorIs(t, err, ErrPushingRSL)
+
+		_, err = remoteRepo.Reference(plumbing.ReferenceName(refName), true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	})
+}
+
+func TestRecordAndPushRSLEntry(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	t.Run("successful record and push", func(t *testing.T) {
+		remoteTmpDir := t.TempDir()
+
+		remoteRepo, err := git.PlainInit(remoteTmpDir, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		localRepo := createTestRepositoryWithPolicy(t, "")
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := gitinterface.Commit(localRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.RecordAndPushRSLEntry(context.Background(), refName, remoteName, false)
 		assert.Nil(t, err)
+
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(localRepo.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, refName, entry.RefName)
+
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, rsl.Ref)
 	})
 
-	t.Run("divergent RSLs, unsuccessful pull", func(t *testing.T) {
+	t.Run("divergent RSLs, entry rolled back", func(t *testing.T) {
 		remoteTmpDir := t.TempDir()
-		createTestRepositoryWithPolicy(t, remoteTmpDir)
 
-		localRepoR, err := git.Init(memory.NewStorage(), memfs.New())
+		remoteRepo, err := git.PlainInit(remoteTmpDir, true)
 		if err != nil {
 			t.Fatal(err)
 		}
-		localRepo := &Repository{r: localRepoR}
 
-		if err := rsl.InitializeNamespace(localRepo.r); err != nil {
+		if err := rsl.InitializeNamespace(remoteRepo); err != nil {
 			t.Fatal(err)
 		}
 
-		if err := rsl.NewReferenceEntry(policy.PolicyRef, plumbing.ZeroHash).Commit(localRepo.r, false); err != nil {
+		if err := rsl.NewReferenceEntry(policy.PolicyRef, plumbing.ZeroHash).Commit(remoteRepo, false); err != nil {
 			t.Fatal(err)
 		}
 
+		localRepo := createTestRepositoryWithPolicy(t, "")
 		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
 			Name: remoteName,
 			URLs: []string{remoteTmpDir},
@@ -557,7 +1138,721 @@ func TestPullRSL(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err = localRepo.PullRSL(context.Background(), remoteName)
-		assert.ErrorIs(t, err, ErrPullingRSL)
+		if _, err := gitinterface.Commit(localRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+
+		previousRSLRef, err := localRepo.r.Reference(rsl.Ref, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.RecordAndPushRSLEntry(context.Background(), refName, remoteName, false)
+		assert.ErrorIs(t, err, ErrRSLEntryRolledBack)
+		assert.ErrorIs(t, err, ErrPushingRSL)
+
+		currentRSLRef, err := localRepo.r.Reference(rsl.Ref, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, previousRSLRef.Hash(), currentRSLRef.Hash())
+	})
+
+	t.Run("unknown remote, entry retained", func(t *testing.T) {
+		localRepo := createTestRepositoryWithPolicy(t, "")
+
+		if _, err := gitinterface.Commit(localRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := localRepo.RecordAndPushRSLEntry(context.Background(), refName, remoteName, false)
+		assert.ErrorIs(t, err, ErrPushingRSL)
+		assert.NotErrorIs(t, err, ErrRSLEntryRolledBack)
+
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(localRepo.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, refName, entry.RefName)
 	})
 }
+
+// testEntryHook counts the RSL entries it is invoked for and records the IDs
+// of the entries reported after they're recorded.
+type testEntryHook struct {
+	name     string
+	calls    int
+	recorded []plumbing.Hash
+}
+
+func (h *testEntryHook) Name() string {
+	return h.name
+}
+
+func (h *testEntryHook) BeforeCommit(_ *git.Repository, _ rsl.Entry) error {
+	h.calls++
+	return nil
+}
+
+func (h *testEntryHook) AfterCommit(_ *git.Repository, entry rsl.Entry) error {
+	h.calls++
+	h.recorded = append(h.recorded, entry.GetID())
+	return nil
+}
+
+func TestReconcileRSL(t *testing.T) {
+	remoteName := "origin"
+	remoteRefName := "refs/heads/feature"
+	localRefName := "refs/heads/local"
+
+	setup := func(t *testing.T) (*Repository, *git.Repository) {
+		t.Helper()
+
+		remoteTmpDir := t.TempDir()
+		remoteRepo, err := git.PlainInit(remoteTmpDir, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		localRepo := createTestRepositoryWithPolicy(t, "")
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := localRepo.PushRSL(testCtx, remoteName); err != nil {
+			t.Fatal(err)
+		}
+
+		return localRepo, remoteRepo
+	}
+
+	// recordUpdate creates a commit for refName with the specified parents
+	// and records it in the RSL
+	recordUpdate := func(t *testing.T, repo *git.Repository, refName string, parentIDs ...plumbing.Hash) plumbing.Hash {
+		t.Helper()
+
+		commitID, err := gitinterface.CommitWithParents(repo, gitinterface.EmptyTree(), parentIDs, refName, "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.NewReferenceEntry(refName, commitID).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		return commitID
+	}
+
+	t.Run("up to date", func(t *testing.T) {
+		localRepo, _ := setup(t)
+
+		reconciliation, err := localRepo.ReconcileRSL(testCtx, remoteName, false, false)
+		assert.Nil(t, err)
+		assert.Empty(t, reconciliation.RewrittenEntries)
+	})
+
+	t.Run("remote ahead", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		recordUpdate(t, remoteRepo, remoteRefName)
+
+		reconciliation, err := localRepo.ReconcileRSL(testCtx, remoteName, false, false)
+		assert.Nil(t, err)
+		assert.Empty(t, reconciliation.RewrittenEntries)
+
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, rsl.Ref)
+	})
+
+	t.Run("diverged", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		recordUpdate(t, remoteRepo, remoteRefName)
+		remoteTip, err := gitinterface.GetTip(remoteRepo, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		recordUpdate(t, localRepo.r, localRefName)
+		localEntry, err := rsl.GetLatestEntry(localRepo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.NewAnnotationEntry([]plumbing.Hash{localEntry.GetID()}, true, "revoke").Commit(localRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+		localTip, err := gitinterface.GetTip(localRepo.r, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reconciliation, err := localRepo.ReconcileRSL(testCtx, remoteName, false, true)
+		assert.Nil(t, err)
+		assert.Equal(t, remoteTip, reconciliation.RemoteTip)
+		if assert.Equal(t, 2, len(reconciliation.RewrittenEntries)) {
+			assert.Equal(t, localEntry.GetID(), reconciliation.RewrittenEntries[0].OriginalEntry.GetID())
+			assert.Equal(t, localTip, reconciliation.RewrittenEntries[1].OriginalEntry.GetID())
+			assert.True(t, reconciliation.RewrittenEntries[0].NewID.IsZero())
+		}
+
+		// A dry run must not modify the local RSL
+		currentTip, err := gitinterface.GetTip(localRepo.r, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, localTip, currentTip)
+
+		// The recreated entries are reported to the entry hooks
+		hook := &testEntryHook{name: "test"}
+		if err := rsl.RegisterEntryHook(hook); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { rsl.UnregisterEntryHook(hook.name) })
+
+		reconciliation, err = localRepo.ReconcileRSL(testCtx, remoteName, false, false)
+		assert.Nil(t, err)
+		if !assert.Equal(t, 2, len(reconciliation.RewrittenEntries)) {
+			return
+		}
+		assert.Equal(t, []plumbing.Hash{reconciliation.RewrittenEntries[0].NewID, reconciliation.RewrittenEntries[1].NewID}, hook.recorded)
+
+		newEntry, err := rsl.GetEntry(localRepo.r, reconciliation.RewrittenEntries[0].NewID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, localRefName, newEntry.(*rsl.ReferenceEntry).RefName)
+
+		parentEntry, err := rsl.GetParentForEntry(localRepo.r, newEntry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, remoteTip, parentEntry.GetID())
+
+		latestEntry, err := rsl.GetLatestEntry(localRepo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, reconciliation.RewrittenEntries[1].NewID, latestEntry.GetID())
+		annotation := latestEntry.(*rsl.AnnotationEntry)
+		assert.Equal(t, []plumbing.Hash{newEntry.GetID()}, annotation.RSLEntryIDs)
+		assert.True(t, annotation.Skip)
+
+		// The reconciled RSL can be pushed
+		err = localRepo.PushRSL(testCtx, remoteName)
+		assert.Nil(t, err)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, rsl.Ref)
+	})
+
+	t.Run("diverged with deletion entry", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		recordUpdate(t, remoteRepo, remoteRefName)
+
+		recordUpdate(t, localRepo.r, localRefName)
+		if err := rsl.NewReferenceDeletionEntry(localRefName).Commit(localRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		reconciliation, err := localRepo.ReconcileRSL(testCtx, remoteName, false, false)
+		assert.Nil(t, err)
+		if !assert.Equal(t, 2, len(reconciliation.RewrittenEntries)) {
+			return
+		}
+
+		newEntry, err := rsl.GetEntry(localRepo.r, reconciliation.RewrittenEntries[1].NewID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, newEntry.(*rsl.ReferenceEntry).Deleted)
+		assert.Equal(t, plumbing.ZeroHash, newEntry.(*rsl.ReferenceEntry).TargetID)
+	})
+
+	t.Run("local update descends from remote update", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		remoteTarget := recordUpdate(t, remoteRepo, remoteRefName)
+		if err := gitinterface.FetchRefSpec(testCtx, localRepo.r, remoteName, []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", remoteRefName, gitinterface.RemoteRef(remoteRefName, remoteName)))}); err != nil {
+			t.Fatal(err)
+		}
+		recordUpdate(t, localRepo.r, remoteRefName, remoteTarget)
+
+		reconciliation, err := localRepo.ReconcileRSL(testCtx, remoteName, false, false)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(reconciliation.RewrittenEntries))
+	})
+
+	t.Run("conflicting updates to the same ref", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		recordUpdate(t, remoteRepo, remoteRefName)
+
+		// The local update doesn't build on the remote's update
+		localTarget, err := gitinterface.Commit(localRepo.r, gitinterface.EmptyTree(), remoteRefName, "Local commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.NewReferenceEntry(remoteRefName, localTarget).Commit(localRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+		localTip, err := gitinterface.GetTip(localRepo.r, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = localRepo.ReconcileRSL(testCtx, remoteName, false, true)
+		assert.ErrorIs(t, err, ErrConflictingRefs)
+
+		_, err = localRepo.ReconcileRSL(testCtx, remoteName, false, false)
+		assert.ErrorIs(t, err, ErrConflictingRefs)
+
+		currentTip, err := gitinterface.GetTip(localRepo.r, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, localTip, currentTip)
+	})
+
+	t.Run("rewritten entries that fail verification", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		recordUpdate(t, remoteRepo, remoteRefName)
+
+		// The protected ref's entry is not signed by an authorized key
+		recordUpdate(t, localRepo.r, "refs/heads/main")
+		localTip, err := gitinterface.GetTip(localRepo.r, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = localRepo.ReconcileRSL(testCtx, remoteName, false, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+		currentTip, err := gitinterface.GetTip(localRepo.r, rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, localTip, currentTip)
+	})
+}
+
+func TestPullRSL(t *testing.T) {
+	remoteName := "origin"
+
+	t.Run("successful pull", func(t *testing.T) {
+		remoteTmpDir := t.TempDir()
+		remoteRepo := createTestRepositoryWithPolicy(t, remoteTmpDir)
+
+		localRepoR, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		localRepo := &Repository{r: localRepoR}
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.PullRSL(context.Background(), remoteName)
+		assert.Nil(t, err)
+
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, rsl.Ref)
+
+		// No updates, successful pull
+		err = localRepo.PullRSL(context.Background(), remoteName)
+		assert.Nil(t, err)
+	})
+
+	t.Run("divergent RSLs, unsuccessful pull", func(t *testing.T) {
+		remoteTmpDir := t.TempDir()
+		createTestRepositoryWithPolicy(t, remoteTmpDir)
+
+		localRepoR, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		localRepo := &Repository{r: localRepoR}
+
+		if err := rsl.InitializeNamespace(localRepo.r); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := rsl.NewReferenceEntry(policy.PolicyRef, plumbing.ZeroHash).Commit(localRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.PullRSL(context.Background(), remoteName)
+		assert.ErrorIs(t, err, ErrPullingRSL)
+	})
+}
+
+func TestSync(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/feature"
+	protectedRefName := "refs/heads/main"
+
+	setup := func(t *testing.T) (*Repository, *Repository) {
+		t.Helper()
+
+		tmpDir := t.TempDir()
+		remoteRepo := createTestRepositoryWithPolicy(t, tmpDir)
+
+		if _, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := remoteRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		localR, err := gitinterface.CloneAndFetchToMemory(testCtx, tmpDir, refName, []string{rsl.Ref, policy.PolicyRef})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return remoteRepo, &Repository{r: localR}
+	}
+
+	t.Run("no updates", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		err := localRepo.Sync(testCtx, remoteName)
+		assert.Nil(t, err)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, rsl.Ref)
+	})
+
+	t.Run("verified updates are fast-forwarded", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		newTip, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := remoteRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.Sync(testCtx, remoteName)
+		assert.Nil(t, err)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, rsl.Ref)
+
+		remoteTrackerRef, err := localRepo.r.Reference(plumbing.ReferenceName(gitinterface.RemoteRef(refName, remoteName)), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, newTip, remoteTrackerRef.Hash())
+	})
+
+	t.Run("unverified updates are not fast-forwarded", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		localRSLTip, err := localRepo.r.Reference(rsl.Ref, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The protected ref's commit is not signed by an authorized key
+		if _, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), protectedRefName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := remoteRepo.RecordRSLEntryForReference(protectedRefName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.Sync(testCtx, remoteName)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+		currentLocalRSLTip, err := localRepo.r.Reference(rsl.Ref, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, localRSLTip.Hash(), currentLocalRSLTip.Hash())
+	})
+
+	t.Run("diverged RSLs", func(t *testing.T) {
+		remoteRepo, localRepo := setup(t)
+
+		if _, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := remoteRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := gitinterface.Commit(localRepo.r, gitinterface.EmptyTree(), refName, "Local test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := localRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := localRepo.Sync(testCtx, remoteName)
+		assert.ErrorIs(t, err, ErrRSLDiverged)
+	})
+}
+
+func TestEvaluateRefUpdate(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/feature"
+	protectedRefName := "refs/heads/main"
+
+	tmpDir := t.TempDir()
+	remoteRepo := createTestRepositoryWithPolicy(t, tmpDir)
+
+	remoteTip, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remoteRepo.RecordRSLEntryForReference(refName, false); err != nil {
+		t.Fatal(err)
+	}
+
+	localR, err := gitinterface.CloneAndFetchToMemory(testCtx, tmpDir, refName, []string{rsl.Ref, policy.PolicyRef})
+	if err != nil {
+		t.Fatal(err)
+	}
+	localRepo := &Repository{r: localR}
+
+	// Create unpushed local entry for the ref
+	if _, err := gitinterface.Commit(localRepo.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := localRepo.RecordRSLEntryForReference(refName, false); err != nil {
+		t.Fatal(err)
+	}
+	localRSLTip, err := localRepo.r.Reference(rsl.Ref, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localTip, err := localRepo.r.Reference(plumbing.ReferenceName(refName), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// New target builds on the remote's state of the ref, not the local state
+	newTarget, err := gitinterface.CommitWithParents(localRepo.r, gitinterface.EmptyTree(), []plumbing.Hash{remoteTip}, "refs/heads/scratch", "Another test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The simulated entries must not be reported to the entry hooks
+	hook := &testEntryHook{name: "test"}
+	if err := rsl.RegisterEntryHook(hook); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { rsl.UnregisterEntryHook(hook.name) })
+
+	t.Run("use local RSL tip", func(t *testing.T) {
+		evaluation, err := localRepo.EvaluateRefUpdate(testCtx, remoteName, refName, newTarget.String(), true, false)
+		assert.Nil(t, err)
+		assert.Equal(t, localRSLTip.Hash(), evaluation.BasisRSLTip)
+		assert.Equal(t, localTip.Hash(), evaluation.PreviousTarget)
+		assert.True(t, evaluation.Rollback)
+	})
+
+	t.Run("use last common state with remote", func(t *testing.T) {
+		remoteRSLTip, err := remoteRepo.r.Reference(rsl.Ref, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		evaluation, err := localRepo.EvaluateRefUpdate(testCtx, remoteName, refName, newTarget.String(), false, false)
+		assert.Nil(t, err)
+		assert.Equal(t, remoteRSLTip.Hash(), evaluation.BasisRSLTip)
+		assert.Equal(t, remoteTip, evaluation.PreviousTarget)
+		assert.False(t, evaluation.Rollback)
+	})
+
+	t.Run("unauthorized update to protected ref", func(t *testing.T) {
+		evaluation, err := localRepo.EvaluateRefUpdate(testCtx, remoteName, protectedRefName, newTarget.String(), true, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+		assert.Equal(t, plumbing.ZeroHash, evaluation.PreviousTarget)
+		assert.False(t, evaluation.Rollback)
+	})
+
+	// The local repository must not be modified by the evaluations
+	currentLocalRSLTip, err := localRepo.r.Reference(rsl.Ref, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, localRSLTip.Hash(), currentLocalRSLTip.Hash())
+	assert.Equal(t, 0, hook.calls)
+}
+
+func TestVerifyAgainstPinnedTip(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	entryIDs := []plumbing.Hash{}
+	for i := 0; i < 3; i++ {
+		if err := rsl.NewReferenceEntry("refs/heads/main", plumbing.NewHash(fmt.Sprintf("%d", i+1))).Commit(repo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entryIDs = append(entryIDs, latestEntry.GetID())
+	}
+
+	t.Run("pinned tip is local tip", func(t *testing.T) {
+		err := repo.VerifyAgainstPinnedTip(entryIDs[2])
+		assert.Nil(t, err)
+	})
+
+	t.Run("pinned tip is ancestor of local tip", func(t *testing.T) {
+		err := repo.VerifyAgainstPinnedTip(entryIDs[0])
+		assert.Nil(t, err)
+	})
+
+	t.Run("pinned tip is unknown", func(t *testing.T) {
+		err := repo.VerifyAgainstPinnedTip(plumbing.NewHash("abcdef1234567890"))
+		assert.ErrorIs(t, err, ErrRSLRollback)
+	})
+
+	t.Run("local RSL rolled back", func(t *testing.T) {
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, entryIDs[1])); err != nil {
+			t.Fatal(err)
+		}
+
+		err := repo.VerifyAgainstPinnedTip(entryIDs[2])
+		assert.ErrorIs(t, err, ErrRSLRollback)
+	})
+}
+
+func TestGetRevocationLog(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry("refs/heads/main", plumbing.NewHash("abcdef1234567890")), gpgKeyBytes)
+
+	// Not a skip annotation, must not be in the revocation log
+	common.CreateTestRSLAnnotationEntryCommit(t, repo.r, rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, false, "note"), gpgKeyBytes)
+
+	signedAnnotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo.r, rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, true, "revoke"), gpgKeyBytes)
+
+	if err := rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, true, "unsigned revoke").Commit(repo.r, false); err != nil {
+		t.Fatal(err)
+	}
+	unsignedAnnotation, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revocationLog, err := repo.GetRevocationLog(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(revocationLog))
+
+	assert.Equal(t, signedAnnotationID, revocationLog[0].Annotation.ID)
+	assert.Equal(t, 1, len(revocationLog[0].RevokedEntries))
+	assert.Equal(t, entryID, revocationLog[0].RevokedEntries[0].ID)
+	assert.Equal(t, "refs/heads/main", revocationLog[0].RevokedEntries[0].RefName)
+	assert.Equal(t, plumbing.NewHash("abcdef1234567890"), revocationLog[0].RevokedEntries[0].TargetID)
+	assert.True(t, revocationLog[0].SignerVerified)
+	assert.Equal(t, gpgKey.KeyID, revocationLog[0].SignerKeyID)
+
+	assert.Equal(t, unsignedAnnotation.GetID(), revocationLog[1].Annotation.ID)
+	assert.Equal(t, entryID, revocationLog[1].RevokedEntries[0].ID)
+	assert.False(t, revocationLog[1].SignerVerified)
+	assert.Empty(t, revocationLog[1].SignerKeyID)
+}
+
+func TestBuildAnnotationTimeline(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry("refs/heads/main", plumbing.NewHash("abcdef1234567890")), gpgKeyBytes)
+
+	noteID := common.CreateTestRSLAnnotationEntryCommit(t, repo.r, rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, false, "note"), gpgKeyBytes)
+	skipID := common.CreateTestRSLAnnotationEntryCommit(t, repo.r, rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, true, "revoke"), gpgKeyBytes)
+
+	if err := rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, false, "unsigned note").Commit(repo.r, false); err != nil {
+		t.Fatal(err)
+	}
+	unsignedNote, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeline, err := repo.BuildAnnotationTimeline(testCtx, entryID.String())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(timeline))
+
+	assert.Equal(t, noteID, timeline[0].Annotation.ID)
+	assert.Equal(t, rsl.AnnotationEffectInformational, timeline[0].Effect)
+	assert.True(t, timeline[0].SignerVerified)
+	assert.Equal(t, gpgKey.KeyID, timeline[0].SignerKeyID)
+
+	assert.Equal(t, skipID, timeline[1].Annotation.ID)
+	assert.Equal(t, rsl.AnnotationEffectSkipped, timeline[1].Effect)
+	assert.True(t, timeline[1].SignerVerified)
+	assert.Equal(t, gpgKey.KeyID, timeline[1].SignerKeyID)
+
+	assert.Equal(t, unsignedNote.GetID(), timeline[2].Annotation.ID)
+	assert.Equal(t, rsl.AnnotationEffectSkipRetained, timeline[2].Effect)
+	assert.False(t, timeline[2].SignerVerified)
+	assert.Empty(t, timeline[2].SignerKeyID)
+}
+
+func TestGetEntriesAuthorizedByKey(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizedEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry("refs/heads/main", plumbing.NewHash("abcdef1234567890")), gpgKeyBytes)
+
+	// Signed by the key but for a ref the key is not trusted for
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry("refs/heads/feature", plumbing.NewHash("abcdef1234567890")), gpgKeyBytes)
+
+	// Unsigned entry for a ref the key is trusted for
+	if err := rsl.NewReferenceEntry("refs/heads/main", plumbing.NewHash("1234567890abcdef")).Commit(repo.r, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstEntry, _, err := rsl.GetFirstEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := repo.GetEntriesAuthorizedByKey(testCtx, gpgKey.KeyID, firstEntry.GetID().String(), latestEntry.GetID().String())
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, authorizedEntryID, entries[0].ID)
+	}
+
+	entries, err = repo.GetEntriesAuthorizedByKey(testCtx, "unknown-key", firstEntry.GetID().String(), latestEntry.GetID().String())
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// RSLLogEntryType identifies the kind of RSL entry in an RSLLogEntry.
+type RSLLogEntryType string
+
+const (
+	RSLLogReferenceEntry   RSLLogEntryType = "reference"
+	RSLLogAnnotationEntry  RSLLogEntryType = "annotation"
+	RSLLogPropagationEntry RSLLogEntryType = "propagation"
+)
+
+// ListRSLEntriesOptions controls which RSL entries are listed by
+// ListRSLEntries and in what order.
+type ListRSLEntriesOptions struct {
+	// RefName limits the listing to entries for the ref and the annotations
+	// that refer to them. All entries are listed if it's unset.
+	RefName string
+
+	// Limit is the maximum number of entries to list. All entries are listed
+	// if it's zero.
+	Limit int
+
+	// Reverse lists entries from the first entry in the RSL to the latest
+	// entry. By default, the latest entry is listed first.
+	Reverse bool
+
+	// StartEntryID is the ID of the entry to start listing from. It is
+	// typically set to the NextEntryID of a previous listing to fetch the
+	// next page of entries.
+	StartEntryID string
+}
+
+// RSLLog is a page of RSL entries returned by ListRSLEntries.
+type RSLLog struct {
+	Entries []*RSLLogEntry `json:"entries"`
+
+	// NextEntryID is the ID of the entry that follows the last listed entry.
+	// It is empty if there are no more entries to list.
+	NextEntryID string `json:"nextEntryID,omitempty"`
+}
+
+// RSLLogEntry describes a single RSL entry for display. Fields that do not
+// apply to the entry's type are left empty.
+type RSLLogEntry struct {
+	ID     string          `json:"id"`
+	Number uint64          `json:"number,omitempty"`
+	Type   RSLLogEntryType `json:"type"`
+
+	// SignerKeyID contains the ID of the key that signed the entry. It is
+	// only set when the signature could be verified using the policy
+	// applicable at the entry.
+	SignerKeyID string `json:"signerKeyID,omitempty"`
+
+	// RefName and TargetID are set for reference and propagation entries.
+	RefName  string `json:"refName,omitempty"`
+	TargetID string `json:"targetID,omitempty"`
+
+	// Deleted, Actor, ActorURL, Skipped, and Annotations are set for
+	// reference entries. Annotations are in order of occurrence.
+	Deleted     bool                `json:"deleted,omitempty"`
+	Actor       string              `json:"actor,omitempty"`
+	ActorURL    string              `json:"actorURL,omitempty"`
+	Skipped     bool                `json:"skipped,omitempty"`
+	Annotations []*RSLLogAnnotation `json:"annotations,omitempty"`
+
+	// AnnotatedEntryIDs, Skip, Unskip, and Message are set for annotation
+	// entries.
+	AnnotatedEntryIDs []string `json:"annotatedEntryIDs,omitempty"`
+	Skip              bool     `json:"skip,omitempty"`
+	Unskip            bool     `json:"unskip,omitempty"`
+	Message           string   `json:"message,omitempty"`
+
+	// UpstreamRepository and UpstreamEntryID are set for propagation entries.
+	UpstreamRepository string `json:"upstreamRepository,omitempty"`
+	UpstreamEntryID    string `json:"upstreamEntryID,omitempty"`
+}
+
+// RSLLogAnnotation summarizes an annotation that refers to a reference entry.
+type RSLLogAnnotation struct {
+	ID      string `json:"id"`
+	Skip    bool   `json:"skip,omitempty"`
+	Unskip  bool   `json:"unskip,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ListRSLEntries returns a page of RSL entries for display, along with the
+// annotations and skip status of reference entries and the signer of each
+// entry. The NextEntryID of the returned log can be set as the StartEntryID
+// of the options to list the next page.
+func (r *Repository) ListRSLEntries(ctx context.Context, opts *ListRSLEntriesOptions) (*RSLLog, error) {
+	if opts == nil {
+		opts = &ListRSLEntriesOptions{}
+	}
+
+	slog.Debug("Loading annotations in RSL...")
+	annotationsMap, err := r.getAnnotationsByEntry()
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Identifying RSL entries to list...")
+	var entries []rsl.Entry
+	var nextEntry rsl.Entry
+	if opts.Reverse {
+		entries, nextEntry, err = r.getRSLEntriesPageFromFirst(opts)
+	} else {
+		entries, nextEntry, err = r.getRSLEntriesPageFromLatest(opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log := &RSLLog{Entries: make([]*RSLLogEntry, 0, len(entries))}
+	if nextEntry != nil {
+		log.NextEntryID = nextEntry.GetID().String()
+	}
+
+	policyStates := map[plumbing.Hash]*policy.State{}
+	skipResolver := policy.NewSkipResolver(r.r)
+
+	for _, entry := range entries {
+		logEntry := &RSLLogEntry{ID: entry.GetID().String()}
+
+		switch entry := entry.(type) {
+		case *rsl.ReferenceEntry:
+			logEntry.Type = RSLLogReferenceEntry
+			logEntry.Number = entry.Number
+			logEntry.RefName = entry.RefName
+			logEntry.TargetID = entry.TargetID.String()
+			logEntry.Deleted = entry.Deleted
+			logEntry.Actor = entry.Actor
+			logEntry.ActorURL = entry.ActorURL
+			logEntry.Skipped, err = skipResolver.IsSkipped(ctx, entry, annotationsMap[entry.ID])
+			if err != nil {
+				return nil, err
+			}
+			for _, annotation := range annotationsMap[entry.ID] {
+				logEntry.Annotations = append(logEntry.Annotations, &RSLLogAnnotation{
+					ID:      annotation.ID.String(),
+					Skip:    annotation.Skip,
+					Unskip:  annotation.Unskip,
+					Message: annotation.Message,
+				})
+			}
+		case *rsl.AnnotationEntry:
+			logEntry.Type = RSLLogAnnotationEntry
+			logEntry.Number = entry.Number
+			logEntry.Skip = entry.Skip
+			logEntry.Unskip = entry.Unskip
+			logEntry.Message = entry.Message
+			for _, entryID := range entry.RSLEntryIDs {
+				logEntry.AnnotatedEntryIDs = append(logEntry.AnnotatedEntryIDs, entryID.String())
+			}
+		case *rsl.PropagationEntry:
+			logEntry.Type = RSLLogPropagationEntry
+			logEntry.Number = entry.Number
+			logEntry.RefName = entry.RefName
+			logEntry.TargetID = entry.TargetID.String()
+			logEntry.UpstreamRepository = entry.UpstreamRepository
+			logEntry.UpstreamEntryID = entry.UpstreamEntryID.String()
+		}
+
+		signerKeyID, err := r.identifyEntrySignerUsingStates(ctx, entry, policyStates)
+		if err != nil {
+			return nil, err
+		}
+		logEntry.SignerKeyID = signerKeyID
+
+		log.Entries = append(log.Entries, logEntry)
+	}
+
+	return log, nil
+}
+
+// getRSLEntriesPageFromLatest returns the page of entries starting from the
+// latest entry or the specified start entry, walking towards the first entry.
+// The entry that follows the page is also returned if there is one.
+func (r *Repository) getRSLEntriesPageFromLatest(opts *ListRSLEntriesOptions) ([]rsl.Entry, rsl.Entry, error) {
+	iterator, err := rsl.NewEntryIteratorWithFilter(r.r, opts.RefName, rsl.AnyEntryType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.StartEntryID != "" {
+		if err := iterator.Seek(plumbing.NewHash(opts.StartEntryID)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	entries := []rsl.Entry{}
+	for opts.Limit <= 0 || len(entries) < opts.Limit {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil, nil
+			}
+			return nil, nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	nextEntry, err := iterator.Next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return entries, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return entries, nextEntry, nil
+}
+
+// getRSLEntriesPageFromFirst returns the page of entries starting from the
+// first entry or the specified start entry, walking towards the latest entry.
+// The entry that follows the page is also returned if there is one.
+func (r *Repository) getRSLEntriesPageFromFirst(opts *ListRSLEntriesOptions) ([]rsl.Entry, rsl.Entry, error) {
+	iterator, err := rsl.NewEntryIteratorWithFilter(r.r, opts.RefName, rsl.AnyEntryType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The RSL can only be walked from the latest entry, so all matching
+	// entries are loaded first
+	allEntries := []rsl.Entry{}
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, nil, err
+		}
+		allEntries = append(allEntries, entry)
+	}
+	slices.Reverse(allEntries)
+
+	start := 0
+	if opts.StartEntryID != "" {
+		startID := plumbing.NewHash(opts.StartEntryID)
+		start = slices.IndexFunc(allEntries, func(entry rsl.Entry) bool {
+			return entry.GetID() == startID
+		})
+		if start == -1 {
+			return nil, nil, rsl.ErrRSLEntryNotFound
+		}
+	}
+
+	end := len(allEntries)
+	if opts.Limit > 0 && start+opts.Limit < end {
+		end = start + opts.Limit
+	}
+
+	var nextEntry rsl.Entry
+	if end < len(allEntries) {
+		nextEntry = allEntries[end]
+	}
+
+	return allEntries[start:end], nextEntry, nil
+}
+
+// getAnnotationsByEntry returns the annotations in the RSL grouped by the
+// entries they refer to. Each entry's annotations are in order of occurrence.
+func (r *Repository) getAnnotationsByEntry() (map[plumbing.Hash][]*rsl.AnnotationEntry, error) {
+	iterator, err := rsl.NewEntryIteratorWithFilter(r.r, "", rsl.AnnotationEntryType)
+	if err != nil {
+		return nil, err
+	}
+
+	annotationsMap := map[plumbing.Hash][]*rsl.AnnotationEntry{}
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		annotation := entry.(*rsl.AnnotationEntry)
+		for _, entryID := range annotation.RSLEntryIDs {
+			annotationsMap[entryID] = append(annotationsMap[entryID], annotation)
+		}
+	}
+
+	// The RSL is walked from the latest entry
+	for _, annotations := range annotationsMap {
+		slices.Reverse(annotations)
+	}
+
+	return annotationsMap, nil
+}
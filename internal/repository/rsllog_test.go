@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListRSLEntries(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	entryIDs := []string{}
+	for i := 0; i < 3; i++ {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		if err := repo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, commitIDs[0], entry.TargetID)
+		entryIDs = append(entryIDs, entry.ID.String())
+	}
+
+	if err := repo.RecordRSLAnnotation([]string{entryIDs[2]}, true, "skip latest", false); err != nil {
+		t.Fatal(err)
+	}
+	annotation, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("all entries for ref", func(t *testing.T) {
+		log, err := repo.ListRSLEntries(testCtx, &ListRSLEntriesOptions{RefName: refName})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, log.NextEntryID)
+		assert.Len(t, log.Entries, 4)
+
+		assert.Equal(t, annotation.GetID().String(), log.Entries[0].ID)
+		assert.Equal(t, RSLLogAnnotationEntry, log.Entries[0].Type)
+		assert.Equal(t, []string{entryIDs[2]}, log.Entries[0].AnnotatedEntryIDs)
+		assert.True(t, log.Entries[0].Skip)
+		assert.Equal(t, "skip latest", log.Entries[0].Message)
+
+		assert.Equal(t, entryIDs[2], log.Entries[1].ID)
+		assert.Equal(t, RSLLogReferenceEntry, log.Entries[1].Type)
+		assert.Equal(t, refName, log.Entries[1].RefName)
+		assert.True(t, log.Entries[1].Skipped)
+		assert.Len(t, log.Entries[1].Annotations, 1)
+		assert.Equal(t, annotation.GetID().String(), log.Entries[1].Annotations[0].ID)
+		assert.True(t, log.Entries[1].Annotations[0].Skip)
+
+		assert.Equal(t, entryIDs[1], log.Entries[2].ID)
+		assert.False(t, log.Entries[2].Skipped)
+		assert.Empty(t, log.Entries[2].Annotations)
+
+		assert.Equal(t, entryIDs[0], log.Entries[3].ID)
+	})
+
+	t.Run("all entries", func(t *testing.T) {
+		log, err := repo.ListRSLEntries(testCtx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, log.NextEntryID)
+		// The policy entries precede the entries for the ref
+		assert.Greater(t, len(log.Entries), 4)
+		assert.Equal(t, annotation.GetID().String(), log.Entries[0].ID)
+		assert.Equal(t, uint64(1), log.Entries[len(log.Entries)-1].Number)
+	})
+
+	t.Run("paged entries for ref", func(t *testing.T) {
+		log, err := repo.ListRSLEntries(testCtx, &ListRSLEntriesOptions{RefName: refName, Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, log.Entries, 2)
+		assert.Equal(t, annotation.GetID().String(), log.Entries[0].ID)
+		assert.Equal(t, entryIDs[2], log.Entries[1].ID)
+		assert.Equal(t, entryIDs[1], log.NextEntryID)
+
+		log, err = repo.ListRSLEntries(testCtx, &ListRSLEntriesOptions{RefName: refName, Limit: 2, StartEntryID: log.NextEntryID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, log.Entries, 2)
+		assert.Equal(t, entryIDs[1], log.Entries[0].ID)
+		assert.Equal(t, entryIDs[0], log.Entries[1].ID)
+		assert.Empty(t, log.NextEntryID)
+	})
+
+	t.Run("reversed paged entries for ref", func(t *testing.T) {
+		log, err := repo.ListRSLEntries(testCtx, &ListRSLEntriesOptions{RefName: refName, Limit: 3, Reverse: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, log.Entries, 3)
+		assert.Equal(t, entryIDs[0], log.Entries[0].ID)
+		assert.Equal(t, entryIDs[1], log.Entries[1].ID)
+		assert.Equal(t, entryIDs[2], log.Entries[2].ID)
+		assert.Equal(t, annotation.GetID().String(), log.NextEntryID)
+
+		log, err = repo.ListRSLEntries(testCtx, &ListRSLEntriesOptions{RefName: refName, Limit: 3, Reverse: true, StartEntryID: log.NextEntryID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, log.Entries, 1)
+		assert.Equal(t, annotation.GetID().String(), log.Entries[0].ID)
+		assert.Empty(t, log.NextEntryID)
+	})
+
+	t.Run("unknown start entry", func(t *testing.T) {
+		_, err := repo.ListRSLEntries(testCtx, &ListRSLEntriesOptions{Reverse: true, StartEntryID: plumbing.ZeroHash.String()})
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+	})
+
+	t.Run("unauthorized unskip is ignored", func(t *testing.T) {
+		entryID := plumbing.NewHash(entryIDs[1])
+		common.CreateTestRSLAnnotationEntryCommit(t, repo.r, rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, true, "skip"), gpgKeyBytes)
+		common.CreateTestRSLAnnotationEntryCommit(t, repo.r, rsl.NewUnskipAnnotationEntry([]plumbing.Hash{entryID}, "unskip"), gpgUnauthorizedKeyBytes)
+
+		log, err := repo.ListRSLEntries(testCtx, &ListRSLEntriesOptions{RefName: refName})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The two new annotations are listed first
+		assert.Equal(t, entryIDs[1], log.Entries[4].ID)
+		assert.Len(t, log.Entries[4].Annotations, 2)
+		assert.True(t, log.Entries[4].Skipped)
+	})
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const rslSignerCheckMessage = "gittuf RSL entry signing key check"
+
+// SetAllowUnauthorizedRSLSigner configures whether RSL entries are recorded
+// when the signing key in the user's Git config is not trusted by the rules
+// protecting the reference. If allowed, a warning is logged instead of
+// refusing to record the entry.
+func (r *Repository) SetAllowUnauthorizedRSLSigner(allow bool) {
+	r.allowUnauthorizedRSLSigner = allow
+}
+
+// checkRSLEntrySigner checks that the signing key in the user's Git config is
+// trusted by the current policy to record RSL entries for each of the
+// specified references. This identifies entries that will fail verification
+// before they are recorded and pushed. As the key is only known by its
+// signatures, a throwaway commit is signed and checked, and it is not written
+// to the repository. No commit is signed if the repository has no policy or if
+// none of the references are protected.
+func (r *Repository) checkRSLEntrySigner(ctx context.Context, refNames ...string) error {
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) || errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	protectedRefNames := []string{}
+	for _, refName := range refNames {
+		verifiers, err := state.FindVerifiersForPath(fmt.Sprintf("git:%s", refName))
+		if err != nil {
+			return err
+		}
+		if len(verifiers) != 0 {
+			protectedRefNames = append(protectedRefNames, refName)
+		}
+	}
+	if len(protectedRefNames) == 0 {
+		return nil
+	}
+
+	slog.Debug("Checking signing key is trusted for RSL entries...")
+	checkRepo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return err
+	}
+	checkCommitID, err := gitinterface.CommitWithParent(checkRepo, gitinterface.EmptyTree(), plumbing.ZeroHash, rslSignerCheckMessage, true)
+	if err != nil {
+		return err
+	}
+	checkCommit, err := gitinterface.GetCommit(checkRepo, checkCommitID)
+	if err != nil {
+		return err
+	}
+
+	for _, refName := range protectedRefNames {
+		err := state.VerifyRSLEntrySigner(ctx, refName, checkCommit)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, policy.ErrUnauthorizedRSLEntrySigner) || !r.allowUnauthorizedRSLSigner {
+			return err
+		}
+
+		slog.Warn(fmt.Sprintf("Recording RSL entry that will fail verification: %s", err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRSLEntrySigner(t *testing.T) {
+	// Configure Git to sign using an SSH key
+	tmpDir := t.TempDir()
+	keyPath := filepath.Join(tmpDir, "ed25519")
+	if err := os.WriteFile(keyPath, artifacts.SSHED25519Private, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	gitConfigPath := filepath.Join(tmpDir, "gitconfig")
+	gitConfig := fmt.Sprintf("[user]\n\tname = Jane Doe\n\temail = jane.doe@example.com\n\tsigningkey = %s\n[gpg]\n\tformat = ssh\n", keyPath)
+	if err := os.WriteFile(gitConfigPath, []byte(gitConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", gitConfigPath)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshKey, err := tuf.LoadKeyFromBytes(artifacts.SSHED25519Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-release", []*tuf.Key{sshKey}, []string{"git:refs/heads/release"}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.Apply(testCtx, repo.r, false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, refName := range []string{"refs/heads/main", "refs/heads/release", "refs/heads/feature"} {
+		common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	}
+
+	t.Run("unauthorized signing key", func(t *testing.T) {
+		rslTip, err := repo.r.Reference(rsl.Ref, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.RecordRSLEntryForReference("refs/heads/main", true)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedRSLEntrySigner)
+
+		currentRSLTip, err := repo.r.Reference(rsl.Ref, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, rslTip.Hash(), currentRSLTip.Hash())
+
+		err = repo.RecordRSLEntriesForReferences([]string{"refs/heads/release", "refs/heads/main"}, true)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedRSLEntrySigner)
+	})
+
+	t.Run("authorized signing key", func(t *testing.T) {
+		err := repo.RecordRSLEntryForReference("refs/heads/release", true)
+		assert.Nil(t, err)
+
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, "refs/heads/release")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, plumbing.ZeroHash, entry.ID)
+	})
+
+	t.Run("unprotected ref", func(t *testing.T) {
+		err := repo.RecordRSLEntryForReference("refs/heads/feature", true)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unauthorized signing key allowed", func(t *testing.T) {
+		repo.SetAllowUnauthorizedRSLSigner(true)
+		defer repo.SetAllowUnauthorizedRSLSigner(false)
+
+		err := repo.RecordRSLEntryForReference("refs/heads/main", true)
+		assert.Nil(t, err)
+
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, "refs/heads/main")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref, err := repo.r.Reference("refs/heads/main", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, ref.Hash(), entry.TargetID)
+	})
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+)
+
+// SetAutoDeepen configures verification to fetch the full history of the RSL,
+// the gittuf refs, and the ref being verified from the specified remote when
+// it reaches the boundary of a shallow clone, after which verification is
+// retried. An empty remote name disables this, and verification fails with
+// gitinterface.ErrShallowBoundary instead.
+func (r *Repository) SetAutoDeepen(remoteName string) {
+	r.autoDeepenRemoteName = remoteName
+}
+
+// retryAfterDeepening runs verify and, if it needs history beyond the shallow
+// clone boundary and SetAutoDeepen is configured, deepens the clone and runs
+// verify once more.
+func (r *Repository) retryAfterDeepening(ctx context.Context, target string, verify func() error) error {
+	err := verify()
+	if !errors.Is(err, gitinterface.ErrShallowBoundary) || r.autoDeepenRemoteName == "" {
+		return err
+	}
+
+	refNames := []string{}
+	for _, refName := range []string{rsl.Ref, policy.PolicyRef, attestations.Ref, target} {
+		if _, tipErr := gitinterface.GetTip(r.r, refName); tipErr == nil {
+			refNames = append(refNames, refName)
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Reached shallow clone boundary, fetching full history from '%s'...", r.autoDeepenRemoteName))
+	if deepenErr := gitinterface.Deepen(ctx, r.r, r.autoDeepenRemoteName, refNames, 0); deepenErr != nil {
+		return errors.Join(err, deepenErr)
+	}
+
+	return verify()
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShallowClone(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	remoteDir := t.TempDir()
+	remote := createTestRepositoryWithPolicy(t, remoteDir)
+
+	if err := remote.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, remote.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, remote.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+	}
+
+	localDir := t.TempDir()
+	local, err := git.PlainInit(localDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := local.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{"file://" + remoteDir}}); err != nil {
+		t.Fatal(err)
+	}
+	refSpecs := []config.RefSpec{
+		config.RefSpec(refName + ":" + refName),
+		"refs/gittuf/*:refs/gittuf/*",
+	}
+	if err := gitinterface.FetchRefSpecWithOptions(testCtx, local, remoteName, refSpecs, &gitinterface.FetchOptions{Depth: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	isShallow, err := gitinterface.IsShallowClone(local)
+	assert.Nil(t, err)
+	assert.True(t, isShallow)
+
+	r := &Repository{r: local}
+
+	t.Run("RSL walk stops at shallow boundary", func(t *testing.T) {
+		iterator, err := rsl.NewEntryIterator(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry, err := iterator.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, latestEntry.GetID(), entry.GetID())
+
+		_, err = iterator.Next()
+		assert.ErrorIs(t, err, gitinterface.ErrShallowBoundary)
+		assert.False(t, errors.Is(err, io.EOF))
+	})
+
+	t.Run("verification fails at shallow boundary", func(t *testing.T) {
+		err := r.VerifyRef(testCtx, refName, false)
+		assert.ErrorIs(t, err, gitinterface.ErrShallowBoundary)
+	})
+
+	t.Run("verification deepens clone", func(t *testing.T) {
+		r.SetAutoDeepen(remoteName)
+
+		err := r.VerifyRef(testCtx, refName, false)
+		assert.Nil(t, err)
+
+		isShallow, err := gitinterface.IsShallowClone(r.r)
+		assert.Nil(t, err)
+		assert.False(t, isShallow)
+	})
+}
@@ -0,0 +1,338 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrCannotSkipEntry    = errors.New("only reference entries can be skipped")
+	ErrNotSkipAnnotation  = errors.New("RSL entry is not an annotation that skips entries")
+	ErrNoEntriesToAnalyze = errors.New("no RSL entries specified to analyze")
+)
+
+// SkipDependencyReason identifies why an RSL entry depends on a skipped entry.
+type SkipDependencyReason string
+
+const (
+	// SkipDependencyVerifiedRelative is used for the next unskipped entry
+	// for the same ref as a skipped entry. The changes recorded in the
+	// skipped entry are verified as part of this entry instead.
+	SkipDependencyVerifiedRelative SkipDependencyReason = "verified-relative-to-skipped-entry"
+
+	// SkipDependencyAnnotation is used for annotations that refer to a
+	// skipped entry.
+	SkipDependencyAnnotation SkipDependencyReason = "annotates-skipped-entry"
+
+	// SkipDependencyPolicy is used for entries that were verified using the
+	// policy recorded in a skipped entry, and that will be verified using
+	// an earlier policy instead.
+	SkipDependencyPolicy SkipDependencyReason = "verified-using-skipped-policy"
+
+	// SkipDependencyAttestations is used for entries that were verified
+	// using the attestations recorded in a skipped entry, and that will be
+	// verified using earlier attestations instead.
+	SkipDependencyAttestations SkipDependencyReason = "verified-using-skipped-attestations"
+)
+
+// SkipImpact describes the effect of skipping a set of RSL entries, so that an
+// operator can confirm the skip before it's recorded.
+type SkipImpact struct {
+	// SkippedEntryIDs contains the IDs of the entries that are newly skipped.
+	SkippedEntryIDs []string `json:"skippedEntryIDs"`
+
+	// AlreadySkippedEntryIDs contains the IDs of the requested entries that
+	// are already skipped by other annotations. Skipping them again has no
+	// effect.
+	AlreadySkippedEntryIDs []string `json:"alreadySkippedEntryIDs,omitempty"`
+
+	// RefRollbacks contains the refs whose latest unskipped state in the
+	// RSL changes, sorted by ref name.
+	RefRollbacks []*SkipImpactRefRollback `json:"refRollbacks,omitempty"`
+
+	// DependentEntries contains the entries that relied on a skipped entry,
+	// in order of occurrence in the RSL.
+	DependentEntries []*SkipImpactDependentEntry `json:"dependentEntries,omitempty"`
+
+	// PolicyAffected indicates that an entry for the policy or the
+	// attestations is skipped, which changes how subsequent entries are
+	// verified.
+	PolicyAffected bool `json:"policyAffected"`
+}
+
+// SkipImpactRefRollback records the change in a ref's latest unskipped state
+// in the RSL.
+type SkipImpactRefRollback struct {
+	RefName         string `json:"refName"`
+	CurrentEntryID  string `json:"currentEntryID"`
+	CurrentTargetID string `json:"currentTargetID"`
+
+	// RolledBackEntryID and RolledBackTargetID identify the ref's latest
+	// unskipped state once the entries are skipped. They are empty if no
+	// unskipped entries remain for the ref.
+	RolledBackEntryID  string `json:"rolledBackEntryID,omitempty"`
+	RolledBackTargetID string `json:"rolledBackTargetID,omitempty"`
+}
+
+// SkipImpactDependentEntry records an RSL entry that relied on a skipped
+// entry.
+type SkipImpactDependentEntry struct {
+	EntryID        string               `json:"entryID"`
+	RefName        string               `json:"refName,omitempty"`
+	SkippedEntryID string               `json:"skippedEntryID"`
+	Reason         SkipDependencyReason `json:"reason"`
+}
+
+// AnalyzeSkipImpact computes the impact of skipping the specified RSL entries,
+// without recording an annotation. The impact is computed relative to the
+// current skip status of the entries in the RSL.
+func (r *Repository) AnalyzeSkipImpact(rslEntryIDs []string) (*SkipImpact, error) {
+	entryIDs := make([]plumbing.Hash, 0, len(rslEntryIDs))
+	for _, id := range rslEntryIDs {
+		entryIDs = append(entryIDs, plumbing.NewHash(id))
+	}
+
+	return r.analyzeSkipImpact(entryIDs, plumbing.ZeroHash)
+}
+
+// AnalyzeSkipAnnotationImpact computes the impact of an existing skip
+// annotation, by comparing the RSL with and without the annotation.
+func (r *Repository) AnalyzeSkipAnnotationImpact(annotationID string) (*SkipImpact, error) {
+	entry, err := rsl.GetEntry(r.r, plumbing.NewHash(annotationID))
+	if err != nil {
+		return nil, err
+	}
+
+	annotation, isAnnotation := entry.(*rsl.AnnotationEntry)
+	if !isAnnotation || !annotation.Skip {
+		return nil, ErrNotSkipAnnotation
+	}
+
+	return r.analyzeSkipImpact(annotation.RSLEntryIDs, annotation.ID)
+}
+
+// analyzeSkipImpact computes the impact of skipping the specified entries. If
+// ignoredAnnotationID is set, the annotation is not considered when
+// determining the current skip status of entries.
+func (r *Repository) analyzeSkipImpact(entryIDs []plumbing.Hash, ignoredAnnotationID plumbing.Hash) (*SkipImpact, error) {
+	if len(entryIDs) == 0 {
+		return nil, ErrNoEntriesToAnalyze
+	}
+
+	slog.Debug("Loading RSL entries...")
+	iterator, err := rsl.NewEntryIterator(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []rsl.Entry{}
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	// Reverse entries so that they're in order of occurrence
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	entryIndices := map[plumbing.Hash]int{}
+	annotationsMap := map[plumbing.Hash][]*rsl.AnnotationEntry{}
+	for i, entry := range entries {
+		entryIndices[entry.GetID()] = i
+
+		annotation, isAnnotation := entry.(*rsl.AnnotationEntry)
+		if !isAnnotation || annotation.ID == ignoredAnnotationID {
+			continue
+		}
+		for _, entryID := range annotation.RSLEntryIDs {
+			annotationsMap[entryID] = append(annotationsMap[entryID], annotation)
+		}
+	}
+
+	// Conflicting annotations are resolved using the current policy, as in
+	// verification
+	skipResolver := policy.NewSkipResolver(r.r)
+	skippedEntries := map[plumbing.Hash]bool{}
+	for _, entry := range entries {
+		referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry || len(annotationsMap[referenceEntry.ID]) == 0 {
+			continue
+		}
+
+		skipped, err := skipResolver.IsSkipped(context.Background(), referenceEntry, annotationsMap[referenceEntry.ID])
+		if err != nil {
+			return nil, err
+		}
+		skippedEntries[referenceEntry.ID] = skipped
+	}
+
+	isSkipped := func(entry *rsl.ReferenceEntry) bool {
+		return skippedEntries[entry.ID]
+	}
+
+	impact := &SkipImpact{SkippedEntryIDs: []string{}}
+	toSkip := map[plumbing.Hash]bool{}
+	skippedIndices := []int{}
+	for _, entryID := range entryIDs {
+		index, has := entryIndices[entryID]
+		if !has {
+			return nil, fmt.Errorf("%w: '%s'", rsl.ErrRSLEntryNotFound, entryID.String())
+		}
+
+		entry, isReferenceEntry := entries[index].(*rsl.ReferenceEntry)
+		if !isReferenceEntry {
+			return nil, fmt.Errorf("%w: '%s'", ErrCannotSkipEntry, entryID.String())
+		}
+
+		if toSkip[entryID] {
+			continue
+		}
+		if isSkipped(entry) {
+			impact.AlreadySkippedEntryIDs = append(impact.AlreadySkippedEntryIDs, entryID.String())
+			continue
+		}
+
+		toSkip[entryID] = true
+		skippedIndices = append(skippedIndices, index)
+		impact.SkippedEntryIDs = append(impact.SkippedEntryIDs, entryID.String())
+
+		if entry.RefName == policy.PolicyRef || entry.RefName == attestations.Ref {
+			impact.PolicyAffected = true
+		}
+	}
+	sort.Ints(skippedIndices)
+
+	isSkippedAfter := func(entry *rsl.ReferenceEntry) bool {
+		return toSkip[entry.ID] || isSkipped(entry)
+	}
+
+	slog.Debug("Identifying refs that are rolled back...")
+	affectedRefs := map[string]bool{}
+	for _, index := range skippedIndices {
+		affectedRefs[entries[index].(*rsl.ReferenceEntry).RefName] = true
+	}
+	refNames := make([]string, 0, len(affectedRefs))
+	for refName := range affectedRefs {
+		refNames = append(refNames, refName)
+	}
+	sort.Strings(refNames)
+
+	for _, refName := range refNames {
+		var current, rolledBack *rsl.ReferenceEntry
+		for i := len(entries) - 1; i >= 0 && (current == nil || rolledBack == nil); i-- {
+			entry, isReferenceEntry := entries[i].(*rsl.ReferenceEntry)
+			if !isReferenceEntry || entry.RefName != refName {
+				continue
+			}
+
+			if current == nil && !isSkipped(entry) {
+				current = entry
+			}
+			if rolledBack == nil && !isSkippedAfter(entry) {
+				rolledBack = entry
+			}
+		}
+
+		if current == nil || current == rolledBack {
+			continue
+		}
+
+		rollback := &SkipImpactRefRollback{
+			RefName:         refName,
+			CurrentEntryID:  current.ID.String(),
+			CurrentTargetID: current.TargetID.String(),
+		}
+		if rolledBack != nil {
+			rollback.RolledBackEntryID = rolledBack.ID.String()
+			rollback.RolledBackTargetID = rolledBack.TargetID.String()
+		}
+		impact.RefRollbacks = append(impact.RefRollbacks, rollback)
+	}
+
+	slog.Debug("Identifying entries that depend on skipped entries...")
+	type dependency struct {
+		index     int
+		dependent *SkipImpactDependentEntry
+	}
+	dependencies := []*dependency{}
+	for _, skippedIndex := range skippedIndices {
+		skippedEntry := entries[skippedIndex].(*rsl.ReferenceEntry)
+
+		var stateReason SkipDependencyReason
+		switch skippedEntry.RefName {
+		case policy.PolicyRef:
+			stateReason = SkipDependencyPolicy
+		case attestations.Ref:
+			stateReason = SkipDependencyAttestations
+		}
+
+		foundNextEntry := false
+		for i := skippedIndex + 1; i < len(entries); i++ {
+			var (
+				refName string
+				reason  SkipDependencyReason
+			)
+
+			switch entry := entries[i].(type) {
+			case *rsl.ReferenceEntry:
+				if foundNextEntry || isSkippedAfter(entry) {
+					continue
+				}
+
+				refName = entry.RefName
+				switch {
+				case entry.RefName == skippedEntry.RefName:
+					reason = SkipDependencyVerifiedRelative
+					foundNextEntry = true
+				case stateReason != "":
+					reason = stateReason
+				default:
+					continue
+				}
+			case *rsl.AnnotationEntry:
+				if entry.ID == ignoredAnnotationID || !entry.RefersTo(skippedEntry.ID) {
+					continue
+				}
+				reason = SkipDependencyAnnotation
+			default:
+				continue
+			}
+
+			dependencies = append(dependencies, &dependency{
+				index: i,
+				dependent: &SkipImpactDependentEntry{
+					EntryID:        entries[i].GetID().String(),
+					RefName:        refName,
+					SkippedEntryID: skippedEntry.ID.String(),
+					Reason:         reason,
+				},
+			})
+		}
+	}
+
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		return dependencies[i].index < dependencies[j].index
+	})
+	for _, dependency := range dependencies {
+		impact.DependentEntries = append(impact.DependentEntries, dependency.dependent)
+	}
+
+	return impact, nil
+}
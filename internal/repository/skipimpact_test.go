@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeSkipImpact(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordEntry := func(refName string) *rsl.ReferenceEntry {
+		t.Helper()
+
+		if _, err := gitinterface.Commit(r.r, gitinterface.EmptyTree(), refName, "Test commit", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entry
+	}
+
+	mainEntry1 := recordEntry("refs/heads/main")
+	featureEntry := recordEntry("refs/heads/feature")
+	mainEntry2 := recordEntry("refs/heads/main")
+	mainEntry3 := recordEntry("refs/heads/main")
+
+	if err := r.RecordRSLAnnotation([]string{mainEntry2.ID.String()}, false, "note", false); err != nil {
+		t.Fatal(err)
+	}
+	noteAnnotation, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("skip latest entry for ref", func(t *testing.T) {
+		impact, err := r.AnalyzeSkipImpact([]string{mainEntry3.ID.String()})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{mainEntry3.ID.String()}, impact.SkippedEntryIDs)
+		assert.False(t, impact.PolicyAffected)
+		assert.Empty(t, impact.DependentEntries)
+		assert.Equal(t, []*SkipImpactRefRollback{{
+			RefName:            "refs/heads/main",
+			CurrentEntryID:     mainEntry3.ID.String(),
+			CurrentTargetID:    mainEntry3.TargetID.String(),
+			RolledBackEntryID:  mainEntry2.ID.String(),
+			RolledBackTargetID: mainEntry2.TargetID.String(),
+		}}, impact.RefRollbacks)
+	})
+
+	t.Run("skip earlier entry for ref", func(t *testing.T) {
+		impact, err := r.AnalyzeSkipImpact([]string{mainEntry2.ID.String()})
+		assert.Nil(t, err)
+		assert.Empty(t, impact.RefRollbacks)
+		assert.Equal(t, []*SkipImpactDependentEntry{
+			{EntryID: mainEntry3.ID.String(), RefName: "refs/heads/main", SkippedEntryID: mainEntry2.ID.String(), Reason: SkipDependencyVerifiedRelative},
+			{EntryID: noteAnnotation.GetID().String(), SkippedEntryID: mainEntry2.ID.String(), Reason: SkipDependencyAnnotation},
+		}, impact.DependentEntries)
+	})
+
+	t.Run("skip policy entry", func(t *testing.T) {
+		impact, err := r.AnalyzeSkipImpact([]string{policyEntry.ID.String()})
+		assert.Nil(t, err)
+		assert.True(t, impact.PolicyAffected)
+		assert.Len(t, impact.RefRollbacks, 1)
+		assert.Equal(t, policy.PolicyRef, impact.RefRollbacks[0].RefName)
+
+		dependentIDs := []string{}
+		for _, dependent := range impact.DependentEntries {
+			assert.Equal(t, SkipDependencyPolicy, dependent.Reason)
+			dependentIDs = append(dependentIDs, dependent.EntryID)
+		}
+		assert.Equal(t, []string{mainEntry1.ID.String(), featureEntry.ID.String(), mainEntry2.ID.String(), mainEntry3.ID.String()}, dependentIDs)
+	})
+
+	t.Run("existing skip annotation", func(t *testing.T) {
+		if err := r.RecordRSLAnnotation([]string{mainEntry3.ID.String()}, true, "revoke", false); err != nil {
+			t.Fatal(err)
+		}
+		skipAnnotation, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The entry is already skipped
+		impact, err := r.AnalyzeSkipImpact([]string{mainEntry3.ID.String()})
+		assert.Nil(t, err)
+		assert.Empty(t, impact.SkippedEntryIDs)
+		assert.Equal(t, []string{mainEntry3.ID.String()}, impact.AlreadySkippedEntryIDs)
+		assert.Empty(t, impact.RefRollbacks)
+
+		impact, err = r.AnalyzeSkipAnnotationImpact(skipAnnotation.GetID().String())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{mainEntry3.ID.String()}, impact.SkippedEntryIDs)
+		assert.Len(t, impact.RefRollbacks, 1)
+
+		_, err = r.AnalyzeSkipAnnotationImpact(noteAnnotation.GetID().String())
+		assert.ErrorIs(t, err, ErrNotSkipAnnotation)
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		_, err := r.AnalyzeSkipImpact([]string{noteAnnotation.GetID().String()})
+		assert.ErrorIs(t, err, ErrCannotSkipEntry)
+
+		_, err = r.AnalyzeSkipImpact([]string{plumbing.NewHash("abcdef1234567890").String()})
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+
+		_, err = r.AnalyzeSkipImpact(nil)
+		assert.ErrorIs(t, err, ErrNoEntriesToAnalyze)
+	})
+}
@@ -0,0 +1,391 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrSubmoduleUpdateNotVerified = errors.New("submodule pointer update does not correspond to a verified RSL entry in the submodule's repository")
+
+// SetSubmodulePolicy configures how updates to the pointer of the submodule at
+// the specified path are verified. location identifies the submodule's
+// repository, and verification is one of tuf.SubmoduleVerificationPropagation
+// and tuf.SubmoduleVerificationRemote. If refName is set, remote checks only
+// consider RSL entries for that ref in the submodule's repository.
+func (r *Repository) SetSubmodulePolicy(ctx context.Context, signer sslibdsse.SignerVerifier, submodulePath, location, refName, verification string, signCommit bool) error {
+	submodulePolicy := &tuf.SubmodulePolicy{
+		Location:     location,
+		RefName:      refName,
+		Verification: verification,
+	}
+
+	return r.updateSubmodulePolicies(ctx, signer, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.SetSubmodulePolicy(targetsMetadata, submodulePath, submodulePolicy)
+	}, fmt.Sprintf("Set policy for submodule '%s'", submodulePath), signCommit)
+}
+
+// RemoveSubmodulePolicy removes the policy for the submodule at the specified
+// path. Updates to the submodule's pointer are no longer verified against the
+// submodule's repository.
+func (r *Repository) RemoveSubmodulePolicy(ctx context.Context, signer sslibdsse.SignerVerifier, submodulePath string, signCommit bool) error {
+	return r.updateSubmodulePolicies(ctx, signer, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.RemoveSubmodulePolicy(targetsMetadata, submodulePath)
+	}, fmt.Sprintf("Remove policy for submodule '%s'", submodulePath), signCommit)
+}
+
+func (r *Repository) updateSubmodulePolicies(ctx context.Context, signer sslibdsse.SignerVerifier, update func(*tuf.TargetsMetadata) (*tuf.TargetsMetadata, error), commitMessage string, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(policy.TargetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Updating submodule policies in rule file...")
+	targetsMetadata, err = update(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	state.TargetsEnvelope = env
+
+	slog.Debug("Committing policy...")
+	return state.Commit(r.r, commitMessage, signCommit)
+}
+
+// VerifySubmodules checks that every update to the pointer of a submodule
+// configured in the policy, as recorded in the unskipped RSL entries for the
+// target ref, corresponds to a verified RSL entry in the submodule's own
+// repository. Depending on the submodule's policy, this is established either
+// using a propagation entry in this repository's RSL that was recorded no
+// later than the update, or by fetching the submodule's repository and
+// verifying the entry that recorded the new pointer. Submodules are checked
+// against the policy applicable at each entry.
+func (r *Repository) VerifySubmodules(ctx context.Context, target string) error {
+	slog.Debug("Identifying absolute reference path...")
+	absTarget, err := gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading RSL entries...")
+	iterator, err := rsl.NewEntryIterator(r.r)
+	if err != nil {
+		return err
+	}
+
+	entries := []rsl.Entry{}
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	// Reverse entries so that they're in order of occurrence
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	annotationsMap := map[plumbing.Hash][]*rsl.AnnotationEntry{}
+	for _, entry := range entries {
+		if annotation, isAnnotation := entry.(*rsl.AnnotationEntry); isAnnotation {
+			for _, entryID := range annotation.RSLEntryIDs {
+				annotationsMap[entryID] = append(annotationsMap[entryID], annotation)
+			}
+		}
+	}
+
+	verifier := &submoduleVerifier{
+		repository:   r,
+		repositories: map[string]*Repository{},
+		verified:     map[string]error{},
+	}
+
+	skipResolver := policy.NewSkipResolver(r.r)
+	previousTargetID := plumbing.ZeroHash
+	for i, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry || referenceEntry.RefName != absTarget {
+			continue
+		}
+		skipped, err := skipResolver.IsSkipped(ctx, referenceEntry, annotationsMap[referenceEntry.ID])
+		if err != nil {
+			return err
+		}
+		if skipped {
+			continue
+		}
+
+		if err := r.verifySubmoduleUpdates(ctx, verifier, entries[:i], referenceEntry, previousTargetID); err != nil {
+			return err
+		}
+
+		previousTargetID = referenceEntry.TargetID
+	}
+
+	slog.Debug("Verification of submodules successful!")
+	return nil
+}
+
+// verifySubmoduleUpdates verifies the submodule pointers that changed in the
+// specified entry relative to the ref's previous target, using the policy
+// applicable at the entry. priorEntries contains the RSL entries that precede
+// the entry.
+func (r *Repository) verifySubmoduleUpdates(ctx context.Context, verifier *submoduleVerifier, priorEntries []rsl.Entry, entry *rsl.ReferenceEntry, previousTargetID plumbing.Hash) error {
+	if entry.TargetID.IsZero() {
+		return nil
+	}
+
+	state, err := policy.LoadStateAtEntry(ctx, r.r, entry.ID)
+	if err != nil {
+		return err
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		return err
+	}
+	if len(targetsMetadata.Submodules) == 0 {
+		return nil
+	}
+
+	pointers, err := getSubmodulePointers(r.r, entry.TargetID, targetsMetadata.Submodules)
+	if err != nil {
+		return err
+	}
+	previousPointers := map[string]plumbing.Hash{}
+	if !previousTargetID.IsZero() {
+		previousPointers, err = getSubmodulePointers(r.r, previousTargetID, targetsMetadata.Submodules)
+		if err != nil {
+			return err
+		}
+	}
+
+	submodulePaths := make([]string, 0, len(pointers))
+	for submodulePath := range pointers {
+		submodulePaths = append(submodulePaths, submodulePath)
+	}
+	sort.Strings(submodulePaths)
+
+	for _, submodulePath := range submodulePaths {
+		pointer := pointers[submodulePath]
+		if pointer == previousPointers[submodulePath] {
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying update of submodule '%s' to '%s' in entry '%s'...", submodulePath, pointer.String(), entry.ID.String()))
+		submodulePolicy := targetsMetadata.Submodules[submodulePath]
+
+		var err error
+		switch submodulePolicy.Verification {
+		case tuf.SubmoduleVerificationPropagation:
+			err = verifySubmoduleUpdateUsingPropagation(priorEntries, submodulePolicy, pointer)
+		case tuf.SubmoduleVerificationRemote:
+			err = verifier.verifyUsingRemote(ctx, submodulePolicy, pointer)
+		default:
+			err = fmt.Errorf("%w: unknown verification mode '%s'", policy.ErrInvalidSubmodulePolicy, submodulePolicy.Verification)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: submodule '%s' updated to '%s' in entry '%s': %w", ErrSubmoduleUpdateNotVerified, submodulePath, pointer.String(), entry.ID.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// submoduleVerifier caches the submodule repositories fetched for remote
+// checks and the results of verifying their entries.
+type submoduleVerifier struct {
+	repository   *Repository
+	repositories map[string]*Repository
+	verified     map[string]error
+}
+
+func (s *submoduleVerifier) verifyUsingRemote(ctx context.Context, submodulePolicy *tuf.SubmodulePolicy, pointer plumbing.Hash) error {
+	cacheKey := fmt.Sprintf("%s %s %s", submodulePolicy.Location, submodulePolicy.RefName, pointer.String())
+	if err, has := s.verified[cacheKey]; has {
+		return err
+	}
+
+	err := s.verifyUsingRemoteUncached(ctx, submodulePolicy, pointer)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	s.verified[cacheKey] = err
+	return err
+}
+
+func (s *submoduleVerifier) verifyUsingRemoteUncached(ctx context.Context, submodulePolicy *tuf.SubmodulePolicy, pointer plumbing.Hash) error {
+	submoduleRepository, has := s.repositories[submodulePolicy.Location]
+	if !has {
+		slog.Debug(fmt.Sprintf("Fetching submodule repository from '%s'...", submodulePolicy.Location))
+		repo, err := fetchToMemory(ctx, submodulePolicy.Location)
+		if err != nil {
+			return err
+		}
+		submoduleRepository = &Repository{r: repo, expirationGracePeriod: s.repository.expirationGracePeriod}
+		s.repositories[submodulePolicy.Location] = submoduleRepository
+	}
+
+	iterator, err := rsl.NewEntryIteratorWithFilter(submoduleRepository.r, submodulePolicy.RefName, rsl.AnyEntryType)
+	if err != nil {
+		return err
+	}
+
+	// Conflicting annotations are resolved using the submodule repository's
+	// policy
+	skipResolver := policy.NewSkipResolver(submoduleRepository.r)
+
+	// Entries are returned latest first, so annotations are seen before the
+	// entries they refer to
+	annotationsMap := map[plumbing.Hash][]*rsl.AnnotationEntry{}
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+
+		switch entry := entry.(type) {
+		case *rsl.AnnotationEntry:
+			for _, entryID := range entry.RSLEntryIDs {
+				annotationsMap[entryID] = append(annotationsMap[entryID], entry)
+			}
+		case *rsl.ReferenceEntry:
+			if entry.TargetID != pointer || rsl.IsRSLRef(entry.RefName) {
+				continue
+			}
+
+			// The annotations were seen latest first
+			annotations := slices.Clone(annotationsMap[entry.ID])
+			slices.Reverse(annotations)
+			skipped, err := skipResolver.IsSkipped(ctx, entry, annotations)
+			if err != nil {
+				return err
+			}
+			if skipped {
+				continue
+			}
+
+			return submoduleRepository.VerifyRefAtEntry(ctx, entry.RefName, entry.ID.String())
+		}
+	}
+
+	return fmt.Errorf("no unskipped RSL entry records '%s' in '%s'", pointer.String(), submodulePolicy.Location)
+}
+
+// verifySubmoduleUpdateUsingPropagation checks that one of the specified RSL
+// entries is a propagation entry from the submodule's repository for the
+// specified pointer.
+func verifySubmoduleUpdateUsingPropagation(entries []rsl.Entry, submodulePolicy *tuf.SubmodulePolicy, pointer plumbing.Hash) error {
+	for _, entry := range entries {
+		propagationEntry, isPropagationEntry := entry.(*rsl.PropagationEntry)
+		if !isPropagationEntry {
+			continue
+		}
+
+		if propagationEntry.UpstreamRepository == submodulePolicy.Location && propagationEntry.TargetID == pointer {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no propagation entry from '%s' records '%s'", submodulePolicy.Location, pointer.String())
+}
+
+// getSubmodulePointers returns the commits recorded for the specified
+// submodules in the tree of the specified commit or tag. Submodules that are
+// not present are omitted.
+func getSubmodulePointers(repo *git.Repository, targetID plumbing.Hash, submodules map[string]*tuf.SubmodulePolicy) (map[string]plumbing.Hash, error) {
+	commit, err := gitinterface.GetCommit(repo, targetID)
+	if err != nil {
+		if !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil, err
+		}
+
+		tag, tagErr := gitinterface.GetTag(repo, targetID)
+		if tagErr != nil {
+			return nil, err
+		}
+		commit, err = gitinterface.GetCommit(repo, tag.Target)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tree, err := gitinterface.GetTree(repo, commit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	pointers := map[string]plumbing.Hash{}
+	for submodulePath := range submodules {
+		entry, err := tree.FindEntry(submodulePath)
+		if err != nil {
+			if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		if entry.Mode == filemode.Submodule {
+			pointers[submodulePath] = entry.Hash
+		}
+	}
+
+	return pointers, nil
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSubmodulePolicy(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetSubmodulePolicy(testCtx, targetsSigner, "lib", "https://example.com/lib.git", "", tuf.SubmoduleVerificationPropagation, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]*tuf.SubmodulePolicy{
+		"lib": {Location: "https://example.com/lib.git", Verification: tuf.SubmoduleVerificationPropagation},
+	}, targetsMetadata.Submodules)
+
+	err = r.RemoveSubmodulePolicy(testCtx, targetsSigner, "lib", false)
+	assert.Nil(t, err)
+
+	err = r.RemoveSubmodulePolicy(testCtx, targetsSigner, "lib", false)
+	assert.ErrorIs(t, err, policy.ErrSubmodulePolicyNotFound)
+}
+
+func TestVerifySubmodules(t *testing.T) {
+	refName := "refs/heads/main"
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// recordPointer records an update of the submodule at lib to the
+	// specified commit in the superproject's RSL
+	recordPointer := func(t *testing.T, r *Repository, pointer plumbing.Hash) *rsl.ReferenceEntry {
+		t.Helper()
+
+		treeID, err := gitinterface.WriteTree(r.r, []object.TreeEntry{{Name: "lib", Mode: filemode.Submodule, Hash: pointer}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gitinterface.Commit(r.r, treeID, refName, "Update submodule", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entry
+	}
+
+	t.Run("remote", func(t *testing.T) {
+		submoduleLocation := t.TempDir()
+		submodule := createTestRepositoryWithPolicy(t, submoduleLocation)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, submodule.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, submodule.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		r := createTestRepositoryWithPolicy(t, "")
+		if err := r.SetSubmodulePolicy(testCtx, targetsSigner, "lib", submoduleLocation, refName, tuf.SubmoduleVerificationRemote, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.ApplyPolicy(testCtx, false); err != nil {
+			t.Fatal(err)
+		}
+
+		recordPointer(t, r, commitIDs[0])
+		err := r.VerifySubmodules(testCtx, refName)
+		assert.Nil(t, err)
+
+		// The submodule's RSL does not record the new pointer
+		unknownEntry := recordPointer(t, r, plumbing.NewHash("abcdef1234567890"))
+		err = r.VerifySubmodules(testCtx, refName)
+		assert.ErrorIs(t, err, ErrSubmoduleUpdateNotVerified)
+
+		// The update is skipped, so the pointer in the next entry is
+		// compared against the last unskipped entry
+		if err := r.RecordRSLAnnotation([]string{unknownEntry.ID.String()}, true, "revoke", false); err != nil {
+			t.Fatal(err)
+		}
+		recordPointer(t, r, commitIDs[0])
+		err = r.VerifySubmodules(testCtx, refName)
+		assert.Nil(t, err)
+	})
+
+	t.Run("propagation", func(t *testing.T) {
+		submoduleLocation := "https://example.com/lib.git"
+		firstPointer := plumbing.NewHash("abcdef1234567890")
+		secondPointer := plumbing.NewHash("1234567890abcdef")
+
+		r := createTestRepositoryWithPolicy(t, "")
+		if err := r.SetSubmodulePolicy(testCtx, targetsSigner, "lib", submoduleLocation, "", tuf.SubmoduleVerificationPropagation, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.ApplyPolicy(testCtx, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := rsl.NewPropagationEntry("refs/heads/lib", firstPointer, submoduleLocation, plumbing.NewHash("abcdef")).Commit(r.r, false); err != nil {
+			t.Fatal(err)
+		}
+		recordPointer(t, r, firstPointer)
+		err := r.VerifySubmodules(testCtx, refName)
+		assert.Nil(t, err)
+
+		// The propagation entry must precede the update
+		recordPointer(t, r, secondPointer)
+		if err := rsl.NewPropagationEntry("refs/heads/lib", secondPointer, submoduleLocation, plumbing.NewHash("abcdef")).Commit(r.r, false); err != nil {
+			t.Fatal(err)
+		}
+		err = r.VerifySubmodules(testCtx, refName)
+		assert.ErrorIs(t, err, ErrSubmoduleUpdateNotVerified)
+	})
+}
@@ -11,21 +11,69 @@ import (
 	"strings"
 
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
+// cloneRefs contains the refs fetched in addition to the standard refs when
+// cloning.
+var cloneRefs = []string{"refs/gittuf/*"}
+
 var (
 	ErrCloningRepository = errors.New("unable to clone repository")
 	ErrDirExists         = errors.New("directory exists")
 )
 
+// CloneOptions configures CloneWithOptions.
+type CloneOptions struct {
+	// RootTrustAnchors contains the root public keys, obtained out-of-band,
+	// that are expected to have signed the repository's initial root of
+	// trust. See Repository.SetRootTrustAnchors.
+	RootTrustAnchors []*tuf.Key
+
+	// RootTrustAnchorsThreshold is the number of RootTrustAnchors that must
+	// have signed the initial root of trust. If 0, all of them must have.
+	RootTrustAnchorsThreshold int
+
+	// RootPinFile is the path of the file used to pin the keys of the
+	// repository's initial root of trust. See Repository.SetRootPinFile.
+	RootPinFile string
+
+	// InMemory clones the repository into go-git's in-memory storage instead
+	// of a directory, so that it can be verified without writing to disk or
+	// invoking the Git binary. The directory passed to CloneWithOptions is
+	// ignored. See NewInMemoryRepository.
+	InMemory bool
+}
+
 // Clone wraps a typical git clone invocation, fetching gittuf refs in addition
-// to the standard refs. It performs a verification of the RSL against the
-// specified HEAD after cloning the repository.
-// TODO: resolve how root keys are trusted / bootstrapped.
+// to the standard refs. After checking that the fetched policy reference and
+// RSL are consistent, it performs a full verification of the RSL against the
+// specified HEAD. The working tree is only populated once verification
+// succeeds. If verification fails, the cloned repository is removed. The
+// initial root of trust is trusted on first use, see CloneWithOptions to
+// authenticate it against out-of-band root keys.
 func Clone(ctx context.Context, remoteURL, dir, initialBranch string) (*Repository, error) {
+	return CloneWithOptions(ctx, remoteURL, dir, initialBranch, nil)
+}
+
+// CloneWithOptions clones the repository like Clone, using the specified
+// options to authenticate the initial root of trust. The options are retained
+// by the returned Repository for subsequent verifications.
+func CloneWithOptions(ctx context.Context, remoteURL, dir, initialBranch string, opts *CloneOptions) (*Repository, error) {
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+
 	slog.Debug(fmt.Sprintf("Cloning from '%s'...", remoteURL))
 
+	if opts.InMemory {
+		return cloneToMemory(ctx, remoteURL, initialBranch, opts)
+	}
+
 	if dir == "" {
 		// FIXME: my understanding is backslashes are not used in URLs but I haven't dived into the RFCs to check yet
 		modifiedURL := strings.ReplaceAll(remoteURL, "\\", "/")
@@ -47,23 +95,100 @@ func Clone(ctx context.Context, remoteURL, dir, initialBranch string) (*Reposito
 		return nil, errors.Join(ErrCloningRepository, err)
 	}
 
-	refs := []string{"refs/gittuf/*"}
-
 	slog.Debug("Cloning repository...")
-	r, err := gitinterface.CloneAndFetch(ctx, remoteURL, dir, initialBranch, refs)
+	r, err := gitinterface.CloneAndFetchWithoutCheckout(ctx, remoteURL, dir, initialBranch, cloneRefs)
+	if err != nil {
+		return nil, removeClonedRepository(dir, errors.Join(ErrCloningRepository, err))
+	}
+
+	repository, err := verifyClonedRepository(ctx, r, opts)
+	if err != nil {
+		return nil, removeClonedRepository(dir, err)
+	}
+
+	slog.Debug("Populating working tree...")
+	if err := gitinterface.CheckoutHEAD(r); err != nil {
+		return nil, errors.Join(ErrCloningRepository, err)
+	}
+
+	return repository, nil
+}
+
+// cloneToMemory clones the repository into memory and verifies it like
+// CloneWithOptions.
+func cloneToMemory(ctx context.Context, remoteURL, initialBranch string, opts *CloneOptions) (*Repository, error) {
+	slog.Debug("Cloning repository into memory...")
+	r, err := gitinterface.CloneAndFetchToMemoryWithoutCheckout(ctx, remoteURL, initialBranch, cloneRefs)
+	if err != nil {
+		return nil, errors.Join(ErrCloningRepository, err)
+	}
+
+	repository, err := verifyClonedRepository(ctx, r, opts)
 	if err != nil {
-		if e := os.RemoveAll(dir); e != nil {
-			return nil, errors.Join(ErrCloningRepository, err, e)
-		}
+		return nil, err
+	}
+
+	slog.Debug("Populating working tree...")
+	if err := gitinterface.CheckoutHEAD(r); err != nil {
 		return nil, errors.Join(ErrCloningRepository, err)
 	}
+
+	return repository, nil
+}
+
+// verifyClonedRepository verifies the gittuf refs and HEAD of a freshly cloned
+// repository.
+func verifyClonedRepository(ctx context.Context, r *git.Repository, opts *CloneOptions) (*Repository, error) {
 	head, err := r.Reference(plumbing.HEAD, false)
 	if err != nil {
 		return nil, errors.Join(ErrCloningRepository, err)
 	}
 
 	repository := &Repository{r: r}
+	repository.SetRootTrustAnchors(opts.RootTrustAnchors, opts.RootTrustAnchorsThreshold)
+	repository.SetRootPinFile(opts.RootPinFile)
+
+	if err := repository.VerifyGittufRefsConsistency(); err != nil {
+		return nil, err
+	}
 
 	slog.Debug("Verifying HEAD...")
-	return repository, repository.VerifyRef(ctx, head.Target().String(), false)
+	if err := repository.VerifyRef(ctx, head.Target().String(), false); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+// removeClonedRepository removes the repository cloned into dir, returning the
+// cause of the removal along with any error encountered while removing it.
+func removeClonedRepository(dir string, cause error) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.Join(cause, err)
+	}
+
+	return cause
+}
+
+// fetchToMemory fetches all refs from the repository at the specified location
+// into memory. Unlike cloning, no progress is written and the refs are stored
+// under their original names rather than as remote-tracking refs.
+func fetchToMemory(ctx context.Context, location string) (*git.Repository, error) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: gitinterface.DefaultRemoteName,
+		URLs: []string{location},
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := gitinterface.FetchRefSpec(ctx, repo, gitinterface.DefaultRemoteName, []config.RefSpec{"+refs/*:refs/*"}); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
 }
@@ -179,6 +179,36 @@ func TestClone(t *testing.T) {
 		assert.Equal(t, remotePolicyRef.Hash(), localPolicyRef.Hash())
 	})
 
+	t.Run("successful clone into memory", func(t *testing.T) {
+		localTmpDir := t.TempDir()
+
+		if err := os.Chdir(localTmpDir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		dirName := "myRepo"
+		repo, err := CloneWithOptions(context.Background(), remoteTmpDir, dirName, "", &CloneOptions{InMemory: true})
+		assert.Nil(t, err)
+		head, err := repo.r.Head()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, commitID, head.Hash())
+
+		_, err = os.Stat(dirName)
+		assert.True(t, os.IsNotExist(err))
+
+		localRSLRef, err := repo.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, remoteRSLRef.Hash(), localRSLRef.Hash())
+
+		err = repo.VerifyRef(context.Background(), refName, false)
+		assert.Nil(t, err)
+	})
+
 	t.Run("unsuccessful clone when unspecified dir already exists", func(t *testing.T) {
 		localTmpDir := t.TempDir()
 
@@ -237,4 +267,68 @@ func TestClone(t *testing.T) {
 		}
 		assert.Equal(t, remotePolicyRef.Hash(), localPolicyRef.Hash())
 	})
+
+	t.Run("unsuccessful clone when verification fails", func(t *testing.T) {
+		localTmpDir := t.TempDir()
+
+		if err := os.Chdir(localTmpDir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		// The ref is never recorded in the RSL
+		unrecordedRefName := "refs/heads/unrecorded"
+		if err := remoteRepo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(unrecordedRefName), commitID)); err != nil {
+			t.Fatal(err)
+		}
+		defer remoteRepo.r.Storer.RemoveReference(plumbing.ReferenceName(unrecordedRefName)) //nolint:errcheck
+
+		dirName := "myRepo"
+		repo, err := Clone(context.Background(), remoteTmpDir, dirName, unrecordedRefName)
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+		assert.Nil(t, repo)
+
+		_, err = os.Stat(dirName)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("successful clone with trusted root keys", func(t *testing.T) {
+		localTmpDir := t.TempDir()
+
+		if err := os.Chdir(localTmpDir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		rootPubKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dirName := "myRepo"
+		repo, err := CloneWithOptions(context.Background(), remoteTmpDir, dirName, "", &CloneOptions{RootTrustAnchors: []*tuf.Key{rootPubKey}})
+		assert.Nil(t, err)
+		head, err := repo.r.Head()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, commitID, head.Hash())
+	})
+
+	t.Run("unsuccessful clone with untrusted root keys", func(t *testing.T) {
+		localTmpDir := t.TempDir()
+
+		if err := os.Chdir(localTmpDir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		dirName := "myRepo"
+		repo, err := CloneWithOptions(context.Background(), remoteTmpDir, dirName, "", &CloneOptions{RootTrustAnchors: []*tuf.Key{targetsPubKey}})
+		assert.ErrorIs(t, err, policy.ErrUntrustedInitialRoot)
+		assert.Nil(t, repo)
+
+		_, err = os.Stat(dirName)
+		assert.True(t, os.IsNotExist(err))
+	})
 }
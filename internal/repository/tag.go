@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// CreateTag creates an annotated tag with the specified name and message
+// pointing to target, which may be a reference or a Git object ID. The ID of
+// the new tag object is returned. The tag can subsequently be recorded in the
+// RSL using RecordRSLEntryForTag.
+func (r *Repository) CreateTag(tagName, target, message string, signTag bool) (string, error) {
+	slog.Debug(fmt.Sprintf("Resolving target '%s'...", target))
+	targetID, err := r.resolveAttestationTarget(target)
+	if err != nil {
+		return "", err
+	}
+
+	slog.Debug(fmt.Sprintf("Creating tag '%s'...", tagName))
+	tagID, err := gitinterface.Tag(r.r, targetID, tagName, message, signTag)
+	if err != nil {
+		return "", err
+	}
+
+	return tagID.String(), nil
+}
+
+// GetTag returns the annotated tag object for the specified tag. If the tag is
+// a lightweight tag, gitinterface.ErrNotAnnotatedTag is returned.
+func (r *Repository) GetTag(tagName string) (*object.Tag, error) {
+	return gitinterface.GetTagForReference(r.r, tagName)
+}
+
+// RecordRSLEntryForTag records the specified tag in the RSL with its tag object
+// as the entry's target. Only annotated tags can be recorded using this
+// function, as lightweight tags cannot be signed and verified as releases.
+func (r *Repository) RecordRSLEntryForTag(tagName string, signCommit bool) error {
+	slog.Debug(fmt.Sprintf("Loading tag object for '%s'...", tagName))
+	tag, err := gitinterface.GetTagForReference(r.r, tagName)
+	if err != nil {
+		return err
+	}
+
+	absRefName, err := gitinterface.AbsoluteReference(r.r, tagName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Checking for existing entry for tag with same target...")
+	isDuplicate, err := r.isDuplicateEntry(absRefName, tag.Hash)
+	if err != nil {
+		return err
+	}
+	if isDuplicate {
+		return nil
+	}
+
+	slog.Debug("Creating RSL reference entry...")
+	return rsl.NewReferenceEntry(absRefName, tag.Hash).Commit(r.r, signCommit)
+}
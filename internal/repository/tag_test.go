@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTag(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	commitID, err := gitinterface.Commit(repo.r, gitinterface.EmptyTree(), "refs/heads/main", "Test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tagID, err := repo.CreateTag("v1.0.0", "main", "Release v1.0.0", false)
+	assert.Nil(t, err)
+
+	tag, err := repo.GetTag("v1.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, tagID, tag.Hash.String())
+	assert.Equal(t, commitID, tag.Target)
+	assert.Equal(t, "Release v1.0.0", tag.Message)
+
+	_, err = repo.CreateTag("v1.0.0", commitID.String(), "Release v1.0.0", false)
+	assert.ErrorIs(t, err, gitinterface.ErrTagAlreadyExists)
+
+	// Lightweight tag
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference("refs/tags/v0.1.0", commitID)); err != nil {
+		t.Fatal(err)
+	}
+	_, err = repo.GetTag("v0.1.0")
+	assert.ErrorIs(t, err, gitinterface.ErrNotAnnotatedTag)
+}
+
+func TestRecordRSLEntryForTag(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := gitinterface.Commit(repo.r, gitinterface.EmptyTree(), "refs/heads/main", "Test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tagID, err := repo.CreateTag("v1.0.0", "main", "Release v1.0.0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLEntryForTag("v1.0.0", false)
+	assert.Nil(t, err)
+
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, "refs/tags/v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, tagID, entry.TargetID.String())
+
+	// Recording the same tag again does not create a new entry
+	err = repo.RecordRSLEntryForTag("refs/tags/v1.0.0", false)
+	assert.Nil(t, err)
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entry.ID, latestEntry.GetID())
+
+	// Lightweight tags cannot be recorded
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference("refs/tags/v0.1.0", commitID)); err != nil {
+		t.Fatal(err)
+	}
+	err = repo.RecordRSLEntryForTag("v0.1.0", false)
+	assert.ErrorIs(t, err, gitinterface.ErrNotAnnotatedTag)
+
+	// Branches are not tags
+	err = repo.RecordRSLEntryForTag("refs/heads/main", false)
+	assert.ErrorIs(t, err, gitinterface.ErrNotTagReference)
+}
@@ -169,6 +169,31 @@ func TestUpdateDelegation(t *testing.T) {
 	})
 }
 
+func TestSetRequireSignedCommits(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetRequireSignedCommits(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", true, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	assert.Nil(t, err)
+	assert.Equal(t, "protect-main", targetsMetadata.Delegations.Roles[0].Name)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireSignedCommits)
+
+	err = r.SetRequireSignedCommits(testCtx, targetsSigner, policy.TargetsRoleName, "does-not-exist", true, false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
 func TestRemoveDelegation(t *testing.T) {
 	r := createTestRepositoryWithPolicy(t, "")
 
@@ -219,6 +244,64 @@ func TestRemoveDelegation(t *testing.T) {
 	assert.Contains(t, targetsMetadata.Delegations.Keys, targetsPubKey.KeyID)
 	assert.Equal(t, 2, len(targetsMetadata.Delegations.Roles))
 	assert.Contains(t, targetsMetadata.Delegations.Roles, policy.AllowRule())
+
+	// A rule with its own rule file cannot be removed
+	err = r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, authorizedKeyBytes, rulePatterns, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.InitializeTargets(testCtx, targetsSigner, ruleName, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.RemoveDelegation(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, false)
+	assert.ErrorIs(t, err, policy.ErrOrphanedRules)
+}
+
+func TestReorderDelegations(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "test-rule", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/feature"}, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalRuleName := targetsMetadata.Delegations.Roles[0].Name
+
+	err = r.ReorderDelegations(testCtx, targetsSigner, policy.TargetsRoleName, []string{"test-rule"}, false)
+	assert.ErrorIs(t, err, policy.ErrMissingRules)
+
+	err = r.ReorderDelegations(testCtx, targetsSigner, policy.TargetsRoleName, []string{"test-rule", originalRuleName}, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = state.GetTargetsMetadata(policy.TargetsRoleName)
+	assert.Nil(t, err)
+	assert.Equal(t, "test-rule", targetsMetadata.Delegations.Roles[0].Name)
+	assert.Equal(t, originalRuleName, targetsMetadata.Delegations.Roles[1].Name)
+	assert.Equal(t, policy.AllowRuleName, targetsMetadata.Delegations.Roles[2].Name)
 }
 
 func TestAddKeyToTargets(t *testing.T) {
@@ -0,0 +1,385 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+const (
+	// ShadowRemoteConfigKey is the per-remote Git config option that names a
+	// second remote that gittuf refs are exchanged with instead, e.g.,
+	// remote.origin.gittufShadowRemote.
+	ShadowRemoteConfigKey = "gittufShadowRemote"
+
+	// HTTPTransportURLConfigKey is the per-remote Git config option that sets
+	// the HTTP endpoint that gittuf refs are exchanged with instead, e.g.,
+	// remote.origin.gittufURL.
+	HTTPTransportURLConfigKey = "gittufURL"
+
+	// FetchDepthConfigKey is the per-remote Git config option that limits
+	// the history fetched for gittuf refs to the specified number of
+	// commits, e.g., remote.origin.gittufFetchDepth.
+	FetchDepthConfigKey = "gittufFetchDepth"
+
+	// FetchFilterConfigKey is the per-remote Git config option that sets the
+	// partial clone filter used when fetching gittuf refs, e.g.,
+	// remote.origin.gittufFetchFilter. Objects excluded by the filter are
+	// fetched on demand during verification.
+	FetchFilterConfigKey = "gittufFetchFilter"
+
+	bundleContentType = "application/x-git-bundle"
+)
+
+var (
+	ErrConflictingTransportConfig = errors.New("remote cannot set both a shadow remote and an HTTP URL for gittuf refs")
+	ErrNonFastForwardUpdate       = errors.New("update to ref is not a fast-forward")
+	ErrHTTPTransport              = errors.New("unable to exchange gittuf refs over HTTP")
+	ErrInvalidFetchDepth          = errors.New("fetch depth for gittuf refs must be a non-negative integer")
+)
+
+// Transport exchanges gittuf refs such as the RSL and policy with a remote.
+// The Git-native transport is used by default. Hosting providers that block
+// custom refs can be supported by configuring an alternate transport for the
+// remote.
+type Transport interface {
+	// Fetch updates the remote tracker refs for the specified refs, leaving
+	// the local refs untouched.
+	Fetch(ctx context.Context, repo *git.Repository, refNames []string) error
+
+	// Pull updates the remote tracker refs and fast-forwards the local refs
+	// for the specified refs.
+	Pull(ctx context.Context, repo *git.Repository, refNames []string) error
+
+	// Push updates the specified refs at the remote. The update must be a
+	// fast-forward.
+	Push(ctx context.Context, repo *git.Repository, refNames []string) error
+}
+
+// SetTransport configures the transport used to exchange gittuf refs with the
+// specified remote. This takes precedence over the transport configured for
+// the remote in the Git config.
+func (r *Repository) SetTransport(remoteName string, transport Transport) {
+	if r.transports == nil {
+		r.transports = map[string]Transport{}
+	}
+	r.transports[remoteName] = transport
+}
+
+// getTransport returns the transport for the specified remote. Unless a
+// transport is set using SetTransport, the remote's Git config is used to pick
+// between a shadow remote, an HTTP endpoint, and the Git-native default.
+func (r *Repository) getTransport(remoteName string) (Transport, error) {
+	if transport, has := r.transports[remoteName]; has {
+		return transport, nil
+	}
+
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return nil, err
+	}
+	options := repoConfig.Raw.Section("remote").Subsection(remoteName).Options
+
+	fetchOptions, err := r.getFetchOptions(remoteName)
+	if err != nil {
+		return nil, err
+	}
+
+	shadowRemoteName := options.Get(ShadowRemoteConfigKey)
+	transportURL := options.Get(HTTPTransportURLConfigKey)
+	switch {
+	case shadowRemoteName != "" && transportURL != "":
+		return nil, ErrConflictingTransportConfig
+	case shadowRemoteName != "":
+		slog.Debug(fmt.Sprintf("Using shadow remote '%s' for gittuf refs of '%s'...", shadowRemoteName, remoteName))
+		return &gitTransport{remoteName: remoteName, targetRemoteName: shadowRemoteName, fetchOptions: fetchOptions}, nil
+	case transportURL != "":
+		slog.Debug(fmt.Sprintf("Using '%s' for gittuf refs of '%s'...", transportURL, remoteName))
+		return NewHTTPTransport(remoteName, transportURL, nil), nil
+	default:
+		return &gitTransport{remoteName: remoteName, targetRemoteName: remoteName, fetchOptions: fetchOptions}, nil
+	}
+}
+
+// getFetchOptions returns the options used when fetching gittuf refs from the
+// specified remote, as set in the remote's Git config.
+func (r *Repository) getFetchOptions(remoteName string) (*gitinterface.FetchOptions, error) {
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return nil, err
+	}
+	options := repoConfig.Raw.Section("remote").Subsection(remoteName).Options
+
+	fetchOptions := &gitinterface.FetchOptions{Filter: options.Get(FetchFilterConfigKey)}
+	if depth := options.Get(FetchDepthConfigKey); depth != "" {
+		fetchOptions.Depth, err = strconv.Atoi(depth)
+		if err != nil || fetchOptions.Depth < 0 {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidFetchDepth, depth)
+		}
+	}
+
+	return fetchOptions, nil
+}
+
+// getOnDemandFetchRepository returns a view of the repository that fetches
+// missing objects on demand from the remote that gittuf refs are fetched
+// from, if a partial clone filter is set for the remote. Otherwise, the
+// repository is returned as is.
+func (r *Repository) getOnDemandFetchRepository(ctx context.Context, remoteName string) (*git.Repository, error) {
+	fetchOptions, err := r.getFetchOptions(remoteName)
+	if err != nil {
+		return nil, err
+	}
+	if fetchOptions.Filter == "" {
+		return r.r, nil
+	}
+
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return nil, err
+	}
+	if shadowRemoteName := repoConfig.Raw.Section("remote").Subsection(remoteName).Options.Get(ShadowRemoteConfigKey); shadowRemoteName != "" {
+		remoteName = shadowRemoteName
+	}
+
+	return gitinterface.NewOnDemandFetchRepository(ctx, r.r, remoteName)
+}
+
+// EnableOnDemandFetch configures the repository to fetch objects that are
+// missing locally from the specified remote when they're needed, such as
+// after fetching gittuf refs using a partial clone filter. The context is used
+// for all subsequent fetches of missing objects.
+func (r *Repository) EnableOnDemandFetch(ctx context.Context, remoteName string) error {
+	repo, err := gitinterface.NewOnDemandFetchRepository(ctx, r.r, remoteName)
+	if err != nil {
+		return err
+	}
+
+	r.r = repo
+	return nil
+}
+
+type gitTransport struct {
+	// remoteName determines the names of the remote tracker refs.
+	remoteName string
+
+	// targetRemoteName is the remote that refs are exchanged with.
+	targetRemoteName string
+
+	// fetchOptions limits the history and objects fetched for gittuf refs.
+	fetchOptions *gitinterface.FetchOptions
+}
+
+// NewGitTransport returns the default transport that exchanges gittuf refs
+// with the remote using Git.
+func NewGitTransport(remoteName string) Transport {
+	return &gitTransport{remoteName: remoteName, targetRemoteName: remoteName}
+}
+
+// NewShadowRemoteTransport returns a transport that exchanges gittuf refs with
+// shadowRemoteName using Git. The remote tracker refs are still named for
+// remoteName, so the shadow remote is transparent to the rest of gittuf.
+func NewShadowRemoteTransport(remoteName, shadowRemoteName string) Transport {
+	return &gitTransport{remoteName: remoteName, targetRemoteName: shadowRemoteName}
+}
+
+func (g *gitTransport) Fetch(ctx context.Context, repo *git.Repository, refNames []string) error {
+	refSpecs := make([]config.RefSpec, 0, len(refNames))
+	for _, refName := range refNames {
+		refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("%s:%s", refName, gitinterface.RemoteRef(refName, g.remoteName))))
+	}
+
+	return gitinterface.FetchRefSpecWithOptions(ctx, repo, g.targetRemoteName, refSpecs, g.fetchOptions)
+}
+
+func (g *gitTransport) Pull(ctx context.Context, repo *git.Repository, refNames []string) error {
+	refSpecs := make([]config.RefSpec, 0, len(refNames)*2)
+	for _, refName := range refNames {
+		// Add the remote tracker destination
+		refSpec, err := gitinterface.RefSpec(repo, refName, g.remoteName, true)
+		if err != nil {
+			return err
+		}
+		refSpecs = append(refSpecs, refSpec)
+
+		// Add the regular destination
+		refSpec, err = gitinterface.RefSpec(repo, refName, "", true)
+		if err != nil {
+			return err
+		}
+		refSpecs = append(refSpecs, refSpec)
+	}
+
+	return gitinterface.FetchRefSpecWithOptions(ctx, repo, g.targetRemoteName, refSpecs, g.fetchOptions)
+}
+
+func (g *gitTransport) Push(ctx context.Context, repo *git.Repository, refNames []string) error {
+	return gitinterface.Push(ctx, repo, g.targetRemoteName, refNames)
+}
+
+// httpTransport exchanges gittuf refs with an HTTP endpoint using Git bundles.
+// A GET request to the endpoint must return a bundle containing the current
+// state of the gittuf refs. A POST request carries a bundle with the refs to
+// update. The endpoint is expected to apply the update atomically and reject
+// it if it isn't a fast-forward.
+type httpTransport struct {
+	remoteName string
+	url        string
+	client     *http.Client
+}
+
+// NewHTTPTransport returns a transport that exchanges gittuf refs with the
+// specified HTTP endpoint. The remote tracker refs are named for remoteName.
+// If client is nil, http.DefaultClient is used.
+func NewHTTPTransport(remoteName, url string, client *http.Client) Transport {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpTransport{remoteName: remoteName, url: url, client: client}
+}
+
+func (h *httpTransport) Fetch(ctx context.Context, repo *git.Repository, refNames []string) error {
+	_, err := h.fetch(ctx, repo, refNames)
+	return err
+}
+
+func (h *httpTransport) Pull(ctx context.Context, repo *git.Repository, refNames []string) error {
+	remoteRefs, err := h.fetch(ctx, repo, refNames)
+	if err != nil {
+		return err
+	}
+
+	for _, refName := range refNames {
+		target, has := remoteRefs[refName]
+		if !has {
+			continue
+		}
+
+		if err := fastForwardReference(repo, refName, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *httpTransport) Push(ctx context.Context, repo *git.Repository, refNames []string) error {
+	refs := map[string]plumbing.Hash{}
+	for _, refName := range refNames {
+		ref, err := repo.Reference(plumbing.ReferenceName(refName), true)
+		if err != nil {
+			return err
+		}
+		if ref.Hash().IsZero() {
+			// There's nothing to send for an uninitialized ref
+			continue
+		}
+		refs[refName] = ref.Hash()
+	}
+
+	bundle := &bytes.Buffer{}
+	if err := gitinterface.WriteBundle(repo, bundle, refs); err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bundle)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", bundleContentType)
+
+	response, err := h.client.Do(request)
+	if err != nil {
+		return errors.Join(ErrHTTPTransport, err)
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("%w: unexpected response '%s'", ErrHTTPTransport, response.Status)
+	}
+
+	return nil
+}
+
+// fetch downloads the endpoint's bundle into repo and updates the remote
+// tracker refs for the specified refs. The state of the refs at the endpoint
+// is returned. Refs the endpoint doesn't have are skipped.
+func (h *httpTransport) fetch(ctx context.Context, repo *git.Repository, refNames []string) (map[string]plumbing.Hash, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", bundleContentType)
+
+	response, err := h.client.Do(request)
+	if err != nil {
+		return nil, errors.Join(ErrHTTPTransport, err)
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected response '%s'", ErrHTTPTransport, response.Status)
+	}
+
+	remoteRefs, err := gitinterface.ImportBundle(repo, response.Body)
+	if err != nil {
+		return nil, errors.Join(ErrHTTPTransport, err)
+	}
+
+	for _, refName := range refNames {
+		target, has := remoteRefs[refName]
+		if !has {
+			continue
+		}
+
+		trackerRef := plumbing.NewHashReference(plumbing.ReferenceName(gitinterface.RemoteRef(refName, h.remoteName)), target)
+		if err := repo.Storer.SetReference(trackerRef); err != nil {
+			return nil, err
+		}
+	}
+
+	return remoteRefs, nil
+}
+
+// fastForwardReference sets refName to target if the ref doesn't exist yet or
+// if target is a descendant of the ref's current target.
+func fastForwardReference(repo *git.Repository, refName string, target plumbing.Hash) error {
+	currentRef, err := repo.Reference(plumbing.ReferenceName(refName), true)
+	if err != nil {
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return err
+		}
+		currentRef = nil
+	}
+
+	if currentRef != nil && !currentRef.Hash().IsZero() {
+		if currentRef.Hash() == target {
+			return nil
+		}
+
+		currentCommit, err := gitinterface.GetCommit(repo, currentRef.Hash())
+		if err != nil {
+			return err
+		}
+		knows, err := gitinterface.KnowsCommit(repo, target, currentCommit)
+		if err != nil {
+			return err
+		}
+		if !knows {
+			return fmt.Errorf("%w: '%s'", ErrNonFastForwardUpdate, refName)
+		}
+	}
+
+	return repo.Storer.CheckAndSetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), target), currentRef)
+}
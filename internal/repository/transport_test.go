@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTransport(t *testing.T) {
+	remoteName := "origin"
+
+	setup := func(t *testing.T, options map[string]string) *Repository {
+		t.Helper()
+
+		r, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		repo := &Repository{r: r}
+		if _, err := repo.r.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{"https://example.com/repo"}}); err != nil {
+			t.Fatal(err)
+		}
+		setRemoteConfigOptions(t, repo, remoteName, options)
+
+		return repo
+	}
+
+	t.Run("git transport by default", func(t *testing.T) {
+		repo := setup(t, nil)
+
+		transport, err := repo.getTransport(remoteName)
+		assert.Nil(t, err)
+		assert.Equal(t, &gitTransport{remoteName: remoteName, targetRemoteName: remoteName, fetchOptions: &gitinterface.FetchOptions{}}, transport)
+	})
+
+	t.Run("shadow remote", func(t *testing.T) {
+		repo := setup(t, map[string]string{ShadowRemoteConfigKey: "shadow"})
+
+		transport, err := repo.getTransport(remoteName)
+		assert.Nil(t, err)
+		assert.Equal(t, &gitTransport{remoteName: remoteName, targetRemoteName: "shadow", fetchOptions: &gitinterface.FetchOptions{}}, transport)
+	})
+
+	t.Run("fetch options", func(t *testing.T) {
+		repo := setup(t, map[string]string{FetchDepthConfigKey: "1", FetchFilterConfigKey: "blob:none"})
+
+		transport, err := repo.getTransport(remoteName)
+		assert.Nil(t, err)
+		assert.Equal(t, &gitTransport{remoteName: remoteName, targetRemoteName: remoteName, fetchOptions: &gitinterface.FetchOptions{Depth: 1, Filter: "blob:none"}}, transport)
+
+		repo = setup(t, map[string]string{FetchDepthConfigKey: "-1"})
+		_, err = repo.getTransport(remoteName)
+		assert.ErrorIs(t, err, ErrInvalidFetchDepth)
+	})
+
+	t.Run("http transport", func(t *testing.T) {
+		repo := setup(t, map[string]string{HTTPTransportURLConfigKey: "https://example.com/gittuf"})
+
+		transport, err := repo.getTransport(remoteName)
+		assert.Nil(t, err)
+		assert.Equal(t, &httpTransport{remoteName: remoteName, url: "https://example.com/gittuf", client: http.DefaultClient}, transport)
+	})
+
+	t.Run("conflicting config", func(t *testing.T) {
+		repo := setup(t, map[string]string{ShadowRemoteConfigKey: "shadow", HTTPTransportURLConfigKey: "https://example.com/gittuf"})
+
+		_, err := repo.getTransport(remoteName)
+		assert.ErrorIs(t, err, ErrConflictingTransportConfig)
+	})
+
+	t.Run("transport set explicitly", func(t *testing.T) {
+		repo := setup(t, map[string]string{ShadowRemoteConfigKey: "shadow"})
+
+		expectedTransport := NewHTTPTransport(remoteName, "https://example.com/gittuf", nil)
+		repo.SetTransport(remoteName, expectedTransport)
+
+		transport, err := repo.getTransport(remoteName)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedTransport, transport)
+	})
+}
+
+func TestShadowRemoteTransport(t *testing.T) {
+	remoteName := "origin"
+	shadowRemoteName := "shadow"
+
+	shadowTmpDir := t.TempDir()
+	shadowRepo := createTestRepositoryWithPolicy(t, shadowTmpDir)
+
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	localRepo := &Repository{r: r}
+	if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{t.TempDir()}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{Name: shadowRemoteName, URLs: []string{shadowTmpDir}}); err != nil {
+		t.Fatal(err)
+	}
+	setRemoteConfigOptions(t, localRepo, remoteName, map[string]string{ShadowRemoteConfigKey: shadowRemoteName})
+
+	err = localRepo.PullRSL(testCtx, remoteName)
+	assert.Nil(t, err)
+	assertLocalAndRemoteRefsMatch(t, localRepo.r, shadowRepo.r, rsl.Ref)
+
+	// The remote tracker is named for the primary remote
+	shadowRSLTip, err := shadowRepo.r.Reference(rsl.Ref, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trackerRef, err := localRepo.r.Reference(plumbing.ReferenceName(rsl.RemoteTrackerRef(remoteName)), true)
+	assert.Nil(t, err)
+	assert.Equal(t, shadowRSLTip.Hash(), trackerRef.Hash())
+
+	if err := rsl.NewReferenceEntry("refs/heads/main", gitinterface.EmptyTree()).Commit(localRepo.r, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = localRepo.PushRSL(testCtx, remoteName)
+	assert.Nil(t, err)
+	assertLocalAndRemoteRefsMatch(t, localRepo.r, shadowRepo.r, rsl.Ref)
+}
+
+func TestHTTPTransport(t *testing.T) {
+	remoteName := "origin"
+
+	serverRepo := createTestRepositoryWithPolicy(t, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			rslRef, err := serverRepo.r.Reference(rsl.Ref, true)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if err := gitinterface.WriteBundle(serverRepo.r, w, map[string]plumbing.Hash{rsl.Ref: rslRef.Hash()}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		case http.MethodPost:
+			refs, err := gitinterface.ImportBundle(serverRepo.r, req.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for refName, target := range refs {
+				if err := fastForwardReference(serverRepo.r, refName, target); err != nil {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+			}
+		}
+	}))
+	defer server.Close()
+
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	localRepo := &Repository{r: r}
+	if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{t.TempDir()}}); err != nil {
+		t.Fatal(err)
+	}
+	setRemoteConfigOptions(t, localRepo, remoteName, map[string]string{HTTPTransportURLConfigKey: server.URL})
+
+	t.Run("pull", func(t *testing.T) {
+		err := localRepo.PullRSL(testCtx, remoteName)
+		assert.Nil(t, err)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, serverRepo.r, rsl.Ref)
+	})
+
+	t.Run("check for updates", func(t *testing.T) {
+		if err := rsl.NewReferenceEntry("refs/heads/main", gitinterface.EmptyTree()).Commit(serverRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		hasUpdates, hasDiverged, err := localRepo.CheckRemoteRSLForUpdates(testCtx, remoteName)
+		assert.Nil(t, err)
+		assert.True(t, hasUpdates)
+		assert.False(t, hasDiverged)
+
+		err = localRepo.PullRSL(testCtx, remoteName)
+		assert.Nil(t, err)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, serverRepo.r, rsl.Ref)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		if err := rsl.NewReferenceEntry("refs/heads/feature", gitinterface.EmptyTree()).Commit(localRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := localRepo.PushRSL(testCtx, remoteName)
+		assert.Nil(t, err)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, serverRepo.r, rsl.Ref)
+	})
+
+	t.Run("divergent push is rejected", func(t *testing.T) {
+		if err := rsl.NewReferenceEntry("refs/heads/main", gitinterface.EmptyTree()).Commit(serverRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.NewReferenceEntry("refs/heads/feature", gitinterface.EmptyTree()).Commit(localRepo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := localRepo.PushRSL(testCtx, remoteName)
+		assert.ErrorIs(t, err, ErrPushingRSL)
+		assert.ErrorIs(t, err, ErrHTTPTransport)
+
+		err = localRepo.PullRSL(testCtx, remoteName)
+		assert.ErrorIs(t, err, ErrNonFastForwardUpdate)
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		invalidServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write(bytes.Repeat([]byte("a"), 10)) //nolint:errcheck
+		}))
+		defer invalidServer.Close()
+
+		err := NewHTTPTransport(remoteName, invalidServer.URL, nil).Fetch(testCtx, localRepo.r, []string{rsl.Ref})
+		assert.ErrorIs(t, err, ErrHTTPTransport)
+	})
+}
+
+func setRemoteConfigOptions(t *testing.T, repo *Repository, remoteName string, options map[string]string) {
+	t.Helper()
+
+	repoConfig, err := repo.r.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range options {
+		repoConfig.Raw.Section("remote").Subsection(remoteName).SetOption(key, value)
+	}
+	if err := repo.r.SetConfig(repoConfig); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+)
+
+var ErrInvalidRootPinFile = errors.New("root pin file is invalid")
+
+// rootPin is the format of the file used to pin the initial root keys of a
+// repository when they are trusted on first use.
+type rootPin struct {
+	RootKeys  []*tuf.Key `json:"rootKeys"`
+	Threshold int        `json:"threshold"`
+}
+
+// SetRootTrustAnchors sets the root public keys, obtained out-of-band, that
+// are expected to have signed the repository's initial root of trust. When
+// set, verification fails unless the root of trust in the first policy entry
+// in the RSL is signed by at least threshold of these keys. If threshold is 0,
+// all of the keys must have signed it. Otherwise, the initial root of trust is
+// trusted on first use.
+func (r *Repository) SetRootTrustAnchors(rootKeys []*tuf.Key, threshold int) {
+	if threshold == 0 {
+		threshold = len(rootKeys)
+	}
+
+	r.rootTrustAnchors = rootKeys
+	r.rootTrustAnchorsThreshold = threshold
+}
+
+// SetRootPinFile sets the path of a file used to pin the keys of the
+// repository's initial root of trust. If the file doesn't exist when the
+// repository is verified, the initial root keys and threshold are trusted on
+// first use and written to it. Subsequent verifications fail unless the initial
+// root of trust is signed by the pinned threshold of the pinned keys. The pin file is ignored if root
+// trust anchors are set using SetRootTrustAnchors.
+func (r *Repository) SetRootPinFile(path string) {
+	r.rootPinFile = path
+}
+
+// verifyRootTrustAnchors authenticates the initial root of trust recorded in
+// policyRepo using the repository's root trust anchors or root pin file. If
+// neither is set, the initial root of trust is trusted on first use.
+func (r *Repository) verifyRootTrustAnchors(ctx context.Context, policyRepo *git.Repository) error {
+	switch {
+	case len(r.rootTrustAnchors) > 0:
+		slog.Debug("Verifying initial root of trust using root trust anchors...")
+		return policy.VerifyInitialRoot(ctx, policyRepo, r.rootTrustAnchors, r.rootTrustAnchorsThreshold)
+	case r.rootPinFile != "":
+		return r.verifyRootPin(ctx, policyRepo)
+	}
+
+	return nil
+}
+
+// verifyRootPin verifies the initial root of trust using the keys and threshold
+// in the root pin file, creating the pin file if it doesn't exist.
+func (r *Repository) verifyRootPin(ctx context.Context, policyRepo *git.Repository) error {
+	contents, err := os.ReadFile(r.rootPinFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		slog.Debug(fmt.Sprintf("Pinning initial root keys in '%s'...", r.rootPinFile))
+		rootKeys, threshold, err := policy.GetInitialRootKeys(policyRepo)
+		if err != nil {
+			return err
+		}
+
+		contents, err := json.MarshalIndent(&rootPin{RootKeys: rootKeys, Threshold: threshold}, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(r.rootPinFile), 0o750); err != nil {
+			return err
+		}
+		return os.WriteFile(r.rootPinFile, contents, 0o644) //nolint:gosec
+	}
+
+	pin := &rootPin{}
+	if err := json.Unmarshal(contents, pin); err != nil {
+		return errors.Join(ErrInvalidRootPinFile, err)
+	}
+	if len(pin.RootKeys) == 0 {
+		return fmt.Errorf("%w: no root keys are pinned", ErrInvalidRootPinFile)
+	}
+	if pin.Threshold < 1 {
+		return fmt.Errorf("%w: no root threshold is pinned", ErrInvalidRootPinFile)
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying initial root of trust using root keys pinned in '%s'...", r.rootPinFile))
+	return policy.VerifyInitialRoot(ctx, policyRepo, pin.RootKeys, pin.Threshold)
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyRefWithRootTrustAnchors(t *testing.T) {
+	rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// createRepository returns a repository with a policy and an entry for
+	// main that complies with the policy
+	createRepository := func(t *testing.T) *Repository {
+		t.Helper()
+
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		refName := "refs/heads/main"
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		return repo
+	}
+
+	t.Run("trusted root keys", func(t *testing.T) {
+		repo := createRepository(t)
+		repo.SetRootTrustAnchors([]*tuf.Key{rootKey}, 0)
+
+		err := repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.Nil(t, err)
+	})
+
+	t.Run("untrusted root keys", func(t *testing.T) {
+		repo := createRepository(t)
+		repo.SetRootTrustAnchors([]*tuf.Key{targetsKey}, 0)
+
+		err := repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.ErrorIs(t, err, policy.ErrUntrustedInitialRoot)
+
+		err = repo.VerifyRef(testCtx, "refs/heads/main", true)
+		assert.ErrorIs(t, err, policy.ErrUntrustedInitialRoot)
+	})
+
+	t.Run("root trust anchors threshold", func(t *testing.T) {
+		repo := createRepository(t)
+
+		// All root keys must have signed by default
+		repo.SetRootTrustAnchors([]*tuf.Key{targetsKey, rootKey}, 0)
+		err := repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.ErrorIs(t, err, policy.ErrUntrustedInitialRoot)
+
+		repo.SetRootTrustAnchors([]*tuf.Key{targetsKey, rootKey}, 1)
+		err = repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.Nil(t, err)
+	})
+
+	t.Run("root pin file", func(t *testing.T) {
+		repo := createRepository(t)
+		pinFile := filepath.Join(t.TempDir(), "pins", "root.json")
+		repo.SetRootPinFile(pinFile)
+
+		// The initial root keys are pinned on first use
+		err := repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.Nil(t, err)
+		_, err = os.Stat(pinFile)
+		assert.Nil(t, err)
+
+		// The initial root's threshold is pinned along with its keys
+		pinContents, err := os.ReadFile(pinFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pin := &rootPin{}
+		if err := json.Unmarshal(pinContents, pin); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, pin.Threshold)
+
+		// The pinned keys are used subsequently
+		err = repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.Nil(t, err)
+
+		// An initial root that doesn't meet the pinned threshold is rejected
+		pinContents, err = json.Marshal(&rootPin{RootKeys: []*tuf.Key{targetsKey, rootKey}, Threshold: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(pinFile, pinContents, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		err = repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.ErrorIs(t, err, policy.ErrUntrustedInitialRoot)
+
+		// An initial root that doesn't match the pinned keys is rejected
+		pinContents, err = json.Marshal(&rootPin{RootKeys: []*tuf.Key{targetsKey}, Threshold: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(pinFile, pinContents, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		err = repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.ErrorIs(t, err, policy.ErrUntrustedInitialRoot)
+	})
+
+	t.Run("invalid root pin file", func(t *testing.T) {
+		repo := createRepository(t)
+		pinFile := filepath.Join(t.TempDir(), "root.json")
+		if err := os.WriteFile(pinFile, []byte(`{"rootKeys": []}`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		repo.SetRootPinFile(pinFile)
+
+		err := repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.ErrorIs(t, err, ErrInvalidRootPinFile)
+
+		// A pin file without a threshold is rejected
+		pinContents, err := json.Marshal(&rootPin{RootKeys: []*tuf.Key{rootKey}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(pinFile, pinContents, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		err = repo.VerifyRef(testCtx, "refs/heads/main", false)
+		assert.ErrorIs(t, err, ErrInvalidRootPinFile)
+	})
+}
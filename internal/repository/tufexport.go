@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+)
+
+const (
+	// TUFMetadataDirectory is the directory in an exported TUF repository
+	// that contains the metadata files.
+	TUFMetadataDirectory = "metadata"
+
+	// TUFRSLSummaryFileName is the name of the file in an exported TUF
+	// repository that summarizes the RSL.
+	TUFRSLSummaryFileName = "rsl.json"
+)
+
+// RSLSummary records the latest state of each ref in the RSL when a TUF
+// repository was exported.
+type RSLSummary struct {
+	// RSLTip is the ID of the latest entry in the RSL.
+	RSLTip string `json:"rslTip"`
+
+	// PolicyEntryID is the ID of the RSL entry for the exported policy.
+	PolicyEntryID string `json:"policyEntryID"`
+
+	// Refs contains the latest entry for each ref in the RSL, sorted by ref
+	// name.
+	Refs []*RSLSummaryRef `json:"refs"`
+}
+
+// RSLSummaryRef records the latest RSL entry for a ref. A ref whose latest
+// entry records its deletion has the zero hash as its target.
+type RSLSummaryRef struct {
+	RefName     string `json:"refName"`
+	EntryID     string `json:"entryID"`
+	EntryNumber uint64 `json:"entryNumber,omitempty"`
+	TargetID    string `json:"targetID"`
+}
+
+// ExportTUFRepository writes the repository's current policy to dir in the
+// layout of a TUF repository, so that TUF clients and inspection tools can
+// consume it. The metadata files are written to the TUFMetadataDirectory
+// subdirectory, as described in policy.ExportTUFMetadata. A summary of the
+// RSL is written alongside it to TUFRSLSummaryFileName. Existing files with the
+// same names are overwritten.
+func (r *Repository) ExportTUFRepository(ctx context.Context, dir string) error {
+	slog.Debug("Identifying latest policy entry...")
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		return err
+	}
+
+	files, err := policy.ExportTUFMetadata(ctx, r.r, policyEntry)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Summarizing RSL...")
+	summary, err := r.getRSLSummary()
+	if err != nil {
+		return err
+	}
+	summary.PolicyEntryID = policyEntry.ID.String()
+
+	summaryContents, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	metadataDir := filepath.Join(dir, TUFMetadataDirectory)
+	if err := os.MkdirAll(metadataDir, 0o755); err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Writing TUF repository to '%s'...", dir))
+	for fileName, contents := range files {
+		if err := os.WriteFile(filepath.Join(metadataDir, fileName), contents, 0o644); err != nil { //nolint:gosec
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(dir, TUFRSLSummaryFileName), summaryContents, 0o644) //nolint:gosec
+}
+
+// getRSLSummary records the latest entry in the RSL and the latest entry for
+// each ref.
+func (r *Repository) getRSLSummary() (*RSLSummary, error) {
+	iterator, err := rsl.NewEntryIterator(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &RSLSummary{Refs: []*RSLSummaryRef{}}
+	seen := map[string]bool{}
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		if summary.RSLTip == "" {
+			summary.RSLTip = entry.GetID().String()
+		}
+
+		referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry || seen[referenceEntry.RefName] {
+			continue
+		}
+		seen[referenceEntry.RefName] = true
+
+		summary.Refs = append(summary.Refs, &RSLSummaryRef{
+			RefName:     referenceEntry.RefName,
+			EntryID:     referenceEntry.ID.String(),
+			EntryNumber: referenceEntry.Number,
+			TargetID:    referenceEntry.TargetID.String(),
+		})
+	}
+
+	sort.Slice(summary.Refs, func(i, j int) bool {
+		return summary.Refs[i].RefName < summary.Refs[j].RefName
+	})
+
+	return summary, nil
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportTUFRepository(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 1, gpgKeyBytes)
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	err = r.ExportTUFRepository(testCtx, dir)
+	assert.Nil(t, err)
+
+	for _, fileName := range []string{"root.json", "targets.json", "snapshot.json", "timestamp.json"} {
+		assert.FileExists(t, filepath.Join(dir, TUFMetadataDirectory, fileName))
+	}
+
+	summaryContents, err := os.ReadFile(filepath.Join(dir, TUFRSLSummaryFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := &RSLSummary{}
+	if err := json.Unmarshal(summaryContents, summary); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entryID.String(), summary.RSLTip)
+	assert.Equal(t, policyEntry.ID.String(), summary.PolicyEntryID)
+
+	refNames := []string{}
+	for _, ref := range summary.Refs {
+		refNames = append(refNames, ref.RefName)
+		if ref.RefName == refName {
+			assert.Equal(t, entryID.String(), ref.EntryID)
+			assert.Equal(t, commitIDs[0].String(), ref.TargetID)
+		}
+	}
+	assert.Contains(t, refNames, refName)
+	assert.Contains(t, refNames, policy.PolicyRef)
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-git/v5/plumbing"
 )
 
@@ -24,18 +26,32 @@ import (
 var ErrRefStateDoesNotMatchRSL = errors.New("Git reference's current state does not match latest RSL entry") //nolint:stylecheck
 
 func (r *Repository) VerifyRef(ctx context.Context, target string, latestOnly bool) error {
+	slog.Debug("Identifying absolute reference path...")
+	target, err := gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		return err
+	}
+
+	return r.retryAfterDeepening(ctx, target, func() error {
+		return r.verifyRef(ctx, target, latestOnly)
+	})
+}
+
+func (r *Repository) verifyRef(ctx context.Context, target string, latestOnly bool) error {
 	var (
 		expectedTip plumbing.Hash
 		err         error
 	)
 
-	slog.Debug("Identifying absolute reference path...")
-	target, err = gitinterface.AbsoluteReference(r.r, target)
-	if err != nil {
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", target))
+
+	if err := r.verifyRootTrustAnchors(ctx, r.r); err != nil {
 		return err
 	}
 
-	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", target))
+	if err := r.verifyPolicyExpiration(ctx, r.r, policy.PolicyRef); err != nil {
+		return err
+	}
 
 	if latestOnly {
 		expectedTip, err = policy.VerifyRef(ctx, r.r, target)
@@ -55,6 +71,231 @@ func (r *Repository) VerifyRef(ctx context.Context, target string, latestOnly bo
 	return nil
 }
 
+// VerifyRefOptions configures VerifyRefWithOptions.
+type VerifyRefOptions struct {
+	// LatestOnly limits verification to the latest RSL entry for the ref.
+	LatestOnly bool
+
+	// Progress is invoked with the verification's progress as each RSL entry
+	// is reached. It is called synchronously, so it must return promptly.
+	Progress policy.ProgressFunc
+
+	// VerifySubmodules additionally checks that updates to submodule
+	// pointers correspond to verified RSL entries in the submodules'
+	// repositories, as configured in the policy. See VerifySubmodules.
+	VerifySubmodules bool
+
+	// UseCache skips entries that were previously verified under the same
+	// policy and attestations, and records new results in the repository's
+	// local verification cache. See VerifyRefUsingCache. It has no effect if
+	// LatestOnly is set.
+	UseCache bool
+}
+
+// VerifyRefWithOptions verifies the target ref like VerifyRef, using the
+// specified options. Verification stops with the context's error if ctx is
+// canceled or its deadline passes, so callers can bound long verifications.
+func (r *Repository) VerifyRefWithOptions(ctx context.Context, target string, opts *VerifyRefOptions) error {
+	if opts == nil {
+		opts = &VerifyRefOptions{}
+	}
+
+	if opts.Progress != nil {
+		ctx = policy.WithVerificationProgress(ctx, opts.Progress)
+	}
+
+	var err error
+	if opts.UseCache && !opts.LatestOnly {
+		err = r.VerifyRefUsingCache(ctx, target)
+	} else {
+		err = r.VerifyRef(ctx, target, opts.LatestOnly)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.VerifySubmodules {
+		return r.VerifySubmodules(ctx, target)
+	}
+
+	return nil
+}
+
+// VerifyRefWithReport verifies the entire RSL for the target ref, like
+// VerifyRef with latestOnly unset. A report capturing the result for each RSL
+// entry and the policy states used is returned alongside any verification
+// error, so that it can be consumed even when verification fails.
+func (r *Repository) VerifyRefWithReport(ctx context.Context, target string) (*policy.VerificationReport, error) {
+	slog.Debug("Identifying absolute reference path...")
+	absTarget, err := gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		report := policy.NewVerificationReport(target)
+		report.SetResult(err)
+		return report, err
+	}
+
+	var report *policy.VerificationReport
+	err = r.retryAfterDeepening(ctx, absTarget, func() error {
+		var err error
+		report, err = r.verifyRefWithReport(ctx, absTarget)
+		return err
+	})
+	return report, err
+}
+
+func (r *Repository) verifyRefWithReport(ctx context.Context, absTarget string) (*policy.VerificationReport, error) {
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", absTarget))
+
+	if err := r.verifyRootTrustAnchors(ctx, r.r); err != nil {
+		report := policy.NewVerificationReport(absTarget)
+		report.SetResult(err)
+		return report, err
+	}
+
+	if err := r.verifyPolicyExpiration(ctx, r.r, policy.PolicyRef); err != nil {
+		report := policy.NewVerificationReport(absTarget)
+		report.SetResult(err)
+		return report, err
+	}
+
+	expectedTip, report, err := policy.VerifyRefFullWithReport(ctx, r.r, absTarget)
+	if err != nil {
+		return report, err
+	}
+
+	slog.Debug("Verifying if tip of reference matches expected value from RSL...")
+	if err := r.verifyRefTip(absTarget, expectedTip); err != nil {
+		report.SetResult(err)
+		return report, err
+	}
+
+	slog.Debug("Verification successful!")
+	return report, nil
+}
+
+// VerifyRefUsingCache verifies the entire RSL for the target ref, like
+// VerifyRef with latestOnly unset. Entries that were previously verified under
+// the same policy state and attestations are not verified again, and new
+// results are persisted in the repository's local verification cache.
+func (r *Repository) VerifyRefUsingCache(ctx context.Context, target string) error {
+	var err error
+
+	slog.Debug("Identifying absolute reference path...")
+	target, err = gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s' using verification cache", target))
+
+	if err := r.verifyRootTrustAnchors(ctx, r.r); err != nil {
+		return err
+	}
+
+	if err := r.verifyPolicyExpiration(ctx, r.r, policy.PolicyRef); err != nil {
+		return err
+	}
+
+	expectedTip, err := policy.VerifyRefFullUsingCache(ctx, r.r, target)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying if tip of reference matches expected value from RSL...")
+	if err := r.verifyRefTip(target, expectedTip); err != nil {
+		return err
+	}
+
+	slog.Debug("Verification successful!")
+	return nil
+}
+
+// ClearVerificationCache discards all results in the repository's local
+// verification cache, so that the next verification using the cache checks
+// every entry again.
+func (r *Repository) ClearVerificationCache() error {
+	slog.Debug("Clearing verification cache...")
+	return policy.ClearVerificationCache(r.r)
+}
+
+// VerifyRefIncremental verifies the RSL for the target ref, resuming from the
+// last entry previously verified for the ref under the current policy. The
+// last verified entry is persisted in the repository's local verification
+// markers. If the policy changed since the ref was last verified, the entire
+// RSL is verified again.
+func (r *Repository) VerifyRefIncremental(ctx context.Context, target string) error {
+	var err error
+
+	slog.Debug("Identifying absolute reference path...")
+	target, err = gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s' incrementally", target))
+
+	if err := r.verifyRootTrustAnchors(ctx, r.r); err != nil {
+		return err
+	}
+
+	if err := r.verifyPolicyExpiration(ctx, r.r, policy.PolicyRef); err != nil {
+		return err
+	}
+
+	expectedTip, err := policy.VerifyRefIncremental(ctx, r.r, target)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying if tip of reference matches expected value from RSL...")
+	if err := r.verifyRefTip(target, expectedTip); err != nil {
+		return err
+	}
+
+	slog.Debug("Verification successful!")
+	return nil
+}
+
+// VerifyRefUsingExternalPolicy verifies the latest RSL entry for the target
+// ref using the policy recorded in policyRef of a separate repository. If
+// policyRef is not specified, the standard gittuf policy reference is used.
+func (r *Repository) VerifyRefUsingExternalPolicy(ctx context.Context, target string, policyRepository *Repository, policyRef string) error {
+	var err error
+
+	slog.Debug("Identifying absolute reference path...")
+	target, err = gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		return err
+	}
+
+	if policyRef == "" {
+		policyRef = policy.PolicyRef
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s' using external policy", target))
+
+	if err := r.verifyRootTrustAnchors(ctx, policyRepository.r); err != nil {
+		return err
+	}
+
+	if err := r.verifyPolicyExpiration(ctx, policyRepository.r, policyRef); err != nil {
+		return err
+	}
+
+	expectedTip, err := policy.VerifyRefUsingExternalPolicy(ctx, r.r, policyRepository.r, policyRef, target)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying if tip of reference matches expected value from RSL...")
+	if err := r.verifyRefTip(target, expectedTip); err != nil {
+		return err
+	}
+
+	slog.Debug("Verification successful!")
+	return nil
+}
+
 func (r *Repository) VerifyRefFromEntry(ctx context.Context, target, entryID string) error {
 	if !dev.InDevMode() {
 		return dev.ErrNotInDevMode
@@ -69,6 +310,15 @@ func (r *Repository) VerifyRefFromEntry(ctx context.Context, target, entryID str
 	}
 
 	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s' from entry '%s'", target, entryID))
+
+	if err := r.verifyRootTrustAnchors(ctx, r.r); err != nil {
+		return err
+	}
+
+	if err := r.verifyPolicyExpiration(ctx, r.r, policy.PolicyRef); err != nil {
+		return err
+	}
+
 	expectedTip, err := policy.VerifyRefFromEntry(ctx, r.r, target, plumbing.NewHash(entryID))
 	if err != nil {
 		return err
@@ -83,6 +333,86 @@ func (r *Repository) VerifyRefFromEntry(ctx context.Context, target, entryID str
 	return nil
 }
 
+// VerifyRefAtEntry verifies the target ref as it was when the specified RSL
+// entry was recorded, using the policy states in effect at that time. The
+// entry need not be for the target ref; for example, it may be the entry for a
+// release tag. The applicable policy is checked for expiry as of when the entry
+// was recorded rather than now. As the ref may have moved since, its current
+// tip is not compared against the RSL.
+func (r *Repository) VerifyRefAtEntry(ctx context.Context, target, entryID string) error {
+	var err error
+
+	slog.Debug("Identifying absolute reference path...")
+	target, err = gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		return err
+	}
+
+	entryHash, err := gitinterface.NewHash(entryID)
+	if err != nil {
+		return err
+	}
+
+	return r.retryAfterDeepening(ctx, target, func() error {
+		return r.verifyRefAtEntry(ctx, target, entryID, entryHash)
+	})
+}
+
+func (r *Repository) verifyRefAtEntry(ctx context.Context, target, entryID string, entryHash plumbing.Hash) error {
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s' as of entry '%s'", target, entryID))
+
+	if err := r.verifyRootTrustAnchors(ctx, r.r); err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying policy had not expired at entry...")
+	entryCommit, err := gitinterface.GetCommit(r.r, entryHash)
+	if err != nil {
+		return err
+	}
+	state, err := policy.LoadStateAtEntry(ctx, r.r, entryHash)
+	if err != nil {
+		return err
+	}
+	if err := state.VerifyExpiration(entryCommit.Committer.When, r.expirationGracePeriod); err != nil {
+		return err
+	}
+
+	if _, err := policy.VerifyRefAtEntry(ctx, r.r, target, entryHash); err != nil {
+		return err
+	}
+
+	slog.Debug("Verification successful!")
+	return nil
+}
+
+// FindEntriesOutOfPolicyScope returns the RSL reference entries for refs that
+// are not covered by any rule in the policy applicable at the time of each
+// entry. Such entries are not unauthorized, but it is up to the user to decide
+// whether they must be tolerated.
+func (r *Repository) FindEntriesOutOfPolicyScope(ctx context.Context) ([]*rsl.ReferenceEntry, error) {
+	slog.Debug("Identifying RSL entries for refs not covered by policy...")
+	return policy.FindEntriesOutOfPolicyScope(ctx, r.r)
+}
+
+// VerifyGittufRefsConsistency checks that the repository's policy reference and
+// RSL agree with each other. This is useful after fetching gittuf references
+// from a remote, to detect a remote that served references from different
+// histories.
+func (r *Repository) VerifyGittufRefsConsistency() error {
+	slog.Debug("Verifying consistency of policy reference and RSL...")
+	return policy.VerifyGittufRefsConsistency(r.r)
+}
+
+// VerifyRootChain verifies that each version of the root of trust recorded in
+// the repository's policy is signed by a threshold of the keys trusted in the
+// prior root version. If pinnedRootKeys is specified, the initial root must be
+// signed by at least pinnedThreshold of them.
+func (r *Repository) VerifyRootChain(ctx context.Context, pinnedRootKeys []*tuf.Key, pinnedThreshold int) error {
+	slog.Debug("Verifying chain of root metadata versions...")
+	return policy.VerifyRootChain(ctx, r.r, pinnedRootKeys, pinnedThreshold)
+}
+
 func (r *Repository) VerifyCommit(ctx context.Context, ids ...string) map[string]string {
 	slog.Debug("Verifying commit signature...")
 	return policy.VerifyCommit(ctx, r.r, ids...)
@@ -93,6 +423,22 @@ func (r *Repository) VerifyTag(ctx context.Context, ids []string) map[string]str
 	return policy.VerifyTag(ctx, r.r, ids)
 }
 
+// VerifyCommitWithResults verifies the signatures on the specified commits
+// using the policy applicable when each commit was recorded in the RSL. A
+// structured result is returned for each commit, in the order requested.
+func (r *Repository) VerifyCommitWithResults(ctx context.Context, ids ...string) []*policy.ObjectVerificationResult {
+	slog.Debug("Verifying commit signature...")
+	return policy.VerifyCommitObjects(ctx, r.r, ids...)
+}
+
+// VerifyTagWithResults verifies the specified tags and their RSL entries using
+// the policy applicable when each tag was recorded in the RSL. A structured
+// result is returned for each tag, in the order requested.
+func (r *Repository) VerifyTagWithResults(ctx context.Context, ids []string) []*policy.ObjectVerificationResult {
+	slog.Debug("Verifying tag signature...")
+	return policy.VerifyTagObjects(ctx, r.r, ids)
+}
+
 func (r *Repository) verifyRefTip(target string, expectedTip plumbing.Hash) error {
 	ref, err := r.r.Reference(plumbing.ReferenceName(target), true)
 	if err != nil {
@@ -9,9 +9,14 @@ import (
 
 	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,6 +78,173 @@ func TestVerifyRef(t *testing.T) {
 	assert.ErrorIs(t, err, ErrRefStateDoesNotMatchRSL)
 }
 
+func TestVerifyRefWithOptions(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	err := repo.VerifyRefWithOptions(testCtx, refName, nil)
+	assert.Nil(t, err)
+
+	var lastProgress policy.VerificationProgress
+	err = repo.VerifyRefWithOptions(testCtx, refName, &VerifyRefOptions{
+		Progress: func(progress policy.VerificationProgress) {
+			lastProgress = progress
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, refName, lastProgress.Target)
+	assert.Equal(t, entryID.String(), lastProgress.EntryID)
+	assert.Equal(t, lastProgress.TotalEntries, lastProgress.EntriesProcessed)
+
+	ctx, cancel := context.WithCancel(testCtx)
+	cancel()
+	err = repo.VerifyRefWithOptions(ctx, refName, &VerifyRefOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestVerifyRefUsingCache(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	err := repo.VerifyRefUsingCache(testCtx, "main")
+	assert.Nil(t, err)
+
+	_, err = repo.r.Reference(plumbing.ReferenceName(policy.VerificationCacheRef), true)
+	assert.Nil(t, err)
+
+	// Verification from the cache
+	err = repo.VerifyRefUsingCache(testCtx, "main")
+	assert.Nil(t, err)
+
+	err = repo.ClearVerificationCache()
+	assert.Nil(t, err)
+	_, err = repo.r.Reference(plumbing.ReferenceName(policy.VerificationCacheRef), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	err = repo.VerifyRefWithOptions(testCtx, "main", &VerifyRefOptions{UseCache: true})
+	assert.Nil(t, err)
+	_, err = repo.r.Reference(plumbing.ReferenceName(policy.VerificationCacheRef), true)
+	assert.Nil(t, err)
+
+	err = repo.VerifyRefUsingCache(testCtx, "refs/heads/unknown")
+	assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+}
+
+func TestVerifyRefWithReport(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	report, err := repo.VerifyRefWithReport(testCtx, "main")
+	assert.Nil(t, err)
+	assert.True(t, report.Verified)
+	assert.Equal(t, refName, report.Target)
+	assert.Len(t, report.Entries, 1)
+	assert.Equal(t, policy.EntryStatusVerified, report.Entries[0].Status)
+
+	// Move the ref away from the state recorded in the RSL
+	common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+
+	report, err = repo.VerifyRefWithReport(testCtx, "main")
+	assert.ErrorIs(t, err, ErrRefStateDoesNotMatchRSL)
+	assert.False(t, report.Verified)
+	assert.Equal(t, err.Error(), report.Error)
+}
+
+func TestVerifyRefIncremental(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	err := repo.VerifyRefIncremental(testCtx, "main")
+	assert.Nil(t, err)
+
+	_, err = repo.r.Reference(plumbing.ReferenceName(policy.VerificationMarkersRef), true)
+	assert.Nil(t, err)
+
+	// Verification resumes from the marker
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	err = repo.VerifyRefIncremental(testCtx, "main")
+	assert.Nil(t, err)
+
+	err = repo.VerifyRefIncremental(testCtx, "refs/heads/unknown")
+	assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+}
+
+func TestVerifyRefUsingExternalPolicy(t *testing.T) {
+	policyRepo := createTestRepositoryWithPolicy(t, "")
+
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	err = repo.VerifyRefUsingExternalPolicy(testCtx, refName, policyRepo, "")
+	assert.Nil(t, err)
+
+	err = repo.VerifyRefUsingExternalPolicy(testCtx, "main", policyRepo, policy.PolicyRef)
+	assert.Nil(t, err)
+
+	// The repository being verified has no policy of its own
+	err = repo.VerifyRef(testCtx, refName, true)
+	assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+
+	// Policy violation
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+	err = repo.VerifyRefUsingExternalPolicy(testCtx, refName, policyRepo, "")
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+}
+
 func TestVerifyRefFromEntry(t *testing.T) {
 	t.Setenv(dev.DevModeKey, "1")
 
@@ -147,3 +319,135 @@ func TestVerifyRefFromEntry(t *testing.T) {
 	err = repo.VerifyRefFromEntry(testCtx, refName, violatingEntryID.String())
 	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
 }
+
+func TestVerifyRefAtEntry(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// No policy violation
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	goodEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	// Policy violation
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	violatingEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+	// Add another commit that is not in the RSL
+	common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+
+	tests := map[string]struct {
+		target  string
+		entryID string
+		err     error
+	}{
+		"absolute ref, at non-violating": {
+			target:  "refs/heads/main",
+			entryID: goodEntryID.String(),
+		},
+		"relative ref, at non-violating": {
+			target:  "main",
+			entryID: goodEntryID.String(),
+		},
+		"absolute ref, at violating": {
+			target:  "refs/heads/main",
+			entryID: violatingEntryID.String(),
+			err:     policy.ErrUnauthorizedSignature,
+		},
+		"unknown ref": {
+			target:  "refs/heads/unknown",
+			entryID: goodEntryID.String(),
+			err:     rsl.ErrRSLEntryNotFound,
+		},
+		"invalid entry ID": {
+			target:  "refs/heads/main",
+			entryID: "invalid",
+			err:     gitinterface.ErrInvalidHash,
+		},
+	}
+
+	for name, test := range tests {
+		err := repo.VerifyRefAtEntry(testCtx, test.target, test.entryID)
+		if test.err != nil {
+			assert.ErrorIs(t, err, test.err, fmt.Sprintf("unexpected error in test '%s'", name))
+		} else {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+		}
+	}
+}
+
+func TestFindEntriesOutOfPolicyScope(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	unprotectedRefName := "refs/heads/feature"
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	entries, err := repo.FindEntriesOutOfPolicyScope(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, unprotectedRefName, 1, gpgKeyBytes)
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(unprotectedRefName, commitIDs[0]), gpgKeyBytes)
+
+	entries, err = repo.FindEntriesOutOfPolicyScope(testCtx)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, entryID, entries[0].ID)
+	}
+}
+
+func TestVerifyGittufRefsConsistency(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	err := repo.VerifyGittufRefsConsistency()
+	assert.Nil(t, err)
+
+	// Move the policy ref without recording it in the RSL
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(policy.PolicyRef, plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyGittufRefsConsistency()
+	assert.ErrorIs(t, err, policy.ErrInconsistentGittufRefs)
+}
+
+func TestVerifyRootChain(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("no pinned root keys", func(t *testing.T) {
+		err := repo.VerifyRootChain(testCtx, nil, 0)
+		assert.Nil(t, err)
+	})
+
+	t.Run("correct pinned root keys", func(t *testing.T) {
+		err := repo.VerifyRootChain(testCtx, []*tuf.Key{rootKey}, 1)
+		assert.Nil(t, err)
+	})
+
+	t.Run("incorrect pinned root keys", func(t *testing.T) {
+		err := repo.VerifyRootChain(testCtx, []*tuf.Key{targetsKey}, 1)
+		assert.ErrorIs(t, err, policy.ErrRootChainBroken)
+	})
+
+	t.Run("pinned threshold not met", func(t *testing.T) {
+		err := repo.VerifyRootChain(testCtx, []*tuf.Key{targetsKey, rootKey}, 2)
+		assert.ErrorIs(t, err, policy.ErrRootChainBroken)
+	})
+}
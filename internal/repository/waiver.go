@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-git/v5/plumbing"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var (
+	ErrWaiverExpiryInPast          = errors.New("verification waiver must expire in the future")
+	ErrWaiverJustificationRequired = errors.New("verification waiver must include a justification")
+)
+
+// AddVerificationWaiver signs a waiver accepting the failure of the specified
+// rule when verifying the RSL entry, until the waiver expires. The verifier
+// only accepts the waiver once it's signed by a threshold of the keys trusted
+// for the top level rule file. If a waiver with the same justification and
+// expiry exists for the rule, the signer's signature is added to it.
+// Otherwise, a new waiver replaces any existing one. Currently, this is limited
+// to developer mode.
+func (r *Repository) AddVerificationWaiver(ctx context.Context, signer sslibdsse.SignerVerifier, entryID, ruleName, justification string, expires time.Time, signCommit bool) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	if justification == "" {
+		return ErrWaiverJustificationRequired
+	}
+
+	if !expires.After(time.Now()) {
+		return ErrWaiverExpiryInPast
+	}
+
+	slog.Debug("Loading RSL entry...")
+	entry, err := rsl.GetEntry(r.r, plumbing.NewHash(entryID))
+	if err != nil {
+		return err
+	}
+	referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+	if !isReferenceEntry {
+		return rsl.ErrInvalidRSLEntry
+	}
+	refName := referenceEntry.RefName
+
+	slog.Debug("Loading current set of attestations...")
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	env, waiver, err := allAttestations.GetVerificationWaiverFor(r.r, refName, entryID, ruleName)
+	if err != nil && !errors.Is(err, attestations.ErrVerificationWaiverNotFound) {
+		return err
+	}
+	expiresString := expires.UTC().Format(time.RFC3339)
+	if waiver == nil || waiver.Justification != justification || waiver.Expires != expiresString {
+		slog.Debug("Creating new verification waiver...")
+		statement, err := attestations.NewVerificationWaiver(refName, entryID, ruleName, justification, expires)
+		if err != nil {
+			return err
+		}
+
+		env, err = dsse.CreateEnvelope(statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing verification waiver using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetVerificationWaiver(r.r, env, refName, entryID, ruleName); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add waiver for rule '%s' for RSL entry '%s' by '%s'", ruleName, entryID, keyID)
+
+	slog.Debug("Committing attestations...")
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// RemoveVerificationWaiver removes the signer's signature from the waiver for
+// the specified rule and RSL entry. The waiver is removed altogether once it
+// has no signatures left. Currently, this is limited to developer mode.
+func (r *Repository) RemoveVerificationWaiver(ctx context.Context, signer sslibdsse.SignerVerifier, entryID, ruleName string, signCommit bool) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	// Ensure only the keys that signed a waiver can remove their signatures
+	slog.Debug("Evaluating if key can sign...")
+	_, err := signer.Sign(ctx, nil)
+	if err != nil {
+		return errors.Join(ErrNotSigningKey, err)
+	}
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading RSL entry...")
+	entry, err := rsl.GetEntry(r.r, plumbing.NewHash(entryID))
+	if err != nil {
+		return err
+	}
+	referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+	if !isReferenceEntry {
+		return rsl.ErrInvalidRSLEntry
+	}
+	refName := referenceEntry.RefName
+
+	slog.Debug("Loading current set of attestations...")
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading verification waiver...")
+	env, _, err := allAttestations.GetVerificationWaiverFor(r.r, refName, entryID, ruleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Removing signature...")
+	newSignatures := []sslibdsse.Signature{}
+	for _, signature := range env.Signatures {
+		if signature.KeyID != keyID {
+			newSignatures = append(newSignatures, signature)
+		}
+	}
+
+	if len(newSignatures) == 0 {
+		if err := allAttestations.RemoveVerificationWaiver(refName, entryID, ruleName); err != nil {
+			return err
+		}
+	} else {
+		env.Signatures = newSignatures
+		if err := allAttestations.SetVerificationWaiver(r.r, env, refName, entryID, ruleName); err != nil {
+			return err
+		}
+	}
+
+	commitMessage := fmt.Sprintf("Remove waiver for rule '%s' for RSL entry '%s' by '%s'", ruleName, entryID, keyID)
+
+	slog.Debug("Committing attestations...")
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
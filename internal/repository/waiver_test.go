@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationWaiver(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The entry is signed by a key that isn't trusted for the ref
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+	err := repo.VerifyRef(testCtx, refName, false)
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expires := time.Now().Add(24 * time.Hour)
+
+	err = repo.AddVerificationWaiver(testCtx, targetsSigner, entryID.String(), "protect-main", "incident response", expires, false)
+	assert.ErrorIs(t, err, dev.ErrNotInDevMode)
+
+	t.Setenv(dev.DevModeKey, "1")
+
+	t.Run("waiver must have a justification", func(t *testing.T) {
+		err := repo.AddVerificationWaiver(testCtx, targetsSigner, entryID.String(), "protect-main", "", expires, false)
+		assert.ErrorIs(t, err, ErrWaiverJustificationRequired)
+	})
+
+	t.Run("waiver must expire in the future", func(t *testing.T) {
+		err := repo.AddVerificationWaiver(testCtx, targetsSigner, entryID.String(), "protect-main", "incident response", time.Now().Add(-time.Hour), false)
+		assert.ErrorIs(t, err, ErrWaiverExpiryInPast)
+	})
+
+	t.Run("waiver not signed by policy administrators", func(t *testing.T) {
+		if err := repo.AddVerificationWaiver(testCtx, rootSigner, entryID.String(), "protect-main", "incident response", expires, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := repo.VerifyRef(testCtx, refName, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+	})
+
+	t.Run("waiver for a different rule", func(t *testing.T) {
+		if err := repo.AddVerificationWaiver(testCtx, targetsSigner, entryID.String(), "protect-feature", "incident response", expires, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := repo.VerifyRef(testCtx, refName, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+	})
+
+	t.Run("valid waiver", func(t *testing.T) {
+		if err := repo.AddVerificationWaiver(testCtx, targetsSigner, entryID.String(), "protect-main", "incident response", expires, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := repo.VerifyRef(testCtx, refName, false)
+		assert.Nil(t, err)
+
+		err = repo.VerifyRef(testCtx, refName, true)
+		assert.Nil(t, err)
+
+		report, err := repo.VerifyRefWithReport(testCtx, refName)
+		assert.Nil(t, err)
+		assert.Len(t, report.Entries, 1)
+		assert.Equal(t, policy.EntryStatusWaived, report.Entries[0].Status)
+		assert.Equal(t, []string{"protect-main"}, report.Entries[0].WaivedRules)
+	})
+
+	t.Run("removed waiver", func(t *testing.T) {
+		// The waiver still has the root key's signature
+		if err := repo.RemoveVerificationWaiver(testCtx, targetsSigner, entryID.String(), "protect-main", false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := repo.VerifyRef(testCtx, refName, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+		if err := repo.RemoveVerificationWaiver(testCtx, rootSigner, entryID.String(), "protect-main", false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.RemoveVerificationWaiver(testCtx, rootSigner, entryID.String(), "protect-main", false)
+		assert.ErrorIs(t, err, attestations.ErrVerificationWaiverNotFound)
+	})
+}
+
+func TestVerificationWaiverWithMarkers(t *testing.T) {
+	t.Setenv(dev.DevModeKey, "1")
+
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	verifiedEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	// The entry is signed by a key that isn't trusted for the ref
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	waivedEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddVerificationWaiver(testCtx, targetsSigner, waivedEntryID.String(), "protect-main", "incident response", time.Now().Add(time.Hour), false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyRefIncremental(testCtx, refName)
+	assert.Nil(t, err)
+
+	// The marker is not moved past the waived entry
+	latestPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	markers, err := policy.LoadVerificationMarkers(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, verifiedEntryID.String(), markers.GetMarker(refName, latestPolicyEntry.ID).EntryID)
+
+	// Replace the waiver with one that has expired
+	statement, err := attestations.NewVerificationWaiver(refName, waivedEntryID.String(), "protect-main", "incident response", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := dsse.CreateEnvelope(statement)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err = dsse.SignEnvelope(testCtx, env, targetsSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allAttestations, err := attestations.LoadCurrentAttestations(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := allAttestations.SetVerificationWaiver(repo.r, env, refName, waivedEntryID.String(), "protect-main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := allAttestations.Commit(repo.r, "Expire waiver", false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyRefIncremental(testCtx, refName)
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+}
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	// ArchiveRef defines the Git namespace used to persist the RSL archive.
+	// The archive identifies a checkpoint entry in the RSL and records a
+	// snapshot of the state of each ref before the checkpoint. Once the RSL is
+	// archived, it is treated as starting at the checkpoint, and the snapshot
+	// is trusted as the state of the repository at that point. As a result,
+	// the archive is deliberately outside refs/gittuf/ so that it is local to
+	// the repository and is never synced with remotes.
+	ArchiveRef = "refs/gittuf-local/rsl-archive"
+
+	archiveTreeEntryName = "archive.json"
+	archiveCommitMessage = "Archive RSL entries"
+)
+
+var (
+	ErrRSLArchiveNotFound       = errors.New("RSL has not been archived")
+	ErrInvalidRSLArchive        = errors.New("invalid RSL archive structure")
+	ErrInvalidArchiveCheckpoint = errors.New("RSL archive checkpoint must be a reference entry in the RSL after the first entry and any existing checkpoint")
+	ErrRSLArchiveMismatch       = errors.New("RSL archive does not match the RSL")
+)
+
+// ArchivedReference records the latest reference entry for a ref before an
+// archive's checkpoint.
+type ArchivedReference struct {
+	EntryID  string `json:"entryID"`
+	TargetID string `json:"targetID"`
+	Number   uint64 `json:"number,omitempty"`
+	Deleted  bool   `json:"deleted,omitempty"`
+}
+
+// Archive records a checkpoint in the RSL along with the state of each ref as
+// of the checkpoint. Entries before the checkpoint are archived: they remain
+// reachable from the archive but are not walked when the RSL is traversed
+// from its first entry.
+type Archive struct {
+	// CheckpointEntryID is the ID of the first entry in the RSL that is not
+	// archived.
+	CheckpointEntryID string `json:"checkpointEntryID"`
+
+	// ArchivedTipID is the ID of the latest archived entry, i.e., the parent
+	// of the checkpoint entry.
+	ArchivedTipID string `json:"archivedTipID"`
+
+	// References records the latest reference entry before the checkpoint for
+	// each ref, including the gittuf namespaces.
+	References map[string]*ArchivedReference `json:"references"`
+}
+
+// NewArchive returns an archive that uses the specified entry as its
+// checkpoint. The snapshot of each ref's state is computed by walking all the
+// entries before the checkpoint. The archive is not persisted until Commit is
+// called.
+func NewArchive(repo *git.Repository, checkpointID plumbing.Hash) (*Archive, error) {
+	archivedTipID, err := getArchivedTipForCheckpoint(repo, checkpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new checkpoint must move forward from any existing checkpoint as
+	// entries that have been archived are no longer walked
+	existingArchive, err := LoadArchive(repo)
+	if err != nil && !errors.Is(err, ErrRSLArchiveNotFound) {
+		return nil, err
+	}
+	if existingArchive != nil {
+		existingCheckpointCommit, err := gitinterface.GetCommit(repo, plumbing.NewHash(existingArchive.CheckpointEntryID))
+		if err != nil {
+			return nil, err
+		}
+		knows, err := gitinterface.KnowsCommit(repo, archivedTipID, existingCheckpointCommit)
+		if err != nil {
+			return nil, err
+		}
+		if !knows {
+			return nil, ErrInvalidArchiveCheckpoint
+		}
+	}
+
+	references, err := getArchivedReferences(repo, archivedTipID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Archive{
+		CheckpointEntryID: checkpointID.String(),
+		ArchivedTipID:     archivedTipID.String(),
+		References:        references,
+	}, nil
+}
+
+// LoadArchive loads the RSL archive persisted in the repository. If the RSL
+// has not been archived, ErrRSLArchiveNotFound is returned.
+func LoadArchive(repo *git.Repository) (*Archive, error) {
+	ref, err := repo.Reference(plumbing.ReferenceName(ArchiveRef), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, ErrRSLArchiveNotFound
+		}
+		return nil, err
+	}
+
+	return LoadArchiveFromCommit(repo, ref.Hash())
+}
+
+// LoadArchiveFromCommit loads the RSL archive recorded in the specified
+// commit. This allows loading archives that are not persisted in ArchiveRef,
+// such as those imported from a bundle. The commit's parent must be the
+// archive's latest archived entry.
+func LoadArchiveFromCommit(repo *git.Repository, commitID plumbing.Hash) (*Archive, error) {
+	archiveCommit, err := gitinterface.GetCommit(repo, commitID)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveTree, err := gitinterface.GetTree(repo, archiveCommit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(archiveTree.Entries) != 1 || archiveTree.Entries[0].Name != archiveTreeEntryName {
+		return nil, ErrInvalidRSLArchive
+	}
+
+	contents, err := gitinterface.ReadBlob(repo, archiveTree.Entries[0].Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{}
+	if err := json.Unmarshal(contents, archive); err != nil {
+		return nil, err
+	}
+
+	if len(archiveCommit.ParentHashes) != 1 || archiveCommit.ParentHashes[0].String() != archive.ArchivedTipID {
+		return nil, ErrInvalidRSLArchive
+	}
+
+	return archive, nil
+}
+
+// GetReferenceEntry returns the archived reference entry for the specified
+// ref. If the ref has no entry before the checkpoint, nil is returned. The
+// entry is constructed from the archive, so it does not contain the entry's
+// signature.
+func (a *Archive) GetReferenceEntry(refName string) *ReferenceEntry {
+	reference, has := a.References[refName]
+	if !has {
+		return nil
+	}
+
+	return &ReferenceEntry{
+		ID:       plumbing.NewHash(reference.EntryID),
+		RefName:  refName,
+		TargetID: plumbing.NewHash(reference.TargetID),
+		Deleted:  reference.Deleted,
+		Number:   reference.Number,
+	}
+}
+
+// Validate checks that the archive's checkpoint is in the RSL, that the
+// archived entries lead up to the checkpoint, and that the snapshot of each
+// ref's state matches the archived entries.
+func (a *Archive) Validate(repo *git.Repository) error {
+	archivedTipID, err := getArchivedTipForCheckpoint(repo, plumbing.NewHash(a.CheckpointEntryID))
+	if err != nil {
+		return err
+	}
+	if archivedTipID.String() != a.ArchivedTipID {
+		return ErrRSLArchiveMismatch
+	}
+
+	references, err := getArchivedReferences(repo, archivedTipID)
+	if err != nil {
+		return err
+	}
+	if !maps.EqualFunc(references, a.References, func(r1, r2 *ArchivedReference) bool {
+		return *r1 == *r2
+	}) {
+		return ErrRSLArchiveMismatch
+	}
+
+	return nil
+}
+
+// Commit persists the archive in the repository. The commit's parent is the
+// latest archived entry so that the archived entries remain reachable from
+// ArchiveRef. The commit is never signed as the archive is local to the
+// repository.
+func (a *Archive) Commit(repo *git.Repository) error {
+	contents, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, contents)
+	if err != nil {
+		return err
+	}
+
+	treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{
+		{
+			Name: archiveTreeEntryName,
+			Mode: filemode.Regular,
+			Hash: blobID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = gitinterface.CommitWithParents(repo, treeID, []plumbing.Hash{plumbing.NewHash(a.ArchivedTipID)}, ArchiveRef, archiveCommitMessage, false)
+	return err
+}
+
+// getArchivedTipForCheckpoint returns the parent of the checkpoint entry after
+// checking that the checkpoint is a reference entry in the RSL with entries
+// before it.
+func getArchivedTipForCheckpoint(repo *git.Repository, checkpointID plumbing.Hash) (plumbing.Hash, error) {
+	checkpoint, err := GetEntry(repo, checkpointID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, isReferenceEntry := checkpoint.(*ReferenceEntry); !isReferenceEntry {
+		return plumbing.ZeroHash, ErrInvalidArchiveCheckpoint
+	}
+
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	checkpointCommit, err := gitinterface.GetCommit(repo, checkpointID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	knows, err := gitinterface.KnowsCommit(repo, latestEntry.GetID(), checkpointCommit)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if !knows {
+		return plumbing.ZeroHash, ErrInvalidArchiveCheckpoint
+	}
+
+	switch len(checkpointCommit.ParentHashes) {
+	case 0:
+		// There's nothing before the first entry to archive
+		return plumbing.ZeroHash, ErrInvalidArchiveCheckpoint
+	case 1:
+		return checkpointCommit.ParentHashes[0], nil
+	default:
+		return plumbing.ZeroHash, ErrRSLBranchDetected
+	}
+}
+
+// getArchivedReferences walks the RSL from the specified entry to the very
+// first entry and returns the latest reference entry for each ref.
+func getArchivedReferences(repo *git.Repository, archivedTipID plumbing.Hash) (map[string]*ArchivedReference, error) {
+	references := map[string]*ArchivedReference{}
+
+	iteratorT, err := GetEntry(repo, archivedTipID)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if entry, isReferenceEntry := iteratorT.(*ReferenceEntry); isReferenceEntry {
+			if _, has := references[entry.RefName]; !has {
+				references[entry.RefName] = &ArchivedReference{
+					EntryID:  entry.ID.String(),
+					TargetID: entry.TargetID.String(),
+					Number:   entry.Number,
+					Deleted:  entry.Deleted,
+				}
+			}
+		}
+
+		iteratorT, err = GetParentForEntry(repo, iteratorT)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				return references, nil
+			}
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	entryIDs := []plumbing.Hash{}
+	for _, refName := range []string{"first", "main", "feature", "main", "main"} {
+		if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entryIDs = append(entryIDs, latestEntry.GetID())
+	}
+
+	_, err = LoadArchive(repo)
+	assert.ErrorIs(t, err, ErrRSLArchiveNotFound)
+
+	t.Run("checkpoint cannot be first entry", func(t *testing.T) {
+		_, err := NewArchive(repo, entryIDs[0])
+		assert.ErrorIs(t, err, ErrInvalidArchiveCheckpoint)
+	})
+
+	t.Run("checkpoint cannot be annotation", func(t *testing.T) {
+		if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[1]}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		annotation, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = NewArchive(repo, annotation.GetID())
+		assert.ErrorIs(t, err, ErrInvalidArchiveCheckpoint)
+	})
+
+	archive, err := NewArchive(repo, entryIDs[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entryIDs[3].String(), archive.CheckpointEntryID)
+	assert.Equal(t, entryIDs[2].String(), archive.ArchivedTipID)
+	assert.Equal(t, 3, len(archive.References))
+	assert.Equal(t, entryIDs[1], archive.GetReferenceEntry("main").ID)
+	assert.Equal(t, entryIDs[2], archive.GetReferenceEntry("feature").ID)
+	assert.Nil(t, archive.GetReferenceEntry("unknown"))
+
+	if err := archive.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	loadedArchive, err := LoadArchive(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, archive, loadedArchive)
+	assert.Nil(t, loadedArchive.Validate(repo))
+
+	t.Run("first entry is checkpoint", func(t *testing.T) {
+		firstEntry, _, err := GetFirstEntry(repo)
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[3], firstEntry.ID)
+	})
+
+	t.Run("first entry for ref is archived entry", func(t *testing.T) {
+		firstEntry, _, err := GetFirstReferenceEntryForRef(repo, "main")
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[1], firstEntry.ID)
+
+		_, _, err = GetFirstReferenceEntryForRef(repo, "first")
+		assert.Nil(t, err)
+
+		_, _, err = GetFirstReferenceEntryForRef(repo, "unknown")
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
+
+	t.Run("new checkpoint cannot precede existing checkpoint", func(t *testing.T) {
+		_, err := NewArchive(repo, entryIDs[2])
+		assert.ErrorIs(t, err, ErrInvalidArchiveCheckpoint)
+	})
+
+	t.Run("tampered archive", func(t *testing.T) {
+		tamperedArchive := &Archive{
+			CheckpointEntryID: archive.CheckpointEntryID,
+			ArchivedTipID:     archive.ArchivedTipID,
+			References: map[string]*ArchivedReference{
+				"main": archive.References["main"],
+			},
+		}
+		assert.ErrorIs(t, tamperedArchive.Validate(repo), ErrRSLArchiveMismatch)
+
+		tamperedArchive = &Archive{
+			CheckpointEntryID: archive.CheckpointEntryID,
+			ArchivedTipID:     entryIDs[1].String(),
+			References:        archive.References,
+		}
+		assert.ErrorIs(t, tamperedArchive.Validate(repo), ErrRSLArchiveMismatch)
+	})
+
+	t.Run("checkpoint not in RSL", func(t *testing.T) {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(Ref), entryIDs[2])); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err := GetFirstEntry(repo)
+		assert.ErrorIs(t, err, ErrRSLArchiveMismatch)
+		assert.ErrorIs(t, archive.Validate(repo), ErrInvalidArchiveCheckpoint)
+	})
+}
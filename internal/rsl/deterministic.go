@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+const (
+	// DeterministicEntriesKey is the environment variable used to enable
+	// deterministic RSL entries.
+	DeterministicEntriesKey = "GITTUF_DETERMINISTIC_RSL"
+
+	// DeterministicEntryName and DeterministicEntryEmail are the identity
+	// used as the author and committer of deterministic RSL entries.
+	DeterministicEntryName  = "gittuf"
+	DeterministicEntryEmail = "gittuf@gittuf.dev"
+)
+
+// DeterministicEntries configures the RSL to create entries whose commit IDs
+// only depend on their contents and their parent entry. The author and
+// committer of each entry are set to DeterministicEntryName and
+// DeterministicEntryEmail, and its timestamps are set to one second after the
+// parent entry's committer timestamp, or the Unix epoch for the first entry.
+// The timestamps therefore increase monotonically along the RSL. This allows
+// entries recreated independently, such as on different mirrors when
+// reconciling diverged RSLs, to be compared by ID. Note that signed entries are
+// only reproducible if the signing scheme is deterministic. This applies to
+// entries created using CommitUsingSpecificKey as well, while entries created
+// using CommitWithCommitter record the specified committer instead.
+var DeterministicEntries = false
+
+// deterministicEntryEpoch is the timestamp of the first deterministic entry.
+var deterministicEntryEpoch = time.Unix(0, 0).UTC()
+
+// getEntryCommitOptions returns the options used to create the commit for an
+// entry on top of parentID, which may be the zero hash for the first entry. If
+// DeterministicEntries is not set, nil is returned so that the user's Git
+// config and the current time are used.
+func getEntryCommitOptions(repo *git.Repository, parentID plumbing.Hash) (*gitinterface.CommitOptions, error) {
+	if !DeterministicEntries {
+		return nil, nil
+	}
+
+	entryTime := deterministicEntryEpoch
+	if !parentID.IsZero() {
+		parent, err := gitinterface.GetCommit(repo, parentID)
+		if err != nil {
+			return nil, err
+		}
+		entryTime = parent.Committer.When.Add(time.Second).UTC()
+	}
+
+	return &gitinterface.CommitOptions{
+		AuthorName:     DeterministicEntryName,
+		AuthorEmail:    DeterministicEntryEmail,
+		AuthorTime:     entryTime,
+		CommitterName:  DeterministicEntryName,
+		CommitterEmail: DeterministicEntryEmail,
+		CommitterTime:  entryTime,
+	}, nil
+}
+
+// getEntryCommitOptionsAtTip returns the options used to create the commit for
+// an entry on top of the current tip of the RSL.
+func getEntryCommitOptionsAtTip(repo *git.Repository) (*gitinterface.CommitOptions, error) {
+	if !DeterministicEntries {
+		return nil, nil
+	}
+
+	tip, err := gitinterface.GetTip(repo, Ref)
+	if err != nil {
+		if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return nil, err
+		}
+		tip = plumbing.ZeroHash
+	}
+
+	return getEntryCommitOptions(repo, tip)
+}
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeterministicEntries(t *testing.T) {
+	DeterministicEntries = true
+	defer func() {
+		DeterministicEntries = false
+	}()
+
+	upstreamEntryID := plumbing.NewHash("abcdef1234567890")
+
+	createEntries := func(t *testing.T) (*git.Repository, []plumbing.Hash) {
+		t.Helper()
+
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		referenceEntry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+		if err := referenceEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		annotationEntry := NewAnnotationEntry([]plumbing.Hash{referenceEntry.ID}, true, annotationMessage)
+		if err := annotationEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		propagationEntry := NewPropagationEntry("refs/heads/main", plumbing.ZeroHash, "https://example.com/upstream", upstreamEntryID)
+		if err := propagationEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		return repo, []plumbing.Hash{referenceEntry.ID, annotationEntry.ID, propagationEntry.ID}
+	}
+
+	t.Run("normalized metadata", func(t *testing.T) {
+		repo, entryIDs := createEntries(t)
+
+		for i, entryID := range entryIDs {
+			commit, err := gitinterface.GetCommit(repo, entryID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedTime := time.Unix(int64(i), 0)
+			assert.Equal(t, DeterministicEntryName, commit.Author.Name)
+			assert.Equal(t, DeterministicEntryEmail, commit.Author.Email)
+			assert.True(t, expectedTime.Equal(commit.Author.When))
+			assert.Equal(t, DeterministicEntryName, commit.Committer.Name)
+			assert.Equal(t, DeterministicEntryEmail, commit.Committer.Email)
+			assert.True(t, expectedTime.Equal(commit.Committer.When))
+		}
+	})
+
+	t.Run("independently created entries are identical", func(t *testing.T) {
+		_, firstEntryIDs := createEntries(t)
+		_, secondEntryIDs := createEntries(t)
+
+		assert.Equal(t, firstEntryIDs, secondEntryIDs)
+	})
+
+	t.Run("recreated entries are identical", func(t *testing.T) {
+		_, entryIDs := createEntries(t)
+
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		referenceEntryID, err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).commitWithParent(repo, plumbing.ZeroHash, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entryIDs[0], referenceEntryID)
+
+		annotationEntryID, err := NewAnnotationEntry([]plumbing.Hash{referenceEntryID}, true, annotationMessage).commitWithParent(repo, referenceEntryID, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entryIDs[1], annotationEntryID)
+
+		propagationEntryID, err := NewPropagationEntry("refs/heads/main", plumbing.ZeroHash, "https://example.com/upstream", upstreamEntryID).commitWithParent(repo, annotationEntryID, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entryIDs[2], propagationEntryID)
+	})
+
+	t.Run("entries committed together", func(t *testing.T) {
+		firstRepo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		secondRepo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		firstEntry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+		secondEntry := NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash)
+		if err := firstEntry.Commit(firstRepo, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := secondEntry.Commit(firstRepo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		entries := []*ReferenceEntry{
+			NewReferenceEntry("refs/heads/main", plumbing.ZeroHash),
+			NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash),
+		}
+		if err := CommitReferenceEntries(secondRepo, entries, false); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, firstEntry.ID, entries[0].ID)
+		assert.Equal(t, secondEntry.ID, entries[1].ID)
+	})
+
+	t.Run("committer is retained", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+		if err := entry.CommitWithCommitter(repo, "jane.doe", "jane.doe@example.com", false); err != nil {
+			t.Fatal(err)
+		}
+
+		commit, err := gitinterface.GetCommit(repo, entry.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, DeterministicEntryName, commit.Author.Name)
+		assert.Equal(t, "jane.doe", commit.Committer.Name)
+		assert.Equal(t, "jane.doe@example.com", commit.Committer.Email)
+		assert.True(t, time.Unix(0, 0).Equal(commit.Committer.When))
+	})
+
+	t.Run("entries committed using specific key", func(t *testing.T) {
+		_, entryIDs := createEntries(t)
+
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		firstEntry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+		if err := firstEntry.CommitUsingSpecificKey(repo, artifacts.SSHED25519Private); err != nil {
+			t.Fatal(err)
+		}
+		secondEntry := NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash)
+		if err := secondEntry.CommitUsingSpecificKey(repo, artifacts.SSHED25519Private); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, entryID := range []plumbing.Hash{firstEntry.ID, secondEntry.ID} {
+			commit, err := gitinterface.GetCommit(repo, entryID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.NotEmpty(t, commit.PGPSignature)
+			assert.Equal(t, DeterministicEntryName, commit.Committer.Name)
+			assert.Equal(t, DeterministicEntryEmail, commit.Committer.Email)
+			assert.True(t, time.Unix(int64(i), 0).Equal(commit.Committer.When))
+		}
+
+		// Only the signature differs from an unsigned entry
+		commit, err := gitinterface.GetCommit(repo, firstEntry.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commit.PGPSignature = ""
+		unsignedEntryID, err := gitinterface.WriteCommit(repo, commit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entryIDs[0], unsignedEntryID)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		DeterministicEntries = false
+		defer func() {
+			DeterministicEntries = true
+		}()
+
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		opts, err := getEntryCommitOptionsAtTip(repo)
+		assert.Nil(t, err)
+		assert.Nil(t, opts)
+	})
+}
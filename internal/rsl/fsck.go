@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// FsckFinding records a problem identified in an existing RSL entry.
+type FsckFinding struct {
+	// EntryID is the ID of the entry the finding applies to.
+	EntryID plumbing.Hash
+
+	// Err identifies the type of problem with the entry.
+	Err error
+
+	// Message contains details about the problem.
+	Message string
+}
+
+// FsckRSL inspects every entry in the RSL and returns findings for entries that
+// could not be created today. This includes reference and propagation entries
+// recorded for the RSL reference itself, deletion entries for references in
+// the gittuf namespace, propagation entries that do not record their upstream
+// entry, and annotations with messages larger than MaxAnnotationMessageSize.
+// Findings are returned in order of occurrence.
+func FsckRSL(repo *git.Repository) ([]*FsckFinding, error) {
+	iterator, err := GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []*FsckFinding{}
+	for {
+		switch entry := iterator.(type) {
+		case *ReferenceEntry:
+			if IsRSLRef(entry.RefName) {
+				findings = append(findings, &FsckFinding{
+					EntryID: entry.ID,
+					Err:     ErrCannotRecordRSLRef,
+					Message: fmt.Sprintf("entry is for '%s'", entry.RefName),
+				})
+			} else if entry.Deleted && IsGittufRef(entry.RefName) {
+				findings = append(findings, &FsckFinding{
+					EntryID: entry.ID,
+					Err:     ErrCannotDeleteGittufRef,
+					Message: fmt.Sprintf("entry records the deletion of '%s'", entry.RefName),
+				})
+			}
+		case *PropagationEntry:
+			if IsRSLRef(entry.RefName) {
+				findings = append(findings, &FsckFinding{
+					EntryID: entry.ID,
+					Err:     ErrCannotRecordRSLRef,
+					Message: fmt.Sprintf("propagation entry is for '%s'", entry.RefName),
+				})
+			}
+			if entry.UpstreamRepository == "" || entry.UpstreamEntryID.IsZero() {
+				findings = append(findings, &FsckFinding{
+					EntryID: entry.ID,
+					Err:     ErrInvalidPropagationEntry,
+					Message: "propagation entry does not record the upstream repository and entry",
+				})
+			}
+		case *AnnotationEntry:
+			if err := checkAnnotationMessageSize(entry.Message); err != nil {
+				findings = append(findings, &FsckFinding{
+					EntryID: entry.ID,
+					Err:     ErrAnnotationMessageTooLarge,
+					Message: err.Error(),
+				})
+			}
+		}
+
+		iterator, err = GetParentForEntry(repo, iterator)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	// Reverse findings so that they're in order of occurrence
+	for i, j := 0, len(findings)-1; i < j; i, j = i+1, j-1 {
+		findings[i], findings[j] = findings[j], findings[i]
+	}
+
+	return findings, nil
+}
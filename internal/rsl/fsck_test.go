@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFsckRSL(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewAnnotationEntry([]plumbing.Hash{entry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := FsckRSL(repo)
+	assert.Nil(t, err)
+	assert.Empty(t, findings)
+
+	// Record an oversized annotation and an entry for the RSL ref, bypassing
+	// the checks performed when creating them
+	oversizedAnnotation := NewAnnotationEntry([]plumbing.Hash{entry.GetID()}, false, strings.Repeat("a", DefaultMaxAnnotationMessageSize+1))
+	MaxAnnotationMessageSize = 0
+	message, err := oversizedAnnotation.createCommitMessage()
+	MaxAnnotationMessageSize = DefaultMaxAnnotationMessageSize
+	if err != nil {
+		t.Fatal(err)
+	}
+	oversizedAnnotationID, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, message, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, _ = NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).createCommitMessage()
+	message = strings.Replace(message, "refs/heads/main", Ref, 1)
+	rslRefEntryID, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, message, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, _ = NewPropagationEntry("refs/heads/main", plumbing.ZeroHash, "https://example.com/upstream", entry.GetID()).createCommitMessage()
+	message = strings.Replace(message, "refs/heads/main", Ref, 1)
+	rslRefPropagationEntryID, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, message, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, _ = NewReferenceDeletionEntry("refs/heads/main").createCommitMessage()
+	message = strings.Replace(message, "refs/heads/main", "refs/gittuf/policy", 1)
+	gittufRefDeletionEntryID, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, message, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err = FsckRSL(repo)
+	assert.Nil(t, err)
+	if assert.Equal(t, 4, len(findings)) {
+		assert.Equal(t, oversizedAnnotationID, findings[0].EntryID)
+		assert.ErrorIs(t, findings[0].Err, ErrAnnotationMessageTooLarge)
+		assert.Equal(t, rslRefEntryID, findings[1].EntryID)
+		assert.ErrorIs(t, findings[1].Err, ErrCannotRecordRSLRef)
+		assert.Equal(t, rslRefPropagationEntryID, findings[2].EntryID)
+		assert.ErrorIs(t, findings[2].Err, ErrCannotRecordRSLRef)
+		assert.Equal(t, gittufRefDeletionEntryID, findings[3].EntryID)
+		assert.ErrorIs(t, findings[3].Err, ErrCannotDeleteGittufRef)
+	}
+}
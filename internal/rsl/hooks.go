@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrEntryHookExists      = errors.New("RSL entry hook with the same name is already registered")
+	ErrEntryRejectedByHook  = errors.New("RSL entry rejected by hook")
+	ErrEntryHookFailed      = errors.New("RSL entry was recorded but hook failed")
+	ErrInvalidEntryHookName = errors.New("RSL entry hook must have a name")
+)
+
+// EntryHook is the extension point for external systems that must be informed
+// of RSL entries created locally, for example to mirror entries to a
+// transparency log, send notifications, or enforce organization specific
+// checks. Hooks are invoked for entries created using Commit,
+// CommitWithCommitter, and CommitUsingSpecificKey, as well as for each entry
+// recorded using CommitReferenceEntries or RecordReplayedEntries. They are not
+// invoked for entries created using ReplayEntries until the entries are
+// recorded in the RSL, nor for simulated entries created using
+// ReferenceEntry.CommitWithoutHooks.
+type EntryHook interface {
+	// Name returns the unique name of the hook.
+	Name() string
+
+	// BeforeCommit is invoked once the entry's number has been set, before
+	// the entry is recorded in the RSL. If it returns an error, the entry is
+	// not recorded.
+	BeforeCommit(repo *git.Repository, entry Entry) error
+
+	// AfterCommit is invoked once the entry has been recorded in the RSL
+	// and its ID has been set. An error does not undo the entry.
+	AfterCommit(repo *git.Repository, entry Entry) error
+}
+
+var (
+	entryHooks      = []EntryHook{}
+	entryHooksMutex sync.RWMutex
+)
+
+// RegisterEntryHook adds hook to the set of hooks invoked when RSL entries are
+// created. Hooks are invoked in the order they are registered.
+func RegisterEntryHook(hook EntryHook) error {
+	if hook.Name() == "" {
+		return ErrInvalidEntryHookName
+	}
+
+	entryHooksMutex.Lock()
+	defer entryHooksMutex.Unlock()
+
+	for _, registered := range entryHooks {
+		if registered.Name() == hook.Name() {
+			return fmt.Errorf("%w: '%s'", ErrEntryHookExists, hook.Name())
+		}
+	}
+
+	entryHooks = append(entryHooks, hook)
+	return nil
+}
+
+// UnregisterEntryHook removes the hook with the specified name. It is a no-op
+// if no such hook is registered.
+func UnregisterEntryHook(name string) {
+	entryHooksMutex.Lock()
+	defer entryHooksMutex.Unlock()
+
+	hooks := make([]EntryHook, 0, len(entryHooks))
+	for _, hook := range entryHooks {
+		if hook.Name() != name {
+			hooks = append(hooks, hook)
+		}
+	}
+	entryHooks = hooks
+}
+
+// registeredEntryHooks returns a snapshot of the registered hooks, so hooks
+// can be invoked without holding the lock.
+func registeredEntryHooks() []EntryHook {
+	entryHooksMutex.RLock()
+	defer entryHooksMutex.RUnlock()
+
+	return append([]EntryHook{}, entryHooks...)
+}
+
+// runBeforeCommitHooks invokes the BeforeCommit method of each registered hook
+// for the entries, stopping at the first rejection.
+func runBeforeCommitHooks(repo *git.Repository, entries ...Entry) error {
+	for _, hook := range registeredEntryHooks() {
+		for _, entry := range entries {
+			if err := hook.BeforeCommit(repo, entry); err != nil {
+				return fmt.Errorf("%w: '%s': %w", ErrEntryRejectedByHook, hook.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runAfterCommitHooks invokes the AfterCommit method of each registered hook
+// for the entries. As the entries have already been recorded, every hook is
+// invoked even if an earlier one fails, and all failures are returned.
+func runAfterCommitHooks(repo *git.Repository, entries ...Entry) error {
+	errs := []error{}
+	for _, hook := range registeredEntryHooks() {
+		for _, entry := range entries {
+			if err := hook.AfterCommit(repo, entry); err != nil {
+				errs = append(errs, fmt.Errorf("%w: '%s': %w", ErrEntryHookFailed, hook.Name(), err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// AuditFileHookName is the name of the hook returned by NewAuditFileHook.
+const AuditFileHookName = "audit-file"
+
+// AuditFileHook is a reference EntryHook that appends a JSON record for each
+// RSL entry created to a local file, one record per line.
+type AuditFileHook struct {
+	path string
+}
+
+// AuditRecord is the record written by AuditFileHook for each RSL entry.
+type AuditRecord struct {
+	RecordedAt time.Time `json:"recordedAt"`
+	EntryID    string    `json:"entryID"`
+	Number     uint64    `json:"number,omitempty"`
+	Type       string    `json:"type"`
+
+	// Set for reference and propagation entries
+	RefName  string `json:"refName,omitempty"`
+	TargetID string `json:"targetID,omitempty"`
+
+	// Set for reference entries
+	ArtifactDigest string `json:"artifactDigest,omitempty"`
+	Deleted        bool   `json:"deleted,omitempty"`
+	Actor          string `json:"actor,omitempty"`
+	ActorURL       string `json:"actorURL,omitempty"`
+
+	// Set for propagation entries
+	UpstreamRepository string `json:"upstreamRepository,omitempty"`
+	UpstreamEntryID    string `json:"upstreamEntryID,omitempty"`
+
+	// Set for annotation entries
+	RSLEntryIDs []string `json:"rslEntryIDs,omitempty"`
+	Skip        bool     `json:"skip,omitempty"`
+	Unskip      bool     `json:"unskip,omitempty"`
+	Message     string   `json:"message,omitempty"`
+}
+
+// NewAuditFileHook returns an AuditFileHook that appends to the file at path,
+// creating it if necessary.
+func NewAuditFileHook(path string) *AuditFileHook {
+	return &AuditFileHook{path: path}
+}
+
+// Name returns AuditFileHookName.
+func (h *AuditFileHook) Name() string {
+	return AuditFileHookName
+}
+
+// BeforeCommit does not reject any entries.
+func (h *AuditFileHook) BeforeCommit(_ *git.Repository, _ Entry) error {
+	return nil
+}
+
+// AfterCommit appends a record for the entry to the audit file.
+func (h *AuditFileHook) AfterCommit(_ *git.Repository, entry Entry) error {
+	record := &AuditRecord{
+		RecordedAt: time.Now().UTC(),
+		EntryID:    entry.GetID().String(),
+	}
+
+	switch entry := entry.(type) {
+	case *ReferenceEntry:
+		record.Type = "reference"
+		record.Number = entry.Number
+		record.RefName = entry.RefName
+		record.TargetID = entry.TargetID.String()
+		record.ArtifactDigest = entry.ArtifactDigest
+		record.Deleted = entry.Deleted
+		record.Actor = entry.Actor
+		record.ActorURL = entry.ActorURL
+	case *AnnotationEntry:
+		record.Type = "annotation"
+		record.Number = entry.Number
+		record.RSLEntryIDs = hashesToStrings(entry.RSLEntryIDs)
+		record.Skip = entry.Skip
+		record.Unskip = entry.Unskip
+		record.Message = entry.Message
+	case *PropagationEntry:
+		record.Type = "propagation"
+		record.Number = entry.Number
+		record.RefName = entry.RefName
+		record.TargetID = entry.TargetID.String()
+		record.UpstreamRepository = entry.UpstreamRepository
+		record.UpstreamEntryID = entry.UpstreamEntryID.String()
+	default:
+		return ErrInvalidRSLEntry
+	}
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(recordBytes, '\n')); err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+
+	return file.Close()
+}
+
+func hashesToStrings(hashes []plumbing.Hash) []string {
+	strs := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		strs = append(strs, hash.String())
+	}
+
+	return strs
+}
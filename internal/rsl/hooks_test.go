@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+var errRejected = errors.New("rejected")
+
+type testEntryHook struct {
+	name         string
+	reject       bool
+	failAfter    bool
+	beforeCalled []uint64
+	afterCalled  []plumbing.Hash
+}
+
+func (h *testEntryHook) Name() string {
+	return h.name
+}
+
+func (h *testEntryHook) BeforeCommit(_ *git.Repository, entry Entry) error {
+	if h.reject {
+		return errRejected
+	}
+	h.beforeCalled = append(h.beforeCalled, getEntryNumber(entry))
+	return nil
+}
+
+func (h *testEntryHook) AfterCommit(_ *git.Repository, entry Entry) error {
+	h.afterCalled = append(h.afterCalled, entry.GetID())
+	if h.failAfter {
+		return errRejected
+	}
+	return nil
+}
+
+func TestRegisterEntryHook(t *testing.T) {
+	hook := &testEntryHook{name: "test"}
+	t.Cleanup(func() { UnregisterEntryHook(hook.name) })
+
+	err := RegisterEntryHook(hook)
+	assert.Nil(t, err)
+
+	err = RegisterEntryHook(&testEntryHook{name: "test"})
+	assert.ErrorIs(t, err, ErrEntryHookExists)
+
+	err = RegisterEntryHook(&testEntryHook{})
+	assert.ErrorIs(t, err, ErrInvalidEntryHookName)
+
+	UnregisterEntryHook(hook.name)
+	assert.Empty(t, registeredEntryHooks())
+}
+
+func TestEntryHooks(t *testing.T) {
+	t.Run("hooks invoked for entries", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hook := &testEntryHook{name: "test"}
+		if err := RegisterEntryHook(hook); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { UnregisterEntryHook(hook.name) })
+
+		entry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+		err = entry.Commit(repo, false)
+		assert.Nil(t, err)
+		assert.False(t, entry.ID.IsZero())
+
+		annotation := NewAnnotationEntry([]plumbing.Hash{entry.ID}, true, annotationMessage)
+		err = annotation.Commit(repo, false)
+		assert.Nil(t, err)
+
+		entries := NewReferenceEntries([]RefTarget{{RefName: "refs/heads/main"}, {RefName: "refs/heads/feature"}})
+		err = CommitReferenceEntries(repo, entries, false)
+		assert.Nil(t, err)
+
+		assert.Equal(t, []uint64{1, 2, 3, 4}, hook.beforeCalled)
+		assert.Equal(t, []plumbing.Hash{entry.ID, annotation.ID, entries[0].ID, entries[1].ID}, hook.afterCalled)
+
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entries[1].ID, latestEntry.GetID())
+	})
+
+	t.Run("hook rejects entry", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hook := &testEntryHook{name: "test", reject: true}
+		if err := RegisterEntryHook(hook); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { UnregisterEntryHook(hook.name) })
+
+		err = NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false)
+		assert.ErrorIs(t, err, ErrEntryRejectedByHook)
+		assert.ErrorIs(t, err, errRejected)
+
+		err = CommitReferenceEntries(repo, NewReferenceEntries([]RefTarget{{RefName: "refs/heads/main"}}), false)
+		assert.ErrorIs(t, err, ErrEntryRejectedByHook)
+
+		_, err = gitinterface.GetTip(repo, Ref)
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+	})
+
+	t.Run("hook fails after entry is recorded", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		failingHook := &testEntryHook{name: "failing", failAfter: true}
+		hook := &testEntryHook{name: "test"}
+		for _, h := range []*testEntryHook{failingHook, hook} {
+			if err := RegisterEntryHook(h); err != nil {
+				t.Fatal(err)
+			}
+			name := h.name
+			t.Cleanup(func() { UnregisterEntryHook(name) })
+		}
+
+		entry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+		err = entry.Commit(repo, false)
+		assert.ErrorIs(t, err, ErrEntryHookFailed)
+
+		// The entry is recorded and later hooks are still invoked
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entry.ID, latestEntry.GetID())
+		assert.Equal(t, []plumbing.Hash{entry.ID}, hook.afterCalled)
+	})
+
+	t.Run("hooks not invoked for simulated entries", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hook := &testEntryHook{name: "test", reject: true}
+		if err := RegisterEntryHook(hook); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { UnregisterEntryHook(hook.name) })
+
+		entry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+		err = entry.CommitWithoutHooks(repo, false)
+		assert.Nil(t, err)
+		assert.Empty(t, hook.afterCalled)
+
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entry.ID, latestEntry.GetID())
+	})
+}
+
+func TestAuditFileHook(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auditFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := RegisterEntryHook(NewAuditFileHook(auditFile)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { UnregisterEntryHook(AuditFileHookName) })
+
+	entry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+	if err := entry.Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	annotation := NewAnnotationEntry([]plumbing.Hash{entry.ID}, true, annotationMessage)
+	if err := annotation.Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(auditFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	records := []*AuditRecord{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &AuditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+
+	assert.Len(t, records, 2)
+
+	assert.Equal(t, "reference", records[0].Type)
+	assert.Equal(t, entry.ID.String(), records[0].EntryID)
+	assert.Equal(t, uint64(1), records[0].Number)
+	assert.Equal(t, "refs/heads/main", records[0].RefName)
+	assert.Equal(t, plumbing.ZeroHash.String(), records[0].TargetID)
+
+	assert.Equal(t, "annotation", records[1].Type)
+	assert.Equal(t, annotation.ID.String(), records[1].EntryID)
+	assert.Equal(t, uint64(2), records[1].Number)
+	assert.Equal(t, []string{entry.ID.String()}, records[1].RSLEntryIDs)
+	assert.True(t, records[1].Skip)
+	assert.Equal(t, annotationMessage, records[1].Message)
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"errors"
+
+	"github.com/gittuf/gittuf/internal/cache"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// UpdateIndex brings the RSL index persisted in the repository up to date with
+// the RSL and returns it. Only entries recorded since the index was last
+// updated are inspected. If the indexed entries are no longer part of the RSL,
+// for example because the local RSL was rolled back, the index is rebuilt.
+func UpdateIndex(repo *git.Repository) (*cache.RSLIndex, error) {
+	index, err := cache.LoadRSLIndex(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if latestEntry.GetID() == index.GetTip() {
+		return index, nil
+	}
+
+	newEntries := []Entry{}
+	iterator := latestEntry
+	foundTip := false
+	for {
+		if iterator.GetID() == index.GetTip() {
+			foundTip = true
+			break
+		}
+		newEntries = append(newEntries, iterator)
+
+		iterator, err = GetParentForEntry(repo, iterator)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	if !foundTip && !index.GetTip().IsZero() {
+		// The indexed entries are not part of the RSL anymore
+		index.Reset()
+	}
+
+	// Add entries in order of occurrence
+	for i := len(newEntries) - 1; i >= 0; i-- {
+		switch entry := newEntries[i].(type) {
+		case *ReferenceEntry:
+			index.AddEntry(entry.RefName, entry.ID, entry.Number)
+		case *AnnotationEntry:
+			index.AddEntry("", entry.ID, entry.Number)
+		case *PropagationEntry:
+			// Only reference entries are indexed by ref
+			index.AddEntry("", entry.ID, entry.Number)
+		}
+	}
+	index.SetTip(latestEntry.GetID())
+
+	if err := index.Commit(repo); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// GetFirstEntryUsingIndex returns the first entry in the RSL using the
+// specified index rather than walking the RSL. If the RSL has been archived,
+// the archive's checkpoint is returned instead.
+func GetFirstEntryUsingIndex(repo *git.Repository, index *cache.RSLIndex) (*ReferenceEntry, error) {
+	archive, err := LoadArchive(repo)
+	if err == nil {
+		return getReferenceEntry(repo, plumbing.NewHash(archive.CheckpointEntryID))
+	}
+	if !errors.Is(err, ErrRSLArchiveNotFound) {
+		return nil, err
+	}
+
+	entryID, has := index.GetFirstEntry()
+	if !has {
+		return nil, ErrRSLEntryNotFound
+	}
+
+	return getReferenceEntry(repo, entryID)
+}
+
+// GetLatestReferenceEntryForRefUsingIndex returns the latest reference entry
+// for the ref using the specified index rather than walking the RSL. Unlike
+// GetLatestReferenceEntryForRef, annotations for the entry are not returned.
+func GetLatestReferenceEntryForRefUsingIndex(repo *git.Repository, index *cache.RSLIndex, refName string) (*ReferenceEntry, error) {
+	entryID, has := index.GetLatestEntryForRef(refName)
+	if !has {
+		return nil, ErrRSLEntryNotFound
+	}
+
+	return getReferenceEntry(repo, entryID)
+}
+
+// loadCurrentIndex returns the RSL index persisted in the repository if it is
+// up to date with the RSL ending at tipID. Otherwise, nil is returned.
+func loadCurrentIndex(repo *git.Repository, tipID plumbing.Hash) (*cache.RSLIndex, error) {
+	index, err := cache.LoadRSLIndex(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if index.GetTip() != tipID {
+		return nil, nil
+	}
+
+	return index, nil
+}
+
+func getReferenceEntry(repo *git.Repository, entryID plumbing.Hash) (*ReferenceEntry, error) {
+	entry, err := GetEntry(repo, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	referenceEntry, isReferenceEntry := entry.(*ReferenceEntry)
+	if !isReferenceEntry {
+		return nil, ErrInvalidRSLEntry
+	}
+
+	return referenceEntry, nil
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/cache"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateIndex(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	firstEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	featureEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := UpdateIndex(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, featureEntry.GetID(), index.GetTip())
+
+	entry, err := GetFirstEntryUsingIndex(repo, index)
+	assert.Nil(t, err)
+	assert.Equal(t, firstEntry.GetID(), entry.ID)
+
+	entry, err = GetLatestReferenceEntryForRefUsingIndex(repo, index, "refs/heads/feature")
+	assert.Nil(t, err)
+	assert.Equal(t, featureEntry.GetID(), entry.ID)
+
+	_, err = GetLatestReferenceEntryForRefUsingIndex(repo, index, "refs/heads/unknown")
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+	// The index is updated incrementally
+	if err := NewAnnotationEntry([]plumbing.Hash{firstEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewReferenceEntry("refs/heads/main", plumbing.NewHash("abcdef1234567890")).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, err = UpdateIndex(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, latestEntry.GetID(), index.GetTip())
+	entry, err = GetLatestReferenceEntryForRefUsingIndex(repo, index, "refs/heads/main")
+	assert.Nil(t, err)
+	assert.Equal(t, latestEntry.GetID(), entry.ID)
+
+	persistedIndex, err := cache.LoadRSLIndex(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, latestEntry.GetID(), persistedIndex.GetTip())
+
+	// The index is used to look up entries by number when it is current
+	numberedEntry, err := GetEntryByNumber(repo, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, latestEntry.GetID(), numberedEntry.GetID())
+
+	// Roll back the RSL, the index must be rebuilt
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(Ref, featureEntry.GetID())); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err = UpdateIndex(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, featureEntry.GetID(), index.GetTip())
+	entry, err = GetLatestReferenceEntryForRefUsingIndex(repo, index, "refs/heads/main")
+	assert.Nil(t, err)
+	assert.Equal(t, firstEntry.GetID(), entry.ID)
+
+	_, err = GetEntryByNumber(repo, 4)
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// EntryType identifies the kinds of RSL entries returned by an EntryIterator.
+type EntryType int
+
+const (
+	AnyEntryType EntryType = iota
+	ReferenceEntryType
+	AnnotationEntryType
+	PropagationEntryType
+)
+
+// EntryIterator walks the RSL lazily from the latest entry to the first entry,
+// loading each entry only when it's requested. Entries can be filtered by ref
+// name and entry type.
+type EntryIterator struct {
+	repo      *git.Repository
+	refName   string
+	entryType EntryType
+
+	next Entry
+
+	// err is returned once the entries before a shallow clone boundary
+	// have been returned.
+	err error
+}
+
+// NewEntryIterator returns an EntryIterator that walks every entry in the RSL
+// starting from the latest entry.
+func NewEntryIterator(repo *git.Repository) (*EntryIterator, error) {
+	return NewEntryIteratorWithFilter(repo, "", AnyEntryType)
+}
+
+// NewEntryIteratorWithFilter returns an EntryIterator that starts from the
+// latest entry and only returns entries of the specified type. If refName is
+// set, only reference entries for the ref and annotations that refer to at
+// least one such reference entry are returned.
+func NewEntryIteratorWithFilter(repo *git.Repository, refName string, entryType EntryType) (*EntryIterator, error) {
+	iterator := &EntryIterator{
+		repo:      repo,
+		refName:   refName,
+		entryType: entryType,
+	}
+
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		if !errors.Is(err, ErrRSLEntryNotFound) {
+			return nil, err
+		}
+		// The RSL is empty, there's nothing to iterate over
+		return iterator, nil
+	}
+	iterator.next = latestEntry
+
+	return iterator, nil
+}
+
+// Next returns the next entry that matches the iterator's filter. When no more
+// entries remain, io.EOF is returned. If the RSL is truncated by a shallow
+// clone, gitinterface.ErrShallowBoundary is returned after the available
+// entries.
+func (i *EntryIterator) Next() (Entry, error) {
+	for i.next != nil {
+		entry := i.next
+
+		parent, err := GetParentForEntry(i.repo, entry)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrRSLEntryNotFound):
+			case errors.Is(err, gitinterface.ErrShallowBoundary):
+				i.err = err
+			default:
+				return nil, err
+			}
+			parent = nil
+		}
+		i.next = parent
+
+		matches, err := i.matches(entry)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			return entry, nil
+		}
+	}
+
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	return nil, io.EOF
+}
+
+// NextPage returns up to pageSize entries that match the iterator's filter.
+// Fewer entries are returned once the first entry in the RSL is reached. When
+// no more entries remain, io.EOF is returned.
+func (i *EntryIterator) NextPage(pageSize int) ([]Entry, error) {
+	entries := []Entry{}
+	for len(entries) < pageSize {
+		entry, err := i.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, io.EOF
+	}
+
+	return entries, nil
+}
+
+// Seek moves the iterator to the specified entry, so that the next call to
+// Next considers the entry first. This can be used to resume iteration from an
+// entry returned by a previous iterator.
+func (i *EntryIterator) Seek(entryID plumbing.Hash) error {
+	entry, err := GetEntry(i.repo, entryID)
+	if err != nil {
+		return err
+	}
+
+	i.next = entry
+	return nil
+}
+
+func (i *EntryIterator) matches(entry Entry) (bool, error) {
+	switch entry := entry.(type) {
+	case *ReferenceEntry:
+		if i.entryType != AnyEntryType && i.entryType != ReferenceEntryType {
+			return false, nil
+		}
+
+		return len(i.refName) == 0 || entry.RefName == i.refName, nil
+	case *PropagationEntry:
+		if i.entryType != AnyEntryType && i.entryType != PropagationEntryType {
+			return false, nil
+		}
+
+		return len(i.refName) == 0 || entry.RefName == i.refName, nil
+	case *AnnotationEntry:
+		if i.entryType != AnyEntryType && i.entryType != AnnotationEntryType {
+			return false, nil
+		}
+
+		if len(i.refName) == 0 {
+			return true, nil
+		}
+
+		for _, entryID := range entry.RSLEntryIDs {
+			referencedEntry, err := GetEntry(i.repo, entryID)
+			if err != nil {
+				return false, err
+			}
+
+			if referenceEntry, isReferenceEntry := referencedEntry.(*ReferenceEntry); isReferenceEntry && referenceEntry.RefName == i.refName {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	return false, nil
+}
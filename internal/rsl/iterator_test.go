@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"io"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryIterator(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("empty RSL", func(t *testing.T) {
+		iterator, err := NewEntryIterator(repo)
+		assert.Nil(t, err)
+
+		_, err = iterator.Next()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	entryIDs := []plumbing.Hash{}
+	for _, refName := range []string{"refs/heads/main", "refs/heads/feature", "refs/heads/main"} {
+		if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entryIDs = append(entryIDs, entry.GetID())
+	}
+
+	// Annotation for the feature branch's entry
+	if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[1]}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	annotation, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("all entries", func(t *testing.T) {
+		iterator, err := NewEntryIterator(repo)
+		assert.Nil(t, err)
+
+		expectedIDs := []plumbing.Hash{annotation.GetID(), entryIDs[2], entryIDs[1], entryIDs[0]}
+		for _, expectedID := range expectedIDs {
+			entry, err := iterator.Next()
+			assert.Nil(t, err)
+			assert.Equal(t, expectedID, entry.GetID())
+		}
+
+		_, err = iterator.Next()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		iterator, err := NewEntryIterator(repo)
+		assert.Nil(t, err)
+
+		entries, err := iterator.NextPage(3)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(entries))
+		assert.Equal(t, annotation.GetID(), entries[0].GetID())
+
+		entries, err = iterator.NextPage(3)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(entries))
+		assert.Equal(t, entryIDs[0], entries[0].GetID())
+
+		_, err = iterator.NextPage(3)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("filter by ref", func(t *testing.T) {
+		iterator, err := NewEntryIteratorWithFilter(repo, "refs/heads/main", AnyEntryType)
+		assert.Nil(t, err)
+
+		entries, err := iterator.NextPage(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, entryIDs[2], entries[0].GetID())
+		assert.Equal(t, entryIDs[0], entries[1].GetID())
+
+		iterator, err = NewEntryIteratorWithFilter(repo, "refs/heads/feature", AnyEntryType)
+		assert.Nil(t, err)
+
+		entries, err = iterator.NextPage(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, annotation.GetID(), entries[0].GetID())
+		assert.Equal(t, entryIDs[1], entries[1].GetID())
+	})
+
+	t.Run("filter by type", func(t *testing.T) {
+		iterator, err := NewEntryIteratorWithFilter(repo, "", AnnotationEntryType)
+		assert.Nil(t, err)
+
+		entries, err := iterator.NextPage(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(entries))
+		assert.Equal(t, annotation.GetID(), entries[0].GetID())
+
+		iterator, err = NewEntryIteratorWithFilter(repo, "", ReferenceEntryType)
+		assert.Nil(t, err)
+
+		entries, err = iterator.NextPage(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(entries))
+		for _, entry := range entries {
+			assert.IsType(t, &ReferenceEntry{}, entry)
+		}
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		iterator, err := NewEntryIterator(repo)
+		assert.Nil(t, err)
+
+		err = iterator.Seek(entryIDs[1])
+		assert.Nil(t, err)
+
+		entries, err := iterator.NextPage(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, entryIDs[1], entries[0].GetID())
+		assert.Equal(t, entryIDs[0], entries[1].GetID())
+
+		err = iterator.Seek(plumbing.NewHash("abcdef1234567890"))
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+const (
+	PropagationEntryHeader = "RSL Propagation Entry"
+	UpstreamRepositoryKey  = "upstreamRepository"
+	UpstreamEntryIDKey     = "upstreamEntryID"
+)
+
+var ErrInvalidPropagationEntry = errors.New("propagation entry must record the upstream repository and entry")
+
+// PropagationEntry records that a ref in the repository was set to the state
+// recorded in an upstream repository's RSL, for example when the upstream
+// repository is vendored. It implements the Entry interface.
+type PropagationEntry struct {
+	// ID contains the Git hash for the commit corresponding to the entry.
+	ID plumbing.Hash
+
+	// RefName contains the local Git reference the entry is for.
+	RefName string
+
+	// TargetID contains the Git hash for the object expected at RefName. It
+	// matches the target recorded in the upstream entry.
+	TargetID plumbing.Hash
+
+	// UpstreamRepository contains the location of the upstream repository.
+	UpstreamRepository string
+
+	// UpstreamEntryID contains the ID of the reference entry in the upstream
+	// repository's RSL that is propagated.
+	UpstreamEntryID plumbing.Hash
+
+	// Number contains the position of the entry in the RSL. It is set when
+	// the entry is committed.
+	Number uint64
+}
+
+// NewPropagationEntry returns a PropagationEntry object that records the
+// propagation of the upstream entry to the local ref.
+func NewPropagationEntry(refName string, targetID plumbing.Hash, upstreamRepository string, upstreamEntryID plumbing.Hash) *PropagationEntry {
+	return &PropagationEntry{
+		RefName:            refName,
+		TargetID:           targetID,
+		UpstreamRepository: upstreamRepository,
+		UpstreamEntryID:    upstreamEntryID,
+	}
+}
+
+func (p *PropagationEntry) GetID() plumbing.Hash {
+	return p.ID
+}
+
+// Commit creates a commit object in the RSL for the PropagationEntry.
+func (p *PropagationEntry) Commit(repo *git.Repository, sign bool) error {
+	number, err := nextEntryNumberAtTip(repo)
+	if err != nil {
+		return err
+	}
+	p.Number = number
+
+	message, err := p.createCommitMessage()
+	if err != nil {
+		return err
+	}
+
+	if err := runBeforeCommitHooks(repo, p); err != nil {
+		return err
+	}
+
+	opts, err := getEntryCommitOptionsAtTip(repo)
+	if err != nil {
+		return err
+	}
+
+	p.ID, err = gitinterface.CommitWithOptions(repo, gitinterface.EmptyTree(), Ref, message, opts, sign)
+	if err != nil {
+		return err
+	}
+
+	return runAfterCommitHooks(repo, p)
+}
+
+// commitWithParent creates a commit object for the PropagationEntry on top of
+// the specified parent RSL entry rather than the current tip of the RSL. The
+// RSL reference is not updated and the ID of the new entry is returned. Like
+// ReferenceEntry.commitWithParent, this is only used by ReplayEntries.
+func (p *PropagationEntry) commitWithParent(repo *git.Repository, parentID plumbing.Hash, sign bool) (plumbing.Hash, error) {
+	if err := checkParentIsRSLEntry(repo, parentID); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	number, err := nextEntryNumber(repo, parentID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	p.Number = number
+
+	message, err := p.createCommitMessage()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	opts, err := getEntryCommitOptions(repo, parentID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return gitinterface.CommitWithParentAndOptions(repo, gitinterface.EmptyTree(), parentID, message, opts, sign)
+}
+
+func (p *PropagationEntry) createCommitMessage() (string, error) {
+	if IsRSLRef(p.RefName) {
+		return "", ErrCannotRecordRSLRef
+	}
+	if p.UpstreamRepository == "" || p.UpstreamEntryID.IsZero() {
+		return "", ErrInvalidPropagationEntry
+	}
+
+	lines := []string{
+		PropagationEntryHeader,
+		"",
+		fmt.Sprintf("%s: %s", RefKey, p.RefName),
+		fmt.Sprintf("%s: %s", TargetIDKey, p.TargetID.String()),
+		fmt.Sprintf("%s: %s", UpstreamRepositoryKey, p.UpstreamRepository),
+		fmt.Sprintf("%s: %s", UpstreamEntryIDKey, p.UpstreamEntryID.String()),
+	}
+	if p.Number != 0 {
+		lines = append(lines, fmt.Sprintf("%s: %d", NumberKey, p.Number))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func parsePropagationEntryText(id plumbing.Hash, text string) (*PropagationEntry, error) {
+	lines := strings.Split(text, "\n")
+	if len(lines) < 6 {
+		return nil, ErrInvalidRSLEntry
+	}
+	lines = lines[2:]
+
+	entry := &PropagationEntry{ID: id}
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+
+		// Split only on the first separator as the upstream repository's
+		// location may include more
+		ls := strings.SplitN(l, ":", 2)
+		if len(ls) < 2 {
+			return nil, ErrInvalidRSLEntry
+		}
+
+		switch strings.TrimSpace(ls[0]) {
+		case RefKey:
+			entry.RefName = strings.TrimSpace(ls[1])
+		case TargetIDKey:
+			targetID, err := gitinterface.NewHash(strings.TrimSpace(ls[1]))
+			if err != nil {
+				return nil, ErrInvalidRSLEntry
+			}
+			entry.TargetID = targetID
+		case UpstreamRepositoryKey:
+			entry.UpstreamRepository = strings.TrimSpace(ls[1])
+		case UpstreamEntryIDKey:
+			upstreamEntryID, err := gitinterface.NewHash(strings.TrimSpace(ls[1]))
+			if err != nil {
+				return nil, ErrInvalidRSLEntry
+			}
+			entry.UpstreamEntryID = upstreamEntryID
+		case NumberKey:
+			number, err := strconv.ParseUint(strings.TrimSpace(ls[1]), 10, 64)
+			if err != nil {
+				return nil, ErrInvalidRSLEntry
+			}
+			entry.Number = number
+		}
+	}
+
+	return entry, nil
+}
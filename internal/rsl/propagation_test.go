@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropagationEntry(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	upstreamRepository := "https://example.com/upstream/repository"
+	upstreamEntryID := plumbing.NewHash("1234567890abcdef")
+	targetID := plumbing.NewHash("abcdef1234567890")
+
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewPropagationEntry("refs/heads/vendor", targetID, upstreamRepository, upstreamEntryID).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	propagationEntry, isPropagationEntry := entry.(*PropagationEntry)
+	if !isPropagationEntry {
+		t.Fatal("invalid entry type")
+	}
+	assert.Equal(t, "refs/heads/vendor", propagationEntry.RefName)
+	assert.Equal(t, targetID, propagationEntry.TargetID)
+	assert.Equal(t, upstreamRepository, propagationEntry.UpstreamRepository)
+	assert.Equal(t, upstreamEntryID, propagationEntry.UpstreamEntryID)
+	assert.Equal(t, uint64(2), propagationEntry.Number)
+
+	commitObj, err := gitinterface.GetCommit(repo, entry.GetID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %d", PropagationEntryHeader, RefKey, "refs/heads/vendor", TargetIDKey, targetID.String(), UpstreamRepositoryKey, upstreamRepository, UpstreamEntryIDKey, upstreamEntryID.String(), NumberKey, 2)
+	assert.Equal(t, expectedMessage, commitObj.Message)
+
+	t.Run("missing upstream details", func(t *testing.T) {
+		err := NewPropagationEntry("refs/heads/vendor", targetID, "", upstreamEntryID).Commit(repo, false)
+		assert.ErrorIs(t, err, ErrInvalidPropagationEntry)
+
+		err = NewPropagationEntry("refs/heads/vendor", targetID, upstreamRepository, plumbing.ZeroHash).Commit(repo, false)
+		assert.ErrorIs(t, err, ErrInvalidPropagationEntry)
+	})
+
+	t.Run("RSL ref", func(t *testing.T) {
+		err := NewPropagationEntry(Ref, targetID, upstreamRepository, upstreamEntryID).Commit(repo, false)
+		assert.ErrorIs(t, err, ErrCannotRecordRSLRef)
+	})
+
+	t.Run("commit with parent", func(t *testing.T) {
+		firstEntry, _, err := GetFirstEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entryID, err := NewPropagationEntry("refs/heads/vendor", targetID, upstreamRepository, upstreamEntryID).commitWithParent(repo, firstEntry.GetID(), false)
+		assert.Nil(t, err)
+
+		commitObj, err := gitinterface.GetCommit(repo, entryID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []plumbing.Hash{firstEntry.GetID()}, commitObj.ParentHashes)
+
+		// The RSL ref must be unchanged
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entry.GetID(), latestEntry.GetID())
+	})
+
+	t.Run("iterate propagation entries", func(t *testing.T) {
+		iterator, err := NewEntryIteratorWithFilter(repo, "", PropagationEntryType)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := iterator.NextPage(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(entries))
+		assert.Equal(t, entry.GetID(), entries[0].GetID())
+	})
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var ErrUnknownReplayBase = errors.New("entries can only be replayed on top of an entry in the RSL or a remote tracker of the RSL")
+
+// ReplayEntries recreates the entries, in order of occurrence, as a linear
+// chain of new entries on top of baseID, and returns the new entries in the
+// same order with their IDs and numbers set. Each new entry records the same
+// contents as the original entry, except that annotations referring to
+// replayed entries are updated to refer to the new entries. The new entries are
+// signed again if sign is set.
+//
+// baseID must be the zero hash, to start a new RSL, or an entry in the RSL or
+// in the remote tracker of the RSL for any remote. The RSL reference is not
+// updated, so the caller can verify the new chain before setting the RSL or a
+// remote tracker to its last entry. RecordReplayedEntries is used to record
+// the new entries in the RSL. Entry hooks are not invoked until then. This is
+// only intended for importing or reconstructing an RSL, such as when
+// reconciling diverged RSLs. In normal operation, entries must be recorded
+// using Commit.
+func ReplayEntries(repo *git.Repository, baseID plumbing.Hash, entries []Entry, sign bool) ([]Entry, error) {
+	if err := checkReplayBase(repo, baseID); err != nil {
+		return nil, err
+	}
+
+	newIDs := map[plumbing.Hash]plumbing.Hash{}
+	newEntries := make([]Entry, 0, len(entries))
+	parentID := baseID
+	for _, entry := range entries {
+		var (
+			newEntry Entry
+			newID    plumbing.Hash
+			err      error
+		)
+
+		// Entries are copied by value so that every field is preserved
+		switch entry := entry.(type) {
+		case *ReferenceEntry:
+			replayed := *entry
+			newID, err = replayed.commitWithParent(repo, parentID, sign)
+			replayed.ID = newID
+			newEntry = &replayed
+		case *PropagationEntry:
+			replayed := *entry
+			newID, err = replayed.commitWithParent(repo, parentID, sign)
+			replayed.ID = newID
+			newEntry = &replayed
+		case *AnnotationEntry:
+			replayed := *entry
+			replayed.RSLEntryIDs = make([]plumbing.Hash, 0, len(entry.RSLEntryIDs))
+			for _, entryID := range entry.RSLEntryIDs {
+				if newEntryID, isReplayed := newIDs[entryID]; isReplayed {
+					entryID = newEntryID
+				}
+				replayed.RSLEntryIDs = append(replayed.RSLEntryIDs, entryID)
+			}
+			newID, err = replayed.commitWithParent(repo, parentID, sign)
+			replayed.ID = newID
+			newEntry = &replayed
+		default:
+			err = ErrInvalidRSLEntry
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		newIDs[entry.GetID()] = newID
+		newEntries = append(newEntries, newEntry)
+		parentID = newID
+	}
+
+	return newEntries, nil
+}
+
+// RecordReplayedEntries sets the RSL reference to the last of the entries,
+// which are expected to be returned by ReplayEntries, provided the reference
+// is still at expectedTip. The registered entry hooks are invoked for each
+// entry before the reference is updated and again once it has been updated, as
+// for entries recorded using CommitReferenceEntries.
+func RecordReplayedEntries(repo *git.Repository, expectedTip plumbing.Hash, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := runBeforeCommitHooks(repo, entries...); err != nil {
+		return err
+	}
+
+	newRef := plumbing.NewHashReference(plumbing.ReferenceName(Ref), entries[len(entries)-1].GetID())
+	oldRef := plumbing.NewHashReference(plumbing.ReferenceName(Ref), expectedTip)
+	if err := repo.Storer.CheckAndSetReference(newRef, oldRef); err != nil {
+		return err
+	}
+
+	return runAfterCommitHooks(repo, entries...)
+}
+
+// checkReplayBase checks that baseID is the zero hash or an entry reachable
+// from the RSL reference or the remote tracker of the RSL for any remote.
+func checkReplayBase(repo *git.Repository, baseID plumbing.Hash) error {
+	if baseID.IsZero() {
+		return nil
+	}
+
+	refs, err := repo.References()
+	if err != nil {
+		return err
+	}
+	defer refs.Close()
+
+	queries := []gitinterface.AncestryQuery{}
+	isTip := false
+	if err := refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || ref.Hash().IsZero() || !IsRSLRef(ref.Name().String()) {
+			return nil
+		}
+
+		isTip = isTip || ref.Hash() == baseID
+		queries = append(queries, gitinterface.AncestryQuery{AncestorID: baseID, DescendantID: ref.Hash()})
+		return nil
+	}); err != nil {
+		return err
+	}
+	if isTip {
+		return nil
+	}
+
+	results, err := gitinterface.IsAncestorBatch(repo, queries)
+	if err != nil {
+		return err
+	}
+	for _, isAncestor := range results {
+		if isAncestor {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: '%s'", ErrUnknownReplayBase, baseID.String())
+}
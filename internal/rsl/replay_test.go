@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayEntries(t *testing.T) {
+	upstreamEntryID := plumbing.NewHash("1234567890abcdef")
+	targetID := plumbing.NewHash("abcdef1234567890")
+
+	// setup returns a repository whose RSL contains a reference entry, a
+	// deletion entry, a propagation entry, and an annotation for the first
+	// entry, in that order
+	setup := func(t *testing.T) (*git.Repository, []Entry) {
+		t.Helper()
+
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		referenceEntry := NewReferenceEntry("refs/heads/main", targetID)
+		referenceEntry.Actor = "alice"
+		if err := referenceEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		deletionEntry := NewReferenceDeletionEntry("refs/heads/feature")
+		if err := deletionEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		propagationEntry := NewPropagationEntry("refs/heads/vendor", targetID, "https://example.com/upstream", upstreamEntryID)
+		if err := propagationEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		annotationEntry := NewAnnotationEntry([]plumbing.Hash{referenceEntry.ID}, true, "revoke")
+		annotationEntry.Severity = SeverityWarning
+		if err := annotationEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		return repo, []Entry{referenceEntry, deletionEntry, propagationEntry, annotationEntry}
+	}
+
+	t.Run("replay on top of earlier entry", func(t *testing.T) {
+		repo, entries := setup(t)
+		tip, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The replayed entries fork from the first entry
+		newEntries, err := ReplayEntries(repo, entries[0].GetID(), entries[1:], false)
+		assert.Nil(t, err)
+		if !assert.Equal(t, 3, len(newEntries)) {
+			return
+		}
+
+		deletionEntry, err := GetEntry(repo, newEntries[0].GetID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, deletionEntry.(*ReferenceEntry).Deleted)
+		assert.Equal(t, uint64(2), deletionEntry.(*ReferenceEntry).Number)
+
+		parentEntry, err := GetParentForEntry(repo, deletionEntry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entries[0].GetID(), parentEntry.GetID())
+
+		propagationEntry, err := GetEntry(repo, newEntries[1].GetID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, upstreamEntryID, propagationEntry.(*PropagationEntry).UpstreamEntryID)
+
+		// The annotation refers to an entry that wasn't replayed
+		annotationEntry, err := GetEntry(repo, newEntries[2].GetID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []plumbing.Hash{entries[0].GetID()}, annotationEntry.(*AnnotationEntry).RSLEntryIDs)
+		assert.Equal(t, SeverityWarning, annotationEntry.(*AnnotationEntry).Severity)
+
+		// The RSL is not updated
+		currentTip, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tip.GetID(), currentTip.GetID())
+	})
+
+	t.Run("annotations refer to replayed entries", func(t *testing.T) {
+		repo, entries := setup(t)
+		tip, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		newEntries, err := ReplayEntries(repo, tip.GetID(), []Entry{entries[0], entries[3]}, false)
+		assert.Nil(t, err)
+		if !assert.Equal(t, 2, len(newEntries)) {
+			return
+		}
+
+		referenceEntry := newEntries[0].(*ReferenceEntry)
+		assert.NotEqual(t, entries[0].GetID(), referenceEntry.ID)
+		assert.Equal(t, "alice", referenceEntry.Actor)
+		assert.Equal(t, uint64(5), referenceEntry.Number)
+		assert.Equal(t, []plumbing.Hash{referenceEntry.ID}, newEntries[1].(*AnnotationEntry).RSLEntryIDs)
+	})
+
+	t.Run("replay on top of remote tracker", func(t *testing.T) {
+		repo, entries := setup(t)
+
+		newEntries, err := ReplayEntries(repo, plumbing.ZeroHash, entries[1:2], false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(RemoteTrackerRef("origin")), newEntries[0].GetID())); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ReplayEntries(repo, newEntries[0].GetID(), entries[2:3], false)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unknown base", func(t *testing.T) {
+		repo, entries := setup(t)
+
+		// The replayed entry is not referenced by the RSL or a remote tracker
+		newEntries, err := ReplayEntries(repo, plumbing.ZeroHash, entries[1:2], false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ReplayEntries(repo, newEntries[0].GetID(), entries[2:3], false)
+		assert.ErrorIs(t, err, ErrUnknownReplayBase)
+	})
+}
+
+func TestRecordReplayedEntries(t *testing.T) {
+	setup := func(t *testing.T) (*git.Repository, plumbing.Hash, []Entry) {
+		t.Helper()
+
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		firstEntry := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash)
+		if err := firstEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		secondEntry := NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash)
+		if err := secondEntry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Replay the second entry on top of an entry recorded remotely
+		remoteEntry := NewReferenceEntry("refs/heads/remote", plumbing.ZeroHash)
+		remoteEntryID, err := remoteEntry.commitWithParent(repo, firstEntry.ID, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(RemoteTrackerRef("origin")), remoteEntryID)); err != nil {
+			t.Fatal(err)
+		}
+
+		newEntries, err := ReplayEntries(repo, remoteEntryID, []Entry{secondEntry}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return repo, secondEntry.ID, newEntries
+	}
+
+	t.Run("entries recorded", func(t *testing.T) {
+		repo, tipID, newEntries := setup(t)
+
+		hook := &testEntryHook{name: "test"}
+		if err := RegisterEntryHook(hook); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { UnregisterEntryHook(hook.name) })
+
+		err := RecordReplayedEntries(repo, tipID, newEntries)
+		assert.Nil(t, err)
+
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, newEntries[0].GetID(), latestEntry.GetID())
+		assert.Equal(t, []uint64{3}, hook.beforeCalled)
+		assert.Equal(t, []plumbing.Hash{newEntries[0].GetID()}, hook.afterCalled)
+	})
+
+	t.Run("hook rejects entries", func(t *testing.T) {
+		repo, tipID, newEntries := setup(t)
+
+		hook := &testEntryHook{name: "test", reject: true}
+		if err := RegisterEntryHook(hook); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { UnregisterEntryHook(hook.name) })
+
+		err := RecordReplayedEntries(repo, tipID, newEntries)
+		assert.ErrorIs(t, err, ErrEntryRejectedByHook)
+
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tipID, latestEntry.GetID())
+	})
+
+	t.Run("RSL moved since replay", func(t *testing.T) {
+		repo, _, newEntries := setup(t)
+
+		err := RecordReplayedEntries(repo, plumbing.ZeroHash, newEntries)
+		assert.NotNil(t, err)
+	})
+}
@@ -3,9 +3,15 @@
 package rsl
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gittuf/gittuf/internal/gitinterface"
@@ -25,21 +31,60 @@ const (
 	EndMessage                 = "-----END MESSAGE-----"
 	EntryIDKey                 = "entryID"
 	SkipKey                    = "skip"
-
-	remoteTrackerRef       = "refs/remotes/%s/gittuf/reference-state-log"
-	gittufNamespacePrefix  = "refs/gittuf/"
-	gittufPolicyStagingRef = "refs/gittuf/policy-staging"
+	UnskipKey                  = "unskip"
+	SeverityKey                = "severity"
+	ArtifactDigestKey          = "artifactDigest"
+	NumberKey                  = "number"
+	DeletedKey                 = "deleted"
+	ActorKey                   = "actor"
+	ActorURLKey                = "actorURL"
+
+	// DefaultMaxEntriesInRange is the default limit on the number of reference
+	// entries returned by GetReferenceEntriesInRangeWithLimit.
+	DefaultMaxEntriesInRange = 10000
+
+	// DefaultMaxAnnotationMessageSize is the default limit in bytes on the
+	// size of an annotation's message.
+	DefaultMaxAnnotationMessageSize = 4096
+
+	remoteTrackerRef         = "refs/remotes/%s/gittuf/reference-state-log"
+	gittufNamespacePrefix    = "refs/gittuf/"
+	gittufPolicyStagingRef   = "refs/gittuf/policy-staging"
+	gittufPolicyProposalsRef = "refs/gittuf/policy-proposals"
+
+	artifactDigestAlgorithm = "sha256"
 )
 
 var (
-	ErrRSLExists               = errors.New("cannot initialize RSL namespace as it exists already")
-	ErrRSLEntryNotFound        = errors.New("unable to find RSL entry")
-	ErrRSLBranchDetected       = errors.New("potential RSL branch detected, entry has more than one parent")
-	ErrInvalidRSLEntry         = errors.New("RSL entry has invalid format or is of unexpected type")
-	ErrRSLEntryDoesNotMatchRef = errors.New("RSL entry does not match requested ref")
-	ErrNoRecordOfCommit        = errors.New("commit has not been encountered before")
+	ErrRSLExists                 = errors.New("cannot initialize RSL namespace as it exists already")
+	ErrRSLEntryNotFound          = errors.New("unable to find RSL entry")
+	ErrRSLBranchDetected         = errors.New("potential RSL branch detected, entry has more than one parent")
+	ErrInvalidRSLEntry           = errors.New("RSL entry has invalid format or is of unexpected type")
+	ErrRSLEntryDoesNotMatchRef   = errors.New("RSL entry does not match requested ref")
+	ErrNoRecordOfCommit          = errors.New("commit has not been encountered before")
+	ErrNonLinearRSL              = errors.New("entry would create a non-linear RSL")
+	ErrInvalidRSLChain           = errors.New("RSL entries do not form a valid chain")
+	ErrInvalidArtifactDigest     = errors.New("artifact digest must be of the form 'sha256:<hex digest>'")
+	ErrNoArtifactDigest          = errors.New("RSL entry does not record an artifact digest")
+	ErrArtifactDigestMismatch    = errors.New("artifact does not match digest recorded in RSL entry")
+	ErrRangeTooLarge             = errors.New("requested RSL range contains more entries than permitted, result is partial")
+	ErrCannotRecordRSLRef        = errors.New("cannot record RSL entry for the RSL reference or its remote tracker")
+	ErrAnnotationMessageTooLarge = errors.New("annotation message exceeds maximum permitted size")
+	ErrInvalidEntryNumber        = errors.New("RSL entry numbers start at 1")
+	ErrEntryNumberMismatch       = errors.New("RSL entry number does not match its position in the RSL")
+	ErrConflictingSkipStatus     = errors.New("annotation cannot both skip and unskip entries")
+	ErrInvalidDeletionEntry      = errors.New("deletion entry must have the zero hash as its target")
+	ErrCannotDeleteGittufRef     = errors.New("cannot record deletion of a reference in the gittuf namespace")
+	ErrInvalidActor              = errors.New("actor must be a single line without leading or trailing whitespace")
+	ErrInvalidActorURL           = errors.New("actor URL must be an absolute URL")
 )
 
+// MaxAnnotationMessageSize is the maximum size in bytes of the message in a new
+// annotation. Annotations with larger messages cannot be created, as the
+// message is embedded in the RSL and would bloat it. Setting it to zero or a
+// negative value disables the limit.
+var MaxAnnotationMessageSize = DefaultMaxAnnotationMessageSize
+
 // InitializeNamespace creates a git ref for the reference state log. Initially,
 // the entry has a zero hash.
 func InitializeNamespace(repo *git.Repository) error {
@@ -67,6 +112,30 @@ func RemoteTrackerRef(remote string) string {
 	return fmt.Sprintf(remoteTrackerRef, remote)
 }
 
+// IsRSLRef returns true if refName is the RSL reference or the remote tracker
+// reference of the RSL for any remote. RSL entries must not be recorded for
+// these references.
+func IsRSLRef(refName string) bool {
+	if refName == Ref {
+		return true
+	}
+
+	remoteName, found := strings.CutPrefix(refName, "refs/remotes/")
+	if !found {
+		return false
+	}
+	remoteName, found = strings.CutSuffix(remoteName, "/gittuf/reference-state-log")
+	return found && len(remoteName) != 0
+}
+
+// IsGittufRef returns true if refName is in the gittuf namespace, such as the
+// policy or attestations references. The deletion of these references must not
+// be recorded in the RSL, as gittuf relies on their latest entries to load the
+// policy and attestations.
+func IsGittufRef(refName string) bool {
+	return strings.HasPrefix(refName, gittufNamespacePrefix)
+}
+
 // Entry is the abstract representation of an object in the RSL.
 type Entry interface {
 	GetID() plumbing.Hash
@@ -85,6 +154,31 @@ type ReferenceEntry struct {
 
 	// TargetID contains the Git hash for the object expected at RefName.
 	TargetID plumbing.Hash
+
+	// ArtifactDigest optionally binds the entry to an external artifact, such
+	// as a release tarball built from TargetID. It is of the form
+	// 'sha256:<hex digest>'.
+	ArtifactDigest string
+
+	// Deleted indicates that the entry records the deletion of RefName. The
+	// TargetID of a deletion entry is always the zero hash.
+	Deleted bool
+
+	// Actor optionally identifies who or what pushed the reference, such as
+	// a forge username or a CI workflow. It is informational only and is
+	// not considered during verification, which relies on the entry's
+	// signature.
+	Actor string
+
+	// ActorURL optionally links to the context of the push, such as the URL
+	// of the CI job that recorded the entry.
+	ActorURL string
+
+	// Number contains the position of the entry in the RSL, starting at 1
+	// for the first entry. It is set when the entry is committed. Entries
+	// recorded before numbering was introduced have no number, indicated by
+	// zero.
+	Number uint64
 }
 
 // NewReferenceEntry returns a ReferenceEntry object for a normal RSL entry.
@@ -92,53 +186,439 @@ func NewReferenceEntry(refName string, targetID plumbing.Hash) *ReferenceEntry {
 	return &ReferenceEntry{RefName: refName, TargetID: targetID}
 }
 
+// NewReferenceEntryWithArtifactDigest returns a ReferenceEntry object that
+// also records the digest of an external artifact for the reference's target.
+func NewReferenceEntryWithArtifactDigest(refName string, targetID plumbing.Hash, artifactDigest string) *ReferenceEntry {
+	return &ReferenceEntry{RefName: refName, TargetID: targetID, ArtifactDigest: artifactDigest}
+}
+
+// NewReferenceDeletionEntry returns a ReferenceEntry object that records the
+// deletion of the specified ref.
+func NewReferenceDeletionEntry(refName string) *ReferenceEntry {
+	return &ReferenceEntry{RefName: refName, TargetID: plumbing.ZeroHash, Deleted: true}
+}
+
+// RefTarget identifies a Git reference and the target to be recorded for it
+// in the RSL.
+type RefTarget struct {
+	RefName  string
+	TargetID plumbing.Hash
+}
+
+// NewReferenceEntries returns ReferenceEntry objects for each of the specified
+// refs, in the same order. The entries can be recorded in the RSL together
+// using CommitReferenceEntries.
+func NewReferenceEntries(refTargets []RefTarget) []*ReferenceEntry {
+	entries := make([]*ReferenceEntry, 0, len(refTargets))
+	for _, refTarget := range refTargets {
+		entries = append(entries, NewReferenceEntry(refTarget.RefName, refTarget.TargetID))
+	}
+
+	return entries
+}
+
+// CommitReferenceEntries creates commit objects in the RSL for each of the
+// entries, in order. The tip of the RSL is resolved once, and each entry is
+// committed on top of the previous one. The RSL reference is updated only after
+// all the entries have been created, so a failure leaves the RSL unchanged. On
+// success, each entry's ID and number are set. Registered entry hooks are
+// invoked for each entry before any entry is created and again once the RSL
+// reference has been updated.
+func CommitReferenceEntries(repo *git.Repository, entries []*ReferenceEntry, sign bool) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tip, err := gitinterface.GetTip(repo, Ref)
+	if err != nil {
+		if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return err
+		}
+		tip = plumbing.ZeroHash
+	}
+
+	number, err := nextEntryNumber(repo, tip)
+	if err != nil {
+		return err
+	}
+
+	// The empty tree is shared by all entries, so it's computed only once
+	emptyTreeID := gitinterface.EmptyTree()
+
+	hookEntries := make([]Entry, 0, len(entries))
+	for i, entry := range entries {
+		entry.Number = number + uint64(i)
+		hookEntries = append(hookEntries, entry)
+	}
+	if err := runBeforeCommitHooks(repo, hookEntries...); err != nil {
+		return err
+	}
+
+	entryIDs := make([]plumbing.Hash, 0, len(entries))
+	parentID := tip
+	for _, entry := range entries {
+		message, err := entry.createCommitMessage()
+		if err != nil {
+			return err
+		}
+
+		opts, err := getEntryCommitOptions(repo, parentID)
+		if err != nil {
+			return err
+		}
+
+		entryID, err := gitinterface.CommitWithParentAndOptions(repo, emptyTreeID, parentID, message, opts, sign)
+		if err != nil {
+			return err
+		}
+
+		entryIDs = append(entryIDs, entryID)
+		parentID = entryID
+	}
+
+	// If the RSL doesn't exist yet, tip is the zero hash, which is also how a
+	// missing ref is read, so the RSL isn't overwritten if it's created in the
+	// meantime
+	oldRef := plumbing.NewHashReference(plumbing.ReferenceName(Ref), tip)
+	if err := repo.Storer.CheckAndSetReference(plumbing.NewHashReference(plumbing.ReferenceName(Ref), parentID), oldRef); err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		entry.ID = entryIDs[i]
+	}
+
+	return runAfterCommitHooks(repo, hookEntries...)
+}
+
 func (e *ReferenceEntry) GetID() plumbing.Hash {
 	return e.ID
 }
 
 // Commit creates a commit object in the RSL for the ReferenceEntry.
 func (e *ReferenceEntry) Commit(repo *git.Repository, sign bool) error {
-	message, _ := e.createCommitMessage() // we have an error return for annotations, always nil here
+	return e.commit(repo, sign, true)
+}
 
-	_, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, message, sign)
-	return err
+// CommitWithoutHooks creates a commit object in the RSL for the
+// ReferenceEntry, like Commit, but without invoking the registered entry hooks.
+// This is only intended for simulating an entry in an RSL that is discarded,
+// such as an in-memory overlay of the repository, so that hooks aren't
+// informed of an entry that is never recorded.
+func (e *ReferenceEntry) CommitWithoutHooks(repo *git.Repository, sign bool) error {
+	return e.commit(repo, sign, false)
+}
+
+func (e *ReferenceEntry) commit(repo *git.Repository, sign, runHooks bool) error {
+	if err := e.setNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := e.createCommitMessage()
+	if err != nil {
+		return err
+	}
+
+	if runHooks {
+		if err := runBeforeCommitHooks(repo, e); err != nil {
+			return err
+		}
+	}
+
+	opts, err := getEntryCommitOptionsAtTip(repo)
+	if err != nil {
+		return err
+	}
+
+	e.ID, err = gitinterface.CommitWithOptions(repo, gitinterface.EmptyTree(), Ref, message, opts, sign)
+	if err != nil {
+		return err
+	}
+
+	if !runHooks {
+		return nil
+	}
+	return runAfterCommitHooks(repo, e)
+}
+
+// CommitWithCommitter creates a commit object in the RSL for the
+// ReferenceEntry, like Commit, but with the specified committer name and email.
+// This is useful for automated workflows that record entries using a
+// consistent identity. The committer is not considered when verifying the
+// entry, which is bound to the signing key instead.
+func (e *ReferenceEntry) CommitWithCommitter(repo *git.Repository, committerName, committerEmail string, sign bool) error {
+	if err := e.setNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := e.createCommitMessage()
+	if err != nil {
+		return err
+	}
+
+	if err := runBeforeCommitHooks(repo, e); err != nil {
+		return err
+	}
+
+	opts, err := getEntryCommitOptionsAtTip(repo)
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &gitinterface.CommitOptions{}
+	}
+	opts.CommitterName = committerName
+	opts.CommitterEmail = committerEmail
+
+	e.ID, err = gitinterface.CommitWithOptions(repo, gitinterface.EmptyTree(), Ref, message, opts, sign)
+	if err != nil {
+		return err
+	}
+
+	return runAfterCommitHooks(repo, e)
+}
+
+// commitWithParent creates a commit object for the ReferenceEntry on top of
+// the specified parent RSL entry rather than the current tip of the RSL. The
+// RSL reference is not updated and the ID of the new entry is returned. This
+// is the low level primitive used by ReplayEntries to build a linear chain of
+// entries. In normal operation, Commit must be used instead.
+func (e *ReferenceEntry) commitWithParent(repo *git.Repository, parentID plumbing.Hash, sign bool) (plumbing.Hash, error) {
+	if err := checkParentIsRSLEntry(repo, parentID); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	number, err := nextEntryNumber(repo, parentID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	e.Number = number
+
+	message, err := e.createCommitMessage()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	opts, err := getEntryCommitOptions(repo, parentID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return gitinterface.CommitWithParentAndOptions(repo, gitinterface.EmptyTree(), parentID, message, opts, sign)
 }
 
 // CommitUsingSpecificKey creates a commit object in the RSL for the
 // ReferenceEmpty. The commit is signed using the provided PEM encoded SSH or
 // GPG private key. This is only intended for use in gittuf's developer mode.
 func (e *ReferenceEntry) CommitUsingSpecificKey(repo *git.Repository, signingKeyBytes []byte) error {
-	message, _ := e.createCommitMessage() // we have an error return for annotations, always nil here
+	if err := e.setNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := e.createCommitMessage()
+	if err != nil {
+		return err
+	}
+
+	if err := runBeforeCommitHooks(repo, e); err != nil {
+		return err
+	}
+
+	opts, err := getEntryCommitOptionsAtTip(repo)
+	if err != nil {
+		return err
+	}
+
+	e.ID, err = gitinterface.CommitUsingSpecificKeyWithOptions(repo, gitinterface.EmptyTree(), Ref, message, opts, signingKeyBytes)
+	if err != nil {
+		return err
+	}
 
-	_, err := gitinterface.CommitUsingSpecificKey(repo, gitinterface.EmptyTree(), Ref, message, signingKeyBytes)
-	return err
+	return runAfterCommitHooks(repo, e)
 }
 
-// Skipped returns true if any of the annotations mark the entry as
-// to-be-skipped.
+// SkippedBy returns true if the annotations mark the entry as to-be-skipped.
+// The annotations are expected to be in order of occurrence in the RSL. When an
+// entry has been both skipped and unskipped, the most recent of these
+// annotations determines whether the entry is skipped. The annotations' signers
+// are not considered, so workflows that act on an entry's skip status must use
+// policy.SkipResolver, which ignores unauthorized annotations when they
+// conflict.
 func (e *ReferenceEntry) SkippedBy(annotations []*AnnotationEntry) bool {
+	skipped := false
 	for _, annotation := range annotations {
-		if annotation.RefersTo(e.ID) && annotation.Skip {
-			return true
+		if !annotation.RefersTo(e.ID) {
+			continue
+		}
+
+		switch {
+		case annotation.Skip:
+			skipped = true
+		case annotation.Unskip:
+			skipped = false
 		}
 	}
 
-	return false
+	return skipped
+}
+
+// VerifyArtifact checks that the artifact matches the artifact digest recorded
+// in the entry.
+func (e *ReferenceEntry) VerifyArtifact(artifact io.Reader) error {
+	if e.ArtifactDigest == "" {
+		return ErrNoArtifactDigest
+	}
+	if err := ValidateArtifactDigest(e.ArtifactDigest); err != nil {
+		return err
+	}
+
+	digest, err := ComputeArtifactDigest(artifact)
+	if err != nil {
+		return err
+	}
+
+	if digest != strings.ToLower(e.ArtifactDigest) {
+		return fmt.Errorf("%w: expected '%s', got '%s'", ErrArtifactDigestMismatch, e.ArtifactDigest, digest)
+	}
+
+	return nil
+}
+
+// setNumber sets the entry's number for it to be recorded at the tip of the
+// RSL.
+func (e *ReferenceEntry) setNumber(repo *git.Repository) error {
+	number, err := nextEntryNumberAtTip(repo)
+	if err != nil {
+		return err
+	}
+
+	e.Number = number
+	return nil
 }
 
 func (e *ReferenceEntry) createCommitMessage() (string, error) {
+	if IsRSLRef(e.RefName) {
+		return "", ErrCannotRecordRSLRef
+	}
+
+	if e.Deleted && !e.TargetID.IsZero() {
+		return "", ErrInvalidDeletionEntry
+	}
+
+	if e.Deleted && IsGittufRef(e.RefName) {
+		return "", ErrCannotDeleteGittufRef
+	}
+
+	if err := validateActor(e.Actor, e.ActorURL); err != nil {
+		return "", err
+	}
+
 	lines := []string{
 		ReferenceEntryHeader,
 		"",
 		fmt.Sprintf("%s: %s", RefKey, e.RefName),
 		fmt.Sprintf("%s: %s", TargetIDKey, e.TargetID.String()),
 	}
+	if e.ArtifactDigest != "" {
+		lines = append(lines, fmt.Sprintf("%s: %s", ArtifactDigestKey, e.ArtifactDigest))
+	}
+	if e.Deleted {
+		lines = append(lines, fmt.Sprintf("%s: true", DeletedKey))
+	}
+	if e.Actor != "" {
+		lines = append(lines, fmt.Sprintf("%s: %s", ActorKey, e.Actor))
+	}
+	if e.ActorURL != "" {
+		lines = append(lines, fmt.Sprintf("%s: %s", ActorURLKey, e.ActorURL))
+	}
+	if e.Number != 0 {
+		lines = append(lines, fmt.Sprintf("%s: %d", NumberKey, e.Number))
+	}
 	return strings.Join(lines, "\n"), nil
 }
 
+// validateActor checks that the actor metadata can be recorded in an entry and
+// parsed back unchanged. Values must fit on a single line as each field is
+// recorded as a line in the entry, and surrounding whitespace is not preserved
+// when the entry is parsed.
+func validateActor(actor, actorURL string) error {
+	if actor != "" && (strings.ContainsAny(actor, "\r\n") || strings.TrimSpace(actor) != actor) {
+		return ErrInvalidActor
+	}
+
+	if actorURL != "" {
+		if strings.ContainsAny(actorURL, "\r\n") || strings.TrimSpace(actorURL) != actorURL {
+			return ErrInvalidActorURL
+		}
+		parsedURL, err := url.Parse(actorURL)
+		if err != nil || !parsedURL.IsAbs() || parsedURL.Host == "" {
+			return ErrInvalidActorURL
+		}
+	}
+
+	return nil
+}
+
+// ComputeArtifactDigest returns the digest of the artifact in the format
+// recorded in RSL reference entries.
+func ComputeArtifactDigest(artifact io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, artifact); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", artifactDigestAlgorithm, hex.EncodeToString(hash.Sum(nil))), nil
+}
+
+// ValidateArtifactDigest checks that the artifact digest is of the form
+// 'sha256:<hex digest>'.
+func ValidateArtifactDigest(artifactDigest string) error {
+	algorithm, digest, found := strings.Cut(artifactDigest, ":")
+	if !found || algorithm != artifactDigestAlgorithm {
+		return ErrInvalidArtifactDigest
+	}
+
+	digestBytes, err := hex.DecodeString(digest)
+	if err != nil || len(digestBytes) != sha256.Size {
+		return ErrInvalidArtifactDigest
+	}
+
+	return nil
+}
+
+// Severity indicates the importance of an annotation. It is used to triage
+// annotations, for example, to alert on critical annotations.
+type Severity string
+
+const (
+	// SeverityNone is used for annotations that do not set a severity. This
+	// includes all annotations created before severity levels were introduced.
+	SeverityNone Severity = ""
+
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+
+	// SeverityUnknown is used when an annotation's severity is set to an
+	// unrecognized value.
+	SeverityUnknown Severity = "unknown"
+)
+
+// parseSeverity returns the Severity corresponding to the specified string.
+// Unrecognized values are parsed as SeverityUnknown.
+func parseSeverity(severity string) Severity {
+	switch Severity(severity) {
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+		return Severity(severity)
+	default:
+		return SeverityUnknown
+	}
+}
+
 // AnnotationEntry is a type of RSL record that references prior items in the
 // RSL. It can be used to add extra information for the referenced items.
-// Annotations can also be used to "skip", i.e. revoke, the referenced items. It
+// Annotations can also be used to "skip", i.e. revoke, the referenced items, or
+// to "unskip", i.e. reinstate, items that were previously skipped. It
 // implements the Entry interface.
 type AnnotationEntry struct {
 	// ID contains the Git hash for the commit corresponding to the annotation.
@@ -150,8 +630,22 @@ type AnnotationEntry struct {
 	// Skip indicates if the RSLEntryIDs must be skipped during gittuf workflows.
 	Skip bool
 
+	// Unskip indicates if the RSLEntryIDs must be reinstated after being
+	// skipped by an earlier annotation. An annotation cannot set both Skip
+	// and Unskip.
+	Unskip bool
+
 	// Message contains any messages or notes added by a user for the annotation.
 	Message string
+
+	// Severity optionally indicates the importance of the annotation. It is
+	// not recorded in the annotation when set to SeverityNone.
+	Severity Severity
+
+	// Number contains the position of the annotation in the RSL. It is set
+	// when the annotation is committed, and is zero for annotations recorded
+	// before numbering was introduced.
+	Number uint64
 }
 
 // NewAnnotationEntry returns an Annotation object that applies to one or more
@@ -160,6 +654,12 @@ func NewAnnotationEntry(rslEntryIDs []plumbing.Hash, skip bool, message string)
 	return &AnnotationEntry{RSLEntryIDs: rslEntryIDs, Skip: skip, Message: message}
 }
 
+// NewUnskipAnnotationEntry returns an Annotation object that reinstates one or
+// more prior RSL entries that were skipped by earlier annotations.
+func NewUnskipAnnotationEntry(rslEntryIDs []plumbing.Hash, message string) *AnnotationEntry {
+	return &AnnotationEntry{RSLEntryIDs: rslEntryIDs, Unskip: true, Message: message}
+}
+
 func (a *AnnotationEntry) GetID() plumbing.Hash {
 	return a.ID
 }
@@ -173,13 +673,126 @@ func (a *AnnotationEntry) Commit(repo *git.Repository, sign bool) error {
 		}
 	}
 
+	if err := a.setNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := a.createCommitMessage()
+	if err != nil {
+		return err
+	}
+
+	if err := runBeforeCommitHooks(repo, a); err != nil {
+		return err
+	}
+
+	opts, err := getEntryCommitOptionsAtTip(repo)
+	if err != nil {
+		return err
+	}
+
+	a.ID, err = gitinterface.CommitWithOptions(repo, gitinterface.EmptyTree(), Ref, message, opts, sign)
+	if err != nil {
+		return err
+	}
+
+	return runAfterCommitHooks(repo, a)
+}
+
+// CommitWithCommitter creates a commit object in the RSL for the
+// AnnotationEntry, like Commit, but with the specified committer name and
+// email.
+func (a *AnnotationEntry) CommitWithCommitter(repo *git.Repository, committerName, committerEmail string, sign bool) error {
+	// Check if referred entries exist in the RSL namespace.
+	for _, id := range a.RSLEntryIDs {
+		if _, err := GetEntry(repo, id); err != nil {
+			return err
+		}
+	}
+
+	if err := a.setNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := a.createCommitMessage()
+	if err != nil {
+		return err
+	}
+
+	if err := runBeforeCommitHooks(repo, a); err != nil {
+		return err
+	}
+
+	opts, err := getEntryCommitOptionsAtTip(repo)
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &gitinterface.CommitOptions{}
+	}
+	opts.CommitterName = committerName
+	opts.CommitterEmail = committerEmail
+
+	a.ID, err = gitinterface.CommitWithOptions(repo, gitinterface.EmptyTree(), Ref, message, opts, sign)
+	if err != nil {
+		return err
+	}
+
+	return runAfterCommitHooks(repo, a)
+}
+
+// commitWithParent creates a commit object for the AnnotationEntry on top of
+// the specified parent RSL entry rather than the current tip of the RSL. All
+// the entries referred to by the annotation must be the parent or one of its
+// ancestors. The RSL reference is not updated and the ID of the new entry is
+// returned. Like ReferenceEntry.commitWithParent, this is only used by
+// ReplayEntries. In normal operation, Commit must be used instead.
+func (a *AnnotationEntry) commitWithParent(repo *git.Repository, parentID plumbing.Hash, sign bool) (plumbing.Hash, error) {
+	if parentID.IsZero() {
+		// An annotation cannot be the first entry in the RSL
+		return plumbing.ZeroHash, ErrRSLEntryNotFound
+	}
+
+	if err := checkParentIsRSLEntry(repo, parentID); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	queries := make([]gitinterface.AncestryQuery, 0, len(a.RSLEntryIDs))
+	for _, id := range a.RSLEntryIDs {
+		if _, err := GetEntry(repo, id); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		queries = append(queries, gitinterface.AncestryQuery{AncestorID: id, DescendantID: parentID})
+	}
+
+	results, err := gitinterface.IsAncestorBatch(repo, queries)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	for _, isAncestor := range results {
+		if !isAncestor {
+			// The annotation refers to an entry on a different chain
+			return plumbing.ZeroHash, ErrNonLinearRSL
+		}
+	}
+
+	number, err := nextEntryNumber(repo, parentID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	a.Number = number
+
 	message, err := a.createCommitMessage()
 	if err != nil {
-		return err
+		return plumbing.ZeroHash, err
+	}
+
+	opts, err := getEntryCommitOptions(repo, parentID)
+	if err != nil {
+		return plumbing.ZeroHash, err
 	}
 
-	_, err = gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, message, sign)
-	return err
+	return gitinterface.CommitWithParentAndOptions(repo, gitinterface.EmptyTree(), parentID, message, opts, sign)
 }
 
 // RefersTo returns true if the specified entryID is referred to by the
@@ -194,7 +807,27 @@ func (a *AnnotationEntry) RefersTo(entryID plumbing.Hash) bool {
 	return false
 }
 
+// setNumber sets the annotation's number for it to be recorded at the tip of
+// the RSL.
+func (a *AnnotationEntry) setNumber(repo *git.Repository) error {
+	number, err := nextEntryNumberAtTip(repo)
+	if err != nil {
+		return err
+	}
+
+	a.Number = number
+	return nil
+}
+
 func (a *AnnotationEntry) createCommitMessage() (string, error) {
+	if err := checkAnnotationMessageSize(a.Message); err != nil {
+		return "", err
+	}
+
+	if a.Skip && a.Unskip {
+		return "", ErrConflictingSkipStatus
+	}
+
 	lines := []string{
 		AnnotationEntryHeader,
 		"",
@@ -210,6 +843,20 @@ func (a *AnnotationEntry) createCommitMessage() (string, error) {
 		lines = append(lines, fmt.Sprintf("%s: false", SkipKey))
 	}
 
+	if a.Unskip {
+		// Only recorded when set so annotations that do not unskip entries
+		// remain readable by older clients
+		lines = append(lines, fmt.Sprintf("%s: true", UnskipKey))
+	}
+
+	if a.Severity != SeverityNone {
+		lines = append(lines, fmt.Sprintf("%s: %s", SeverityKey, a.Severity))
+	}
+
+	if a.Number != 0 {
+		lines = append(lines, fmt.Sprintf("%s: %d", NumberKey, a.Number))
+	}
+
 	if len(a.Message) != 0 {
 		var message strings.Builder
 		messageBlock := pem.Block{
@@ -235,6 +882,64 @@ func GetEntry(repo *git.Repository, entryID plumbing.Hash) (Entry, error) {
 	return parseRSLEntryText(entryID, commitObj.Message)
 }
 
+// GetEntryByNumber returns the entry with the specified number. If the RSL
+// index persisted in the repository is up to date, the entry is looked up in
+// the index. Otherwise, the RSL is walked back from the latest entry, stopping
+// as soon as an entry numbered lower than the requested number is found.
+// Entries recorded before numbering was introduced have no number and cannot
+// be looked up using this function. As the number is recorded in the entry's
+// text, the entry found is checked to be at the corresponding position in the
+// RSL before it is returned, and ErrEntryNumberMismatch is returned if it isn't.
+// Note that the entry's signature is not verified.
+func GetEntryByNumber(repo *git.Repository, number uint64) (Entry, error) {
+	if number == 0 {
+		return nil, ErrInvalidEntryNumber
+	}
+
+	iterator, err := GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := loadCurrentIndex(repo, iterator.GetID())
+	if err != nil {
+		return nil, err
+	}
+	if index != nil {
+		entryID, has := index.GetEntryByNumber(number)
+		if !has {
+			return nil, ErrRSLEntryNotFound
+		}
+
+		entry, err := GetEntry(repo, entryID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkEntryPosition(repo, entry, number); err != nil {
+			return nil, err
+		}
+		return entry, nil
+	}
+
+	for {
+		entryNumber := getEntryNumber(iterator)
+		if entryNumber == number {
+			if err := checkEntryPosition(repo, iterator, number); err != nil {
+				return nil, err
+			}
+			return iterator, nil
+		}
+		if entryNumber != 0 && entryNumber < number {
+			return nil, ErrRSLEntryNotFound
+		}
+
+		iterator, err = GetParentForEntry(repo, iterator)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
 // GetParentForEntry returns the entry's parent RSL entry.
 func GetParentForEntry(repo *git.Repository, entry Entry) (Entry, error) {
 	commitObj, err := gitinterface.GetCommit(repo, entry.GetID())
@@ -250,7 +955,20 @@ func GetParentForEntry(repo *git.Repository, entry Entry) (Entry, error) {
 		return nil, ErrRSLBranchDetected
 	}
 
-	return GetEntry(repo, commitObj.ParentHashes[0])
+	parent, err := GetEntry(repo, commitObj.ParentHashes[0])
+	if err != nil {
+		// In a shallow clone, the RSL may be truncated rather than end here
+		isShallow, shallowErr := gitinterface.IsShallowCommit(repo, entry.GetID())
+		if shallowErr != nil {
+			return nil, shallowErr
+		}
+		if isShallow {
+			return nil, fmt.Errorf("%w: parent of RSL entry '%s' is missing", gitinterface.ErrShallowBoundary, entry.GetID().String())
+		}
+		return nil, err
+	}
+
+	return parent, nil
 }
 
 // GetNonGittufParentReferenceEntryForEntry returns the first RSL reference
@@ -437,7 +1155,9 @@ func GetLatestReferenceEntryForRefBefore(repo *git.Repository, refName string, a
 // GetLatestUnskippedReferenceEntryForRef returns the latest reference entry for
 // the ref that does not have an annotation marking it as to-be-skipped. Entries
 // are searched from the latest entry in the RSL to include new annotations for
-// each reference entry tested for the ref.
+// each reference entry tested for the ref. The skip status is determined using
+// SkippedBy, so conflicting annotations are not resolved by their signers; see
+// policy.SkipResolver.
 func GetLatestUnskippedReferenceEntryForRef(repo *git.Repository, refName string) (*ReferenceEntry, []*AnnotationEntry, error) {
 	return GetLatestUnskippedReferenceEntryForRefBefore(repo, refName, plumbing.ZeroHash)
 }
@@ -465,15 +1185,23 @@ func GetLatestUnskippedReferenceEntryForRefBefore(repo *git.Repository, refName
 }
 
 // GetFirstEntry returns the very first entry in the RSL. It is expected to be
-// a reference entry as the first entry in the RSL cannot be an annotation.
+// a reference entry as the first entry in the RSL cannot be an annotation. If
+// the RSL has been archived, the archive's checkpoint is returned.
 func GetFirstEntry(repo *git.Repository) (*ReferenceEntry, []*AnnotationEntry, error) {
 	return GetFirstReferenceEntryForRef(repo, "")
 }
 
 // GetFirstReferenceEntryForRef returns the very first entry in the RSL for the
 // specified ref. It is expected to be a reference entry as the first entry in
-// the RSL for a reference cannot be an annotation.
+// the RSL for a reference cannot be an annotation. If the RSL has been
+// archived, entries before the archive's checkpoint are not walked. Instead,
+// the ref's entry recorded in the archive is returned, if there is one.
 func GetFirstReferenceEntryForRef(repo *git.Repository, targetRef string) (*ReferenceEntry, []*AnnotationEntry, error) {
+	archive, err := LoadArchive(repo)
+	if err != nil && !errors.Is(err, ErrRSLArchiveNotFound) {
+		return nil, nil, err
+	}
+
 	iteratorT, err := GetLatestEntry(repo)
 	if err != nil {
 		return nil, nil, err
@@ -481,6 +1209,7 @@ func GetFirstReferenceEntryForRef(repo *git.Repository, targetRef string) (*Refe
 
 	allAnnotations := []*AnnotationEntry{}
 	var firstEntry *ReferenceEntry
+	reachedCheckpoint := false
 
 	for {
 		switch entry := iteratorT.(type) {
@@ -492,6 +1221,14 @@ func GetFirstReferenceEntryForRef(repo *git.Repository, targetRef string) (*Refe
 			allAnnotations = append(allAnnotations, entry)
 		}
 
+		if archive != nil && iteratorT.GetID().String() == archive.CheckpointEntryID {
+			reachedCheckpoint = true
+			if archivedEntry := archive.GetReferenceEntry(targetRef); archivedEntry != nil {
+				firstEntry = archivedEntry
+			}
+			break
+		}
+
 		parentT, err := GetParentForEntry(repo, iteratorT)
 		if err != nil {
 			if errors.Is(err, ErrRSLEntryNotFound) {
@@ -504,6 +1241,10 @@ func GetFirstReferenceEntryForRef(repo *git.Repository, targetRef string) (*Refe
 		iteratorT = parentT
 	}
 
+	if archive != nil && !reachedCheckpoint {
+		return nil, nil, ErrRSLArchiveMismatch
+	}
+
 	if firstEntry == nil {
 		return nil, nil, ErrRSLEntryNotFound
 	}
@@ -578,6 +1319,21 @@ func GetReferenceEntriesInRange(repo *git.Repository, firstID, lastID plumbing.H
 // reference entry, with the value being a list of annotations that apply to
 // that reference entry.
 func GetReferenceEntriesInRangeForRef(repo *git.Repository, firstID, lastID plumbing.Hash, refName string) ([]*ReferenceEntry, map[plumbing.Hash][]*AnnotationEntry, error) {
+	return getReferenceEntriesInRange(repo, firstID, lastID, refName, 0)
+}
+
+// GetReferenceEntriesInRangeWithLimit is similar to
+// GetReferenceEntriesInRangeForRef but stops after maxEntries reference entries
+// are found. If the range contains more entries, the latest maxEntries entries
+// in the range are returned along with ErrRangeTooLarge. The caller can then
+// request the rest of the range using the parent of the earliest returned
+// entry as lastID. DefaultMaxEntriesInRange is a reasonable limit for most
+// callers. If maxEntries is zero or negative, the limit is disabled.
+func GetReferenceEntriesInRangeWithLimit(repo *git.Repository, firstID, lastID plumbing.Hash, refName string, maxEntries int) ([]*ReferenceEntry, map[plumbing.Hash][]*AnnotationEntry, error) {
+	return getReferenceEntriesInRange(repo, firstID, lastID, refName, maxEntries)
+}
+
+func getReferenceEntriesInRange(repo *git.Repository, firstID, lastID plumbing.Hash, refName string, maxEntries int) ([]*ReferenceEntry, map[plumbing.Hash][]*AnnotationEntry, error) {
 	// We have to iterate from latest to get the annotations that refer to the
 	// last requested entry
 	iterator, err := GetLatestEntry(repo)
@@ -602,6 +1358,7 @@ func GetReferenceEntriesInRangeForRef(repo *git.Repository, firstID, lastID plum
 
 	entryStack := []*ReferenceEntry{}
 	inRange := map[plumbing.Hash]bool{}
+	limitExceeded := false
 	for iterator.GetID() != firstID {
 		// Here, all items are relevant until the one corresponding to first is
 		// found
@@ -612,6 +1369,10 @@ func GetReferenceEntriesInRangeForRef(repo *git.Repository, firstID, lastID plum
 				// a) there's no refName set, or
 				// b) the entry's refName matches the set refName, or
 				// c) the entry is for a gittuf namespace
+				if maxEntries > 0 && len(entryStack) == maxEntries {
+					limitExceeded = true
+					break
+				}
 				entryStack = append(entryStack, it)
 				inRange[it.ID] = true
 			}
@@ -619,6 +1380,10 @@ func GetReferenceEntriesInRangeForRef(repo *git.Repository, firstID, lastID plum
 			allAnnotations = append(allAnnotations, it)
 		}
 
+		if limitExceeded {
+			break
+		}
+
 		parent, err := GetParentForEntry(repo, iterator)
 		if err != nil {
 			return nil, nil, err
@@ -629,14 +1394,18 @@ func GetReferenceEntriesInRangeForRef(repo *git.Repository, firstID, lastID plum
 	// Handle the item corresponding to first explicitly
 	// If it's an annotation, ignore it as it refers to something before the
 	// range we care about
-	if entry, isEntry := iterator.(*ReferenceEntry); isEntry {
+	if entry, isEntry := iterator.(*ReferenceEntry); isEntry && !limitExceeded {
 		if len(refName) == 0 || entry.RefName == refName || isRelevantGittufRef(entry.RefName) {
 			// It's a relevant entry if:
 			// a) there's no refName set, or
 			// b) the entry's refName matches the set refName, or
 			// c) the entry is for a gittuf namespace
-			entryStack = append(entryStack, entry)
-			inRange[entry.ID] = true
+			if maxEntries > 0 && len(entryStack) == maxEntries {
+				limitExceeded = true
+			} else {
+				entryStack = append(entryStack, entry)
+				inRange[entry.ID] = true
+			}
 		}
 	}
 
@@ -666,14 +1435,253 @@ func GetReferenceEntriesInRangeForRef(repo *git.Repository, firstID, lastID plum
 		allEntries = append(allEntries, entryStack[i])
 	}
 
+	if limitExceeded {
+		return allEntries, annotationMap, ErrRangeTooLarge
+	}
+
 	return allEntries, annotationMap, nil
 }
 
+// ReferenceEntryWithSkipStatus wraps a ReferenceEntry with its annotations and
+// whether the entry is currently marked as to-be-skipped.
+type ReferenceEntryWithSkipStatus struct {
+	*ReferenceEntry
+
+	// Annotations contains all the annotations that refer to the entry.
+	Annotations []*AnnotationEntry
+
+	// Skipped indicates if the annotations mark the entry as to-be-skipped,
+	// accounting for entries that were later unskipped.
+	Skipped bool
+
+	// SkippedBy contains the IDs of the annotations that mark the entry as
+	// to-be-skipped.
+	SkippedBy []plumbing.Hash
+
+	// UnskippedBy contains the IDs of the annotations that reinstate the
+	// entry.
+	UnskippedBy []plumbing.Hash
+}
+
+// GetReferenceEntriesInRangeWithSkipStatus returns a list of reference entries
+// between the specified range. If refName is set, only entries relevant to the
+// ref are returned, as in GetReferenceEntriesInRangeForRef. Each entry is
+// returned with the annotations that refer to it and its current skip status,
+// so that callers need not compute it from the annotations themselves.
+func GetReferenceEntriesInRangeWithSkipStatus(repo *git.Repository, firstID, lastID plumbing.Hash, refName string) ([]*ReferenceEntryWithSkipStatus, error) {
+	entries, annotationMap, err := GetReferenceEntriesInRangeForRef(repo, firstID, lastID, refName)
+	if err != nil {
+		return nil, err
+	}
+
+	entriesWithStatus := make([]*ReferenceEntryWithSkipStatus, 0, len(entries))
+	for _, entry := range entries {
+		entryWithStatus := &ReferenceEntryWithSkipStatus{
+			ReferenceEntry: entry,
+			Annotations:    annotationMap[entry.ID],
+			Skipped:        entry.SkippedBy(annotationMap[entry.ID]),
+			SkippedBy:      []plumbing.Hash{},
+			UnskippedBy:    []plumbing.Hash{},
+		}
+
+		for _, annotation := range annotationMap[entry.ID] {
+			switch {
+			case annotation.Skip:
+				entryWithStatus.SkippedBy = append(entryWithStatus.SkippedBy, annotation.ID)
+			case annotation.Unskip:
+				entryWithStatus.UnskippedBy = append(entryWithStatus.UnskippedBy, annotation.ID)
+			}
+		}
+
+		entriesWithStatus = append(entriesWithStatus, entryWithStatus)
+	}
+
+	return entriesWithStatus, nil
+}
+
+// AnnotationEffect describes how an annotation changes the status of the entry
+// it refers to.
+type AnnotationEffect string
+
+const (
+	// AnnotationEffectSkipped indicates that the annotation marked the entry
+	// as to-be-skipped.
+	AnnotationEffectSkipped AnnotationEffect = "skipped"
+
+	// AnnotationEffectAlreadySkipped indicates that the annotation marked the
+	// entry as to-be-skipped, but the entry had already been skipped by an
+	// earlier annotation.
+	AnnotationEffectAlreadySkipped AnnotationEffect = "already-skipped"
+
+	// AnnotationEffectInformational indicates that the annotation does not
+	// skip the entry and the entry had not been skipped.
+	AnnotationEffectInformational AnnotationEffect = "informational"
+
+	// AnnotationEffectSkipRetained indicates that the annotation is
+	// informational, but the entry had already been skipped. The entry remains
+	// skipped.
+	AnnotationEffectSkipRetained AnnotationEffect = "skip-retained"
+
+	// AnnotationEffectUnskipped indicates that the annotation reinstated the
+	// entry after it had been skipped.
+	AnnotationEffectUnskipped AnnotationEffect = "unskipped"
+
+	// AnnotationEffectNotSkipped indicates that the annotation reinstates the
+	// entry, but the entry was not skipped at the time.
+	AnnotationEffectNotSkipped AnnotationEffect = "not-skipped"
+)
+
+// AnnotationEvent records an annotation that refers to an entry along with its
+// effect on the entry.
+type AnnotationEvent struct {
+	// Annotation is the annotation that refers to the entry.
+	Annotation *AnnotationEntry
+
+	// Effect describes how the annotation changed the entry's status.
+	Effect AnnotationEffect
+
+	// SignerKeyID contains the ID of the key that signed the annotation. This
+	// package does not set it as identifying the signer requires the policy
+	// applicable at the annotation.
+	SignerKeyID string
+
+	// SignerVerified indicates if the annotation's signer could be verified.
+	SignerVerified bool
+}
+
+// BuildAnnotationTimeline returns the annotations that refer to the specified
+// entry in order of occurrence. Each annotation's effect on the entry is
+// resolved in order: a skip annotation marks the entry as to-be-skipped until a
+// later unskip annotation reinstates it.
+func BuildAnnotationTimeline(repo *git.Repository, entryID plumbing.Hash) ([]*AnnotationEvent, error) {
+	if _, err := GetEntry(repo, entryID); err != nil {
+		return nil, err
+	}
+
+	iterator, err := GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := []*AnnotationEntry{}
+	for iterator.GetID() != entryID {
+		if annotation, isAnnotation := iterator.(*AnnotationEntry); isAnnotation && annotation.RefersTo(entryID) {
+			annotations = append(annotations, annotation)
+		}
+
+		iterator, err = GetParentForEntry(repo, iterator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	timeline := make([]*AnnotationEvent, 0, len(annotations))
+	skipped := false
+	// Walk annotations in reverse so the timeline is in order of occurrence
+	for i := len(annotations) - 1; i >= 0; i-- {
+		annotation := annotations[i]
+
+		event := &AnnotationEvent{Annotation: annotation}
+		switch {
+		case annotation.Skip && !skipped:
+			event.Effect = AnnotationEffectSkipped
+			skipped = true
+		case annotation.Skip:
+			event.Effect = AnnotationEffectAlreadySkipped
+		case annotation.Unskip && skipped:
+			event.Effect = AnnotationEffectUnskipped
+			skipped = false
+		case annotation.Unskip:
+			event.Effect = AnnotationEffectNotSkipped
+		case skipped:
+			event.Effect = AnnotationEffectSkipRetained
+		default:
+			event.Effect = AnnotationEffectInformational
+		}
+
+		timeline = append(timeline, event)
+	}
+
+	return timeline, nil
+}
+
+// GetRefTargetHistory returns the sequence of targets the specified ref has had
+// according to the RSL, ordered from oldest to newest. Consecutive entries with
+// the same target are collapsed as they do not change the ref's state. Note
+// that a target may still appear more than once, e.g., when a ref moves from A
+// to B and then back to A.
+func GetRefTargetHistory(repo *git.Repository, refName string) ([]plumbing.Hash, error) {
+	return getRefTargetHistory(repo, refName, false)
+}
+
+// GetUnskippedRefTargetHistory is similar to GetRefTargetHistory but ignores
+// entries for the ref that are marked as to-be-skipped by an annotation before
+// collapsing consecutive duplicates.
+func GetUnskippedRefTargetHistory(repo *git.Repository, refName string) ([]plumbing.Hash, error) {
+	return getRefTargetHistory(repo, refName, true)
+}
+
+func getRefTargetHistory(repo *git.Repository, refName string, excludeSkipped bool) ([]plumbing.Hash, error) {
+	iteratorT, err := GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries are tracked in reverse order as we walk back from the latest
+	entryStack := []*ReferenceEntry{}
+	annotations := []*AnnotationEntry{}
+	for {
+		switch iterator := iteratorT.(type) {
+		case *ReferenceEntry:
+			if iterator.RefName == refName {
+				entryStack = append(entryStack, iterator)
+			}
+		case *AnnotationEntry:
+			annotations = append(annotations, iterator)
+		}
+
+		iteratorT, err = GetParentForEntry(repo, iteratorT)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	if len(entryStack) == 0 {
+		return nil, ErrRSLEntryNotFound
+	}
+
+	// SkippedBy expects annotations in order of occurrence
+	slices.Reverse(annotations)
+
+	targets := []plumbing.Hash{}
+	for i := len(entryStack) - 1; i >= 0; i-- {
+		entry := entryStack[i]
+
+		if excludeSkipped && entry.SkippedBy(annotations) {
+			continue
+		}
+
+		if len(targets) > 0 && targets[len(targets)-1] == entry.TargetID {
+			continue
+		}
+
+		targets = append(targets, entry.TargetID)
+	}
+
+	return targets, nil
+}
+
 func parseRSLEntryText(id plumbing.Hash, text string) (Entry, error) {
 	text = strings.TrimSpace(text)
 	if strings.HasPrefix(text, AnnotationEntryHeader) {
 		return parseAnnotationEntryText(id, text)
 	}
+	if strings.HasPrefix(text, PropagationEntryHeader) {
+		return parsePropagationEntryText(id, text)
+	}
 	return parseReferenceEntryText(id, text)
 }
 
@@ -688,7 +1696,9 @@ func parseReferenceEntryText(id plumbing.Hash, text string) (*ReferenceEntry, er
 	for _, l := range lines {
 		l = strings.TrimSpace(l)
 
-		ls := strings.Split(l, ":")
+		// Split only on the first separator as the artifact digest includes
+		// one
+		ls := strings.SplitN(l, ":", 2)
 		if len(ls) < 2 {
 			return nil, ErrInvalidRSLEntry
 		}
@@ -697,7 +1707,25 @@ func parseReferenceEntryText(id plumbing.Hash, text string) (*ReferenceEntry, er
 		case RefKey:
 			entry.RefName = strings.TrimSpace(ls[1])
 		case TargetIDKey:
-			entry.TargetID = plumbing.NewHash(strings.TrimSpace(ls[1]))
+			targetID, err := gitinterface.NewHash(strings.TrimSpace(ls[1]))
+			if err != nil {
+				return nil, ErrInvalidRSLEntry
+			}
+			entry.TargetID = targetID
+		case ArtifactDigestKey:
+			entry.ArtifactDigest = strings.TrimSpace(ls[1])
+		case DeletedKey:
+			entry.Deleted = strings.TrimSpace(ls[1]) == "true"
+		case ActorKey:
+			entry.Actor = strings.TrimSpace(ls[1])
+		case ActorURLKey:
+			entry.ActorURL = strings.TrimSpace(ls[1])
+		case NumberKey:
+			number, err := strconv.ParseUint(strings.TrimSpace(ls[1]), 10, 64)
+			if err != nil {
+				return nil, ErrInvalidRSLEntry
+			}
+			entry.Number = number
 		}
 	}
 
@@ -734,23 +1762,40 @@ func parseAnnotationEntryText(id plumbing.Hash, text string) (*AnnotationEntry,
 
 		switch strings.TrimSpace(ls[0]) {
 		case EntryIDKey:
-			annotation.RSLEntryIDs = append(annotation.RSLEntryIDs, plumbing.NewHash(strings.TrimSpace(ls[1])))
+			entryID, err := gitinterface.NewHash(strings.TrimSpace(ls[1]))
+			if err != nil {
+				return nil, ErrInvalidRSLEntry
+			}
+			annotation.RSLEntryIDs = append(annotation.RSLEntryIDs, entryID)
 		case SkipKey:
 			if strings.TrimSpace(ls[1]) == "true" {
 				annotation.Skip = true
 			} else {
 				annotation.Skip = false
 			}
+		case UnskipKey:
+			annotation.Unskip = strings.TrimSpace(ls[1]) == "true"
+		case SeverityKey:
+			annotation.Severity = parseSeverity(strings.TrimSpace(ls[1]))
+		case NumberKey:
+			number, err := strconv.ParseUint(strings.TrimSpace(ls[1]), 10, 64)
+			if err != nil {
+				return nil, ErrInvalidRSLEntry
+			}
+			annotation.Number = number
 		}
 	}
 
 	return annotation, nil
 }
 
+// filterAnnotationsForRelevantAnnotations returns the annotations that refer to
+// entryID. allAnnotations is expected to be in the order the RSL is walked,
+// i.e., latest first, and the returned annotations are in order of occurrence.
 func filterAnnotationsForRelevantAnnotations(allAnnotations []*AnnotationEntry, entryID plumbing.Hash) []*AnnotationEntry {
 	annotations := []*AnnotationEntry{}
-	for _, annotation := range allAnnotations {
-		annotation := annotation
+	for i := len(allAnnotations) - 1; i >= 0; i-- {
+		annotation := allAnnotations[i]
 		if annotation.RefersTo(entryID) {
 			annotations = append(annotations, annotation)
 		}
@@ -768,9 +1813,183 @@ func isRelevantGittufRef(refName string) bool {
 		return false
 	}
 
-	if refName == gittufPolicyStagingRef {
+	if refName == gittufPolicyStagingRef || refName == gittufPolicyProposalsRef {
 		return false
 	}
 
 	return true
 }
+
+// VerifyRSLChainIntegrity checks that the RSL entries from lastID back to
+// firstID form a linear chain. Every entry in the range must be a valid RSL
+// entry with at most one parent, and the chain must lead back to firstID.
+// Reference and propagation entries recorded for the RSL reference itself, and
+// propagation entries that do not record their upstream entry, are also
+// flagged. firstID itself is not checked. If firstID is the zero hash, the chain is
+// checked all the way to the first entry in the RSL.
+func VerifyRSLChainIntegrity(repo *git.Repository, firstID, lastID plumbing.Hash) error {
+	currentID := lastID
+	for currentID != firstID {
+		if currentID.IsZero() {
+			return fmt.Errorf("%w: chain does not lead to entry '%s'", ErrInvalidRSLChain, firstID.String())
+		}
+
+		commitObj, err := gitinterface.GetCommit(repo, currentID)
+		if err != nil {
+			return fmt.Errorf("%w: unable to load entry '%s': %w", ErrInvalidRSLChain, currentID.String(), err)
+		}
+
+		entry, err := parseRSLEntryText(currentID, commitObj.Message)
+		if err != nil {
+			return fmt.Errorf("%w: unable to parse entry '%s': %w", ErrInvalidRSLChain, currentID.String(), err)
+		}
+		switch entry := entry.(type) {
+		case *ReferenceEntry:
+			if IsRSLRef(entry.RefName) {
+				return fmt.Errorf("%w: entry '%s' is for '%s': %w", ErrInvalidRSLChain, currentID.String(), entry.RefName, ErrCannotRecordRSLRef)
+			}
+		case *PropagationEntry:
+			if IsRSLRef(entry.RefName) {
+				return fmt.Errorf("%w: entry '%s' is for '%s': %w", ErrInvalidRSLChain, currentID.String(), entry.RefName, ErrCannotRecordRSLRef)
+			}
+			if entry.UpstreamRepository == "" || entry.UpstreamEntryID.IsZero() {
+				return fmt.Errorf("%w: entry '%s': %w", ErrInvalidRSLChain, currentID.String(), ErrInvalidPropagationEntry)
+			}
+		}
+
+		switch len(commitObj.ParentHashes) {
+		case 0:
+			currentID = plumbing.ZeroHash
+		case 1:
+			currentID = commitObj.ParentHashes[0]
+		default:
+			return fmt.Errorf("%w: entry '%s' has more than one parent", ErrInvalidRSLChain, currentID.String())
+		}
+	}
+
+	return nil
+}
+
+// checkAnnotationMessageSize returns ErrAnnotationMessageTooLarge if the
+// message exceeds MaxAnnotationMessageSize.
+func checkAnnotationMessageSize(message string) error {
+	if MaxAnnotationMessageSize > 0 && len(message) > MaxAnnotationMessageSize {
+		return fmt.Errorf("%w: %d bytes, maximum is %d bytes", ErrAnnotationMessageTooLarge, len(message), MaxAnnotationMessageSize)
+	}
+
+	return nil
+}
+
+// checkParentIsRSLEntry ensures that an entry created with parentID as its
+// parent extends a linear RSL. The parent must be an RSL entry that itself has
+// at most one parent. The zero hash is accepted as the parent of the first
+// entry in an RSL.
+func checkParentIsRSLEntry(repo *git.Repository, parentID plumbing.Hash) error {
+	if parentID.IsZero() {
+		return nil
+	}
+
+	parentCommit, err := gitinterface.GetCommit(repo, parentID)
+	if err != nil {
+		return err
+	}
+
+	if len(parentCommit.ParentHashes) > 1 {
+		return ErrNonLinearRSL
+	}
+
+	if _, err := parseRSLEntryText(parentID, parentCommit.Message); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// nextEntryNumberAtTip returns the number of an entry to be recorded at the
+// current tip of the RSL.
+func nextEntryNumberAtTip(repo *git.Repository) (uint64, error) {
+	tip, err := gitinterface.GetTip(repo, Ref)
+	if err != nil {
+		if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return 0, err
+		}
+		tip = plumbing.ZeroHash
+	}
+
+	return nextEntryNumber(repo, tip)
+}
+
+// nextEntryNumber returns the number of an entry to be recorded on top of the
+// specified parent entry. If the parent predates entry numbering, its number is
+// inferred from its distance to the nearest numbered ancestor or to the first
+// entry in the RSL.
+func nextEntryNumber(repo *git.Repository, parentID plumbing.Hash) (uint64, error) {
+	if parentID.IsZero() {
+		return 1, nil
+	}
+
+	iterator, err := GetEntry(repo, parentID)
+	if err != nil {
+		return 0, err
+	}
+
+	distance := uint64(0)
+	for {
+		if number := getEntryNumber(iterator); number != 0 {
+			return number + distance + 1, nil
+		}
+
+		iterator, err = GetParentForEntry(repo, iterator)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				// Reached the first entry, which is numbered 1
+				return distance + 2, nil
+			}
+			return 0, err
+		}
+		distance++
+	}
+}
+
+func getEntryNumber(entry Entry) uint64 {
+	switch entry := entry.(type) {
+	case *ReferenceEntry:
+		return entry.Number
+	case *AnnotationEntry:
+		return entry.Number
+	case *PropagationEntry:
+		return entry.Number
+	}
+
+	return 0
+}
+
+// checkEntryPosition checks that the entry is at the position in the RSL that
+// corresponds to number, counting the first entry in the RSL as 1. Entries are
+// numbered this way when they are recorded, including entries recorded after
+// unnumbered entries.
+func checkEntryPosition(repo *git.Repository, entry Entry, number uint64) error {
+	position := uint64(1)
+	iterator := entry
+	for {
+		parent, err := GetParentForEntry(repo, iterator)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				break
+			}
+			return err
+		}
+
+		position++
+		if position > number {
+			break
+		}
+		iterator = parent
+	}
+
+	if position != number {
+		return fmt.Errorf("%w: entry '%s' is numbered %d", ErrEntryNumberMismatch, entry.GetID().String(), number)
+	}
+
+	return nil
+}
@@ -3,14 +3,17 @@
 package rsl
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/assert"
 )
@@ -79,7 +82,7 @@ func TestNewReferenceEntry(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %s", ReferenceEntryHeader, RefKey, "main", TargetIDKey, plumbing.ZeroHash.String())
+	expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d", ReferenceEntryHeader, RefKey, "main", TargetIDKey, plumbing.ZeroHash.String(), NumberKey, 1)
 	assert.Equal(t, expectedMessage, commitObj.Message)
 	assert.Empty(t, commitObj.ParentHashes)
 
@@ -99,146 +102,182 @@ func TestNewReferenceEntry(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedMessage = fmt.Sprintf("%s\n\n%s: %s\n%s: %s", ReferenceEntryHeader, RefKey, "main", TargetIDKey, plumbing.NewHash("abcdef1234567890"))
+	expectedMessage = fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d", ReferenceEntryHeader, RefKey, "main", TargetIDKey, plumbing.NewHash("abcdef1234567890"), NumberKey, 2)
 	assert.Equal(t, expectedMessage, commitObj.Message)
 	assert.Contains(t, commitObj.ParentHashes, originalRefHash)
 }
 
-func TestGetLatestEntry(t *testing.T) {
+func TestNewReferenceDeletionEntry(t *testing.T) {
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	if err := InitializeNamespace(repo); err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
-	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
-		t.Error(err)
+	if err := NewReferenceEntry("refs/heads/feature", plumbing.NewHash("abcdef1234567890")).Commit(repo, false); err != nil {
+		t.Fatal(err)
 	}
 
-	if entry, err := GetLatestEntry(repo); err != nil {
-		t.Error(err)
-	} else {
-		e := entry.(*ReferenceEntry)
-		assert.Equal(t, "main", e.RefName)
-		assert.Equal(t, plumbing.ZeroHash, e.TargetID)
-	}
+	err = NewReferenceDeletionEntry("refs/heads/feature").Commit(repo, false)
+	assert.Nil(t, err)
 
-	if err := NewReferenceEntry("feature", plumbing.NewHash("abcdef1234567890")).Commit(repo, false); err != nil {
-		t.Error(err)
+	entry, _, err := GetLatestReferenceEntryForRef(repo, "refs/heads/feature")
+	if err != nil {
+		t.Fatal(err)
 	}
-	if entry, err := GetLatestEntry(repo); err != nil {
-		t.Error(err)
-	} else {
-		e := entry.(*ReferenceEntry)
-		assert.NotEqual(t, "main", e.RefName)
-		assert.NotEqual(t, plumbing.ZeroHash, e.TargetID)
+	assert.True(t, entry.Deleted)
+	assert.Equal(t, plumbing.ZeroHash, entry.TargetID)
+
+	commitObj, err := gitinterface.GetCommit(repo, entry.ID)
+	if err != nil {
+		t.Fatal(err)
 	}
+	expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: true\n%s: %d", ReferenceEntryHeader, RefKey, "refs/heads/feature", TargetIDKey, plumbing.ZeroHash.String(), DeletedKey, NumberKey, 2)
+	assert.Equal(t, expectedMessage, commitObj.Message)
 
-	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	invalidEntry := NewReferenceDeletionEntry("refs/heads/feature")
+	invalidEntry.TargetID = plumbing.NewHash("abcdef1234567890")
+	err = invalidEntry.Commit(repo, false)
+	assert.ErrorIs(t, err, ErrInvalidDeletionEntry)
+
+	err = NewReferenceDeletionEntry("refs/gittuf/policy").Commit(repo, false)
+	assert.ErrorIs(t, err, ErrCannotDeleteGittufRef)
+}
+
+func TestCommitReferenceEntries(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
 	}
-	entryID := ref.Hash()
 
-	if err := NewAnnotationEntry([]plumbing.Hash{entryID}, true, "This was a mistaken push!").Commit(repo, false); err != nil {
-		t.Error(err)
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
 	}
 
-	if entry, err := GetLatestEntry(repo); err != nil {
-		t.Error(err)
-	} else {
-		a := entry.(*AnnotationEntry)
-		assert.True(t, a.Skip)
-		assert.Equal(t, []plumbing.Hash{entryID}, a.RSLEntryIDs)
-		assert.Equal(t, "This was a mistaken push!", a.Message)
+	// No entries is a no-op
+	err = CommitReferenceEntries(repo, nil, false)
+	assert.Nil(t, err)
+	_, err = GetLatestEntry(repo)
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	firstEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
 	}
-}
 
-func TestGetLatestNonGittufReferenceEntry(t *testing.T) {
-	t.Run("mix of gittuf and non gittuf entries", func(t *testing.T) {
-		repo, err := git.Init(memory.NewStorage(), memfs.New())
-		if err != nil {
-			t.Fatal(err)
-		}
+	refTargets := []RefTarget{
+		{RefName: "refs/heads/feature", TargetID: plumbing.NewHash("abcdef1234567890")},
+		{RefName: "refs/tags/v1", TargetID: plumbing.NewHash("1234567890abcdef")},
+		{RefName: "refs/tags/v2", TargetID: plumbing.NewHash("1234567890abcdef")},
+	}
+	entries := NewReferenceEntries(refTargets)
+	assert.Equal(t, 3, len(entries))
 
-		if err := InitializeNamespace(repo); err != nil {
-			t.Fatal(err)
-		}
+	err = CommitReferenceEntries(repo, entries, false)
+	assert.Nil(t, err)
 
-		// Add the first gittuf entry
-		if err := NewReferenceEntry("refs/gittuf/policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entries[2].ID, latestEntry.GetID())
 
-		// Add non gittuf entries
-		if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+	// Entries are chained in order on top of the prior tip
+	expectedParentID := firstEntry.GetID()
+	for i, entry := range entries {
+		recordedEntry, err := GetEntry(repo, entry.ID)
+		if err != nil {
 			t.Fatal(err)
 		}
+		referenceEntry := recordedEntry.(*ReferenceEntry)
+		assert.Equal(t, refTargets[i].RefName, referenceEntry.RefName)
+		assert.Equal(t, refTargets[i].TargetID, referenceEntry.TargetID)
+		assert.Equal(t, uint64(i+2), referenceEntry.Number)
 
-		// At this point, latest entry should be returned
-		expectedLatestEntry, err := GetLatestEntry(repo)
+		parentEntry, err := GetParentForEntry(repo, recordedEntry)
 		if err != nil {
 			t.Fatal(err)
 		}
-		latestEntry, annotations, err := GetLatestNonGittufReferenceEntry(repo)
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, expectedLatestEntry, latestEntry)
+		assert.Equal(t, expectedParentID, parentEntry.GetID())
+		expectedParentID = entry.ID
+	}
 
-		// Add another gittuf entry
-		if err := NewReferenceEntry("refs/gittuf/not-policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+	// A failure leaves the RSL unchanged
+	invalidEntries := NewReferenceEntries([]RefTarget{
+		{RefName: "refs/heads/main", TargetID: plumbing.NewHash("abcdef1234567890")},
+		{RefName: Ref, TargetID: plumbing.ZeroHash},
+	})
+	err = CommitReferenceEntries(repo, invalidEntries, false)
+	assert.ErrorIs(t, err, ErrCannotRecordRSLRef)
 
-		// At this point, the expected entry is the same as before
-		latestEntry, annotations, err = GetLatestNonGittufReferenceEntry(repo)
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, expectedLatestEntry, latestEntry)
+	latestEntry, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entries[2].ID, latestEntry.GetID())
+}
 
-		// Add an annotation for latest entry, check that it's returned
-		if err := NewAnnotationEntry([]plumbing.Hash{expectedLatestEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+func TestReferenceEntryWithArtifactDigest(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
 
-		latestEntry, annotations, err = GetLatestNonGittufReferenceEntry(repo)
-		assert.Nil(t, err)
-		assert.Equal(t, expectedLatestEntry, latestEntry)
-		assertAnnotationsReferToEntry(t, latestEntry, annotations)
-	})
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
 
-	t.Run("only gittuf entries", func(t *testing.T) {
-		repo, err := git.Init(memory.NewStorage(), memfs.New())
-		if err != nil {
-			t.Fatal(err)
-		}
+	artifact := []byte("release artifact")
+	artifactDigest, err := ComputeArtifactDigest(bytes.NewReader(artifact))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, ValidateArtifactDigest(artifactDigest))
 
-		if err := InitializeNamespace(repo); err != nil {
-			t.Fatal(err)
-		}
+	refName := "refs/tags/v1.0.0"
+	targetID := plumbing.NewHash("abcdef1234567890")
+	if err := NewReferenceEntryWithArtifactDigest(refName, targetID, artifactDigest).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
 
-		// Add the first gittuf entry
-		if err := NewReferenceEntry("refs/gittuf/policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+	entry, _, err := GetLatestReferenceEntryForRef(repo, refName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, refName, entry.RefName)
+	assert.Equal(t, targetID, entry.TargetID)
+	assert.Equal(t, artifactDigest, entry.ArtifactDigest)
 
-		_, _, err = GetLatestNonGittufReferenceEntry(repo)
-		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	commitObj, err := gitinterface.GetCommit(repo, entry.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %d", ReferenceEntryHeader, RefKey, refName, TargetIDKey, targetID.String(), ArtifactDigestKey, artifactDigest, NumberKey, 1)
+	assert.Equal(t, expectedMessage, commitObj.Message)
 
-		// Add another gittuf entry
-		if err := NewReferenceEntry("refs/gittuf/not-policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+	t.Run("matching artifact", func(t *testing.T) {
+		err := entry.VerifyArtifact(bytes.NewReader(artifact))
+		assert.Nil(t, err)
+	})
 
-		_, _, err = GetLatestNonGittufReferenceEntry(repo)
-		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	t.Run("mismatched artifact", func(t *testing.T) {
+		err := entry.VerifyArtifact(bytes.NewReader([]byte("tampered artifact")))
+		assert.ErrorIs(t, err, ErrArtifactDigestMismatch)
+	})
+
+	t.Run("entry without artifact digest", func(t *testing.T) {
+		err := NewReferenceEntry(refName, targetID).VerifyArtifact(bytes.NewReader(artifact))
+		assert.ErrorIs(t, err, ErrNoArtifactDigest)
 	})
 }
 
-func TestGetLatestReferenceEntryForRef(t *testing.T) {
+func TestReferenceEntryWithActor(t *testing.T) {
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
@@ -249,161 +288,158 @@ func TestGetLatestReferenceEntryForRef(t *testing.T) {
 	}
 
 	refName := "refs/heads/main"
-	otherRefName := "refs/heads/feature"
+	targetID := plumbing.NewHash("abcdef1234567890")
+	actor := "github:alice"
+	actorURL := "https://github.com/gittuf/gittuf/actions/runs/1234"
 
-	if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+	entry := NewReferenceEntry(refName, targetID)
+	entry.Actor = actor
+	entry.ActorURL = actorURL
+	if err := entry.Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
 
-	rslRef, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	commitObj, err := gitinterface.GetCommit(repo, entry.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
+	expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %d", ReferenceEntryHeader, RefKey, refName, TargetIDKey, targetID.String(), ActorKey, actor, ActorURLKey, actorURL, NumberKey, 1)
+	assert.Equal(t, expectedMessage, commitObj.Message)
 
-	entry, annotations, err := GetLatestReferenceEntryForRef(repo, refName)
-	assert.Nil(t, err)
-	assert.Nil(t, annotations)
-	assert.Equal(t, rslRef.Hash(), entry.ID)
-
-	if err := NewReferenceEntry(otherRefName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+	latestEntry, _, err := GetLatestReferenceEntryForRef(repo, refName)
+	if err != nil {
 		t.Fatal(err)
 	}
+	assert.Equal(t, entry, latestEntry)
 
-	entry, annotations, err = GetLatestReferenceEntryForRef(repo, refName)
-	assert.Nil(t, err)
-	assert.Nil(t, annotations)
-	assert.Equal(t, rslRef.Hash(), entry.ID)
-
-	// Add annotation for the target entry
-	if err := NewAnnotationEntry([]plumbing.Hash{entry.ID}, false, annotationMessage).Commit(repo, false); err != nil {
-		t.Fatal(err)
+	tests := map[string]struct {
+		actor         string
+		actorURL      string
+		expectedError error
+	}{
+		"actor only":                    {actor: actor},
+		"actor URL only":                {actorURL: actorURL},
+		"multi-line actor":              {actor: "alice\nnumber: 10", expectedError: ErrInvalidActor},
+		"actor with surrounding spaces": {actor: " alice ", expectedError: ErrInvalidActor},
+		"relative actor URL":            {actorURL: "actions/runs/1234", expectedError: ErrInvalidActorURL},
+		"multi-line actor URL":          {actorURL: actorURL + "\nnumber: 10", expectedError: ErrInvalidActorURL},
 	}
 
-	entry, annotations, err = GetLatestReferenceEntryForRef(repo, refName)
-	assert.Nil(t, err)
-	assert.Equal(t, rslRef.Hash(), entry.ID)
-	assertAnnotationsReferToEntry(t, entry, annotations)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			entry := NewReferenceEntry(refName, targetID)
+			entry.Actor = test.actor
+			entry.ActorURL = test.actorURL
+			_, err := entry.createCommitMessage()
+			assert.ErrorIs(t, err, test.expectedError)
+		})
+	}
 }
 
-func TestGetLatestReferenceEntryForRefBefore(t *testing.T) {
-	t.Run("no annotations", func(t *testing.T) {
-		repo, err := git.Init(memory.NewStorage(), memfs.New())
-		if err != nil {
-			t.Fatal(err)
-		}
-		if err := InitializeNamespace(repo); err != nil {
-			t.Fatal(err)
-		}
+func TestValidateArtifactDigest(t *testing.T) {
+	tests := map[string]struct {
+		artifactDigest string
+		expectedError  error
+	}{
+		"valid digest": {
+			artifactDigest: "sha256:" + strings.Repeat("ab", 32),
+		},
+		"missing algorithm": {
+			artifactDigest: strings.Repeat("ab", 32),
+			expectedError:  ErrInvalidArtifactDigest,
+		},
+		"unsupported algorithm": {
+			artifactDigest: "sha512:" + strings.Repeat("ab", 32),
+			expectedError:  ErrInvalidArtifactDigest,
+		},
+		"invalid hex": {
+			artifactDigest: "sha256:" + strings.Repeat("zz", 32),
+			expectedError:  ErrInvalidArtifactDigest,
+		},
+		"wrong length": {
+			artifactDigest: "sha256:abcdef",
+			expectedError:  ErrInvalidArtifactDigest,
+		},
+	}
 
-		// RSL structure for the test
-		// main <- feature <- main <- feature <- main
-		testRefs := []string{"main", "feature", "main", "feature", "main"}
-		entryIDs := []plumbing.Hash{}
-		for _, ref := range testRefs {
-			if err := NewReferenceEntry(ref, plumbing.ZeroHash).Commit(repo, false); err != nil {
-				t.Fatal(err)
-			}
-			latest, err := GetLatestEntry(repo)
-			if err != nil {
-				t.Fatal(err)
-			}
-			entryIDs = append(entryIDs, latest.GetID())
+	for name, test := range tests {
+		err := ValidateArtifactDigest(test.artifactDigest)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error in test '%s'", name))
 		}
+	}
+}
 
-		entry, annotations, err := GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[4])
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, entryIDs[2], entry.ID)
-
-		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[3])
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, entryIDs[2], entry.ID)
+func TestEntryCommitWithCommitter(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
 
-		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[4])
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, entryIDs[3], entry.ID)
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
 
-		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[3])
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, entryIDs[1], entry.ID)
+	committerName := "gittuf"
+	committerEmail := "gittuf@example.com"
 
-		_, _, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[1])
-		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
-	})
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).CommitWithCommitter(repo, committerName, committerEmail, false); err != nil {
+		t.Fatal(err)
+	}
 
-	t.Run("with annotations", func(t *testing.T) {
-		repo, err := git.Init(memory.NewStorage(), memfs.New())
-		if err != nil {
-			t.Fatal(err)
-		}
-		if err := InitializeNamespace(repo); err != nil {
-			t.Fatal(err)
-		}
+	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entryID := ref.Hash()
 
-		// RSL structure for the test
-		// main <- A <- feature <- A <- main <- A <- feature <- A <- main <- A
-		testRefs := []string{"main", "feature", "main", "feature", "main"}
-		entryIDs := []plumbing.Hash{}
-		for _, ref := range testRefs {
-			if err := NewReferenceEntry(ref, plumbing.ZeroHash).Commit(repo, false); err != nil {
-				t.Fatal(err)
-			}
-			latest, err := GetLatestEntry(repo)
-			if err != nil {
-				t.Fatal(err)
-			}
-			entryIDs = append(entryIDs, latest.GetID())
+	commitObj, err := gitinterface.GetCommit(repo, entryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, committerName, commitObj.Committer.Name)
+	assert.Equal(t, committerEmail, commitObj.Committer.Email)
 
-			if err := NewAnnotationEntry([]plumbing.Hash{latest.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
-				t.Fatal(err)
-			}
-			latest, err = GetLatestEntry(repo)
-			if err != nil {
-				t.Fatal(err)
-			}
-			entryIDs = append(entryIDs, latest.GetID())
-		}
+	entry, err := GetEntry(repo, entryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "refs/heads/main", entry.(*ReferenceEntry).RefName)
 
-		entry, annotations, err := GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[4])
-		assert.Nil(t, err)
-		assert.Equal(t, entryIDs[0], entry.ID)
-		assertAnnotationsReferToEntry(t, entry, annotations)
-		// Add an annotation at the end for some entry and see it gets pulled in
-		// even when the anchor is for its ancestor
-		assert.Len(t, annotations, 1) // before adding an annotation, we have just 1
-		if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[0]}, false, annotationMessage).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
-		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[4])
-		assert.Nil(t, err)
-		assert.Equal(t, entryIDs[0], entry.ID)
-		assertAnnotationsReferToEntry(t, entry, annotations)
-		assert.Len(t, annotations, 2) // now we have 2
+	if err := NewAnnotationEntry([]plumbing.Hash{entryID}, true, "message").CommitWithCommitter(repo, committerName, committerEmail, false); err != nil {
+		t.Fatal(err)
+	}
 
-		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[3])
-		assert.Nil(t, err)
-		assert.Equal(t, entryIDs[0], entry.ID)
-		assertAnnotationsReferToEntry(t, entry, annotations)
+	ref, err = repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[6])
-		assert.Nil(t, err)
-		assert.Equal(t, entryIDs[2], entry.ID)
-		assertAnnotationsReferToEntry(t, entry, annotations)
+	commitObj, err = gitinterface.GetCommit(repo, ref.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, committerName, commitObj.Committer.Name)
+	assert.Equal(t, committerEmail, commitObj.Committer.Email)
+	assert.Equal(t, []plumbing.Hash{entryID}, commitObj.ParentHashes)
 
-		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[7])
-		assert.Nil(t, err)
-		assert.Equal(t, entryIDs[6], entry.ID)
-		assertAnnotationsReferToEntry(t, entry, annotations)
+	err = NewAnnotationEntry([]plumbing.Hash{plumbing.NewHash("abcdef1234567890")}, true, "message").CommitWithCommitter(repo, committerName, committerEmail, false)
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+}
 
-		_, _, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[1])
-		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
-	})
+func TestIsRSLRef(t *testing.T) {
+	assert.True(t, IsRSLRef(Ref))
+	assert.True(t, IsRSLRef(RemoteTrackerRef("origin")))
+	assert.True(t, IsRSLRef(RemoteTrackerRef("upstream")))
+	assert.False(t, IsRSLRef("refs/heads/main"))
+	assert.False(t, IsRSLRef("refs/remotes/origin/main"))
+	assert.False(t, IsRSLRef("refs/remotes//gittuf/reference-state-log"))
+	assert.False(t, IsRSLRef("refs/gittuf/policy"))
 }
 
-func TestGetEntry(t *testing.T) {
+func TestReferenceEntryForRSLRef(t *testing.T) {
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
@@ -413,51 +449,42 @@ func TestGetEntry(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
-		t.Error(err)
-	}
+	err = NewReferenceEntry(Ref, plumbing.ZeroHash).Commit(repo, false)
+	assert.ErrorIs(t, err, ErrCannotRecordRSLRef)
+
+	err = NewReferenceEntry(RemoteTrackerRef("origin"), plumbing.ZeroHash).Commit(repo, false)
+	assert.ErrorIs(t, err, ErrCannotRecordRSLRef)
+
+	_, err = NewReferenceEntry(Ref, plumbing.ZeroHash).commitWithParent(repo, plumbing.ZeroHash, false)
+	assert.ErrorIs(t, err, ErrCannotRecordRSLRef)
 
 	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
 	if err != nil {
 		t.Fatal(err)
 	}
+	assert.Equal(t, plumbing.ZeroHash, ref.Hash())
 
-	initialEntryID := ref.Hash()
-
-	if err := NewAnnotationEntry([]plumbing.Hash{initialEntryID}, true, "This was a mistaken push!").Commit(repo, false); err != nil {
-		t.Error(err)
+	// Existing entries for the RSL ref are flagged
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
 	}
-
-	ref, err = repo.Reference(plumbing.ReferenceName(Ref), true)
+	firstEntry, err := GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	annotationID := ref.Hash()
-
-	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
-		t.Error(err)
-	}
-
-	if entry, err := GetEntry(repo, initialEntryID); err != nil {
-		t.Error(err)
-	} else {
-		e := entry.(*ReferenceEntry)
-		assert.Equal(t, "main", e.RefName)
-		assert.Equal(t, plumbing.ZeroHash, e.TargetID)
+	message := fmt.Sprintf("%s\n\n%s: %s\n%s: %s", ReferenceEntryHeader, RefKey, Ref, TargetIDKey, firstEntry.GetID().String())
+	entryID, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, message, false)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	if entry, err := GetEntry(repo, annotationID); err != nil {
-		t.Error(err)
-	} else {
-		a := entry.(*AnnotationEntry)
-		assert.True(t, a.Skip)
-		assert.Equal(t, []plumbing.Hash{initialEntryID}, a.RSLEntryIDs)
-		assert.Equal(t, "This was a mistaken push!", a.Message)
-	}
+	err = VerifyRSLChainIntegrity(repo, firstEntry.GetID(), entryID)
+	assert.ErrorIs(t, err, ErrInvalidRSLChain)
+	assert.ErrorIs(t, err, ErrCannotRecordRSLRef)
 }
 
-func TestGetParentForEntry(t *testing.T) {
+func TestReferenceEntryCommitWithParent(t *testing.T) {
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
@@ -467,530 +494,1486 @@ func TestGetParentForEntry(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Assert no parent for first entry
-	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
-		t.Fatal(err)
-	}
-
-	entry, err := GetLatestEntry(repo)
-	if err != nil {
-		t.Fatal(err)
-	}
-	entryID := entry.GetID()
-
-	_, err = GetParentForEntry(repo, entry)
-	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
-
-	// Find parent for an entry
 	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
-
-	entry, err = GetLatestEntry(repo)
+	firstEntry, err := GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	parentEntry, err := GetParentForEntry(repo, entry)
-	assert.Nil(t, err)
-	assert.Equal(t, entryID, parentEntry.GetID())
-
-	entryID = entry.GetID()
-
-	// Find parent for an annotation
-	if err := NewAnnotationEntry([]plumbing.Hash{entryID}, false, annotationMessage).Commit(repo, false); err != nil {
+	if err := NewReferenceEntry("main", plumbing.NewHash("abcdef1234567890")).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
-
-	entry, err = GetLatestEntry(repo)
+	secondEntry, err := GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	parentEntry, err = GetParentForEntry(repo, entry)
-	assert.Nil(t, err)
-	assert.Equal(t, entryID, parentEntry.GetID())
-}
+	t.Run("entry with no parent", func(t *testing.T) {
+		entryID, err := NewReferenceEntry("feature", plumbing.ZeroHash).commitWithParent(repo, plumbing.ZeroHash, false)
+		assert.Nil(t, err)
 
-func TestGetNonGittufParentReferenceEntryForEntry(t *testing.T) {
-	t.Run("mix of gittuf and non gittuf entries", func(t *testing.T) {
-		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		commitObj, err := gitinterface.GetCommit(repo, entryID)
 		if err != nil {
 			t.Fatal(err)
 		}
+		assert.Empty(t, commitObj.ParentHashes)
+	})
 
-		if err := InitializeNamespace(repo); err != nil {
-			t.Fatal(err)
-		}
-
-		// Add the first gittuf entry
-		if err := NewReferenceEntry("refs/gittuf/policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+	t.Run("entry with specified parent", func(t *testing.T) {
+		entryID, err := NewReferenceEntry("feature", plumbing.ZeroHash).commitWithParent(repo, firstEntry.GetID(), false)
+		assert.Nil(t, err)
 
-		// Add non gittuf entry
-		if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		commitObj, err := gitinterface.GetCommit(repo, entryID)
+		if err != nil {
 			t.Fatal(err)
 		}
+		expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d", ReferenceEntryHeader, RefKey, "feature", TargetIDKey, plumbing.ZeroHash.String(), NumberKey, 2)
+		assert.Equal(t, expectedMessage, commitObj.Message)
+		assert.Equal(t, []plumbing.Hash{firstEntry.GetID()}, commitObj.ParentHashes)
 
-		expectedEntry, err := GetLatestEntry(repo)
+		// The RSL ref must be unchanged
+		latestEntry, err := GetLatestEntry(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
+		assert.Equal(t, secondEntry.GetID(), latestEntry.GetID())
+	})
 
-		// Add non gittuf entry
-		if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+	t.Run("parent is not an RSL entry", func(t *testing.T) {
+		treeHash, err := gitinterface.WriteTree(repo, nil)
+		if err != nil {
 			t.Fatal(err)
 		}
-
-		latestEntry, err := GetLatestEntry(repo)
+		commitID, err := gitinterface.CommitWithParent(repo, treeHash, plumbing.ZeroHash, "Test commit", false)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		parentEntry, annotations, err := GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, expectedEntry, parentEntry)
-
-		// Add another gittuf entry and then a non gittuf entry
-		expectedEntry = latestEntry
+		_, err = NewReferenceEntry("feature", plumbing.ZeroHash).commitWithParent(repo, commitID, false)
+		assert.ErrorIs(t, err, ErrInvalidRSLEntry)
+	})
 
-		if err := NewReferenceEntry("refs/gittuf/not-policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
+	t.Run("parent is a merge commit", func(t *testing.T) {
+		commit := &object.Commit{
+			Message:      "Test commit",
+			TreeHash:     gitinterface.EmptyTree(),
+			ParentHashes: []plumbing.Hash{firstEntry.GetID(), secondEntry.GetID()},
 		}
-		if err := NewReferenceEntry("refs/gittuf/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
-
-		latestEntry, err = GetLatestEntry(repo)
+		commitID, err := gitinterface.WriteCommit(repo, commit)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// The expected entry should be from before this latest gittuf addition
-		parentEntry, annotations, err = GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, expectedEntry, parentEntry)
+		_, err = NewReferenceEntry("feature", plumbing.ZeroHash).commitWithParent(repo, commitID, false)
+		assert.ErrorIs(t, err, ErrNonLinearRSL)
+	})
+}
 
-		// Add annotation pertaining to the expected entry
-		if err := NewAnnotationEntry([]plumbing.Hash{expectedEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+func TestAnnotationEntryCommitWithParent(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
 
-		parentEntry, annotations, err = GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
-		assert.Nil(t, err)
-		assert.Equal(t, expectedEntry, parentEntry)
-		assertAnnotationsReferToEntry(t, parentEntry, annotations)
-	})
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
 
-	t.Run("only gittuf entries", func(t *testing.T) {
-		repo, err := git.Init(memory.NewStorage(), memfs.New())
-		if err != nil {
-			t.Fatal(err)
-		}
+	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	firstEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-		if err := InitializeNamespace(repo); err != nil {
-			t.Fatal(err)
-		}
+	if err := NewReferenceEntry("main", plumbing.NewHash("abcdef1234567890")).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	secondEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-		// Add the first gittuf entry
-		if err := NewReferenceEntry("refs/gittuf/policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+	t.Run("annotation refers to ancestor", func(t *testing.T) {
+		entryID, err := NewAnnotationEntry([]plumbing.Hash{firstEntry.GetID()}, true, annotationMessage).commitWithParent(repo, firstEntry.GetID(), false)
+		assert.Nil(t, err)
 
-		latestEntry, err := GetLatestEntry(repo)
+		entry, err := GetEntry(repo, entryID)
 		if err != nil {
 			t.Fatal(err)
 		}
-		_, _, err = GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
-		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
-
-		// Add another gittuf entry
-		if err := NewReferenceEntry("refs/gittuf/not-policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
+		annotation, ok := entry.(*AnnotationEntry)
+		if !ok {
+			t.Fatal("expected annotation entry")
 		}
+		assert.Equal(t, []plumbing.Hash{firstEntry.GetID()}, annotation.RSLEntryIDs)
+		assert.True(t, annotation.Skip)
 
-		latestEntry, err = GetLatestEntry(repo)
+		parentEntry, err := GetParentForEntry(repo, annotation)
 		if err != nil {
 			t.Fatal(err)
 		}
+		assert.Equal(t, firstEntry.GetID(), parentEntry.GetID())
+	})
 
-		_, _, err = GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
+	t.Run("annotation refers to entry not in parent's history", func(t *testing.T) {
+		_, err := NewAnnotationEntry([]plumbing.Hash{secondEntry.GetID()}, true, annotationMessage).commitWithParent(repo, firstEntry.GetID(), false)
+		assert.ErrorIs(t, err, ErrNonLinearRSL)
+	})
+
+	t.Run("annotation with no parent", func(t *testing.T) {
+		_, err := NewAnnotationEntry([]plumbing.Hash{firstEntry.GetID()}, true, annotationMessage).commitWithParent(repo, plumbing.ZeroHash, false)
 		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
 	})
 }
 
-func TestGetFirstEntry(t *testing.T) {
+func TestGetLatestEntry(t *testing.T) {
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	if err := InitializeNamespace(repo); err != nil {
-		t.Fatal(err)
+		t.Error(err)
 	}
 
-	if err := NewReferenceEntry("first", plumbing.ZeroHash).Commit(repo, false); err != nil {
-		t.Fatal(err)
+	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Error(err)
 	}
 
-	firstEntryT, err := GetLatestEntry(repo)
-	if err != nil {
-		t.Fatal(err)
+	if entry, err := GetLatestEntry(repo); err != nil {
+		t.Error(err)
+	} else {
+		e := entry.(*ReferenceEntry)
+		assert.Equal(t, "main", e.RefName)
+		assert.Equal(t, plumbing.ZeroHash, e.TargetID)
 	}
-	firstEntry := firstEntryT.(*ReferenceEntry)
 
-	for i := 0; i < 5; i++ {
-		if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+	if err := NewReferenceEntry("feature", plumbing.NewHash("abcdef1234567890")).Commit(repo, false); err != nil {
+		t.Error(err)
+	}
+	if entry, err := GetLatestEntry(repo); err != nil {
+		t.Error(err)
+	} else {
+		e := entry.(*ReferenceEntry)
+		assert.NotEqual(t, "main", e.RefName)
+		assert.NotEqual(t, plumbing.ZeroHash, e.TargetID)
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entryID := ref.Hash()
+
+	if err := NewAnnotationEntry([]plumbing.Hash{entryID}, true, "This was a mistaken push!").Commit(repo, false); err != nil {
+		t.Error(err)
+	}
+
+	if entry, err := GetLatestEntry(repo); err != nil {
+		t.Error(err)
+	} else {
+		a := entry.(*AnnotationEntry)
+		assert.True(t, a.Skip)
+		assert.Equal(t, []plumbing.Hash{entryID}, a.RSLEntryIDs)
+		assert.Equal(t, "This was a mistaken push!", a.Message)
+	}
+}
+
+func TestGetLatestNonGittufReferenceEntry(t *testing.T) {
+	t.Run("mix of gittuf and non gittuf entries", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add the first gittuf entry
+		if err := NewReferenceEntry("refs/gittuf/policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add non gittuf entries
+		if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// At this point, latest entry should be returned
+		expectedLatestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		latestEntry, annotations, err := GetLatestNonGittufReferenceEntry(repo)
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, expectedLatestEntry, latestEntry)
+
+		// Add another gittuf entry
+		if err := NewReferenceEntry("refs/gittuf/not-policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// At this point, the expected entry is the same as before
+		latestEntry, annotations, err = GetLatestNonGittufReferenceEntry(repo)
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, expectedLatestEntry, latestEntry)
+
+		// Add an annotation for latest entry, check that it's returned
+		if err := NewAnnotationEntry([]plumbing.Hash{expectedLatestEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, annotations, err = GetLatestNonGittufReferenceEntry(repo)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedLatestEntry, latestEntry)
+		assertAnnotationsReferToEntry(t, latestEntry, annotations)
+	})
+
+	t.Run("only gittuf entries", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add the first gittuf entry
+		if err := NewReferenceEntry("refs/gittuf/policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err = GetLatestNonGittufReferenceEntry(repo)
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+		// Add another gittuf entry
+		if err := NewReferenceEntry("refs/gittuf/not-policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
 			t.Fatal(err)
 		}
+
+		_, _, err = GetLatestNonGittufReferenceEntry(repo)
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
+}
+
+func TestGetLatestReferenceEntryForRef(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	testEntry, annotations, err := GetFirstEntry(repo)
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	otherRefName := "refs/heads/feature"
+
+	if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	rslRef, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, annotations, err := GetLatestReferenceEntryForRef(repo, refName)
 	assert.Nil(t, err)
 	assert.Nil(t, annotations)
-	assert.Equal(t, firstEntry, testEntry)
+	assert.Equal(t, rslRef.Hash(), entry.ID)
+
+	if err := NewReferenceEntry(otherRefName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, annotations, err = GetLatestReferenceEntryForRef(repo, refName)
+	assert.Nil(t, err)
+	assert.Nil(t, annotations)
+	assert.Equal(t, rslRef.Hash(), entry.ID)
+
+	// Add annotation for the target entry
+	if err := NewAnnotationEntry([]plumbing.Hash{entry.ID}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, annotations, err = GetLatestReferenceEntryForRef(repo, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, rslRef.Hash(), entry.ID)
+	assertAnnotationsReferToEntry(t, entry, annotations)
+}
+
+func TestGetLatestReferenceEntryForRefBefore(t *testing.T) {
+	t.Run("no annotations", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		// RSL structure for the test
+		// main <- feature <- main <- feature <- main
+		testRefs := []string{"main", "feature", "main", "feature", "main"}
+		entryIDs := []plumbing.Hash{}
+		for _, ref := range testRefs {
+			if err := NewReferenceEntry(ref, plumbing.ZeroHash).Commit(repo, false); err != nil {
+				t.Fatal(err)
+			}
+			latest, err := GetLatestEntry(repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entryIDs = append(entryIDs, latest.GetID())
+		}
+
+		entry, annotations, err := GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[4])
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, entryIDs[2], entry.ID)
+
+		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[3])
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, entryIDs[2], entry.ID)
+
+		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[4])
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, entryIDs[3], entry.ID)
+
+		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[3])
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, entryIDs[1], entry.ID)
+
+		_, _, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[1])
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
+
+	t.Run("with annotations", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		// RSL structure for the test
+		// main <- A <- feature <- A <- main <- A <- feature <- A <- main <- A
+		testRefs := []string{"main", "feature", "main", "feature", "main"}
+		entryIDs := []plumbing.Hash{}
+		for _, ref := range testRefs {
+			if err := NewReferenceEntry(ref, plumbing.ZeroHash).Commit(repo, false); err != nil {
+				t.Fatal(err)
+			}
+			latest, err := GetLatestEntry(repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entryIDs = append(entryIDs, latest.GetID())
+
+			if err := NewAnnotationEntry([]plumbing.Hash{latest.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+				t.Fatal(err)
+			}
+			latest, err = GetLatestEntry(repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entryIDs = append(entryIDs, latest.GetID())
+		}
+
+		entry, annotations, err := GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[4])
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[0], entry.ID)
+		assertAnnotationsReferToEntry(t, entry, annotations)
+		// Add an annotation at the end for some entry and see it gets pulled in
+		// even when the anchor is for its ancestor
+		assert.Len(t, annotations, 1) // before adding an annotation, we have just 1
+		if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[0]}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[4])
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[0], entry.ID)
+		assertAnnotationsReferToEntry(t, entry, annotations)
+		assert.Len(t, annotations, 2) // now we have 2
+
+		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "main", entryIDs[3])
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[0], entry.ID)
+		assertAnnotationsReferToEntry(t, entry, annotations)
+
+		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[6])
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[2], entry.ID)
+		assertAnnotationsReferToEntry(t, entry, annotations)
+
+		entry, annotations, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[7])
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[6], entry.ID)
+		assertAnnotationsReferToEntry(t, entry, annotations)
+
+		_, _, err = GetLatestReferenceEntryForRefBefore(repo, "feature", entryIDs[1])
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
+}
+
+func TestAnnotationEntryMessageSize(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewAnnotationEntry([]plumbing.Hash{entry.GetID()}, false, strings.Repeat("a", DefaultMaxAnnotationMessageSize)).Commit(repo, false)
+	assert.Nil(t, err)
+
+	err = NewAnnotationEntry([]plumbing.Hash{entry.GetID()}, false, strings.Repeat("a", DefaultMaxAnnotationMessageSize+1)).Commit(repo, false)
+	assert.ErrorIs(t, err, ErrAnnotationMessageTooLarge)
+
+	t.Run("custom limit", func(t *testing.T) {
+		MaxAnnotationMessageSize = 8
+		defer func() {
+			MaxAnnotationMessageSize = DefaultMaxAnnotationMessageSize
+		}()
+
+		err := NewAnnotationEntry([]plumbing.Hash{entry.GetID()}, false, "short").Commit(repo, false)
+		assert.Nil(t, err)
+
+		err = NewAnnotationEntry([]plumbing.Hash{entry.GetID()}, false, "too long message").Commit(repo, false)
+		assert.ErrorIs(t, err, ErrAnnotationMessageTooLarge)
+	})
+
+	t.Run("limit disabled", func(t *testing.T) {
+		MaxAnnotationMessageSize = 0
+		defer func() {
+			MaxAnnotationMessageSize = DefaultMaxAnnotationMessageSize
+		}()
+
+		err := NewAnnotationEntry([]plumbing.Hash{entry.GetID()}, false, strings.Repeat("a", DefaultMaxAnnotationMessageSize+1)).Commit(repo, false)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGetEntry(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Error(err)
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initialEntryID := ref.Hash()
+
+	if err := NewAnnotationEntry([]plumbing.Hash{initialEntryID}, true, "This was a mistaken push!").Commit(repo, false); err != nil {
+		t.Error(err)
+	}
+
+	ref, err = repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	annotationID := ref.Hash()
+
+	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Error(err)
+	}
+
+	if entry, err := GetEntry(repo, initialEntryID); err != nil {
+		t.Error(err)
+	} else {
+		e := entry.(*ReferenceEntry)
+		assert.Equal(t, "main", e.RefName)
+		assert.Equal(t, plumbing.ZeroHash, e.TargetID)
+	}
+
+	if entry, err := GetEntry(repo, annotationID); err != nil {
+		t.Error(err)
+	} else {
+		a := entry.(*AnnotationEntry)
+		assert.True(t, a.Skip)
+		assert.Equal(t, []plumbing.Hash{initialEntryID}, a.RSLEntryIDs)
+		assert.Equal(t, "This was a mistaken push!", a.Message)
+	}
+}
+
+func TestGetParentForEntry(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert no parent for first entry
+	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entryID := entry.GetID()
+
+	_, err = GetParentForEntry(repo, entry)
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+	// Find parent for an entry
+	if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parentEntry, err := GetParentForEntry(repo, entry)
+	assert.Nil(t, err)
+	assert.Equal(t, entryID, parentEntry.GetID())
+
+	entryID = entry.GetID()
+
+	// Find parent for an annotation
+	if err := NewAnnotationEntry([]plumbing.Hash{entryID}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parentEntry, err = GetParentForEntry(repo, entry)
+	assert.Nil(t, err)
+	assert.Equal(t, entryID, parentEntry.GetID())
+
+	// Detect RSL branch for an entry with multiple parents
+	message, _ := NewReferenceEntry("main", plumbing.ZeroHash).createCommitMessage()
+	mergeEntryID, err := gitinterface.CommitWithParents(repo, gitinterface.EmptyTree(), []plumbing.Hash{entryID, entry.GetID()}, Ref, message, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err = GetEntry(repo, mergeEntryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = GetParentForEntry(repo, entry)
+	assert.ErrorIs(t, err, ErrRSLBranchDetected)
+}
+
+func TestGetNonGittufParentReferenceEntryForEntry(t *testing.T) {
+	t.Run("mix of gittuf and non gittuf entries", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add the first gittuf entry
+		if err := NewReferenceEntry("refs/gittuf/policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add non gittuf entry
+		if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		expectedEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Add non gittuf entry
+		if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parentEntry, annotations, err := GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, expectedEntry, parentEntry)
+
+		// Add another gittuf entry and then a non gittuf entry
+		expectedEntry = latestEntry
+
+		if err := NewReferenceEntry("refs/gittuf/not-policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := NewReferenceEntry("refs/gittuf/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err = GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The expected entry should be from before this latest gittuf addition
+		parentEntry, annotations, err = GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, expectedEntry, parentEntry)
+
+		// Add annotation pertaining to the expected entry
+		if err := NewAnnotationEntry([]plumbing.Hash{expectedEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		parentEntry, annotations, err = GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedEntry, parentEntry)
+		assertAnnotationsReferToEntry(t, parentEntry, annotations)
+	})
+
+	t.Run("only gittuf entries", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add the first gittuf entry
+		if err := NewReferenceEntry("refs/gittuf/policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+		// Add another gittuf entry
+		if err := NewReferenceEntry("refs/gittuf/not-policy", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err = GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err = GetNonGittufParentReferenceEntryForEntry(repo, latestEntry)
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
+}
+
+func TestGetFirstEntry(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("first", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	firstEntryT, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstEntry := firstEntryT.(*ReferenceEntry)
+
+	for i := 0; i < 5; i++ {
+		if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testEntry, annotations, err := GetFirstEntry(repo)
+	assert.Nil(t, err)
+	assert.Nil(t, annotations)
+	assert.Equal(t, firstEntry, testEntry)
+
+	for i := 0; i < 5; i++ {
+		if err := NewAnnotationEntry([]plumbing.Hash{firstEntry.ID}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testEntry, annotations, err = GetFirstEntry(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, firstEntry, testEntry)
+	assert.Equal(t, 5, len(annotations))
+	assertAnnotationsReferToEntry(t, firstEntry, annotations)
+}
+
+func TestGetFirstReferenceEntryForRef(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("first", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	firstEntryT, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstEntry := firstEntryT.(*ReferenceEntry)
+
+	for i := 0; i < 5; i++ {
+		if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testEntry, annotations, err := GetFirstReferenceEntryForRef(repo, "first")
+	assert.Nil(t, err)
+	assert.Nil(t, annotations)
+	assert.Equal(t, firstEntry, testEntry)
+
+	for i := 0; i < 5; i++ {
+		if err := NewAnnotationEntry([]plumbing.Hash{firstEntry.ID}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testEntry, annotations, err = GetFirstReferenceEntryForRef(repo, "first")
+	assert.Nil(t, err)
+	assert.Equal(t, firstEntry, testEntry)
+	assert.Equal(t, 5, len(annotations))
+	assertAnnotationsReferToEntry(t, firstEntry, annotations)
+}
+
+func TestGetFirstReferenceEntryForCommit(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyTreeHash, err := gitinterface.WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mainRef := "refs/heads/main"
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(mainRef), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	initialTargetIDs := []plumbing.Hash{}
+	for i := 0; i < 3; i++ {
+		commitID, err := gitinterface.Commit(repo, emptyTreeHash, mainRef, "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		initialTargetIDs = append(initialTargetIDs, commitID)
+	}
+
+	// Right now, the RSL has no entries.
+	for _, commitID := range initialTargetIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = GetFirstReferenceEntryForCommit(repo, commit)
+		assert.ErrorIs(t, err, ErrNoRecordOfCommit)
+	}
+
+	if err := NewReferenceEntry(mainRef, initialTargetIDs[len(initialTargetIDs)-1]).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// At this point, searching for any commit's entry should return the
+	// solitary RSL entry.
+	latestEntryT, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, commitID := range initialTargetIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, latestEntryT, entry)
+	}
+
+	// Now, let's branch off from this ref and add more commits.
+	featureRef := "refs/heads/feature"
+	// First, "checkout" the feature branch.
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(featureRef), initialTargetIDs[len(initialTargetIDs)-1])); err != nil {
+		t.Fatal(err)
+	}
+	// Next, add some new commits to this branch.
+	featureTargetIDs := []plumbing.Hash{}
+	for i := 0; i < 3; i++ {
+		commitID, err := gitinterface.Commit(repo, emptyTreeHash, featureRef, "Feature commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		featureTargetIDs = append(featureTargetIDs, commitID)
+	}
+
+	// The RSL hasn't seen these new commits, however.
+	for _, commitID := range featureTargetIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = GetFirstReferenceEntryForCommit(repo, commit)
+		assert.ErrorIs(t, err, ErrNoRecordOfCommit)
+	}
+
+	if err := NewReferenceEntry(featureRef, featureTargetIDs[len(featureTargetIDs)-1]).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// At this point, searching for any of the original commits' entry should
+	// return the first RSL entry.
+	for _, commitID := range initialTargetIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, latestEntryT, entry)
+	}
+	// Searching for the feature commits should return the second entry.
+	latestEntryT, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, commitID := range featureTargetIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, latestEntryT, entry)
+	}
+
+	// Now, fast forward main branch to the latest feature branch commit.
+	oldRef, err := repo.Reference(plumbing.ReferenceName(mainRef), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRef := plumbing.NewHashReference(plumbing.ReferenceName(mainRef), featureTargetIDs[len(featureTargetIDs)-1])
+	if err := repo.Storer.CheckAndSetReference(newRef, oldRef); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry(mainRef, featureTargetIDs[len(featureTargetIDs)-1]).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Testing for any of the feature commits should return the feature branch
+	// entry, not the main branch entry.
+	for _, commitID := range featureTargetIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
+		assert.Nil(t, err)
+		assert.Nil(t, annotations)
+		assert.Equal(t, latestEntryT, entry)
+	}
+
+	// Add annotation for feature entry
+	if err := NewAnnotationEntry([]plumbing.Hash{latestEntryT.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntry := latestEntryT.(*ReferenceEntry)
+	for _, commitID := range featureTargetIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
+		assert.Nil(t, err)
+		assert.Equal(t, latestEntryT, entry)
+		assertAnnotationsReferToEntry(t, latestEntry, annotations)
+	}
+}
+
+func TestGetReferenceEntriesInRange(t *testing.T) {
+	refName := "refs/heads/main"
+	anotherRefName := "refs/heads/feature"
+
+	// We add a mix of reference entries and annotations, establishing expected
+	// return values as we go along
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEntries := []*ReferenceEntry{}
+	expectedAnnotationMap := map[plumbing.Hash][]*AnnotationEntry{}
+
+	// Add some entries to main
+	for i := 0; i < 3; i++ {
+		if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// We run GetLatestEntry so that the entry has its ID set as well
+		entry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedEntries = append(expectedEntries, entry.(*ReferenceEntry))
+	}
+
+	// Add some annotations
+	for i := 0; i < 3; i++ {
+		if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[i].ID}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		annotation, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedAnnotationMap[expectedEntries[i].ID] = []*AnnotationEntry{annotation.(*AnnotationEntry)}
+	}
+
+	// Each entry has one annotation
+	entries, annotationMap, err := GetReferenceEntriesInRange(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, expectedAnnotationMap, annotationMap)
+
+	// Add an entry and annotation for feature branch
+	if err := NewReferenceEntry(anotherRefName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedEntries = append(expectedEntries, latestEntry.(*ReferenceEntry))
+	if err := NewAnnotationEntry([]plumbing.Hash{latestEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedAnnotationMap[expectedEntries[len(expectedEntries)-1].ID] = []*AnnotationEntry{latestEntry.(*AnnotationEntry)}
+
+	// Expected values include the feature branch entry and annotation
+	entries, annotationMap, err = GetReferenceEntriesInRange(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, expectedAnnotationMap, annotationMap)
+
+	// Add an annotation that refers to two valid entries
+	if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[0].ID, expectedEntries[1].ID}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// This annotation is relevant to both entries
+	annotation := latestEntry.(*AnnotationEntry)
+	expectedAnnotationMap[expectedEntries[0].ID] = append(expectedAnnotationMap[expectedEntries[0].ID], annotation)
+	expectedAnnotationMap[expectedEntries[1].ID] = append(expectedAnnotationMap[expectedEntries[1].ID], annotation)
+
+	entries, annotationMap, err = GetReferenceEntriesInRange(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, expectedAnnotationMap, annotationMap)
+
+	// Add a gittuf namespace entry and ensure it's returned as relevant
+	if err := NewReferenceEntry("refs/gittuf/relevant", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedEntries = append(expectedEntries, latestEntry.(*ReferenceEntry))
+
+	entries, annotationMap, err = GetReferenceEntriesInRange(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, expectedAnnotationMap, annotationMap)
+}
+
+func TestGetReferenceEntriesInRangeForRef(t *testing.T) {
+	refName := "refs/heads/main"
+	anotherRefName := "refs/heads/feature"
+
+	// We add a mix of reference entries and annotations, establishing expected
+	// return values as we go along
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEntries := []*ReferenceEntry{}
+	expectedAnnotationMap := map[plumbing.Hash][]*AnnotationEntry{}
+
+	// Add some entries to main
+	for i := 0; i < 3; i++ {
+		if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// We run GetLatestEntry so that the entry has its ID set as well
+		entry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedEntries = append(expectedEntries, entry.(*ReferenceEntry))
+	}
+
+	// Add some annotations
+	for i := 0; i < 3; i++ {
+		if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[i].ID}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
 
-	for i := 0; i < 5; i++ {
-		if err := NewAnnotationEntry([]plumbing.Hash{firstEntry.ID}, false, annotationMessage).Commit(repo, false); err != nil {
+		annotation, err := GetLatestEntry(repo)
+		if err != nil {
 			t.Fatal(err)
 		}
+		expectedAnnotationMap[expectedEntries[i].ID] = []*AnnotationEntry{annotation.(*AnnotationEntry)}
 	}
 
-	testEntry, annotations, err = GetFirstEntry(repo)
+	// Each entry has one annotation
+	entries, annotationMap, err := GetReferenceEntriesInRangeForRef(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID, refName)
 	assert.Nil(t, err)
-	assert.Equal(t, firstEntry, testEntry)
-	assert.Equal(t, 5, len(annotations))
-	assertAnnotationsReferToEntry(t, firstEntry, annotations)
-}
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, expectedAnnotationMap, annotationMap)
 
-func TestGetFirstReferenceEntryForRef(t *testing.T) {
-	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	// Add an entry and annotation for feature branch
+	if err := NewReferenceEntry(anotherRefName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err := GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
-
-	if err := InitializeNamespace(repo); err != nil {
+	if err := NewAnnotationEntry([]plumbing.Hash{latestEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
 
-	if err := NewReferenceEntry("first", plumbing.ZeroHash).Commit(repo, false); err != nil {
+	// Expected values do not change
+	entries, annotationMap, err = GetReferenceEntriesInRangeForRef(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, expectedAnnotationMap, annotationMap)
+
+	// Add an annotation that refers to two valid entries
+	if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[0].ID, expectedEntries[1].ID}, false, annotationMessage).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
-
-	firstEntryT, err := GetLatestEntry(repo)
+	latestEntry, err = GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
-	firstEntry := firstEntryT.(*ReferenceEntry)
-
-	for i := 0; i < 5; i++ {
-		if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
-	}
+	// This annotation is relevant to both entries
+	annotation := latestEntry.(*AnnotationEntry)
+	expectedAnnotationMap[expectedEntries[0].ID] = append(expectedAnnotationMap[expectedEntries[0].ID], annotation)
+	expectedAnnotationMap[expectedEntries[1].ID] = append(expectedAnnotationMap[expectedEntries[1].ID], annotation)
 
-	testEntry, annotations, err := GetFirstReferenceEntryForRef(repo, "first")
+	entries, annotationMap, err = GetReferenceEntriesInRangeForRef(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID, refName)
 	assert.Nil(t, err)
-	assert.Nil(t, annotations)
-	assert.Equal(t, firstEntry, testEntry)
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, expectedAnnotationMap, annotationMap)
 
-	for i := 0; i < 5; i++ {
-		if err := NewAnnotationEntry([]plumbing.Hash{firstEntry.ID}, false, annotationMessage).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+	// Add a gittuf namespace entry and ensure it's returned as relevant
+	if err := NewReferenceEntry("refs/gittuf/relevant", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
 	}
+	expectedEntries = append(expectedEntries, latestEntry.(*ReferenceEntry))
 
-	testEntry, annotations, err = GetFirstReferenceEntryForRef(repo, "first")
+	entries, annotationMap, err = GetReferenceEntriesInRangeForRef(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID, refName)
 	assert.Nil(t, err)
-	assert.Equal(t, firstEntry, testEntry)
-	assert.Equal(t, 5, len(annotations))
-	assertAnnotationsReferToEntry(t, firstEntry, annotations)
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, expectedAnnotationMap, annotationMap)
 }
 
-func TestGetFirstReferenceEntryForCommit(t *testing.T) {
+func TestGetReferenceEntriesInRangeWithLimit(t *testing.T) {
+	refName := "refs/heads/main"
+
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
 	}
-
 	if err := InitializeNamespace(repo); err != nil {
 		t.Fatal(err)
 	}
 
-	emptyTreeHash, err := gitinterface.WriteTree(repo, nil)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	mainRef := "refs/heads/main"
-	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(mainRef), plumbing.ZeroHash)); err != nil {
-		t.Fatal(err)
-	}
-
-	initialTargetIDs := []plumbing.Hash{}
-	for i := 0; i < 3; i++ {
-		commitID, err := gitinterface.Commit(repo, emptyTreeHash, mainRef, "Test commit", false)
-		if err != nil {
+	expectedEntries := []*ReferenceEntry{}
+	for i := 0; i < 5; i++ {
+		if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
 			t.Fatal(err)
 		}
 
-		initialTargetIDs = append(initialTargetIDs, commitID)
-	}
-
-	// Right now, the RSL has no entries.
-	for _, commitID := range initialTargetIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
+		entry, err := GetLatestEntry(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
-		_, _, err = GetFirstReferenceEntryForCommit(repo, commit)
-		assert.ErrorIs(t, err, ErrNoRecordOfCommit)
+		expectedEntries = append(expectedEntries, entry.(*ReferenceEntry))
 	}
 
-	if err := NewReferenceEntry(mainRef, initialTargetIDs[len(initialTargetIDs)-1]).Commit(repo, false); err != nil {
+	if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[4].ID}, false, annotationMessage).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
-
-	// At this point, searching for any commit's entry should return the
-	// solitary RSL entry.
-	latestEntryT, err := GetLatestEntry(repo)
+	annotation, err := GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, commitID := range initialTargetIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
-		if err != nil {
-			t.Fatal(err)
-		}
-		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
+
+	firstID := expectedEntries[0].ID
+	lastID := expectedEntries[4].ID
+
+	t.Run("range within limit", func(t *testing.T) {
+		entries, _, err := GetReferenceEntriesInRangeWithLimit(repo, firstID, lastID, refName, 5)
 		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, latestEntryT, entry)
-	}
+		assert.Equal(t, expectedEntries, entries)
+	})
 
-	// Now, let's branch off from this ref and add more commits.
-	featureRef := "refs/heads/feature"
-	// First, "checkout" the feature branch.
-	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(featureRef), initialTargetIDs[len(initialTargetIDs)-1])); err != nil {
+	t.Run("range exceeds limit", func(t *testing.T) {
+		entries, annotationMap, err := GetReferenceEntriesInRangeWithLimit(repo, firstID, lastID, refName, 2)
+		assert.ErrorIs(t, err, ErrRangeTooLarge)
+		assert.Equal(t, expectedEntries[3:], entries)
+		assert.Equal(t, map[plumbing.Hash][]*AnnotationEntry{lastID: {annotation.(*AnnotationEntry)}}, annotationMap)
+
+		// Request the rest of the range
+		entries, _, err = GetReferenceEntriesInRangeWithLimit(repo, firstID, expectedEntries[2].ID, refName, 2)
+		assert.ErrorIs(t, err, ErrRangeTooLarge)
+		assert.Equal(t, expectedEntries[1:3], entries)
+
+		entries, _, err = GetReferenceEntriesInRangeWithLimit(repo, firstID, expectedEntries[0].ID, refName, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedEntries[:1], entries)
+	})
+
+	t.Run("limit disabled", func(t *testing.T) {
+		entries, _, err := GetReferenceEntriesInRangeWithLimit(repo, firstID, lastID, refName, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedEntries, entries)
+	})
+}
+
+func TestGetReferenceEntriesInRangeWithSkipStatus(t *testing.T) {
+	refName := "refs/heads/main"
+	anotherRefName := "refs/heads/feature"
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
 		t.Fatal(err)
 	}
-	// Next, add some new commits to this branch.
-	featureTargetIDs := []plumbing.Hash{}
-	for i := 0; i < 3; i++ {
-		commitID, err := gitinterface.Commit(repo, emptyTreeHash, featureRef, "Feature commit", false)
-		if err != nil {
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	entryIDs := []plumbing.Hash{}
+	for _, name := range []string{refName, anotherRefName, refName} {
+		if err := NewReferenceEntry(name, plumbing.ZeroHash).Commit(repo, false); err != nil {
 			t.Fatal(err)
 		}
 
-		featureTargetIDs = append(featureTargetIDs, commitID)
-	}
-
-	// The RSL hasn't seen these new commits, however.
-	for _, commitID := range featureTargetIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
+		entry, err := GetLatestEntry(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
-		_, _, err = GetFirstReferenceEntryForCommit(repo, commit)
-		assert.ErrorIs(t, err, ErrNoRecordOfCommit)
+		entryIDs = append(entryIDs, entry.GetID())
 	}
 
-	if err := NewReferenceEntry(featureRef, featureTargetIDs[len(featureTargetIDs)-1]).Commit(repo, false); err != nil {
+	// Add a non-skip annotation for the first entry
+	if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[0]}, false, annotationMessage).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
 
-	// At this point, searching for any of the original commits' entry should
-	// return the first RSL entry.
-	for _, commitID := range initialTargetIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
-		if err != nil {
-			t.Fatal(err)
-		}
-		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, latestEntryT, entry)
+	// Add a skip annotation for the first and last entries
+	if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[0], entryIDs[2]}, true, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
 	}
-	// Searching for the feature commits should return the second entry.
-	latestEntryT, err = GetLatestEntry(repo)
+	skipAnnotation, err := GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, commitID := range featureTargetIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
-		if err != nil {
-			t.Fatal(err)
-		}
-		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
+
+	t.Run("all refs", func(t *testing.T) {
+		entries, err := GetReferenceEntriesInRangeWithSkipStatus(repo, entryIDs[0], entryIDs[2], "")
 		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, latestEntryT, entry)
-	}
+		assert.Equal(t, 3, len(entries))
 
-	// Now, fast forward main branch to the latest feature branch commit.
-	oldRef, err := repo.Reference(plumbing.ReferenceName(mainRef), true)
+		assert.Equal(t, entryIDs[0], entries[0].ID)
+		assert.Equal(t, 2, len(entries[0].Annotations))
+		assert.True(t, entries[0].Skipped)
+		assert.Equal(t, []plumbing.Hash{skipAnnotation.GetID()}, entries[0].SkippedBy)
+
+		assert.Equal(t, entryIDs[1], entries[1].ID)
+		assert.Nil(t, entries[1].Annotations)
+		assert.False(t, entries[1].Skipped)
+		assert.Empty(t, entries[1].SkippedBy)
+
+		assert.Equal(t, entryIDs[2], entries[2].ID)
+		assert.True(t, entries[2].Skipped)
+		assert.Equal(t, []plumbing.Hash{skipAnnotation.GetID()}, entries[2].SkippedBy)
+	})
+
+	t.Run("specific ref", func(t *testing.T) {
+		entries, err := GetReferenceEntriesInRangeWithSkipStatus(repo, entryIDs[0], entryIDs[2], refName)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, entryIDs[0], entries[0].ID)
+		assert.Equal(t, entryIDs[2], entries[1].ID)
+		assert.True(t, entries[0].Skipped)
+		assert.True(t, entries[1].Skipped)
+	})
+}
+
+func TestVerifyRSLChainIntegrity(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
 	}
-	newRef := plumbing.NewHashReference(plumbing.ReferenceName(mainRef), featureTargetIDs[len(featureTargetIDs)-1])
-	if err := repo.Storer.CheckAndSetReference(newRef, oldRef); err != nil {
-		t.Fatal(err)
-	}
 
-	if err := NewReferenceEntry(mainRef, featureTargetIDs[len(featureTargetIDs)-1]).Commit(repo, false); err != nil {
+	if err := InitializeNamespace(repo); err != nil {
 		t.Fatal(err)
 	}
 
-	// Testing for any of the feature commits should return the feature branch
-	// entry, not the main branch entry.
-	for _, commitID := range featureTargetIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
-		if err != nil {
+	entryIDs := []plumbing.Hash{}
+	for i := 0; i < 3; i++ {
+		if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
 			t.Fatal(err)
 		}
-		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
-		assert.Nil(t, err)
-		assert.Nil(t, annotations)
-		assert.Equal(t, latestEntryT, entry)
-	}
-
-	// Add annotation for feature entry
-	if err := NewAnnotationEntry([]plumbing.Hash{latestEntryT.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
-		t.Fatal(err)
-	}
-
-	latestEntry := latestEntryT.(*ReferenceEntry)
-	for _, commitID := range featureTargetIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
+		latestEntry, err := GetLatestEntry(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
-		entry, annotations, err := GetFirstReferenceEntryForCommit(repo, commit)
-		assert.Nil(t, err)
-		assert.Equal(t, latestEntryT, entry)
-		assertAnnotationsReferToEntry(t, latestEntry, annotations)
+		entryIDs = append(entryIDs, latestEntry.GetID())
 	}
-}
-
-func TestGetReferenceEntriesInRange(t *testing.T) {
-	refName := "refs/heads/main"
-	anotherRefName := "refs/heads/feature"
 
-	// We add a mix of reference entries and annotations, establishing expected
-	// return values as we go along
+	t.Run("full chain", func(t *testing.T) {
+		err := VerifyRSLChainIntegrity(repo, plumbing.ZeroHash, entryIDs[2])
+		assert.Nil(t, err)
+	})
 
-	repo, err := git.Init(memory.NewStorage(), memfs.New())
-	if err != nil {
-		t.Fatal(err)
-	}
-	if err := InitializeNamespace(repo); err != nil {
-		t.Fatal(err)
-	}
+	t.Run("partial chain", func(t *testing.T) {
+		err := VerifyRSLChainIntegrity(repo, entryIDs[0], entryIDs[2])
+		assert.Nil(t, err)
+	})
 
-	expectedEntries := []*ReferenceEntry{}
-	expectedAnnotationMap := map[plumbing.Hash][]*AnnotationEntry{}
+	t.Run("first entry is not an ancestor", func(t *testing.T) {
+		err := VerifyRSLChainIntegrity(repo, entryIDs[2], entryIDs[1])
+		assert.ErrorIs(t, err, ErrInvalidRSLChain)
+	})
 
-	// Add some entries to main
-	for i := 0; i < 3; i++ {
-		if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+	t.Run("invalid entry in chain", func(t *testing.T) {
+		invalidID, err := gitinterface.CommitWithParent(repo, gitinterface.EmptyTree(), entryIDs[2], "Not an RSL entry", false)
+		if err != nil {
 			t.Fatal(err)
 		}
 
-		// We run GetLatestEntry so that the entry has its ID set as well
-		entry, err := GetLatestEntry(repo)
+		// Add a valid entry on top of the invalid commit
+		message, _ := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).createCommitMessage()
+		latestID, err := gitinterface.CommitWithParent(repo, gitinterface.EmptyTree(), invalidID, message, false)
 		if err != nil {
 			t.Fatal(err)
 		}
-		expectedEntries = append(expectedEntries, entry.(*ReferenceEntry))
-	}
 
-	// Add some annotations
-	for i := 0; i < 3; i++ {
-		if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[i].ID}, false, annotationMessage).Commit(repo, false); err != nil {
+		err = VerifyRSLChainIntegrity(repo, entryIDs[2], latestID)
+		assert.ErrorIs(t, err, ErrInvalidRSLChain)
+	})
+
+	t.Run("entry with multiple parents", func(t *testing.T) {
+		message, _ := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).createCommitMessage()
+		mergeID, err := gitinterface.CommitWithParents(repo, gitinterface.EmptyTree(), []plumbing.Hash{entryIDs[1], entryIDs[2]}, "refs/heads/merge", message, false)
+		if err != nil {
 			t.Fatal(err)
 		}
 
-		annotation, err := GetLatestEntry(repo)
+		err = VerifyRSLChainIntegrity(repo, entryIDs[0], mergeID)
+		assert.ErrorIs(t, err, ErrInvalidRSLChain)
+	})
+
+	t.Run("propagation entry for RSL ref", func(t *testing.T) {
+		message, _ := NewPropagationEntry("refs/heads/main", plumbing.ZeroHash, "https://example.com/upstream", entryIDs[0]).createCommitMessage()
+		message = strings.Replace(message, "refs/heads/main", Ref, 1)
+		propagationID, err := gitinterface.CommitWithParent(repo, gitinterface.EmptyTree(), entryIDs[2], message, false)
 		if err != nil {
 			t.Fatal(err)
 		}
-		expectedAnnotationMap[expectedEntries[i].ID] = []*AnnotationEntry{annotation.(*AnnotationEntry)}
+
+		err = VerifyRSLChainIntegrity(repo, entryIDs[2], propagationID)
+		assert.ErrorIs(t, err, ErrInvalidRSLChain)
+		assert.ErrorIs(t, err, ErrCannotRecordRSLRef)
+	})
+}
+
+func TestBuildAnnotationTimeline(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	// Each entry has one annotation
-	entries, annotationMap, err := GetReferenceEntriesInRange(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID)
-	assert.Nil(t, err)
-	assert.Equal(t, expectedEntries, entries)
-	assert.Equal(t, expectedAnnotationMap, annotationMap)
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
 
-	// Add an entry and annotation for feature branch
-	if err := NewReferenceEntry(anotherRefName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
-	latestEntry, err := GetLatestEntry(repo)
+	entry, err := GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
-	expectedEntries = append(expectedEntries, latestEntry.(*ReferenceEntry))
-	if err := NewAnnotationEntry([]plumbing.Hash{latestEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+	entryID := entry.GetID()
+
+	if err := NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
-	latestEntry, err = GetLatestEntry(repo)
+	otherEntry, err := GetLatestEntry(repo)
 	if err != nil {
 		t.Fatal(err)
 	}
-	expectedAnnotationMap[expectedEntries[len(expectedEntries)-1].ID] = []*AnnotationEntry{latestEntry.(*AnnotationEntry)}
 
-	// Expected values include the feature branch entry and annotation
-	entries, annotationMap, err = GetReferenceEntriesInRange(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID)
+	timeline, err := BuildAnnotationTimeline(repo, entryID)
 	assert.Nil(t, err)
-	assert.Equal(t, expectedEntries, entries)
-	assert.Equal(t, expectedAnnotationMap, annotationMap)
+	assert.Empty(t, timeline)
 
-	// Add an annotation that refers to two valid entries
-	if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[0].ID, expectedEntries[1].ID}, false, annotationMessage).Commit(repo, false); err != nil {
-		t.Fatal(err)
+	annotations := []struct {
+		entryIDs []plumbing.Hash
+		skip     bool
+	}{
+		{[]plumbing.Hash{entryID}, false},
+		{[]plumbing.Hash{otherEntry.GetID()}, true}, // not relevant to entry
+		{[]plumbing.Hash{entryID, otherEntry.GetID()}, true},
+		{[]plumbing.Hash{entryID}, true},
+		{[]plumbing.Hash{entryID}, false},
 	}
-	latestEntry, err = GetLatestEntry(repo)
-	if err != nil {
-		t.Fatal(err)
+	annotationIDs := []plumbing.Hash{}
+	for _, annotation := range annotations {
+		if err := NewAnnotationEntry(annotation.entryIDs, annotation.skip, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		annotationIDs = append(annotationIDs, latestEntry.GetID())
 	}
-	// This annotation is relevant to both entries
-	annotation := latestEntry.(*AnnotationEntry)
-	expectedAnnotationMap[expectedEntries[0].ID] = append(expectedAnnotationMap[expectedEntries[0].ID], annotation)
-	expectedAnnotationMap[expectedEntries[1].ID] = append(expectedAnnotationMap[expectedEntries[1].ID], annotation)
 
-	entries, annotationMap, err = GetReferenceEntriesInRange(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID)
+	timeline, err = BuildAnnotationTimeline(repo, entryID)
 	assert.Nil(t, err)
-	assert.Equal(t, expectedEntries, entries)
-	assert.Equal(t, expectedAnnotationMap, annotationMap)
+	assert.Equal(t, 4, len(timeline))
 
-	// Add a gittuf namespace entry and ensure it's returned as relevant
-	if err := NewReferenceEntry("refs/gittuf/relevant", plumbing.ZeroHash).Commit(repo, false); err != nil {
-		t.Fatal(err)
+	expectedIDs := []plumbing.Hash{annotationIDs[0], annotationIDs[2], annotationIDs[3], annotationIDs[4]}
+	expectedEffects := []AnnotationEffect{AnnotationEffectInformational, AnnotationEffectSkipped, AnnotationEffectAlreadySkipped, AnnotationEffectSkipRetained}
+	for i, event := range timeline {
+		assert.Equal(t, expectedIDs[i], event.Annotation.ID)
+		assert.Equal(t, expectedEffects[i], event.Effect)
 	}
-	latestEntry, err = GetLatestEntry(repo)
-	if err != nil {
-		t.Fatal(err)
+
+	// Reinstate the entry, then attempt to reinstate it again
+	for i := 0; i < 2; i++ {
+		if err := NewUnskipAnnotationEntry([]plumbing.Hash{entryID}, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
 	}
-	expectedEntries = append(expectedEntries, latestEntry.(*ReferenceEntry))
 
-	entries, annotationMap, err = GetReferenceEntriesInRange(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID)
+	timeline, err = BuildAnnotationTimeline(repo, entryID)
 	assert.Nil(t, err)
-	assert.Equal(t, expectedEntries, entries)
-	assert.Equal(t, expectedAnnotationMap, annotationMap)
+	assert.Equal(t, 6, len(timeline))
+	assert.Equal(t, AnnotationEffectUnskipped, timeline[4].Effect)
+	assert.Equal(t, AnnotationEffectNotSkipped, timeline[5].Effect)
+
+	_, err = BuildAnnotationTimeline(repo, plumbing.NewHash("abcdef1234567890"))
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
 }
 
-func TestGetReferenceEntriesInRangeForRef(t *testing.T) {
+func TestGetRefTargetHistory(t *testing.T) {
 	refName := "refs/heads/main"
 	anotherRefName := "refs/heads/feature"
 
-	// We add a mix of reference entries and annotations, establishing expected
-	// return values as we go along
-
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
 		t.Fatal(err)
@@ -999,92 +1982,59 @@ func TestGetReferenceEntriesInRangeForRef(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	expectedEntries := []*ReferenceEntry{}
-	expectedAnnotationMap := map[plumbing.Hash][]*AnnotationEntry{}
+	_, err = GetRefTargetHistory(repo, refName)
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
 
-	// Add some entries to main
-	for i := 0; i < 3; i++ {
-		if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
-			t.Fatal(err)
-		}
+	targetA := plumbing.NewHash("aaaa")
+	targetB := plumbing.NewHash("bbbb")
+	targetC := plumbing.NewHash("cccc")
 
-		// We run GetLatestEntry so that the entry has its ID set as well
-		entry, err := GetLatestEntry(repo)
-		if err != nil {
-			t.Fatal(err)
-		}
-		expectedEntries = append(expectedEntries, entry.(*ReferenceEntry))
+	entries := []*ReferenceEntry{
+		NewReferenceEntry(refName, targetA),
+		NewReferenceEntry(refName, targetA), // consecutive duplicate
+		NewReferenceEntry(anotherRefName, targetB),
+		NewReferenceEntry(refName, targetB),
+		NewReferenceEntry(refName, targetC), // to be skipped
+		NewReferenceEntry(refName, targetB),
+		NewReferenceEntry(refName, targetA), // non-consecutive repetition
 	}
-
-	// Add some annotations
-	for i := 0; i < 3; i++ {
-		if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[i].ID}, false, annotationMessage).Commit(repo, false); err != nil {
+	entryIDs := []plumbing.Hash{}
+	for _, entry := range entries {
+		if err := entry.Commit(repo, false); err != nil {
 			t.Fatal(err)
 		}
 
-		annotation, err := GetLatestEntry(repo)
+		latestEntry, err := GetLatestEntry(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
-		expectedAnnotationMap[expectedEntries[i].ID] = []*AnnotationEntry{annotation.(*AnnotationEntry)}
+		entryIDs = append(entryIDs, latestEntry.GetID())
 	}
 
-	// Each entry has one annotation
-	entries, annotationMap, err := GetReferenceEntriesInRangeForRef(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID, refName)
-	assert.Nil(t, err)
-	assert.Equal(t, expectedEntries, entries)
-	assert.Equal(t, expectedAnnotationMap, annotationMap)
-
-	// Add an entry and annotation for feature branch
-	if err := NewReferenceEntry(anotherRefName, plumbing.ZeroHash).Commit(repo, false); err != nil {
-		t.Fatal(err)
-	}
-	latestEntry, err := GetLatestEntry(repo)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if err := NewAnnotationEntry([]plumbing.Hash{latestEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+	if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[4]}, true, annotationMessage).Commit(repo, false); err != nil {
 		t.Fatal(err)
 	}
 
-	// Expected values do not change
-	entries, annotationMap, err = GetReferenceEntriesInRangeForRef(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID, refName)
-	assert.Nil(t, err)
-	assert.Equal(t, expectedEntries, entries)
-	assert.Equal(t, expectedAnnotationMap, annotationMap)
-
-	// Add an annotation that refers to two valid entries
-	if err := NewAnnotationEntry([]plumbing.Hash{expectedEntries[0].ID, expectedEntries[1].ID}, false, annotationMessage).Commit(repo, false); err != nil {
-		t.Fatal(err)
-	}
-	latestEntry, err = GetLatestEntry(repo)
-	if err != nil {
-		t.Fatal(err)
-	}
-	// This annotation is relevant to both entries
-	annotation := latestEntry.(*AnnotationEntry)
-	expectedAnnotationMap[expectedEntries[0].ID] = append(expectedAnnotationMap[expectedEntries[0].ID], annotation)
-	expectedAnnotationMap[expectedEntries[1].ID] = append(expectedAnnotationMap[expectedEntries[1].ID], annotation)
+	t.Run("all entries", func(t *testing.T) {
+		history, err := GetRefTargetHistory(repo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, []plumbing.Hash{targetA, targetB, targetC, targetB, targetA}, history)
 
-	entries, annotationMap, err = GetReferenceEntriesInRangeForRef(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID, refName)
-	assert.Nil(t, err)
-	assert.Equal(t, expectedEntries, entries)
-	assert.Equal(t, expectedAnnotationMap, annotationMap)
+		history, err = GetRefTargetHistory(repo, anotherRefName)
+		assert.Nil(t, err)
+		assert.Equal(t, []plumbing.Hash{targetB}, history)
+	})
 
-	// Add a gittuf namespace entry and ensure it's returned as relevant
-	if err := NewReferenceEntry("refs/gittuf/relevant", plumbing.ZeroHash).Commit(repo, false); err != nil {
-		t.Fatal(err)
-	}
-	latestEntry, err = GetLatestEntry(repo)
-	if err != nil {
-		t.Fatal(err)
-	}
-	expectedEntries = append(expectedEntries, latestEntry.(*ReferenceEntry))
+	t.Run("unskipped entries", func(t *testing.T) {
+		history, err := GetUnskippedRefTargetHistory(repo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, []plumbing.Hash{targetA, targetB, targetA}, history)
+	})
 
-	entries, annotationMap, err = GetReferenceEntriesInRangeForRef(repo, expectedEntries[0].ID, expectedEntries[len(expectedEntries)-1].ID, refName)
-	assert.Nil(t, err)
-	assert.Equal(t, expectedEntries, entries)
-	assert.Equal(t, expectedAnnotationMap, annotationMap)
+	t.Run("unknown ref", func(t *testing.T) {
+		_, err := GetRefTargetHistory(repo, "refs/heads/unknown")
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
 }
 
 func TestGetLatestUnskippedReferenceEntryForRef(t *testing.T) {
@@ -1344,6 +2294,23 @@ func TestAnnotationEntryCreateCommitMessage(t *testing.T) {
 			},
 			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "false"),
 		},
+		"annotation, no message, unskip": {
+			entry: &AnnotationEntry{
+				RSLEntryIDs: []plumbing.Hash{plumbing.ZeroHash},
+				Unskip:      true,
+				Message:     "",
+			},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "false", UnskipKey, "true"),
+		},
+		"annotation, with message, with severity": {
+			entry: &AnnotationEntry{
+				RSLEntryIDs: []plumbing.Hash{plumbing.ZeroHash},
+				Skip:        true,
+				Message:     "message",
+				Severity:    SeverityCritical,
+			},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "true", SeverityKey, "critical", BeginMessage, base64.StdEncoding.EncodeToString([]byte("message")), EndMessage),
+		},
 	}
 
 	for name, test := range tests {
@@ -1359,6 +2326,45 @@ func TestAnnotationEntryCreateCommitMessage(t *testing.T) {
 	}
 }
 
+func TestAnnotationEntryCreateCommitMessageConflictingSkipStatus(t *testing.T) {
+	annotation := &AnnotationEntry{
+		RSLEntryIDs: []plumbing.Hash{plumbing.ZeroHash},
+		Skip:        true,
+		Unskip:      true,
+	}
+
+	_, err := annotation.createCommitMessage()
+	assert.ErrorIs(t, err, ErrConflictingSkipStatus)
+}
+
+func TestReferenceEntrySkippedBy(t *testing.T) {
+	entry := &ReferenceEntry{ID: plumbing.NewHash("abcdef12345678900987654321fedcbaabcdef12")}
+	otherEntryID := plumbing.NewHash("1234567890abcdef1234567890abcdef12345678")
+
+	skip := &AnnotationEntry{RSLEntryIDs: []plumbing.Hash{entry.ID}, Skip: true}
+	unskip := &AnnotationEntry{RSLEntryIDs: []plumbing.Hash{entry.ID}, Unskip: true}
+	info := &AnnotationEntry{RSLEntryIDs: []plumbing.Hash{entry.ID}}
+	otherUnskip := &AnnotationEntry{RSLEntryIDs: []plumbing.Hash{otherEntryID}, Unskip: true}
+
+	tests := map[string]struct {
+		annotations []*AnnotationEntry
+		expected    bool
+	}{
+		"no annotations":                 {annotations: nil, expected: false},
+		"skip":                           {annotations: []*AnnotationEntry{skip}, expected: true},
+		"skip, then informational":       {annotations: []*AnnotationEntry{skip, info}, expected: true},
+		"skip, then unskip":              {annotations: []*AnnotationEntry{skip, unskip}, expected: false},
+		"unskip, then skip":              {annotations: []*AnnotationEntry{unskip, skip}, expected: true},
+		"skip, then unskip of other":     {annotations: []*AnnotationEntry{skip, otherUnskip}, expected: true},
+		"skip, unskip, then skip again":  {annotations: []*AnnotationEntry{skip, unskip, skip}, expected: true},
+		"unskip without an earlier skip": {annotations: []*AnnotationEntry{unskip}, expected: false},
+	}
+
+	for name, test := range tests {
+		assert.Equal(t, test.expected, entry.SkippedBy(test.annotations), fmt.Sprintf("unexpected result in test '%s'", name))
+	}
+}
+
 func TestParseRSLEntryText(t *testing.T) {
 	tests := map[string]struct {
 		expectedEntry Entry
@@ -1434,6 +2440,26 @@ func TestParseRSLEntryText(t *testing.T) {
 			},
 			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "false"),
 		},
+		"annotation, with message, with severity": {
+			expectedEntry: &AnnotationEntry{
+				ID:          plumbing.ZeroHash,
+				RSLEntryIDs: []plumbing.Hash{plumbing.ZeroHash},
+				Skip:        true,
+				Message:     "message",
+				Severity:    SeverityWarning,
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "true", SeverityKey, "warning", BeginMessage, base64.StdEncoding.EncodeToString([]byte("message")), EndMessage),
+		},
+		"annotation, no message, unknown severity": {
+			expectedEntry: &AnnotationEntry{
+				ID:          plumbing.ZeroHash,
+				RSLEntryIDs: []plumbing.Hash{plumbing.ZeroHash},
+				Skip:        false,
+				Message:     "",
+				Severity:    SeverityUnknown,
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "false", SeverityKey, "catastrophic"),
+		},
 		"annotation, missing header": {
 			expectedError: ErrInvalidRSLEntry,
 			message:       fmt.Sprintf("%s: %s\n%s: %s\n%s\n%s\n%s", EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "true", BeginMessage, base64.StdEncoding.EncodeToString([]byte("message")), EndMessage),
@@ -1468,3 +2494,97 @@ func assertAnnotationsReferToEntry(t *testing.T, entry *ReferenceEntry, annotati
 		assert.Equal(t, annotationMessage, annotation.Message)
 	}
 }
+
+func TestEntryNumbering(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	firstEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(1), firstEntry.(*ReferenceEntry).Number)
+
+	if err := NewAnnotationEntry([]plumbing.Hash{firstEntry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	annotation, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(2), annotation.(*AnnotationEntry).Number)
+
+	// Entry that predates numbering
+	if _, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, fmt.Sprintf("%s\n\n%s: %s\n%s: %s", ReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, plumbing.ZeroHash.String()), false); err != nil {
+		t.Fatal(err)
+	}
+	unnumberedEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(0), unnumberedEntry.(*ReferenceEntry).Number)
+
+	if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(4), latestEntry.(*ReferenceEntry).Number)
+
+	t.Run("lookup by number", func(t *testing.T) {
+		entry, err := GetEntryByNumber(repo, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, firstEntry.GetID(), entry.GetID())
+
+		entry, err = GetEntryByNumber(repo, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, annotation.GetID(), entry.GetID())
+
+		entry, err = GetEntryByNumber(repo, 4)
+		assert.Nil(t, err)
+		assert.Equal(t, latestEntry.GetID(), entry.GetID())
+	})
+
+	t.Run("unknown number", func(t *testing.T) {
+		_, err := GetEntryByNumber(repo, 3)
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+		_, err = GetEntryByNumber(repo, 5)
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+		_, err = GetEntryByNumber(repo, 0)
+		assert.ErrorIs(t, err, ErrInvalidEntryNumber)
+	})
+
+	t.Run("number does not match position", func(t *testing.T) {
+		// The entry at position 5 claims to be entry 7, and the entry after
+		// it claims to be entry 8
+		for _, number := range []int{7, 8} {
+			message := fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d", ReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, plumbing.ZeroHash.String(), NumberKey, number)
+			if _, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, message, false); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, err := GetEntryByNumber(repo, 7)
+		assert.ErrorIs(t, err, ErrEntryNumberMismatch)
+
+		_, err = GetEntryByNumber(repo, 8)
+		assert.ErrorIs(t, err, ErrEntryNumberMismatch)
+
+		entry, err := GetEntryByNumber(repo, 4)
+		assert.Nil(t, err)
+		assert.Equal(t, latestEntry.GetID(), entry.GetID())
+	})
+}
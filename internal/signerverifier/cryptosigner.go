@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signerverifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/common"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// CryptoSignerVerifier signs using a crypto.Signer whose private key may not
+// be directly accessible, such as a key held in a KMS service or on a
+// PIV-compatible hardware token. Signatures use the same schemes as the
+// corresponding sslib signerverifiers, so they can be verified using the
+// signer's public key alone.
+//
+// gittuf does not talk to hardware tokens directly: it doesn't discover
+// tokens, select PIV slots, or prompt for PINs. Callers that open a token
+// themselves can use CryptoSignerVerifier. From the CLI, a token's key can be
+// used by loading it into the ssh-agent using its PKCS#11 provider, for
+// example with `ssh-add -s`, which prompts for the PIN, and referencing it as
+// ssh-agent:<fingerprint>. Such a key signs both RSL entries and policy
+// metadata.
+type CryptoSignerVerifier struct {
+	signer   crypto.Signer
+	verifier dsse.SignerVerifier
+	keyID    string
+}
+
+// NewSignerVerifierFromCryptoSigner returns a CryptoSignerVerifier for the
+// signer. Signers for RSA, ECDSA, and ED25519 keys are supported. Any unlocking
+// of the underlying key, such as entering a hardware token's PIN, must happen
+// before the signer is passed in.
+func NewSignerVerifierFromCryptoSigner(signer crypto.Signer) (*CryptoSignerVerifier, error) {
+	key, err := sslibsv.NewKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CryptoSignerVerifier{
+		signer:   signer,
+		verifier: verifier,
+		keyID:    key.KeyID,
+	}, nil
+}
+
+// Sign signs data using the crypto.Signer.
+func (sv *CryptoSignerVerifier) Sign(_ context.Context, data []byte) ([]byte, error) {
+	switch publicKey := sv.signer.Public().(type) {
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return sv.signer.Sign(rand.Reader, digest[:], &rsa.PSSOptions{SaltLength: sha256.Size, Hash: crypto.SHA256})
+	case *ecdsa.PublicKey:
+		// This matches the hash selection in the sslib ECDSA signerverifier
+		curveSize := publicKey.Curve.Params().BitSize
+		switch {
+		case curveSize <= 256:
+			digest := sha256.Sum256(data)
+			return sv.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		case curveSize <= 384:
+			digest := sha512.Sum384(data)
+			return sv.signer.Sign(rand.Reader, digest[:], crypto.SHA384)
+		default:
+			digest := sha512.Sum512(data)
+			return sv.signer.Sign(rand.Reader, digest[:], crypto.SHA512)
+		}
+	case ed25519.PublicKey:
+		return sv.signer.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+
+	return nil, common.ErrUnknownKeyType
+}
+
+// Verify verifies sig against data using the signer's public key.
+func (sv *CryptoSignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the signer's public key.
+func (sv *CryptoSignerVerifier) KeyID() (string, error) {
+	return sv.keyID, nil
+}
+
+// Public returns the signer's public key.
+func (sv *CryptoSignerVerifier) Public() crypto.PublicKey {
+	return sv.signer.Public()
+}
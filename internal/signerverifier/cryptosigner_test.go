@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signerverifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoSignerVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	ecdsaP256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	ecdsaP384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.Nil(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+
+	tests := map[string]crypto.Signer{
+		"rsa":         rsaKey,
+		"ecdsa p-256": ecdsaP256Key,
+		"ecdsa p-384": ecdsaP384Key,
+		"ed25519":     ed25519Key,
+	}
+
+	data := []byte("test data")
+	for name, signer := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv, err := NewSignerVerifierFromCryptoSigner(signer)
+			require.Nil(t, err)
+
+			sig, err := sv.Sign(context.Background(), data)
+			require.Nil(t, err)
+			assert.Nil(t, sv.Verify(context.Background(), data, sig))
+			assert.NotNil(t, sv.Verify(context.Background(), []byte("other data"), sig))
+
+			// The signature must be verifiable using only the public key
+			key, err := sslibsv.NewKey(signer.Public())
+			require.Nil(t, err)
+			verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+			require.Nil(t, err)
+			assert.Nil(t, verifier.Verify(context.Background(), data, sig))
+
+			keyID, err := sv.KeyID()
+			assert.Nil(t, err)
+			assert.Equal(t, key.KeyID, keyID)
+			assert.Equal(t, signer.Public(), sv.Public())
+		})
+	}
+}
@@ -4,25 +4,39 @@ package gpg
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
 )
 
+var (
+	ErrKeyExpired        = errors.New("GPG key had expired at the time of verification")
+	ErrKeyRevoked        = errors.New("GPG key has been revoked")
+	ErrSignatureExpired  = errors.New("GPG signature had expired at the time of verification")
+	ErrInvalidSignature  = errors.New("invalid GPG signature")
+	ErrSignatureNotByKey = errors.New("GPG signature was not created by the key or any of its signing subkeys")
+)
+
 // LoadGPGKeyFromBytes returns a tuf.Key for a GPG / PGP key passed in as
 // armored bytes. The returned tuf.Key uses the primary key's fingerprint as the
-// key ID.
+// key ID. The key's subkeys are retained, and signatures created by any of its
+// signing subkeys are attributed to the primary key's identity.
 func LoadGPGKeyFromBytes(contents []byte) (*tuf.Key, error) {
 	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(contents))
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: check if this is correct for subkeys
 	fingerprint := fmt.Sprintf("%x", keyring[0].PrimaryKey.Fingerprint)
 	publicKey := strings.TrimSpace(string(contents))
 
@@ -37,3 +51,128 @@ func LoadGPGKeyFromBytes(contents []byte) (*tuf.Key, error) {
 
 	return gpgKey, nil
 }
+
+// VerifySignature verifies the armored detached signature over data using the
+// GPG key. The signature may be created by the key's primary key or any of its
+// signing subkeys. The expiration of the key and subkey is evaluated at
+// verifyAt, such as the time the signed object was recorded in the RSL. The
+// creation time in the signature is chosen by the signer and is not used to
+// evaluate the key. Neither is a trusted timestamp, so revocations are always
+// evaluated at the current time: a signature by a revoked key or subkey is
+// rejected regardless of when it claims to have been created. The fingerprint
+// of the primary key or subkey that created the signature is returned.
+func VerifySignature(key *tuf.Key, data, armoredSignature []byte, verifyAt time.Time) (string, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.KeyVal.Public))
+	if err != nil {
+		return "", err
+	}
+
+	block, err := armor.Decode(bytes.NewReader(armoredSignature))
+	if err != nil {
+		return "", errors.Join(ErrInvalidSignature, err)
+	}
+	signatureBytes, err := io.ReadAll(block.Body)
+	if err != nil {
+		return "", errors.Join(ErrInvalidSignature, err)
+	}
+
+	signaturePacket, err := packet.Read(bytes.NewReader(signatureBytes))
+	if err != nil {
+		return "", errors.Join(ErrInvalidSignature, err)
+	}
+	signature, isSignature := signaturePacket.(*packet.Signature)
+	if !isSignature || signature.IssuerKeyId == nil {
+		return "", ErrInvalidSignature
+	}
+
+	config := &packet.Config{Time: func() time.Time { return verifyAt }}
+	_, _, err = openpgp.VerifyDetachedSignature(keyring, bytes.NewReader(data), bytes.NewReader(signatureBytes), config)
+
+	// The signature is cryptographically valid if verification passes or only
+	// the checks of the key's validity fail
+	signingKey, hasSigningKey := findSigningKey(keyring, *signature.IssuerKeyId)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, pgperrors.ErrKeyRevoked):
+		return "", ErrKeyRevoked
+	case errors.Is(err, pgperrors.ErrKeyExpired):
+		return "", ErrKeyExpired
+	case errors.Is(err, pgperrors.ErrSignatureExpired) && hasSigningKey:
+		// The signature or the key's self-signatures may have been created
+		// after verifyAt, for example due to clock skew or because the key's
+		// expiry was extended later. We only care that the signature's own
+		// lifetime and the key had not expired at verifyAt.
+		if signatureExpiredAt(signature, verifyAt) {
+			return "", ErrSignatureExpired
+		}
+		if keyExpiredAt(signingKey, verifyAt) {
+			return "", ErrKeyExpired
+		}
+	case errors.Is(err, pgperrors.ErrUnknownIssuer):
+		return "", ErrSignatureNotByKey
+	default:
+		return "", errors.Join(ErrSignatureNotByKey, err)
+	}
+
+	if !hasSigningKey {
+		return "", ErrSignatureNotByKey
+	}
+
+	if revokedAt(signingKey, time.Now()) {
+		return "", ErrKeyRevoked
+	}
+
+	return fmt.Sprintf("%x", signingKey.PublicKey.Fingerprint), nil
+}
+
+// findSigningKey returns the primary key or subkey in the keyring with the
+// specified key ID that is permitted to sign.
+func findSigningKey(keyring openpgp.EntityList, keyID uint64) (openpgp.Key, bool) {
+	keys := keyring.KeysByIdUsage(keyID, packet.KeyFlagSign)
+	if len(keys) == 0 {
+		return openpgp.Key{}, false
+	}
+
+	return keys[0], true
+}
+
+// signatureExpiredAt checks if the signature's lifetime, if any, had ended at
+// the specified time.
+func signatureExpiredAt(signature *packet.Signature, at time.Time) bool {
+	if signature.SigLifetimeSecs == nil || *signature.SigLifetimeSecs == 0 {
+		return false
+	}
+
+	return at.After(signature.CreationTime.Add(time.Duration(*signature.SigLifetimeSecs) * time.Second))
+}
+
+// keyExpiredAt checks if the primary key or, if applicable, the subkey had
+// expired at the specified time.
+func keyExpiredAt(key openpgp.Key, at time.Time) bool {
+	primaryIdentity := key.Entity.PrimaryIdentity()
+	if primaryIdentity != nil && key.Entity.PrimaryKey.KeyExpired(primaryIdentity.SelfSignature, at) {
+		return true
+	}
+
+	if key.PublicKey != key.Entity.PrimaryKey && key.PublicKey.KeyExpired(key.SelfSignature, at) {
+		return true
+	}
+
+	return false
+}
+
+// revokedAt checks if the primary key, its primary identity, or, if
+// applicable, the subkey had been revoked at the specified time.
+func revokedAt(key openpgp.Key, at time.Time) bool {
+	if key.Entity.Revoked(at) {
+		return true
+	}
+
+	primaryIdentity := key.Entity.PrimaryIdentity()
+	if primaryIdentity != nil && primaryIdentity.Revoked(at) {
+		return true
+	}
+
+	return key.PublicKey != key.Entity.PrimaryKey && key.Revoked(at)
+}
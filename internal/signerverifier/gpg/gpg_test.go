@@ -3,10 +3,17 @@
 package gpg
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,3 +26,192 @@ func TestLoadGPGKeyFromBytes(t *testing.T) {
 	assert.Equal(t, signerverifier.GPGKeyType, key.Scheme)
 	assert.Equal(t, "157507bbe151e378ce8126c1dcfe043cdd2db96e", key.KeyID)
 }
+
+func TestVerifySignature(t *testing.T) {
+	data := []byte("gittuf")
+	createdAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	configAt := func(at time.Time) *packet.Config {
+		return &packet.Config{
+			Algorithm: packet.PubKeyAlgoEdDSA,
+			Time:      func() time.Time { return at },
+		}
+	}
+
+	createEntity := func(t *testing.T, config *packet.Config) *openpgp.Entity {
+		t.Helper()
+
+		entity, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entity
+	}
+
+	loadKey := func(t *testing.T, entity *openpgp.Entity) *tuf.Key {
+		t.Helper()
+
+		publicKey := &bytes.Buffer{}
+		writer, err := armor.Encode(publicKey, openpgp.PublicKeyType, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := entity.Serialize(writer); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := LoadGPGKeyFromBytes(publicKey.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+
+	sign := func(t *testing.T, entity *openpgp.Entity, at time.Time) []byte {
+		t.Helper()
+
+		signature := &bytes.Buffer{}
+		if err := openpgp.ArmoredDetachSign(signature, entity, bytes.NewReader(data), configAt(at)); err != nil {
+			t.Fatal(err)
+		}
+		return signature.Bytes()
+	}
+
+	t.Run("primary key", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		key := loadKey(t, entity)
+		verifyAt := createdAt.Add(2 * time.Hour)
+
+		signingKeyID, err := VerifySignature(key, data, sign(t, entity, createdAt.Add(time.Hour)), verifyAt)
+		assert.Nil(t, err)
+		assert.Equal(t, key.KeyID, signingKeyID)
+
+		_, err = VerifySignature(key, []byte("not gittuf"), sign(t, entity, createdAt.Add(time.Hour)), verifyAt)
+		assert.ErrorIs(t, err, ErrSignatureNotByKey)
+	})
+
+	t.Run("signing subkey", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		if err := entity.AddSigningSubkey(configAt(createdAt)); err != nil {
+			t.Fatal(err)
+		}
+		key := loadKey(t, entity)
+
+		signingKeyID, err := VerifySignature(key, data, sign(t, entity, createdAt.Add(time.Hour)), createdAt.Add(2*time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("%x", entity.PrimaryKey.Fingerprint), key.KeyID)
+		assert.Equal(t, fmt.Sprintf("%x", entity.Subkeys[1].PublicKey.Fingerprint), signingKeyID)
+	})
+
+	t.Run("unrelated key", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		otherEntity := createEntity(t, configAt(createdAt))
+
+		_, err := VerifySignature(loadKey(t, otherEntity), data, sign(t, entity, createdAt.Add(time.Hour)), createdAt.Add(2*time.Hour))
+		assert.ErrorIs(t, err, ErrSignatureNotByKey)
+	})
+
+	t.Run("signature created after verification time", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		key := loadKey(t, entity)
+
+		_, err := VerifySignature(key, data, sign(t, entity, createdAt.Add(2*time.Hour)), createdAt.Add(time.Hour))
+		assert.Nil(t, err)
+	})
+
+	t.Run("expired signature", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		key := loadKey(t, entity)
+
+		config := configAt(createdAt.Add(time.Hour))
+		config.SigLifetimeSecs = uint32(time.Hour.Seconds())
+		signature := &bytes.Buffer{}
+		if err := openpgp.ArmoredDetachSign(signature, entity, bytes.NewReader(data), config); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := VerifySignature(key, data, signature.Bytes(), createdAt.Add(90*time.Minute))
+		assert.Nil(t, err)
+
+		_, err = VerifySignature(key, data, signature.Bytes(), createdAt.Add(3*time.Hour))
+		assert.ErrorIs(t, err, ErrSignatureExpired)
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		signatureBeforeExpiry := sign(t, entity, createdAt.Add(time.Hour))
+
+		// Set the key to expire a day after it was created
+		lifetime := uint32((24 * time.Hour).Seconds())
+		identity := entity.PrimaryIdentity()
+		identity.SelfSignature.KeyLifetimeSecs = &lifetime
+		if err := identity.SelfSignature.SignUserId(identity.UserId.Id, entity.PrimaryKey, entity.PrivateKey, configAt(createdAt)); err != nil {
+			t.Fatal(err)
+		}
+		key := loadKey(t, entity)
+
+		// Signatures verified before the key expired are valid
+		_, err := VerifySignature(key, data, signatureBeforeExpiry, createdAt.Add(2*time.Hour))
+		assert.Nil(t, err)
+
+		// The signature's creation time is not trusted, so a signature
+		// verified after the key expired is rejected even if it claims to
+		// have been created before
+		_, err = VerifySignature(key, data, signatureBeforeExpiry, createdAt.Add(48*time.Hour))
+		assert.ErrorIs(t, err, ErrKeyExpired)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		signatureBeforeRevocation := sign(t, entity, createdAt.Add(time.Hour))
+		if err := entity.RevokeKey(packet.KeySuperseded, "", configAt(createdAt.Add(24*time.Hour))); err != nil {
+			t.Fatal(err)
+		}
+		key := loadKey(t, entity)
+
+		// The verification time isn't trusted, so revocations apply even to
+		// signatures verified before the key was revoked
+		_, err := VerifySignature(key, data, signatureBeforeRevocation, createdAt.Add(2*time.Hour))
+		assert.ErrorIs(t, err, ErrKeyRevoked)
+
+		_, err = VerifySignature(key, data, signatureBeforeRevocation, createdAt.Add(48*time.Hour))
+		assert.ErrorIs(t, err, ErrKeyRevoked)
+	})
+
+	t.Run("revoked subkey", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		if err := entity.AddSigningSubkey(configAt(createdAt)); err != nil {
+			t.Fatal(err)
+		}
+		signatureBeforeRevocation := sign(t, entity, createdAt.Add(time.Hour))
+		if err := entity.RevokeSubkey(&entity.Subkeys[1], packet.KeyRetired, "", configAt(createdAt.Add(24*time.Hour))); err != nil {
+			t.Fatal(err)
+		}
+		key := loadKey(t, entity)
+
+		_, err := VerifySignature(key, data, signatureBeforeRevocation, createdAt.Add(2*time.Hour))
+		assert.ErrorIs(t, err, ErrKeyRevoked)
+	})
+
+	t.Run("compromised key", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+		signatureBeforeRevocation := sign(t, entity, createdAt.Add(time.Hour))
+		if err := entity.RevokeKey(packet.KeyCompromised, "", configAt(createdAt.Add(24*time.Hour))); err != nil {
+			t.Fatal(err)
+		}
+		key := loadKey(t, entity)
+
+		_, err := VerifySignature(key, data, signatureBeforeRevocation, createdAt.Add(2*time.Hour))
+		assert.ErrorIs(t, err, ErrKeyRevoked)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		entity := createEntity(t, configAt(createdAt))
+
+		_, err := VerifySignature(loadKey(t, entity), data, []byte("not a signature"), createdAt.Add(time.Hour))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sigkms "github.com/sigstore/sigstore/pkg/signature/kms"
+
+	// Register the KMS providers available to gittuf with sigstore's KMS
+	// package. The GCP and Azure providers are not included, so gcpkms:// and
+	// azurekms:// references are rejected with ErrUnsupportedKMSProvider.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/hashivault"
+)
+
+var ErrUnsupportedKMSProvider = errors.New("KMS provider is not supported by this build of gittuf")
+
+// KeyReferenceSchemes lists the URI schemes used to reference keys held in the
+// KMS services supported by sigstore. Only the schemes of providers registered
+// with sigstore's KMS package can be used to sign, currently awskms:// and
+// hashivault://. The other schemes are recognized so that using them results
+// in ErrUnsupportedKMSProvider rather than the reference being treated as a
+// path to a key file.
+var KeyReferenceSchemes = []string{
+	"awskms://",
+	"gcpkms://",
+	"azurekms://",
+	"hashivault://",
+}
+
+// IsKeyReference returns true if key is a URI-style reference to a key held in
+// a KMS service, such as awskms:///arn:aws:kms:... or
+// gcpkms://projects/.../cryptoKeyVersions/1.
+func IsKeyReference(key string) bool {
+	key = strings.TrimSpace(key)
+
+	for _, scheme := range KeyReferenceSchemes {
+		if strings.HasPrefix(key, scheme) {
+			return true
+		}
+	}
+
+	// Also recognize schemes of any additionally registered providers
+	return isSupportedKeyReference(key)
+}
+
+// LoadCryptoSigner returns a crypto.Signer for the KMS key referenced by
+// keyRef. If the provider for keyRef's scheme is not registered,
+// ErrUnsupportedKMSProvider is returned.
+func LoadCryptoSigner(ctx context.Context, keyRef string) (crypto.Signer, error) {
+	keyRef = strings.TrimSpace(keyRef)
+	if !isSupportedKeyReference(keyRef) {
+		scheme, _, _ := strings.Cut(keyRef, "://")
+		supportedSchemes := sigkms.SupportedProviders()
+		sort.Strings(supportedSchemes)
+		return nil, fmt.Errorf("%w: '%s://', supported schemes are %s", ErrUnsupportedKMSProvider, scheme, strings.Join(supportedSchemes, ", "))
+	}
+
+	kmsSignerVerifier, err := sigkms.Get(ctx, keyRef, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, _, err := kmsSignerVerifier.CryptoSigner(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// isSupportedKeyReference returns true if keyRef uses the scheme of a KMS
+// provider registered with sigstore's KMS package.
+func isSupportedKeyReference(keyRef string) bool {
+	for _, scheme := range sigkms.SupportedProviders() {
+		if strings.HasPrefix(keyRef, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewSignerVerifierFromKeyReference returns a signerverifier for the KMS key
+// referenced by keyRef, for use in signing gittuf metadata.
+func NewSignerVerifierFromKeyReference(ctx context.Context, keyRef string) (*signerverifier.CryptoSignerVerifier, error) {
+	signer, err := LoadCryptoSigner(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return signerverifier.NewSignerVerifierFromCryptoSigner(signer)
+}
+
+// LoadPublicKey returns a tuf.Key object for the public portion of the KMS key
+// referenced by keyRef.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	signer, err := LoadCryptoSigner(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sslibsv.NewKey(signer.Public())
+}
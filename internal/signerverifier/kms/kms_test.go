@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	sigkms "github.com/sigstore/sigstore/pkg/signature/kms"
+	"github.com/sigstore/sigstore/pkg/signature/kms/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsKeyReference(t *testing.T) {
+	assert.True(t, IsKeyReference("awskms:///arn:aws:kms:us-east-1:123456789012:key/test"))
+	assert.True(t, IsKeyReference("gcpkms://projects/test/locations/global/keyRings/test/cryptoKeys/test/cryptoKeyVersions/1"))
+	assert.True(t, IsKeyReference("azurekms://test.vault.azure.net/test"))
+	assert.True(t, IsKeyReference(fake.ReferenceScheme))
+	assert.False(t, IsKeyReference("path/to/key"))
+	assert.False(t, IsKeyReference("gpg:abcdef"))
+}
+
+func TestNewSignerVerifierFromKeyReference(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	ctx := context.WithValue(context.Background(), fake.KmsCtxKey{}, privateKey)
+
+	sv, err := NewSignerVerifierFromKeyReference(ctx, fake.ReferenceScheme)
+	require.Nil(t, err)
+
+	data := []byte("test data")
+	sig, err := sv.Sign(ctx, data)
+	require.Nil(t, err)
+	assert.Nil(t, sv.Verify(ctx, data, sig))
+
+	key, err := LoadPublicKey(ctx, fake.ReferenceScheme)
+	require.Nil(t, err)
+	assert.Equal(t, sslibsv.ECDSAKeyType, key.KeyType)
+
+	keyID, err := sv.KeyID()
+	assert.Nil(t, err)
+	assert.Equal(t, key.KeyID, keyID)
+
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+	require.Nil(t, err)
+	assert.Nil(t, verifier.Verify(ctx, data, sig))
+
+	_, err = NewSignerVerifierFromKeyReference(ctx, "unknownkms://test")
+	assert.ErrorIs(t, err, ErrUnsupportedKMSProvider)
+
+	// The GCP and Azure providers are recognized but not registered
+	_, err = NewSignerVerifierFromKeyReference(ctx, "gcpkms://projects/test/locations/global/keyRings/test/cryptoKeys/test/cryptoKeyVersions/1")
+	assert.ErrorIs(t, err, ErrUnsupportedKMSProvider)
+
+	_, err = LoadPublicKey(ctx, "azurekms://test.vault.azure.net/test")
+	assert.ErrorIs(t, err, ErrUnsupportedKMSProvider)
+}
+
+func TestRegisteredProviders(t *testing.T) {
+	assert.Contains(t, sigkms.SupportedProviders(), "awskms://")
+	assert.Contains(t, sigkms.SupportedProviders(), "hashivault://")
+}
@@ -20,6 +20,8 @@ const (
 	GPGKeyType      = "gpg"
 	FulcioKeyType   = "sigstore-oidc"
 	FulcioKeyScheme = "fulcio"
+	X509KeyType     = "x509"
+	X509KeyScheme   = "smime"
 	RekorServer     = "https://rekor.sigstore.dev"
 )
 
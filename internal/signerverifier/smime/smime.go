@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package smime verifies Git signatures created using X.509 certificates, such
+// as those created by gpgsm or smimesign. Signers are identified by the email
+// address in their certificate's subject alternative names, and certificates
+// must chain to one of the certificate authorities trusted for the identity.
+package smime
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	cms "github.com/github/smimesign/ietf-cms"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+const pemCertificateType = "CERTIFICATE"
+
+var (
+	ErrInvalidCABundle       = errors.New("CA bundle must contain one or more PEM encoded certificates")
+	ErrInvalidIdentity       = errors.New("identity must be an email address")
+	ErrInvalidSignature      = errors.New("invalid S/MIME signature")
+	ErrUntrustedCertificate  = errors.New("signing certificate is not issued by a trusted certificate authority")
+	ErrCertificateIdentity   = errors.New("signing certificate does not belong to the expected identity")
+	ErrUnexpectedSignerCount = errors.New("S/MIME signature must have exactly one signer")
+)
+
+// LoadX509Identity returns a tuf.Key for the signer identified by the email
+// address identity whose certificates are issued by one of the certificate
+// authorities in the PEM encoded caBundle. The key ID is of the form
+// "<identity>::<bundle digest>", where the bundle digest is the SHA-256 digest
+// of the DER encoded certificates in the bundle.
+func LoadX509Identity(identity string, caBundle []byte) (*tuf.Key, error) {
+	identity = strings.TrimSpace(identity)
+	if !strings.Contains(identity, "@") {
+		return nil, ErrInvalidIdentity
+	}
+
+	certificates, err := parseCABundle(caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleDigest := sha256.New()
+	encodedBundle := strings.Builder{}
+	for _, certificate := range certificates {
+		bundleDigest.Write(certificate.Raw)
+		encodedBundle.Write(pem.EncodeToMemory(&pem.Block{Type: pemCertificateType, Bytes: certificate.Raw}))
+	}
+
+	return &sslibsv.SSLibKey{
+		KeyID:   fmt.Sprintf("%s::%x", identity, bundleDigest.Sum(nil)),
+		KeyType: signerverifier.X509KeyType,
+		Scheme:  signerverifier.X509KeyScheme,
+		KeyVal: sslibsv.KeyVal{
+			Identity: identity,
+			Public:   strings.TrimSpace(encodedBundle.String()),
+		},
+	}, nil
+}
+
+// VerifySignature verifies the PEM or DER encoded detached S/MIME signature
+// over data using the X.509 identity in key. The signature's certificate must
+// chain to a certificate authority in key's CA bundle and must include key's
+// identity as an email address. Certificate validity is evaluated at verifyAt,
+// such as the time the signed object was recorded in the RSL, which is not a
+// trusted timestamp. The signing time in the signature is chosen by the signer
+// and is not used. Revocation is not checked: certificate
+// revocation lists and OCSP responders are not consulted, so a revoked
+// certificate is trusted until it expires or its CA is removed from key's CA
+// bundle. The verified signing certificate is returned.
+func VerifySignature(key *tuf.Key, data, signature []byte, verifyAt time.Time) (*x509.Certificate, error) {
+	if key.KeyType != signerverifier.X509KeyType {
+		return nil, fmt.Errorf("%w: not an X.509 identity", ErrInvalidSignature)
+	}
+
+	roots, err := parseCABundle([]byte(key.KeyVal.Public))
+	if err != nil {
+		return nil, err
+	}
+	rootPool := x509.NewCertPool()
+	for _, root := range roots {
+		rootPool.AddCert(root)
+	}
+
+	signatureDER := signature
+	if block, _ := pem.Decode(signature); block != nil {
+		signatureDER = block.Bytes
+	}
+
+	signedData, err := cms.ParseSignedData(signatureDER)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidSignature, err)
+	}
+
+	chains, err := signedData.VerifyDetached(data, x509.VerifyOptions{
+		Roots:       rootPool,
+		CurrentTime: verifyAt,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection, x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		var (
+			unknownAuthorityErr x509.UnknownAuthorityError
+			invalidCertErr      x509.CertificateInvalidError
+		)
+		if errors.As(err, &unknownAuthorityErr) || errors.As(err, &invalidCertErr) {
+			return nil, errors.Join(ErrUntrustedCertificate, err)
+		}
+		return nil, errors.Join(ErrInvalidSignature, err)
+	}
+	if len(chains) != 1 || len(chains[0]) == 0 || len(chains[0][0]) == 0 {
+		return nil, ErrUnexpectedSignerCount
+	}
+
+	certificate := chains[0][0][0]
+	for _, emailAddress := range certificate.EmailAddresses {
+		if strings.EqualFold(emailAddress, key.KeyVal.Identity) {
+			return certificate, nil
+		}
+	}
+
+	return nil, ErrCertificateIdentity
+}
+
+// parseCABundle parses the certificates in a PEM encoded CA bundle.
+func parseCABundle(caBundle []byte) ([]*x509.Certificate, error) {
+	certificates := []*x509.Certificate{}
+
+	rest := caBundle
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != pemCertificateType {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidCABundle, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, ErrInvalidCABundle
+	}
+
+	return certificates, nil
+}
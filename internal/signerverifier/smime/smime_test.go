@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package smime
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	cms "github.com/github/smimesign/ietf-cms"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIdentity = "jane.doe@example.com"
+
+func TestLoadX509Identity(t *testing.T) {
+	ca, _ := createTestCertificate(t, nil, nil, "", time.Now())
+	caBundle := encodeCertificates(ca)
+
+	key, err := LoadX509Identity(testIdentity, caBundle)
+	assert.Nil(t, err)
+	assert.Equal(t, signerverifier.X509KeyType, key.KeyType)
+	assert.Equal(t, signerverifier.X509KeyScheme, key.Scheme)
+	assert.Equal(t, testIdentity, key.KeyVal.Identity)
+	assert.True(t, strings.HasPrefix(key.KeyID, testIdentity+"::"))
+
+	// The key ID doesn't depend on how the bundle is encoded
+	sameKey, err := LoadX509Identity(testIdentity, append([]byte("# CA bundle\n"), caBundle...))
+	assert.Nil(t, err)
+	assert.Equal(t, key.KeyID, sameKey.KeyID)
+
+	_, err = LoadX509Identity("jane.doe", caBundle)
+	assert.ErrorIs(t, err, ErrInvalidIdentity)
+
+	_, err = LoadX509Identity(testIdentity, []byte("not a CA bundle"))
+	assert.ErrorIs(t, err, ErrInvalidCABundle)
+}
+
+func TestVerifySignature(t *testing.T) {
+	data := []byte("gittuf")
+	now := time.Now()
+
+	ca, caSigner := createTestCertificate(t, nil, nil, "", time.Now())
+	key, err := LoadX509Identity(testIdentity, encodeCertificates(ca))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(t *testing.T, certificate *x509.Certificate, signer crypto.Signer, data []byte) []byte {
+		t.Helper()
+
+		signature, err := cms.SignDetached(data, []*x509.Certificate{certificate}, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "SIGNED MESSAGE", Bytes: signature})
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		certificate, signer := createTestCertificate(t, ca, caSigner, testIdentity, time.Now())
+
+		signingCertificate, err := VerifySignature(key, data, sign(t, certificate, signer, data), now)
+		assert.Nil(t, err)
+		assert.Equal(t, certificate.Raw, signingCertificate.Raw)
+	})
+
+	t.Run("identity is case insensitive", func(t *testing.T) {
+		certificate, signer := createTestCertificate(t, ca, caSigner, strings.ToUpper(testIdentity), time.Now())
+
+		_, err := VerifySignature(key, data, sign(t, certificate, signer, data), now)
+		assert.Nil(t, err)
+	})
+
+	t.Run("modified data", func(t *testing.T) {
+		certificate, signer := createTestCertificate(t, ca, caSigner, testIdentity, time.Now())
+
+		_, err := VerifySignature(key, []byte("not gittuf"), sign(t, certificate, signer, data), now)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("different identity", func(t *testing.T) {
+		certificate, signer := createTestCertificate(t, ca, caSigner, "john.doe@example.com", time.Now())
+
+		_, err := VerifySignature(key, data, sign(t, certificate, signer, data), now)
+		assert.ErrorIs(t, err, ErrCertificateIdentity)
+	})
+
+	t.Run("untrusted certificate authority", func(t *testing.T) {
+		otherCA, otherCASigner := createTestCertificate(t, nil, nil, "", time.Now())
+		certificate, signer := createTestCertificate(t, otherCA, otherCASigner, testIdentity, time.Now())
+
+		_, err := VerifySignature(key, data, sign(t, certificate, signer, data), now)
+		assert.ErrorIs(t, err, ErrUntrustedCertificate)
+	})
+
+	t.Run("expired certificate", func(t *testing.T) {
+		certificate, signer := createTestCertificate(t, ca, caSigner, testIdentity, now.Add(-48*time.Hour))
+
+		_, err := VerifySignature(key, data, sign(t, certificate, signer, data), now)
+		assert.ErrorIs(t, err, ErrUntrustedCertificate)
+	})
+
+	t.Run("certificate not valid at verification time", func(t *testing.T) {
+		certificate, signer := createTestCertificate(t, ca, caSigner, testIdentity, now)
+
+		// The signing time recorded in the signature is controlled by the
+		// signer, so only the time passed in by the caller is used
+		_, err := VerifySignature(key, data, sign(t, certificate, signer, data), now.Add(-time.Hour))
+		assert.ErrorIs(t, err, ErrUntrustedCertificate)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		_, err := VerifySignature(key, data, []byte("not a signature"), now)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+// createTestCertificate creates a certificate valid for a day from notBefore.
+// If issuer is nil, a self-signed CA certificate is created. Otherwise, a leaf
+// certificate for the email address identity is issued by issuer.
+func createTestCertificate(t *testing.T, issuer *x509.Certificate, issuerSigner crypto.Signer, identity string, notBefore time.Time) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: "gittuf test CA"},
+		NotBefore:    notBefore.Add(-time.Minute),
+		NotAfter:     notBefore.Add(24 * time.Hour),
+	}
+	if issuer == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		issuer = template
+		issuerSigner = signer
+	} else {
+		template.Subject = pkix.Name{CommonName: identity}
+		template.EmailAddresses = []string{identity}
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection}
+	}
+
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, issuer, signer.Public(), issuerSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(certificateDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certificate, signer
+}
+
+func encodeCertificates(certificates ...*x509.Certificate) []byte {
+	encoded := []byte{}
+	for _, certificate := range certificates {
+		encoded = append(encoded, pem.EncodeToMemory(&pem.Block{Type: pemCertificateType, Bytes: certificate.Raw})...)
+	}
+	return encoded
+}
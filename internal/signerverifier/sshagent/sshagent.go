@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sshagent
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// KeyReferencePrefix is used to reference a key resident in the user's
+// ssh-agent. The prefix is followed by the key's fingerprint, for example
+// ssh-agent:SHA256:..., or the key's comment.
+const KeyReferencePrefix = "ssh-agent:"
+
+var (
+	ErrAgentNotAvailable  = errors.New("unable to connect to ssh-agent, SSH_AUTH_SOCK is not set")
+	ErrKeyNotFound        = errors.New("no key matching reference found in ssh-agent")
+	ErrUnsupportedKeyType = errors.New("ssh-agent key type is not supported for signing gittuf metadata")
+)
+
+// IsKeyReference returns true if key references a key in the ssh-agent.
+func IsKeyReference(key string) bool {
+	return strings.HasPrefix(strings.TrimSpace(key), KeyReferencePrefix)
+}
+
+// LoadSigner returns an ssh.Signer for the agent key referenced by keyRef,
+// along with a function to close the connection to the agent once the signer
+// is no longer needed.
+func LoadSigner(keyRef string) (ssh.Signer, func() error, error) {
+	client, conn, err := connect()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := findKey(client, keyRef)
+	if err != nil {
+		conn.Close() //nolint:errcheck
+		return nil, nil, err
+	}
+
+	signers, err := client.Signers()
+	if err != nil {
+		conn.Close() //nolint:errcheck
+		return nil, nil, err
+	}
+
+	for _, signer := range signers {
+		if bytes.Equal(signer.PublicKey().Marshal(), key.Marshal()) {
+			return signer, conn.Close, nil
+		}
+	}
+
+	conn.Close() //nolint:errcheck
+	return nil, nil, ErrKeyNotFound
+}
+
+// LoadPublicKey returns a tuf.Key object for the agent key referenced by
+// keyRef.
+func LoadPublicKey(keyRef string) (*tuf.Key, error) {
+	client, conn, err := connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close() //nolint:errcheck
+
+	key, err := findKey(client, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSSLibKey(key)
+}
+
+// SignerVerifier signs gittuf metadata using a key resident in the ssh-agent.
+// Signatures use the same schemes as the corresponding sslib signerverifiers,
+// so they can be verified using the key's public key alone. Only ECDSA and
+// ED25519 keys are supported, as the agent cannot create RSA-PSS signatures.
+type SignerVerifier struct {
+	keyRef   string
+	key      ssh.PublicKey
+	verifier dsse.SignerVerifier
+	keyID    string
+}
+
+// NewSignerVerifierFromKeyReference returns a SignerVerifier for the agent key
+// referenced by keyRef.
+func NewSignerVerifierFromKeyReference(keyRef string) (*SignerVerifier, error) {
+	client, conn, err := connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close() //nolint:errcheck
+
+	key, err := findKey(client, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.Type() {
+	case ssh.KeyAlgoED25519, ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521:
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedKeyType, key.Type())
+	}
+
+	sslibKey, err := newSSLibKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(sslibKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignerVerifier{
+		keyRef:   keyRef,
+		key:      key,
+		verifier: verifier,
+		keyID:    sslibKey.KeyID,
+	}, nil
+}
+
+// Sign signs data using the agent key.
+func (sv *SignerVerifier) Sign(_ context.Context, data []byte) ([]byte, error) {
+	client, conn, err := connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close() //nolint:errcheck
+
+	signature, err := client.Sign(sv.key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch signature.Format {
+	case ssh.KeyAlgoED25519:
+		return signature.Blob, nil
+	case ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521:
+		// The agent uses the same curve-dependent hash as the sslib ECDSA
+		// signerverifier, but encodes the signature in the SSH wire format
+		// rather than ASN.1
+		var ecdsaSignature struct {
+			R *big.Int
+			S *big.Int
+		}
+		if err := ssh.Unmarshal(signature.Blob, &ecdsaSignature); err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(ecdsaSignature)
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedKeyType, signature.Format)
+}
+
+// Verify verifies sig against data using the agent key's public key.
+func (sv *SignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the agent key.
+func (sv *SignerVerifier) KeyID() (string, error) {
+	return sv.keyID, nil
+}
+
+// Public returns the agent key's public key.
+func (sv *SignerVerifier) Public() crypto.PublicKey {
+	return sv.verifier.Public()
+}
+
+func connect() (agent.ExtendedAgent, net.Conn, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil, ErrAgentNotAvailable
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, errors.Join(ErrAgentNotAvailable, err)
+	}
+
+	return agent.NewClient(conn), conn, nil
+}
+
+// findKey returns the agent key whose fingerprint or comment matches keyRef.
+func findKey(client agent.ExtendedAgent, keyRef string) (*agent.Key, error) {
+	selector := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(keyRef), KeyReferencePrefix))
+
+	keys, err := client.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if ssh.FingerprintSHA256(key) == selector || ssh.FingerprintLegacyMD5(key) == selector || key.Comment == selector {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrKeyNotFound, selector)
+}
+
+func newSSLibKey(key ssh.PublicKey) (*tuf.Key, error) {
+	// Agent keys only hold the wire encoding of the public key, so it must be
+	// parsed to access the underlying crypto.PublicKey
+	publicKey, err := ssh.ParsePublicKey(key.Marshal())
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoPublicKey, ok := publicKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedKeyType, key.Type())
+	}
+
+	return sslibsv.NewKey(cryptoPublicKey.CryptoPublicKey())
+}
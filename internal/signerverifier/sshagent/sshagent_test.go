@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sshagent
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestSSHAgentSigning(t *testing.T) {
+	startTestAgent(t, map[string][]byte{
+		"ecdsa-key":   artifacts.SSHECDSAPrivate,
+		"ed25519-key": artifacts.SSHED25519Private,
+		"rsa-key":     artifacts.SSHRSAPrivate,
+	})
+
+	for _, comment := range []string{"ecdsa-key", "ed25519-key"} {
+		t.Run(comment, func(t *testing.T) {
+			keyRef := KeyReferencePrefix + comment
+			assert.True(t, IsKeyReference(keyRef))
+
+			sv, err := NewSignerVerifierFromKeyReference(keyRef)
+			require.Nil(t, err)
+
+			data := []byte("test data")
+			sig, err := sv.Sign(context.Background(), data)
+			require.Nil(t, err)
+			assert.Nil(t, sv.Verify(context.Background(), data, sig))
+
+			// The signature must be verifiable using only the public key
+			key, err := LoadPublicKey(keyRef)
+			require.Nil(t, err)
+			verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+			require.Nil(t, err)
+			assert.Nil(t, verifier.Verify(context.Background(), data, sig))
+
+			keyID, err := sv.KeyID()
+			assert.Nil(t, err)
+			assert.Equal(t, key.KeyID, keyID)
+
+			signer, closeAgent, err := LoadSigner(keyRef)
+			require.Nil(t, err)
+			defer closeAgent() //nolint:errcheck
+
+			// The key can also be selected by fingerprint
+			_, err = LoadPublicKey(KeyReferencePrefix + ssh.FingerprintSHA256(signer.PublicKey()))
+			assert.Nil(t, err)
+		})
+	}
+
+	t.Run("rsa key", func(t *testing.T) {
+		_, err := NewSignerVerifierFromKeyReference(KeyReferencePrefix + "rsa-key")
+		assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+
+		// RSA keys can still be used to sign Git objects
+		_, closeAgent, err := LoadSigner(KeyReferencePrefix + "rsa-key")
+		assert.Nil(t, err)
+		closeAgent() //nolint:errcheck
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := LoadPublicKey(KeyReferencePrefix + "unknown")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("no agent", func(t *testing.T) {
+		t.Setenv("SSH_AUTH_SOCK", "")
+
+		_, err := LoadPublicKey(KeyReferencePrefix + "ecdsa-key")
+		assert.ErrorIs(t, err, ErrAgentNotAvailable)
+	})
+}
+
+// startTestAgent serves an in-memory ssh-agent holding the specified keys,
+// keyed by comment, and points SSH_AUTH_SOCK at it.
+func startTestAgent(t *testing.T, keys map[string][]byte) {
+	t.Helper()
+
+	keyring := agent.NewKeyring()
+	for comment, keyBytes := range keys {
+		privateKey, err := ssh.ParseRawPrivateKey(keyBytes)
+		require.Nil(t, err)
+		require.Nil(t, keyring.Add(agent.AddedKey{PrivateKey: privateKey, Comment: comment}))
+	}
+
+	// Unix socket paths have a short length limit, so the socket is not
+	// created in t.TempDir()
+	socketDir, err := os.MkdirTemp("", "gittuf-agent")
+	require.Nil(t, err)
+	t.Cleanup(func() { os.RemoveAll(socketDir) }) //nolint:errcheck
+
+	socket := filepath.Join(socketDir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	require.Nil(t, err)
+	t.Cleanup(func() { listener.Close() }) //nolint:errcheck
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+				continue
+			}
+			go func() {
+				defer conn.Close()              //nolint:errcheck
+				agent.ServeAgent(keyring, conn) //nolint:errcheck
+			}()
+		}
+	}()
+
+	t.Setenv("SSH_AUTH_SOCK", socket)
+}
@@ -138,6 +138,11 @@ type TargetsMetadata struct {
 	Expires     string         `json:"expires"`
 	Targets     map[string]any `json:"targets"`
 	Delegations *Delegations   `json:"delegations"`
+
+	// Submodules maps the path of a submodule in the repository to how
+	// updates to its pointer are verified. It is only used in the top-level
+	// targets metadata.
+	Submodules map[string]*SubmodulePolicy `json:"submodules,omitempty"`
 }
 
 // NewTargetsMetadata returns a new instance of TargetsMetadata.
@@ -168,11 +173,22 @@ func (t *TargetsMetadata) Validate() error {
 	return nil
 }
 
+// AddSubmodulePolicy adds a submodule policy for the specified path. An
+// existing policy for the same path is replaced.
+func (t *TargetsMetadata) AddSubmodulePolicy(path string, submodulePolicy *SubmodulePolicy) {
+	if t.Submodules == nil {
+		t.Submodules = map[string]*SubmodulePolicy{}
+	}
+
+	t.Submodules[path] = submodulePolicy
+}
+
 // Delegations defines the schema for specifying delegations in TUF's Targets
 // metadata.
 type Delegations struct {
-	Keys  map[string]*Key `json:"keys"`
-	Roles []Delegation    `json:"roles"`
+	Keys       map[string]*Key       `json:"keys"`
+	Principals map[string]*Principal `json:"principals,omitempty"`
+	Roles      []Delegation          `json:"roles"`
 }
 
 // AddKey adds a delegations key.
@@ -184,6 +200,16 @@ func (d *Delegations) AddKey(key *Key) {
 	d.Keys[key.KeyID] = key
 }
 
+// AddPrincipal adds a delegations principal. An existing principal with the
+// same name is replaced.
+func (d *Delegations) AddPrincipal(principal *Principal) {
+	if d.Principals == nil {
+		d.Principals = map[string]*Principal{}
+	}
+
+	d.Principals[principal.Name] = principal
+}
+
 // AddDelegation adds a new delegation.
 func (d *Delegations) AddDelegation(delegation Delegation) {
 	if d.Roles == nil {
@@ -205,11 +231,96 @@ func (d *Delegation) Matches(target string) bool {
 
 // Delegation defines the schema for a single delegation entry. It differs from
 // the standard TUF schema by allowing a `custom` field to record details
-// pertaining to the delegation.
+// pertaining to the delegation. In addition, RequireSignedCommits indicates
+// that every commit introduced to a matching Git reference must itself be
+// signed by one of the delegation's keys. Principals lists the names of
+// principals that are trusted by the delegation in addition to the keys in
+// Role. Each principal counts towards the delegation's threshold once a
+// threshold of the principal's own keys have signed. InTotoLayout, if set, is
+// an in-toto layout that must verify for every change protected by the
+// delegation. Similarly, EnginePolicy, if set, is a policy that must allow
+// every change protected by the delegation. CommitConstraints, if set, are
+// checked for every commit introduced to the Git references protected by the
+// delegation.
 type Delegation struct {
-	Name        string           `json:"name"`
-	Paths       []string         `json:"paths"`
-	Terminating bool             `json:"terminating"`
-	Custom      *json.RawMessage `json:"custom,omitempty"`
+	Name                 string             `json:"name"`
+	Paths                []string           `json:"paths"`
+	Terminating          bool               `json:"terminating"`
+	RequireSignedCommits bool               `json:"require_signed_commits,omitempty"`
+	Principals           []string           `json:"principals,omitempty"`
+	InTotoLayout         *InTotoLayout      `json:"in_toto_layout,omitempty"`
+	EnginePolicy         *EnginePolicy      `json:"engine_policy,omitempty"`
+	CommitConstraints    *CommitConstraints `json:"commit_constraints,omitempty"`
+	Custom               *json.RawMessage   `json:"custom,omitempty"`
 	Role
 }
+
+// InTotoLayout records a signed in-toto layout and the IDs of the keys that
+// must have signed it. The keys are recorded alongside the keys of the
+// delegations.
+type InTotoLayout struct {
+	Layout json.RawMessage `json:"layout"`
+	KeyIDs []string        `json:"keyids"`
+}
+
+// EnginePolicy records a policy evaluated by a policy engine, such as a Rego
+// policy evaluated using Open Policy Agent, against the details of a change.
+type EnginePolicy struct {
+	Engine string `json:"engine"`
+	Source string `json:"source"`
+}
+
+// CommitConstraints records requirements on the metadata of commits.
+// RequireSignOff requires each commit's message to have a Signed-off-by
+// trailer for the commit's author, as used for the Developer Certificate of
+// Origin. MessagePattern, if set, is a regular expression that must match the
+// first line of each commit's message. NoMergeCommits rejects commits with
+// more than one parent, requiring a linear history.
+type CommitConstraints struct {
+	RequireSignOff bool   `json:"require_sign_off,omitempty"`
+	MessagePattern string `json:"message_pattern,omitempty"`
+	NoMergeCommits bool   `json:"no_merge_commits,omitempty"`
+}
+
+// Principal defines a named set of keys, such as the keys held by a person or
+// the members of a team. Delegations can trust principals instead of
+// individual keys. A principal is considered to have signed when a threshold
+// of its keys have signed.
+type Principal struct {
+	Name      string   `json:"name"`
+	KeyIDs    []string `json:"keyids"`
+	Threshold int      `json:"threshold"`
+
+	// ForgeAccount identifies the forge account, such as "github:<username>",
+	// whose published keys are pinned for the principal, if any.
+	ForgeAccount string `json:"forge_account,omitempty"`
+}
+
+const (
+	// SubmoduleVerificationPropagation indicates that each update to a
+	// submodule's pointer must be recorded by a propagation entry from the
+	// submodule's repository.
+	SubmoduleVerificationPropagation = "propagation"
+
+	// SubmoduleVerificationRemote indicates that each update to a
+	// submodule's pointer must match a verified RSL entry in the submodule's
+	// repository, which is fetched during verification.
+	SubmoduleVerificationRemote = "remote"
+)
+
+// SubmodulePolicy defines how updates to a submodule's pointer are verified
+// against the submodule's own gittuf-enabled repository.
+type SubmodulePolicy struct {
+	// Location identifies the submodule's repository, such as its URL.
+	// Propagation entries must record the same location as their upstream
+	// repository.
+	Location string `json:"location"`
+
+	// RefName optionally restricts the submodule's RSL entries considered
+	// for remote checks to the specified ref.
+	RefName string `json:"ref_name,omitempty"`
+
+	// Verification is one of SubmoduleVerificationPropagation and
+	// SubmoduleVerificationRemote.
+	Verification string `json:"verification"`
+}